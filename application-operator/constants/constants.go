@@ -103,6 +103,17 @@ const StatusReasonSuccess = "success"
 // OCILoggingIDAnnotation Annotation name for a customized OCI log ID for all containers in a namespace
 const OCILoggingIDAnnotation = "verrazzano.io/oci-log-id"
 
+// LogParserAnnotation Annotation name for the parser applied to the logs of all containers in a namespace, one of
+// multiline-java, json or regex
+const LogParserAnnotation = "verrazzano.io/log-parser"
+
+// LogParserRegexAnnotation Annotation name for the regular expression, with named groups, of the regex log parser
+const LogParserRegexAnnotation = "verrazzano.io/log-parser-regex"
+
+// LogFieldMappingsAnnotation Annotation name for the fields renamed after parsing the logs of a namespace, as a comma
+// separated list of target=source pairs
+const LogFieldMappingsAnnotation = "verrazzano.io/log-field-mappings"
+
 // WorkloadTypeCoherence indicates the workload is Coherence
 const WorkloadTypeCoherence = "coherence"
 
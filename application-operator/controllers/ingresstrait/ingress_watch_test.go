@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	k8net "k8s.io/api/networking/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
@@ -67,6 +68,14 @@ func Test_isConsoleIngressUpdated(t *testing.T) {
 		ObjectNew: newIngress,
 	}))
 
+	// A change of the ClusterIssuer annotation is detected
+	issuerIngress := oldIngress.DeepCopyObject().(*k8net.Ingress)
+	issuerIngress.Annotations = map[string]string{vzconst.ClusterIssuerAnnotation: "vault-issuer"}
+	asserts.True(r.isConsoleIngressUpdated(event.UpdateEvent{
+		ObjectOld: oldIngress,
+		ObjectNew: issuerIngress,
+	}))
+
 	oldOtherIngress := &k8net.Ingress{
 		ObjectMeta: metav1.ObjectMeta{Name: "someingress", Namespace: constants.VerrazzanoSystemNamespace},
 		Spec: k8net.IngressSpec{
@@ -86,6 +86,9 @@ var (
 		"tls-default", "tls-cbts", "tls-iiops", "tcp-internal-t3", "internal-t3"}
 )
 
+// getClusterIssuerNameFunc is used to get the ClusterIssuer of the gateway certificates, overridden by unit tests
+var getClusterIssuerNameFunc = getClusterIssuerName
+
 // Reconciler is used to reconcile an IngressTrait object
 type Reconciler struct {
 	client.Client
@@ -485,12 +488,13 @@ func (r *Reconciler) createGatewayCertificate(ctx context.Context, trait *vzapi.
 			Name:      certName,
 		}}
 
+	issuerName := getClusterIssuerNameFunc(ctx, r.Client, log)
 	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, certificate, func() error {
 		certificate.Spec = certapiv1.CertificateSpec{
 			DNSNames:   hostsForTrait,
 			SecretName: secretName,
 			IssuerRef: certv1.ObjectReference{
-				Name: verrazzanoClusterIssuer,
+				Name: issuerName,
 				Kind: "ClusterIssuer",
 			},
 		}
@@ -907,7 +911,7 @@ func (r *Reconciler) setupWatches() error {
 	return nil
 }
 
-// isConsoleIngressUpdated Predicate func used by the Ingress watcher, returns true if the TLS settings have changed;
+// isConsoleIngressUpdated Predicate func used by the Ingress watcher, returns true if the TLS settings or the ClusterIssuer have changed;
 // - this is largely to attempt to scope the change detection to Host name changes
 func (r *Reconciler) isConsoleIngressUpdated(updateEvent event.UpdateEvent) bool {
 	oldIngress := updateEvent.ObjectOld.(*k8net.Ingress)
@@ -916,7 +920,8 @@ func (r *Reconciler) isConsoleIngressUpdated(updateEvent event.UpdateEvent) bool
 		return false
 	}
 	newIngress := updateEvent.ObjectNew.(*k8net.Ingress)
-	if !reflect.DeepEqual(oldIngress.Spec, newIngress.Spec) {
+	if !reflect.DeepEqual(oldIngress.Spec, newIngress.Spec) ||
+		oldIngress.Annotations[vzconst.ClusterIssuerAnnotation] != newIngress.Annotations[vzconst.ClusterIssuerAnnotation] {
 		r.Log.Infof("Ingress %s/%s has changed", oldIngress.Namespace, oldIngress.Name)
 		return true
 	}
@@ -1298,6 +1303,24 @@ func buildAppFullyQualifiedHostName(cli client.Reader, trait *vzapi.IngressTrait
 	return fmt.Sprintf("%s.%s", appName, domainName), nil
 }
 
+// getClusterIssuerName returns the name of the ClusterIssuer used for the Verrazzano certificates, read from the
+// cert-manager annotation of the Verrazzano console ingress.  The Verrazzano ClusterIssuer is returned if the ingress or
+// the annotation is not found.
+func getClusterIssuerName(ctx context.Context, cli client.Reader, log vzlog.VerrazzanoLogger) string {
+	ingress := k8net.Ingress{}
+	err := cli.Get(ctx, types.NamespacedName{Name: constants.VzConsoleIngress, Namespace: constants.VerrazzanoSystemNamespace}, &ingress)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Infof("Failed to get the Verrazzano ingress, using the ClusterIssuer %s: %v", verrazzanoClusterIssuer, err)
+		}
+		return verrazzanoClusterIssuer
+	}
+	if issuer, ok := ingress.Annotations[vzconst.ClusterIssuerAnnotation]; ok && len(issuer) > 0 {
+		return issuer
+	}
+	return verrazzanoClusterIssuer
+}
+
 // buildNamespacedDomainName generates a domain name for the application using the following structure:
 // <namespace>.<dns-subdomain>  where
 //   namespace is the namespace of the OAM application
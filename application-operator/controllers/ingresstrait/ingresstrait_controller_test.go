@@ -85,6 +85,15 @@ var (
 	namespace                            = k8score.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
 )
 
+// TestMain uses the Verrazzano ClusterIssuer for the gateway certificates, so that the mocks do not need to expect the
+// ClusterIssuer lookup
+func TestMain(m *testing.M) {
+	getClusterIssuerNameFunc = func(_ context.Context, _ client.Reader, _ vzlog.VerrazzanoLogger) string {
+		return verrazzanoClusterIssuer
+	}
+	os.Exit(m.Run())
+}
+
 // GIVEN a controller implementation
 // WHEN the controller is created
 // THEN verify no error is returned
@@ -4240,3 +4249,25 @@ func TestReconcileFailed(t *testing.T) {
 	assert.Equal(reconcileFailedCounterBefore, reconcileFailedCounterAfter-1)
 
 }
+
+// TestGetClusterIssuerName tests getting the ClusterIssuer of the gateway certificates
+// GIVEN a Verrazzano console ingress with or without the cert-manager ClusterIssuer annotation
+// WHEN getClusterIssuerName is called
+// THEN the annotation value is returned, or the Verrazzano ClusterIssuer if there is no annotation or ingress
+func TestGetClusterIssuerName(t *testing.T) {
+	assert := asserts.New(t)
+	log := vzlog.DefaultLogger()
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	assert.Equal(verrazzanoClusterIssuer, getClusterIssuerName(context.TODO(), cli, log))
+
+	ingress := &k8net.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.VzConsoleIngress, Namespace: constants.VerrazzanoSystemNamespace},
+	}
+	cli = fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(ingress).Build()
+	assert.Equal(verrazzanoClusterIssuer, getClusterIssuerName(context.TODO(), cli, log))
+
+	ingress.Annotations = map[string]string{vzconst.ClusterIssuerAnnotation: "vault-issuer"}
+	cli = fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(ingress).Build()
+	assert.Equal("vault-issuer", getClusterIssuerName(context.TODO(), cli, log))
+}
@@ -34,6 +34,8 @@ const fluentdConfig = `|
 # Parsing/Filtering
 @include systemd-filter.conf
 @include kubernetes-filter.conf
+# Start namespace parsing configs
+# End namespace parsing configs
 
 # Send to storage
 @include output.conf
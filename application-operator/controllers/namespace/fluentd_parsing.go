@@ -0,0 +1,263 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	nsParsingConfigKeyTemplate  = "parsing-ns-%s.conf"
+	startNamespaceParsingMarker = "# Start namespace parsing configs"
+
+	// Log parsers that can be selected for a namespace
+	multilineJavaParser = "multiline-java"
+	jsonParser          = "json"
+	regexParser         = "regex"
+)
+
+// The parsing rules are applied after the generic parsing of the container logs, which stores the text of the log
+// lines in the message field.  The Java stack trace lines are joined by the concat plugin, which emits the last record
+// to the namespace label when no more lines are received, that label sends it to the outputs.
+const parsingTemplateBody = `{{- if eq .parser "multiline-java" }}
+<filter kubernetes.**_{{ .namespace }}_**>
+  @type concat
+  @id ns_{{ .namespace }}_multiline
+  key message
+  multiline_start_regexp /^(?!\s|Caused by:|Suppressed:|\.\.\.)/
+  flush_interval 5
+  timeout_label @NS_PARSING_{{ .namespace }}
+  use_first_timestamp true
+</filter>
+{{- else }}
+<filter kubernetes.**_{{ .namespace }}_**>
+  @type parser
+  @id ns_{{ .namespace }}_parser
+  key_name message
+  reserve_data true
+  emit_invalid_record_to_error false
+  <parse>
+  {{- if eq .parser "json" }}
+    @type json
+  {{- else }}
+    @type regexp
+    expression /{{ .regex }}/
+  {{- end }}
+  </parse>
+</filter>
+{{- end }}
+{{- if .mappings }}
+<filter kubernetes.**_{{ .namespace }}_**>
+  @type record_transformer
+  @id ns_{{ .namespace }}_field_mappings
+  <record>
+  {{- range .mappings }}
+    {{ .Target }} ${record["{{ .Source }}"]}
+  {{- end }}
+  </record>
+  remove_keys {{ .sources }}
+</filter>
+{{- end }}
+{{- if eq .parser "multiline-java" }}
+<label @NS_PARSING_{{ .namespace }}>
+  {{- if .mappings }}
+  <filter **>
+    @type record_transformer
+    <record>
+    {{- range .mappings }}
+      {{ .Target }} ${record["{{ .Source }}"]}
+    {{- end }}
+    </record>
+    remove_keys {{ .sources }}
+  </filter>
+  {{- end }}
+  <match **>
+    @type relabel
+    @label @OUTPUT
+  </match>
+</label>
+{{- end }}
+`
+
+// fieldNameRegex matches the field names that can be used in the field mappings
+var fieldNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// namedGroupRegex matches a named group of a Ruby regular expression, (?<= and (?<! are lookbehinds
+var namedGroupRegex = regexp.MustCompile(`\(\?<[A-Za-z_][A-Za-z0-9_]*>`)
+
+var parsingTemplate *template.Template
+
+// init creates a parsing template.
+func init() {
+	parsingTemplate, _ = template.New("parsingConfig").Parse(parsingTemplateBody)
+}
+
+// namespaceParsing is the log parsing configuration of a namespace, read from its annotations
+type namespaceParsing struct {
+	parser   string
+	regex    string
+	mappings []fieldMapping
+}
+
+// fieldMapping renames a log record field
+type fieldMapping struct {
+	Target string
+	Source string
+}
+
+// getNamespaceParsing returns the log parsing configuration of a namespace, or nil if the namespace does not select a
+// parser.  An error is returned if the annotations are not valid.
+func getNamespaceParsing(ns *corev1.Namespace) (*namespaceParsing, error) {
+	parser, ok := ns.Annotations[constants.LogParserAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	parsing := &namespaceParsing{parser: parser}
+	switch parser {
+	case multilineJavaParser, jsonParser:
+	case regexParser:
+		parsing.regex = ns.Annotations[constants.LogParserRegexAnnotation]
+		if err := validateParserRegex(parsing.regex); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid %s annotation \"%s\", it must be %s, %s or %s", constants.LogParserAnnotation, parser,
+			multilineJavaParser, jsonParser, regexParser)
+	}
+
+	mappings := ns.Annotations[constants.LogFieldMappingsAnnotation]
+	if len(strings.TrimSpace(mappings)) == 0 {
+		return parsing, nil
+	}
+	for _, pair := range strings.Split(mappings, ",") {
+		parts := strings.Split(strings.TrimSpace(pair), "=")
+		if len(parts) != 2 || !fieldNameRegex.MatchString(parts[0]) || !fieldNameRegex.MatchString(parts[1]) {
+			return nil, fmt.Errorf("invalid field mapping \"%s\" in the %s annotation, it must be target=source", pair, constants.LogFieldMappingsAnnotation)
+		}
+		parsing.mappings = append(parsing.mappings, fieldMapping{Target: parts[0], Source: parts[1]})
+	}
+	return parsing, nil
+}
+
+// validateParserRegex checks that the regex parser expression has named groups and can be written on a single line of
+// the Fluentd config.  The expression is not compiled, Fluentd uses the Ruby regular expression syntax, which accepts
+// lookarounds and backreferences that are not supported by Go.
+func validateParserRegex(expression string) error {
+	if len(expression) == 0 {
+		return fmt.Errorf("the %s annotation is required by the %s parser", constants.LogParserRegexAnnotation, regexParser)
+	}
+	if strings.ContainsAny(expression, "\r\n") {
+		return fmt.Errorf("the %s annotation must be on a single line", constants.LogParserRegexAnnotation)
+	}
+	if !namedGroupRegex.MatchString(expression) {
+		return fmt.Errorf("the %s annotation must have named groups, such as (?<level>\\w+)", constants.LogParserRegexAnnotation)
+	}
+	return nil
+}
+
+// addNamespaceParsing updates the system Fluentd config map to include the log parsing rules of the given namespace.
+// It returns true if the config map was updated.
+func addNamespaceParsing(ctx context.Context, cli client.Client, namespace string, parsing *namespaceParsing) (bool, error) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fluentdConfigMapName, Namespace: vzconst.VerrazzanoSystemNamespace}}
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, cli, cm, func() error {
+		if cm.ObjectMeta.CreationTimestamp.IsZero() {
+			return fmt.Errorf("configmap '%s' in namespace '%s' must exist", cm.ObjectMeta.Name, cm.ObjectMeta.Namespace)
+		}
+		return addNamespaceParsingToConfigMap(cm, namespace, parsing)
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return opResult != controllerutil.OperationResultNone, nil
+}
+
+// removeNamespaceParsing updates the system Fluentd config map, removing the log parsing rules of the given namespace.
+// It returns true if the config map was updated.
+func removeNamespaceParsing(ctx context.Context, cli client.Client, namespace string) (bool, error) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fluentdConfigMapName, Namespace: vzconst.VerrazzanoSystemNamespace}}
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, cli, cm, func() error {
+		if !cm.ObjectMeta.CreationTimestamp.IsZero() {
+			removeNamespaceParsingFromConfigMap(cm, namespace)
+			return nil
+		}
+		// return an error here, otherwise the configmap will get created and we don't want that
+		return k8serrors.NewNotFound(schema.ParseGroupResource("ConfigMap"), fluentdConfigMapName)
+	})
+
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return opResult != controllerutil.OperationResultNone, nil
+}
+
+// addNamespaceParsingToConfigMap adds a config map key for the namespace log parsing rules and adds an include
+// directive in the main Fluentd config. This function is idempotent.
+func addNamespaceParsingToConfigMap(configMap *corev1.ConfigMap, namespace string, parsing *namespaceParsing) error {
+	if parsingTemplate == nil {
+		return fmt.Errorf("parsing config template is empty")
+	}
+
+	var sources []string
+	for _, mapping := range parsing.mappings {
+		sources = append(sources, mapping.Source)
+	}
+	values := map[string]interface{}{
+		"namespace": namespace,
+		"parser":    parsing.parser,
+		"regex":     parsing.regex,
+		"mappings":  parsing.mappings,
+		"sources":   strings.Join(sources, ","),
+	}
+	var buff bytes.Buffer
+	if err := parsingTemplate.Execute(&buff, values); err != nil {
+		return err
+	}
+
+	nsConfigKey := fmt.Sprintf(nsParsingConfigKeyTemplate, namespace)
+	configMap.Data[nsConfigKey] = strings.TrimSpace(buff.String()) + "\n"
+
+	if fluentdConfig, ok := configMap.Data[fluentdConfigKey]; ok {
+		includeLine := fmt.Sprintf("@include %s", nsConfigKey)
+		if !strings.Contains(fluentdConfig, includeLine) {
+			replace := fmt.Sprintf("%s\n%s", startNamespaceParsingMarker, includeLine)
+			fluentdConfig = strings.Replace(fluentdConfig, startNamespaceParsingMarker, replace, 1)
+			configMap.Data[fluentdConfigKey] = fluentdConfig
+		}
+	}
+
+	return nil
+}
+
+// removeNamespaceParsingFromConfigMap removes the config map key for the namespace log parsing rules and removes the
+// include directive in the main Fluentd config. This function is idempotent.
+func removeNamespaceParsingFromConfigMap(configMap *corev1.ConfigMap, namespace string) {
+	nsConfigKey := fmt.Sprintf(nsParsingConfigKeyTemplate, namespace)
+	delete(configMap.Data, nsConfigKey)
+
+	if fluentdConfig, ok := configMap.Data[fluentdConfigKey]; ok {
+		toRemove := fmt.Sprintf("@include %s\n", nsConfigKey)
+		if strings.Contains(fluentdConfig, toRemove) {
+			configMap.Data[fluentdConfigKey] = strings.Replace(fluentdConfig, toRemove, "", 1)
+		}
+	}
+}
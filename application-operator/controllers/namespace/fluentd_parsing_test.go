@@ -0,0 +1,174 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/mocks"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newParsingNamespace(annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testNamespace,
+			Annotations: annotations,
+		},
+	}
+}
+
+// TestGetNamespaceParsing tests reading the log parsing configuration from the namespace annotations
+// GIVEN namespaces with valid and invalid log parsing annotations
+// WHEN getNamespaceParsing is called
+// THEN the parsing configuration is returned for the valid annotations, and an error for the invalid ones
+func TestGetNamespaceParsing(t *testing.T) {
+	asserts := assert.New(t)
+
+	parsing, err := getNamespaceParsing(newParsingNamespace(nil))
+	asserts.NoError(err)
+	asserts.Nil(parsing)
+
+	parsing, err = getNamespaceParsing(newParsingNamespace(map[string]string{
+		vzconst.LogParserAnnotation:        multilineJavaParser,
+		vzconst.LogFieldMappingsAnnotation: "level=severity, msg=message",
+	}))
+	asserts.NoError(err)
+	asserts.Equal(multilineJavaParser, parsing.parser)
+	asserts.Equal([]fieldMapping{{Target: "level", Source: "severity"}, {Target: "msg", Source: "message"}}, parsing.mappings)
+
+	parsing, err = getNamespaceParsing(newParsingNamespace(map[string]string{
+		vzconst.LogParserAnnotation:      regexParser,
+		vzconst.LogParserRegexAnnotation: `^(?<level>\w+) (?<message>.*)$`,
+	}))
+	asserts.NoError(err)
+	asserts.Equal(`^(?<level>\w+) (?<message>.*)$`, parsing.regex)
+
+	// Fluentd uses Ruby regular expressions, lookarounds are accepted even though Go does not support them
+	parsing, err = getNamespaceParsing(newParsingNamespace(map[string]string{
+		vzconst.LogParserAnnotation:      regexParser,
+		vzconst.LogParserRegexAnnotation: `^(?<level>\w+) (?!DEBUG)(?<message>.*)$`,
+	}))
+	asserts.NoError(err)
+	asserts.Equal(`^(?<level>\w+) (?!DEBUG)(?<message>.*)$`, parsing.regex)
+
+	for _, annotations := range []map[string]string{
+		{vzconst.LogParserAnnotation: "xml"},
+		{vzconst.LogParserAnnotation: regexParser},
+		{vzconst.LogParserAnnotation: regexParser, vzconst.LogParserRegexAnnotation: `^(\w+) (.*)$`},
+		{vzconst.LogParserAnnotation: regexParser, vzconst.LogParserRegexAnnotation: `^(?<=\s)(\w+)$`},
+		{vzconst.LogParserAnnotation: regexParser, vzconst.LogParserRegexAnnotation: "^(?<level>\\w+)\n(?<message>.*)$"},
+		{vzconst.LogParserAnnotation: jsonParser, vzconst.LogFieldMappingsAnnotation: "level"},
+		{vzconst.LogParserAnnotation: jsonParser, vzconst.LogFieldMappingsAnnotation: "level=a b"},
+	} {
+		_, err = getNamespaceParsing(newParsingNamespace(annotations))
+		asserts.Error(err, "Expected an error for %v", annotations)
+	}
+}
+
+// TestAddAndRemoveNamespaceParsing tests adding and removing the namespace log parsing rules in the Fluentd config map
+// GIVEN a system Fluentd config map
+// WHEN the multiline Java parsing rules of a namespace are added twice and then removed
+// THEN the config map is only updated when the rendered config changes, and is restored after removing the rules
+func TestAddAndRemoveNamespaceParsing(t *testing.T) {
+	asserts := assert.New(t)
+	cm := newConfigMap()
+	cm.Data = map[string]string{fluentdConfigKey: fluentdConfig}
+	cli := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(cm).Build()
+	parsing := &namespaceParsing{parser: multilineJavaParser, mappings: []fieldMapping{{Target: "level", Source: "severity"}}}
+
+	updated, err := addNamespaceParsing(context.TODO(), cli, testNamespace, parsing)
+	asserts.NoError(err)
+	asserts.True(updated)
+	updated, err = addNamespaceParsing(context.TODO(), cli, testNamespace, parsing)
+	asserts.NoError(err)
+	asserts.False(updated)
+
+	cm = &corev1.ConfigMap{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}, cm))
+	nsConfigKey := fmt.Sprintf(nsParsingConfigKeyTemplate, testNamespace)
+	asserts.Contains(cm.Data[fluentdConfigKey], fmt.Sprintf("%s\n@include %s\n", startNamespaceParsingMarker, nsConfigKey))
+	asserts.Contains(cm.Data[nsConfigKey], "@type concat")
+	asserts.Contains(cm.Data[nsConfigKey], "timeout_label @NS_PARSING_unit-test-ns")
+	asserts.Contains(cm.Data[nsConfigKey], `level ${record["severity"]}`)
+
+	// A different parser changes the rendered config
+	updated, err = addNamespaceParsing(context.TODO(), cli, testNamespace, &namespaceParsing{parser: jsonParser})
+	asserts.NoError(err)
+	asserts.True(updated)
+
+	updated, err = removeNamespaceParsing(context.TODO(), cli, testNamespace)
+	asserts.NoError(err)
+	asserts.True(updated)
+	cm = &corev1.ConfigMap{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}, cm))
+	asserts.Equal(fluentdConfig, cm.Data[fluentdConfigKey])
+	asserts.NotContains(cm.Data, nsConfigKey)
+}
+
+// Test_reconcileLogParsing tests the reconcileLogParsing method
+// GIVEN a namespace with a log parser annotation
+// WHEN reconcileLogParsing is called
+// THEN the finalizer is added and Fluentd is restarted only when the parsing config has changed
+func Test_reconcileLogParsing(t *testing.T) {
+	for _, changed := range []bool{true, false} {
+		asserts := assert.New(t)
+		mocker := gomock.NewController(t)
+		mock := mocks.NewMockClient(mocker)
+		nc, err := newTestController(mock)
+		asserts.NoError(err)
+
+		ns := newParsingNamespace(map[string]string{vzconst.LogParserAnnotation: jsonParser})
+		mock.EXPECT().
+			Update(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, ns *corev1.Namespace, opts ...client.UpdateOption) error {
+				return nil
+			})
+		if changed {
+			mockFluentdRestart(mock, asserts)
+		}
+		addNamespaceParsingFunc = func(_ context.Context, _ client.Client, _ string, parsing *namespaceParsing) (bool, error) {
+			asserts.Equal(jsonParser, parsing.parser)
+			return changed, nil
+		}
+
+		err = nc.reconcileLogParsing(context.TODO(), ns, logger)
+		addNamespaceParsingFunc = addNamespaceParsing
+		mocker.Finish()
+		asserts.NoError(err)
+		asserts.Contains(ns.Finalizers, namespaceControllerFinalizer)
+	}
+}
+
+// Test_reconcileLogParsingInvalid tests the reconcileLogParsing method
+// GIVEN a namespace with an invalid log parser annotation
+// WHEN reconcileLogParsing is called
+// THEN the parsing config of the namespace is removed and no error is returned
+func Test_reconcileLogParsingInvalid(t *testing.T) {
+	asserts := assert.New(t)
+	nc, err := newTestController(fake.NewClientBuilder().WithScheme(testScheme).Build())
+	asserts.NoError(err)
+
+	removeCalled := false
+	removeNamespaceParsingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		removeCalled = true
+		return false, nil
+	}
+	defer func() { removeNamespaceParsingFunc = removeNamespaceParsing }()
+
+	err = nc.reconcileLogParsing(context.TODO(), newParsingNamespace(map[string]string{vzconst.LogParserAnnotation: "xml"}), logger)
+	asserts.NoError(err)
+	asserts.True(removeCalled)
+}
@@ -136,6 +136,10 @@ func (nc *NamespaceController) reconcileNamespace(ctx context.Context, ns *corev
 		log.Errorf("Failed to reconcile OCI Logging: %v", err)
 		return err
 	}
+	if err := nc.reconcileLogParsing(ctx, ns, log); err != nil {
+		log.Errorf("Failed to reconcile log parsing: %v", err)
+		return err
+	}
 	log.Debugf("Reconciled namespace %s successfully", ns.Name)
 	return nil
 }
@@ -144,7 +148,10 @@ func (nc *NamespaceController) reconcileNamespace(ctx context.Context, ns *corev
 func (nc *NamespaceController) reconcileNamespaceDelete(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
 	// Update the OCI Logging configuration to remove the namespace configuration
 	// If the annotation is not present, remove any existing logging configuration
-	return nc.removeOCILogging(ctx, ns, log)
+	if err := nc.removeOCILogging(ctx, ns, log); err != nil {
+		return err
+	}
+	return nc.removeLogParsing(ctx, ns, log)
 }
 
 // reconcileOCILogging - Configure OCI logging based on the annotation if present
@@ -185,6 +192,49 @@ func (nc *NamespaceController) removeOCILogging(ctx context.Context, ns *corev1.
 	return err
 }
 
+// reconcileLogParsing - Configure the log parsing rules of the namespace based on the annotations if present
+func (nc *NamespaceController) reconcileLogParsing(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
+	parsing, err := getNamespaceParsing(ns)
+	if err != nil {
+		// The annotations must be fixed by the user, retrying will not help
+		log.ErrorfThrottled("Ignoring the log parsing annotations of namespace %s: %v", ns.Name, err)
+	}
+	if parsing == nil {
+		return nc.removeLogParsing(ctx, ns, log)
+	}
+
+	var added bool
+	if ns.Finalizers, added = vzstring.SliceAddString(ns.Finalizers, namespaceControllerFinalizer); added {
+		if err := nc.Update(ctx, ns); err != nil {
+			return err
+		}
+	}
+	log.Debugw("Updating log parsing configuration for namespace", namespaceField, ns.Name, "parser", parsing.parser)
+	updated, err := addNamespaceParsingFunc(ctx, nc.Client, ns.Name, parsing)
+	if err != nil {
+		return err
+	}
+	// Fluentd is only restarted when the rendered configuration has changed
+	if updated {
+		log.Debugw("Updated log parsing configuration for namespace", namespaceField, ns.Name)
+		err = nc.restartFluentd(ctx, log)
+	}
+	return err
+}
+
+// removeLogParsing - Remove the log parsing rules of the namespace
+func (nc *NamespaceController) removeLogParsing(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
+	removed, err := removeNamespaceParsingFunc(ctx, nc.Client, ns.Name)
+	if err != nil {
+		return err
+	}
+	if removed {
+		log.Debugw("Removed log parsing configuration for namespace", namespaceField, ns.Name)
+		err = nc.restartFluentd(ctx, log)
+	}
+	return err
+}
+
 // restartFluentd - restarts the Fluentd pods by adding an annotation to the Fluentd daemonset.
 func (nc *NamespaceController) restartFluentd(ctx context.Context, log vzlog.VerrazzanoLogger) error {
 	log.Debug("Restarting Fluentd")
@@ -218,3 +268,9 @@ type removeNamespaceLoggingFuncSig func(_ context.Context, _ client.Client, _ st
 
 // removeNamespaceLoggingFunc - Variable to allow replacing remove namespace logging func for unit tests
 var removeNamespaceLoggingFunc removeNamespaceLoggingFuncSig = removeNamespaceLogging
+
+// addNamespaceParsingFunc - Variable to allow replacing add namespace parsing func for unit tests
+var addNamespaceParsingFunc = addNamespaceParsing
+
+// removeNamespaceParsingFunc - Variable to allow replacing remove namespace parsing func for unit tests
+var removeNamespaceParsingFunc = removeNamespaceParsing
@@ -96,6 +96,11 @@ func TestReconcileNamespaceUpdate(t *testing.T) {
 	}
 	defer func() { addNamespaceLoggingFunc = addNamespaceLogging }()
 
+	removeNamespaceParsingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceParsingFunc = removeNamespaceParsing }()
+
 	nc, err := newTestController(mock)
 	asserts.NoError(err)
 
@@ -196,6 +201,11 @@ func TestReconcileNamespaceDeleted(t *testing.T) {
 	}
 	defer func() { removeNamespaceLoggingFunc = removeNamespaceLogging }()
 
+	removeNamespaceParsingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceParsingFunc = removeNamespaceParsing }()
+
 	nc, err := newTestController(mock)
 	asserts.NoError(err)
 
@@ -458,6 +468,11 @@ func Test_reconcileNamespace(t *testing.T) {
 	}
 	defer func() { addNamespaceLoggingFunc = addNamespaceLogging }()
 
+	removeNamespaceParsingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceParsingFunc = removeNamespaceParsing }()
+
 	err = nc.reconcileNamespace(context.TODO(), ns, logger)
 
 	mocker.Finish()
@@ -10,6 +10,7 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/mcconstants"
+	"github.com/verrazzano/verrazzano/pkg/security/cabundle"
 	platformopclusters "github.com/verrazzano/verrazzano/platform-operator/apis/clusters/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -171,10 +172,28 @@ func (s *Syncer) syncLocalClusterCA() error {
 	return nil
 }
 
-// getLocalClusterCASecret gets the local cluster CA secret and returns the CA data in the secret
+// getLocalClusterCASecretData gets the local cluster CA data, including the CAs of the transition secret
+// while the local Verrazzano CA is rotated, so that the admin cluster trusts both the previous and the new CA
+func (s *Syncer) getLocalClusterCASecretData() ([]byte, error) {
+	caData, err := s.getLocalClusterCAData()
+	if err != nil {
+		return nil, err
+	}
+	transitionSecret := corev1.Secret{}
+	err = s.LocalClient.Get(s.Context, client.ObjectKey{
+		Namespace: globalconst.CertManagerNamespace,
+		Name:      globalconst.VerrazzanoCATransitionSecret,
+	}, &transitionSecret)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	return cabundle.AppendCertificates(caData, transitionSecret.Data[mcconstants.CaCrtKey]), nil
+}
+
+// getLocalClusterCAData gets the local cluster CA secret and returns the CA data in the secret
 // This could be in the Additional TLS secret in Rancher NS (for Let's Encrypt staging CA) or
 // the Verrazzano ingress TLS secret in Verrazzano System NS for other cases
-func (s *Syncer) getLocalClusterCASecretData() ([]byte, error) {
+func (s *Syncer) getLocalClusterCAData() ([]byte, error) {
 	localCASecret := corev1.Secret{}
 	errAddlTLS := s.LocalClient.Get(s.Context, client.ObjectKey{
 		Namespace: globalconst.RancherSystemNamespace,
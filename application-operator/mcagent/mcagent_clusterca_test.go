@@ -5,16 +5,25 @@ package mcagent
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"path/filepath"
 	"testing"
+	"time"
 
 	asserts "github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/mcconstants"
 	"github.com/verrazzano/verrazzano/tests/e2e/pkg"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	clusterstest "github.com/verrazzano/verrazzano/application-operator/controllers/clusters/test"
@@ -263,6 +272,77 @@ func TestSyncCACertsAdditionalTLSPresent(t *testing.T) {
 	assertRegistrationInfoEqual(t, localSecret, testClusterRegSecret)
 }
 
+// TestSyncCACertsDuringCARotation tests the synchronization method for the following use case.
+// GIVEN a request to sync the local cluster CA
+// WHEN the local Verrazzano CA is rotated and the CA transition secret exists
+// THEN ensure that the admin copy of the local CA also trusts the CAs of the transition secret
+func TestSyncCACertsDuringCARotation(t *testing.T) {
+	assert := asserts.New(t)
+	log := zap.S().With("test")
+
+	// Test data
+	testMCTLSSecret, err := getSampleSecret(vzTLSSecretPathNew)
+	assert.NoError(err, sampleMCTLSReadErrMsg)
+
+	testMCCASecret, err := getSampleSecret(mcCASecretPath)
+	assert.NoError(err, sampleMCCAReadErrMsg)
+
+	testVMC, err := getSampleClusterCAVMC(vmcPath)
+	assert.NoError(err, sampleVMCReadErrMsg)
+
+	previousCA := newTestCA(t, "verrazzano-root-ca-previous")
+	testTransitionSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.VerrazzanoCATransitionSecret, Namespace: constants.CertManagerNamespace},
+		Data:       map[string][]byte{mcconstants.CaCrtKey: previousCA},
+	}
+
+	adminClient := fake.NewClientBuilder().
+		WithScheme(newClusterCAScheme()).
+		WithRuntimeObjects(&testMCCASecret, &testVMC).
+		Build()
+
+	localClient := fake.NewClientBuilder().
+		WithScheme(newClusterCAScheme()).
+		WithRuntimeObjects(&testMCTLSSecret, &testTransitionSecret).
+		Build()
+
+	// Make the request
+	s := &Syncer{
+		AdminClient:        adminClient,
+		LocalClient:        localClient,
+		Log:                log,
+		ManagedClusterName: testClusterName,
+		Context:            context.TODO(),
+	}
+	err = s.syncLocalClusterCA()
+
+	// Validate the results
+	assert.NoError(err)
+
+	adminSecret := &corev1.Secret{}
+	err = s.AdminClient.Get(s.Context, types.NamespacedName{Name: testMCCASecret.Name, Namespace: testMCCASecret.Namespace}, adminSecret)
+	assert.NoError(err)
+	expectedCA := append(append([]byte{}, testMCTLSSecret.Data[mcconstants.CaCrtKey]...), previousCA...)
+	assert.Equal(expectedCA, adminSecret.Data[keyCaCrtNoDot], "MC CA secret on admin cluster did not include the CAs of the transition secret")
+}
+
+// newTestCA returns a PEM encoded self-signed CA certificate
+func newTestCA(t *testing.T, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	asserts.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	asserts.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
 // TestSyncRegistrationInfoDifferent tests the synchronization method for the following use case.
 // GIVEN a request to sync Admin registration info
 // WHEN the registration info is different but CAs are the same,
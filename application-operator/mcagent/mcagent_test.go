@@ -472,6 +472,12 @@ func expectCASyncSuccess(localMock, adminMock *mocks.MockClient, assert *asserts
 			secret.Namespace = localIngressTLSSecret.Namespace
 			return nil
 		})
+	// The CA transition secret only exists while the local CA is rotated
+	localMock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: vzconstants.CertManagerNamespace, Name: vzconstants.VerrazzanoCATransitionSecret}, gomock.Not(gomock.Nil())).
+		DoAndReturn(func(ctx context.Context, name types.NamespacedName, secret *corev1.Secret) error {
+			return errors.NewNotFound(schema.GroupResource{Group: "", Resource: "Secret"}, name.Name)
+		})
 
 	vmcName := types.NamespacedName{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: testClusterName}
 	clusterCASecret := "clusterCASecret"
@@ -57,6 +57,12 @@ const VerrazzanoMultiClusterNamespace = "verrazzano-mc"
 // CertManagerNamespace - the CertManager namespace
 const CertManagerNamespace = "cert-manager"
 
+// VerrazzanoClusterIssuerName - the name of the ClusterIssuer created by Verrazzano to issue certificates
+const VerrazzanoClusterIssuerName = "verrazzano-cluster-issuer"
+
+// ClusterIssuerAnnotation - the cert-manager annotation that selects the ClusterIssuer of an Ingress
+const ClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+
 // KeycloakNamespace - the keycloak namespace
 const KeycloakNamespace = "keycloak"
 
@@ -82,6 +88,9 @@ const LabelIstioInjection = "istio-injection"
 // LabelVerrazzanoNamespace - constant for a Kubernetes label that is used by network policies
 const LabelVerrazzanoNamespace = "verrazzano.io/namespace"
 
+// LabelGrafanaDashboard - constant for a Kubernetes label that marks the ConfigMaps with application Grafana dashboards
+const LabelGrafanaDashboard = "verrazzano.io/grafana-dashboard"
+
 // LegacyElasticsearchSecretName legacy secret name for Elasticsearch credentials
 const LegacyElasticsearchSecretName = "verrazzano"
 
@@ -113,6 +122,11 @@ const KubeSystem = "kube-system"
 // #nosec
 const DefaultVerrazzanoCASecretName = "verrazzano-ca-certificate-secret"
 
+// VerrazzanoCATransitionSecret is the secret in the cert-manager namespace that holds the previous and the new
+// self-signed CA while the Verrazzano CA is rotated, the CA bundles trust both until the previous CA is retired
+// #nosec
+const VerrazzanoCATransitionSecret = "verrazzano-ca-transition"
+
 // VmiPromConfigName - The name of the prometheus config map
 const VmiPromConfigName string = "vmi-system-prometheus-config"
 
@@ -200,6 +214,16 @@ const (
 	RancherFleetLocalSystemNamespace = "cattle-fleet-local-system"
 )
 
+// NetworkPolicyNamespaces are the Verrazzano system namespaces protected by the network policies of the platform
+var NetworkPolicyNamespaces = []string{
+	KeycloakNamespace,
+	RancherSystemNamespace,
+	VerrazzanoSystemNamespace,
+	platformOperatorConstants.VerrazzanoMonitoringNamespace,
+	platformOperatorConstants.IngressNginxNamespace,
+	IstioSystemNamespace,
+}
+
 var ComponentNameToNamespacesMap = map[string][]string{
 	OamKubernetesRuntime:          {VerrazzanoSystemNamespace},
 	KialiServer:                   {VerrazzanoSystemNamespace},
@@ -0,0 +1,57 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"errors"
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// Helm operations reported in a HelmError
+const (
+	opGetValues = "get values"
+	opHistory   = "history"
+	opInstall   = "install"
+	opStatus    = "status"
+	opUninstall = "uninstall"
+	opUpgrade   = "upgrade"
+)
+
+// ErrReleaseNotFound is returned when the release does not exist in the namespace
+var ErrReleaseNotFound = driver.ErrReleaseNotFound
+
+// HelmError is returned when a Helm operation on a release fails
+type HelmError struct {
+	Operation   string
+	ReleaseName string
+	Namespace   string
+	Err         error
+}
+
+// Error returns the error message
+func (e *HelmError) Error() string {
+	return fmt.Sprintf("Helm %s of release %s/%s failed: %v", e.Operation, e.Namespace, e.ReleaseName, e.Err)
+}
+
+// Unwrap returns the underlying Helm SDK error
+func (e *HelmError) Unwrap() error {
+	return e.Err
+}
+
+// IsReleaseNotFound returns true if the error indicates that the release does not exist
+func IsReleaseNotFound(err error) bool {
+	return errors.Is(err, ErrReleaseNotFound)
+}
+
+// newHelmError returns a HelmError for the operation
+func newHelmError(operation string, releaseName string, namespace string, err error) error {
+	return &HelmError{
+		Operation:   operation,
+		ReleaseName: releaseName,
+		Namespace:   namespace,
+		Err:         err,
+	}
+}
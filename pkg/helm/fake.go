@@ -0,0 +1,69 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"io/ioutil"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+// NewFakeActionConfigFunction returns an action configuration function for unit testing.  Releases are kept
+// in memory, starting with the specified releases, and Kubernetes operations are no-ops.
+func NewFakeActionConfigFunction(releases ...*release.Release) ActionConfigFnType {
+	mem := driver.NewMemory()
+	store := storage.Init(mem)
+	for _, rel := range releases {
+		_ = store.Create(rel)
+	}
+	return func(log vzlog.VerrazzanoLogger, _ *cli.EnvSettings, namespace string) (*action.Configuration, error) {
+		mem.SetNamespace(namespace)
+		return &action.Configuration{
+			Releases:     store,
+			KubeClient:   &kubefake.PrintingKubeClient{Out: ioutil.Discard},
+			Capabilities: chartutil.DefaultCapabilities,
+			Log:          debugLogFunc(log),
+		}, nil
+	}
+}
+
+// NewFailingActionConfigFunction returns an action configuration function for unit testing that always fails
+func NewFailingActionConfigFunction(err error) ActionConfigFnType {
+	return func(_ vzlog.VerrazzanoLogger, _ *cli.EnvSettings, _ string) (*action.Configuration, error) {
+		return nil, err
+	}
+}
+
+// NewFakeRelease returns revision 1 of a release for unit testing
+func NewFakeRelease(name string, namespace string, status release.Status, appVersion string, config map[string]interface{}) *release.Release {
+	now := helmtime.Now()
+	return &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   1,
+		Config:    config,
+		Info: &release.Info{
+			FirstDeployed: now,
+			LastDeployed:  now,
+			Status:        status,
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       name,
+				Version:    "1.0.0",
+				AppVersion: appVersion,
+			},
+		},
+	}
+}
@@ -4,34 +4,47 @@
 package helm
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"os/exec"
-	"regexp"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
-	vzos "github.com/verrazzano/verrazzano/pkg/os"
-	"go.uber.org/zap"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
 )
 
-// Debug is set from a platform-operator arg and sets the helm --debug flag
+// Debug is set from a platform-operator arg and enables logging of the Helm SDK debug output
 var Debug bool
 
-// cmdRunner needed for unit tests
-var runner vzos.CmdRunner = vzos.DefaultRunner{}
-
 // Helm chart status values: unknown, deployed, uninstalled, superseded, failed, uninstalling, pending-install, pending-upgrade or pending-rollback
 const ChartNotFound = "NotFound"
 const ChartStatusDeployed = "deployed"
 const ChartStatusPendingInstall = "pending-install"
 const ChartStatusFailed = "failed"
 
+// defaultTimeout is the time to wait for Kubernetes operations, same as the Helm CLI default
+const defaultTimeout = 5 * time.Minute
+
+// maxRetry is the number of attempts of a Helm install or upgrade that fails with a retryable error
+const maxRetry = 5
+
+// retryDelay is the time to wait before retrying a Helm install or upgrade, overridden for unit testing
+var retryDelay = 5 * time.Second
+
 // ChartStatusFnType - Package-level var and functions to allow overriding GetChartStatus for unit test purposes
 type ChartStatusFnType func(releaseName string, namespace string) (string, error)
 
-// HelmOverrides contains all of the overrides that gets passed to the helm cli runner
+// HelmOverrides contains all of the overrides that gets passed to the Helm upgrade
 type HelmOverrides struct {
 	SetOverrides       string // for --set
 	SetStringOverrides string // for --set-string
@@ -81,187 +94,242 @@ func SetDefaultChartStateFunction() {
 	releaseStateFn = getChartStatus
 }
 
-// GetValues will run 'helm get values' command and return the output from the command.
-func GetValues(log vzlog.VerrazzanoLogger, releaseName string, namespace string) ([]byte, error) {
-	// Helm get values command will get the current set values for the installed chart.
-	// The output will be used as input to the helm upgrade command.
-	args := []string{"get", "values", releaseName}
-	if namespace != "" {
-		args = append(args, "--namespace")
-		args = append(args, namespace)
-	}
+// ActionConfigFnType - Package-level var and functions to allow overriding the Helm action configuration for unit test purposes
+type ActionConfigFnType func(log vzlog.VerrazzanoLogger, settings *cli.EnvSettings, namespace string) (*action.Configuration, error)
+
+var actionConfigFn ActionConfigFnType = getActionConfig
+
+// SetActionConfigFunction Override the Helm action configuration function for unit testing
+func SetActionConfigFunction(f ActionConfigFnType) {
+	actionConfigFn = f
+}
+
+// SetDefaultActionConfigFunction Reset the Helm action configuration function
+func SetDefaultActionConfigFunction() {
+	actionConfigFn = getActionConfig
+}
+
+// LoadChartFnType - Package-level var and functions to allow overriding chart loading for unit test purposes
+type LoadChartFnType func(chartDir string) (*chart.Chart, error)
+
+var loadChartFn LoadChartFnType = loader.Load
+
+// SetLoadChartFunction Override the chart load function for unit testing
+func SetLoadChartFunction(f LoadChartFnType) {
+	loadChartFn = f
+}
+
+// SetDefaultLoadChartFunction Reset the chart load function
+func SetDefaultLoadChartFunction() {
+	loadChartFn = loader.Load
+}
 
-	cmd := exec.Command("helm", args...)
-	log.Debugf("Running command to get Helm values: %s", cmd.String())
-	stdout, stderr, err := runner.Run(cmd)
+// GetValues returns the user-supplied values of a release as YAML, the same as 'helm get values'.
+func GetValues(log vzlog.VerrazzanoLogger, releaseName string, namespace string) ([]byte, error) {
+	// The current set values for the installed chart are used as input to the Helm upgrade.
+	vals, err := getUserValues(log, releaseName, namespace)
 	if err != nil {
-		log.Errorf("Failed to get Helm values for %s: stderr %s", releaseName, string(stderr))
 		return nil, err
 	}
+	yamlValues, err := yaml.Marshal(vals)
+	if err != nil {
+		return nil, newHelmError(opGetValues, releaseName, namespace, err)
+	}
 
 	//  Log get values output
 	log.Debugf("Successfully fetched Helm get values %s", releaseName)
-
-	return stdout, nil
+	return yamlValues, nil
 }
 
-// GetValuesMap will run 'helm get values' command and return the output from the command as a map of Objects.
+// GetValuesMap returns the user-supplied values of a release as a map of Objects.
 func GetValuesMap(log vzlog.VerrazzanoLogger, releaseName string, namespace string) (map[string]interface{}, error) {
-	// Helm get values command will get the current set values for the installed chart.
-	// The output will be used as input to the helm upgrade command.
-	args := []string{"get", "values", releaseName}
-	if namespace != "" {
-		args = append(args, "--namespace")
-		args = append(args, namespace)
-		args = append(args, "-o")
-		args = append(args, "json")
-	}
-
-	cmd := exec.Command("helm", args...)
-	log.Debugf("Running command to get Helm values: %s", cmd.String())
-	stdout, stderr, err := runner.Run(cmd)
+	vals, err := getUserValues(log, releaseName, namespace)
 	if err != nil {
-		log.Errorf("Failed to get Helm values for %s: stderr %s", releaseName, string(stderr))
 		return nil, err
 	}
-
-	var valuesMap map[string]interface{}
-	if err := json.Unmarshal(stdout, &valuesMap); err != nil {
-		return map[string]interface{}{}, err
+	if vals == nil {
+		vals = map[string]interface{}{}
 	}
+
 	//  Log get values output
 	log.Debugf("Successfully fetched Helm get values %s", releaseName)
-	return valuesMap, nil
+	return vals, nil
 }
 
-// Upgrade will upgrade a Helm release with the specified charts.  The override files array
-// are in order with the first files in the array have lower precedence than latter files.
-func Upgrade(log vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []HelmOverrides) (stdout []byte, stderr []byte, err error) {
-	// Helm upgrade command will apply the new chart, but use all the existing
-	// overrides that we used during the install.
-	args := []string{"--install"}
-
-	// Do not pass the --reuse-values arg to 'helm upgrade'.  Instead, pass the
-	// values retrieved from 'helm get values' with the -f arg to 'helm upgrade'. This is a workaround to avoid
-	// a failed helm upgrade that results from a nil reference.  The nil reference occurs when a default value
-	// is added to a new chart and new chart references the new value.
-	for _, override := range overrides {
-		// Add file overrides
-		if len(override.FileOverride) > 0 {
-			args = append(args, "-f")
-			args = append(args, override.FileOverride)
-		}
-		// Add the override strings
-		if len(override.SetOverrides) > 0 {
-			args = append(args, "--set")
-			args = append(args, override.SetOverrides)
-		}
-		// Add the set-string override strings
-		if len(override.SetStringOverrides) > 0 {
-			args = append(args, "--set-string")
-			args = append(args, override.SetStringOverrides)
-		}
-		// Add the set-file override strings
-		if len(override.SetFileOverrides) > 0 {
-			args = append(args, "--set-file")
-			args = append(args, override.SetFileOverrides)
-		}
+// getUserValues runs the Helm get values action for a release
+func getUserValues(log vzlog.VerrazzanoLogger, releaseName string, namespace string) (map[string]interface{}, error) {
+	actionConfig, err := newActionConfig(log, namespace)
+	if err != nil {
+		return nil, newHelmError(opGetValues, releaseName, namespace, err)
 	}
-
-	stdout, stderr, err = runHelm(log, releaseName, namespace, chartDir, "upgrade", wait, args, dryRun)
+	client := action.NewGetValues(actionConfig)
+	vals, err := client.Run(releaseName)
 	if err != nil {
-		return stdout, stderr, err
+		log.Errorf("Failed to get Helm values for %s/%s: %v", namespace, releaseName, err)
+		return nil, newHelmError(opGetValues, releaseName, namespace, err)
 	}
-
-	return stdout, stderr, nil
+	return vals, nil
 }
 
-// Uninstall will uninstall the release in the specified namespace  using helm uninstall
-func Uninstall(log vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) (stdout []byte, stderr []byte, err error) {
-	// Helm upgrade command will apply the new chart, but use all the existing
-	// overrides that we used during the install.
-	var args []string
-
-	stdout, stderr, err = runHelm(log, releaseName, namespace, "", "uninstall", false, args, dryRun)
+// Upgrade will upgrade a Helm release with the specified charts, installing it if the release does not exist.
+// The overrides array is in order with the first overrides in the array having lower precedence than latter ones.
+func Upgrade(log vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []HelmOverrides) (*release.Release, error) {
+	settings := newSettings(namespace)
+	actionConfig, err := actionConfigFn(log, settings, namespace)
 	if err != nil {
-		return stdout, stderr, err
+		return nil, newHelmError(opUpgrade, releaseName, namespace, err)
 	}
 
-	return stdout, stderr, nil
-}
-
-// runHelm is a helper function to execute the helm CLI and return a result
-func runHelm(log vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, operation string, wait bool, args []string, dryRun bool) (stdout []byte, stderr []byte, err error) {
-	cmdArgs := []string{operation, releaseName}
-	if len(chartDir) > 0 {
-		cmdArgs = append(cmdArgs, chartDir)
-	}
-	if Debug {
-		cmdArgs = append(cmdArgs, "--debug")
+	// Do not reuse the values of the deployed release.  Instead, the caller passes the values retrieved
+	// from GetValues as the first file override. This is a workaround to avoid a failed upgrade that results
+	// from a nil reference.  The nil reference occurs when a default value is added to a new chart and new
+	// chart references the new value.
+	vals, err := mergeOverrides(settings, overrides)
+	if err != nil {
+		log.Errorf("Failed to merge Helm overrides for release %s: %v", releaseName, err)
+		return nil, newHelmError(opUpgrade, releaseName, namespace, err)
 	}
-	if dryRun {
-		cmdArgs = append(cmdArgs, "--dry-run")
+
+	chartPath, err := locateChart(settings, chartDir)
+	if err != nil {
+		log.Errorf("Failed to locate Helm chart %s for release %s: %v", chartDir, releaseName, err)
+		return nil, newHelmError(opUpgrade, releaseName, namespace, err)
 	}
-	if wait {
-		cmdArgs = append(cmdArgs, "--wait")
+	ch, err := loadChartFn(chartPath)
+	if err != nil {
+		log.Errorf("Failed to load Helm chart %s for release %s: %v", chartDir, releaseName, err)
+		return nil, newHelmError(opUpgrade, releaseName, namespace, err)
 	}
-	if namespace != "" {
-		cmdArgs = append(cmdArgs, "--namespace")
-		cmdArgs = append(cmdArgs, namespace)
-	}
-	cmdArgs = append(cmdArgs, args...)
-
-	// Try to upgrade several times.  Sometimes upgrade fails with "already exists" or "no deployed release".
-	// We have seen from tests that doing a retry will eventually succeed if these 2 errors occur.
-	const maxRetry = 5
-	for i := 1; i <= maxRetry; i++ {
-		cmd := exec.Command("helm", cmdArgs...)
-
-		// mask sensitive data before logging
-		cmdStr := maskSensitiveData(cmd.String())
-		if i == 1 {
-			log.Progressf("Running Helm command %s for release %s", cmdStr, releaseName)
-		} else {
-			log.Progressf("Re-running Helm command for release %s", releaseName)
+	if req := ch.Metadata.Dependencies; req != nil {
+		if err := action.CheckDependencies(ch, req); err != nil {
+			return nil, newHelmError(opUpgrade, releaseName, namespace, err)
 		}
+	}
 
-		stdout, stderr, err = runner.Run(cmd)
-		if err == nil {
-			log.Debugf("Successfully ran Helm command for operation %s and release %s", operation, releaseName)
-			break
+	// Try to upgrade several times.  Sometimes the upgrade fails with "already exists" or "has no deployed
+	// releases".  We have seen from tests that doing a retry will eventually succeed if these 2 errors occur.
+	for i := 1; ; i++ {
+		rel, err := installOrUpgrade(log, actionConfig, releaseName, namespace, chartDir, ch, vals, wait, dryRun)
+		if err == nil || i == maxRetry || !isRetryableError(err) {
+			return rel, err
 		}
-		if i == 1 || i == maxRetry {
-			log.Errorf("Failed running Helm command for release %s: stderr %s",
-				releaseName, string(stderr))
-			return stdout, stderr, err
+		log.Infof("Failed running Helm upgrade for release %s/%s, retrying %d of %d: %v", namespace, releaseName, i+1, maxRetry, err)
+		time.Sleep(retryDelay)
+	}
+}
+
+// installOrUpgrade installs the release if there is no release history, the same as 'helm upgrade --install',
+// otherwise the release is upgraded
+func installOrUpgrade(log vzlog.VerrazzanoLogger, actionConfig *action.Configuration, releaseName string, namespace string, chartDir string, ch *chart.Chart, vals map[string]interface{}, wait bool, dryRun bool) (*release.Release, error) {
+	histClient := action.NewHistory(actionConfig)
+	histClient.Max = 1
+	if _, err := histClient.Run(releaseName); errors.Is(err, ErrReleaseNotFound) {
+		log.Progressf("Running Helm install for release %s/%s using chart %s", namespace, releaseName, chartDir)
+		client := action.NewInstall(actionConfig)
+		client.ReleaseName = releaseName
+		client.Namespace = namespace
+		client.Wait = wait
+		client.DryRun = dryRun
+		client.Timeout = defaultTimeout
+		rel, err := client.Run(ch, vals)
+		if err != nil {
+			log.Errorf("Failed running Helm install for release %s/%s: %v", namespace, releaseName, err)
+			return nil, newHelmError(opInstall, releaseName, namespace, err)
 		}
-		log.Infof("Failed running Helm command for operation %s and release %s. Retrying %s of %s", operation, releaseName, i+1, maxRetry)
+		log.Debugf("Successfully ran Helm install for release %s/%s", namespace, releaseName)
+		return rel, nil
+	} else if err != nil {
+		return nil, newHelmError(opHistory, releaseName, namespace, err)
+	}
+
+	log.Progressf("Running Helm upgrade for release %s/%s using chart %s", namespace, releaseName, chartDir)
+	client := action.NewUpgrade(actionConfig)
+	client.Namespace = namespace
+	client.Wait = wait
+	client.DryRun = dryRun
+	client.Timeout = defaultTimeout
+	rel, err := client.Run(releaseName, ch, vals)
+	if err != nil {
+		log.Errorf("Failed running Helm upgrade for release %s/%s: %v", namespace, releaseName, err)
+		return nil, newHelmError(opUpgrade, releaseName, namespace, err)
 	}
+	log.Debugf("Successfully ran Helm upgrade for release %s/%s", namespace, releaseName)
+	return rel, nil
+}
 
-	return stdout, stderr, nil
+// isRemoteChart returns true if the chart is the URL of a chart archive or an OCI registry reference
+func isRemoteChart(chartPath string) bool {
+	return registry.IsOCI(chartPath) || strings.HasPrefix(chartPath, "http://") || strings.HasPrefix(chartPath, "https://")
 }
 
-// maskSensitiveData replaces sensitive data in a string with mask characters.
-func maskSensitiveData(str string) string {
-	const maskString = "*****"
-	re := regexp.MustCompile(`[Pp]assword=(.+?)(?:,|\z)`)
+// isRetryableError returns true if a failed install or upgrade is known to succeed when retried
+func isRetryableError(err error) bool {
+	return strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "has no deployed releases")
+}
 
-	matches := re.FindAllStringSubmatch(str, -1)
-	for _, match := range matches {
-		if len(match) == 2 {
-			str = strings.Replace(str, match[1], maskString, 1)
+// locateChart returns the local path of a chart.  A chart directory or archive is used as is, while the URL of a
+// chart archive or an OCI registry reference is downloaded to the Helm repository cache, the same as the Helm CLI.
+func locateChart(settings *cli.EnvSettings, chartPath string) (string, error) {
+	if !isRemoteChart(chartPath) {
+		return chartPath, nil
+	}
+	actionConfig := new(action.Configuration)
+	if registry.IsOCI(chartPath) {
+		registryClient, err := registry.NewClient(registry.ClientOptDebug(settings.Debug),
+			registry.ClientOptCredentialsFile(settings.RegistryConfig))
+		if err != nil {
+			return "", err
 		}
+		actionConfig.RegistryClient = registryClient
+	}
+	return action.NewInstall(actionConfig).ChartPathOptions.LocateChart(chartPath, settings)
+}
+
+// Uninstall will uninstall the release in the specified namespace
+func Uninstall(log vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) error {
+	actionConfig, err := newActionConfig(log, namespace)
+	if err != nil {
+		return newHelmError(opUninstall, releaseName, namespace, err)
 	}
 
-	return str
+	log.Progressf("Running Helm uninstall for release %s/%s", namespace, releaseName)
+	client := action.NewUninstall(actionConfig)
+	client.DryRun = dryRun
+	client.Timeout = defaultTimeout
+	if _, err := client.Run(releaseName); err != nil {
+		log.Errorf("Failed running Helm uninstall for release %s/%s: %v", namespace, releaseName, err)
+		return newHelmError(opUninstall, releaseName, namespace, err)
+	}
+	log.Debugf("Successfully ran Helm uninstall for release %s/%s", namespace, releaseName)
+	return nil
+}
+
+// GetReleaseHistory returns the revisions of a release, newest first.  At most max revisions are
+// returned, or all of them if max is not positive.
+func GetReleaseHistory(log vzlog.VerrazzanoLogger, releaseName string, namespace string, max int) ([]*release.Release, error) {
+	actionConfig, err := newActionConfig(log, namespace)
+	if err != nil {
+		return nil, newHelmError(opHistory, releaseName, namespace, err)
+	}
+	history, err := action.NewHistory(actionConfig).Run(releaseName)
+	if err != nil {
+		return nil, newHelmError(opHistory, releaseName, namespace, err)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Version > history[j].Version
+	})
+	if max > 0 && len(history) > max {
+		history = history[:max]
+	}
+	return history, nil
 }
 
 // IsReleaseFailed Returns true if the chart release state is marked 'failed'
 func IsReleaseFailed(releaseName string, namespace string) (bool, error) {
-	log := zap.S()
+	log := vzlog.DefaultLogger()
 	releaseStatus, err := releaseStateFn(releaseName, namespace)
 	if err != nil {
-		log.Errorf("Getting status for chart %s/%s failed with stderr: %v\n", namespace, releaseName, err)
+		log.Errorf("Getting status for chart %s/%s failed: %v", namespace, releaseName, err)
 		return false, err
 	}
 	return releaseStatus == ChartStatusFailed, nil
@@ -269,10 +337,10 @@ func IsReleaseFailed(releaseName string, namespace string) (bool, error) {
 
 // IsReleaseDeployed returns true if the release is deployed
 func IsReleaseDeployed(releaseName string, namespace string) (found bool, err error) {
-	log := zap.S()
+	log := vzlog.DefaultLogger()
 	releaseStatus, err := chartStatusFn(releaseName, namespace)
 	if err != nil {
-		log.Errorf("Getting status for chart %s/%s failed with stderr: %v\n", namespace, releaseName, err)
+		log.Errorf("Getting status for chart %s/%s failed: %v", namespace, releaseName, err)
 		return false, err
 	}
 	switch releaseStatus {
@@ -286,78 +354,37 @@ func IsReleaseDeployed(releaseName string, namespace string) (found bool, err er
 
 // IsReleaseInstalled returns true if the release is installed
 func IsReleaseInstalled(releaseName string, namespace string) (found bool, err error) {
-	log := zap.S()
-
-	args := []string{"status", releaseName}
-	if namespace != "" {
-		args = append(args, "--namespace")
-		args = append(args, namespace)
-	}
-	cmd := exec.Command("helm", args...)
-	stdout, stderr, err := runner.Run(cmd)
-
-	if err == nil {
-		log.Debugf("helm status stdout: %s", string(stdout))
-		return true, nil
-	}
-
-	if strings.Contains(string(stderr), "not found") {
-		return false, nil
+	log := vzlog.DefaultLogger()
+	rel, err := getRelease(log, releaseName, namespace)
+	if err != nil {
+		if IsReleaseNotFound(err) {
+			return false, nil
+		}
+		log.Errorf("Helm status for release %s/%s failed: %v", namespace, releaseName, err)
+		return false, err
 	}
-	log.Errorf("helm status for release %s failed with stderr: %s\n", releaseName, string(stderr))
-	return false, err
+	log.Debugf("Helm release %s/%s found with status %s", namespace, releaseName, rel.Info.Status)
+	return true, nil
 }
 
-// getChartStatus extracts the Helm deployment status of the specified chart from the JSON output as a string
+// getChartStatus returns the Helm deployment status of the latest revision of the specified release as a string
 func getChartStatus(releaseName string, namespace string) (string, error) {
-	args := []string{"status", releaseName}
-	if namespace != "" {
-		args = append(args, "--namespace")
-		args = append(args, namespace)
-		args = append(args, "-o")
-		args = append(args, "json")
-	}
-	cmd := exec.Command("helm", args...)
-	stdout, stderr, err := runner.Run(cmd)
+	rel, err := getRelease(vzlog.DefaultLogger(), releaseName, namespace)
 	if err != nil {
-		if strings.Contains(string(stderr), "not found") {
+		if IsReleaseNotFound(err) {
 			return ChartNotFound, nil
 		}
-		return "", fmt.Errorf("helm status for release %s failed with stderr: %s", releaseName, string(stderr))
-	}
-
-	var statusInfo map[string]interface{}
-	if err := json.Unmarshal(stdout, &statusInfo); err != nil {
 		return "", err
 	}
-
-	if info, infoFound := statusInfo["info"].(map[string]interface{}); infoFound {
-		if status, statusFound := info["status"].(string); statusFound {
-			return strings.TrimSpace(status), nil
-		}
+	if rel.Info == nil {
+		return "", fmt.Errorf("No chart status found for %s/%s", namespace, releaseName)
 	}
-	return "", fmt.Errorf("No chart status found for %s/%s", namespace, releaseName)
+	return strings.TrimSpace(rel.Info.Status.String()), nil
 }
 
-// getReleaseState extracts the release state from an "ls -o json" command for a specific release/namespace
+// getReleaseState returns the release state for a specific release/namespace
 func getReleaseState(releaseName string, namespace string) (string, error) {
-	statusInfo, err := getReleases(namespace)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return ChartNotFound, nil
-		}
-		return "", err
-	}
-
-	var status string
-	for _, info := range statusInfo {
-		release := info["name"].(string)
-		if release == releaseName {
-			status = info["status"].(string)
-			break
-		}
-	}
-	return strings.TrimSpace(status), nil
+	return getChartStatus(releaseName, namespace)
 }
 
 // GetReleaseAppVersion - public function to execute releaseAppVersionFn
@@ -400,59 +427,86 @@ func GetReleaseValues(log vzlog.VerrazzanoLogger, valueKeys []string, releaseNam
 	return values, nil
 }
 
-// getReleaseAppVersion extracts the release app_version from a "ls -o json" command for a specific release/namespace
+// getReleaseAppVersion returns the chart app version of the latest revision of a specific release/namespace
 func getReleaseAppVersion(releaseName string, namespace string) (string, error) {
-	statusInfo, err := getReleases(namespace)
+	rel, err := getRelease(vzlog.DefaultLogger(), releaseName, namespace)
 	if err != nil {
-		if err.Error() == ChartNotFound {
+		if IsReleaseNotFound(err) {
 			return ChartNotFound, nil
 		}
 		return "", err
 	}
-
-	var status string
-	for _, info := range statusInfo {
-		release := info["name"].(string)
-		if release == releaseName {
-			status = info["app_version"].(string)
-			break
-		}
+	if rel.Chart == nil || rel.Chart.Metadata == nil {
+		return "", nil
 	}
-	return strings.TrimSpace(status), nil
+	return strings.TrimSpace(rel.Chart.Metadata.AppVersion), nil
 }
 
-func getReleases(namespace string) ([]map[string]interface{}, error) {
-	var statusInfo []map[string]interface{}
-
-	args := []string{"ls"}
-	if namespace != "" {
-		args = append(args, "--namespace")
-		args = append(args, namespace)
-		args = append(args, "-o")
-		args = append(args, "json")
+// getRelease returns the latest revision of a release
+func getRelease(log vzlog.VerrazzanoLogger, releaseName string, namespace string) (*release.Release, error) {
+	actionConfig, err := newActionConfig(log, namespace)
+	if err != nil {
+		return nil, newHelmError(opStatus, releaseName, namespace, err)
 	}
-	cmd := exec.Command("helm", args...)
-	stdout, stderr, err := runner.Run(cmd)
+	rel, err := action.NewStatus(actionConfig).Run(releaseName)
 	if err != nil {
-		if strings.Contains(string(stderr), "not found") {
-			return statusInfo, fmt.Errorf(ChartNotFound)
+		return nil, newHelmError(opStatus, releaseName, namespace, err)
+	}
+	return rel, nil
+}
+
+// mergeOverrides merges the overrides into a single set of values.  Like the Helm CLI, file overrides are merged
+// first, followed by the set, set-string and set-file overrides.
+func mergeOverrides(settings *cli.EnvSettings, overrides []HelmOverrides) (map[string]interface{}, error) {
+	opts := values.Options{}
+	for _, override := range overrides {
+		if len(override.FileOverride) > 0 {
+			opts.ValueFiles = append(opts.ValueFiles, override.FileOverride)
+		}
+		if len(override.SetOverrides) > 0 {
+			opts.Values = append(opts.Values, override.SetOverrides)
+		}
+		if len(override.SetStringOverrides) > 0 {
+			opts.StringValues = append(opts.StringValues, override.SetStringOverrides)
+		}
+		if len(override.SetFileOverrides) > 0 {
+			opts.FileValues = append(opts.FileValues, override.SetFileOverrides)
 		}
-		return statusInfo, fmt.Errorf("helm status for namespace %s failed with stderr: %s", namespace, string(stderr))
 	}
+	return opts.MergeValues(getter.All(settings))
+}
 
-	if err := json.Unmarshal(stdout, &statusInfo); err != nil {
-		return statusInfo, err
+// newSettings returns the Helm environment settings for the namespace
+func newSettings(namespace string) *cli.EnvSettings {
+	settings := cli.New()
+	settings.Debug = Debug
+	if namespace != "" {
+		settings.SetNamespace(namespace)
 	}
+	return settings
+}
 
-	return statusInfo, nil
+// newActionConfig returns the Helm action configuration for the namespace
+func newActionConfig(log vzlog.VerrazzanoLogger, namespace string) (*action.Configuration, error) {
+	return actionConfigFn(log, newSettings(namespace), namespace)
 }
 
-// SetCmdRunner sets the command runner as needed by unit tests
-func SetCmdRunner(r vzos.CmdRunner) {
-	runner = r
+// getActionConfig initializes a Helm action configuration that stores release data in the namespace
+func getActionConfig(log vzlog.VerrazzanoLogger, settings *cli.EnvSettings, namespace string) (*action.Configuration, error) {
+	actionConfig := new(action.Configuration)
+	if err := actionConfig.Init(settings.RESTClientGetter(), namespace, os.Getenv("HELM_DRIVER"), debugLogFunc(log)); err != nil {
+		return nil, err
+	}
+	return actionConfig, nil
 }
 
-// SetDefaultRunner sets the command runner to default
-func SetDefaultRunner() {
-	runner = vzos.DefaultRunner{}
+// debugLogFunc returns the Helm SDK log function, which logs at info level when Debug is set
+func debugLogFunc(log vzlog.VerrazzanoLogger) action.DebugLog {
+	return func(format string, v ...interface{}) {
+		if Debug {
+			log.Infof(format, v...)
+			return
+		}
+		log.Debugf(format, v...)
+	}
 }
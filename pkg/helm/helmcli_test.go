@@ -6,171 +6,295 @@ package helm
 import (
 	"errors"
 	"fmt"
-	"os/exec"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/kube"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
 )
 
-const ns = "my_namespace"
-const chartdir = "my_charts"
-const release = "my_release"
-const missingRelease = "no_release"
+const ns = "my-namespace"
+const chartdir = "./testdata"
+const releaseName = "my-release"
+const missingRelease = "no-release"
 
-// upgradeRunner is used to test Helm upgrade without actually running an OS exec command
-type upgradeRunner struct {
-	t *testing.T
-
-	expectedArgs []string
+// deployedRelease returns a deployed release with the given values
+func deployedRelease(values map[string]interface{}) *release.Release {
+	return NewFakeRelease(releaseName, ns, release.StatusDeployed, "1.0.0", values)
 }
 
-// getValuesRunner is used to test Helm get values without actually running an OS exec command
-type getValuesRunner struct {
-	t *testing.T
-}
+// TestGetValues tests the Helm get values action
+// GIVEN a deployed release
+//  WHEN I call GetValues
+//  THEN the user-supplied values of the release are returned as YAML
+func TestGetValues(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(NewFakeActionConfigFunction(deployedRelease(map[string]interface{}{"foo": "bar"})))
+	defer SetDefaultActionConfigFunction()
 
-// badRunner is used to test Helm errors without actually running an OS exec command
-type badRunner struct {
-	t *testing.T
+	stdout, err := GetValues(vzlog.DefaultLogger(), releaseName, ns)
+	assert.NoError(err, "GetValues returned an error")
+	assert.Equal("foo: bar\n", string(stdout))
 }
 
-// foundRunner is used to test helm status command
-type foundRunner struct {
-	t *testing.T
-}
+// TestGetValuesReleaseNotFound tests the Helm get values action
+// GIVEN a release that does not exist
+//  WHEN I call GetValues
+//  THEN a release not found error is returned
+func TestGetValuesReleaseNotFound(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
 
-// genericTestRunner is used to run generic OS commands with expected results
-type genericTestRunner struct {
-	stdOut []byte
-	stdErr []byte
-	err    error
+	_, err := GetValues(vzlog.DefaultLogger(), missingRelease, ns)
+	assert.Error(err)
+	assert.True(IsReleaseNotFound(err), "Expected a release not found error")
 }
 
-// Run genericTestRunner executor
-func (r genericTestRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return r.stdOut, r.stdErr, r.err
+// TestUpgradeInstall tests the Helm upgrade of a release that does not exist
+// GIVEN a set of upgrade parameters and no existing release
+//  WHEN I call Upgrade
+//  THEN the release is installed with the overrides
+func TestUpgradeInstall(t *testing.T) {
+	assert := assert.New(t)
+	overrideFile := filepath.Join(t.TempDir(), "my-override.yaml")
+	assert.NoError(os.WriteFile(overrideFile, []byte("foo: bar\nname: file\n"), 0600))
+	overrides := []HelmOverrides{
+		{SetOverrides: "name=set"},
+		{FileOverride: overrideFile},
+		{SetStringOverrides: "count=1"},
+	}
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
+
+	rel, err := Upgrade(vzlog.DefaultLogger(), releaseName, ns, chartdir, false, false, overrides)
+	assert.NoError(err, "Upgrade returned an error")
+	assert.Equal(1, rel.Version)
+	assert.Equal(release.StatusDeployed, rel.Info.Status)
+	// set overrides take precedence over file overrides, the same as the Helm CLI
+	assert.Equal(map[string]interface{}{"foo": "bar", "name": "set", "count": "1"}, rel.Config)
 }
 
-// TestGetValues tests the Helm get values command
-// GIVEN a set of upgrade parameters
+// TestUpgradeCustomFileOverrides tests the Helm upgrade of an existing release
+// GIVEN a deployed release and a set of upgrade parameters with additional file overrides
 //  WHEN I call Upgrade
-//  THEN the Helm upgrade returns success and the cmd object has correct values
-func TestGetValues(t *testing.T) {
+//  THEN a new revision is created and later file overrides take precedence
+func TestUpgradeCustomFileOverrides(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(getValuesRunner{t: t})
-	defer SetDefaultRunner()
+	dir := t.TempDir()
+	overrideFile := filepath.Join(dir, "my-override.yaml")
+	customFile := filepath.Join(dir, "custom-override.yaml")
+	assert.NoError(os.WriteFile(overrideFile, []byte("foo: bar\nname: file\n"), 0600))
+	assert.NoError(os.WriteFile(customFile, []byte("name: custom\n"), 0600))
+	overrides := []HelmOverrides{
+		{FileOverride: overrideFile},
+		{FileOverride: customFile},
+	}
+	SetActionConfigFunction(NewFakeActionConfigFunction(deployedRelease(map[string]interface{}{"old": "value"})))
+	defer SetDefaultActionConfigFunction()
 
-	stdout, err := GetValues(vzlog.DefaultLogger(), release, ns)
-	assert.NoError(err, "GetValues returned an error")
-	assert.NotZero(stdout, "GetValues stdout should not be empty")
+	rel, err := Upgrade(vzlog.DefaultLogger(), releaseName, ns, chartdir, false, false, overrides)
+	assert.NoError(err, "Upgrade returned an error")
+	assert.Equal(2, rel.Version)
+	assert.Equal(map[string]interface{}{"foo": "bar", "name": "custom"}, rel.Config)
+
+	history, err := GetReleaseHistory(vzlog.DefaultLogger(), releaseName, ns, 0)
+	assert.NoError(err)
+	assert.Len(history, 2)
+	assert.Equal(2, history[0].Version)
+	assert.Equal(release.StatusDeployed, history[0].Info.Status)
+	assert.Equal(release.StatusSuperseded, history[1].Info.Status)
 }
 
-// TestUpgrade tests the Helm upgrade command
-// GIVEN a set of upgrade parameters
+// TestUpgradeFail tests the Helm upgrade failure condition
+// GIVEN a set of upgrade parameters and an action configuration that fails
 //  WHEN I call Upgrade
-//  THEN the Helm upgrade returns success and the cmd object has correct values
-func TestUpgrade(t *testing.T) {
-	var overrides []HelmOverrides
-	overrides = append(overrides, HelmOverrides{FileOverride: "my-override.yaml"})
+//  THEN the Helm upgrade returns a HelmError
+func TestUpgradeFail(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(upgradeRunner{
-		t: t,
-		expectedArgs: []string{
-			"--namespace",
-			"my_namespace",
-			"--install",
-			"-f",
-			"my-override.yaml",
-		},
-	})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFailingActionConfigFunction(errors.New("error")))
+	defer SetDefaultActionConfigFunction()
 
-	stdout, stderr, err := Upgrade(vzlog.DefaultLogger(), release, ns, chartdir, false, false, overrides)
-	assert.NoError(err, "Upgrade returned an error")
-	assert.Len(stderr, 0, "Upgrade stderr should be empty")
-	assert.NotZero(stdout, "Upgrade stdout should not be empty")
+	rel, err := Upgrade(vzlog.DefaultLogger(), releaseName, ns, chartdir, false, false, nil)
+	assert.Error(err, "Upgrade should have returned an error")
+	assert.Nil(rel)
+	var helmErr *HelmError
+	assert.True(errors.As(err, &helmErr))
+	assert.Equal(opUpgrade, helmErr.Operation)
+	assert.Equal(releaseName, helmErr.ReleaseName)
+	assert.Equal(ns, helmErr.Namespace)
 }
 
-// TestUpgradeCustomFileOverrides tests the Helm upgrade command
-// GIVEN a set of upgrade parameters with additional file overrides
+// TestUpgradeChartNotFound tests the Helm upgrade failure condition
+// GIVEN a chart directory that does not exist
 //  WHEN I call Upgrade
-//  THEN the Helm upgrade returns success and the cmd object has correct values
-func TestUpgradeCustomFileOverrides(t *testing.T) {
-	var overrides []HelmOverrides
-	overrides = append(overrides, HelmOverrides{FileOverride: "my-override.yaml"})
-	overrides = append(overrides, HelmOverrides{FileOverride: "custom-override.yaml"})
+//  THEN the Helm upgrade returns an error
+func TestUpgradeChartNotFound(t *testing.T) {
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
+
+	_, err := Upgrade(vzlog.DefaultLogger(), releaseName, ns, "./no-such-chart", false, false, nil)
+	assert.Error(t, err, "Upgrade should have returned an error")
+}
+
+// TestUpgradeRemoteChart tests the Helm upgrade of a chart archive URL
+// GIVEN the URL of a chart archive served over HTTP and no existing release
+//  WHEN I call Upgrade
+//  THEN the chart is downloaded to the repository cache and the release is installed
+func TestUpgradeRemoteChart(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(upgradeRunner{
-		t: t,
-		expectedArgs: []string{
-			"--namespace",
-			"my_namespace",
-			"--install",
-			"-f",
-			"my-override.yaml",
-			"-f",
-			"custom-override.yaml",
-		},
+	ch, err := loader.Load(chartdir)
+	assert.NoError(err)
+	archive, err := chartutil.Save(ch, t.TempDir())
+	assert.NoError(err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, archive)
+	}))
+	defer server.Close()
+	t.Setenv("HELM_REPOSITORY_CACHE", t.TempDir())
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
+
+	rel, err := Upgrade(vzlog.DefaultLogger(), releaseName, ns, server.URL+"/"+filepath.Base(archive), false, false, nil)
+	assert.NoError(err, "Upgrade returned an error")
+	assert.Equal(1, rel.Version)
+	assert.Equal(ch.Metadata.Name, rel.Chart.Metadata.Name)
+}
+
+// retryKubeClient is a fake Kubernetes client that fails to create resources the first time
+type retryKubeClient struct {
+	kubefake.PrintingKubeClient
+	createErr   error
+	createCalls int
+}
+
+// Create fails the first time it is called
+func (c *retryKubeClient) Create(resources kube.ResourceList) (*kube.Result, error) {
+	c.createCalls++
+	if c.createCalls == 1 {
+		return nil, c.createErr
+	}
+	return c.PrintingKubeClient.Create(resources)
+}
+
+// loadChartWithTemplate loads the test chart with a hook template, so that installing or upgrading it creates a resource
+func loadChartWithTemplate(chartDir string) (*chart.Chart, error) {
+	ch, err := loader.Load(chartDir)
+	if err != nil {
+		return nil, err
+	}
+	ch.Templates = append(ch.Templates, &chart.File{
+		Name: "templates/configmap.yaml",
+		Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-config\n  annotations:\n    helm.sh/hook: pre-install,pre-upgrade\n"),
 	})
-	defer SetDefaultRunner()
+	return ch, nil
+}
+
+// newRetryActionConfigFunction returns an action configuration function that uses the fake Kubernetes client
+func newRetryActionConfigFunction(kubeClient *retryKubeClient) ActionConfigFnType {
+	fakeConfigFn := NewFakeActionConfigFunction()
+	return func(log vzlog.VerrazzanoLogger, settings *cli.EnvSettings, namespace string) (*action.Configuration, error) {
+		actionConfig, err := fakeConfigFn(log, settings, namespace)
+		if err != nil {
+			return nil, err
+		}
+		actionConfig.KubeClient = kubeClient
+		return actionConfig, nil
+	}
+}
+
+// TestUpgradeRetry tests the retry of a failed Helm install
+// GIVEN an install that fails the first time with an "already exists" error
+//  WHEN I call Upgrade
+//  THEN the operation is retried and the failed release is upgraded
+func TestUpgradeRetry(t *testing.T) {
+	assert := assert.New(t)
+	retryDelay = 0
+	defer func() { retryDelay = 5 * time.Second }()
+	kubeClient := &retryKubeClient{
+		PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard},
+		createErr:          errors.New("configmaps \"my-config\" already exists"),
+	}
+	SetLoadChartFunction(loadChartWithTemplate)
+	defer SetDefaultLoadChartFunction()
+	SetActionConfigFunction(newRetryActionConfigFunction(kubeClient))
+	defer SetDefaultActionConfigFunction()
 
-	stdout, stderr, err := Upgrade(vzlog.DefaultLogger(), release, ns, chartdir, false, false, overrides)
+	rel, err := Upgrade(vzlog.DefaultLogger(), releaseName, ns, chartdir, false, false, nil)
 	assert.NoError(err, "Upgrade returned an error")
-	assert.Len(stderr, 0, "Upgrade stderr should be empty")
-	assert.NotZero(stdout, "Upgrade stdout should not be empty")
+	assert.Equal(2, rel.Version)
+	assert.Equal(release.StatusDeployed, rel.Info.Status)
+	assert.Equal(2, kubeClient.createCalls)
 }
 
-// TestUpgradeFail tests the Helm upgrade command failure condition
-// GIVEN a set of upgrade parameters and a fake runner that fails
+// TestUpgradeNoRetry tests a failed Helm install that is not retried
+// GIVEN an install that fails with an error that is not retryable
 //  WHEN I call Upgrade
-//  THEN the Helm upgrade returns an error
-func TestUpgradeFail(t *testing.T) {
-	var overrides []HelmOverrides
+//  THEN the install error is returned without a retry
+func TestUpgradeNoRetry(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(badRunner{t: t})
-	defer SetDefaultRunner()
+	retryDelay = 0
+	defer func() { retryDelay = 5 * time.Second }()
+	kubeClient := &retryKubeClient{
+		PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard},
+		createErr:          errors.New("forbidden"),
+	}
+	SetLoadChartFunction(loadChartWithTemplate)
+	defer SetDefaultLoadChartFunction()
+	SetActionConfigFunction(newRetryActionConfigFunction(kubeClient))
+	defer SetDefaultActionConfigFunction()
 
-	stdout, stderr, err := Upgrade(vzlog.DefaultLogger(), release, ns, "", false, false, overrides)
+	_, err := Upgrade(vzlog.DefaultLogger(), releaseName, ns, chartdir, false, false, nil)
 	assert.Error(err, "Upgrade should have returned an error")
-	assert.Len(stdout, 0, "Upgrade stdout should be empty")
-	assert.NotZero(stderr, "Upgrade stderr should not be empty")
+	var helmErr *HelmError
+	assert.True(errors.As(err, &helmErr))
+	assert.Equal(opInstall, helmErr.Operation)
+	assert.Equal(1, kubeClient.createCalls)
+
+	history, err := GetReleaseHistory(vzlog.DefaultLogger(), releaseName, ns, 0)
+	assert.NoError(err)
+	assert.Len(history, 1)
 }
 
 // TestUninstall tests the Helm Uninstall fn
-// GIVEN a call to Uninstall
-//  WHEN the command executes successfully
-//  THEN the function returns no error
+// GIVEN a deployed release
+//  WHEN I call Uninstall
+//  THEN the function returns no error and the release is removed
 func TestUninstall(t *testing.T) {
-	stdout := []byte{}
-	stdErr := []byte{}
+	SetActionConfigFunction(NewFakeActionConfigFunction(deployedRelease(nil)))
+	defer SetDefaultActionConfigFunction()
 
-	SetCmdRunner(genericTestRunner{
-		stdOut: stdout,
-		stdErr: stdErr,
-		err:    nil,
-	})
-	defer SetDefaultRunner()
-	_, _, err := Uninstall(vzlog.DefaultLogger(), "weblogic-operator", "verrazzano-system", false)
+	err := Uninstall(vzlog.DefaultLogger(), releaseName, ns, false)
+	assert.NoError(t, err)
+	found, err := IsReleaseInstalled(releaseName, ns)
 	assert.NoError(t, err)
+	assert.False(t, found)
 }
 
 // TestUninstallError tests the Helm Uninstall fn
-// GIVEN a call to Uninstall
-//  WHEN the command executes and returns an error
-//  THEN the function returns an error
+// GIVEN a release that does not exist
+//  WHEN I call Uninstall
+//  THEN the function returns a release not found error
 func TestUninstallError(t *testing.T) {
-	var stdout []byte
-	var stdErr []byte
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
 
-	SetCmdRunner(genericTestRunner{
-		stdOut: stdout,
-		stdErr: stdErr,
-		err:    fmt.Errorf("Unexpected uninstall error"),
-	})
-	defer SetDefaultRunner()
-	_, _, err := Uninstall(vzlog.DefaultLogger(), "weblogic-operator", "verrazzano-system", false)
+	err := Uninstall(vzlog.DefaultLogger(), "weblogic-operator", "verrazzano-system", false)
 	assert.Error(t, err)
+	assert.True(t, IsReleaseNotFound(err), "Expected a release not found error")
 }
 
 // TestIsReleaseInstalled tests checking if a Helm release is installed
@@ -179,10 +303,10 @@ func TestUninstallError(t *testing.T) {
 //  THEN the function returns success and found equal true
 func TestIsReleaseInstalled(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(foundRunner{t: t})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFakeActionConfigFunction(deployedRelease(nil)))
+	defer SetDefaultActionConfigFunction()
 
-	found, err := IsReleaseInstalled(release, ns)
+	found, err := IsReleaseInstalled(releaseName, ns)
 	assert.NoError(err, "IsReleaseInstalled returned an error")
 	assert.True(found, "Release not found")
 }
@@ -193,24 +317,28 @@ func TestIsReleaseInstalled(t *testing.T) {
 //  THEN the function returns success and the correct found status
 func TestIsReleaseNotInstalled(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(foundRunner{t: t})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFakeActionConfigFunction(deployedRelease(nil)))
+	defer SetDefaultActionConfigFunction()
 
 	found, err := IsReleaseInstalled(missingRelease, ns)
 	assert.NoError(err, "IsReleaseInstalled returned an error")
 	assert.False(found, "Release should not be found")
+
+	found, err = IsReleaseInstalled(releaseName, "other-namespace")
+	assert.NoError(err, "IsReleaseInstalled returned an error")
+	assert.False(found, "Release should not be found in another namespace")
 }
 
 // TestIsReleaseInstalledFailed tests failure when checking if a Helm release is installed
-// GIVEN a bad release name and namespace
-//  WHEN I call IsReleaseInstalled
+// GIVEN a release name and namespace
+//  WHEN I call IsReleaseInstalled and Helm returns an error
 //  THEN the function returns a failure
 func TestIsReleaseInstalledFailed(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(foundRunner{t: t})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFailingActionConfigFunction(errors.New("helm error")))
+	defer SetDefaultActionConfigFunction()
 
-	found, err := IsReleaseInstalled("", ns)
+	found, err := IsReleaseInstalled(releaseName, ns)
 	assert.Error(err, "IsReleaseInstalled should have returned an error")
 	assert.False(found, "Release should not be found")
 }
@@ -221,14 +349,12 @@ func TestIsReleaseInstalledFailed(t *testing.T) {
 //  THEN the function returns success and found equal true
 func TestIsReleaseDeployed(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(foundRunner{t: t})
-	defer SetDefaultRunner()
 	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return ChartStatusDeployed, nil
 	})
 	defer SetDefaultChartStatusFunction()
 
-	found, err := IsReleaseDeployed(release, ns)
+	found, err := IsReleaseDeployed(releaseName, ns)
 	assert.NoError(err, "IsReleaseInstalled returned an error")
 	assert.True(found, "Release not found")
 }
@@ -239,8 +365,6 @@ func TestIsReleaseDeployed(t *testing.T) {
 //  THEN the function returns success and the correct found status
 func TestIsReleaseNotDeployed(t *testing.T) {
 	assert := assert.New(t)
-	SetCmdRunner(foundRunner{t: t})
-	defer SetDefaultRunner()
 	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return ChartNotFound, nil
 	})
@@ -320,56 +444,24 @@ func TestIsReleaseFailedError(t *testing.T) {
 //  WHEN the chart state is deployed
 //  THEN the function returns ChartStatusDeployed and no error
 func Test_getReleaseStateDeployed(t *testing.T) {
-	jsonOut := []byte(`
-[
-  {
-    "name": "weblogic-operator",
-    "namespace": "verrazzano-system",
-    "revision": "1",
-    "updated": "2021-09-08 17:15:01.516374225 +0000 UTC",
-    "status": "deployed",
-    "chart": "weblogic-operator-3.3.0",
-    "app_version": "3.3.0"
-  }
-]
-`)
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFakeActionConfigFunction(
+		NewFakeRelease("weblogic-operator", "verrazzano-system", release.StatusDeployed, "3.3.0", nil)))
+	defer SetDefaultActionConfigFunction()
+
 	state, err := getReleaseState("weblogic-operator", "verrazzano-system")
 	assert.NoError(t, err)
 	assert.Equalf(t, ChartStatusDeployed, state, "unpexected state: %s", state)
 }
 
-// Test_getReleaseStateDeployed tests the getReleaseState fn
+// Test_getReleaseStatePendingInstall tests the getReleaseState fn
 // GIVEN a call to getReleaseState
 //  WHEN the chart state is pending-install
 //  THEN the function returns ChartStatusPendingInstall and no error
 func Test_getReleaseStatePendingInstall(t *testing.T) {
-	jsonOut := []byte(`
-[
-  {
-    "name": "weblogic-operator",
-    "namespace": "verrazzano-system",
-    "revision": "1",
-    "updated": "2021-09-08 17:15:01.516374225 +0000 UTC",
-    "status": "pending-install",
-    "chart": "weblogic-operator-3.3.0",
-    "app_version": "3.3.0"
-  }
-]
-`)
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFakeActionConfigFunction(
+		NewFakeRelease("weblogic-operator", "verrazzano-system", release.StatusPendingInstall, "3.3.0", nil)))
+	defer SetDefaultActionConfigFunction()
+
 	state, err := getReleaseState("weblogic-operator", "verrazzano-system")
 	assert.NoError(t, err)
 	assert.Equalf(t, ChartStatusPendingInstall, state, "unpexected state: %s", state)
@@ -378,19 +470,14 @@ func Test_getReleaseStatePendingInstall(t *testing.T) {
 // Test_getReleaseStateChartNotFound tests the getReleaseState fn
 // GIVEN a call to getReleaseState
 //  WHEN the chart/release can not be found
-//  THEN the function returns "" and no error
+//  THEN the function returns ChartNotFound and no error
 func Test_getReleaseStateChartNotFound(t *testing.T) {
-	jsonOut := []byte(`[]`)
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
 
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
 	state, err := getReleaseState("weblogic-operator", "verrazzano-system")
 	assert.NoError(t, err)
-	assert.Equalf(t, "", state, "unpexected state: %s", state)
+	assert.Equalf(t, ChartNotFound, state, "unpexected state: %s", state)
 }
 
 // Test_getChartStatusDeployed tests the getChartStatus fn
@@ -398,108 +485,23 @@ func Test_getReleaseStateChartNotFound(t *testing.T) {
 //  WHEN Helm returns a deployed state
 //  THEN the function returns "deployed" and no error
 func Test_getChartStatusDeployed(t *testing.T) {
-	jsonOut := []byte(`
-{
-  "name": "weblogic-operator",
-  "info": {
-    "first_deployed": "2021-09-08T17:15:01.516374225Z",
-    "last_deployed": "2021-09-08T17:15:01.516374225Z",
-    "deleted": "",
-    "description": "Install complete",
-    "status": "deployed"
-  },
-  "config": {
-    "annotations": {
-      "traffic.sidecar.istio.io/excludeOutboundPorts": "443"
-    },
-    "domainNamespaceLabelSelector": "verrazzano-managed",
-    "domainNamespaceSelectionStrategy": "LabelSelector",
-    "enableClusterRoleBinding": true,
-    "image": "ghcr.io/oracle/weblogic-kubernetes-operator:3.3.0",
-    "imagePullSecrets": [
-      {
-        "name": "verrazzano-container-registry"
-      }
-    ],
-    "serviceAccount": "weblogic-operator-sa"
-  },
-  "manifest": "manifest-text",
-  "version": 1,
-  "namespace": "verrazzano-system"
-}
-`)
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFakeActionConfigFunction(
+		NewFakeRelease("weblogic-operator", "verrazzano-system", release.StatusDeployed, "3.3.0", nil)))
+	defer SetDefaultActionConfigFunction()
+
 	state, err := getChartStatus("weblogic-operator", "verrazzano-system")
 	assert.NoError(t, err)
 	assert.Equalf(t, ChartStatusDeployed, state, "unpexected state: %s", state)
 }
 
-// Test_getChartStatusNotFound tests the getChartStatus fn
-// GIVEN a call to getChartStatus
-//  WHEN the json structure does not have an status filed in the info section
-//  THEN the function returns an error
-func Test_getChartStatusNotFound(t *testing.T) {
-	jsonOut := []byte(`
-{
-  "name": "weblogic-operator",
-  "info": {
-    "first_deployed": "2021-09-08T17:15:01.516374225Z",
-    "last_deployed": "2021-09-08T17:15:01.516374225Z",
-    "deleted": "",
-    "description": "Install complete",
-  },
-  "config": {
-    "annotations": {
-      "traffic.sidecar.istio.io/excludeOutboundPorts": "443"
-    },
-    "domainNamespaceLabelSelector": "verrazzano-managed",
-    "domainNamespaceSelectionStrategy": "LabelSelector",
-    "enableClusterRoleBinding": true,
-    "image": "ghcr.io/oracle/weblogic-kubernetes-operator:3.3.0",
-    "imagePullSecrets": [
-      {
-        "name": "verrazzano-container-registry"
-      }
-    ],
-    "serviceAccount": "weblogic-operator-sa"
-  },
-  "manifest": "manifest-text",
-  "version": 1,
-  "namespace": "verrazzano-system"
-}
-`)
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
-	state, err := getChartStatus("weblogic-operator", "verrazzano-system")
-	assert.Error(t, err)
-	assert.Empty(t, state)
-}
-
 // Test_getChartStatusChartNotFound tests the getChartStatus fn
 // GIVEN a call to getChartStatus
 //  WHEN the Chart is not found
 //  THEN the function returns chart not found and no error
 func Test_getChartStatusChartNotFound(t *testing.T) {
-	stdout := []byte{}
-	stdErr := []byte("Error: release: not found")
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
 
-	SetCmdRunner(genericTestRunner{
-		stdOut: stdout,
-		stdErr: stdErr,
-		err:    fmt.Errorf("Error running status command"),
-	})
-	defer SetDefaultRunner()
 	state, err := getChartStatus("weblogic-operator", "verrazzano-system")
 	assert.NoError(t, err)
 	assert.Equalf(t, ChartNotFound, state, "unpexected state: %s", state)
@@ -510,85 +512,29 @@ func Test_getChartStatusChartNotFound(t *testing.T) {
 //  WHEN Helm returns an error
 //  THEN the function returns an error
 func Test_getChartStatusUnexpectedHelmError(t *testing.T) {
-	var stdout []byte
-	stdErr := []byte("Some unknown helm status error")
+	SetActionConfigFunction(NewFailingActionConfigFunction(fmt.Errorf("Unexpected error getting release status")))
+	defer SetDefaultActionConfigFunction()
 
-	SetCmdRunner(genericTestRunner{
-		stdOut: stdout,
-		stdErr: stdErr,
-		err:    fmt.Errorf("Unexpected error running status command"),
-	})
-	defer SetDefaultRunner()
 	state, err := getChartStatus("weblogic-operator", "verrazzano-system")
 	assert.Error(t, err)
+	assert.False(t, IsReleaseNotFound(err))
 	assert.Equalf(t, "", state, "unpexected state: %s", state)
 }
 
-// Test_getChartInfoNotFound tests the getChartStatus fn
-// GIVEN a call to getChartStatus
-//  WHEN the json structure does not have an info section
-//  THEN the function returns an error
-func Test_getChartInfoNotFound(t *testing.T) {
-	jsonOut := []byte(`
-{
-  "name": "weblogic-operator",
-  "config": {
-    "annotations": {
-      "traffic.sidecar.istio.io/excludeOutboundPorts": "443"
-    },
-    "domainNamespaceLabelSelector": "verrazzano-managed",
-    "domainNamespaceSelectionStrategy": "LabelSelector",
-    "enableClusterRoleBinding": true,
-    "image": "ghcr.io/oracle/weblogic-kubernetes-operator:3.3.0",
-    "imagePullSecrets": [
-      {
-        "name": "verrazzano-container-registry"
-      }
-    ],
-    "serviceAccount": "weblogic-operator-sa"
-  },
-  "manifest": "manifest-text",
-  "version": 1,
-  "namespace": "verrazzano-system"
-}
-`)
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
-	state, err := getChartStatus("weblogic-operator", "verrazzano-system")
-	assert.Error(t, err)
-	assert.Empty(t, state)
-}
-
 // TestGetReleaseValue tests the GetReleaseValues fn
 // GIVEN a call to GetReleaseValues
 //  WHEN a valid helm release and namespace are deployed
 //  THEN the function returns the value/true/nil if the helm key exists, or ""/false/nil if it doesn't
 func TestGetReleaseValue(t *testing.T) {
-	jsonOut := []byte(`
-{
-  "domainFilters": [
-    "my.domain.io"
-  ],
-  "triggerLoopOnEvent": true,
-  "txtOwnerId": "v8o-default-my-verrazzano-3201314693",
-  "txtPrefix": "_v8o-default-my-verrazzano-3201314693-",
-  "zoneIDFilters": [
-    "ocid1.dns-zone.oc1..blahblahblah"
-  ]
-}
-`)
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
+	SetActionConfigFunction(NewFakeActionConfigFunction(
+		NewFakeRelease("external-dns", "cert-manager", release.StatusDeployed, "0.7.6", map[string]interface{}{
+			"domainFilters":      []interface{}{"my.domain.io"},
+			"triggerLoopOnEvent": true,
+			"txtOwnerId":         "v8o-default-my-verrazzano-3201314693",
+			"txtPrefix":          "_v8o-default-my-verrazzano-3201314693-",
+			"zoneIDFilters":      []interface{}{"ocid1.dns-zone.oc1..blahblahblah"},
+		})))
+	defer SetDefaultActionConfigFunction()
 
 	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return ChartStatusDeployed, nil
@@ -611,31 +557,15 @@ func TestGetReleaseValue(t *testing.T) {
 //  WHEN a valid helm release and namespace are deployed
 //  THEN the function returns the value/true/nil if the helm key exists, or ""/false/nil if it doesn't
 func TestGetReleaseStringValue(t *testing.T) {
-	jsonOut := []byte(`
-{
-  "domainFilters": [
-    "my.domain.io"
-  ],
-  "triggerLoopOnEvent": true,
-  "txtOwnerId": "v8o-default-my-verrazzano-3201314693",
-  "txtPrefix": "_v8o-default-my-verrazzano-3201314693-",
-  "zoneIDFilters": [
-    "ocid1.dns-zone.oc1..blahblahblah"
-  ]
-}
-`)
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer SetDefaultRunner()
-
-	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
-		return ChartStatusDeployed, nil
-	})
-	defer SetDefaultChartStatusFunction()
+	SetActionConfigFunction(NewFakeActionConfigFunction(
+		NewFakeRelease("external-dns", "cert-manager", release.StatusDeployed, "0.7.6", map[string]interface{}{
+			"domainFilters":      []interface{}{"my.domain.io"},
+			"triggerLoopOnEvent": true,
+			"txtOwnerId":         "v8o-default-my-verrazzano-3201314693",
+			"txtPrefix":          "_v8o-default-my-verrazzano-3201314693-",
+			"zoneIDFilters":      []interface{}{"ocid1.dns-zone.oc1..blahblahblah"},
+		})))
+	defer SetDefaultActionConfigFunction()
 
 	keys := []string{"txtOwnerId", "zoneIDFilters", "foo"}
 	value, err := GetReleaseStringValues(vzlog.DefaultLogger(), keys, "external-dns", "cert-manager")
@@ -653,18 +583,8 @@ func TestGetReleaseStringValue(t *testing.T) {
 //  WHEN a the helm release is NOT deployed
 //  THEN the function returns the value/true/nil if the helm key exists, or ""/false/nil if it doesn't
 func TestGetReleaseValueReleaseNotFound(t *testing.T) {
-
-	SetCmdRunner(genericTestRunner{
-		stdOut: []byte{},
-		stdErr: []byte("Chart not found"),
-		err:    nil,
-	})
-	defer SetDefaultRunner()
-
-	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
-		return ChartNotFound, nil
-	})
-	defer SetDefaultChartStatusFunction()
+	SetActionConfigFunction(NewFakeActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
 
 	keys := []string{"txtOwnerId", "external-dns"}
 	values, err := GetReleaseValues(vzlog.DefaultLogger(), keys, "external-dns", "cert-manager")
@@ -675,6 +595,7 @@ func TestGetReleaseValueReleaseNotFound(t *testing.T) {
 	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return ChartNotFound, expectedErr
 	})
+	defer SetDefaultChartStatusFunction()
 	values, helmErr := GetReleaseValues(vzlog.DefaultLogger(), keys, "external-dns", "cert-manager")
 	assert.NoErrorf(t, err, "Unexpected error: %v", err)
 	assert.Equal(t, map[string]interface{}{}, values, "Found unexpected release values")
@@ -682,117 +603,15 @@ func TestGetReleaseValueReleaseNotFound(t *testing.T) {
 	assert.Equal(t, expectedErr, helmErr)
 }
 
-// Test_maskSensitiveData tests the maskSensitiveData function
-func Test_maskSensitiveData(t *testing.T) {
-	// GIVEN a string with sensitive data
-	// WHEN the maskSensitiveData function is called
-	// THEN the returned string has sensitive values masked
-	str := `Running command: /usr/bin/helm upgrade mysql /verrazzano/platform-operator/thirdparty/charts/mysql
-		--wait --namespace keycloak --install -f /verrazzano/platform-operator/helm_config/overrides/mysql-values.yaml
-		-f /tmp/values-145495151.yaml
-		--set imageTag=8.0.26,image=ghcr.io/verrazzano/mysql,mysqlPassword=BgD2SBNaGm,mysqlRootPassword=ydqtBpasQ4`
-	expected := `Running command: /usr/bin/helm upgrade mysql /verrazzano/platform-operator/thirdparty/charts/mysql
-		--wait --namespace keycloak --install -f /verrazzano/platform-operator/helm_config/overrides/mysql-values.yaml
-		-f /tmp/values-145495151.yaml
-		--set imageTag=8.0.26,image=ghcr.io/verrazzano/mysql,mysqlPassword=*****,mysqlRootPassword=*****`
-	maskedStr := maskSensitiveData(str)
-	assert.Equal(t, expected, maskedStr)
-
-	// GIVEN a string without sensitive data
-	// WHEN the maskSensitiveData function is called
-	// THEN the returned string is unaltered
-	str = `Running command: /usr/bin/helm upgrade ingress-controller /verrazzano/platform-operator/thirdparty/charts/ingress-nginx
-		--wait --namespace ingress-nginx --install -f /verrazzano/platform-operator/helm_config/overrides/ingress-nginx-values.yaml
-		-f /tmp/values-037653479.yaml --set controller.image.tag=0.46.0-20211005200943-bd017fde2,
-		controller.image.repository=ghcr.io/verrazzano/nginx-ingress-controller,
-		defaultBackend.image.tag=0.46.0-20211005200943-bd017fde2,
-		defaultBackend.image.repository=ghcr.io/verrazzano/nginx-ingress-default-backend,controller.service.type=LoadBalancer`
-	maskedStr = maskSensitiveData(str)
-	assert.Equal(t, str, maskedStr)
-}
-
-// Run should assert the command parameters are correct then return a success with stdout contents
-func (r upgradeRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	assert := assert.New(r.t)
-	argIndex := 0
-	assert.Contains(cmd.Path, "helm", "command should contain helm")
-	assert.Contains(cmd.Args[argIndex], "helm", "args should contain helm")
-	argIndex++
-	assert.Contains(cmd.Args[argIndex], "upgrade", "args should contain upgrade")
-	argIndex++
-	assert.Contains(cmd.Args[argIndex], release, "args should contain release name")
-	argIndex++
-	assert.Contains(cmd.Args[argIndex], chartdir, "args should contain chart dir ")
-	argIndex++
-
-	for _, arg := range r.expectedArgs {
-		assert.Contains(cmd.Args[argIndex], arg)
-		argIndex++
-	}
-	return []byte("success"), []byte(""), nil
-}
-
-// Run should assert the command parameters are correct then return a success with stdout contents
-func (r getValuesRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	assert := assert.New(r.t)
-	assert.Contains(cmd.Path, "helm", "command should contain helm")
-	assert.Contains(cmd.Args[0], "helm", "args should contain helm")
-	assert.Contains(cmd.Args[1], "get", "args should contain get")
-	assert.Contains(cmd.Args[2], "values", "args should contain get")
-	assert.Contains(cmd.Args[3], release, "args should contain release name")
-	return []byte("success"), []byte(""), nil
-}
-
-// Run should return an error with stderr contents
-func (r badRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return []byte(""), []byte("error"), errors.New("error")
-}
-
-// Run should assert the command parameters are correct then return a success or error depending on release name
-func (r foundRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	assert := assert.New(r.t)
-	assert.Contains(cmd.Path, "helm", "command should contain helm")
-	assert.Contains(cmd.Args[0], "helm", "args should contain helm")
-	assert.Contains(cmd.Args[1], "status", "args should contain status")
-
-	if cmd.Args[2] == release {
-		return []byte(""), []byte(""), nil
-	}
-	if cmd.Args[2] == missingRelease {
-		return []byte(""), []byte("not found"), errors.New("not found error")
-	}
-	// simulate a Helm error
-	return []byte(""), []byte("error"), errors.New("helm error")
-}
-
 // Test_GetReleaseAppVersion tests the GetReleaseAppVersion function
 // GIVEN a call to GetReleaseAppVersion
 //  WHEN varying the inputs and underlying status
 //  THEN test the expected result is returned
 func Test_GetReleaseAppVersion(t *testing.T) {
-	jsonSuccess := []byte(`
-[
-  {
-    "name": "verrazzano",
-    "namespace": "verrazzano-system",
-    "app_version": "1"
-  }
-]
-`)
-
-	jsonFail := []byte(`
-[
-  {
-    "name": "unknown",
-    "namespace": "verrazzano-system",
-    "app_version": "1"
-  }
-]
-`)
 	type args struct {
 		releaseName string
 		namespace   string
-		jsonOut     []byte
+		releases    []*release.Release
 	}
 	tests := []struct {
 		name    string
@@ -806,7 +625,7 @@ func Test_GetReleaseAppVersion(t *testing.T) {
 			args: args{
 				releaseName: "verrazzano",
 				namespace:   "verrazzano-system",
-				jsonOut:     jsonSuccess,
+				releases:    []*release.Release{NewFakeRelease("verrazzano", "verrazzano-system", release.StatusDeployed, "1", nil)},
 			},
 			wantErr: false,
 		},
@@ -816,19 +635,25 @@ func Test_GetReleaseAppVersion(t *testing.T) {
 			args: args{
 				releaseName: "verrazzano",
 				namespace:   "verrazzano-system",
-				jsonOut:     jsonFail,
+				releases:    []*release.Release{NewFakeRelease("verrazzano", "verrazzano-system", release.StatusDeployed, "", nil)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test GetReleaseAppVersion when the release does not exist",
+			want: ChartNotFound,
+			args: args{
+				releaseName: "verrazzano",
+				namespace:   "verrazzano-system",
+				releases:    []*release.Release{NewFakeRelease("unknown", "verrazzano-system", release.StatusDeployed, "1", nil)},
 			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			SetCmdRunner(genericTestRunner{
-				stdOut: tt.args.jsonOut,
-				stdErr: []byte{},
-				err:    nil,
-			})
-			defer SetDefaultRunner()
+			SetActionConfigFunction(NewFakeActionConfigFunction(tt.args.releases...))
+			defer SetDefaultActionConfigFunction()
 			got, err := GetReleaseAppVersion(tt.args.releaseName, tt.args.namespace)
 			if !tt.wantErr {
 				assert.NoError(t, err)
@@ -839,3 +664,32 @@ func Test_GetReleaseAppVersion(t *testing.T) {
 		})
 	}
 }
+
+// TestGetReleaseHistory tests the GetReleaseHistory function
+// GIVEN a release with several revisions
+//  WHEN I call GetReleaseHistory with a maximum number of revisions
+//  THEN the newest revisions are returned first
+func TestGetReleaseHistory(t *testing.T) {
+	assert := assert.New(t)
+	rel1 := NewFakeRelease(releaseName, ns, release.StatusSuperseded, "1.0.0", nil)
+	rel2 := NewFakeRelease(releaseName, ns, release.StatusSuperseded, "1.0.0", nil)
+	rel2.Version = 2
+	rel3 := NewFakeRelease(releaseName, ns, release.StatusFailed, "1.1.0", nil)
+	rel3.Version = 3
+	SetActionConfigFunction(NewFakeActionConfigFunction(rel1, rel3, rel2))
+	defer SetDefaultActionConfigFunction()
+
+	history, err := GetReleaseHistory(vzlog.DefaultLogger(), releaseName, ns, 2)
+	assert.NoError(err)
+	assert.Len(history, 2)
+	assert.Equal(3, history[0].Version)
+	assert.Equal(release.StatusFailed, history[0].Info.Status)
+	assert.Equal(2, history[1].Version)
+
+	history, err = GetReleaseHistory(vzlog.DefaultLogger(), releaseName, ns, 0)
+	assert.NoError(err)
+	assert.Len(history, 3)
+
+	_, err = GetReleaseHistory(vzlog.DefaultLogger(), missingRelease, ns, 0)
+	assert.True(IsReleaseNotFound(err), "Expected a release not found error")
+}
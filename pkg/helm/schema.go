@@ -0,0 +1,326 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// globalValuesKey is the top-level key Helm shares with all subcharts
+	globalValuesKey = "global"
+
+	// rootField is the field gojsonschema reports for errors on the values document itself
+	rootField = "(root)"
+
+	// mergedValuesSource is reported when no override source sets the invalid value
+	mergedValuesSource = "merged values"
+)
+
+// OverrideSource is a YAML document of Helm values along with a description of where it came from,
+// for example "ConfigMap my-overrides key values.yaml"
+type OverrideSource struct {
+	Source string
+	Values string
+}
+
+// parsedSource is an OverrideSource that has been unmarshalled
+type parsedSource struct {
+	source string
+	values map[string]interface{}
+}
+
+// ValuesSchema is the JSON schema used to validate the values of a chart
+type ValuesSchema struct {
+	chart  *chart.Chart
+	schema []byte
+}
+
+// LoadValuesSchema loads the values.schema.json of the chart in chartDir, or schemaFile when the chart does not have
+// one.  Nil is returned if the chart does not exist or there is no schema, in which case overrides are not validated.
+func LoadValuesSchema(chartDir string, schemaFile string) (*ValuesSchema, error) {
+	if _, err := os.Stat(chartDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	ch, err := loadChartFn(chartDir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading chart %s: %v", chartDir, err)
+	}
+	schema := ch.Schema
+	if len(schema) == 0 && schemaFile != "" {
+		schema, err = ioutil.ReadFile(schemaFile)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("Failed reading values schema %s: %v", schemaFile, err)
+		}
+	}
+	if len(schema) == 0 {
+		return nil, nil
+	}
+	return &ValuesSchema{chart: ch, schema: schema}, nil
+}
+
+// NewValuesSchema returns the values schema of a chart that is not available, for example to validate overrides
+// outside of the platform operator.  Unknown top-level keys are only reported if the schema disallows additional
+// properties, since the chart values are not known.
+func NewValuesSchema(chartName string, schema []byte) *ValuesSchema {
+	return &ValuesSchema{
+		chart:  &chart.Chart{Metadata: &chart.Metadata{Name: chartName}},
+		schema: schema,
+	}
+}
+
+// NewValuesSchemaWithValues returns the values schema of a chart that is not available along with the default values
+// of the chart, so that the overrides are validated against the same merged values as when the chart is available.
+func NewValuesSchemaWithValues(chartName string, values []byte, schema []byte) (*ValuesSchema, error) {
+	chartValues, err := chartutil.ReadValues(values)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading the values of chart %s: %v", chartName, err)
+	}
+	return &ValuesSchema{
+		chart:  &chart.Chart{Metadata: &chart.Metadata{Name: chartName}, Values: chartValues},
+		schema: schema,
+	}, nil
+}
+
+// ValidateOverrides validates Helm value overrides against the values schema.  The overrides are ordered highest
+// precedence first, and any unknown top-level key or schema violation is reported against the override source
+// that set it.
+func (s *ValuesSchema) ValidateOverrides(overrides []OverrideSource) error {
+	return s.ValidateOverridesUpdate(nil, overrides)
+}
+
+// ValidateOverridesUpdate validates updated Helm value overrides against the values schema, the same as
+// ValidateOverrides.  Problems caused by an override source with the same values as one of the old overrides are
+// not reported, so that overrides that were already accepted do not block an update.
+func (s *ValuesSchema) ValidateOverridesUpdate(oldOverrides []OverrideSource, overrides []OverrideSource) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	unchanged := map[string]bool{}
+	for _, override := range oldOverrides {
+		unchanged[override.Values] = true
+	}
+	changed := map[string]bool{}
+	for _, override := range overrides {
+		if !unchanged[override.Values] {
+			changed[override.Source] = true
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	sources, err := parseOverrideSources(overrides)
+	if err != nil {
+		return err
+	}
+	var msgs []string
+	knownKeys, err := getKnownTopLevelKeys(s.chart, s.schema)
+	if err != nil {
+		return fmt.Errorf("Failed reading values schema for chart %s: %v", s.chart.Name(), err)
+	}
+	for _, src := range sources {
+		for _, key := range sortedKeys(src.values) {
+			if !knownKeys[key] && changed[src.source] {
+				msgs = append(msgs, fmt.Sprintf("%s: unknown top-level key \"%s\"", src.source, key))
+			}
+		}
+	}
+
+	schemaErrors, err := validateAgainstSchema(s.schema, mergeSources(sources, s.chart.Values))
+	if err != nil {
+		return fmt.Errorf("Failed validating overrides against the values schema for chart %s: %v", s.chart.Name(), err)
+	}
+	for _, schemaErr := range schemaErrors {
+		field := schemaErr.Field()
+		if schemaErr.Type() == "additional_property_not_allowed" {
+			if field == rootField {
+				// Unknown top-level keys have already been reported
+				continue
+			}
+			field = fmt.Sprintf("%s.%v", field, schemaErr.Details()["property"])
+		}
+		source := findSource(sources, field)
+		if !changed[source] && (source != mergedValuesSource || len(oldOverrides) > 0) {
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("%s: %s: %s", source, field, schemaErr.Description()))
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("Install overrides for chart %s are not valid: %s", s.chart.Name(), strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// parseOverrideSources unmarshals the YAML of each override source
+func parseOverrideSources(overrides []OverrideSource) ([]parsedSource, error) {
+	var sources []parsedSource
+	for _, override := range overrides {
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(override.Values), &values); err != nil {
+			return nil, fmt.Errorf("%s: invalid YAML: %v", override.Source, err)
+		}
+		sources = append(sources, parsedSource{source: override.Source, values: values})
+	}
+	return sources, nil
+}
+
+// getKnownTopLevelKeys returns the top-level keys that are allowed in the chart values.  If the schema does not
+// disallow additional properties, the keys in the chart values.yaml, the chart dependencies and the Helm global
+// values are allowed in addition to the schema properties.
+func getKnownTopLevelKeys(ch *chart.Chart, schema []byte) (map[string]bool, error) {
+	schemaMap := map[string]interface{}{}
+	if err := json.Unmarshal(schema, &schemaMap); err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	if props, ok := schemaMap["properties"].(map[string]interface{}); ok {
+		for key := range props {
+			known[key] = true
+		}
+	}
+	if additional, ok := schemaMap["additionalProperties"].(bool); ok && !additional {
+		return known, nil
+	}
+	for key := range ch.Values {
+		known[key] = true
+	}
+	if ch.Metadata != nil {
+		for _, dep := range ch.Metadata.Dependencies {
+			known[dep.Name] = true
+			if dep.Alias != "" {
+				known[dep.Alias] = true
+			}
+		}
+	}
+	for _, dep := range ch.Dependencies() {
+		known[dep.Name()] = true
+	}
+	known[globalValuesKey] = true
+	return known, nil
+}
+
+// mergeSources merges the override sources, highest precedence first, on top of the chart default values.  A null
+// override removes the chart default, the same as Helm.
+func mergeSources(sources []parsedSource, chartValues map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for i := len(sources) - 1; i >= 0; i-- {
+		merged = mergeMaps(merged, copyValues(sources[i].values))
+	}
+	return coalesceValues(merged, copyValues(chartValues))
+}
+
+// mergeMaps merges src into dst, values in src take precedence
+func mergeMaps(dst map[string]interface{}, src map[string]interface{}) map[string]interface{} {
+	for key, val := range src {
+		srcMap, srcOK := val.(map[string]interface{})
+		dstMap, dstOK := dst[key].(map[string]interface{})
+		if srcOK && dstOK {
+			dst[key] = mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = val
+	}
+	return dst
+}
+
+// coalesceValues fills in the default values that are not set in dst, removing the keys that dst sets to null
+func coalesceValues(dst map[string]interface{}, defaults map[string]interface{}) map[string]interface{} {
+	for key, def := range defaults {
+		val, ok := dst[key]
+		if !ok {
+			dst[key] = def
+			continue
+		}
+		if val == nil {
+			delete(dst, key)
+			continue
+		}
+		valMap, valOK := val.(map[string]interface{})
+		defMap, defOK := def.(map[string]interface{})
+		if valOK && defOK {
+			dst[key] = coalesceValues(valMap, defMap)
+		}
+	}
+	return dst
+}
+
+// validateAgainstSchema validates the values against the JSON schema and returns the schema violations
+func validateAgainstSchema(schema []byte, values map[string]interface{}) ([]gojsonschema.ResultError, error) {
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(valuesJSON))
+	if err != nil {
+		return nil, err
+	}
+	return result.Errors(), nil
+}
+
+// findSource returns the highest precedence override source that sets the field, which is a dot separated
+// path as reported by gojsonschema
+func findSource(sources []parsedSource, field string) string {
+	if field == rootField {
+		return mergedValuesSource
+	}
+	path := strings.Split(field, ".")
+	for _, src := range sources {
+		if hasPath(src.values, path) {
+			return src.source
+		}
+	}
+	return mergedValuesSource
+}
+
+// hasPath returns true if the path is set in the values
+func hasPath(values interface{}, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+	switch v := values.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		return ok && hasPath(child, path[1:])
+	case []interface{}:
+		var i int
+		if _, err := fmt.Sscanf(path[0], "%d", &i); err != nil || i < 0 || i >= len(v) {
+			return false
+		}
+		return hasPath(v[i], path[1:])
+	}
+	return false
+}
+
+// copyValues returns a deep copy of the values, since merging modifies the maps
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	copied := map[string]interface{}{}
+	for key, val := range values {
+		if m, ok := val.(map[string]interface{}); ok {
+			copied[key] = copyValues(m)
+			continue
+		}
+		copied[key] = val
+	}
+	return copied
+}
+
+// sortedKeys returns the keys of the map in sorted order so errors are reported consistently
+func sortedKeys(values map[string]interface{}) []string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
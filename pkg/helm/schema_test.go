@@ -0,0 +1,192 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	schemaChartDir   = "./testdata/schemachart"
+	noSchemaChartDir = "./testdata"
+	testSchemaFile   = "./testdata/test.schema.json"
+)
+
+// validateOverrides loads the values schema and validates the overrides
+func validateOverrides(t *testing.T, chartDir string, schemaFile string, overrides []OverrideSource) error {
+	schema, err := LoadValuesSchema(chartDir, schemaFile)
+	assert.NoError(t, err)
+	assert.NotNil(t, schema)
+	return schema.ValidateOverrides(overrides)
+}
+
+// TestValidateOverrides tests the ValidateOverrides function
+// GIVEN overrides that are valid for the chart values schema
+// WHEN ValidateOverrides is called
+// THEN no error is returned
+func TestValidateOverrides(t *testing.T) {
+	overrides := []OverrideSource{
+		{Source: "ConfigMap test-cm key values.yaml", Values: "replicas: 3\nimage:\n  tag: 1.0.1\n"},
+		{Source: "values[1]", Values: "resources:\n  limits:\n    cpu: 1\nnodeSelector:\n  disk: ssd\nglobal:\n  foo: bar\n"},
+	}
+	assert.NoError(t, validateOverrides(t, schemaChartDir, "", overrides))
+}
+
+// TestValidateOverridesUnknownKey tests the ValidateOverrides function
+// GIVEN overrides with a top-level key that is not in the chart values or schema
+// WHEN ValidateOverrides is called
+// THEN an error is returned that names the override source and the key
+func TestValidateOverridesUnknownKey(t *testing.T) {
+	overrides := []OverrideSource{
+		{Source: "values[0]", Values: "replicas: 3\n"},
+		{Source: "Secret test-secret key overrides", Values: "replicaCount: 3\n"},
+	}
+	err := validateOverrides(t, schemaChartDir, "", overrides)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Secret test-secret key overrides: unknown top-level key \"replicaCount\"")
+	assert.NotContains(t, err.Error(), "values[0]")
+}
+
+// TestValidateOverridesTypeMismatch tests the ValidateOverrides function
+// GIVEN overrides where a value has the wrong type
+// WHEN ValidateOverrides is called
+// THEN an error is returned that names the highest precedence override source that set the value
+func TestValidateOverridesTypeMismatch(t *testing.T) {
+	overrides := []OverrideSource{
+		{Source: "values[0]", Values: "image:\n  tag: 2\n"},
+		{Source: "values[1]", Values: "replicas: three\nimage:\n  tag: \"1.0.1\"\n"},
+	}
+	err := validateOverrides(t, schemaChartDir, "", overrides)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "values[0]: image.tag: Invalid type. Expected: string, given: integer")
+	assert.Contains(t, err.Error(), "values[1]: replicas: Invalid type. Expected: integer, given: string")
+}
+
+// TestValidateOverridesNullRemovesDefault tests the ValidateOverrides function
+// GIVEN overrides that set a required value to null
+// WHEN ValidateOverrides is called
+// THEN an error is returned because the chart default is removed
+func TestValidateOverridesNullRemovesDefault(t *testing.T) {
+	overrides := []OverrideSource{
+		{Source: "values[0]", Values: "image:\n  repository: null\n"},
+	}
+	err := validateOverrides(t, schemaChartDir, "", overrides)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "repository is required")
+}
+
+// TestValidateOverridesSchemaFile tests the ValidateOverrides function
+// GIVEN a chart without a values schema and a Verrazzano provided schema file
+// WHEN ValidateOverrides is called
+// THEN the overrides are validated against the schema file
+func TestValidateOverridesSchemaFile(t *testing.T) {
+	assert.NoError(t, validateOverrides(t, noSchemaChartDir, testSchemaFile, []OverrideSource{
+		{Source: "values[0]", Values: "name: test\nport: 8080\n"},
+	}))
+
+	err := validateOverrides(t, noSchemaChartDir, testSchemaFile, []OverrideSource{
+		{Source: "values[0]", Values: "name: test\nport: \"8080\"\nother: true\n"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "values[0]: unknown top-level key \"other\"")
+	assert.Contains(t, err.Error(), "values[0]: port: Invalid type. Expected: integer, given: string")
+}
+
+// TestLoadValuesSchemaNoSchema tests the LoadValuesSchema function
+// GIVEN a chart without a values schema and no schema file, or a chart that does not exist
+// WHEN LoadValuesSchema is called
+// THEN no schema is returned so validation is skipped
+func TestLoadValuesSchemaNoSchema(t *testing.T) {
+	for _, dirs := range [][]string{
+		{noSchemaChartDir, ""},
+		{noSchemaChartDir, "./testdata/missing.schema.json"},
+		{"./testdata/missing", testSchemaFile},
+	} {
+		schema, err := LoadValuesSchema(dirs[0], dirs[1])
+		assert.NoError(t, err)
+		assert.Nil(t, schema)
+	}
+}
+
+// TestValidateOverridesInvalidYAML tests the ValidateOverrides function
+// GIVEN an override that is not valid YAML
+// WHEN ValidateOverrides is called
+// THEN an error is returned that names the override source
+func TestValidateOverridesInvalidYAML(t *testing.T) {
+	err := validateOverrides(t, schemaChartDir, "", []OverrideSource{
+		{Source: "ConfigMap test-cm key values.yaml", Values: "replicas: [3\n"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ConfigMap test-cm key values.yaml: invalid YAML")
+}
+
+// TestValidateOverridesUpdate tests the ValidateOverridesUpdate function
+// GIVEN old overrides that do not match the values schema and updated overrides
+// WHEN ValidateOverridesUpdate is called
+// THEN only the problems caused by the overrides that changed are reported
+func TestValidateOverridesUpdate(t *testing.T) {
+	schema, err := LoadValuesSchema(schemaChartDir, "")
+	assert.NoError(t, err)
+	invalid := OverrideSource{Source: "values[0]", Values: "replicaCount: 3\n"}
+	oldOverrides := []OverrideSource{invalid}
+
+	// the invalid override was already accepted and is not changed
+	assert.NoError(t, schema.ValidateOverridesUpdate(oldOverrides, []OverrideSource{
+		invalid,
+		{Source: "values[1]", Values: "replicas: 3\n"},
+	}))
+
+	// the new override is not valid
+	err = schema.ValidateOverridesUpdate(oldOverrides, []OverrideSource{
+		invalid,
+		{Source: "values[1]", Values: "replicas: three\n"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "values[1]: replicas: Invalid type")
+	assert.NotContains(t, err.Error(), "replicaCount")
+}
+
+// TestNewValuesSchema tests the NewValuesSchema function
+// GIVEN a values schema without a chart
+// WHEN ValidateOverrides is called
+// THEN the overrides are validated against the schema
+func TestNewValuesSchema(t *testing.T) {
+	data, err := os.ReadFile(testSchemaFile)
+	assert.NoError(t, err)
+	schema := NewValuesSchema("test", data)
+
+	assert.NoError(t, schema.ValidateOverrides([]OverrideSource{{Source: "values[0]", Values: "name: test\n"}}))
+
+	err = schema.ValidateOverrides([]OverrideSource{{Source: "values[0]", Values: "port: \"8080\"\nother: true\n"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Install overrides for chart test are not valid")
+	assert.Contains(t, err.Error(), "values[0]: unknown top-level key \"other\"")
+	assert.Contains(t, err.Error(), "values[0]: port: Invalid type")
+}
+
+// TestNewValuesSchemaWithValues tests the NewValuesSchemaWithValues function
+// GIVEN the values schema and the default values of a chart that requires values set by its defaults
+// WHEN ValidateOverrides is called
+// THEN the overrides are validated against the merged values, and keys of the default values are known
+func TestNewValuesSchemaWithValues(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(schemaChartDir, "values.schema.json"))
+	assert.NoError(t, err)
+	values, err := os.ReadFile(filepath.Join(schemaChartDir, "values.yaml"))
+	assert.NoError(t, err)
+	schema, err := NewValuesSchemaWithValues("schemachart", values, data)
+	assert.NoError(t, err)
+
+	assert.NoError(t, schema.ValidateOverrides([]OverrideSource{{Source: "values[0]", Values: "replicas: 2\nnodeSelector:\n  zone: a\n"}}))
+
+	err = schema.ValidateOverrides([]OverrideSource{{Source: "values[0]", Values: "replicas: two\n"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "values[0]: replicas: Invalid type")
+
+	_, err = NewValuesSchemaWithValues("schemachart", []byte("replicas: [\n"), data)
+	assert.Error(t, err)
+}
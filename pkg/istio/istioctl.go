@@ -13,6 +13,9 @@ import (
 	vzos "github.com/verrazzano/verrazzano/pkg/os"
 )
 
+// DefaultRevision is the revision of an Istio control plane installed without a revision
+const DefaultRevision = "default"
+
 // cmdRunner needed for unit tests
 var runner vzos.CmdRunner = vzos.DefaultRunner{}
 
@@ -77,7 +80,12 @@ func Install(log vzlog.VerrazzanoLogger, overrideStrings string, overridesFiles
 
 // Uninstall does an Istio uninstall removing the default revision installation. Istio CRDs are not removed.
 func Uninstall(log vzlog.VerrazzanoLogger) (stdout []byte, stderr []byte, err error) {
-	args := []string{"x", "uninstall", "--revision", "default", "-y"}
+	return UninstallRevision(log, DefaultRevision)
+}
+
+// UninstallRevision does an Istio uninstall removing the control plane of a revision. Istio CRDs are not removed.
+func UninstallRevision(log vzlog.VerrazzanoLogger, revision string) (stdout []byte, stderr []byte, err error) {
+	args := []string{"x", "uninstall", "--revision", revision, "-y"}
 
 	// Perform istioctl call of type uninstall
 	stdout, stderr, err = runIstioctl(log, args, "uninstall", true)
@@ -88,6 +96,20 @@ func Uninstall(log vzlog.VerrazzanoLogger) (stdout []byte, stderr []byte, err er
 	return stdout, stderr, nil
 }
 
+// SetDefaultTag points the default revision tag to a revision, so that the namespaces labeled with
+// istio-injection=enabled get the sidecar proxies of that revision
+func SetDefaultTag(log vzlog.VerrazzanoLogger, revision string) (stdout []byte, stderr []byte, err error) {
+	args := []string{"tag", "set", DefaultRevision, "--revision", revision, "--overwrite", "-y"}
+
+	// Perform istioctl call of type tag
+	stdout, stderr, err = runIstioctl(log, args, "tag", true)
+	if err != nil {
+		return stdout, stderr, errors.Wrapf(err, "tag failed, stderr: %s", stderr)
+	}
+
+	return stdout, stderr, nil
+}
+
 // IsInstalled returns true if Istio is installed
 func IsInstalled(log vzlog.VerrazzanoLogger) (bool, error) {
 	// Perform istioctl call of type upgrade
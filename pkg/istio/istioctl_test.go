@@ -27,6 +27,12 @@ type uninstallRunner struct {
 	t *testing.T
 }
 
+// argsRunner is used to test the istioctl arguments without actually running an OS exec command
+type argsRunner struct {
+	t    *testing.T
+	args []string
+}
+
 // badRunner is used to test istioctl errors without actually running an OS exec command
 type badRunner struct {
 	t *testing.T
@@ -125,6 +131,38 @@ func TestUninstallFail(t *testing.T) {
 	assert.NotZero(stderr, "Uninstall stderr should not be empty")
 }
 
+// TestUninstallRevision tests the istioctl uninstall command of a revision
+// GIVEN a revision
+//  WHEN I call UninstallRevision
+//  THEN the control plane of the revision is uninstalled
+func TestUninstallRevision(t *testing.T) {
+	assert := assert.New(t)
+	SetCmdRunner(argsRunner{t: t, args: []string{"x", "uninstall", "--revision", "1-14-3", "-y"}})
+	defer SetDefaultRunner()
+
+	stdout, _, err := UninstallRevision(vzlog.DefaultLogger(), "1-14-3")
+	assert.NoError(err, "UninstallRevision returned an error")
+	assert.NotZero(stdout, "UninstallRevision stdout should not be empty")
+}
+
+// TestSetDefaultTag tests the istioctl tag set command
+// GIVEN a revision
+//  WHEN I call SetDefaultTag
+//  THEN the default tag is pointed to the revision
+func TestSetDefaultTag(t *testing.T) {
+	assert := assert.New(t)
+	SetCmdRunner(argsRunner{t: t, args: []string{"tag", "set", "default", "--revision", "1-14-3", "--overwrite", "-y"}})
+	defer SetDefaultRunner()
+
+	stdout, _, err := SetDefaultTag(vzlog.DefaultLogger(), "1-14-3")
+	assert.NoError(err, "SetDefaultTag returned an error")
+	assert.NotZero(stdout, "SetDefaultTag stdout should not be empty")
+
+	SetCmdRunner(badRunner{t: t})
+	_, _, err = SetDefaultTag(vzlog.DefaultLogger(), "1-14-3")
+	assert.Error(err, "SetDefaultTag should have returned an error")
+}
+
 // fakeIsInstalledRunner overrides the istio run command
 func (r fakeIstioInstalledRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
 	return []byte("Istio is installed and verified successfully"), []byte(""), nil
@@ -166,6 +204,12 @@ func (r uninstallRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err e
 	return []byte("success"), []byte(""), nil
 }
 
+// Run should assert the command arguments are the expected ones then return a success with stdout contents
+func (r argsRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
+	assert.Equal(r.t, append([]string{"istioctl"}, r.args...), cmd.Args, "unexpected istioctl arguments")
+	return []byte("success"), []byte(""), nil
+}
+
 // Run should return an error with stderr contents
 func (r badRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
 	return []byte(""), []byte("error"), errors.New("error")
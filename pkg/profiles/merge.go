@@ -94,12 +94,17 @@ func MergeProfilesForV1beta1(actualCR *v1beta1.Verrazzano, profileFiles ...strin
 	return &newCR, nil
 }
 
+// appendProfileComponentOverrides returns the profiles in order, the later profiles are overlaid on the
+// earlier ones.  The component overrides of the earlier profiles are appended to the overrides of the last profile,
+// so that the merge does not replace them.
 func appendProfileComponentOverrides(profileFiles ...string) ([]string, error) {
+	if len(profileFiles) == 0 {
+		return nil, nil
+	}
 	var profileCR *v1alpha1.Verrazzano
-	var profileStrings []string
-	for i := range profileFiles {
-		profileFile := profileFiles[len(profileFiles)-1-i]
-		data, err := os.ReadFile(profileFile)
+	profileStrings := make([]string, len(profileFiles))
+	for i := len(profileFiles) - 1; i >= 0; i-- {
+		data, err := os.ReadFile(profileFiles[i])
 		if err != nil {
 			return nil, err
 		}
@@ -109,26 +114,30 @@ func appendProfileComponentOverrides(profileFiles ...string) ([]string, error) {
 		}
 		if profileCR == nil {
 			profileCR = cr
-		} else {
-			AppendComponentOverrides(profileCR, cr)
-			profileStrings = append(profileStrings, string(data))
+			continue
 		}
-
+		AppendComponentOverrides(profileCR, cr)
+		profileStrings[i] = string(data)
 	}
 	data, err := yaml.Marshal(profileCR)
 	if err != nil {
 		return nil, err
 	}
-	profileStrings = append(profileStrings, string(data))
+	profileStrings[len(profileFiles)-1] = string(data)
 	return profileStrings, nil
 }
 
+// appendProfileComponentOverridesV1beta1 returns the profiles in order, the later profiles are overlaid on the
+// earlier ones.  The component overrides of the earlier profiles are appended to the overrides of the last profile,
+// so that the merge does not replace them.
 func appendProfileComponentOverridesV1beta1(profileFiles ...string) ([]string, error) {
+	if len(profileFiles) == 0 {
+		return nil, nil
+	}
 	var profileCR *v1beta1.Verrazzano
-	var profileStrings []string
-	for i := range profileFiles {
-		profileFile := profileFiles[len(profileFiles)-1-i]
-		data, err := os.ReadFile(profileFile)
+	profileStrings := make([]string, len(profileFiles))
+	for i := len(profileFiles) - 1; i >= 0; i-- {
+		data, err := os.ReadFile(profileFiles[i])
 		if err != nil {
 			return nil, err
 		}
@@ -138,17 +147,16 @@ func appendProfileComponentOverridesV1beta1(profileFiles ...string) ([]string, e
 		}
 		if profileCR == nil {
 			profileCR = cr
-		} else {
-			AppendComponentOverridesV1beta1(profileCR, cr)
-			profileStrings = append(profileStrings, string(data))
+			continue
 		}
-
+		AppendComponentOverridesV1beta1(profileCR, cr)
+		profileStrings[i] = string(data)
 	}
 	data, err := yaml.Marshal(profileCR)
 	if err != nil {
 		return nil, err
 	}
-	profileStrings = append(profileStrings, string(data))
+	profileStrings[len(profileFiles)-1] = string(data)
 	return profileStrings, nil
 }
 
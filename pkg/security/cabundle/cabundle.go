@@ -0,0 +1,53 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package cabundle
+
+import (
+	"bytes"
+	"encoding/pem"
+)
+
+const certificateBlockType = "CERTIFICATE"
+
+// AppendCertificates returns the PEM bundle with the certificates of certs that it does not already contain appended,
+// the bundle is returned unchanged when there is nothing to add
+func AppendCertificates(bundle []byte, certs []byte) []byte {
+	existing := decodeCertificates(bundle)
+	result := append([]byte(nil), bundle...)
+	for _, cert := range decodeCertificates(certs) {
+		if containsCertificate(existing, cert) {
+			continue
+		}
+		if len(result) > 0 && !bytes.HasSuffix(result, []byte("\n")) {
+			result = append(result, '\n')
+		}
+		result = append(result, pem.EncodeToMemory(&pem.Block{Type: certificateBlockType, Bytes: cert})...)
+		existing = append(existing, cert)
+	}
+	return result
+}
+
+// decodeCertificates returns the DER bytes of the certificates of a PEM bundle
+func decodeCertificates(data []byte) [][]byte {
+	var certs [][]byte
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type == certificateBlockType {
+			certs = append(certs, block.Bytes)
+		}
+		data = rest
+	}
+}
+
+func containsCertificate(certs [][]byte, cert []byte) bool {
+	for _, c := range certs {
+		if bytes.Equal(c, cert) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package cabundle
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCA returns a PEM encoded self-signed CA certificate
+func newTestCA(t *testing.T, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// TestAppendCertificates tests the AppendCertificates function
+// GIVEN a CA bundle and a set of certificates
+// WHEN AppendCertificates is called
+// THEN only the certificates that are not in the bundle are appended
+func TestAppendCertificates(t *testing.T) {
+	previousCA := newTestCA(t, "verrazzano-root-ca-previous")
+	currentCA := newTestCA(t, "verrazzano-root-ca-current")
+	transition := append(append([]byte{}, previousCA...), currentCA...)
+
+	// The bundle is unchanged when there is nothing to add
+	assert.Equal(t, currentCA, AppendCertificates(currentCA, nil))
+	assert.Equal(t, currentCA, AppendCertificates(currentCA, currentCA))
+
+	// The missing certificates are appended after the bundle
+	bundle := AppendCertificates(currentCA, transition)
+	assert.Equal(t, append(append([]byte{}, currentCA...), previousCA...), bundle)
+	assert.Equal(t, bundle, AppendCertificates(bundle, transition))
+
+	// A bundle without a trailing newline stays a valid PEM bundle
+	bundle = AppendCertificates(currentCA[:len(currentCA)-1], previousCA)
+	assert.Len(t, decodeCertificates(bundle), 2)
+
+	// An empty bundle gets all of the certificates
+	assert.Equal(t, transition, AppendCertificates(nil, transition))
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The VerrazzanoComponent custom resource describes a user-defined Helm chart that is installed, upgraded
+// and uninstalled by the Verrazzano platform operator along with the built-in Verrazzano components.
+// Only VerrazzanoComponent resources in the namespace of the Verrazzano resource are processed.
+
+// FinalizerName is the finalizer used to uninstall the Helm release when a VerrazzanoComponent is deleted
+const FinalizerName = "components.verrazzano.io"
+
+// VerrazzanoComponentSpec defines the desired state of VerrazzanoComponent
+type VerrazzanoComponentSpec struct {
+	// The location of the Helm chart.
+	Chart ChartLocation `json:"chart"`
+
+	// The namespace where the Helm release is installed.  The namespace is created if it does not exist.
+	Namespace string `json:"namespace"`
+
+	// The name of the Helm release, defaults to the name of the VerrazzanoComponent.  The release name is also
+	// the name of the component in the Verrazzano status and must not be the name of a built-in component.
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// The names of the built-in or user-defined components that must be ready before this component is installed.
+	// +optional
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// The names of the deployments, in the release namespace, that must be available before the component is ready.
+	// +optional
+	ReadinessDeployments []string `json:"readinessDeployments,omitempty"`
+
+	// The Helm value overrides for the chart.  ConfigMap and Secret override sources are read from the namespace
+	// of the Verrazzano resource.
+	// +optional
+	installv1alpha1.InstallOverrides `json:",inline"`
+}
+
+// ChartLocation identifies the Helm chart of a VerrazzanoComponent
+type ChartLocation struct {
+	// The chart reference passed to Helm: a chart directory or archive available to the platform operator,
+	// the URL of a chart archive, or an OCI registry reference.
+	Path string `json:"path"`
+}
+
+// VerrazzanoComponentStatus defines the observed state of VerrazzanoComponent
+type VerrazzanoComponentStatus struct {
+	// The generation of the VerrazzanoComponent that was last applied to the Verrazzano installation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// VerrazzanoComponent is the Schema for the verrazzanocomponents API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=vzcomp;vzcomps
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.namespace",description="The namespace of the Helm release"
+// +kubebuilder:printcolumn:name="Chart",type="string",JSONPath=".spec.chart.path",description="The location of the Helm chart"
+// +genclient
+type VerrazzanoComponent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VerrazzanoComponentSpec   `json:"spec,omitempty"`
+	Status VerrazzanoComponentStatus `json:"status,omitempty"`
+}
+
+// GetReleaseName returns the name of the Helm release, which is also the name of the component
+func (c *VerrazzanoComponent) GetReleaseName() string {
+	if len(c.Spec.ReleaseName) > 0 {
+		return c.Spec.ReleaseName
+	}
+	return c.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VerrazzanoComponentList contains a list of VerrazzanoComponent
+type VerrazzanoComponentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VerrazzanoComponent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VerrazzanoComponent{}, &VerrazzanoComponentList{})
+}
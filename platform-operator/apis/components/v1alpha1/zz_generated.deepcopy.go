@@ -0,0 +1,129 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartLocation) DeepCopyInto(out *ChartLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartLocation.
+func (in *ChartLocation) DeepCopy() *ChartLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoComponent) DeepCopyInto(out *VerrazzanoComponent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoComponent.
+func (in *VerrazzanoComponent) DeepCopy() *VerrazzanoComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerrazzanoComponent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoComponentList) DeepCopyInto(out *VerrazzanoComponentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VerrazzanoComponent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoComponentList.
+func (in *VerrazzanoComponentList) DeepCopy() *VerrazzanoComponentList {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoComponentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerrazzanoComponentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoComponentSpec) DeepCopyInto(out *VerrazzanoComponentSpec) {
+	*out = *in
+	out.Chart = in.Chart
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadinessDeployments != nil {
+		in, out := &in.ReadinessDeployments, &out.ReadinessDeployments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoComponentSpec.
+func (in *VerrazzanoComponentSpec) DeepCopy() *VerrazzanoComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoComponentStatus) DeepCopyInto(out *VerrazzanoComponentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoComponentStatus.
+func (in *VerrazzanoComponentStatus) DeepCopy() *VerrazzanoComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,11 +19,15 @@ func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
 	// Convert Spec
 	in.Spec.Components = convertComponentsFromV1Beta1(src.Spec.Components)
 	in.Spec.Profile = ProfileType(src.Spec.Profile)
+	in.Spec.Size = SizeType(src.Spec.Size)
+	in.Spec.HighAvailability = convertHighAvailabilityFromV1Beta1(src.Spec.HighAvailability)
 	in.Spec.EnvironmentName = src.Spec.EnvironmentName
 	in.Spec.Version = src.Spec.Version
 	in.Spec.DefaultVolumeSource = src.Spec.DefaultVolumeSource
 	in.Spec.VolumeClaimSpecTemplates = convertVoumeClaimTemplatesFromV1Beta1(src.Spec.VolumeClaimSpecTemplates)
 	in.Spec.Security = convertSecuritySpecFromV1Beta1(src.Spec.Security)
+	in.Spec.MaintenanceWindow = convertMaintenanceWindowFromV1Beta1(src.Spec.MaintenanceWindow)
+	in.Spec.Backup = convertBackupSpecFromV1Beta1(src.Spec.Backup)
 
 	// Convert status
 	in.Status.State = VzStateType(src.Status.State)
@@ -31,6 +35,12 @@ func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
 	in.Status.Conditions = convertConditionsFromV1Beta1(src.Status.Conditions)
 	in.Status.Components = convertComponentStatusMapFromV1Beta1(src.Status.Components)
 	in.Status.VerrazzanoInstance = convertVerrazzanoInstanceFromV1Beta1(src.Status.VerrazzanoInstance)
+	in.Status.Maintenance = convertMaintenanceStatusFromV1Beta1(src.Status.Maintenance)
+	in.Status.OpenSearch = convertOpenSearchStatusFromV1Beta1(src.Status.OpenSearch)
+	in.Status.Backup = convertBackupStatusFromV1Beta1(src.Status.Backup)
+	in.Status.Keycloak = convertKeycloakStatusFromV1Beta1(src.Status.Keycloak)
+	in.Status.MySQL = convertMySQLStatusFromV1Beta1(src.Status.MySQL)
+	in.Status.Certificates = convertCertificatesStatusFromV1Beta1(src.Status.Certificates)
 	return nil
 }
 
@@ -72,6 +82,7 @@ func convertComponentStatusMapFromV1Beta1(components v1beta1.ComponentStatusMap)
 				Version:                  detail.Version,
 				LastReconciledGeneration: detail.LastReconciledGeneration,
 				ReconcilingGeneration:    detail.ReconcilingGeneration,
+				Workload:                 convertWorkloadFromV1Beta1(detail.Workload),
 			}
 		}
 	}
@@ -99,9 +110,140 @@ func convertSecuritySpecFromV1Beta1(security v1beta1.SecuritySpec) SecuritySpec
 	return SecuritySpec{
 		AdminSubjects:   security.AdminSubjects,
 		MonitorSubjects: security.MonitorSubjects,
+		NetworkPolicies: convertNetworkPoliciesFromV1Beta1(security.NetworkPolicies),
 	}
 }
 
+func convertNetworkPoliciesFromV1Beta1(policies *v1beta1.NetworkPoliciesSpec) *NetworkPoliciesSpec {
+	if policies == nil {
+		return nil
+	}
+	out := &NetworkPoliciesSpec{Enabled: policies.Enabled}
+	for _, policy := range policies.AdditionalPolicies {
+		out.AdditionalPolicies = append(out.AdditionalPolicies, AdditionalNetworkPolicy(policy))
+	}
+	return out
+}
+
+func convertHighAvailabilityFromV1Beta1(ha *v1beta1.HighAvailabilitySpec) *HighAvailabilitySpec {
+	if ha == nil {
+		return nil
+	}
+	return &HighAvailabilitySpec{Enabled: ha.Enabled}
+}
+
+func convertMaintenanceWindowFromV1Beta1(window *v1beta1.MaintenanceWindow) *MaintenanceWindow {
+	if window == nil {
+		return nil
+	}
+	return &MaintenanceWindow{
+		Schedule: window.Schedule,
+		Duration: window.Duration,
+		TimeZone: window.TimeZone,
+	}
+}
+
+func convertMaintenanceStatusFromV1Beta1(status *v1beta1.MaintenanceStatus) *MaintenanceStatus {
+	if status == nil {
+		return nil
+	}
+	return &MaintenanceStatus{
+		DeferredUntil:      status.DeferredUntil,
+		DeferredOperations: status.DeferredOperations,
+		Message:            status.Message,
+	}
+}
+
+func convertOpenSearchStatusFromV1Beta1(status *v1beta1.OpenSearchStatus) *OpenSearchStatus {
+	if status == nil {
+		return nil
+	}
+	out := &OpenSearchStatus{}
+	for _, snapshot := range status.Snapshots {
+		out.Snapshots = append(out.Snapshots, OpenSearchSnapshotStatus(snapshot))
+	}
+	return out
+}
+
+func convertBackupSpecFromV1Beta1(backup *v1beta1.BackupSpec) *BackupSpec {
+	if backup == nil {
+		return nil
+	}
+	out := &BackupSpec{
+		Storage: BackupStorage(backup.Storage),
+	}
+	for _, schedule := range backup.Schedules {
+		out.Schedules = append(out.Schedules, BackupSchedule{
+			Name:          schedule.Name,
+			Schedule:      schedule.Schedule,
+			RetentionDays: schedule.RetentionDays,
+			Stores:        convertBackupStoresFromV1Beta1(schedule.Stores),
+		})
+	}
+	return out
+}
+
+func convertBackupStoresFromV1Beta1(stores []v1beta1.BackupStore) []BackupStore {
+	var out []BackupStore
+	for _, store := range stores {
+		out = append(out, BackupStore(store))
+	}
+	return out
+}
+
+func convertBackupStatusFromV1Beta1(status *v1beta1.BackupStatus) *BackupStatus {
+	if status == nil {
+		return nil
+	}
+	out := &BackupStatus{}
+	for _, store := range status.Stores {
+		out.Stores = append(out.Stores, BackupStoreStatus{
+			Schedule:       store.Schedule,
+			Store:          BackupStore(store.Store),
+			Name:           store.Name,
+			State:          store.State,
+			StartTime:      store.StartTime,
+			CompletionTime: store.CompletionTime,
+			Message:        store.Message,
+		})
+	}
+	return out
+}
+
+func convertKeycloakStatusFromV1Beta1(status *v1beta1.KeycloakStatus) *KeycloakStatus {
+	if status == nil {
+		return nil
+	}
+	out := &KeycloakStatus{}
+	for _, realm := range status.Realms {
+		out.Realms = append(out.Realms, KeycloakRealmStatus(realm))
+	}
+	return out
+}
+
+func convertMySQLStatusFromV1Beta1(status *v1beta1.MySQLStatus) *MySQLStatus {
+	if status == nil {
+		return nil
+	}
+	return &MySQLStatus{
+		LastBackup: (*MySQLBackupStatus)(status.LastBackup),
+		Restore:    (*MySQLRestoreStatus)(status.Restore),
+	}
+}
+
+func convertCertificatesStatusFromV1Beta1(status *v1beta1.CertificatesStatus) *CertificatesStatus {
+	if status == nil {
+		return nil
+	}
+	out := &CertificatesStatus{
+		CARotation: (*CARotationStatus)(status.CARotation),
+	}
+	for _, expiry := range status.Expiry {
+		out.Expiry = append(out.Expiry, CertificateExpiryStatus(expiry))
+	}
+	return out
+}
+
 func convertComponentsFromV1Beta1(in v1beta1.ComponentSpec) ComponentSpec {
 	return ComponentSpec{
 		CertManager:            convertCertManagerFromV1Beta1(in.CertManager),
@@ -141,6 +283,7 @@ func convertApplicationOperatorFromV1Beta1(in *v1beta1.ApplicationOperatorCompon
 	}
 	return &ApplicationOperatorComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -151,17 +294,41 @@ func convertAuthProxyFromV1Beta1(in *v1beta1.AuthProxyComponent) *AuthProxyCompo
 	}
 	return &AuthProxyComponent{
 		Enabled:          in.Enabled,
+		OIDC:             convertAuthProxyOIDCFromV1Beta1(in.OIDC),
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
 
+func convertAuthProxyOIDCFromV1Beta1(in *v1beta1.AuthProxyOIDC) *AuthProxyOIDC {
+	if in == nil {
+		return nil
+	}
+	oidc := &AuthProxyOIDC{
+		IssuerURL:         in.IssuerURL,
+		ClientID:          in.ClientID,
+		ClientSecretRef:   in.ClientSecretRef,
+		CABundleSecretRef: in.CABundleSecretRef,
+		UsernameClaim:     in.UsernameClaim,
+		GroupsClaim:       in.GroupsClaim,
+		AdminGroups:       in.AdminGroups,
+		MonitorGroups:     in.MonitorGroups,
+	}
+	for _, mapping := range in.GroupMappings {
+		oidc.GroupMappings = append(oidc.GroupMappings, AuthProxyOIDCGroupMapping(mapping))
+	}
+	return oidc
+}
+
 func convertCertManagerFromV1Beta1(in *v1beta1.CertManagerComponent) *CertManagerComponent {
 	if in == nil {
 		return nil
 	}
 	return &CertManagerComponent{
 		Certificate:      convertCertificateFromV1Beta1(in.Certificate),
+		CARotation:       (*CARotationSpec)(in.CARotation),
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -169,14 +336,17 @@ func convertCertManagerFromV1Beta1(in *v1beta1.CertManagerComponent) *CertManage
 func convertCertificateFromV1Beta1(certificate v1beta1.Certificate) Certificate {
 	return Certificate{
 		Acme: Acme{
-			Provider:     ProviderType(certificate.Acme.Provider),
-			EmailAddress: certificate.Acme.EmailAddress,
-			Environment:  certificate.Acme.Environment,
+			Provider:               ProviderType(certificate.Acme.Provider),
+			EmailAddress:           certificate.Acme.EmailAddress,
+			Environment:            certificate.Acme.Environment,
+			Server:                 certificate.Acme.Server,
+			ExternalAccountBinding: ExternalAccountBinding(certificate.Acme.ExternalAccountBinding),
 		},
 		CA: CA{
 			SecretName:               certificate.CA.SecretName,
 			ClusterResourceNamespace: certificate.CA.ClusterResourceNamespace,
 		},
+		IssuerRef: IssuerRef(certificate.IssuerRef),
 	}
 }
 
@@ -186,6 +356,7 @@ func convertCoherenceOperatorFromV1Beta1(in *v1beta1.CoherenceOperatorComponent)
 	}
 	return &CoherenceOperatorComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -196,6 +367,7 @@ func convertConsoleFromV1Beta1(in *v1beta1.ConsoleComponent) *ConsoleComponent {
 	}
 	return &ConsoleComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -204,11 +376,43 @@ func convertOpenSearchFromV1Beta1(in *v1beta1.OpenSearchComponent) *Elasticsearc
 	if in == nil {
 		return nil
 	}
-	return &ElasticsearchComponent{
-		Enabled:  in.Enabled,
-		Policies: in.Policies,
-		Nodes:    convertOSNodesFromV1Beta1(in.Nodes),
+	opensearch := &ElasticsearchComponent{
+		Enabled:   in.Enabled,
+		Policies:  in.Policies,
+		Nodes:     convertOSNodesFromV1Beta1(in.Nodes),
+		Snapshots: convertOpenSearchSnapshotsFromV1Beta1(in.Snapshots),
 	}
+	for _, policy := range in.NamespacePolicies {
+		opensearch.NamespacePolicies = append(opensearch.NamespacePolicies, OpenSearchNamespacePolicy(policy))
+	}
+	return opensearch
+}
+
+func convertOpenSearchSnapshotsFromV1Beta1(in *v1beta1.OpenSearchSnapshots) *OpenSearchSnapshots {
+	if in == nil {
+		return nil
+	}
+	snapshots := &OpenSearchSnapshots{
+		S3CredentialsSecret: in.S3CredentialsSecret,
+	}
+	for _, repository := range in.Repositories {
+		out := OpenSearchSnapshotRepository{
+			Name: repository.Name,
+		}
+		if repository.FileSystem != nil {
+			fileSystem := OpenSearchFileSystemRepository(*repository.FileSystem)
+			out.FileSystem = &fileSystem
+		}
+		if repository.S3 != nil {
+			s3 := OpenSearchS3Repository(*repository.S3)
+			out.S3 = &s3
+		}
+		snapshots.Repositories = append(snapshots.Repositories, out)
+	}
+	for _, schedule := range in.Schedules {
+		snapshots.Schedules = append(snapshots.Schedules, OpenSearchSnapshotSchedule(schedule))
+	}
+	return snapshots
 }
 
 func convertOSNodesFromV1Beta1(in []v1beta1.OpenSearchNode) []OpenSearchNode {
@@ -239,6 +443,8 @@ func convertDNSFromV1Beta1(in *v1beta1.DNSComponent) *DNSComponent {
 		Wildcard:         convertWildcardDNSFromV1Beta1(in.Wildcard),
 		OCI:              convertOCIDNSFromV1Beta1(in.OCI),
 		External:         convertExternalDNSFromV1Beta1(in.External),
+		RFC2136:          convertRFC2136DNSFromV1Beta1(in.RFC2136),
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -272,6 +478,20 @@ func convertExternalDNSFromV1Beta1(external *v1beta1.External) *External {
 	return &External{Suffix: external.Suffix}
 }
 
+func convertRFC2136DNSFromV1Beta1(rfc2136 *v1beta1.RFC2136) *RFC2136 {
+	if rfc2136 == nil {
+		return nil
+	}
+	return &RFC2136{
+		Host:          rfc2136.Host,
+		Port:          rfc2136.Port,
+		DNSZoneName:   rfc2136.DNSZoneName,
+		TSIGKeyName:   rfc2136.TSIGKeyName,
+		TSIGAlgorithm: rfc2136.TSIGAlgorithm,
+		TSIGSecret:    rfc2136.TSIGSecret,
+	}
+}
+
 func convertFluentdFromV1Beta1(in *v1beta1.FluentdComponent) *FluentdComponent {
 	if in == nil {
 		return nil
@@ -282,10 +502,36 @@ func convertFluentdFromV1Beta1(in *v1beta1.FluentdComponent) *FluentdComponent {
 		ElasticsearchURL:    in.OpenSearchURL,
 		ElasticsearchSecret: in.OpenSearchSecret,
 		OCI:                 convertOCILoggingConfigurationFromV1Beta1(in.OCI),
+		Outputs:             convertFluentdOutputsFromV1Beta1(in.Outputs),
+		Workload:            convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides:    convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
 
+func convertFluentdOutputsFromV1Beta1(outputs []v1beta1.FluentdOutput) []FluentdOutput {
+	var out []FluentdOutput
+	for _, output := range outputs {
+		converted := FluentdOutput{
+			Name:       output.Name,
+			Namespaces: output.Namespaces,
+		}
+		if output.Kafka != nil {
+			kafka := FluentdKafkaOutput(*output.Kafka)
+			converted.Kafka = &kafka
+		}
+		if output.S3 != nil {
+			s3 := FluentdS3Output(*output.S3)
+			converted.S3 = &s3
+		}
+		if output.Syslog != nil {
+			syslog := FluentdSyslogOutput(*output.Syslog)
+			converted.Syslog = &syslog
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
 func convertVolumeMountsFromV1Beta1(mounts []v1beta1.VolumeMount) []VolumeMount {
 	var out []VolumeMount
 	for _, mount := range mounts {
@@ -324,6 +570,7 @@ func convertGrafanaFromV1Beta1(in *v1beta1.GrafanaComponent) *GrafanaComponent {
 		Enabled:  in.Enabled,
 		Replicas: in.Replicas,
 		Database: info,
+		Workload: convertWorkloadFromV1Beta1(in.Workload),
 	}
 }
 
@@ -335,11 +582,37 @@ func convertIngressNGINXFromV1Beta1(in *v1beta1.IngressNginxComponent) *IngressN
 		IngressClassName: in.IngressClassName,
 		Type:             IngressType(in.Type),
 		Ports:            in.Ports,
+		Security:         convertIngressSecurityFromV1Beta1(in.Security),
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
 
+func convertIngressSecurityFromV1Beta1(security *v1beta1.IngressSecuritySpec) *IngressSecuritySpec {
+	if security == nil {
+		return nil
+	}
+	return &IngressSecuritySpec{
+		TLS:            (*IngressTLSSpec)(security.TLS),
+		HSTS:           (*HSTSSpec)(security.HSTS),
+		ModSecurity:    convertModSecurityFromV1Beta1(security.ModSecurity),
+		RateLimit:      (*RateLimitSpec)(security.RateLimit),
+		ClientBodySize: security.ClientBodySize,
+	}
+}
+
+func convertModSecurityFromV1Beta1(modSecurity *v1beta1.ModSecuritySpec) *ModSecuritySpec {
+	if modSecurity == nil {
+		return nil
+	}
+	return &ModSecuritySpec{
+		Enabled:          modSecurity.Enabled,
+		OWASPCoreRuleSet: modSecurity.OWASPCoreRuleSet,
+		Mode:             ModSecurityMode(modSecurity.Mode),
+	}
+}
+
 func convertIstioFromV1Beta1(in *v1beta1.IstioComponent) *IstioComponent {
 	if in == nil {
 		return nil
@@ -348,6 +621,18 @@ func convertIstioFromV1Beta1(in *v1beta1.IstioComponent) *IstioComponent {
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 		Enabled:          in.Enabled,
 		InjectionEnabled: in.InjectionEnabled,
+		Upgrade:          convertIstioUpgradeFromV1Beta1(in.Upgrade),
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
+	}
+}
+
+func convertIstioUpgradeFromV1Beta1(upgrade *v1beta1.IstioUpgrade) *IstioUpgrade {
+	if upgrade == nil {
+		return nil
+	}
+	return &IstioUpgrade{
+		Strategy:           IstioUpgradeStrategy(upgrade.Strategy),
+		NamespaceBatchSize: upgrade.NamespaceBatchSize,
 	}
 }
 
@@ -357,16 +642,50 @@ func convertJaegerOperatorFromV1Beta1(in *v1beta1.JaegerOperatorComponent) *Jaeg
 	}
 	return &JaegerOperatorComponent{
 		Enabled:          in.Enabled,
+		Storage:          convertJaegerStorageFromV1Beta1(in.Storage),
+		Sampling:         convertJaegerSamplingFromV1Beta1(in.Sampling),
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
 
+func convertJaegerStorageFromV1Beta1(in *v1beta1.JaegerStorageSpec) *JaegerStorageSpec {
+	if in == nil {
+		return nil
+	}
+	return &JaegerStorageSpec{
+		Type:              JaegerStorageType(in.Type),
+		URL:               in.URL,
+		CredentialsSecret: in.CredentialsSecret,
+		RetentionDays:     in.RetentionDays,
+	}
+}
+
+func convertJaegerSamplingFromV1Beta1(in *v1beta1.JaegerSamplingSpec) *JaegerSamplingSpec {
+	if in == nil {
+		return nil
+	}
+	var services []JaegerServiceSampling
+	for _, service := range in.Services {
+		services = append(services, JaegerServiceSampling{
+			Service:            service.Service,
+			Percentage:         service.Percentage,
+			MaxTracesPerSecond: service.MaxTracesPerSecond,
+		})
+	}
+	return &JaegerSamplingSpec{
+		DefaultPercentage: in.DefaultPercentage,
+		Services:          services,
+	}
+}
+
 func convertKialiFromV1Beta1(in *v1beta1.KialiComponent) *KialiComponent {
 	if in == nil {
 		return nil
 	}
 	return &KialiComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -378,19 +697,59 @@ func convertKeycloakFromV1Beta1(in *v1beta1.KeycloakComponent) *KeycloakComponen
 	return &KeycloakComponent{
 		MySQL: MySQLComponent{
 			VolumeSource:     in.MySQL.VolumeSource,
+			Workload:         convertWorkloadFromV1Beta1(in.MySQL.Workload),
+			Backup:           convertMySQLBackupFromV1Beta1(in.MySQL.Backup),
+			Restore:          (*MySQLRestoreSpec)(in.MySQL.Restore),
 			InstallOverrides: convertInstallOverridesFromV1Beta1(in.MySQL.InstallOverrides),
 		},
 		Enabled:          in.Enabled,
+		Realms:           convertKeycloakRealmsFromV1Beta1(in.Realms),
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
 
+func convertMySQLBackupFromV1Beta1(backup *v1beta1.MySQLBackupSpec) *MySQLBackupSpec {
+	if backup == nil {
+		return nil
+	}
+	return &MySQLBackupSpec{
+		Schedule:              backup.Schedule,
+		RetentionCount:        backup.RetentionCount,
+		PersistentVolumeClaim: backup.PersistentVolumeClaim,
+		S3:                    (*BackupStorage)(backup.S3),
+		PreUpgrade:            backup.PreUpgrade,
+	}
+}
+
+func convertKeycloakRealmsFromV1Beta1(in []v1beta1.KeycloakRealm) []KeycloakRealm {
+	if in == nil {
+		return nil
+	}
+	var realms []KeycloakRealm
+	for _, realm := range in {
+		out := KeycloakRealm{Name: realm.Name}
+		for _, group := range realm.Groups {
+			out.Groups = append(out.Groups, KeycloakRealmGroup(group))
+		}
+		for _, user := range realm.Users {
+			out.Users = append(out.Users, KeycloakRealmUser(user))
+		}
+		for _, client := range realm.Clients {
+			out.Clients = append(out.Clients, KeycloakRealmClient(client))
+		}
+		realms = append(realms, out)
+	}
+	return realms
+}
+
 func convertOAMFromV1Beta1(in *v1beta1.OAMComponent) *OAMComponent {
 	if in == nil {
 		return nil
 	}
 	return &OAMComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -411,6 +770,7 @@ func convertKubeStateMetricsFromV1Beta1(in *v1beta1.KubeStateMetricsComponent) *
 	}
 	return &KubeStateMetricsComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -421,6 +781,7 @@ func convertMySQLOperatorFromV1Beta1(in *v1beta1.MySQLOperatorComponent) *MySQLO
 	}
 	return &MySQLOperatorComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -430,7 +791,8 @@ func convertPrometheusFromV1Beta1(in *v1beta1.PrometheusComponent) *PrometheusCo
 		return nil
 	}
 	return &PrometheusComponent{
-		Enabled: in.Enabled,
+		Enabled:  in.Enabled,
+		Workload: convertWorkloadFromV1Beta1(in.Workload),
 	}
 }
 
@@ -440,6 +802,7 @@ func convertPrometheusAdapterFromV1Beta1(in *v1beta1.PrometheusAdapterComponent)
 	}
 	return &PrometheusAdapterComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -450,6 +813,7 @@ func convertPrometheusNodeExporterFromV1Beta1(in *v1beta1.PrometheusNodeExporter
 	}
 	return &PrometheusNodeExporterComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -460,16 +824,38 @@ func convertPrometheusOperatorFromV1Beta1(in *v1beta1.PrometheusOperatorComponen
 	}
 	return &PrometheusOperatorComponent{
 		Enabled:          in.Enabled,
+		Alertmanager:     convertAlertmanagerFromV1Beta1(in.Alertmanager),
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
 
+func convertAlertmanagerFromV1Beta1(in *v1beta1.AlertmanagerSpec) *AlertmanagerSpec {
+	if in == nil {
+		return nil
+	}
+	var receivers []AlertmanagerReceiver
+	for _, receiver := range in.Receivers {
+		receivers = append(receivers, AlertmanagerReceiver{
+			Name:       receiver.Name,
+			Type:       AlertmanagerReceiverType(receiver.Type),
+			Secret:     receiver.Secret,
+			Severities: receiver.Severities,
+		})
+	}
+	return &AlertmanagerSpec{
+		Enabled:   in.Enabled,
+		Receivers: receivers,
+	}
+}
+
 func convertPrometheusPushGatewayFromV1Beta1(in *v1beta1.PrometheusPushgatewayComponent) *PrometheusPushgatewayComponent {
 	if in == nil {
 		return nil
 	}
 	return &PrometheusPushgatewayComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -480,6 +866,7 @@ func convertRancherFromV1Beta1(in *v1beta1.RancherComponent) *RancherComponent {
 	}
 	return &RancherComponent{
 		Enabled:             in.Enabled,
+		Workload:            convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides:    convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 		KeycloakAuthEnabled: in.KeycloakAuthEnabled,
 	}
@@ -491,6 +878,7 @@ func convertRancherBackupFromV1Beta1(in *v1beta1.RancherBackupComponent) *Ranche
 	}
 	return &RancherBackupComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -501,6 +889,7 @@ func convertWeblogicOperatorFromV1Beta1(in *v1beta1.WebLogicOperatorComponent) *
 	}
 	return &WebLogicOperatorComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -511,6 +900,7 @@ func convertVeleroFromV1Beta1(in *v1beta1.VeleroComponent) *VeleroComponent {
 	}
 	return &VeleroComponent{
 		Enabled:          in.Enabled,
+		Workload:         convertWorkloadFromV1Beta1(in.Workload),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -525,6 +915,14 @@ func convertVerrazzanoFromV1Beta1(in *v1beta1.VerrazzanoComponent) *VerrazzanoCo
 	}
 }
 
+func convertWorkloadFromV1Beta1(in *v1beta1.WorkloadSpec) *WorkloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := WorkloadSpec(*in)
+	return &out
+}
+
 func convertInstallOverridesFromV1Beta1(in v1beta1.InstallOverrides) InstallOverrides {
 	return InstallOverrides{
 		MonitorChanges: in.MonitorChanges,
@@ -539,6 +937,7 @@ func convertValueOverridesFromV1Beta1(in []v1beta1.Overrides) []Overrides {
 			ConfigMapRef: oIn.ConfigMapRef,
 			SecretRef:    oIn.SecretRef,
 			Values:       oIn.Values,
+			Template:     oIn.Template,
 		})
 	}
 	return out
@@ -25,6 +25,86 @@ func TestConvertFromV1Beta1(t *testing.T) {
 			testCaseRancherKeycloak,
 			false,
 		},
+		{
+			"converts keycloak realms",
+			testCaseKeycloakRealms,
+			false,
+		},
+		{
+			"converts authproxy oidc",
+			testCaseAuthProxyOIDC,
+			false,
+		},
+		{
+			"converts opensearch snapshots",
+			testCaseOpenSearchSnaps,
+			false,
+		},
+		{
+			"converts fluentd outputs",
+			testCaseFluentdOutputs,
+			false,
+		},
+		{
+			"converts ACME server",
+			testCaseAcmeServer,
+			false,
+		},
+		{
+			"converts RFC2136 DNS",
+			testCaseRFC2136DNS,
+			false,
+		},
+		{
+			"converts Istio revision upgrade",
+			testCaseIstioRevision,
+			false,
+		},
+		{
+			"converts Alertmanager",
+			testCaseAlertmanager,
+			false,
+		},
+		{
+			"converts Jaeger storage and sampling",
+			testCaseJaegerStorage,
+			false,
+		},
+		{
+			"converts scheduled backups",
+			testCaseBackup,
+			false,
+		},
+		{
+			"converts component workload settings",
+			testCaseWorkload,
+			false,
+		},
+		{
+			"converts the network policies of the system namespaces",
+			testCaseNetworkPolicies,
+			false,
+		},
+		{
+			"converts the high availability mode",
+			testCaseHighAvailability,
+			false,
+		},
+		{
+			"converts the Keycloak MySQL backups and restore",
+			testCaseMySQLBackup,
+			false,
+		},
+		{
+			"converts the security settings of the ingress controller",
+			testCaseIngressSecurity,
+			false,
+		},
+		{
+			"converts the CA rotation and the certificates status",
+			testCaseCARotation,
+			false,
+		},
 		{
 			"converts all comps to v1alpha1",
 			testCaseToAllComps,
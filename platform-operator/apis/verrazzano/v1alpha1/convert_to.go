@@ -59,12 +59,16 @@ func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
 
 	// Convert Spec
 	out.Spec.Profile = v1beta1.ProfileType(in.Spec.Profile)
+	out.Spec.Size = v1beta1.SizeType(in.Spec.Size)
+	out.Spec.HighAvailability = convertHighAvailabilityTo(in.Spec.HighAvailability)
 	out.Spec.EnvironmentName = in.Spec.EnvironmentName
 	out.Spec.Version = in.Spec.Version
 	out.Spec.DefaultVolumeSource = in.Spec.DefaultVolumeSource
 	out.Spec.VolumeClaimSpecTemplates = ConvertVolumeClaimTemplateTo(in.Spec.VolumeClaimSpecTemplates)
 	out.Spec.Components = components
 	out.Spec.Security = convertSecuritySpecTo(in.Spec.Security)
+	out.Spec.MaintenanceWindow = convertMaintenanceWindowTo(in.Spec.MaintenanceWindow)
+	out.Spec.Backup = convertBackupSpecTo(in.Spec.Backup)
 
 	// Convert Status
 	out.Status.State = v1beta1.VzStateType(in.Status.State)
@@ -72,6 +76,12 @@ func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
 	out.Status.Conditions = convertConditionsTo(in.Status.Conditions)
 	out.Status.Components = convertComponentStatusMapTo(in.Status.Components)
 	out.Status.VerrazzanoInstance = convertVerrazzanoInstanceTo(in.Status.VerrazzanoInstance)
+	out.Status.Maintenance = convertMaintenanceStatusTo(in.Status.Maintenance)
+	out.Status.OpenSearch = convertOpenSearchStatusTo(in.Status.OpenSearch)
+	out.Status.Backup = convertBackupStatusTo(in.Status.Backup)
+	out.Status.Keycloak = convertKeycloakStatusTo(in.Status.Keycloak)
+	out.Status.MySQL = convertMySQLStatusTo(in.Status.MySQL)
+	out.Status.Certificates = convertCertificatesStatusTo(in.Status.Certificates)
 	return nil
 }
 
@@ -149,7 +159,9 @@ func ConvertCertManagerToV1Beta1(src *CertManagerComponent) *v1beta1.CertManager
 	}
 	return &v1beta1.CertManagerComponent{
 		Certificate:      convertCertificateToV1Beta1(src.Certificate),
+		CARotation:       (*v1beta1.CARotationSpec)(src.CARotation),
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -157,14 +169,17 @@ func ConvertCertManagerToV1Beta1(src *CertManagerComponent) *v1beta1.CertManager
 func convertCertificateToV1Beta1(certificate Certificate) v1beta1.Certificate {
 	return v1beta1.Certificate{
 		Acme: v1beta1.Acme{
-			Provider:     v1beta1.ProviderType(certificate.Acme.Provider),
-			EmailAddress: certificate.Acme.EmailAddress,
-			Environment:  certificate.Acme.Environment,
+			Provider:               v1beta1.ProviderType(certificate.Acme.Provider),
+			EmailAddress:           certificate.Acme.EmailAddress,
+			Environment:            certificate.Acme.Environment,
+			Server:                 certificate.Acme.Server,
+			ExternalAccountBinding: v1beta1.ExternalAccountBinding(certificate.Acme.ExternalAccountBinding),
 		},
 		CA: v1beta1.CA{
 			SecretName:               certificate.CA.SecretName,
 			ClusterResourceNamespace: certificate.CA.ClusterResourceNamespace,
 		},
+		IssuerRef: v1beta1.IssuerRef(certificate.IssuerRef),
 	}
 }
 
@@ -174,6 +189,7 @@ func convertCoherenceOperatorToV1Beta1(src *CoherenceOperatorComponent) *v1beta1
 	}
 	return &v1beta1.CoherenceOperatorComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -184,6 +200,7 @@ func convertApplicationOperatorToV1Beta1(src *ApplicationOperatorComponent) *v1b
 	}
 	return &v1beta1.ApplicationOperatorComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -215,16 +232,39 @@ func convertAuthProxyToV1Beta1(src *AuthProxyComponent) (*v1beta1.AuthProxyCompo
 
 	return &v1beta1.AuthProxyComponent{
 		Enabled:          src.Enabled,
+		OIDC:             convertAuthProxyOIDCToV1Beta1(src.OIDC),
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: overrides,
 	}, nil
 }
 
+func convertAuthProxyOIDCToV1Beta1(src *AuthProxyOIDC) *v1beta1.AuthProxyOIDC {
+	if src == nil {
+		return nil
+	}
+	oidc := &v1beta1.AuthProxyOIDC{
+		IssuerURL:         src.IssuerURL,
+		ClientID:          src.ClientID,
+		ClientSecretRef:   src.ClientSecretRef,
+		CABundleSecretRef: src.CABundleSecretRef,
+		UsernameClaim:     src.UsernameClaim,
+		GroupsClaim:       src.GroupsClaim,
+		AdminGroups:       src.AdminGroups,
+		MonitorGroups:     src.MonitorGroups,
+	}
+	for _, mapping := range src.GroupMappings {
+		oidc.GroupMappings = append(oidc.GroupMappings, v1beta1.AuthProxyOIDCGroupMapping(mapping))
+	}
+	return oidc
+}
+
 func convertOAMToV1Beta1(src *OAMComponent) *v1beta1.OAMComponent {
 	if src == nil {
 		return nil
 	}
 	return &v1beta1.OAMComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -235,6 +275,7 @@ func convertConsoleToV1Beta1(src *ConsoleComponent) *v1beta1.ConsoleComponent {
 	}
 	return &v1beta1.ConsoleComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -247,6 +288,8 @@ func convertDNSToV1Beta1(src *DNSComponent) *v1beta1.DNSComponent {
 		Wildcard:         convertWildcardDNSToV1Beta1(src.Wildcard),
 		OCI:              convertOCIDNSToV1Beta1(src.OCI),
 		External:         convertExternalDNSToV1Beta1(src.External),
+		RFC2136:          convertRFC2136DNSToV1Beta1(src.RFC2136),
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -280,6 +323,20 @@ func convertExternalDNSToV1Beta1(external *External) *v1beta1.External {
 	return &v1beta1.External{Suffix: external.Suffix}
 }
 
+func convertRFC2136DNSToV1Beta1(rfc2136 *RFC2136) *v1beta1.RFC2136 {
+	if rfc2136 == nil {
+		return nil
+	}
+	return &v1beta1.RFC2136{
+		Host:          rfc2136.Host,
+		Port:          rfc2136.Port,
+		DNSZoneName:   rfc2136.DNSZoneName,
+		TSIGKeyName:   rfc2136.TSIGKeyName,
+		TSIGAlgorithm: rfc2136.TSIGAlgorithm,
+		TSIGSecret:    rfc2136.TSIGSecret,
+	}
+}
+
 func convertOpenSearchToV1Beta1(src *ElasticsearchComponent) (*v1beta1.OpenSearchComponent, error) {
 	if src == nil {
 		return nil, nil
@@ -288,11 +345,43 @@ func convertOpenSearchToV1Beta1(src *ElasticsearchComponent) (*v1beta1.OpenSearc
 	if err != nil {
 		return nil, err
 	}
-	return &v1beta1.OpenSearchComponent{
-		Enabled:  src.Enabled,
-		Policies: src.Policies,
-		Nodes:    nodes,
-	}, nil
+	opensearch := &v1beta1.OpenSearchComponent{
+		Enabled:   src.Enabled,
+		Policies:  src.Policies,
+		Nodes:     nodes,
+		Snapshots: convertOpenSearchSnapshotsToV1Beta1(src.Snapshots),
+	}
+	for _, policy := range src.NamespacePolicies {
+		opensearch.NamespacePolicies = append(opensearch.NamespacePolicies, v1beta1.OpenSearchNamespacePolicy(policy))
+	}
+	return opensearch, nil
+}
+
+func convertOpenSearchSnapshotsToV1Beta1(src *OpenSearchSnapshots) *v1beta1.OpenSearchSnapshots {
+	if src == nil {
+		return nil
+	}
+	snapshots := &v1beta1.OpenSearchSnapshots{
+		S3CredentialsSecret: src.S3CredentialsSecret,
+	}
+	for _, repository := range src.Repositories {
+		out := v1beta1.OpenSearchSnapshotRepository{
+			Name: repository.Name,
+		}
+		if repository.FileSystem != nil {
+			fileSystem := v1beta1.OpenSearchFileSystemRepository(*repository.FileSystem)
+			out.FileSystem = &fileSystem
+		}
+		if repository.S3 != nil {
+			s3 := v1beta1.OpenSearchS3Repository(*repository.S3)
+			out.S3 = &s3
+		}
+		snapshots.Repositories = append(snapshots.Repositories, out)
+	}
+	for _, schedule := range src.Schedules {
+		snapshots.Schedules = append(snapshots.Schedules, v1beta1.OpenSearchSnapshotSchedule(schedule))
+	}
+	return snapshots
 }
 
 func convertOSNodesToV1Beta1(args []InstallArgs, nodes []OpenSearchNode) ([]v1beta1.OpenSearchNode, error) {
@@ -437,10 +526,36 @@ func convertFluentdToV1Beta1(src *FluentdComponent) *v1beta1.FluentdComponent {
 		OpenSearchURL:     src.ElasticsearchURL,
 		OpenSearchSecret:  src.ElasticsearchSecret,
 		OCI:               convertOCILoggingConfigurationToV1Beta1(src.OCI),
+		Outputs:           convertFluentdOutputsToV1Beta1(src.Outputs),
+		Workload:          convertWorkloadTo(src.Workload),
 		InstallOverrides:  convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
 
+func convertFluentdOutputsToV1Beta1(outputs []FluentdOutput) []v1beta1.FluentdOutput {
+	var out []v1beta1.FluentdOutput
+	for _, output := range outputs {
+		converted := v1beta1.FluentdOutput{
+			Name:       output.Name,
+			Namespaces: output.Namespaces,
+		}
+		if output.Kafka != nil {
+			kafka := v1beta1.FluentdKafkaOutput(*output.Kafka)
+			converted.Kafka = &kafka
+		}
+		if output.S3 != nil {
+			s3 := v1beta1.FluentdS3Output(*output.S3)
+			converted.S3 = &s3
+		}
+		if output.Syslog != nil {
+			syslog := v1beta1.FluentdSyslogOutput(*output.Syslog)
+			converted.Syslog = &syslog
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
 func convertVolumeMountsToV1Beta1(mounts []VolumeMount) []v1beta1.VolumeMount {
 	var out []v1beta1.VolumeMount
 	for _, mount := range mounts {
@@ -479,6 +594,7 @@ func convertGrafanaToV1Beta1(src *GrafanaComponent) *v1beta1.GrafanaComponent {
 		Enabled:  src.Enabled,
 		Replicas: src.Replicas,
 		Database: info,
+		Workload: convertWorkloadTo(src.Workload),
 	}
 }
 
@@ -494,7 +610,9 @@ func convertIngressNGINXToV1Beta1(src *IngressNginxComponent) (*v1beta1.IngressN
 		IngressClassName: src.IngressClassName,
 		Type:             v1beta1.IngressType(src.Type),
 		Ports:            src.Ports,
+		Security:         convertIngressSecurityTo(src.Security),
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: installOverrides,
 	}, nil
 }
@@ -520,9 +638,21 @@ func convertIstioToV1Beta1(src *IstioComponent) (*v1beta1.IstioComponent, error)
 		InstallOverrides: overrides,
 		Enabled:          src.Enabled,
 		InjectionEnabled: src.InjectionEnabled,
+		Upgrade:          convertIstioUpgradeToV1Beta1(src.Upgrade),
+		Workload:         convertWorkloadTo(src.Workload),
 	}, nil
 }
 
+func convertIstioUpgradeToV1Beta1(upgrade *IstioUpgrade) *v1beta1.IstioUpgrade {
+	if upgrade == nil {
+		return nil
+	}
+	return &v1beta1.IstioUpgrade{
+		Strategy:           v1beta1.IstioUpgradeStrategy(upgrade.Strategy),
+		NamespaceBatchSize: upgrade.NamespaceBatchSize,
+	}
+}
+
 func mergeIstioOverrides(override v1beta1.Overrides, overrides []v1beta1.Overrides) ([]v1beta1.Overrides, error) {
 	if !isOverrideValueUnset(override) {
 		if len(overrides) < 1 {
@@ -559,16 +689,50 @@ func convertJaegerOperatorToV1Beta1(src *JaegerOperatorComponent) *v1beta1.Jaege
 	}
 	return &v1beta1.JaegerOperatorComponent{
 		Enabled:          src.Enabled,
+		Storage:          convertJaegerStorageToV1Beta1(src.Storage),
+		Sampling:         convertJaegerSamplingToV1Beta1(src.Sampling),
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
 
+func convertJaegerStorageToV1Beta1(src *JaegerStorageSpec) *v1beta1.JaegerStorageSpec {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.JaegerStorageSpec{
+		Type:              v1beta1.JaegerStorageType(src.Type),
+		URL:               src.URL,
+		CredentialsSecret: src.CredentialsSecret,
+		RetentionDays:     src.RetentionDays,
+	}
+}
+
+func convertJaegerSamplingToV1Beta1(src *JaegerSamplingSpec) *v1beta1.JaegerSamplingSpec {
+	if src == nil {
+		return nil
+	}
+	var services []v1beta1.JaegerServiceSampling
+	for _, service := range src.Services {
+		services = append(services, v1beta1.JaegerServiceSampling{
+			Service:            service.Service,
+			Percentage:         service.Percentage,
+			MaxTracesPerSecond: service.MaxTracesPerSecond,
+		})
+	}
+	return &v1beta1.JaegerSamplingSpec{
+		DefaultPercentage: src.DefaultPercentage,
+		Services:          services,
+	}
+}
+
 func convertKialiToV1Beta1(src *KialiComponent) *v1beta1.KialiComponent {
 	if src == nil {
 		return nil
 	}
 	return &v1beta1.KialiComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -588,19 +752,46 @@ func convertKeycloakToV1Beta1(src *KeycloakComponent) (*v1beta1.KeycloakComponen
 	return &v1beta1.KeycloakComponent{
 		MySQL: v1beta1.MySQLComponent{
 			VolumeSource:     src.MySQL.VolumeSource,
+			Workload:         convertWorkloadTo(src.MySQL.Workload),
+			Backup:           convertMySQLBackupTo(src.MySQL.Backup),
+			Restore:          (*v1beta1.MySQLRestoreSpec)(src.MySQL.Restore),
 			InstallOverrides: mysqlOverrides,
 		},
 		Enabled:          src.Enabled,
+		Realms:           convertKeycloakRealmsToV1Beta1(src.Realms),
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: keycloakOverrides,
 	}, nil
 }
 
+func convertKeycloakRealmsToV1Beta1(src []KeycloakRealm) []v1beta1.KeycloakRealm {
+	if src == nil {
+		return nil
+	}
+	var realms []v1beta1.KeycloakRealm
+	for _, realm := range src {
+		out := v1beta1.KeycloakRealm{Name: realm.Name}
+		for _, group := range realm.Groups {
+			out.Groups = append(out.Groups, v1beta1.KeycloakRealmGroup(group))
+		}
+		for _, user := range realm.Users {
+			out.Users = append(out.Users, v1beta1.KeycloakRealmUser(user))
+		}
+		for _, client := range realm.Clients {
+			out.Clients = append(out.Clients, v1beta1.KeycloakRealmClient(client))
+		}
+		realms = append(realms, out)
+	}
+	return realms
+}
+
 func convertMySQLOperatorToV1Beta1(src *MySQLOperatorComponent) *v1beta1.MySQLOperatorComponent {
 	if src == nil {
 		return nil
 	}
 	return &v1beta1.MySQLOperatorComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -621,6 +812,7 @@ func convertKubeStateMetricsToV1Beta1(src *KubeStateMetricsComponent) *v1beta1.K
 	}
 	return &v1beta1.KubeStateMetricsComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -630,7 +822,8 @@ func convertPrometheusToV1Beta1(src *PrometheusComponent) *v1beta1.PrometheusCom
 		return nil
 	}
 	return &v1beta1.PrometheusComponent{
-		Enabled: src.Enabled,
+		Enabled:  src.Enabled,
+		Workload: convertWorkloadTo(src.Workload),
 	}
 }
 
@@ -640,6 +833,7 @@ func convertPrometheusAdapterToV1Beta1(src *PrometheusAdapterComponent) *v1beta1
 	}
 	return &v1beta1.PrometheusAdapterComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -650,6 +844,7 @@ func convertPrometheusNodeExporterToV1Beta1(src *PrometheusNodeExporterComponent
 	}
 	return &v1beta1.PrometheusNodeExporterComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -660,16 +855,38 @@ func convertPrometheusOperatorToV1Beta1(src *PrometheusOperatorComponent) *v1bet
 	}
 	return &v1beta1.PrometheusOperatorComponent{
 		Enabled:          src.Enabled,
+		Alertmanager:     convertAlertmanagerToV1Beta1(src.Alertmanager),
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
 
+func convertAlertmanagerToV1Beta1(src *AlertmanagerSpec) *v1beta1.AlertmanagerSpec {
+	if src == nil {
+		return nil
+	}
+	var receivers []v1beta1.AlertmanagerReceiver
+	for _, receiver := range src.Receivers {
+		receivers = append(receivers, v1beta1.AlertmanagerReceiver{
+			Name:       receiver.Name,
+			Type:       v1beta1.AlertmanagerReceiverType(receiver.Type),
+			Secret:     receiver.Secret,
+			Severities: receiver.Severities,
+		})
+	}
+	return &v1beta1.AlertmanagerSpec{
+		Enabled:   src.Enabled,
+		Receivers: receivers,
+	}
+}
+
 func convertPrometheusPushGatewayToV1Beta1(src *PrometheusPushgatewayComponent) *v1beta1.PrometheusPushgatewayComponent {
 	if src == nil {
 		return nil
 	}
 	return &v1beta1.PrometheusPushgatewayComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -680,6 +897,7 @@ func convertRancherToV1Beta1(src *RancherComponent) *v1beta1.RancherComponent {
 	}
 	return &v1beta1.RancherComponent{
 		Enabled:             src.Enabled,
+		Workload:            convertWorkloadTo(src.Workload),
 		InstallOverrides:    convertInstallOverridesToV1Beta1(src.InstallOverrides),
 		KeycloakAuthEnabled: src.KeycloakAuthEnabled,
 	}
@@ -691,6 +909,7 @@ func convertRancherBackupToV1Beta1(src *RancherBackupComponent) *v1beta1.Rancher
 	}
 	return &v1beta1.RancherBackupComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -701,6 +920,7 @@ func convertWeblogicOperatorToV1Beta1(src *WebLogicOperatorComponent) *v1beta1.W
 	}
 	return &v1beta1.WebLogicOperatorComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -711,6 +931,7 @@ func convertVeleroToV1Beta1(src *VeleroComponent) *v1beta1.VeleroComponent {
 	}
 	return &v1beta1.VeleroComponent{
 		Enabled:          src.Enabled,
+		Workload:         convertWorkloadTo(src.Workload),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -756,6 +977,7 @@ func convertComponentStatusMapTo(components ComponentStatusMap) v1beta1.Componen
 				Version:                  detail.Version,
 				LastReconciledGeneration: detail.LastReconciledGeneration,
 				ReconcilingGeneration:    detail.ReconcilingGeneration,
+				Workload:                 convertWorkloadTo(detail.Workload),
 			}
 		}
 	}
@@ -783,7 +1005,183 @@ func convertSecuritySpecTo(security SecuritySpec) v1beta1.SecuritySpec {
 	return v1beta1.SecuritySpec{
 		AdminSubjects:   security.AdminSubjects,
 		MonitorSubjects: security.MonitorSubjects,
+		NetworkPolicies: convertNetworkPoliciesTo(security.NetworkPolicies),
+	}
+}
+
+func convertNetworkPoliciesTo(policies *NetworkPoliciesSpec) *v1beta1.NetworkPoliciesSpec {
+	if policies == nil {
+		return nil
+	}
+	out := &v1beta1.NetworkPoliciesSpec{Enabled: policies.Enabled}
+	for _, policy := range policies.AdditionalPolicies {
+		out.AdditionalPolicies = append(out.AdditionalPolicies, v1beta1.AdditionalNetworkPolicy(policy))
+	}
+	return out
+}
+
+func convertHighAvailabilityTo(ha *HighAvailabilitySpec) *v1beta1.HighAvailabilitySpec {
+	if ha == nil {
+		return nil
+	}
+	return &v1beta1.HighAvailabilitySpec{Enabled: ha.Enabled}
+}
+
+func convertMaintenanceWindowTo(window *MaintenanceWindow) *v1beta1.MaintenanceWindow {
+	if window == nil {
+		return nil
+	}
+	return &v1beta1.MaintenanceWindow{
+		Schedule: window.Schedule,
+		Duration: window.Duration,
+		TimeZone: window.TimeZone,
+	}
+}
+
+func convertMaintenanceStatusTo(status *MaintenanceStatus) *v1beta1.MaintenanceStatus {
+	if status == nil {
+		return nil
+	}
+	return &v1beta1.MaintenanceStatus{
+		DeferredUntil:      status.DeferredUntil,
+		DeferredOperations: status.DeferredOperations,
+		Message:            status.Message,
+	}
+}
+
+func convertOpenSearchStatusTo(status *OpenSearchStatus) *v1beta1.OpenSearchStatus {
+	if status == nil {
+		return nil
+	}
+	out := &v1beta1.OpenSearchStatus{}
+	for _, snapshot := range status.Snapshots {
+		out.Snapshots = append(out.Snapshots, v1beta1.OpenSearchSnapshotStatus(snapshot))
+	}
+	return out
+}
+
+func convertBackupSpecTo(backup *BackupSpec) *v1beta1.BackupSpec {
+	if backup == nil {
+		return nil
+	}
+	out := &v1beta1.BackupSpec{
+		Storage: v1beta1.BackupStorage(backup.Storage),
+	}
+	for _, schedule := range backup.Schedules {
+		out.Schedules = append(out.Schedules, v1beta1.BackupSchedule{
+			Name:          schedule.Name,
+			Schedule:      schedule.Schedule,
+			RetentionDays: schedule.RetentionDays,
+			Stores:        convertBackupStoresTo(schedule.Stores),
+		})
+	}
+	return out
+}
+
+func convertBackupStoresTo(stores []BackupStore) []v1beta1.BackupStore {
+	var out []v1beta1.BackupStore
+	for _, store := range stores {
+		out = append(out, v1beta1.BackupStore(store))
+	}
+	return out
+}
+
+func convertBackupStatusTo(status *BackupStatus) *v1beta1.BackupStatus {
+	if status == nil {
+		return nil
+	}
+	out := &v1beta1.BackupStatus{}
+	for _, store := range status.Stores {
+		out.Stores = append(out.Stores, v1beta1.BackupStoreStatus{
+			Schedule:       store.Schedule,
+			Store:          v1beta1.BackupStore(store.Store),
+			Name:           store.Name,
+			State:          store.State,
+			StartTime:      store.StartTime,
+			CompletionTime: store.CompletionTime,
+			Message:        store.Message,
+		})
+	}
+	return out
+}
+
+func convertKeycloakStatusTo(status *KeycloakStatus) *v1beta1.KeycloakStatus {
+	if status == nil {
+		return nil
+	}
+	out := &v1beta1.KeycloakStatus{}
+	for _, realm := range status.Realms {
+		out.Realms = append(out.Realms, v1beta1.KeycloakRealmStatus(realm))
+	}
+	return out
+}
+
+func convertMySQLBackupTo(backup *MySQLBackupSpec) *v1beta1.MySQLBackupSpec {
+	if backup == nil {
+		return nil
+	}
+	return &v1beta1.MySQLBackupSpec{
+		Schedule:              backup.Schedule,
+		RetentionCount:        backup.RetentionCount,
+		PersistentVolumeClaim: backup.PersistentVolumeClaim,
+		S3:                    (*v1beta1.BackupStorage)(backup.S3),
+		PreUpgrade:            backup.PreUpgrade,
+	}
+}
+
+func convertIngressSecurityTo(security *IngressSecuritySpec) *v1beta1.IngressSecuritySpec {
+	if security == nil {
+		return nil
+	}
+	return &v1beta1.IngressSecuritySpec{
+		TLS:            (*v1beta1.IngressTLSSpec)(security.TLS),
+		HSTS:           (*v1beta1.HSTSSpec)(security.HSTS),
+		ModSecurity:    convertModSecurityTo(security.ModSecurity),
+		RateLimit:      (*v1beta1.RateLimitSpec)(security.RateLimit),
+		ClientBodySize: security.ClientBodySize,
+	}
+}
+
+func convertModSecurityTo(modSecurity *ModSecuritySpec) *v1beta1.ModSecuritySpec {
+	if modSecurity == nil {
+		return nil
+	}
+	return &v1beta1.ModSecuritySpec{
+		Enabled:          modSecurity.Enabled,
+		OWASPCoreRuleSet: modSecurity.OWASPCoreRuleSet,
+		Mode:             v1beta1.ModSecurityMode(modSecurity.Mode),
+	}
+}
+
+func convertMySQLStatusTo(status *MySQLStatus) *v1beta1.MySQLStatus {
+	if status == nil {
+		return nil
+	}
+	return &v1beta1.MySQLStatus{
+		LastBackup: (*v1beta1.MySQLBackupStatus)(status.LastBackup),
+		Restore:    (*v1beta1.MySQLRestoreStatus)(status.Restore),
+	}
+}
+
+func convertCertificatesStatusTo(status *CertificatesStatus) *v1beta1.CertificatesStatus {
+	if status == nil {
+		return nil
+	}
+	out := &v1beta1.CertificatesStatus{
+		CARotation: (*v1beta1.CARotationStatus)(status.CARotation),
+	}
+	for _, expiry := range status.Expiry {
+		out.Expiry = append(out.Expiry, v1beta1.CertificateExpiryStatus(expiry))
+	}
+	return out
+}
+
+func convertWorkloadTo(workload *WorkloadSpec) *v1beta1.WorkloadSpec {
+	if workload == nil {
+		return nil
 	}
+	out := v1beta1.WorkloadSpec(*workload)
+	return &out
 }
 
 func ConvertInstallOverridesWithArgsToV1Beta1(args []InstallArgs, overrides InstallOverrides) (v1beta1.InstallOverrides, error) {
@@ -878,6 +1276,7 @@ func ConvertValueOverridesToV1Beta1(overrides []Overrides) []v1beta1.Overrides {
 			ConfigMapRef: override.ConfigMapRef,
 			SecretRef:    override.SecretRef,
 			Values:       override.Values.DeepCopy(),
+			Template:     override.Template,
 		})
 	}
 	return out
@@ -366,6 +366,86 @@ func TestConvertToV1Beta1(t *testing.T) {
 			testCaseRancherKeycloak,
 			false,
 		},
+		{
+			"convert keycloak realms from v1alpha1",
+			testCaseKeycloakRealms,
+			false,
+		},
+		{
+			"convert authproxy oidc from v1alpha1",
+			testCaseAuthProxyOIDC,
+			false,
+		},
+		{
+			"convert opensearch snapshots from v1alpha1",
+			testCaseOpenSearchSnaps,
+			false,
+		},
+		{
+			"convert fluentd outputs from v1alpha1",
+			testCaseFluentdOutputs,
+			false,
+		},
+		{
+			"convert ACME server from v1alpha1",
+			testCaseAcmeServer,
+			false,
+		},
+		{
+			"convert RFC2136 DNS from v1alpha1",
+			testCaseRFC2136DNS,
+			false,
+		},
+		{
+			"converts Istio revision upgrade",
+			testCaseIstioRevision,
+			false,
+		},
+		{
+			"converts Alertmanager",
+			testCaseAlertmanager,
+			false,
+		},
+		{
+			"converts Jaeger storage and sampling",
+			testCaseJaegerStorage,
+			false,
+		},
+		{
+			"converts scheduled backups",
+			testCaseBackup,
+			false,
+		},
+		{
+			"converts component workload settings",
+			testCaseWorkload,
+			false,
+		},
+		{
+			"converts the network policies of the system namespaces",
+			testCaseNetworkPolicies,
+			false,
+		},
+		{
+			"converts the high availability mode",
+			testCaseHighAvailability,
+			false,
+		},
+		{
+			"converts the Keycloak MySQL backups and restore",
+			testCaseMySQLBackup,
+			false,
+		},
+		{
+			"converts the security settings of the ingress controller",
+			testCaseIngressSecurity,
+			false,
+		},
+		{
+			"converts the CA rotation and the certificates status",
+			testCaseCARotation,
+			false,
+		},
 		{
 			"convert volume claim templates from v1alpha1",
 			testCaseVolumeOverrides,
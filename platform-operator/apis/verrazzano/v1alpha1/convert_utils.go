@@ -27,6 +27,22 @@ const (
 	testCaseInstallArgsErr    = "frominstallargserr"
 	testCaseToAllComps        = "toallcomps"
 	testCaseRancherKeycloak   = "rancherkeycloak"
+	testCaseKeycloakRealms    = "keycloakrealms"
+	testCaseAuthProxyOIDC     = "authproxyoidc"
+	testCaseOpenSearchSnaps   = "opensearchsnapshots"
+	testCaseFluentdOutputs    = "fluentdoutputs"
+	testCaseAcmeServer        = "acmeserver"
+	testCaseRFC2136DNS        = "rfc2136dns"
+	testCaseIstioRevision     = "istiorevisionupgrade"
+	testCaseAlertmanager      = "alertmanager"
+	testCaseJaegerStorage     = "jaegerstorage"
+	testCaseBackup            = "backup"
+	testCaseWorkload          = "workload"
+	testCaseNetworkPolicies   = "networkpolicies"
+	testCaseHighAvailability  = "highavailability"
+	testCaseMySQLBackup       = "mysqlbackup"
+	testCaseIngressSecurity   = "ingresssecurity"
+	testCaseCARotation        = "carotation"
 	testCaseVolumeOverrides   = "volumeoverrides"
 	testCaseGeneralOverrides  = "overrides"
 	testBaseProfile           = "base"
@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/yaml"
 	"testing"
+	"time"
 )
 
 // For unit testing
@@ -1422,6 +1423,189 @@ func TestValidateProfileInvalidProfile(t *testing.T) {
 	assert.Error(t, ValidateProfile("wrong-profile"))
 }
 
+// TestValidateMaintenanceWindow Tests ValidateMaintenanceWindow()
+// GIVEN a request with a maintenance window
+// WHEN the schedule and duration are valid
+// THEN no error is returned, otherwise an error is returned
+func TestValidateMaintenanceWindow(t *testing.T) {
+	assert.NoError(t, ValidateMaintenanceWindow(nil))
+	assert.NoError(t, ValidateMaintenanceWindow(&MaintenanceWindow{
+		Schedule: "0 2 * * SAT",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+		TimeZone: "UTC",
+	}))
+	assert.Error(t, ValidateMaintenanceWindow(&MaintenanceWindow{
+		Schedule: "every saturday",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}))
+	assert.Error(t, ValidateMaintenanceWindow(&MaintenanceWindow{
+		Schedule: "0 2 * * SAT",
+	}))
+}
+
+// TestValidateBackup Tests ValidateBackup()
+// GIVEN a request with scheduled backups
+// WHEN the storage, the schedules and the components of the requested stores are valid
+// THEN no error is returned, otherwise an error is returned
+func TestValidateBackup(t *testing.T) {
+	enabled := true
+	newSpec := func(update func(spec *VerrazzanoSpec)) *VerrazzanoSpec {
+		spec := &VerrazzanoSpec{
+			Backup: &BackupSpec{
+				Storage: BackupStorage{
+					Endpoint:          "http://minio.minio:9000",
+					Bucket:            "verrazzano",
+					CredentialsSecret: "backup-credentials",
+				},
+				Schedules: []BackupSchedule{{Name: "daily", Schedule: "0 1 * * *"}},
+			},
+		}
+		update(spec)
+		return spec
+	}
+	tests := []struct {
+		name    string
+		spec    *VerrazzanoSpec
+		wantErr bool
+	}{
+		{"no backup", &VerrazzanoSpec{}, false},
+		{"default stores", newSpec(func(spec *VerrazzanoSpec) {}), false},
+		{"missing bucket", newSpec(func(spec *VerrazzanoSpec) { spec.Backup.Storage.Bucket = "" }), true},
+		{"missing credentials secret", newSpec(func(spec *VerrazzanoSpec) { spec.Backup.Storage.CredentialsSecret = "" }), true},
+		{"endpoint without scheme", newSpec(func(spec *VerrazzanoSpec) { spec.Backup.Storage.Endpoint = "minio.minio:9000" }), true},
+		{"invalid schedule", newSpec(func(spec *VerrazzanoSpec) { spec.Backup.Schedules[0].Schedule = "daily" }), true},
+		{"duplicate schedule names", newSpec(func(spec *VerrazzanoSpec) {
+			spec.Backup.Schedules = append(spec.Backup.Schedules, BackupSchedule{Name: "daily", Schedule: "0 2 * * *"})
+		}), true},
+		{"Velero not enabled", newSpec(func(spec *VerrazzanoSpec) {
+			spec.Backup.Schedules[0].Stores = []BackupStore{BackupStoreKeycloakMySQL}
+		}), true},
+		{"Velero enabled", newSpec(func(spec *VerrazzanoSpec) {
+			spec.Backup.Schedules[0].Stores = []BackupStore{BackupStoreKeycloakMySQL, BackupStorePlatformNamespaces}
+			spec.Components.Velero = &VeleroComponent{Enabled: &enabled}
+		}), false},
+		{"Rancher Backup not enabled", newSpec(func(spec *VerrazzanoSpec) {
+			spec.Backup.Schedules[0].Stores = []BackupStore{BackupStoreRancher}
+		}), true},
+		{"Rancher Backup enabled", newSpec(func(spec *VerrazzanoSpec) {
+			spec.Backup.Schedules[0].Stores = []BackupStore{BackupStoreRancher, BackupStoreOpenSearch}
+			spec.Components.RancherBackup = &RancherBackupComponent{Enabled: &enabled}
+		}), false},
+		{"different OpenSearch snapshot credentials", newSpec(func(spec *VerrazzanoSpec) {
+			spec.Components.Elasticsearch = &ElasticsearchComponent{Snapshots: &OpenSearchSnapshots{S3CredentialsSecret: "other"}}
+		}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBackup(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestValidateNetworkPolicies Tests ValidateNetworkPolicies()
+// GIVEN a request with additional network policies
+// WHEN the policies are in the system namespaces and their names are unique and not reserved
+// THEN no error is returned, otherwise an error is returned
+func TestValidateNetworkPolicies(t *testing.T) {
+	enabled := true
+	newSpec := func(policies ...AdditionalNetworkPolicy) *VerrazzanoSpec {
+		return &VerrazzanoSpec{
+			Security: SecuritySpec{
+				NetworkPolicies: &NetworkPoliciesSpec{Enabled: &enabled, AdditionalPolicies: policies},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		spec    *VerrazzanoSpec
+		wantErr bool
+	}{
+		{"no network policies", &VerrazzanoSpec{}, false},
+		{"no additional policies", newSpec(), false},
+		{"valid policies", newSpec(
+			AdditionalNetworkPolicy{Name: "allow-app", Namespace: "keycloak"},
+			AdditionalNetworkPolicy{Name: "allow-app", Namespace: "verrazzano-system"},
+		), false},
+		{"not a system namespace", newSpec(AdditionalNetworkPolicy{Name: "allow-app", Namespace: "default"}), true},
+		{"reserved name", newSpec(AdditionalNetworkPolicy{Name: "verrazzano-allow-app", Namespace: "keycloak"}), true},
+		{"duplicate names", newSpec(
+			AdditionalNetworkPolicy{Name: "allow-app", Namespace: "keycloak"},
+			AdditionalNetworkPolicy{Name: "allow-app", Namespace: "keycloak"},
+		), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNetworkPolicies(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestValidateKeycloakRealms Tests ValidateKeycloakRealms()
+// GIVEN a request with declared Keycloak realms
+// WHEN the realms, groups, users and clients have the required fields, are unique and the realm names are not reserved
+// THEN no error is returned, otherwise an error is returned
+func TestValidateKeycloakRealms(t *testing.T) {
+	password := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "app-user"}, Key: "password"}
+	newSpec := func(realms ...KeycloakRealm) *VerrazzanoSpec {
+		return &VerrazzanoSpec{
+			Components: ComponentSpec{
+				Keycloak: &KeycloakComponent{Realms: realms},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		spec    *VerrazzanoSpec
+		wantErr bool
+	}{
+		{"no Keycloak", &VerrazzanoSpec{}, false},
+		{"valid realm", newSpec(KeycloakRealm{
+			Name:    "apps",
+			Groups:  []KeycloakRealmGroup{{Name: "users"}, {Name: "admins", Parent: "users"}, {Name: "admins"}},
+			Users:   []KeycloakRealmUser{{Username: "app-user", Groups: []string{"users", "/users/admins"}, PasswordSecretRef: password}},
+			Clients: []KeycloakRealmClient{{ClientID: "app"}},
+		}), false},
+		{"missing realm name", newSpec(KeycloakRealm{}), true},
+		{"reserved realm name", newSpec(KeycloakRealm{Name: "master"}), true},
+		{"duplicate realm names", newSpec(KeycloakRealm{Name: "apps"}, KeycloakRealm{Name: "apps"}), true},
+		{"missing group name", newSpec(KeycloakRealm{Name: "apps", Groups: []KeycloakRealmGroup{{Parent: "users"}}}), true},
+		{"group name with a slash", newSpec(KeycloakRealm{Name: "apps", Groups: []KeycloakRealmGroup{{Name: "users/admins"}}}), true},
+		{"duplicate groups", newSpec(KeycloakRealm{Name: "apps", Groups: []KeycloakRealmGroup{{Name: "users"}, {Name: "users"}}}), true},
+		{"missing user name", newSpec(KeycloakRealm{Name: "apps", Users: []KeycloakRealmUser{{PasswordSecretRef: password}}}), true},
+		{"missing password key", newSpec(KeycloakRealm{Name: "apps", Users: []KeycloakRealmUser{{Username: "app-user",
+			PasswordSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "app-user"}}}}}), true},
+		{"duplicate users", newSpec(KeycloakRealm{Name: "apps", Users: []KeycloakRealmUser{
+			{Username: "app-user", PasswordSecretRef: password}, {Username: "app-user", PasswordSecretRef: password}}}), true},
+		{"ambiguous user group", newSpec(KeycloakRealm{
+			Name:   "apps",
+			Groups: []KeycloakRealmGroup{{Name: "users"}, {Name: "admins", Parent: "users"}, {Name: "admins"}},
+			Users:  []KeycloakRealmUser{{Username: "app-user", Groups: []string{"admins"}, PasswordSecretRef: password}},
+		}), true},
+		{"missing client ID", newSpec(KeycloakRealm{Name: "apps", Clients: []KeycloakRealmClient{{Public: true}}}), true},
+		{"duplicate clients", newSpec(KeycloakRealm{Name: "apps", Clients: []KeycloakRealmClient{{ClientID: "app"}, {ClientID: "app"}}}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateKeycloakRealms(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateInstallOverrides(t *testing.T) {
 	assert := assert.New(t)
 
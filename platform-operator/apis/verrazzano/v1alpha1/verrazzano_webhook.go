@@ -81,6 +81,22 @@ func (v *Verrazzano) ValidateCreate() error {
 		return err
 	}
 
+	if err := ValidateMaintenanceWindow(v.Spec.MaintenanceWindow); err != nil {
+		return err
+	}
+
+	if err := ValidateBackup(&v.Spec); err != nil {
+		return err
+	}
+
+	if err := ValidateNetworkPolicies(&v.Spec); err != nil {
+		return err
+	}
+
+	if err := ValidateKeycloakRealms(&v.Spec); err != nil {
+		return err
+	}
+
 	if err := validateOCISecrets(client, &v.Spec); err != nil {
 		return err
 	}
@@ -123,6 +139,22 @@ func (v *Verrazzano) ValidateUpdate(old runtime.Object) error {
 		return fmt.Errorf("Profile change is not allowed oldResource %s to %s", oldResource.Spec.Profile, v.Spec.Profile)
 	}
 
+	if err := ValidateMaintenanceWindow(v.Spec.MaintenanceWindow); err != nil {
+		return err
+	}
+
+	if err := ValidateBackup(&v.Spec); err != nil {
+		return err
+	}
+
+	if err := ValidateNetworkPolicies(&v.Spec); err != nil {
+		return err
+	}
+
+	if err := ValidateKeycloakRealms(&v.Spec); err != nil {
+		return err
+	}
+
 	// Check to see if the update is an upgrade request, and if it is valid and allowable
 	newSpecVerString := strings.TrimSpace(v.Spec.Version)
 	currStatusVerString := strings.TrimSpace(oldResource.Status.Version)
@@ -6,9 +6,15 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"github.com/robfig/cron/v3"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/maintenance"
 	corev1 "k8s.io/api/core/v1"
+	"net/url"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 )
 
 // ValidateProfile check that requestedProfile is valid
@@ -25,6 +31,163 @@ func ValidateProfile(requestedProfile ProfileType) error {
 	return nil
 }
 
+// ValidateMaintenanceWindow checks that the maintenance window, if specified, has a valid schedule, duration and time zone
+func ValidateMaintenanceWindow(window *MaintenanceWindow) error {
+	if window == nil {
+		return nil
+	}
+	_, err := maintenance.NewWindow(window.Schedule, window.Duration.Duration, window.TimeZone)
+	return err
+}
+
+// ValidateBackup check that the scheduled backups, if specified, have a valid storage location and schedules, and that
+// the components backing up the stores that are explicitly requested are enabled
+func ValidateBackup(spec *VerrazzanoSpec) error {
+	backup := spec.Backup
+	if backup == nil {
+		return nil
+	}
+	storage := backup.Storage
+	if storage.Bucket == "" || storage.CredentialsSecret == "" {
+		return fmt.Errorf("The backup storage must specify a bucket and a credentials secret")
+	}
+	if endpoint, err := url.Parse(storage.Endpoint); err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("The backup storage endpoint \"%s\" must be a http or https URL", storage.Endpoint)
+	}
+	names := map[string]bool{}
+	for _, schedule := range backup.Schedules {
+		if names[schedule.Name] {
+			return fmt.Errorf("The backup schedule name %s is not unique", schedule.Name)
+		}
+		names[schedule.Name] = true
+		if _, err := cron.ParseStandard(schedule.Schedule); err != nil {
+			return fmt.Errorf("The schedule \"%s\" of backup schedule %s is invalid: %v", schedule.Schedule, schedule.Name, err)
+		}
+		for _, store := range schedule.Stores {
+			switch store {
+			case BackupStoreKeycloakMySQL, BackupStorePlatformNamespaces:
+				if spec.Components.Velero == nil || spec.Components.Velero.Enabled == nil || !*spec.Components.Velero.Enabled {
+					return fmt.Errorf("Velero must be enabled to back up the %s store of backup schedule %s", store, schedule.Name)
+				}
+			case BackupStoreRancher:
+				if spec.Components.RancherBackup == nil || spec.Components.RancherBackup.Enabled == nil || !*spec.Components.RancherBackup.Enabled {
+					return fmt.Errorf("Rancher Backup must be enabled to back up the %s store of backup schedule %s", store, schedule.Name)
+				}
+			}
+		}
+	}
+	// The OpenSearch nodes have a single set of S3 credentials, shared by the snapshot repositories
+	if opensearch := spec.Components.OpenSearch; opensearch != nil && opensearch.Snapshots != nil &&
+		opensearch.Snapshots.S3CredentialsSecret != "" && opensearch.Snapshots.S3CredentialsSecret != storage.CredentialsSecret {
+		return fmt.Errorf("The OpenSearch snapshot credentials secret %s must be the backup credentials secret %s",
+			opensearch.Snapshots.S3CredentialsSecret, storage.CredentialsSecret)
+	}
+	return nil
+}
+
+// ValidateNetworkPolicies check that the additional network policies, if specified, are in the system namespaces
+// protected by the network policies of the platform, and that their names are unique and not reserved
+func ValidateNetworkPolicies(spec *VerrazzanoSpec) error {
+	policies := spec.Security.NetworkPolicies
+	if policies == nil {
+		return nil
+	}
+	names := map[string]bool{}
+	for _, policy := range policies.AdditionalPolicies {
+		if !vzstring.SliceContainsString(vzconst.NetworkPolicyNamespaces, policy.Namespace) {
+			return fmt.Errorf("The namespace \"%s\" of network policy %s must be one of %s", policy.Namespace, policy.Name,
+				strings.Join(vzconst.NetworkPolicyNamespaces, ", "))
+		}
+		if strings.HasPrefix(policy.Name, "verrazzano-") {
+			return fmt.Errorf("The network policy name %s is invalid, the names starting with verrazzano- are reserved", policy.Name)
+		}
+		key := policy.Namespace + "/" + policy.Name
+		if names[key] {
+			return fmt.Errorf("The network policy name %s is not unique in namespace %s", policy.Name, policy.Namespace)
+		}
+		names[key] = true
+	}
+	return nil
+}
+
+// reservedKeycloakRealms are the realms managed by Keycloak and Verrazzano that cannot be declared
+var reservedKeycloakRealms = []string{"master", "verrazzano-system"}
+
+// ValidateKeycloakRealms check that the declared Keycloak realms, if specified, have unique names that are not reserved,
+// and that their groups, users and clients have the required fields and are unique
+func ValidateKeycloakRealms(spec *VerrazzanoSpec) error {
+	keycloak := spec.Components.Keycloak
+	if keycloak == nil {
+		return nil
+	}
+	realms := map[string]bool{}
+	for _, realm := range keycloak.Realms {
+		if realm.Name == "" {
+			return fmt.Errorf("The Keycloak realm name is required")
+		}
+		if vzstring.SliceContainsString(reservedKeycloakRealms, realm.Name) {
+			return fmt.Errorf("The Keycloak realm name %s is reserved", realm.Name)
+		}
+		if realms[realm.Name] {
+			return fmt.Errorf("The Keycloak realm name %s is not unique", realm.Name)
+		}
+		realms[realm.Name] = true
+		if err := validateKeycloakRealm(realm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateKeycloakRealm check the groups, users and clients of a declared Keycloak realm
+func validateKeycloakRealm(realm KeycloakRealm) error {
+	groupPaths := map[string]bool{}
+	groupNames := map[string]int{}
+	for _, group := range realm.Groups {
+		if group.Name == "" || strings.Contains(group.Name, "/") || strings.Contains(group.Parent, "/") {
+			return fmt.Errorf("The group names of Keycloak realm %s are required and cannot contain /", realm.Name)
+		}
+		path := "/" + group.Name
+		if group.Parent != "" {
+			path = "/" + group.Parent + path
+		}
+		if groupPaths[path] {
+			return fmt.Errorf("The group %s of Keycloak realm %s is not unique", path, realm.Name)
+		}
+		groupPaths[path] = true
+		groupNames[group.Name]++
+	}
+	users := map[string]bool{}
+	for _, user := range realm.Users {
+		if user.Username == "" {
+			return fmt.Errorf("The user names of Keycloak realm %s are required", realm.Name)
+		}
+		if users[user.Username] {
+			return fmt.Errorf("The user %s of Keycloak realm %s is not unique", user.Username, realm.Name)
+		}
+		users[user.Username] = true
+		if user.PasswordSecretRef.Name == "" || user.PasswordSecretRef.Key == "" {
+			return fmt.Errorf("The password secret of user %s of Keycloak realm %s must specify a name and a key", user.Username, realm.Name)
+		}
+		for _, group := range user.Groups {
+			if groupNames[group] > 1 {
+				return fmt.Errorf("The group %s of user %s of Keycloak realm %s is ambiguous, the path of the group is required", group, user.Username, realm.Name)
+			}
+		}
+	}
+	clients := map[string]bool{}
+	for _, client := range realm.Clients {
+		if client.ClientID == "" {
+			return fmt.Errorf("The client IDs of Keycloak realm %s are required", realm.Name)
+		}
+		if clients[client.ClientID] {
+			return fmt.Errorf("The client %s of Keycloak realm %s is not unique", client.ClientID, realm.Name)
+		}
+		clients[client.ClientID] = true
+	}
+	return nil
+}
+
 // ValidateActiveInstall enforces that only one install of Verrazzano is allowed.
 func ValidateActiveInstall(client client.Client) error {
 	vzList := &VerrazzanoList{}
@@ -6,6 +6,7 @@ package v1beta1
 import (
 	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +23,21 @@ const (
 	// ManagedCluster identifies the production managed-cluster install profile
 	ManagedCluster ProfileType = "managed-cluster"
 )
+
+// SizeType is the name of a size preset, which sets the resources and replicas of the components for a cluster size.
+// +kubebuilder:validation:Enum=small;medium;large
+type SizeType string
+
+const (
+	// SizeSmall sizes the components for a small cluster, such as a development or test cluster
+	SizeSmall SizeType = "small"
+	// SizeMedium sizes the components for a medium cluster
+	SizeMedium SizeType = "medium"
+	// SizeLarge sizes the components for a large cluster, with more replicas of the components that handle the
+	// cluster traffic
+	SizeLarge SizeType = "large"
+)
+
 const (
 	// LoadBalancer is an ingress type of LoadBalancer.  This is the default value.
 	LoadBalancer IngressType = "LoadBalancer"
@@ -64,6 +80,14 @@ type VerrazzanoSpec struct {
 	// Profile is the name of the profile to install.  Default is "prod".
 	// +optional
 	Profile ProfileType `json:"profile,omitempty"`
+	// Size is the name of the size preset applied on top of the profile, the workload settings of the components set
+	// in this resource take precedence over the preset.
+	// +optional
+	Size SizeType `json:"size,omitempty"`
+	// HighAvailability enables the high availability mode, a preset applied on top of the profile and before the size
+	// preset, the settings of the components set in this resource take precedence over the preset.
+	// +optional
+	HighAvailability *HighAvailabilitySpec `json:"highAvailability,omitempty"`
 	// EnvironmentName identifies install environment.  Default environment name is "default".
 	// +optional
 	EnvironmentName string `json:"environmentName,omitempty"`
@@ -87,6 +111,103 @@ type VerrazzanoSpec struct {
 	// +optional
 	// +patchStrategy=merge,retainKeys
 	VolumeClaimSpecTemplates []VolumeClaimSpecTemplate `json:"volumeClaimSpecTemplates,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+	// MaintenanceWindow Defines a recurring window in which disruptive operations, such as upgrades, Helm upgrades
+	// triggered by configuration changes and restarts of workloads with old Istio sidecars, are allowed to run.  When
+	// not specified, disruptive operations are performed as soon as they are requested.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// Backup Defines the scheduled backups of the Verrazzano platform data.  Velero must be enabled to back up the
+	// Keycloak MySQL database and the platform namespaces, and rancherBackup must be enabled to back up Rancher.
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+}
+
+// HighAvailabilitySpec Defines the high availability mode of the platform.  When enabled, the stateless components
+// run at least two replicas spread across the nodes and zones of the cluster, and pod disruption budgets allow only one
+// pod of each component with at least two replicas, including the OpenSearch master statefulset, to be evicted at a
+// time.  The HighAvailabilityDegraded condition is set while the nodes of the cluster cannot satisfy the mode.
+type HighAvailabilitySpec struct {
+	// Enabled enables the high availability mode.  Default is false.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// MaintenanceWindow Defines a recurring window in which disruptive operations are allowed to run
+type MaintenanceWindow struct {
+	// Schedule is a cron expression, in the standard five field format, for the start of each window; for
+	// example "0 2 * * SAT" starts a window every Saturday at 02:00
+	Schedule string `json:"schedule"`
+	// Duration is the length of each window, for example "4h"
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone name used to evaluate the schedule.  Default is "UTC".
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// BackupStore identifies a store of platform data that is backed up
+// +kubebuilder:validation:Enum=KeycloakMySQL;OpenSearch;Rancher;PlatformNamespaces
+type BackupStore string
+
+const (
+	// BackupStoreKeycloakMySQL is the Keycloak MySQL database, backed up by Velero
+	BackupStoreKeycloakMySQL BackupStore = "KeycloakMySQL"
+	// BackupStoreOpenSearch is the OpenSearch data, backed up by OpenSearch snapshots
+	BackupStoreOpenSearch BackupStore = "OpenSearch"
+	// BackupStoreRancher is the Rancher configuration, backed up by rancher-backup
+	BackupStoreRancher BackupStore = "Rancher"
+	// BackupStorePlatformNamespaces is the Kubernetes resources of the Verrazzano system namespaces, backed up by Velero
+	BackupStorePlatformNamespaces BackupStore = "PlatformNamespaces"
+)
+
+// BackupSpec Defines the scheduled backups of the Verrazzano platform data
+type BackupSpec struct {
+	// Storage is the S3 compatible object storage location of the backups
+	Storage BackupStorage `json:"storage"`
+	// Schedules are the backup schedules
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	Schedules []BackupSchedule `json:"schedules" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// BackupStorage Defines the S3 compatible object storage location of the backups, each store is backed up under its
+// own path in the bucket
+type BackupStorage struct {
+	// Endpoint is the URL of the S3 compatible API, for example "https://s3.us-east-1.amazonaws.com" or
+	// "http://minio.minio:9000"
+	Endpoint string `json:"endpoint"`
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// Prefix is the path in the bucket under which the backups are stored
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Region of the bucket.  Default is "us-east-1".
+	// +optional
+	Region string `json:"region,omitempty"`
+	// PathStyleAccess addresses the bucket in the path of the URL rather than in the host name, as required by MinIO
+	// +optional
+	PathStyleAccess bool `json:"pathStyleAccess,omitempty"`
+	// CredentialsSecret is the name of a secret in the namespace of the Verrazzano resource that holds the access key
+	// and the secret key of the bucket, under the keys "object_store_access_key" and "object_store_secret_key"
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// BackupSchedule Defines a recurring backup of a set of stores
+type BackupSchedule struct {
+	// Name of the schedule
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// Schedule is a cron expression, in the standard five field format, for the start of each backup; for example
+	// "0 1 * * *" backs up every day at 01:00 UTC
+	Schedule string `json:"schedule"`
+	// RetentionDays is the number of days the backups are kept.  Rancher and OpenSearch keep a number of backups
+	// rather than an age, enough backups to cover the retention period are kept.  Default is 30.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RetentionDays *int32 `json:"retentionDays,omitempty"`
+	// Stores are the stores that are backed up.  Default is all the stores whose components are enabled.
+	// +optional
+	Stores []BackupStore `json:"stores,omitempty"`
 }
 
 // SecuritySpec defines the security configuration for Verrazzano
@@ -97,6 +218,34 @@ type SecuritySpec struct {
 	// MonitorSubjects specifies subjects that should be bound to the verrazzano-monitor role
 	// +optional
 	MonitorSubjects []rbacv1.Subject `json:"monitorSubjects,omitempty"`
+	// NetworkPolicies defines the network policies of the Verrazzano system namespaces
+	// +optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+}
+
+// NetworkPoliciesSpec defines the network policies of the Verrazzano system namespaces.  When enabled, all the
+// traffic of the keycloak, cattle-system, verrazzano-system, verrazzano-monitoring, ingress-nginx and istio-system
+// namespaces is denied, except for the traffic within each namespace and the traffic required by the components.
+type NetworkPoliciesSpec struct {
+	// Enabled specifies whether the network policies of the system namespaces are created, the default is false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// AdditionalPolicies are network policies created in the system namespaces in addition to the policies of the
+	// components, to allow the traffic of user workloads with the Verrazzano system pods
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	AdditionalPolicies []AdditionalNetworkPolicy `json:"additionalPolicies,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// AdditionalNetworkPolicy defines a network policy of a system namespace
+type AdditionalNetworkPolicy struct {
+	// Name is the name of the network policy, the names starting with verrazzano- are reserved
+	Name string `json:"name"`
+	// Namespace is the system namespace of the network policy
+	Namespace string `json:"namespace"`
+	// Spec is the specification of the network policy
+	Spec netv1.NetworkPolicySpec `json:"spec"`
 }
 
 // VolumeClaimSpecTemplate Contains common PVC configuration that can be referenced from Components; these
@@ -144,6 +293,175 @@ type VerrazzanoStatus struct {
 	State VzStateType `json:"state,omitempty"`
 	// States of the individual installed components
 	Components ComponentStatusMap `json:"components,omitempty"`
+	// Information about disruptive operations deferred until the next maintenance window
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+	// Information about the scheduled OpenSearch snapshots
+	OpenSearch *OpenSearchStatus `json:"openSearch,omitempty"`
+	// Information about the last scheduled backup of each store
+	Backup *BackupStatus `json:"backup,omitempty"`
+	// Information about the drift corrected in the declared Keycloak realms
+	Keycloak *KeycloakStatus `json:"keycloak,omitempty"`
+	// Information about the backups and the restore of the Keycloak MySQL database
+	MySQL *MySQLStatus `json:"mysql,omitempty"`
+	// Information about the expiry of the Verrazzano certificates and the rotation of the Verrazzano CA
+	Certificates *CertificatesStatus `json:"certificates,omitempty"`
+}
+
+// CertificatesStatus describes the expiry of the certificates issued for Verrazzano and the rotation of the
+// self-signed Verrazzano CA
+type CertificatesStatus struct {
+	// Expiry of the Verrazzano CA and of each certificate issued for Verrazzano
+	Expiry []CertificateExpiryStatus `json:"expiry,omitempty"`
+	// CARotation is the last rotation of the self-signed Verrazzano CA
+	CARotation *CARotationStatus `json:"caRotation,omitempty"`
+}
+
+// CertificateExpiryStatus describes when a certificate expires
+type CertificateExpiryStatus struct {
+	// Namespace of the secret of the certificate
+	Namespace string `json:"namespace"`
+	// SecretName is the name of the secret of the certificate
+	SecretName string `json:"secretName"`
+	// CA is true for the Verrazzano CA
+	CA bool `json:"ca,omitempty"`
+	// NotAfter is the expiry time of the certificate, in RFC3339 format
+	NotAfter string `json:"notAfter"`
+}
+
+// CARotationStatus describes a rotation of the self-signed Verrazzano CA
+type CARotationStatus struct {
+	// Trigger is the value of the rotation trigger when the rotation started
+	Trigger string `json:"trigger,omitempty"`
+	// Reason of the rotation, one of Requested or Expiring
+	Reason string `json:"reason,omitempty"`
+	// State of the rotation, one of Issuing, Distributing, Reissuing, Transition or Completed
+	State string `json:"state,omitempty"`
+	// PreviousCA is the common name of the CA that is retired
+	PreviousCA string `json:"previousCA,omitempty"`
+	// CurrentCA is the common name of the CA that replaces it
+	CurrentCA string `json:"currentCA,omitempty"`
+	// StartTime of the rotation, in RFC3339 format
+	StartTime string `json:"startTime,omitempty"`
+	// StateTime is when the rotation entered its current state, in RFC3339 format
+	StateTime string `json:"stateTime,omitempty"`
+	// CompletionTime of the rotation, in RFC3339 format
+	CompletionTime string `json:"completionTime,omitempty"`
+	// Message is a human readable description of the progress of the rotation
+	Message string `json:"message,omitempty"`
+}
+
+// MySQLStatus describes the observed state of the backups and the restore of the Keycloak MySQL database
+type MySQLStatus struct {
+	// LastBackup is the last scheduled or pre-upgrade backup
+	LastBackup *MySQLBackupStatus `json:"lastBackup,omitempty"`
+	// Restore is the last restore
+	Restore *MySQLRestoreStatus `json:"restore,omitempty"`
+}
+
+// MySQLBackupStatus describes a backup of the Keycloak MySQL database
+type MySQLBackupStatus struct {
+	// Name of the backup
+	Name string `json:"name"`
+	// State of the backup, one of InProgress, Completed or Failed
+	State string `json:"state,omitempty"`
+	// StartTime of the backup, in RFC3339 format
+	StartTime string `json:"startTime,omitempty"`
+	// CompletionTime of the backup, in RFC3339 format
+	CompletionTime string `json:"completionTime,omitempty"`
+	// Message is a human readable description of a failed backup
+	Message string `json:"message,omitempty"`
+}
+
+// MySQLRestoreStatus describes a restore of the Keycloak MySQL database
+type MySQLRestoreStatus struct {
+	// BackupName is the name of the backup that is restored
+	BackupName string `json:"backupName"`
+	// PointInTime is the time up to which the binary log is replayed, in RFC3339 format
+	PointInTime string `json:"pointInTime,omitempty"`
+	// State of the restore, one of InProgress, Completed or Failed
+	State string `json:"state,omitempty"`
+	// StartTime of the restore, in RFC3339 format
+	StartTime string `json:"startTime,omitempty"`
+	// CompletionTime of the restore, in RFC3339 format
+	CompletionTime string `json:"completionTime,omitempty"`
+	// Message is a human readable description of a failed restore
+	Message string `json:"message,omitempty"`
+}
+
+// KeycloakStatus describes the observed state of the declared Keycloak realms
+type KeycloakStatus struct {
+	// Drift last corrected in each declared realm
+	Realms []KeycloakRealmStatus `json:"realms,omitempty"`
+}
+
+// KeycloakRealmStatus describes the last drift from the declared configuration that was corrected in a Keycloak realm
+type KeycloakRealmStatus struct {
+	// Name of the realm
+	Name string `json:"name"`
+	// Drift lists the changes made outside of Verrazzano that were last reverted in the realm
+	Drift []string `json:"drift,omitempty"`
+	// DriftTime is when the drift was last corrected, in RFC3339 format
+	DriftTime string `json:"driftTime,omitempty"`
+}
+
+// BackupStatus describes the observed state of the scheduled backups
+type BackupStatus struct {
+	// Status of the last backup of each store of each backup schedule
+	Stores []BackupStoreStatus `json:"stores,omitempty"`
+}
+
+// BackupStoreStatus describes the last backup of a store by a backup schedule
+type BackupStoreStatus struct {
+	// Name of the backup schedule
+	Schedule string `json:"schedule"`
+	// Store that is backed up
+	Store BackupStore `json:"store"`
+	// Name of the last backup
+	Name string `json:"name,omitempty"`
+	// State of the last backup, one of InProgress, Completed, PartiallyFailed or Failed
+	State string `json:"state,omitempty"`
+	// StartTime of the last backup, in RFC3339 format
+	StartTime string `json:"startTime,omitempty"`
+	// CompletionTime of the last backup, in RFC3339 format
+	CompletionTime string `json:"completionTime,omitempty"`
+	// Message is a human readable description of a failed backup
+	Message string `json:"message,omitempty"`
+}
+
+// MaintenanceStatus describes the disruptive operations waiting for a maintenance window
+type MaintenanceStatus struct {
+	// DeferredUntil is the start time of the next maintenance window, in RFC3339 format
+	DeferredUntil string `json:"deferredUntil,omitempty"`
+	// DeferredOperations lists the operations waiting for the maintenance window
+	DeferredOperations []string `json:"deferredOperations,omitempty"`
+	// Message is a human readable description of the deferral
+	Message string `json:"message,omitempty"`
+}
+
+// OpenSearchStatus describes the observed state of the OpenSearch snapshots
+type OpenSearchStatus struct {
+	// Status of the last snapshot of each snapshot schedule
+	Snapshots []OpenSearchSnapshotStatus `json:"snapshots,omitempty"`
+}
+
+// OpenSearchSnapshotStatus describes the last snapshot of a snapshot schedule
+type OpenSearchSnapshotStatus struct {
+	// Name of the snapshot schedule
+	Schedule string `json:"schedule"`
+	// Name of the last snapshot
+	Name string `json:"name,omitempty"`
+	// Repository of the last snapshot
+	Repository string `json:"repository,omitempty"`
+	// State of the last snapshot, one of IN_PROGRESS, SUCCESS, PARTIAL or FAILED
+	State string `json:"state,omitempty"`
+	// StartTime of the last snapshot, in RFC3339 format
+	StartTime string `json:"startTime,omitempty"`
+	// EndTime of the last snapshot, in RFC3339 format
+	EndTime string `json:"endTime,omitempty"`
+	// Message is a human readable description of a failure
+	Message string `json:"message,omitempty"`
+	// NextSnapshotTime is when the next snapshot of the schedule is due, in RFC3339 format
+	NextSnapshotTime string `json:"nextSnapshotTime,omitempty"`
 }
 
 type ComponentStatusMap map[string]*ComponentStatusDetails
@@ -162,6 +480,8 @@ type ComponentStatusDetails struct {
 	LastReconciledGeneration int64 `json:"lastReconciledGeneration,omitempty"`
 	// The generation of the VZ resource the Component is currently being reconciled against
 	ReconcilingGeneration int64 `json:"reconcilingGeneration,omitempty"`
+	// The workload settings applied to the component when it was last installed or upgraded
+	Workload *WorkloadSpec `json:"workload,omitempty"`
 }
 
 // ConditionType identifies the condition of the install/uninstall/upgrade which can be checked with kubectl wait
@@ -200,6 +520,9 @@ const (
 
 	// CondUpgradeComplete means the upgrade has completed successfully
 	CondUpgradeComplete ConditionType = "UpgradeComplete"
+
+	// CondHighAvailabilityDegraded means the nodes of the cluster cannot satisfy the high availability mode
+	CondHighAvailabilityDegraded ConditionType = "HighAvailabilityDegraded"
 )
 
 // Condition describes current state of an install.
@@ -396,6 +719,14 @@ type OpenSearchComponent struct {
 	// +patchMergeKey=name
 	// +patchStrategy=merge,retainKeys
 	Nodes []OpenSearchNode `json:"nodes,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+	// ISM policies managing the application log indices of namespaces and projects
+	// +optional
+	// +patchMergeKey=policyName
+	// +patchStrategy=merge,retainKeys
+	NamespacePolicies []OpenSearchNamespacePolicy `json:"namespacePolicies,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"policyName"`
+	// Snapshot repositories and scheduled snapshots
+	// +optional
+	Snapshots *OpenSearchSnapshots `json:"snapshots,omitempty"`
 }
 
 //OpenSearchNode specifies a node group in the OpenSearch cluster
@@ -411,6 +742,98 @@ type OpenSearchNodeStorage struct {
 	Size string `json:"size"`
 }
 
+// OpenSearchNamespacePolicy is an ISM policy managing the application log indices of a set of namespaces
+type OpenSearchNamespacePolicy struct {
+	// Name of the policy
+	PolicyName string `json:"policyName"`
+	// Namespaces whose application log indices are managed by the policy
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Names of the VerrazzanoProjects whose namespaces have their application log indices managed by the policy
+	// +optional
+	Projects []string `json:"projects,omitempty"`
+	// Minimum age of an index before it is automatically deleted
+	// +kubebuilder:validation:Pattern:=^[0-9]+(d|h|m|s|ms|micros|nanos)$
+	// +optional
+	MinIndexAge *string `json:"minIndexAge,omitempty"`
+	// Rollover settings of the indices
+	// +optional
+	Rollover vmov1.RolloverPolicy `json:"rollover,omitempty"`
+}
+
+// OpenSearchSnapshots specifies the snapshot repositories and the scheduled snapshots of the OpenSearch cluster
+type OpenSearchSnapshots struct {
+	// Repositories registered with the OpenSearch cluster
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	Repositories []OpenSearchSnapshotRepository `json:"repositories,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+	// Schedules of the snapshots taken by Verrazzano
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	Schedules []OpenSearchSnapshotSchedule `json:"schedules,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+	// Name of a Secret in the namespace of the Verrazzano resource holding the access key of the S3-compatible
+	// repositories under object_store_access_key and the secret key under object_store_secret_key; the OpenSearch
+	// nodes load the keys when they start
+	// +optional
+	S3CredentialsSecret string `json:"s3CredentialsSecret,omitempty"`
+}
+
+// OpenSearchSnapshotRepository specifies a snapshot repository, exactly one of FileSystem or S3 must be set
+type OpenSearchSnapshotRepository struct {
+	// Name of the repository
+	Name string `json:"name"`
+	// Shared file system repository
+	// +optional
+	FileSystem *OpenSearchFileSystemRepository `json:"fileSystem,omitempty"`
+	// S3-compatible object storage repository
+	// +optional
+	S3 *OpenSearchS3Repository `json:"s3,omitempty"`
+}
+
+// OpenSearchFileSystemRepository specifies a shared file system snapshot repository
+type OpenSearchFileSystemRepository struct {
+	// Location of the repository, this must be a path of a shared file system registered in the path.repo setting
+	// of every OpenSearch node
+	Location string `json:"location"`
+}
+
+// OpenSearchS3Repository specifies an S3-compatible object storage snapshot repository
+type OpenSearchS3Repository struct {
+	// Name of the bucket
+	Bucket string `json:"bucket"`
+	// Path within the bucket where the snapshots are stored
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+	// Endpoint of the object storage service, for example mytenancy.compat.objectstorage.us-ashburn-1.oraclecloud.com
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region of the object storage service
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Use path style access instead of virtual hosted bucket access
+	// +optional
+	PathStyleAccess bool `json:"pathStyleAccess,omitempty"`
+}
+
+// OpenSearchSnapshotSchedule specifies snapshots taken on a schedule
+type OpenSearchSnapshotSchedule struct {
+	// Name of the schedule, snapshot names are prefixed with it
+	Name string `json:"name"`
+	// Name of the repository the snapshots are stored in
+	Repository string `json:"repository"`
+	// Schedule is a cron expression, in the standard five field format, for the start of each snapshot
+	Schedule string `json:"schedule"`
+	// Index patterns of the indices in the snapshots, all indices are included by default
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+	// Number of snapshots of the schedule kept in the repository, older snapshots are deleted; all snapshots are
+	// kept by default
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+}
+
 // OpenSearchDashboardsComponent specifies the OpenSearch Dashboards configuration.
 type OpenSearchDashboardsComponent struct {
 	// +optional
@@ -421,7 +844,10 @@ type OpenSearchDashboardsComponent struct {
 // KubeStateMetricsComponent specifies the kube-state-metrics configuration.
 type KubeStateMetricsComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -437,39 +863,101 @@ type GrafanaComponent struct {
 	Enabled  *bool         `json:"enabled,omitempty"`
 	Replicas *int32        `json:"replicas,omitempty"`
 	Database *DatabaseInfo `json:"database,omitempty"`
+	// Workload sets the resources and replicas of the Grafana pods, the replicas default to Replicas.  The Grafana
+	// pods are created by the Verrazzano Monitoring Operator, which does not support the scheduling settings.
+	// +optional
+	Workload *WorkloadSpec `json:"workload,omitempty"`
 }
 
 // PrometheusComponent specifies the Prometheus configuration.
 type PrometheusComponent struct {
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the Prometheus pods, they are applied to the Prometheus
+	// resource created by the Prometheus Operator
+	// +optional
+	Workload *WorkloadSpec `json:"workload,omitempty"`
 }
 
 // PrometheusAdapterComponent specifies the Prometheus Adapter configuration.
 type PrometheusAdapterComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
 // PrometheusNodeExporterComponent specifies the Prometheus Node Exporter configuration.
 type PrometheusNodeExporterComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
 // PrometheusOperatorComponent specifies the Prometheus Operator configuration
 type PrometheusOperatorComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Alertmanager configuration, Alertmanager is not installed by default
+	// +optional
+	Alertmanager *AlertmanagerSpec `json:"alertmanager,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
+// AlertmanagerSpec specifies the Alertmanager installed with the Prometheus Operator.  Prometheus sends the alerts of
+// the Verrazzano platform alert rules, and of any other PrometheusRule, to this Alertmanager.
+type AlertmanagerSpec struct {
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The receivers that are notified of the alerts
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +optional
+	Receivers []AlertmanagerReceiver `json:"receivers,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// AlertmanagerReceiverType identifies the kind of notification sent to an Alertmanager receiver
+type AlertmanagerReceiverType string
+
+const (
+	// AlertmanagerReceiverWebhook posts the alerts to a webhook, the receiver secret has the "url" of the webhook
+	AlertmanagerReceiverWebhook AlertmanagerReceiverType = "Webhook"
+	// AlertmanagerReceiverEmail emails the alerts, the receiver secret has the "to", "from" and "smarthost" of the
+	// email and optionally the "username" and "password" of the SMTP server
+	AlertmanagerReceiverEmail AlertmanagerReceiverType = "Email"
+	// AlertmanagerReceiverSlack posts the alerts to a Slack-compatible incoming webhook, the receiver secret has the
+	// "url" of the webhook and optionally the "channel"
+	AlertmanagerReceiverSlack AlertmanagerReceiverType = "Slack"
+)
+
+// AlertmanagerReceiver specifies where Alertmanager sends alerts
+type AlertmanagerReceiver struct {
+	// Name of the receiver
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Webhook;Email;Slack
+	Type AlertmanagerReceiverType `json:"type"`
+	// Name of the secret in the verrazzano-install namespace with the configuration of the receiver
+	Secret string `json:"secret"`
+	// The severities of the alerts sent to the receiver, all the alerts are sent if no severity is specified
+	// +optional
+	Severities []string `json:"severities,omitempty"`
+}
+
 // PrometheusPushgatewayComponent specifies the Prometheus Pushgateway configuration.
 type PrometheusPushgatewayComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -479,36 +967,117 @@ type CertManagerComponent struct {
 	// +optional
 	// +patchStrategy=replace
 	Certificate Certificate `json:"certificate,omitempty" patchStrategy:"replace"`
+	// CARotation configures the rotation of the self-signed Verrazzano CA
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	CARotation *CARotationSpec `json:"caRotation,omitempty"`
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
+// CARotationSpec defines the rotation of the self-signed Verrazzano CA.  A rotation issues a new CA, trusts both CAs in
+// the CA bundles of the admin and managed clusters, re-issues the certificates signed by the previous CA, then retires
+// the previous CA once the transition period is over.
+type CARotationSpec struct {
+	// RenewBefore rotates the CA when it expires within this duration.  It must be longer than 720h, when cert-manager
+	// renews the 90 day CA itself, and shorter than 2160h.  Default is 1080h.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// TransitionPeriod is how long the previous CA remains trusted after the certificates are re-issued.  Default is
+	// 24h.
+	// +optional
+	TransitionPeriod *metav1.Duration `json:"transitionPeriod,omitempty"`
+	// Trigger starts a rotation whenever it is set to a new value, for example the current date
+	// +optional
+	Trigger string `json:"trigger,omitempty"`
+}
+
 // CoherenceOperatorComponent specifies the Coherence Operator configuration
 type CoherenceOperatorComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
 // ApplicationOperatorComponent specifies the Application Operator configuration
 type ApplicationOperatorComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources and scheduling of the component pods, the component runs a single replica
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
 // AuthProxyComponent specifies the AuthProxy configuration
 type AuthProxyComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the AuthProxy pods
+	// +optional
+	Workload *WorkloadSpec `json:"workload,omitempty"`
+	// OIDC configures an external OpenID Connect provider used in place of Keycloak
+	// +optional
+	OIDC             *AuthProxyOIDC `json:"oidc,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
+// AuthProxyOIDC specifies an external OpenID Connect provider used by the AuthProxy, in place of Keycloak, to
+// authenticate users of the Verrazzano consoles and API
+type AuthProxyOIDC struct {
+	// IssuerURL is the HTTPS URL of the OpenID Connect issuer; the provider configuration is discovered from
+	// <issuerURL>/.well-known/openid-configuration
+	IssuerURL string `json:"issuerURL"`
+	// ClientID is the ID of the confidential client registered with the provider
+	ClientID string `json:"clientID"`
+	// ClientSecretRef selects the key of a Secret, in the namespace of the Verrazzano resource, holding the client secret
+	ClientSecretRef corev1.SecretKeySelector `json:"clientSecretRef"`
+	// CABundleSecretRef optionally selects the key of a Secret, in the namespace of the Verrazzano resource, holding
+	// the PEM encoded CA certificates used to verify the provider
+	// +optional
+	CABundleSecretRef *corev1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+	// UsernameClaim is the ID token claim used as the user name, defaults to preferred_username
+	// +optional
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// GroupsClaim is the ID token claim holding the provider groups of the user, defaults to groups
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// GroupMappings maps provider groups to the groups used when impersonating the user
+	// +optional
+	// +patchMergeKey=providerGroup
+	// +patchStrategy=merge,retainKeys
+	GroupMappings []AuthProxyOIDCGroupMapping `json:"groupMappings,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"providerGroup"`
+	// AdminGroups lists the provider groups mapped to the Group subjects of Security.AdminSubjects, or to
+	// verrazzano-admins when none are declared
+	// +optional
+	AdminGroups []string `json:"adminGroups,omitempty"`
+	// MonitorGroups lists the provider groups mapped to the Group subjects of Security.MonitorSubjects, or to
+	// verrazzano-monitors when none are declared
+	// +optional
+	MonitorGroups []string `json:"monitorGroups,omitempty"`
+}
+
+// AuthProxyOIDCGroupMapping maps a provider group to one or more groups
+type AuthProxyOIDCGroupMapping struct {
+	// ProviderGroup is the value of the groups claim to be mapped
+	ProviderGroup string `json:"providerGroup"`
+	// Groups are the groups the provider group is mapped to
+	Groups []string `json:"groups"`
+}
+
 // OAMComponent specifies the OAM configuration
 type OAMComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -522,14 +1091,20 @@ type VerrazzanoComponent struct {
 // KialiComponent specifies the Kiali configuration
 type KialiComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
 // ConsoleComponent specifies the Console UI configuration
 type ConsoleComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -543,7 +1118,13 @@ type DNSComponent struct {
 	OCI *OCI `json:"oci,omitempty"`
 	// DNS type of external. For example, OLCNE uses this type.
 	// +optional
-	External         *External `json:"external,omitempty"`
+	External *External `json:"external,omitempty"`
+	// DNS type of RFC2136, the DNS records are updated with dynamic updates on a name server such as BIND.
+	// +optional
+	RFC2136 *RFC2136 `json:"rfc2136,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -558,11 +1139,110 @@ type IngressNginxComponent struct {
 	// Ports to be used for NGINX
 	// +optional
 	Ports []corev1.ServicePort `json:"ports,omitempty"`
+	// Security sets the TLS policy, HSTS, web application firewall, rate limits and request size of the ingress
+	// controller
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Security *IngressSecuritySpec `json:"security,omitempty"`
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
+// IngressSecuritySpec hardens the NGINX ingress controller.  The settings are rendered into the controller ConfigMap,
+// so they apply to every ingress served by the controller, including the Verrazzano system ingresses.
+type IngressSecuritySpec struct {
+	// TLS sets the TLS protocols and cipher suites accepted by the ingress controller
+	// +optional
+	TLS *IngressTLSSpec `json:"tls,omitempty"`
+	// HSTS sets the HTTP Strict Transport Security header returned over HTTPS
+	// +optional
+	HSTS *HSTSSpec `json:"hsts,omitempty"`
+	// ModSecurity enables the ModSecurity web application firewall
+	// +optional
+	ModSecurity *ModSecuritySpec `json:"modSecurity,omitempty"`
+	// RateLimit limits the requests and connections of each client IP address
+	// +optional
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
+	// ClientBodySize is the maximum size of a request body, for example 8m.  Larger requests are rejected with 413.
+	// +optional
+	ClientBodySize string `json:"clientBodySize,omitempty"`
+}
+
+// IngressTLSSpec sets the TLS protocols and cipher suites of the ingress controller
+type IngressTLSSpec struct {
+	// MinVersion is the lowest TLS protocol version accepted, TLSv1.2 or TLSv1.3.  Default is TLSv1.2
+	// +optional
+	// +kubebuilder:validation:Enum=TLSv1.2;TLSv1.3
+	MinVersion string `json:"minVersion,omitempty"`
+	// Ciphers lists the OpenSSL names of the cipher suites accepted for TLS 1.2, in order of preference
+	// +optional
+	Ciphers []string `json:"ciphers,omitempty"`
+}
+
+// HSTSSpec sets the HTTP Strict Transport Security header
+type HSTSSpec struct {
+	// Enabled returns the header, default is true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxAge is the number of seconds browsers only use HTTPS for the host.  Default is 15724800
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAge *int64 `json:"maxAge,omitempty"`
+	// IncludeSubdomains applies the policy to the subdomains of the host, default is true
+	// +optional
+	IncludeSubdomains *bool `json:"includeSubdomains,omitempty"`
+	// Preload asks browsers to add the host to their preload list
+	// +optional
+	Preload bool `json:"preload,omitempty"`
+}
+
+// ModSecurityMode identifies what ModSecurity does with the requests matching its rules
+type ModSecurityMode string
+
+const (
+	// ModSecurityDetectionOnly logs the requests matching the rules
+	ModSecurityDetectionOnly ModSecurityMode = "DetectionOnly"
+
+	// ModSecurityOn blocks the requests matching the rules
+	ModSecurityOn ModSecurityMode = "On"
+)
+
+// ModSecuritySpec configures the ModSecurity web application firewall
+type ModSecuritySpec struct {
+	// Enabled loads ModSecurity in the ingress controller
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// OWASPCoreRuleSet enables the OWASP ModSecurity core rule set
+	// +optional
+	OWASPCoreRuleSet bool `json:"owaspCoreRuleSet,omitempty"`
+	// Mode is DetectionOnly to only log the matching requests or On to block them.  Default is DetectionOnly
+	// +optional
+	// +kubebuilder:validation:Enum=DetectionOnly;On
+	Mode ModSecurityMode `json:"mode,omitempty"`
+}
+
+// RateLimitSpec limits the requests and connections of each client IP address
+type RateLimitSpec struct {
+	// RequestsPerSecond is the number of requests per second accepted from a client IP address, zero means no limit
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RequestsPerSecond int32 `json:"requestsPerSecond,omitempty"`
+	// Burst is the number of requests above the rate accepted from a client IP address
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Burst int32 `json:"burst,omitempty"`
+	// Connections is the number of concurrent connections accepted from a client IP address, zero means no limit
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Connections int32 `json:"connections,omitempty"`
+	// AllowedCIDRs lists the client addresses that are not limited
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+}
+
 // IstioIngressSection specifies the specific config options available for the Istio Ingress Gateways.
 type IstioIngressSection struct {
 	// Type of ingress.  Default is LoadBalancer
@@ -581,6 +1261,12 @@ type IstioComponent struct {
 	Enabled *bool `json:"enabled,omitempty"`
 	// +optional
 	InjectionEnabled *bool `json:"injectionEnabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the Istio ingress gateway pods
+	// +optional
+	Workload *WorkloadSpec `json:"workload,omitempty"`
+	// Upgrade configures how the Istio control plane and the sidecar proxies are upgraded
+	// +optional
+	Upgrade *IstioUpgrade `json:"upgrade,omitempty"`
 }
 
 // IsInjectionEnabled is istio sidecar injection enabled check
@@ -591,23 +1277,195 @@ func (c *IstioComponent) IsInjectionEnabled() bool {
 	return c.InjectionEnabled != nil && *c.InjectionEnabled
 }
 
+// IstioUpgradeStrategy is the strategy used to upgrade the Istio control plane and the sidecar proxies
+type IstioUpgradeStrategy string
+
+const (
+	// IstioUpgradeInPlace upgrades the Istio control plane in place, then restarts all the workloads with an old
+	// sidecar proxy.  This is the default value.
+	IstioUpgradeInPlace IstioUpgradeStrategy = "InPlace"
+	// IstioUpgradeRevision installs the new Istio control plane as a revision alongside the old one, then migrates
+	// the namespaces to the new revision in batches and removes the old revision once nothing references it.
+	IstioUpgradeRevision IstioUpgradeStrategy = "Revision"
+)
+
+// IstioUpgrade specifies how Istio is upgraded
+type IstioUpgrade struct {
+	// Default is InPlace
+	// +kubebuilder:validation:Enum=InPlace;Revision
+	// +optional
+	Strategy IstioUpgradeStrategy `json:"strategy,omitempty"`
+	// Number of application namespaces migrated to the new revision at a time with the Revision strategy, the
+	// system namespaces are always migrated one at a time.  Default is 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NamespaceBatchSize int `json:"namespaceBatchSize,omitempty"`
+}
+
 // JaegerOperatorComponent specifies the Jaeger Operator configuration
 type JaegerOperatorComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Storage backend of the default Jaeger instance, Verrazzano OpenSearch if not specified
+	// +optional
+	Storage *JaegerStorageSpec `json:"storage,omitempty"`
+	// Sampling strategies of the default Jaeger instance, the default sampling percentage also applies to the Istio
+	// mesh tracing
+	// +optional
+	Sampling *JaegerSamplingSpec `json:"sampling,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
+// JaegerStorageType identifies the storage backend of the default Jaeger instance
+type JaegerStorageType string
+
+const (
+	// JaegerStorageVerrazzanoOpenSearch stores the spans in the Verrazzano OpenSearch
+	JaegerStorageVerrazzanoOpenSearch JaegerStorageType = "VerrazzanoOpenSearch"
+	// JaegerStorageExternalOpenSearch stores the spans in an OpenSearch cluster that is not managed by Verrazzano
+	JaegerStorageExternalOpenSearch JaegerStorageType = "ExternalOpenSearch"
+	// JaegerStorageMemory keeps the spans in memory, they are lost when the Jaeger pod restarts.  Only meant for
+	// development.
+	JaegerStorageMemory JaegerStorageType = "Memory"
+)
+
+// JaegerStorageSpec specifies the storage backend of the default Jaeger instance
+type JaegerStorageSpec struct {
+	// +kubebuilder:validation:Enum=VerrazzanoOpenSearch;ExternalOpenSearch;Memory
+	Type JaegerStorageType `json:"type"`
+	// URL of the external OpenSearch, required with the ExternalOpenSearch storage
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Name of the secret in the verrazzano-install namespace with the "username" and "password" of the external
+	// OpenSearch, required with the ExternalOpenSearch storage
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	// Number of days the spans are kept in OpenSearch before they are deleted.  Default is 7.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RetentionDays *int32 `json:"retentionDays,omitempty"`
+}
+
+// JaegerSamplingSpec specifies the sampling strategies of the default Jaeger instance
+type JaegerSamplingSpec struct {
+	// Percentage of the traces sampled for the services without a sampling strategy, and by the Istio mesh tracing,
+	// from "0" to "100".  Default is the sampling of Jaeger and Istio.
+	// +kubebuilder:validation:Pattern=`^(100(\.0+)?|[0-9]{1,2}(\.[0-9]+)?)$`
+	// +optional
+	DefaultPercentage string `json:"defaultPercentage,omitempty"`
+	// The sampling strategies of individual services
+	// +patchMergeKey=service
+	// +patchStrategy=merge,retainKeys
+	// +optional
+	Services []JaegerServiceSampling `json:"services,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"service"`
+}
+
+// JaegerServiceSampling specifies the sampling strategy of a service, either the percentage of the traces sampled or
+// the maximum number of traces sampled per second
+type JaegerServiceSampling struct {
+	// Name of the service
+	Service string `json:"service"`
+	// Percentage of the traces sampled, from "0" to "100"
+	// +kubebuilder:validation:Pattern=`^(100(\.0+)?|[0-9]{1,2}(\.[0-9]+)?)$`
+	// +optional
+	Percentage string `json:"percentage,omitempty"`
+	// Maximum number of traces sampled per second
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTracesPerSecond *int32 `json:"maxTracesPerSecond,omitempty"`
+}
+
 // KeycloakComponent specifies the Keycloak configuration
 type KeycloakComponent struct {
 	// MySQL contains the MySQL component configuration needed for Keycloak
 	// +optional
 	MySQL MySQLComponent `json:"mysql,omitempty"`
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Realms declares additional groups, users and clients that Verrazzano keeps in sync in Keycloak
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Realms []KeycloakRealm `json:"realms,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
+// KeycloakRealm specifies groups, users and OpenID Connect clients that are kept in sync in a Keycloak realm.
+// Objects in the realm that are not declared are left unchanged.
+type KeycloakRealm struct {
+	// Name of the realm, the realm is created if it does not exist.  The master and verrazzano-system realms are reserved.
+	Name string `json:"name"`
+	// Groups of the realm
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Groups []KeycloakRealmGroup `json:"groups,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	// Users of the realm
+	// +optional
+	// +patchMergeKey=username
+	// +patchStrategy=merge
+	Users []KeycloakRealmUser `json:"users,omitempty" patchStrategy:"merge" patchMergeKey:"username"`
+	// OpenID Connect clients of the realm
+	// +optional
+	// +patchMergeKey=clientId
+	// +patchStrategy=merge
+	Clients []KeycloakRealmClient `json:"clients,omitempty" patchStrategy:"merge" patchMergeKey:"clientId"`
+}
+
+// KeycloakRealmGroup specifies a group of a Keycloak realm
+type KeycloakRealmGroup struct {
+	// Name of the group
+	Name string `json:"name"`
+	// Name of the top level group that contains the group, the group is a top level group if it is not set
+	// +optional
+	Parent string `json:"parent,omitempty"`
+	// Realm roles mapped to the group, roles that do not exist are created
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+}
+
+// KeycloakRealmUser specifies a user of a Keycloak realm
+type KeycloakRealmUser struct {
+	// User name of the user
+	Username string `json:"username"`
+	// +optional
+	FirstName string `json:"firstName,omitempty"`
+	// +optional
+	LastName string `json:"lastName,omitempty"`
+	// +optional
+	Email string `json:"email,omitempty"`
+	// Names of the groups the user is a member of, a subgroup can also be selected by its path such as /parent/name
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// Selects the key of a Secret in the namespace of the Verrazzano resource that contains the password of the user
+	PasswordSecretRef corev1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// KeycloakRealmClient specifies an OpenID Connect client of a Keycloak realm
+type KeycloakRealmClient struct {
+	// Client ID used by applications to authenticate
+	ClientID string `json:"clientId"`
+	// Public specifies that the client does not authenticate with a client secret
+	// +optional
+	Public bool `json:"public,omitempty"`
+	// Valid redirect URIs of the client
+	// +optional
+	RedirectURIs []string `json:"redirectURIs,omitempty"`
+	// Allowed CORS origins of the client
+	// +optional
+	WebOrigins []string `json:"webOrigins,omitempty"`
+	// Selects the key of a Secret in the namespace of the Verrazzano resource that contains the secret of a
+	// confidential client, Keycloak generates the client secret if it is not set
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
 // MySQLComponent specifies the MySQL configuration
 type MySQLComponent struct {
 	// VolumeSource Defines the type of volume to be used for persistence; at present only EmptyDirVolumeSource or
@@ -615,21 +1473,70 @@ type MySQLComponent struct {
 	// is used, it must reference a VolumeClaimSpecTemplate in the VolumeClaimSpecTemplates section.
 	// +optional
 	// +patchStrategy=replace
-	VolumeSource     *corev1.VolumeSource `json:"volumeSource,omitempty" patchStrategy:"replace"`
+	VolumeSource *corev1.VolumeSource `json:"volumeSource,omitempty" patchStrategy:"replace"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload *WorkloadSpec `json:"workload,omitempty"`
+	// Backup Defines the scheduled logical backups of the Keycloak database
+	// +optional
+	Backup *MySQLBackupSpec `json:"backup,omitempty"`
+	// Restore Defines a restore of the Keycloak database from one of its backups.  A restore runs once, each change of
+	// the restore settings starts a new restore.
+	// +optional
+	Restore          *MySQLRestoreSpec `json:"restore,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
+// MySQLBackupSpec Defines the scheduled logical backups of the Keycloak database, taken with the dump utility of MySQL
+// Shell.  Exactly one of persistentVolumeClaim and s3 must be specified.
+type MySQLBackupSpec struct {
+	// Schedule is a cron expression, in the standard five field format, for the start of each backup; for example
+	// "0 2 * * *" backs up every day at 02:00 UTC
+	Schedule string `json:"schedule"`
+	// RetentionCount is the number of backups kept in the persistent volume claim, the backups stored in S3 are
+	// expired by the lifecycle rules of the bucket.  Default is 7.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RetentionCount *int32 `json:"retentionCount,omitempty"`
+	// PersistentVolumeClaim is the name of an existing claim in the keycloak namespace where the backups are written
+	// +optional
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// S3 is the S3 compatible object storage location of the backups
+	// +optional
+	S3 *BackupStorage `json:"s3,omitempty"`
+	// PreUpgrade backs up the database before MySQL is upgraded, the upgrade does not start until the backup
+	// completes.  Default is true.
+	// +optional
+	PreUpgrade *bool `json:"preUpgrade,omitempty"`
+}
+
+// MySQLRestoreSpec Defines a restore of the Keycloak database from one of its backups
+type MySQLRestoreSpec struct {
+	// BackupName is the name of the backup that is restored, as reported in the MySQL status
+	BackupName string `json:"backupName"`
+	// PointInTime, in RFC3339 format, replays the changes recorded in the MySQL binary log after the backup up to this
+	// time.  The binary log is kept by the MySQL server, so the recovery is only possible while its data volume exists.
+	// +optional
+	PointInTime string `json:"pointInTime,omitempty"`
+}
+
 // MySQLOperatorComponent specifies the MySQL Operator configuration
 type MySQLOperatorComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources and scheduling of the component pods, the component runs a single replica
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
 // RancherComponent specifies the Rancher configuration
 type RancherComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 	// KeycloakAuthEnabled specifies whether the Keycloak Auth provider is enabled.  Default is false.
 	// +optional
@@ -639,7 +1546,10 @@ type RancherComponent struct {
 // RancherBackupComponent specifies the Rancher Backup configuration
 type RancherBackupComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -648,6 +1558,10 @@ type FluentdComponent struct {
 	// Specifies whether Fluentd is deployed or not on a cluster.  Default is true.
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources and scheduling of the Fluentd pods, Fluentd runs on every node so the replicas
+	// are not supported
+	// +optional
+	Workload *WorkloadSpec `json:"workload,omitempty"`
 	// +optional
 	// +patchStrategy=merge,retainKeys
 	ExtraVolumeMounts []VolumeMount `json:"extraVolumeMounts,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"source"`
@@ -658,21 +1572,99 @@ type FluentdComponent struct {
 
 	// Configuration for integration with OCI (Oracle Cloud Infrastructure) Logging Service
 	// +optional
-	OCI              *OciLoggingConfiguration `json:"oci,omitempty"`
+	OCI *OciLoggingConfiguration `json:"oci,omitempty"`
+
+	// Additional outputs the logs are sent to
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	Outputs          []FluentdOutput `json:"outputs,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 	InstallOverrides `json:",inline"`
 }
 
+// FluentdOutput specifies an additional destination of the logs collected by Fluentd.  The logs are copied to the
+// output in addition to OpenSearch or OCI Logging.  Exactly one of Kafka, S3 or Syslog must be set.
+type FluentdOutput struct {
+	// Name of the output, unique among the outputs
+	Name string `json:"name"`
+	// Namespaces whose container logs are sent to the output.  All the logs, including the system logs, are sent
+	// when empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// +optional
+	Kafka *FluentdKafkaOutput `json:"kafka,omitempty"`
+	// +optional
+	S3 *FluentdS3Output `json:"s3,omitempty"`
+	// +optional
+	Syslog *FluentdSyslogOutput `json:"syslog,omitempty"`
+}
+
+// FluentdKafkaOutput sends the logs to a Kafka topic
+type FluentdKafkaOutput struct {
+	// Kafka brokers, as host:port
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+	// Connect to the brokers with TLS, using the ca.crt entry of the secret as CA bundle, and the tls.crt and
+	// tls.key entries as client certificate when present
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+	// SASL mechanism used to authenticate with the username and password entries of the secret
+	// +kubebuilder:validation:Enum=plain;scram-sha-256;scram-sha-512
+	// +optional
+	SASLMechanism string `json:"saslMechanism,omitempty"`
+	// Name of the secret in the verrazzano-install namespace holding the TLS and SASL credentials
+	// +optional
+	Secret string `json:"secret,omitempty"`
+}
+
+// FluentdS3Output archives the logs in an S3-compatible object storage bucket
+type FluentdS3Output struct {
+	// Endpoint of the object storage, the AWS endpoint of the region is used when empty
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	Bucket   string `json:"bucket"`
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Path of the objects in the bucket, may contain time placeholders such as %Y/%m/%d.  Default is logs/%Y/%m/%d/.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Address the bucket with path style URLs, required by most S3-compatible object storage
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+	// Name of the secret in the verrazzano-install namespace holding the object_store_access_key and
+	// object_store_secret_key entries
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// FluentdSyslogOutput sends the logs to a syslog server in the RFC5424 format
+type FluentdSyslogOutput struct {
+	Host string `json:"host"`
+	// Default is 514
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Default is tcp
+	// +kubebuilder:validation:Enum=udp;tcp;tls
+	// +optional
+	Transport string `json:"transport,omitempty"`
+}
+
 // WebLogicOperatorComponent specifies the WebLogic Operator configuration
 type WebLogicOperatorComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
 // VeleroComponent  specifies the Velero configuration
 type VeleroComponent struct {
 	// +optional
-	Enabled          *bool `json:"enabled,omitempty"`
+	Enabled *bool `json:"enabled,omitempty"`
+	// Workload sets the resources, replicas and scheduling of the component pods
+	// +optional
+	Workload         *WorkloadSpec `json:"workload,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -694,6 +1686,8 @@ type ProviderType string
 const (
 	// LetsEncrypt is a Let's Encrypt provider
 	LetsEncrypt ProviderType = "LetsEncrypt"
+	// Generic is an ACME provider identified by the URL of its directory, such as a private step-ca server
+	Generic ProviderType = "Generic"
 )
 
 // Acme identifies the ACME cert issuer.
@@ -706,6 +1700,21 @@ type Acme struct {
 	// environment
 	// +optional
 	Environment string `json:"environment,omitempty"`
+	// URL of the ACME directory, required by the Generic provider.
+	// +optional
+	Server string `json:"server,omitempty"`
+	// External account binding used to register the ACME account with the provider.
+	// +optional
+	ExternalAccountBinding ExternalAccountBinding `json:"externalAccountBinding,omitempty"`
+}
+
+// ExternalAccountBinding identifies the external account of an ACME provider.
+type ExternalAccountBinding struct {
+	// Key ID of the external account
+	KeyID string `json:"keyID"`
+	// Name of the secret in the verrazzano-install namespace which contains the base64 URL encoded HMAC key of the
+	// external account in the "secret" key
+	SecretName string `json:"secretName"`
 }
 
 // CA identifies the CA cert issuer.
@@ -725,6 +1734,16 @@ type Certificate struct {
 	// CA cert issuer
 	// +optional
 	CA CA `json:"ca,omitempty"`
+	// Existing ClusterIssuer used to issue the certificates, for example a Vault or ACME ClusterIssuer that
+	// is managed outside of Verrazzano.
+	// +optional
+	IssuerRef IssuerRef `json:"issuerRef,omitempty"`
+}
+
+// IssuerRef identifies an existing cert-manager ClusterIssuer.
+type IssuerRef struct {
+	// Name of the ClusterIssuer
+	Name string `json:"name"`
 }
 
 // OciPrivateKeyFileName is the private key file name
@@ -748,6 +1767,24 @@ type OCI struct {
 	DNSScope               string `json:"dnsScope,omitempty"`
 }
 
+// RFC2136 DNS type
+type RFC2136 struct {
+	// Host name or IP address of the name server
+	Host string `json:"host"`
+	// Port of the name server, defaults to 53
+	// +optional
+	Port int `json:"port,omitempty"`
+	// Name of the DNS zone updated on the name server
+	DNSZoneName string `json:"dnsZoneName"`
+	// Name of the TSIG key used to sign the updates
+	TSIGKeyName string `json:"tsigKeyName"`
+	// Algorithm of the TSIG key, one of hmac-md5, hmac-sha1, hmac-sha256 or hmac-sha512.  Defaults to hmac-sha256
+	// +optional
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+	// Name of the secret in the verrazzano-install namespace that contains the TSIG key secret in the "secret" key
+	TSIGSecret string `json:"tsigSecret"`
+}
+
 // External DNS type
 type External struct {
 	// DNS suffix appended to EnviromentName to form DNS name
@@ -768,6 +1805,27 @@ type OciLoggingConfiguration struct {
 	APISecret       string `json:"apiSecret,omitempty"`
 }
 
+// WorkloadSpec sets the resources, replicas and scheduling of the pods of a component, each component maps the
+// settings onto the values of its Helm chart.  A setting that the chart of the component does not support is rejected.
+type WorkloadSpec struct {
+	// Resources are the compute resources of the component containers
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Replicas is the number of replicas of the component deployment
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// NodeSelector selects the nodes that run the component pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of the component pods
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PriorityClassName is the priority class of the component pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
 // InstallOverrides are used to pass install overrides to components
 type InstallOverrides struct {
 	MonitorChanges *bool       `json:"monitorChanges,omitempty"`
@@ -779,4 +1837,9 @@ type Overrides struct {
 	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
 	SecretRef    *corev1.SecretKeySelector    `json:"secretRef,omitempty"`
 	Values       *apiextensionsv1.JSON        `json:"values,omitempty"`
+	// Template indicates that the override values contain Go template expressions, which are rendered with the
+	// effective Verrazzano resource before being passed to Helm.  An inline value that consists of a single
+	// template expression takes the type of the rendered value, for example a number or a boolean.
+	// +optional
+	Template bool `json:"template,omitempty"`
 }
@@ -13,12 +13,14 @@ import (
 	"k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Acme) DeepCopyInto(out *Acme) {
 	*out = *in
+	out.ExternalAccountBinding = in.ExternalAccountBinding
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Acme.
@@ -31,6 +33,69 @@ func (in *Acme) DeepCopy() *Acme {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalNetworkPolicy) DeepCopyInto(out *AdditionalNetworkPolicy) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalNetworkPolicy.
+func (in *AdditionalNetworkPolicy) DeepCopy() *AdditionalNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerReceiver) DeepCopyInto(out *AlertmanagerReceiver) {
+	*out = *in
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerReceiver.
+func (in *AlertmanagerReceiver) DeepCopy() *AlertmanagerReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerSpec) DeepCopyInto(out *AlertmanagerSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]AlertmanagerReceiver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerSpec.
+func (in *AlertmanagerSpec) DeepCopy() *AlertmanagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationOperatorComponent) DeepCopyInto(out *ApplicationOperatorComponent) {
 	*out = *in
@@ -39,6 +104,11 @@ func (in *ApplicationOperatorComponent) DeepCopyInto(out *ApplicationOperatorCom
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -60,6 +130,16 @@ func (in *AuthProxyComponent) DeepCopyInto(out *AuthProxyComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(AuthProxyOIDC)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -73,6 +153,162 @@ func (in *AuthProxyComponent) DeepCopy() *AuthProxyComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthProxyOIDC) DeepCopyInto(out *AuthProxyOIDC) {
+	*out = *in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupMappings != nil {
+		in, out := &in.GroupMappings, &out.GroupMappings
+		*out = make([]AuthProxyOIDCGroupMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdminGroups != nil {
+		in, out := &in.AdminGroups, &out.AdminGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MonitorGroups != nil {
+		in, out := &in.MonitorGroups, &out.MonitorGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthProxyOIDC.
+func (in *AuthProxyOIDC) DeepCopy() *AuthProxyOIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthProxyOIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthProxyOIDCGroupMapping) DeepCopyInto(out *AuthProxyOIDCGroupMapping) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthProxyOIDCGroupMapping.
+func (in *AuthProxyOIDCGroupMapping) DeepCopy() *AuthProxyOIDCGroupMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthProxyOIDCGroupMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
+	*out = *in
+	if in.RetentionDays != nil {
+		in, out := &in.RetentionDays, &out.RetentionDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]BackupStore, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSchedule.
+func (in *BackupSchedule) DeepCopy() *BackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
+	*out = *in
+	out.Storage = in.Storage
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]BackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSpec.
+func (in *BackupSpec) DeepCopy() *BackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
+	*out = *in
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]BackupStoreStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
+func (in *BackupStatus) DeepCopy() *BackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStorage) DeepCopyInto(out *BackupStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStorage.
+func (in *BackupStorage) DeepCopy() *BackupStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStoreStatus) DeepCopyInto(out *BackupStoreStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStoreStatus.
+func (in *BackupStoreStatus) DeepCopy() *BackupStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CA) DeepCopyInto(out *CA) {
 	*out = *in
@@ -88,15 +324,65 @@ func (in *CA) DeepCopy() *CA {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CARotationSpec) DeepCopyInto(out *CARotationSpec) {
+	*out = *in
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TransitionPeriod != nil {
+		in, out := &in.TransitionPeriod, &out.TransitionPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CARotationSpec.
+func (in *CARotationSpec) DeepCopy() *CARotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CARotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CARotationStatus) DeepCopyInto(out *CARotationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CARotationStatus.
+func (in *CARotationStatus) DeepCopy() *CARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertManagerComponent) DeepCopyInto(out *CertManagerComponent) {
 	*out = *in
 	out.Certificate = in.Certificate
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(CARotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -115,6 +401,7 @@ func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
 	out.Acme = in.Acme
 	out.CA = in.CA
+	out.IssuerRef = in.IssuerRef
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
@@ -127,6 +414,46 @@ func (in *Certificate) DeepCopy() *Certificate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateExpiryStatus) DeepCopyInto(out *CertificateExpiryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateExpiryStatus.
+func (in *CertificateExpiryStatus) DeepCopy() *CertificateExpiryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateExpiryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+	*out = *in
+	if in.Expiry != nil {
+		in, out := &in.Expiry, &out.Expiry
+		*out = make([]CertificateExpiryStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(CARotationStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
+func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoherenceOperatorComponent) DeepCopyInto(out *CoherenceOperatorComponent) {
 	*out = *in
@@ -135,6 +462,11 @@ func (in *CoherenceOperatorComponent) DeepCopyInto(out *CoherenceOperatorCompone
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -311,6 +643,11 @@ func (in *ComponentStatusDetails) DeepCopyInto(out *ComponentStatusDetails) {
 		*out = make([]Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatusDetails.
@@ -375,6 +712,11 @@ func (in *ConsoleComponent) DeepCopyInto(out *ConsoleComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -406,6 +748,16 @@ func (in *DNSComponent) DeepCopyInto(out *DNSComponent) {
 		*out = new(External)
 		**out = **in
 	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136)
+		**out = **in
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -449,6 +801,21 @@ func (in *External) DeepCopy() *External {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAccountBinding) DeepCopyInto(out *ExternalAccountBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAccountBinding.
+func (in *ExternalAccountBinding) DeepCopy() *ExternalAccountBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAccountBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentdComponent) DeepCopyInto(out *FluentdComponent) {
 	*out = *in
@@ -457,6 +824,11 @@ func (in *FluentdComponent) DeepCopyInto(out *FluentdComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtraVolumeMounts != nil {
 		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
 		*out = make([]VolumeMount, len(*in))
@@ -469,6 +841,13 @@ func (in *FluentdComponent) DeepCopyInto(out *FluentdComponent) {
 		*out = new(OciLoggingConfiguration)
 		**out = **in
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]FluentdOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -483,39 +862,179 @@ func (in *FluentdComponent) DeepCopy() *FluentdComponent {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrafanaComponent) DeepCopyInto(out *GrafanaComponent) {
+func (in *FluentdKafkaOutput) DeepCopyInto(out *FluentdKafkaOutput) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Database != nil {
-		in, out := &in.Database, &out.Database
-		*out = new(DatabaseInfo)
-		**out = **in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaComponent.
-func (in *GrafanaComponent) DeepCopy() *GrafanaComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdKafkaOutput.
+func (in *FluentdKafkaOutput) DeepCopy() *FluentdKafkaOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(GrafanaComponent)
+	out := new(FluentdKafkaOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressNginxComponent) DeepCopyInto(out *IngressNginxComponent) {
+func (in *FluentdOutput) DeepCopyInto(out *FluentdOutput) {
 	*out = *in
-	if in.IngressClassName != nil {
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(FluentdKafkaOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(FluentdS3Output)
+		**out = **in
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(FluentdSyslogOutput)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdOutput.
+func (in *FluentdOutput) DeepCopy() *FluentdOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentdS3Output) DeepCopyInto(out *FluentdS3Output) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdS3Output.
+func (in *FluentdS3Output) DeepCopy() *FluentdS3Output {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdS3Output)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentdSyslogOutput) DeepCopyInto(out *FluentdSyslogOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdSyslogOutput.
+func (in *FluentdSyslogOutput) DeepCopy() *FluentdSyslogOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdSyslogOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaComponent) DeepCopyInto(out *GrafanaComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(DatabaseInfo)
+		**out = **in
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaComponent.
+func (in *GrafanaComponent) DeepCopy() *GrafanaComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HSTSSpec) DeepCopyInto(out *HSTSSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IncludeSubdomains != nil {
+		in, out := &in.IncludeSubdomains, &out.IncludeSubdomains
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSTSSpec.
+func (in *HSTSSpec) DeepCopy() *HSTSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HSTSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilitySpec) DeepCopyInto(out *HighAvailabilitySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilitySpec.
+func (in *HighAvailabilitySpec) DeepCopy() *HighAvailabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressNginxComponent) DeepCopyInto(out *IngressNginxComponent) {
+	*out = *in
+	if in.IngressClassName != nil {
 		in, out := &in.IngressClassName, &out.IngressClassName
 		*out = new(string)
 		**out = **in
@@ -527,11 +1046,21 @@ func (in *IngressNginxComponent) DeepCopyInto(out *IngressNginxComponent) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(IngressSecuritySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -545,6 +1074,61 @@ func (in *IngressNginxComponent) DeepCopy() *IngressNginxComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSecuritySpec) DeepCopyInto(out *IngressSecuritySpec) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(IngressTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HSTS != nil {
+		in, out := &in.HSTS, &out.HSTS
+		*out = new(HSTSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModSecurity != nil {
+		in, out := &in.ModSecurity, &out.ModSecurity
+		*out = new(ModSecuritySpec)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSecuritySpec.
+func (in *IngressSecuritySpec) DeepCopy() *IngressSecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressTLSSpec) DeepCopyInto(out *IngressTLSSpec) {
+	*out = *in
+	if in.Ciphers != nil {
+		in, out := &in.Ciphers, &out.Ciphers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTLSSpec.
+func (in *IngressTLSSpec) DeepCopy() *IngressTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstallOverrides) DeepCopyInto(out *InstallOverrides) {
 	*out = *in
@@ -632,6 +1216,21 @@ func (in *InstanceInfo) DeepCopy() *InstanceInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerRef) DeepCopyInto(out *IssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerRef.
+func (in *IssuerRef) DeepCopy() *IssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IstioComponent) DeepCopyInto(out *IstioComponent) {
 	*out = *in
@@ -646,6 +1245,16 @@ func (in *IstioComponent) DeepCopyInto(out *IstioComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(IstioUpgrade)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioComponent.
@@ -680,6 +1289,21 @@ func (in *IstioIngressSection) DeepCopy() *IstioIngressSection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioUpgrade) DeepCopyInto(out *IstioUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioUpgrade.
+func (in *IstioUpgrade) DeepCopy() *IstioUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JaegerOperatorComponent) DeepCopyInto(out *JaegerOperatorComponent) {
 	*out = *in
@@ -688,6 +1312,21 @@ func (in *JaegerOperatorComponent) DeepCopyInto(out *JaegerOperatorComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(JaegerStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(JaegerSamplingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -701,6 +1340,68 @@ func (in *JaegerOperatorComponent) DeepCopy() *JaegerOperatorComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JaegerSamplingSpec) DeepCopyInto(out *JaegerSamplingSpec) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]JaegerServiceSampling, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerSamplingSpec.
+func (in *JaegerSamplingSpec) DeepCopy() *JaegerSamplingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerSamplingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JaegerServiceSampling) DeepCopyInto(out *JaegerServiceSampling) {
+	*out = *in
+	if in.MaxTracesPerSecond != nil {
+		in, out := &in.MaxTracesPerSecond, &out.MaxTracesPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerServiceSampling.
+func (in *JaegerServiceSampling) DeepCopy() *JaegerServiceSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerServiceSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JaegerStorageSpec) DeepCopyInto(out *JaegerStorageSpec) {
+	*out = *in
+	if in.RetentionDays != nil {
+		in, out := &in.RetentionDays, &out.RetentionDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerStorageSpec.
+func (in *JaegerStorageSpec) DeepCopy() *JaegerStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakComponent) DeepCopyInto(out *KeycloakComponent) {
 	*out = *in
@@ -710,6 +1411,18 @@ func (in *KeycloakComponent) DeepCopyInto(out *KeycloakComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Realms != nil {
+		in, out := &in.Realms, &out.Realms
+		*out = make([]KeycloakRealm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -723,6 +1436,155 @@ func (in *KeycloakComponent) DeepCopy() *KeycloakComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]KeycloakRealmGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]KeycloakRealmUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]KeycloakRealmClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealm.
+func (in *KeycloakRealm) DeepCopy() *KeycloakRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmClient) DeepCopyInto(out *KeycloakRealmClient) {
+	*out = *in
+	if in.RedirectURIs != nil {
+		in, out := &in.RedirectURIs, &out.RedirectURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebOrigins != nil {
+		in, out := &in.WebOrigins, &out.WebOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmClient.
+func (in *KeycloakRealmClient) DeepCopy() *KeycloakRealmClient {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmGroup) DeepCopyInto(out *KeycloakRealmGroup) {
+	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroup.
+func (in *KeycloakRealmGroup) DeepCopy() *KeycloakRealmGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
+	*out = *in
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
+func (in *KeycloakRealmStatus) DeepCopy() *KeycloakRealmStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUser) DeepCopyInto(out *KeycloakRealmUser) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUser.
+func (in *KeycloakRealmUser) DeepCopy() *KeycloakRealmUser {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakStatus) DeepCopyInto(out *KeycloakStatus) {
+	*out = *in
+	if in.Realms != nil {
+		in, out := &in.Realms, &out.Realms
+		*out = make([]KeycloakRealmStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakStatus.
+func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KialiComponent) DeepCopyInto(out *KialiComponent) {
 	*out = *in
@@ -731,78 +1593,286 @@ func (in *KialiComponent) DeepCopyInto(out *KialiComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KialiComponent.
+func (in *KialiComponent) DeepCopy() *KialiComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(KialiComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeStateMetricsComponent) DeepCopyInto(out *KubeStateMetricsComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KialiComponent.
-func (in *KialiComponent) DeepCopy() *KialiComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsComponent.
+func (in *KubeStateMetricsComponent) DeepCopy() *KubeStateMetricsComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeStateMetricsComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+	if in.DeferredOperations != nil {
+		in, out := &in.DeferredOperations, &out.DeferredOperations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModSecuritySpec) DeepCopyInto(out *ModSecuritySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModSecuritySpec.
+func (in *ModSecuritySpec) DeepCopy() *ModSecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModSecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupSpec) DeepCopyInto(out *MySQLBackupSpec) {
+	*out = *in
+	if in.RetentionCount != nil {
+		in, out := &in.RetentionCount, &out.RetentionCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(BackupStorage)
+		**out = **in
+	}
+	if in.PreUpgrade != nil {
+		in, out := &in.PreUpgrade, &out.PreUpgrade
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLBackupSpec.
+func (in *MySQLBackupSpec) DeepCopy() *MySQLBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLBackupStatus) DeepCopyInto(out *MySQLBackupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLBackupStatus.
+func (in *MySQLBackupStatus) DeepCopy() *MySQLBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLComponent) DeepCopyInto(out *MySQLComponent) {
+	*out = *in
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(v1.VolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(MySQLBackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(MySQLRestoreSpec)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLComponent.
+func (in *MySQLComponent) DeepCopy() *MySQLComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLOperatorComponent) DeepCopyInto(out *MySQLOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLOperatorComponent.
+func (in *MySQLOperatorComponent) DeepCopy() *MySQLOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLRestoreSpec) DeepCopyInto(out *MySQLRestoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLRestoreSpec.
+func (in *MySQLRestoreSpec) DeepCopy() *MySQLRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KialiComponent)
+	out := new(MySQLRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeStateMetricsComponent) DeepCopyInto(out *KubeStateMetricsComponent) {
+func (in *MySQLRestoreStatus) DeepCopyInto(out *MySQLRestoreStatus) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsComponent.
-func (in *KubeStateMetricsComponent) DeepCopy() *KubeStateMetricsComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLRestoreStatus.
+func (in *MySQLRestoreStatus) DeepCopy() *MySQLRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeStateMetricsComponent)
+	out := new(MySQLRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MySQLComponent) DeepCopyInto(out *MySQLComponent) {
+func (in *MySQLStatus) DeepCopyInto(out *MySQLStatus) {
 	*out = *in
-	if in.VolumeSource != nil {
-		in, out := &in.VolumeSource, &out.VolumeSource
-		*out = new(v1.VolumeSource)
-		(*in).DeepCopyInto(*out)
+	if in.LastBackup != nil {
+		in, out := &in.LastBackup, &out.LastBackup
+		*out = new(MySQLBackupStatus)
+		**out = **in
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(MySQLRestoreStatus)
+		**out = **in
 	}
-	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLComponent.
-func (in *MySQLComponent) DeepCopy() *MySQLComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLStatus.
+func (in *MySQLStatus) DeepCopy() *MySQLStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MySQLComponent)
+	out := new(MySQLStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MySQLOperatorComponent) DeepCopyInto(out *MySQLOperatorComponent) {
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.AdditionalPolicies != nil {
+		in, out := &in.AdditionalPolicies, &out.AdditionalPolicies
+		*out = make([]AdditionalNetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLOperatorComponent.
-func (in *MySQLOperatorComponent) DeepCopy() *MySQLOperatorComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MySQLOperatorComponent)
+	out := new(NetworkPoliciesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -815,6 +1885,11 @@ func (in *OAMComponent) DeepCopyInto(out *OAMComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -880,6 +1955,18 @@ func (in *OpenSearchComponent) DeepCopyInto(out *OpenSearchComponent) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NamespacePolicies != nil {
+		in, out := &in.NamespacePolicies, &out.NamespacePolicies
+		*out = make([]OpenSearchNamespacePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(OpenSearchSnapshots)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchComponent.
@@ -917,6 +2004,52 @@ func (in *OpenSearchDashboardsComponent) DeepCopy() *OpenSearchDashboardsCompone
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchFileSystemRepository) DeepCopyInto(out *OpenSearchFileSystemRepository) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchFileSystemRepository.
+func (in *OpenSearchFileSystemRepository) DeepCopy() *OpenSearchFileSystemRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchFileSystemRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNamespacePolicy) DeepCopyInto(out *OpenSearchNamespacePolicy) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinIndexAge != nil {
+		in, out := &in.MinIndexAge, &out.MinIndexAge
+		*out = new(string)
+		**out = **in
+	}
+	in.Rollover.DeepCopyInto(&out.Rollover)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNamespacePolicy.
+func (in *OpenSearchNamespacePolicy) DeepCopy() *OpenSearchNamespacePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNamespacePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenSearchNode) DeepCopyInto(out *OpenSearchNode) {
 	*out = *in
@@ -962,6 +2095,135 @@ func (in *OpenSearchNodeStorage) DeepCopy() *OpenSearchNodeStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchS3Repository) DeepCopyInto(out *OpenSearchS3Repository) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchS3Repository.
+func (in *OpenSearchS3Repository) DeepCopy() *OpenSearchS3Repository {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchS3Repository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotRepository) DeepCopyInto(out *OpenSearchSnapshotRepository) {
+	*out = *in
+	if in.FileSystem != nil {
+		in, out := &in.FileSystem, &out.FileSystem
+		*out = new(OpenSearchFileSystemRepository)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(OpenSearchS3Repository)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotRepository.
+func (in *OpenSearchSnapshotRepository) DeepCopy() *OpenSearchSnapshotRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotSchedule) DeepCopyInto(out *OpenSearchSnapshotSchedule) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotSchedule.
+func (in *OpenSearchSnapshotSchedule) DeepCopy() *OpenSearchSnapshotSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotStatus) DeepCopyInto(out *OpenSearchSnapshotStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotStatus.
+func (in *OpenSearchSnapshotStatus) DeepCopy() *OpenSearchSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshots) DeepCopyInto(out *OpenSearchSnapshots) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]OpenSearchSnapshotRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]OpenSearchSnapshotSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshots.
+func (in *OpenSearchSnapshots) DeepCopy() *OpenSearchSnapshots {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshots)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchStatus) DeepCopyInto(out *OpenSearchStatus) {
+	*out = *in
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]OpenSearchSnapshotStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchStatus.
+func (in *OpenSearchStatus) DeepCopy() *OpenSearchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Overrides) DeepCopyInto(out *Overrides) {
 	*out = *in
@@ -1000,6 +2262,11 @@ func (in *PrometheusAdapterComponent) DeepCopyInto(out *PrometheusAdapterCompone
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -1021,6 +2288,11 @@ func (in *PrometheusComponent) DeepCopyInto(out *PrometheusComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusComponent.
@@ -1041,6 +2313,11 @@ func (in *PrometheusNodeExporterComponent) DeepCopyInto(out *PrometheusNodeExpor
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -1062,6 +2339,16 @@ func (in *PrometheusOperatorComponent) DeepCopyInto(out *PrometheusOperatorCompo
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Alertmanager != nil {
+		in, out := &in.Alertmanager, &out.Alertmanager
+		*out = new(AlertmanagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -1083,6 +2370,11 @@ func (in *PrometheusPushgatewayComponent) DeepCopyInto(out *PrometheusPushgatewa
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -1096,6 +2388,21 @@ func (in *PrometheusPushgatewayComponent) DeepCopy() *PrometheusPushgatewayCompo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RFC2136) DeepCopyInto(out *RFC2136) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136.
+func (in *RFC2136) DeepCopy() *RFC2136 {
+	if in == nil {
+		return nil
+	}
+	out := new(RFC2136)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RancherBackupComponent) DeepCopyInto(out *RancherBackupComponent) {
 	*out = *in
@@ -1104,6 +2411,11 @@ func (in *RancherBackupComponent) DeepCopyInto(out *RancherBackupComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -1125,6 +2437,11 @@ func (in *RancherComponent) DeepCopyInto(out *RancherComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 	if in.KeycloakAuthEnabled != nil {
 		in, out := &in.KeycloakAuthEnabled, &out.KeycloakAuthEnabled
@@ -1143,6 +2460,26 @@ func (in *RancherComponent) DeepCopy() *RancherComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 	*out = *in
@@ -1156,6 +2493,11 @@ func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 		*out = make([]rbacv1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuritySpec.
@@ -1176,6 +2518,11 @@ func (in *VeleroComponent) DeepCopyInto(out *VeleroComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -1272,6 +2619,11 @@ func (in *VerrazzanoList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerrazzanoSpec) DeepCopyInto(out *VerrazzanoSpec) {
 	*out = *in
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(HighAvailabilitySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Components.DeepCopyInto(&out.Components)
 	in.Security.DeepCopyInto(&out.Security)
 	if in.DefaultVolumeSource != nil {
@@ -1286,6 +2638,16 @@ func (in *VerrazzanoSpec) DeepCopyInto(out *VerrazzanoSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoSpec.
@@ -1326,6 +2688,36 @@ func (in *VerrazzanoStatus) DeepCopyInto(out *VerrazzanoStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenSearch != nil {
+		in, out := &in.OpenSearch, &out.OpenSearch
+		*out = new(OpenSearchStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = new(KeycloakStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MySQL != nil {
+		in, out := &in.MySQL, &out.MySQL
+		*out = new(MySQLStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(CertificatesStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoStatus.
@@ -1383,6 +2775,11 @@ func (in *WebLogicOperatorComponent) DeepCopyInto(out *WebLogicOperatorComponent
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -1410,3 +2807,42 @@ func (in *Wildcard) DeepCopy() *Wildcard {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSpec) DeepCopyInto(out *WorkloadSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpec.
+func (in *WorkloadSpec) DeepCopy() *WorkloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
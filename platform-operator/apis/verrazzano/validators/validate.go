@@ -45,6 +45,8 @@ const (
 	InstancePrincipalDelegationToken AuthenticationType = "instance_principle_delegation_token"
 	// UnknownAuthenticationType is used for none meaningful auth type
 	UnknownAuthenticationType AuthenticationType = "unknown_auth_type"
+	// nodeZoneLabel is the well-known label of the zone of a node
+	nodeZoneLabel = "topology.kubernetes.io/zone"
 )
 
 type AuthenticationType string
@@ -341,6 +343,34 @@ func ValidateVersionHigherOrEqual(currentVersion string, requestedVersion string
 
 }
 
+// GetHighAvailabilityWarning returns a warning when the schedulable nodes of the cluster cannot satisfy the high
+// availability mode, empty if they can.  The replicas of the components need at least two nodes to be evicted one at a
+// time, and at least two zones to survive the failure of a zone.
+func GetHighAvailabilityWarning(client client.Client) (string, error) {
+	nodes := &corev1.NodeList{}
+	if err := client.List(context.TODO(), nodes); err != nil {
+		return "", err
+	}
+	schedulable := 0
+	zones := map[string]bool{}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		schedulable++
+		if zone := node.Labels[nodeZoneLabel]; zone != "" {
+			zones[zone] = true
+		}
+	}
+	if schedulable < 2 {
+		return fmt.Sprintf("The high availability mode requires at least two schedulable nodes, the cluster has %d: the replicas of the components run on the same node and are all evicted when it is drained", schedulable), nil
+	}
+	if len(zones) < 2 {
+		return fmt.Sprintf("The %d schedulable nodes of the cluster are not spread across at least two zones: the replicas of the components are spread across the nodes only", schedulable), nil
+	}
+	return "", nil
+}
+
 // getClient returns a controller runtime client for the Verrazzano resource
 func GetClient(scheme *runtime.Scheme) (client.Client, error) {
 
@@ -8,8 +8,12 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/semver"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
 	"os"
 	"path/filepath"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
 
@@ -214,3 +218,31 @@ func Test_cleanTempFiles(t *testing.T) {
 		}
 	}
 }
+
+// TestGetHighAvailabilityWarning tests the GetHighAvailabilityWarning function
+// GIVEN clusters with one schedulable node, two nodes in the same zone and two nodes in different zones
+// WHEN GetHighAvailabilityWarning is called
+// THEN a warning is returned unless the nodes are in at least two zones, and the unschedulable nodes are not counted
+func TestGetHighAvailabilityWarning(t *testing.T) {
+	newNode := func(name string, zone string, unschedulable bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{nodeZoneLabel: zone}},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(newNode("node1", "zone1", false), newNode("node2", "zone2", true)).Build()
+	warning, err := GetHighAvailabilityWarning(c)
+	assert.NoError(t, err)
+	assert.Contains(t, warning, "at least two schedulable nodes, the cluster has 1")
+
+	c = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(newNode("node1", "zone1", false), newNode("node2", "zone1", false)).Build()
+	warning, err = GetHighAvailabilityWarning(c)
+	assert.NoError(t, err)
+	assert.Contains(t, warning, "not spread across at least two zones")
+
+	c = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(newNode("node1", "zone1", false), newNode("node2", "zone2", false)).Build()
+	warning, err = GetHighAvailabilityWarning(c)
+	assert.NoError(t, err)
+	assert.Empty(t, warning)
+}
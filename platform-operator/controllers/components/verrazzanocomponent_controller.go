@@ -0,0 +1,167 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package components
+
+import (
+	"context"
+	"time"
+
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/usercomponent"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// VerrazzanoComponentReconciler reconciles VerrazzanoComponent resources.
+// This controller uninstalls the Helm release of a user-defined component when its VerrazzanoComponent is deleted,
+// the Verrazzano controller installs and upgrades the component.
+type VerrazzanoComponentReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	DryRun bool
+	log    vzlog.VerrazzanoLogger
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *VerrazzanoComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&componentsv1alpha1.VerrazzanoComponent{}).
+		Complete(r)
+}
+
+// Reconcile the VerrazzanoComponent
+func (r *VerrazzanoComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	vc := &componentsv1alpha1.VerrazzanoComponent{}
+	if err := r.Get(ctx, req.NamespacedName, vc); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		zap.S().Errorf("Failed to fetch VerrazzanoComponent %v: %v", req.NamespacedName, err)
+		return newRequeueWithDelay(), err
+	}
+
+	if result, err := r.initLogger(*vc); err != nil {
+		return result, err
+	}
+
+	if vc.DeletionTimestamp.IsZero() {
+		// Add the finalizer so the Helm release is uninstalled when the VerrazzanoComponent is deleted
+		if !controllerutil.ContainsFinalizer(vc, componentsv1alpha1.FinalizerName) {
+			controllerutil.AddFinalizer(vc, componentsv1alpha1.FinalizerName)
+			if err := r.Update(ctx, vc); err != nil {
+				return newRequeueWithDelay(), err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(vc, componentsv1alpha1.FinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	vz, err := r.getVerrazzano(ctx, vc.Namespace)
+	if err != nil {
+		return newRequeueWithDelay(), err
+	}
+
+	// The Verrazzano uninstall removes all components, so only uninstall the component when Verrazzano remains
+	if vz != nil && vz.DeletionTimestamp.IsZero() && !registry.IsBuiltInComponent(vc.GetReleaseName()) {
+		if err := r.uninstallComponent(vz, vc); err != nil {
+			return newRequeueWithDelay(), err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(vc, componentsv1alpha1.FinalizerName)
+	if err := r.Update(ctx, vc); err != nil {
+		return newRequeueWithDelay(), err
+	}
+	return ctrl.Result{}, nil
+}
+
+// getVerrazzano returns the Verrazzano resource in the namespace, or nil if there is none
+func (r *VerrazzanoComponentReconciler) getVerrazzano(ctx context.Context, namespace string) (*installv1alpha1.Verrazzano, error) {
+	vzList := &installv1alpha1.VerrazzanoList{}
+	if err := r.List(ctx, vzList, client.InNamespace(namespace)); err != nil {
+		r.log.Errorf("Failed to fetch Verrazzano resource: %v", err)
+		return nil, err
+	}
+	if len(vzList.Items) == 0 {
+		return nil, nil
+	}
+	return &vzList.Items[0], nil
+}
+
+// uninstallComponent uninstalls the Helm release of the user-defined component and removes the component
+// from the Verrazzano status, which causes the Verrazzano resource to be reconciled
+func (r *VerrazzanoComponentReconciler) uninstallComponent(vz *installv1alpha1.Verrazzano, vc *componentsv1alpha1.VerrazzanoComponent) error {
+	spiCtx, err := spi.NewContext(r.log, r.Client, vz, nil, r.DryRun)
+	if err != nil {
+		r.log.Errorf("Failed to construct component context: %v", err)
+		return err
+	}
+	comp := usercomponent.NewComponent(vc)
+	compContext := spiCtx.Init(comp.Name()).Operation(vzconst.UninstallOperation)
+
+	installed, err := comp.IsInstalled(compContext)
+	if err != nil {
+		return err
+	}
+	if installed {
+		r.log.Oncef("Uninstalling component %s", comp.Name())
+		if err := comp.PreUninstall(compContext); err != nil {
+			return err
+		}
+		if err := comp.Uninstall(compContext); err != nil {
+			r.log.Errorf("Failed uninstalling component %s, will retry: %v", comp.Name(), err)
+			return err
+		}
+		if err := comp.PostUninstall(compContext); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := vz.Status.Components[comp.Name()]; !ok {
+		return nil
+	}
+	delete(vz.Status.Components, comp.Name())
+	return r.Status().Update(context.TODO(), vz)
+}
+
+// initLogger initializes the logger for the VerrazzanoComponent
+func (r *VerrazzanoComponentReconciler) initLogger(vc componentsv1alpha1.VerrazzanoComponent) (ctrl.Result, error) {
+	// Get the resource logger needed to log message using 'progress' and 'once' methods
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           vc.Name,
+		Namespace:      vc.Namespace,
+		ID:             string(vc.UID),
+		Generation:     vc.Generation,
+		ControllerName: "VerrazzanoComponent",
+	})
+	if err != nil {
+		zap.S().Errorf("Failed to create resource logger for VerrazzanoComponent controller: %v", err)
+		return newRequeueWithDelay(), err
+	}
+	r.log = log
+	return ctrl.Result{}, nil
+}
+
+// Create a new Result that will cause a reconcile requeue after a short delay
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(3, 5, time.Second)
+}
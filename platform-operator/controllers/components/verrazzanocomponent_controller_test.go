@@ -0,0 +1,186 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package components
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/helm"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/istio"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	testNS     = "default"
+	testVZName = "verrazzano"
+	testVCName = "policy-agent"
+)
+
+const testReleaseNS = "policy-system"
+
+// setFakeRelease fakes the Helm release of the test component and the Istio release
+func setFakeRelease() {
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction(
+		helm.NewFakeRelease(testVCName, testReleaseNS, release.StatusDeployed, "1.0.0", nil),
+		helm.NewFakeRelease(istio.ComponentName, testNS, release.StatusDeployed, "1.0.0", nil)))
+}
+
+// isReleaseInstalled returns true if the fake Helm release exists
+func isReleaseInstalled(t *testing.T, releaseName string, namespace string) bool {
+	installed, err := helm.IsReleaseInstalled(releaseName, namespace)
+	assert.NoError(t, err)
+	return installed
+}
+
+// TestAddFinalizer tests the Reconcile function
+// GIVEN a VerrazzanoComponent without the finalizer
+// WHEN the VerrazzanoComponent is reconciled
+// THEN the finalizer is added
+func TestAddFinalizer(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newTestVZ(), newTestVC()).Build()
+
+	res, err := newReconciler(cli).Reconcile(context.TODO(), newRequest())
+	asserts.NoError(err)
+	asserts.False(res.Requeue)
+	asserts.True(controllerutil.ContainsFinalizer(getTestVC(t, cli), componentsv1alpha1.FinalizerName))
+}
+
+// TestDeleteUninstallsComponent tests the Reconcile function
+// GIVEN a VerrazzanoComponent that is being deleted and a Verrazzano resource
+// WHEN the VerrazzanoComponent is reconciled
+// THEN the Helm release is uninstalled, the component is removed from the Verrazzano status and the VerrazzanoComponent is deleted
+func TestDeleteUninstallsComponent(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	setFakeRelease()
+	defer helm.SetDefaultActionConfigFunction()
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newTestVZ(), newDeletedTestVC()).Build()
+
+	res, err := newReconciler(cli).Reconcile(context.TODO(), newRequest())
+	asserts.NoError(err)
+	asserts.False(res.Requeue)
+	asserts.False(isReleaseInstalled(t, testVCName, testReleaseNS))
+	asserts.True(isTestVCDeleted(cli))
+
+	vz := &vzapi.Verrazzano{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNS, Name: testVZName}, vz))
+	asserts.NotContains(vz.Status.Components, testVCName)
+	asserts.Contains(vz.Status.Components, istio.ComponentName)
+}
+
+// TestDeleteWithoutVerrazzano tests the Reconcile function
+// GIVEN a VerrazzanoComponent that is being deleted and no Verrazzano resource
+// WHEN the VerrazzanoComponent is reconciled
+// THEN nothing is uninstalled and the VerrazzanoComponent is deleted
+func TestDeleteWithoutVerrazzano(t *testing.T) {
+	asserts := assert.New(t)
+	setFakeRelease()
+	defer helm.SetDefaultActionConfigFunction()
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newDeletedTestVC()).Build()
+
+	res, err := newReconciler(cli).Reconcile(context.TODO(), newRequest())
+	asserts.NoError(err)
+	asserts.False(res.Requeue)
+	asserts.True(isReleaseInstalled(t, testVCName, testReleaseNS))
+	asserts.True(isTestVCDeleted(cli))
+}
+
+// TestDeleteBuiltInName tests the Reconcile function
+// GIVEN a VerrazzanoComponent that is being deleted and that has the name of a built-in component
+// WHEN the VerrazzanoComponent is reconciled
+// THEN the built-in component is not uninstalled and the VerrazzanoComponent is deleted
+func TestDeleteBuiltInName(t *testing.T) {
+	asserts := assert.New(t)
+	setFakeRelease()
+	defer helm.SetDefaultActionConfigFunction()
+
+	vc := newDeletedTestVC()
+	vc.Spec.ReleaseName = istio.ComponentName
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newTestVZ(), vc).Build()
+
+	_, err := newReconciler(cli).Reconcile(context.TODO(), newRequest())
+	asserts.NoError(err)
+	asserts.True(isReleaseInstalled(t, istio.ComponentName, testNS))
+	asserts.True(isTestVCDeleted(cli))
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = componentsv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func newReconciler(c client.Client) *VerrazzanoComponentReconciler {
+	return &VerrazzanoComponentReconciler{
+		Client: c,
+		Scheme: newScheme(),
+	}
+}
+
+func newRequest() ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNS, Name: testVCName}}
+}
+
+func newTestVZ() *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: testVZName},
+		Status: vzapi.VerrazzanoStatus{
+			State: vzapi.VzStateReady,
+			Components: map[string]*vzapi.ComponentStatusDetails{
+				istio.ComponentName: {Name: istio.ComponentName, State: vzapi.CompStateReady},
+				testVCName:          {Name: testVCName, State: vzapi.CompStateReady},
+			},
+		},
+	}
+}
+
+func newTestVC() *componentsv1alpha1.VerrazzanoComponent {
+	return &componentsv1alpha1.VerrazzanoComponent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: testVCName},
+		Spec: componentsv1alpha1.VerrazzanoComponentSpec{
+			Chart:     componentsv1alpha1.ChartLocation{Path: "oci://registry.example.com/charts/policy-agent"},
+			Namespace: testReleaseNS,
+		},
+	}
+}
+
+func newDeletedTestVC() *componentsv1alpha1.VerrazzanoComponent {
+	vc := newTestVC()
+	vc.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	vc.Finalizers = []string{componentsv1alpha1.FinalizerName}
+	return vc
+}
+
+// isTestVCDeleted returns true if the VerrazzanoComponent is gone, which happens once the finalizer is removed
+func isTestVCDeleted(c client.Client) bool {
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNS, Name: testVCName}, &componentsv1alpha1.VerrazzanoComponent{})
+	return errors.IsNotFound(err)
+}
+
+func getTestVC(t *testing.T, c client.Client) *componentsv1alpha1.VerrazzanoComponent {
+	vc := &componentsv1alpha1.VerrazzanoComponent{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNS, Name: testVCName}, vc))
+	return vc
+}
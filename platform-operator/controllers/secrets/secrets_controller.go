@@ -72,6 +72,11 @@ func (r *VerrazzanoSecretsReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			return r.reconcileVerrazzanoTLS(ctx, req)
 		}
 
+		// While the Verrazzano CA is rotated, the CA bundle also trusts the CAs of the transition secret
+		if isCATransitionSecretName(req.NamespacedName) {
+			return r.reconcileVerrazzanoTLS(ctx, r.getCASourceSecretRequest())
+		}
+
 		res, err := r.reconcileInstallOverrideSecret(ctx, req, vz)
 		if err != nil {
 			zap.S().Errorf("Failed to reconcile Secret: %v", err)
@@ -113,6 +118,18 @@ func (r *VerrazzanoSecretsReconciler) additionalTLSSecretExists() bool {
 	return true
 }
 
+// getCASourceSecretRequest returns a request for the secret that the CA bundle is copied from
+func (r *VerrazzanoSecretsReconciler) getCASourceSecretRequest() ctrl.Request {
+	if r.additionalTLSSecretExists() {
+		return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: vzconst.RancherSystemNamespace, Name: vzconst.AdditionalTLS}}
+	}
+	return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.VerrazzanoIngressSecret}}
+}
+
+func isCATransitionSecretName(secretName types.NamespacedName) bool {
+	return secretName.Name == vzconst.VerrazzanoCATransitionSecret && secretName.Namespace == vzconst.CertManagerNamespace
+}
+
 func isAdditionalTLSSecretName(secretName types.NamespacedName) bool {
 	return secretName.Name == vzconst.AdditionalTLS && secretName.Namespace == vzconst.RancherSystemNamespace
 }
@@ -5,7 +5,14 @@ package secrets
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"testing"
 	"time"
@@ -34,6 +41,7 @@ var mcNamespace = types.NamespacedName{Name: constants.VerrazzanoMultiClusterNam
 var vzTLSSecret = types.NamespacedName{Name: constants.VerrazzanoIngressSecret, Namespace: constants.VerrazzanoSystemNamespace}
 var additionalTLSSecret = types.NamespacedName{Name: constants2.AdditionalTLS, Namespace: constants2.RancherSystemNamespace}
 var vzLocalCaBundleSecret = types.NamespacedName{Name: "verrazzano-local-ca-bundle", Namespace: constants.VerrazzanoMultiClusterNamespace}
+var caTransitionSecret = types.NamespacedName{Name: constants2.VerrazzanoCATransitionSecret, Namespace: constants2.CertManagerNamespace}
 var unwatchedSecret = types.NamespacedName{Name: "any-secret", Namespace: "any-namespace"}
 
 const addnlTLSData = "YWRkaXRpb25hbCB0bHMgc2VjcmV0" // "additional tls secret"
@@ -114,6 +122,60 @@ func TestCreateLocalCABundle(t *testing.T) {
 	}
 }
 
+// TestCABundleDuringCARotation tests the Reconcile method for the following use case
+// GIVEN a request to reconcile the CA transition secret during a rotation of the Verrazzano CA
+// WHEN the local-ca-bundle secret exists
+// THEN the local-ca-bundle trusts the CAs of the transition secret until the secret is deleted
+func TestCABundleDuringCARotation(t *testing.T) {
+	asserts := assert.New(t)
+	previousCA := newTestCA(t, "verrazzano-root-ca-previous")
+	currentCA := newTestCA(t, "verrazzano-root-ca-current")
+	transition := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: caTransitionSecret.Name, Namespace: caTransitionSecret.Namespace},
+		Data:       map[string][]byte{"ca.crt": append(append([]byte{}, previousCA...), currentCA...)},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		&testVZ,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: mcNamespace.Name}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: vzTLSSecret.Name, Namespace: vzTLSSecret.Namespace},
+			Data:       map[string][]byte{"ca.crt": currentCA},
+		},
+		transition,
+	).Build()
+	reconciler := newSecretsReconciler(cli)
+
+	_, err := reconciler.Reconcile(context.TODO(), newRequest(caTransitionSecret.Namespace, caTransitionSecret.Name))
+	asserts.NoError(err)
+	bundle := &corev1.Secret{}
+	asserts.NoError(cli.Get(context.TODO(), vzLocalCaBundleSecret, bundle))
+	asserts.Equal(append(append([]byte{}, currentCA...), previousCA...), bundle.Data["ca-bundle"])
+
+	// Once the previous CA is retired, the bundle only trusts the current CA
+	asserts.NoError(cli.Delete(context.TODO(), transition))
+	_, err = reconciler.Reconcile(context.TODO(), newRequest(caTransitionSecret.Namespace, caTransitionSecret.Name))
+	asserts.NoError(err)
+	asserts.NoError(cli.Get(context.TODO(), vzLocalCaBundleSecret, bundle))
+	asserts.Equal(currentCA, bundle.Data["ca-bundle"])
+}
+
+// newTestCA returns a PEM encoded self-signed CA certificate
+func newTestCA(t *testing.T, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
 // TestIgnoresOtherSecrets tests the Reconcile method for the following use case
 // GIVEN a request to reconcile a secret other than verrazzano TLS secret or additional TLS secret
 // WHEN any conditions
@@ -559,6 +621,12 @@ func expectGetCalls(t *testing.T, mock *mocks.MockClient, secretNS string, secre
 			return nil
 		}).MinTimes(1)
 
+	// Expect a call to get the CA transition secret, which only exists while the CA is rotated
+	mock.EXPECT().
+		Get(gomock.Any(), caTransitionSecret, gomock.Not(gomock.Nil())).
+		Return(errors.NewNotFound(schema.GroupResource{Group: constants2.CertManagerNamespace, Resource: "Secret"}, caTransitionSecret.Name)).
+		AnyTimes()
+
 	// Expect a call to get the local ca bundle secret
 	mock.EXPECT().
 		Get(gomock.Any(), vzLocalCaBundleSecret, gomock.Not(gomock.Nil())).
@@ -6,6 +6,7 @@ package secrets
 import (
 	"context"
 	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/security/cabundle"
 
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 
@@ -18,13 +19,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const caCrtKey = "ca.crt"
+
 // reconcileVerrazzanoTLS reconciles secret containing the admin ca bundle in the Multi Cluster namespace
 func (r *VerrazzanoSecretsReconciler) reconcileVerrazzanoTLS(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 
 	isVzIngressSecret := isVerrazzanoIngressSecretName(req.NamespacedName)
 	isAddnlTLSSecret := isAdditionalTLSSecretName(req.NamespacedName)
 
-	caKey := "ca.crt"
+	caKey := caCrtKey
 	if isAddnlTLSSecret {
 		caKey = vzconst.AdditionalTLSCAKey
 	}
@@ -74,12 +77,25 @@ func (r *VerrazzanoSecretsReconciler) reconcileVerrazzanoTLS(ctx context.Context
 		mcCASecret.Namespace = constants.VerrazzanoMultiClusterNamespace
 	}
 
+	// While the Verrazzano CA is rotated, the bundle trusts both the previous and the new CA
+	transitionSecret := corev1.Secret{}
+	err = r.Get(context.TODO(), client.ObjectKey{
+		Namespace: vzconst.CertManagerNamespace,
+		Name:      vzconst.VerrazzanoCATransitionSecret,
+	}, &transitionSecret)
+	if client.IgnoreNotFound(err) != nil {
+		r.log.Errorf("Failed to fetch secret %s/%s: %v",
+			vzconst.CertManagerNamespace, vzconst.VerrazzanoCATransitionSecret, err)
+		return newRequeueWithDelay(), nil
+	}
+	caBundle := cabundle.AppendCertificates(caSecret.Data[caKey], transitionSecret.Data[caCrtKey])
+
 	result, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, &mcCASecret, func() error {
 		if mcCASecret.Data == nil {
 			mcCASecret.Data = make(map[string][]byte)
 		}
 		zap.S().Debugf("Updating MC CA secret with data from %s key of %s/%s secret ", caKey, caSecret.Namespace, caSecret.Name)
-		mcCASecret.Data["ca-bundle"] = caSecret.Data[caKey]
+		mcCASecret.Data["ca-bundle"] = caBundle
 		return nil
 	})
 
@@ -0,0 +1,47 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/authproxy"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// watchExternalOIDCSecrets watches the Secrets in the namespace of the Verrazzano resource, so that the AuthProxy is
+// reconciled when the client secret or the CA bundle of the external OIDC provider is rotated
+func (r *Reconciler) watchExternalOIDCSecrets(namespace string, name string, log vzlog.VerrazzanoLogger) error {
+	log.Debugf("Watching for external OIDC secrets to activate reconcile for Verrazzano CR %s/%s", namespace, name)
+	return r.Controller.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		createReconcileEventHandler(namespace, name),
+		predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return r.markExternalOIDCSecret(namespace, name, object)
+		}))
+}
+
+// markExternalOIDCSecret marks the AuthProxy for reconcile if the Secret holds the client secret or the CA bundle of
+// the external OIDC provider of the Verrazzano resource
+func (r *Reconciler) markExternalOIDCSecret(namespace string, name string, object client.Object) bool {
+	if object.GetNamespace() != namespace {
+		return false
+	}
+	vz := &installv1alpha1.Verrazzano{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, vz); err != nil {
+		return false
+	}
+	if !common.IsExternalOIDCSecret(vz, object.GetName()) {
+		return false
+	}
+	r.AddWatch(authproxy.ComponentJSONName)
+	return true
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/authproxy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestMarkExternalOIDCSecret tests the markExternalOIDCSecret function
+// GIVEN a Verrazzano resource configuring an external OIDC provider
+// WHEN a Secret event is received
+// THEN the AuthProxy is marked for reconcile only for the Secrets of the external OIDC provider
+func TestMarkExternalOIDCSecret(t *testing.T) {
+	asserts := assert.New(t)
+	vz := newMaintenanceTestVZ(nil)
+	vz.Spec.Components.AuthProxy = &vzapi.AuthProxyComponent{
+		OIDC: &vzapi.AuthProxyOIDC{
+			ClientSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp"}, Key: "client-secret"},
+		},
+	}
+	r := newMaintenanceTestReconciler(vz)
+	newSecret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	asserts.False(r.markExternalOIDCSecret(vz.Namespace, vz.Name, newSecret(vz.Namespace, "other")))
+	asserts.False(r.markExternalOIDCSecret(vz.Namespace, vz.Name, newSecret("verrazzano-system", "idp")))
+	asserts.False(r.IsWatchedComponent(authproxy.ComponentJSONName))
+
+	asserts.True(r.markExternalOIDCSecret(vz.Namespace, vz.Name, newSecret(vz.Namespace, "idp")))
+	asserts.True(r.IsWatchedComponent(authproxy.ComponentJSONName))
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package availability
+
+import (
+	"context"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// managedLabel identifies the pod disruption budgets created by the platform operator
+const managedLabel = "verrazzano.io/pod-disruption-budget"
+
+// getComponentsFunc returns the components declaring their replicated pods, can be overridden for unit testing
+var getComponentsFunc = registry.GetComponents
+
+// ReconcilePodDisruptionBudgets creates and updates the pod disruption budgets of the replicated pods of the enabled
+// components in the high availability mode, and deletes the pod disruption budgets that are no longer required.  Each
+// budget allows one pod to be evicted at a time.  The pods of workloads with less than two replicas are skipped, such
+// as the single MySQL instance of Keycloak, and so are the pods already covered by a pod disruption budget created by
+// their chart or operator, since the eviction of a pod selected by more than one budget is always refused.  All the
+// budgets are deleted when the high availability mode is disabled.  It returns a warning when the nodes of the
+// cluster cannot satisfy the high availability mode.
+func ReconcilePodDisruptionBudgets(ctx spi.ComponentContext) (string, error) {
+	var budgets []*policyv1.PodDisruptionBudget
+	var warning string
+	if vzconfig.IsHighAvailabilityEnabled(ctx.EffectiveCR()) {
+		warning = getHighAvailabilityWarning(ctx)
+		var err error
+		if budgets, err = getPodDisruptionBudgets(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	names := map[types.NamespacedName]bool{}
+	for _, budget := range budgets {
+		names[types.NamespacedName{Namespace: budget.Namespace, Name: budget.Name}] = true
+		if err := createOrUpdatePodDisruptionBudget(ctx, budget); err != nil {
+			return "", err
+		}
+	}
+	return warning, deleteRemovedPodDisruptionBudgets(ctx, names)
+}
+
+// getHighAvailabilityWarning returns a warning when the nodes of the cluster cannot satisfy the high availability
+// mode, the warning is also logged once
+func getHighAvailabilityWarning(ctx spi.ComponentContext) string {
+	warning, err := validators.GetHighAvailabilityWarning(ctx.Client())
+	if err != nil {
+		ctx.Log().Infof("Failed checking the nodes of the cluster for the high availability mode: %v", err)
+		return ""
+	}
+	if warning != "" {
+		ctx.Log().Oncef(warning)
+	}
+	return warning
+}
+
+// getPodDisruptionBudgets returns the pod disruption budgets of the replicated pods of the enabled components whose
+// namespace exists
+func getPodDisruptionBudgets(ctx spi.ComponentContext) ([]*policyv1.PodDisruptionBudget, error) {
+	var budgets []*policyv1.PodDisruptionBudget
+	for _, comp := range getComponentsFunc() {
+		availabilityComp, ok := comp.(spi.ComponentAvailability)
+		if !ok || !comp.IsEnabled(ctx.EffectiveCR()) {
+			continue
+		}
+		declared := availabilityComp.GetPodDisruptionBudgets(ctx)
+		if len(declared) == 0 {
+			continue
+		}
+		namespace := comp.Namespace()
+		exists, err := namespaceExists(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		for _, pods := range declared {
+			replicas, err := getReplicas(ctx, namespace, pods.PodLabels)
+			if err != nil {
+				return nil, err
+			}
+			if replicas < 2 {
+				continue
+			}
+			covered, err := isCoveredByOtherBudget(ctx, namespace, pods.PodLabels)
+			if err != nil {
+				return nil, err
+			}
+			if !covered {
+				budgets = append(budgets, newPodDisruptionBudget(namespace, pods))
+			}
+		}
+	}
+	return budgets, nil
+}
+
+// getReplicas returns the number of replicas of the Deployments and StatefulSets of the namespace whose pods are
+// selected by the labels
+func getReplicas(ctx spi.ComponentContext, namespace string, podLabels map[string]string) (int32, error) {
+	selector := labels.SelectorFromSet(podLabels)
+	var replicas int32
+	deployments := &appsv1.DeploymentList{}
+	if err := ctx.Client().List(context.TODO(), deployments, client.InNamespace(namespace)); err != nil {
+		return 0, ctx.Log().ErrorfNewErr("Failed listing the deployments of namespace %s: %v", namespace, err)
+	}
+	for _, deployment := range deployments.Items {
+		replicas += getSelectedReplicas(selector, deployment.Spec.Template.Labels, deployment.Spec.Replicas)
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := ctx.Client().List(context.TODO(), statefulSets, client.InNamespace(namespace)); err != nil {
+		return 0, ctx.Log().ErrorfNewErr("Failed listing the statefulsets of namespace %s: %v", namespace, err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		replicas += getSelectedReplicas(selector, statefulSet.Spec.Template.Labels, statefulSet.Spec.Replicas)
+	}
+	return replicas, nil
+}
+
+// getSelectedReplicas returns the replicas of a workload if its pods are selected, the replicas default to one when
+// they are not set
+func getSelectedReplicas(selector labels.Selector, templateLabels map[string]string, replicas *int32) int32 {
+	if !selector.Matches(labels.Set(templateLabels)) {
+		return 0
+	}
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// isCoveredByOtherBudget returns true if one of the pods is selected by a pod disruption budget that was not created
+// by the platform operator
+func isCoveredByOtherBudget(ctx spi.ComponentContext, namespace string, podLabels map[string]string) (bool, error) {
+	budgets := &policyv1.PodDisruptionBudgetList{}
+	if err := ctx.Client().List(context.TODO(), budgets, client.InNamespace(namespace)); err != nil {
+		return false, ctx.Log().ErrorfNewErr("Failed listing the pod disruption budgets of namespace %s: %v", namespace, err)
+	}
+	var selectors []labels.Selector
+	for i := range budgets.Items {
+		budget := &budgets.Items[i]
+		if _, ok := budget.Labels[managedLabel]; ok || budget.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		selectors = append(selectors, selector)
+	}
+	if len(selectors) == 0 {
+		return false, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := ctx.Client().List(context.TODO(), pods, client.InNamespace(namespace), client.MatchingLabels(podLabels)); err != nil {
+		return false, ctx.Log().ErrorfNewErr("Failed listing the pods of namespace %s: %v", namespace, err)
+	}
+	for _, pod := range pods.Items {
+		for _, selector := range selectors {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// createOrUpdatePodDisruptionBudget creates or updates a pod disruption budget of a component
+func createOrUpdatePodDisruptionBudget(ctx spi.ComponentContext, budget *policyv1.PodDisruptionBudget) error {
+	existing := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: budget.Name, Namespace: budget.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), existing, func() error {
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		existing.Labels[managedLabel] = "true"
+		existing.Spec = budget.Spec
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating pod disruption budget %s/%s: %v", budget.Namespace, budget.Name, err)
+	}
+	return nil
+}
+
+// deleteRemovedPodDisruptionBudgets deletes the pod disruption budgets created by the platform operator that are not
+// in names
+func deleteRemovedPodDisruptionBudgets(ctx spi.ComponentContext, names map[types.NamespacedName]bool) error {
+	budgets := &policyv1.PodDisruptionBudgetList{}
+	if err := ctx.Client().List(context.TODO(), budgets, client.HasLabels{managedLabel}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing pod disruption budgets: %v", err)
+	}
+	for i := range budgets.Items {
+		budget := &budgets.Items[i]
+		if names[types.NamespacedName{Namespace: budget.Namespace, Name: budget.Name}] {
+			continue
+		}
+		ctx.Log().Oncef("Deleting pod disruption budget %s/%s", budget.Namespace, budget.Name)
+		if err := ctx.Client().Delete(context.TODO(), budget); client.IgnoreNotFound(err) != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting pod disruption budget %s/%s: %v", budget.Namespace, budget.Name, err)
+		}
+	}
+	return nil
+}
+
+// namespaceExists returns true if the namespace exists
+func namespaceExists(ctx spi.ComponentContext, namespace string) (bool, error) {
+	err := ctx.Client().Get(context.TODO(), types.NamespacedName{Name: namespace}, &corev1.Namespace{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, ctx.Log().ErrorfNewErr("Failed getting namespace %s: %v", namespace, err)
+	}
+	return true, nil
+}
+
+// newPodDisruptionBudget returns the pod disruption budget allowing one of the replicated pods to be evicted at a time
+func newPodDisruptionBudget(namespace string, pods spi.PodDisruptionBudget) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      pods.Name,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: pods.PodLabels},
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+}
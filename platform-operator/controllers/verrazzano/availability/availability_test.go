@@ -0,0 +1,156 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package availability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	keycloakNamespace = "keycloak"
+	nginxNamespace    = "ingress-nginx"
+)
+
+// newTestComponents returns a component declaring replicated pods, a component whose pods are covered by the pod
+// disruption budget of their chart, and a component of a namespace that does not exist
+func newTestComponents() []spi.Component {
+	return []spi.Component{
+		helm.HelmComponent{
+			ReleaseName:    "keycloak",
+			ChartNamespace: keycloakNamespace,
+			PodDisruptionBudgets: []spi.PodDisruptionBudget{
+				{Name: "keycloak", PodLabels: map[string]string{"app": "keycloak"}},
+				{Name: "mysql", PodLabels: map[string]string{"app": "mysql"}},
+			},
+		},
+		helm.HelmComponent{
+			ReleaseName:          "ingress-controller",
+			ChartNamespace:       nginxNamespace,
+			PodDisruptionBudgets: []spi.PodDisruptionBudget{{Name: "ingress-controller", PodLabels: map[string]string{"app": "ingress-controller"}}},
+		},
+		helm.HelmComponent{
+			ReleaseName:          "cert-manager",
+			ChartNamespace:       "cert-manager",
+			PodDisruptionBudgets: []spi.PodDisruptionBudget{{Name: "cert-manager", PodLabels: map[string]string{"app": "cert-manager"}}},
+		},
+	}
+}
+
+func newHighAvailabilityVZ(enabled bool) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Spec: vzapi.VerrazzanoSpec{
+			HighAvailability: &vzapi.HighAvailabilitySpec{Enabled: &enabled},
+		},
+	}
+}
+
+func newManagedBudget(namespace string, name string) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{managedLabel: "true"}},
+	}
+}
+
+// newWorkload returns a Deployment, or a StatefulSet when stateful is true, with the replicas and the pod labels
+func newWorkload(namespace string, name string, replicas int32, stateful bool, podLabels map[string]string) client.Object {
+	template := corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}}
+	meta := metav1.ObjectMeta{Namespace: namespace, Name: name}
+	if stateful {
+		return &appsv1.StatefulSet{ObjectMeta: meta, Spec: appsv1.StatefulSetSpec{Replicas: &replicas, Template: template}}
+	}
+	return &appsv1.Deployment{ObjectMeta: meta, Spec: appsv1.DeploymentSpec{Replicas: &replicas, Template: template}}
+}
+
+func newFakeClient(objects ...client.Object) client.Client {
+	chartBudget := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: nginxNamespace, Name: "ingress-controller-chart"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ingress-controller", "component": "controller"}},
+		},
+	}
+	objects = append(objects, chartBudget,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: keycloakNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nginxNamespace}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: nginxNamespace, Name: "ingress-controller-0",
+			Labels: map[string]string{"app": "ingress-controller", "component": "controller"}}},
+		newWorkload(keycloakNamespace, "keycloak", 2, true, map[string]string{"app": "keycloak", "component": "server"}),
+		newWorkload(keycloakNamespace, "mysql", 1, false, map[string]string{"app": "mysql"}),
+		newWorkload(nginxNamespace, "ingress-controller", 2, false, map[string]string{"app": "ingress-controller", "component": "controller"}),
+	)
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build()
+}
+
+// TestReconcilePodDisruptionBudgets tests the ReconcilePodDisruptionBudgets function
+// GIVEN a Verrazzano resource with the high availability mode enabled
+// WHEN ReconcilePodDisruptionBudgets is called
+// THEN the pod disruption budgets of the replicated pods are created in the namespaces that exist, the pods of a single
+// replica and the pods covered by the pod disruption budget of their chart are skipped, the stale budgets created by
+// the operator are deleted, and a warning is returned since the cluster has no nodes
+func TestReconcilePodDisruptionBudgets(t *testing.T) {
+	getComponentsFunc = newTestComponents
+	defer func() { getComponentsFunc = registry.GetComponents }()
+
+	c := newFakeClient(newManagedBudget(keycloakNamespace, "removed"), newManagedBudget(keycloakNamespace, "mysql"),
+		newManagedBudget(nginxNamespace, "ingress-controller"))
+	ctx := spi.NewFakeContext(c, newHighAvailabilityVZ(true), nil, false)
+
+	warning, err := ReconcilePodDisruptionBudgets(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, warning, "at least two schedulable nodes")
+
+	budgets := &policyv1.PodDisruptionBudgetList{}
+	assert.NoError(t, c.List(context.TODO(), budgets))
+	var names []string
+	for _, budget := range budgets.Items {
+		names = append(names, budget.Namespace+"/"+budget.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"keycloak/keycloak",
+		"ingress-nginx/ingress-controller-chart",
+	}, names)
+
+	budget := &policyv1.PodDisruptionBudget{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: keycloakNamespace, Name: "keycloak"}, budget))
+	assert.Equal(t, "true", budget.Labels[managedLabel])
+	assert.Equal(t, map[string]string{"app": "keycloak"}, budget.Spec.Selector.MatchLabels)
+	assert.Equal(t, 1, budget.Spec.MaxUnavailable.IntValue())
+	assert.Nil(t, budget.Spec.MinAvailable)
+}
+
+// TestReconcilePodDisruptionBudgetsDisabled tests the ReconcilePodDisruptionBudgets function
+// GIVEN a Verrazzano resource with the high availability mode disabled
+// WHEN ReconcilePodDisruptionBudgets is called
+// THEN the pod disruption budgets created by the operator are deleted, the other budgets are kept, and no warning is
+// returned
+func TestReconcilePodDisruptionBudgetsDisabled(t *testing.T) {
+	getComponentsFunc = newTestComponents
+	defer func() { getComponentsFunc = registry.GetComponents }()
+
+	c := newFakeClient(newManagedBudget(keycloakNamespace, "keycloak"), newManagedBudget(keycloakNamespace, "mysql"))
+	ctx := spi.NewFakeContext(c, newHighAvailabilityVZ(false), nil, false)
+
+	warning, err := ReconcilePodDisruptionBudgets(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, warning)
+
+	budgets := &policyv1.PodDisruptionBudgetList{}
+	assert.NoError(t, c.List(context.TODO(), budgets))
+	assert.Len(t, budgets.Items, 1)
+	assert.Equal(t, "ingress-controller-chart", budgets.Items[0].Name)
+}
@@ -0,0 +1,362 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// storageLocationName is the name of the Velero backup storage location and of the OpenSearch snapshot repository
+	// of the scheduled backups
+	storageLocationName = "verrazzano-platform-backup"
+	// credentialsSecretName is the name of the copies of the storage credentials made for Velero and rancher-backup
+	credentialsSecretName = "verrazzano-platform-backup-credentials"
+
+	// scheduleLabel and storeLabel identify the objects created for the stores of a backup schedule
+	scheduleLabel = "verrazzano.io/backup-schedule"
+	storeLabel    = "verrazzano.io/backup-store"
+
+	// snapshotSchedulePrefix prefixes the names of the OpenSearch snapshot schedules of the backup schedules
+	snapshotSchedulePrefix = "platform-backup-"
+
+	defaultRegion        = "us-east-1"
+	defaultRetentionDays = 30
+
+	// statusRefreshInterval is how often the status of the last backups is refreshed
+	statusRefreshInterval = 5 * time.Minute
+)
+
+// States of the last backup of a store
+const (
+	StateInProgress      = "InProgress"
+	StateCompleted       = "Completed"
+	StatePartiallyFailed = "PartiallyFailed"
+	StateFailed          = "Failed"
+)
+
+// retentionReference is the start of the period in which the backups kept by Rancher and OpenSearch are counted, a
+// fixed time keeps the count of irregular schedules from changing between reconciles
+var retentionReference = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ReconcileBackups creates and updates the Velero schedules, the rancher-backup backups and their storage locations
+// for the backup schedules of the Verrazzano resource, and deletes those of the schedules that have been removed.  The
+// OpenSearch snapshots are taken by the OpenSearch component, see OpenSearchSnapshots.  It returns the status of the
+// last backup of each store, and how long to wait before refreshing it; zero means there is nothing to refresh.
+func ReconcileBackups(ctx spi.ComponentContext) (*vzapi.BackupStatus, time.Duration, error) {
+	spec := ctx.EffectiveCR().Spec.Backup
+	if err := deleteRemovedVeleroSchedules(ctx, spec); err != nil {
+		return nil, 0, err
+	}
+	if err := deleteRemovedRancherBackups(ctx, spec); err != nil {
+		return nil, 0, err
+	}
+	if spec == nil || len(spec.Schedules) == 0 {
+		return nil, 0, nil
+	}
+
+	if hasStore(ctx.EffectiveCR(), isVeleroStore) {
+		if err := createOrUpdateVeleroStorage(ctx, spec.Storage); err != nil {
+			return nil, 0, err
+		}
+	}
+	if hasStore(ctx.EffectiveCR(), isRancherStore) {
+		if err := createOrUpdateRancherCredentials(ctx, spec.Storage); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	status := &vzapi.BackupStatus{}
+	for _, schedule := range spec.Schedules {
+		for _, store := range getStores(ctx.EffectiveCR(), schedule) {
+			storeStatus := vzapi.BackupStoreStatus{Schedule: schedule.Name, Store: store}
+			var err error
+			switch {
+			case !isStoreEnabled(ctx.EffectiveCR(), store):
+				storeStatus.State = StateFailed
+				storeStatus.Message = "The components that back up the store are not enabled"
+			case isVeleroStore(store):
+				err = reconcileVeleroSchedule(ctx, schedule, store, &storeStatus)
+			case isRancherStore(store):
+				err = reconcileRancherBackup(ctx, spec, schedule, &storeStatus)
+			default:
+				getSnapshotStatus(ctx.ActualCR(), schedule, &storeStatus)
+			}
+			if err != nil {
+				return nil, 0, err
+			}
+			status.Stores = append(status.Stores, storeStatus)
+		}
+	}
+	return status, statusRefreshInterval, nil
+}
+
+// OpenSearchSnapshots returns the snapshot repository and the snapshot schedules that back up the OpenSearch store of
+// the backup schedules, nil if no schedule backs up OpenSearch
+func OpenSearchSnapshots(cr *vzapi.Verrazzano) *vzapi.OpenSearchSnapshots {
+	spec := cr.Spec.Backup
+	if spec == nil || !vzconfig.IsOpenSearchEnabled(cr) {
+		return nil
+	}
+	var schedules []vzapi.OpenSearchSnapshotSchedule
+	for _, schedule := range spec.Schedules {
+		if !includesStore(cr, schedule, vzapi.BackupStoreOpenSearch) {
+			continue
+		}
+		snapshotSchedule := vzapi.OpenSearchSnapshotSchedule{
+			Name:       snapshotSchedulePrefix + schedule.Name,
+			Repository: storageLocationName,
+			Schedule:   schedule.Schedule,
+		}
+		if count, err := retentionCount(schedule); err == nil {
+			snapshotSchedule.MaxCount = &count
+		}
+		schedules = append(schedules, snapshotSchedule)
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+	storage := spec.Storage
+	return &vzapi.OpenSearchSnapshots{
+		Repositories: []vzapi.OpenSearchSnapshotRepository{{
+			Name: storageLocationName,
+			S3: &vzapi.OpenSearchS3Repository{
+				Bucket:          storage.Bucket,
+				BasePath:        storePath(storage, "opensearch"),
+				Endpoint:        getEndpointHost(storage),
+				Region:          getRegion(storage),
+				PathStyleAccess: storage.PathStyleAccess,
+			},
+		}},
+		Schedules:           schedules,
+		S3CredentialsSecret: storage.CredentialsSecret,
+	}
+}
+
+// getSnapshotStatus fills in the store status from the status of the last OpenSearch snapshot of the schedule
+func getSnapshotStatus(actualCR *vzapi.Verrazzano, schedule vzapi.BackupSchedule, status *vzapi.BackupStoreStatus) {
+	if actualCR.Status.OpenSearch == nil {
+		return
+	}
+	for _, snapshot := range actualCR.Status.OpenSearch.Snapshots {
+		if snapshot.Schedule != snapshotSchedulePrefix+schedule.Name || snapshot.Name == "" {
+			continue
+		}
+		status.Name = snapshot.Name
+		status.StartTime = snapshot.StartTime
+		status.CompletionTime = snapshot.EndTime
+		status.Message = snapshot.Message
+		switch snapshot.State {
+		case "SUCCESS":
+			status.State = StateCompleted
+		case "PARTIAL":
+			status.State = StatePartiallyFailed
+		case "FAILED":
+			status.State = StateFailed
+		default:
+			status.State = StateInProgress
+		}
+	}
+}
+
+// getStores returns the stores backed up by the schedule, by default all the stores whose components are enabled
+func getStores(cr *vzapi.Verrazzano, schedule vzapi.BackupSchedule) []vzapi.BackupStore {
+	if len(schedule.Stores) > 0 {
+		return schedule.Stores
+	}
+	var stores []vzapi.BackupStore
+	for _, store := range []vzapi.BackupStore{vzapi.BackupStoreKeycloakMySQL, vzapi.BackupStoreOpenSearch, vzapi.BackupStoreRancher, vzapi.BackupStorePlatformNamespaces} {
+		if isStoreEnabled(cr, store) {
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+// includesStore returns true if the schedule backs up the store and the components that back it up are enabled
+func includesStore(cr *vzapi.Verrazzano, schedule vzapi.BackupSchedule, store vzapi.BackupStore) bool {
+	for _, s := range getStores(cr, schedule) {
+		if s == store {
+			return isStoreEnabled(cr, store)
+		}
+	}
+	return false
+}
+
+// hasStore returns true if a backup schedule backs up an enabled store selected by the filter
+func hasStore(cr *vzapi.Verrazzano, filter func(store vzapi.BackupStore) bool) bool {
+	for _, schedule := range cr.Spec.Backup.Schedules {
+		for _, store := range getStores(cr, schedule) {
+			if filter(store) && isStoreEnabled(cr, store) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isStoreEnabled returns true if the store and the components that back it up are enabled
+func isStoreEnabled(cr *vzapi.Verrazzano, store vzapi.BackupStore) bool {
+	switch store {
+	case vzapi.BackupStoreKeycloakMySQL:
+		return vzconfig.IsVeleroEnabled(cr) && vzconfig.IsKeycloakEnabled(cr)
+	case vzapi.BackupStorePlatformNamespaces:
+		return vzconfig.IsVeleroEnabled(cr)
+	case vzapi.BackupStoreRancher:
+		return vzconfig.IsRancherBackupEnabled(cr) && vzconfig.IsRancherEnabled(cr)
+	case vzapi.BackupStoreOpenSearch:
+		return vzconfig.IsOpenSearchEnabled(cr)
+	}
+	return false
+}
+
+func isVeleroStore(store vzapi.BackupStore) bool {
+	return store == vzapi.BackupStoreKeycloakMySQL || store == vzapi.BackupStorePlatformNamespaces
+}
+
+func isRancherStore(store vzapi.BackupStore) bool {
+	return store == vzapi.BackupStoreRancher
+}
+
+// getRetention returns how long the backups of the schedule are kept
+func getRetention(schedule vzapi.BackupSchedule) time.Duration {
+	days := int32(defaultRetentionDays)
+	if schedule.RetentionDays != nil {
+		days = *schedule.RetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// retentionCount returns the number of backups of the schedule that cover its retention period, for the stores that
+// keep a number of backups rather than an age; at least one backup is kept
+func retentionCount(schedule vzapi.BackupSchedule) (int32, error) {
+	sched, err := cron.ParseStandard(schedule.Schedule)
+	if err != nil {
+		return 0, err
+	}
+	end := retentionReference.Add(getRetention(schedule))
+	var count int32
+	for next := sched.Next(retentionReference.Add(-time.Second)); next.Before(end); next = sched.Next(next) {
+		count++
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count, nil
+}
+
+// deleteRemovedObjects deletes the labeled objects of a kind that were created for stores that are no longer backed
+// up, nothing is done if the kind is not installed
+func deleteRemovedObjects(ctx spi.ComponentContext, spec *vzapi.BackupSpec, gvk schema.GroupVersionKind, opts ...client.ListOption) error {
+	objects := &unstructured.UnstructuredList{}
+	objects.SetGroupVersionKind(gvk)
+	opts = append(opts, client.HasLabels{scheduleLabel})
+	if err := ctx.Client().List(context.TODO(), objects, opts...); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return ctx.Log().ErrorfNewErr("Failed listing %s objects: %v", gvk.Kind, err)
+	}
+	wanted := map[string]bool{}
+	if spec != nil {
+		for _, schedule := range spec.Schedules {
+			for _, store := range getStores(ctx.EffectiveCR(), schedule) {
+				if isStoreEnabled(ctx.EffectiveCR(), store) {
+					wanted[getObjectName(schedule, store)] = true
+				}
+			}
+		}
+	}
+	for i := range objects.Items {
+		object := &objects.Items[i]
+		if wanted[object.GetName()] {
+			continue
+		}
+		ctx.Log().Infof("Deleting %s %s of a removed backup schedule", gvk.Kind, object.GetName())
+		if err := ctx.Client().Delete(context.TODO(), object); client.IgnoreNotFound(err) != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting %s %s: %v", gvk.Kind, object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// getObjectName returns the name of the object created for a store of the backup schedule
+func getObjectName(schedule vzapi.BackupSchedule, store vzapi.BackupStore) string {
+	return fmt.Sprintf("%s-%s", schedule.Name, getStoreName(store))
+}
+
+func setLabels(object client.Object, schedule vzapi.BackupSchedule, store vzapi.BackupStore) {
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[scheduleLabel] = schedule.Name
+	labels[storeLabel] = getStoreName(store)
+	object.SetLabels(labels)
+}
+
+// getCredentials returns the access key and the secret key of the backup storage
+func getCredentials(ctx spi.ComponentContext, storage vzapi.BackupStorage) ([]byte, []byte, error) {
+	secret := &corev1.Secret{}
+	nsn := types.NamespacedName{Namespace: ctx.EffectiveCR().Namespace, Name: storage.CredentialsSecret}
+	if err := ctx.Client().Get(context.TODO(), nsn, secret); err != nil {
+		return nil, nil, ctx.Log().ErrorfNewErr("Failed getting the backup credentials secret %s/%s: %v", nsn.Namespace, nsn.Name, err)
+	}
+	accessKey := secret.Data[constants.ObjectStoreAccessKey]
+	secretKey := secret.Data[constants.ObjectStoreAccessSecretKey]
+	if len(accessKey) == 0 || len(secretKey) == 0 {
+		return nil, nil, ctx.Log().ErrorfNewErr("Failed, the backup credentials secret %s/%s must contain the keys %s and %s",
+			nsn.Namespace, nsn.Name, constants.ObjectStoreAccessKey, constants.ObjectStoreAccessSecretKey)
+	}
+	return accessKey, secretKey, nil
+}
+
+func getRegion(storage vzapi.BackupStorage) string {
+	if storage.Region == "" {
+		return defaultRegion
+	}
+	return storage.Region
+}
+
+// getEndpointHost returns the host and port of the storage endpoint, for the clients that take the scheme separately
+func getEndpointHost(storage vzapi.BackupStorage) string {
+	endpoint, err := url.Parse(storage.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return storage.Endpoint
+	}
+	return endpoint.Host
+}
+
+// storePath returns the path in the bucket under which the backups of a store are kept
+func storePath(storage vzapi.BackupStorage, store string) string {
+	return strings.TrimPrefix(path.Join(storage.Prefix, store), "/")
+}
+
+// getStoreName returns the lower case name of a store, used in the names of the objects created for it
+func getStoreName(store vzapi.BackupStore) string {
+	switch store {
+	case vzapi.BackupStoreKeycloakMySQL:
+		return "keycloak-mysql"
+	case vzapi.BackupStorePlatformNamespaces:
+		return "platform-namespaces"
+	}
+	return strings.ToLower(string(store))
+}
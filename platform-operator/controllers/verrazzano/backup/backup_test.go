@@ -0,0 +1,350 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	for _, gvk := range []schema.GroupVersionKind{veleroScheduleGVK, veleroBackupGVK, veleroStorageLocationGVK, rancherBackupGVK} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	return scheme
+}
+
+func newBackupVZ(stores ...vzapi.BackupStore) *vzapi.Verrazzano {
+	enabled := true
+	retentionDays := int32(7)
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Velero:        &vzapi.VeleroComponent{Enabled: &enabled},
+				RancherBackup: &vzapi.RancherBackupComponent{Enabled: &enabled},
+			},
+			Backup: &vzapi.BackupSpec{
+				Storage: vzapi.BackupStorage{
+					Endpoint:          "http://minio.minio:9000",
+					Bucket:            "verrazzano",
+					Prefix:            "dev",
+					PathStyleAccess:   true,
+					CredentialsSecret: "backup-credentials",
+				},
+				Schedules: []vzapi.BackupSchedule{{
+					Name:          "daily",
+					Schedule:      "0 1 * * *",
+					RetentionDays: &retentionDays,
+					Stores:        stores,
+				}},
+			},
+		},
+	}
+}
+
+func newCredentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup-credentials"},
+		Data: map[string][]byte{
+			constants.ObjectStoreAccessKey:       []byte("access"),
+			constants.ObjectStoreAccessSecretKey: []byte("secret"),
+		},
+	}
+}
+
+func newVeleroBackup(name string, schedule string, created time.Time, phase string) *unstructured.Unstructured {
+	backup := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":               phase,
+			"startTimestamp":      created.Format(time.RFC3339),
+			"completionTimestamp": created.Add(time.Minute).Format(time.RFC3339),
+		},
+	}}
+	backup.SetGroupVersionKind(veleroBackupGVK)
+	backup.SetNamespace(constants.VeleroNameSpace)
+	backup.SetName(name)
+	backup.SetLabels(map[string]string{veleroScheduleNameLabel: schedule})
+	backup.SetCreationTimestamp(metav1.NewTime(created))
+	return backup
+}
+
+// TestReconcileBackups tests the ReconcileBackups function
+// GIVEN a Verrazzano resource with a backup schedule of all the stores
+// WHEN ReconcileBackups is called
+// THEN the Velero schedules, storage location and credentials and the rancher-backup backup are created, and the
+// status of the last backup of each store is returned
+func TestReconcileBackups(t *testing.T) {
+	asserts := assert.New(t)
+	vz := newBackupVZ()
+	vz.Status.OpenSearch = &vzapi.OpenSearchStatus{Snapshots: []vzapi.OpenSearchSnapshotStatus{{
+		Schedule:  "platform-backup-daily",
+		Name:      "platform-backup-daily-20261019-010000",
+		State:     "SUCCESS",
+		StartTime: "2026-10-19T01:00:00Z",
+		EndTime:   "2026-10-19T01:00:40Z",
+	}}}
+	now := time.Date(2026, time.October, 19, 1, 0, 0, 0, time.UTC)
+	partialBackup := newVeleroBackup("daily-platform-namespaces-20261019010000", "daily-platform-namespaces", now, "PartiallyFailed")
+	asserts.NoError(unstructured.SetNestedField(partialBackup.Object, int64(2), "status", "errors"))
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		newCredentialsSecret(),
+		newVeleroBackup("daily-keycloak-mysql-20261018010000", "daily-keycloak-mysql", now.Add(-24*time.Hour), "Failed"),
+		newVeleroBackup("daily-keycloak-mysql-20261019010000", "daily-keycloak-mysql", now, "Completed"),
+		partialBackup,
+	).Build()
+
+	status, requeueAfter, err := ReconcileBackups(spi.NewFakeContext(c, vz, nil, false))
+	asserts.NoError(err)
+	asserts.Equal(statusRefreshInterval, requeueAfter)
+	asserts.Equal([]vzapi.BackupStoreStatus{
+		{
+			Schedule:       "daily",
+			Store:          vzapi.BackupStoreKeycloakMySQL,
+			Name:           "daily-keycloak-mysql-20261019010000",
+			State:          StateCompleted,
+			StartTime:      "2026-10-19T01:00:00Z",
+			CompletionTime: "2026-10-19T01:01:00Z",
+		},
+		{
+			Schedule:       "daily",
+			Store:          vzapi.BackupStoreOpenSearch,
+			Name:           "platform-backup-daily-20261019-010000",
+			State:          StateCompleted,
+			StartTime:      "2026-10-19T01:00:00Z",
+			CompletionTime: "2026-10-19T01:00:40Z",
+		},
+		{
+			Schedule: "daily",
+			Store:    vzapi.BackupStoreRancher,
+		},
+		{
+			Schedule:       "daily",
+			Store:          vzapi.BackupStorePlatformNamespaces,
+			Name:           "daily-platform-namespaces-20261019010000",
+			State:          StatePartiallyFailed,
+			StartTime:      "2026-10-19T01:00:00Z",
+			CompletionTime: "2026-10-19T01:01:00Z",
+			Message:        "The backup completed with 2 errors",
+		},
+	}, status.Stores)
+
+	// The Velero credentials are in the AWS credentials file format
+	secret := &corev1.Secret{}
+	asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: constants.VeleroNameSpace, Name: credentialsSecretName}, secret))
+	asserts.Equal("[default]\naws_access_key_id=access\naws_secret_access_key=secret\n", string(secret.Data[veleroCredentialsKey]))
+	asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: constants.RancherBackupNamesSpace, Name: credentialsSecretName}, secret))
+	asserts.Equal("access", string(secret.Data[rancherAccessKey]))
+	asserts.Equal("secret", string(secret.Data[rancherSecretKey]))
+
+	location := newObject(veleroStorageLocationGVK)
+	asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: constants.VeleroNameSpace, Name: storageLocationName}, location))
+	prefix, _, _ := unstructured.NestedString(location.Object, "spec", "objectStorage", "prefix")
+	asserts.Equal("dev/velero", prefix)
+	pathStyle, _, _ := unstructured.NestedString(location.Object, "spec", "config", "s3ForcePathStyle")
+	asserts.Equal("true", pathStyle)
+
+	schedule := newObject(veleroScheduleGVK)
+	asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: constants.VeleroNameSpace, Name: "daily-keycloak-mysql"}, schedule))
+	asserts.Equal("daily", schedule.GetLabels()[scheduleLabel])
+	ttl, _, _ := unstructured.NestedString(schedule.Object, "spec", "template", "ttl")
+	asserts.Equal("168h0m0s", ttl)
+	hooks, _, _ := unstructured.NestedSlice(schedule.Object, "spec", "template", "hooks", "resources")
+	asserts.Len(hooks, 1)
+	asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: constants.VeleroNameSpace, Name: "daily-platform-namespaces"}, schedule))
+	namespaces, _, _ := unstructured.NestedStringSlice(schedule.Object, "spec", "template", "includedNamespaces")
+	asserts.Contains(namespaces, constants.VerrazzanoInstallNamespace)
+
+	rancherBackup := newObject(rancherBackupGVK)
+	asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Name: "daily-rancher"}, rancherBackup))
+	retentionCount, _, _ := unstructured.NestedInt64(rancherBackup.Object, "spec", "retentionCount")
+	asserts.Equal(int64(7), retentionCount)
+	endpoint, _, _ := unstructured.NestedString(rancherBackup.Object, "spec", "storageLocation", "s3", "endpoint")
+	asserts.Equal("minio.minio:9000", endpoint)
+}
+
+// TestReconcileBackupsRemovedSchedule tests the ReconcileBackups function
+// GIVEN a Verrazzano resource whose backup schedule no longer backs up some stores
+// WHEN ReconcileBackups is called
+// THEN the Velero schedules and rancher-backup backups of those stores are deleted
+func TestReconcileBackupsRemovedSchedule(t *testing.T) {
+	asserts := assert.New(t)
+	vz := newBackupVZ(vzapi.BackupStoreKeycloakMySQL)
+	staleSchedule := newObject(veleroScheduleGVK)
+	staleSchedule.SetNamespace(constants.VeleroNameSpace)
+	staleSchedule.SetName("daily-platform-namespaces")
+	staleSchedule.SetLabels(map[string]string{scheduleLabel: "daily"})
+	staleBackup := newObject(rancherBackupGVK)
+	staleBackup.SetName("weekly-rancher")
+	staleBackup.SetLabels(map[string]string{scheduleLabel: "weekly"})
+	userBackup := newObject(rancherBackupGVK)
+	userBackup.SetName("manual")
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newCredentialsSecret(), staleSchedule, staleBackup, userBackup).Build()
+
+	status, _, err := ReconcileBackups(spi.NewFakeContext(c, vz, nil, false))
+	asserts.NoError(err)
+	asserts.Equal([]vzapi.BackupStoreStatus{{Schedule: "daily", Store: vzapi.BackupStoreKeycloakMySQL}}, status.Stores)
+
+	schedules := &unstructured.UnstructuredList{}
+	schedules.SetGroupVersionKind(veleroScheduleGVK)
+	asserts.NoError(c.List(context.TODO(), schedules, client.InNamespace(constants.VeleroNameSpace)))
+	asserts.Len(schedules.Items, 1)
+	asserts.Equal("daily-keycloak-mysql", schedules.Items[0].GetName())
+	backups := &unstructured.UnstructuredList{}
+	backups.SetGroupVersionKind(rancherBackupGVK)
+	asserts.NoError(c.List(context.TODO(), backups))
+	asserts.Len(backups.Items, 1)
+	asserts.Equal("manual", backups.Items[0].GetName())
+
+	// Once the backups are removed from the spec, nothing is left and no status is returned
+	vz.Spec.Backup = nil
+	status, requeueAfter, err := ReconcileBackups(spi.NewFakeContext(c, vz, nil, false))
+	asserts.NoError(err)
+	asserts.Nil(status)
+	asserts.Zero(requeueAfter)
+	asserts.NoError(c.List(context.TODO(), schedules, client.InNamespace(constants.VeleroNameSpace)))
+	asserts.Empty(schedules.Items)
+}
+
+// TestReconcileBackupsMissingCredentials tests the ReconcileBackups function
+// GIVEN a Verrazzano resource with a backup schedule
+// WHEN ReconcileBackups is called and the credentials secret does not exist
+// THEN an error is returned
+func TestReconcileBackupsMissingCredentials(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	_, _, err := ReconcileBackups(spi.NewFakeContext(c, newBackupVZ(vzapi.BackupStorePlatformNamespaces), nil, false))
+	assert.Error(t, err)
+}
+
+// TestReconcileBackupsDisabledStore tests the ReconcileBackups function
+// GIVEN a Verrazzano resource with a backup schedule of a store whose components are disabled
+// WHEN ReconcileBackups is called
+// THEN the store is reported as failed
+func TestReconcileBackupsDisabledStore(t *testing.T) {
+	asserts := assert.New(t)
+	disabled := false
+	vz := newBackupVZ(vzapi.BackupStoreOpenSearch)
+	vz.Spec.Components.Elasticsearch = &vzapi.ElasticsearchComponent{Enabled: &disabled}
+	c := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+
+	status, _, err := ReconcileBackups(spi.NewFakeContext(c, vz, nil, false))
+	asserts.NoError(err)
+	asserts.Len(status.Stores, 1)
+	asserts.Equal(StateFailed, status.Stores[0].State)
+	asserts.Nil(OpenSearchSnapshots(vz))
+}
+
+// TestOpenSearchSnapshots tests the OpenSearchSnapshots function
+// GIVEN a Verrazzano resource with backup schedules
+// WHEN OpenSearchSnapshots is called
+// THEN a snapshot repository in the backup storage and a snapshot schedule for each schedule backing up OpenSearch
+// are returned
+func TestOpenSearchSnapshots(t *testing.T) {
+	asserts := assert.New(t)
+	vz := newBackupVZ()
+	vz.Spec.Backup.Schedules = append(vz.Spec.Backup.Schedules,
+		vzapi.BackupSchedule{Name: "hourly", Schedule: "0 * * * *", Stores: []vzapi.BackupStore{vzapi.BackupStoreRancher}},
+		vzapi.BackupSchedule{Name: "weekly", Schedule: "0 2 * * SUN", Stores: []vzapi.BackupStore{vzapi.BackupStoreOpenSearch}})
+
+	snapshots := OpenSearchSnapshots(vz)
+	asserts.NotNil(snapshots)
+	asserts.Equal("backup-credentials", snapshots.S3CredentialsSecret)
+	asserts.Len(snapshots.Repositories, 1)
+	asserts.Equal(storageLocationName, snapshots.Repositories[0].Name)
+	asserts.Equal(vzapi.OpenSearchS3Repository{
+		Bucket:          "verrazzano",
+		BasePath:        "dev/opensearch",
+		Endpoint:        "minio.minio:9000",
+		Region:          defaultRegion,
+		PathStyleAccess: true,
+	}, *snapshots.Repositories[0].S3)
+	asserts.Len(snapshots.Schedules, 2)
+	asserts.Equal("platform-backup-daily", snapshots.Schedules[0].Name)
+	asserts.Equal(int32(7), *snapshots.Schedules[0].MaxCount)
+	asserts.Equal("platform-backup-weekly", snapshots.Schedules[1].Name)
+	asserts.Equal(int32(5), *snapshots.Schedules[1].MaxCount)
+
+	vz.Spec.Backup = nil
+	asserts.Nil(OpenSearchSnapshots(vz))
+}
+
+// TestRetentionCount tests the retentionCount function
+// GIVEN a backup schedule
+// WHEN retentionCount is called
+// THEN the number of backups taken during the retention period is returned, at least one
+func TestRetentionCount(t *testing.T) {
+	days := func(d int32) *int32 { return &d }
+	tests := []struct {
+		schedule vzapi.BackupSchedule
+		count    int32
+	}{
+		{vzapi.BackupSchedule{Schedule: "0 1 * * *"}, defaultRetentionDays},
+		{vzapi.BackupSchedule{Schedule: "0 */6 * * *", RetentionDays: days(2)}, 8},
+		{vzapi.BackupSchedule{Schedule: "0 0 1 * *", RetentionDays: days(7)}, 1},
+	}
+	for _, tt := range tests {
+		count, err := retentionCount(tt.schedule)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.count, count, tt.schedule.Schedule)
+	}
+	_, err := retentionCount(vzapi.BackupSchedule{Schedule: "daily"})
+	assert.Error(t, err)
+}
+
+// TestGetRancherBackupStatus tests the getRancherBackupStatus function
+// GIVEN a recurring rancher-backup backup
+// WHEN getRancherBackupStatus is called
+// THEN the store status describes its last backup
+func TestGetRancherBackupStatus(t *testing.T) {
+	asserts := assert.New(t)
+	newBackup := func(ready string, message string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"filename":       "daily-rancher-1f0d-2026-10-19T01-00-00Z.tar.gz",
+				"lastSnapshotTs": "2026-10-19T01:00:00Z",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": ready, "message": message},
+				},
+			},
+		}}
+	}
+
+	status := vzapi.BackupStoreStatus{}
+	getRancherBackupStatus(newBackup("True", "Completed"), &status)
+	asserts.Equal(vzapi.BackupStoreStatus{
+		Name:           "daily-rancher-1f0d-2026-10-19T01-00-00Z.tar.gz",
+		State:          StateCompleted,
+		StartTime:      "2026-10-19T01:00:00Z",
+		CompletionTime: "2026-10-19T01:00:00Z",
+	}, status)
+
+	status = vzapi.BackupStoreStatus{}
+	getRancherBackupStatus(newBackup("False", "bucket verrazzano not found"), &status)
+	asserts.Equal(StateFailed, status.State)
+	asserts.Equal("bucket verrazzano not found", status.Message)
+}
+
+func newObject(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	return object
+}
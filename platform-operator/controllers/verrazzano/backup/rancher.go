@@ -0,0 +1,122 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backup
+
+import (
+	"context"
+
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// rancherResourceSetName is the resource set installed by rancher-backup that selects the Rancher resources
+	rancherResourceSetName = "rancher-resource-set"
+
+	// Keys of the credentials secret of the rancher-backup S3 storage location
+	rancherAccessKey = "accessKey"
+	rancherSecretKey = "secretKey"
+)
+
+var rancherBackupGVK = schema.GroupVersionKind{Group: "resources.cattle.io", Version: "v1", Kind: "Backup"}
+
+// createOrUpdateRancherCredentials copies the storage credentials into the secret used by the rancher-backup backups
+func createOrUpdateRancherCredentials(ctx spi.ComponentContext, storage vzapi.BackupStorage) error {
+	accessKey, secretKey, err := getCredentials(ctx, storage)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentialsSecretName,
+			Namespace: constants.RancherBackupNamesSpace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), secret, func() error {
+		secret.Data = map[string][]byte{
+			rancherAccessKey: accessKey,
+			rancherSecretKey: secretKey,
+		}
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating secret %s/%s: %v", constants.RancherBackupNamesSpace, credentialsSecretName, err)
+	}
+	return nil
+}
+
+// reconcileRancherBackup creates or updates the recurring rancher-backup backup of the backup schedule, and fills in
+// the store status from its last backup.  rancher-backup keeps a number of backups, enough to cover the retention
+// period of the schedule.
+func reconcileRancherBackup(ctx spi.ComponentContext, spec *vzapi.BackupSpec, schedule vzapi.BackupSchedule, status *vzapi.BackupStoreStatus) error {
+	count, err := retentionCount(schedule)
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed parsing the schedule of backup schedule %s: %v", schedule.Name, err)
+	}
+	backup := &unstructured.Unstructured{}
+	backup.SetGroupVersionKind(rancherBackupGVK)
+	backup.SetName(getObjectName(schedule, vzapi.BackupStoreRancher))
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), backup, func() error {
+		setLabels(backup, schedule, vzapi.BackupStoreRancher)
+		storage := spec.Storage
+		backupSpec := map[string]interface{}{
+			"resourceSetName": rancherResourceSetName,
+			"schedule":        schedule.Schedule,
+			"retentionCount":  int64(count),
+			"storageLocation": map[string]interface{}{
+				"s3": map[string]interface{}{
+					"credentialSecretName":      credentialsSecretName,
+					"credentialSecretNamespace": constants.RancherBackupNamesSpace,
+					"bucketName":                storage.Bucket,
+					"folder":                    storePath(storage, "rancher"),
+					"region":                    getRegion(storage),
+					"endpoint":                  getEndpointHost(storage),
+				},
+			},
+		}
+		return unstructured.SetNestedMap(backup.Object, backupSpec, "spec")
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating the rancher-backup backup %s: %v", backup.GetName(), err)
+	}
+	getRancherBackupStatus(backup, status)
+	return nil
+}
+
+// getRancherBackupStatus fills in the store status from the status of the recurring rancher-backup backup, which
+// describes its last backup
+func getRancherBackupStatus(backup *unstructured.Unstructured, status *vzapi.BackupStoreStatus) {
+	status.Name, _, _ = unstructured.NestedString(backup.Object, "status", "filename")
+	lastTime, _, _ := unstructured.NestedString(backup.Object, "status", "lastSnapshotTs")
+	conditions, _, _ := unstructured.NestedSlice(backup.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		switch condition["status"] {
+		case "False":
+			status.State = StateFailed
+			status.Message, _ = condition["message"].(string)
+		case "True":
+			if lastTime != "" {
+				status.State = StateCompleted
+				status.CompletionTime = lastTime
+			}
+		default:
+			status.State = StateInProgress
+		}
+	}
+	status.StartTime = lastTime
+}
+
+// deleteRemovedRancherBackups deletes the recurring rancher-backup backups of the schedules that no longer back up
+// Rancher, the backup files are kept in the storage
+func deleteRemovedRancherBackups(ctx spi.ComponentContext, spec *vzapi.BackupSpec) error {
+	return deleteRemovedObjects(ctx, spec, rancherBackupGVK)
+}
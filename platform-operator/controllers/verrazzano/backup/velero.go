@@ -0,0 +1,206 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysql"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// veleroCredentialsKey is the key of the AWS credentials file in the Velero credentials secret
+	veleroCredentialsKey = "cloud"
+	// veleroScheduleNameLabel is the label Velero sets on the backups created by a schedule
+	veleroScheduleNameLabel = "velero.io/schedule-name"
+
+	// mysqlDumpFile is written by the pre-backup hook of the Keycloak MySQL pod, on the data volume that Velero backs
+	// up with restic; restoring the dump is consistent, unlike the data files copied while MySQL is running
+	mysqlDumpFile = "/var/lib/mysql/verrazzano-backup.sql"
+)
+
+var (
+	veleroScheduleGVK        = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Schedule"}
+	veleroBackupGVK          = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Backup"}
+	veleroStorageLocationGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "BackupStorageLocation"}
+
+	// platformNamespaces are the Verrazzano system namespaces backed up by the PlatformNamespaces store, Keycloak and
+	// Rancher are backed up by their own stores
+	platformNamespaces = []interface{}{
+		constants.VerrazzanoInstallNamespace,
+		vzconst.VerrazzanoSystemNamespace,
+		vzconst.VerrazzanoMultiClusterNamespace,
+		constants.VerrazzanoMonitoringNamespace,
+		vzconst.CertManagerNamespace,
+		vzconst.IstioSystemNamespace,
+		constants.IngressNginxNamespace,
+	}
+)
+
+// createOrUpdateVeleroStorage creates or updates the Velero backup storage location of the scheduled backups and its
+// credentials, in the AWS credentials file format used by the Velero AWS plugin
+func createOrUpdateVeleroStorage(ctx spi.ComponentContext, storage vzapi.BackupStorage) error {
+	accessKey, secretKey, err := getCredentials(ctx, storage)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentialsSecretName,
+			Namespace: constants.VeleroNameSpace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), secret, func() error {
+		secret.Data = map[string][]byte{
+			veleroCredentialsKey: []byte(fmt.Sprintf("[default]\naws_access_key_id=%s\naws_secret_access_key=%s\n", accessKey, secretKey)),
+		}
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating secret %s/%s: %v", constants.VeleroNameSpace, credentialsSecretName, err)
+	}
+
+	location := &unstructured.Unstructured{}
+	location.SetGroupVersionKind(veleroStorageLocationGVK)
+	location.SetName(storageLocationName)
+	location.SetNamespace(constants.VeleroNameSpace)
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), location, func() error {
+		spec := map[string]interface{}{
+			"provider": "aws",
+			"objectStorage": map[string]interface{}{
+				"bucket": storage.Bucket,
+				"prefix": storePath(storage, "velero"),
+			},
+			"config": map[string]interface{}{
+				"region":           getRegion(storage),
+				"s3Url":            storage.Endpoint,
+				"s3ForcePathStyle": fmt.Sprintf("%t", storage.PathStyleAccess),
+			},
+			"credential": map[string]interface{}{
+				"name": credentialsSecretName,
+				"key":  veleroCredentialsKey,
+			},
+		}
+		return unstructured.SetNestedMap(location.Object, spec, "spec")
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating the Velero backup storage location %s: %v", storageLocationName, err)
+	}
+	return nil
+}
+
+// reconcileVeleroSchedule creates or updates the Velero schedule of a store of the backup schedule, and fills in the
+// store status from its last backup
+func reconcileVeleroSchedule(ctx spi.ComponentContext, schedule vzapi.BackupSchedule, store vzapi.BackupStore, status *vzapi.BackupStoreStatus) error {
+	veleroSchedule := &unstructured.Unstructured{}
+	veleroSchedule.SetGroupVersionKind(veleroScheduleGVK)
+	veleroSchedule.SetName(getObjectName(schedule, store))
+	veleroSchedule.SetNamespace(constants.VeleroNameSpace)
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), veleroSchedule, func() error {
+		setLabels(veleroSchedule, schedule, store)
+		return unstructured.SetNestedMap(veleroSchedule.Object, newVeleroScheduleSpec(schedule, store), "spec")
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating the Velero schedule %s: %v", veleroSchedule.GetName(), err)
+	}
+	return getVeleroBackupStatus(ctx, veleroSchedule.GetName(), status)
+}
+
+func newVeleroScheduleSpec(schedule vzapi.BackupSchedule, store vzapi.BackupStore) map[string]interface{} {
+	template := map[string]interface{}{
+		"storageLocation": storageLocationName,
+		"ttl":             getRetention(schedule).String(),
+	}
+	if store == vzapi.BackupStorePlatformNamespaces {
+		template["includedNamespaces"] = platformNamespaces
+		template["snapshotVolumes"] = false
+	} else {
+		template["includedNamespaces"] = []interface{}{mysql.ComponentNamespace}
+		template["defaultVolumesToRestic"] = true
+		template["hooks"] = map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{
+					"name":               "keycloak-mysql-dump",
+					"includedNamespaces": []interface{}{mysql.ComponentNamespace},
+					"labelSelector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"app": mysql.ComponentName},
+					},
+					"pre": []interface{}{
+						map[string]interface{}{
+							"exec": map[string]interface{}{
+								"container": mysql.ComponentName,
+								"command": []interface{}{"bash", "-c",
+									fmt.Sprintf(`mysqldump --all-databases --single-transaction --routines --events -u root -p"${MYSQL_ROOT_PASSWORD}" > %s`, mysqlDumpFile)},
+								"onError": "Fail",
+								"timeout": "10m",
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"schedule": schedule.Schedule,
+		"template": template,
+	}
+}
+
+// getVeleroBackupStatus fills in the store status from the last backup of the Velero schedule
+func getVeleroBackupStatus(ctx spi.ComponentContext, scheduleName string, status *vzapi.BackupStoreStatus) error {
+	backups := &unstructured.UnstructuredList{}
+	backups.SetGroupVersionKind(veleroBackupGVK)
+	if err := ctx.Client().List(context.TODO(), backups, client.InNamespace(constants.VeleroNameSpace),
+		client.MatchingLabels{veleroScheduleNameLabel: scheduleName}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing the backups of Velero schedule %s: %v", scheduleName, err)
+	}
+	var last *unstructured.Unstructured
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if last == nil || backup.GetCreationTimestamp().After(last.GetCreationTimestamp().Time) {
+			last = backup
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	status.Name = last.GetName()
+	status.StartTime, _, _ = unstructured.NestedString(last.Object, "status", "startTimestamp")
+	status.CompletionTime, _, _ = unstructured.NestedString(last.Object, "status", "completionTimestamp")
+	phase, _, _ := unstructured.NestedString(last.Object, "status", "phase")
+	switch phase {
+	case "Completed":
+		status.State = StateCompleted
+	case "PartiallyFailed":
+		status.State = StatePartiallyFailed
+		errorCount, _, _ := unstructured.NestedInt64(last.Object, "status", "errors")
+		status.Message = fmt.Sprintf("The backup completed with %d errors", errorCount)
+	case "Failed":
+		status.State = StateFailed
+		status.Message, _, _ = unstructured.NestedString(last.Object, "status", "failureReason")
+	case "FailedValidation":
+		status.State = StateFailed
+		validationErrors, _, _ := unstructured.NestedStringSlice(last.Object, "status", "validationErrors")
+		status.Message = strings.Join(validationErrors, ", ")
+	default:
+		status.State = StateInProgress
+	}
+	return nil
+}
+
+// deleteRemovedVeleroSchedules deletes the Velero schedules of the stores that are no longer backed up, their backups
+// are kept until they expire
+func deleteRemovedVeleroSchedules(ctx spi.ComponentContext, spec *vzapi.BackupSpec) error {
+	return deleteRemovedObjects(ctx, spec, veleroScheduleGVK, client.InNamespace(constants.VeleroNameSpace))
+}
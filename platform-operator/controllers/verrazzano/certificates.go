@@ -0,0 +1,43 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
+)
+
+// reconcileCertificatesFunc tracks the expiry of the certificates and rotates the Verrazzano CA, can be overridden
+// for unit testing
+var reconcileCertificatesFunc = certmanager.ReconcileCertificates
+
+// reconcileCertificates records the expiry of the Verrazzano certificates and the progress of the rotation of the
+// Verrazzano CA in the Verrazzano status, and exports the expiry as a metric.  It returns how long to wait before
+// refreshing the status.
+func (r *Reconciler) reconcileCertificates(vzctx vzcontext.VerrazzanoContext) (time.Duration, error) {
+	actualCR := vzctx.ActualCR
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, actualCR, nil, r.DryRun)
+	if err != nil {
+		return 0, err
+	}
+	status, requeueAfter, err := reconcileCertificatesFunc(spiCtx)
+	if err != nil {
+		return 0, err
+	}
+	if reflect.DeepEqual(status, actualCR.Status.Certificates) {
+		metricsexporter.AnalyzeCertificateMetrics(vzctx.Log, *actualCR)
+		return requeueAfter, nil
+	}
+	actualCR.Status.Certificates = status
+	if err := r.updateVerrazzanoStatus(vzctx.Log, actualCR); err != nil {
+		return 0, err
+	}
+	metricsexporter.AnalyzeCertificateMetrics(vzctx.Log, *actualCR)
+	return requeueAfter, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+var testCertificatesStatus = &vzapi.CertificatesStatus{
+	Expiry: []vzapi.CertificateExpiryStatus{
+		{Namespace: "cert-manager", SecretName: "verrazzano-ca-certificate-secret", CA: true, NotAfter: "2027-01-17T09:14:30Z"},
+		{Namespace: "verrazzano-system", SecretName: "verrazzano-tls", NotAfter: "2026-12-18T09:14:30Z"},
+	},
+	CARotation: &vzapi.CARotationStatus{
+		Trigger:    "2026-10-19",
+		Reason:     "Requested",
+		State:      "Distributing",
+		PreviousCA: "verrazzano-root-ca-abcdefgh",
+		CurrentCA:  "verrazzano-root-ca-ijklmnop",
+		StartTime:  "2026-10-19T09:14:00Z",
+		StateTime:  "2026-10-19T09:14:30Z",
+	},
+}
+
+// TestReconcileCertificates tests the reconcileCertificates function
+// GIVEN a Verrazzano resource with a rotation of the Verrazzano CA in progress
+// WHEN reconcileCertificates is called
+// THEN the expiry of the certificates and the progress of the rotation are recorded in the Verrazzano status
+func TestReconcileCertificates(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileCertificatesFunc = func(_ spi.ComponentContext) (*vzapi.CertificatesStatus, time.Duration, error) {
+		return testCertificatesStatus, 5 * time.Minute, nil
+	}
+	defer func() { reconcileCertificatesFunc = certmanager.ReconcileCertificates }()
+
+	vz := newMaintenanceTestVZ(nil)
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcileCertificates(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Equal(5*time.Minute, requeueAfter)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Equal(testCertificatesStatus, updated.Status.Certificates)
+}
+
+// TestReconcileCertificatesError tests the reconcileCertificates function
+// GIVEN a Verrazzano resource
+// WHEN reconcileCertificates is called and the certificates fail to be reconciled
+// THEN an error is returned and the Verrazzano status is unchanged
+func TestReconcileCertificatesError(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileCertificatesFunc = func(_ spi.ComponentContext) (*vzapi.CertificatesStatus, time.Duration, error) {
+		return nil, 0, fmt.Errorf("failed to list the certificates")
+	}
+	defer func() { reconcileCertificatesFunc = certmanager.ReconcileCertificates }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Certificates = testCertificatesStatus
+	r := newMaintenanceTestReconciler(vz)
+
+	_, err := r.reconcileCertificates(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.Error(err)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Equal(testCertificatesStatus, updated.Status.Certificates)
+}
@@ -211,3 +211,18 @@ func GetOverrides(object runtime.Object) interface{} {
 
 	return []vzapi.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.ApplicationOperator != nil {
+			return effectiveCR.Spec.Components.ApplicationOperator.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*v1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.ApplicationOperator != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.ApplicationOperator.Workload)
+		}
+	}
+	return nil
+}
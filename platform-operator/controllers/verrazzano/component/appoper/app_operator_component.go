@@ -38,6 +38,16 @@ type applicationOperatorComponent struct {
 	helm.HelmComponent
 }
 
+// networkPolicyRules are the network peers of the operator outside of the verrazzano-system namespace, the operator
+// serves its webhooks and its multicluster agent connects to the admin cluster
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app": ComponentName},
+		Ingress:   []spi.NetworkPeer{{Ports: []int32{9443}}},
+		Egress:    []spi.NetworkPeer{{}},
+	},
+}
+
 func NewComponent() spi.Component {
 	return applicationOperatorComponent{
 		helm.HelmComponent{
@@ -45,6 +55,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetHelmChartsDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -52,6 +63,13 @@ func NewComponent() spi.Component {
 			ImagePullSecretKeyname:    "global.imagePullSecrets[0]",
 			Dependencies:              []string{oam.ComponentName, istio.ComponentName},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
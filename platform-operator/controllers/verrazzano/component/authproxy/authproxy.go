@@ -69,6 +69,8 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 		EnvName:                   vzconfig.GetEnvName(effectiveCR),
 		PrometheusOperatorEnabled: vzconfig.IsPrometheusOperatorEnabled(effectiveCR),
 		IngressClassName:          vzconfig.GetIngressClassName(effectiveCR),
+		ClusterIssuer:             vzconfig.GetClusterIssuerName(effectiveCR),
+		IngressHSTS:               vzconfig.IsIngressHSTSConfigured(effectiveCR),
 	}
 
 	// DNS Suffix
@@ -81,6 +83,7 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 	overrides.Proxy = &proxyValues{
 		OidcProviderHost:          fmt.Sprintf("keycloak.%s.%s", overrides.Config.EnvName, dnsSuffix),
 		OidcProviderHostInCluster: keycloakInClusterURL,
+		ExternalOidc:              buildExternalOIDCValues(ctx),
 	}
 
 	// Image name and version
@@ -131,7 +134,10 @@ func GetHelmManagedResources() []common.HelmManagedResource {
 // ensuring the resource policy of "keep" is removed (if it remains then helm is unable to delete these resources and
 // they will become orphaned)
 func authproxyPreHelmOps(ctx spi.ComponentContext) error {
-	return reassociateResources(ctx.Client())
+	if err := reassociateResources(ctx.Client()); err != nil {
+		return err
+	}
+	return createOrUpdateOIDCSecret(ctx)
 }
 
 //reassociateResources updates the resources to ensure they are managed by this release/component.  The resource policy
@@ -280,6 +286,34 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []v1beta1.Overrides{}
 }
 
+// GetWorkload gets the workload settings, the replicas of the v1alpha1 Kubernetes settings are used when the workload
+// settings do not set the replicas
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		authProxy := effectiveCR.Spec.Components.AuthProxy
+		if authProxy == nil {
+			return nil
+		}
+		if authProxy.Kubernetes == nil || authProxy.Kubernetes.Replicas == 0 {
+			return authProxy.Workload
+		}
+		workload := &vzapi.WorkloadSpec{}
+		if authProxy.Workload != nil {
+			workload = authProxy.Workload.DeepCopy()
+		}
+		if workload.Replicas == nil {
+			replicas := int32(authProxy.Kubernetes.Replicas)
+			workload.Replicas = &replicas
+		}
+		return workload
+	} else if effectiveCR, ok := object.(*v1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.AuthProxy != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.AuthProxy.Workload)
+		}
+	}
+	return nil
+}
+
 // getAuthproxyManagedResources returns a list of resource types and their namespaced names that are managed by the
 // Authproxy helm chart
 func getAuthproxyManagedResources() []common.HelmManagedResource {
@@ -40,6 +40,27 @@ type authProxyComponent struct {
 // Verify that AuthProxyComponent implements Component
 var _ spi.Component = authProxyComponent{}
 
+// networkPolicyRules are the network peers of the proxy outside of the verrazzano-system namespace, the proxy is reached
+// through the ingress controller and by Jaeger, and forwards the requests to Keycloak and the monitoring pods
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app": ComponentName},
+		Ingress: []spi.NetworkPeer{
+			{Namespace: nginx.ComponentNamespace, PodLabels: map[string]string{"app.kubernetes.io/instance": "ingress-controller"}, Ports: []int32{8775}},
+			{Namespace: constants.VerrazzanoMonitoringNamespace, PodLabels: map[string]string{"app": "jaeger"}, Ports: []int32{8775}},
+		},
+		Egress: []spi.NetworkPeer{
+			{Namespace: constants.KeycloakNamespace, Ports: []int32{8080}},
+			{Namespace: constants.VerrazzanoMonitoringNamespace},
+		},
+	},
+}
+
+// podDisruptionBudgets are the replicated pods of the proxy, every request to the consoles and APIs goes through it
+var podDisruptionBudgets = []spi.PodDisruptionBudget{
+	{Name: ComponentName, PodLabels: map[string]string{"app": ComponentName}},
+}
+
 // NewComponent returns a new authProxyComponent component
 func NewComponent() spi.Component {
 	return authProxyComponent{
@@ -48,6 +69,8 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetHelmChartsDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
+			PodDisruptionBudgets:      podDisruptionBudgets,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -56,6 +79,14 @@ func NewComponent() spi.Component {
 			ImagePullSecretKeyname:    "global.imagePullSecrets[0]",
 			GetInstallOverridesFunc:   GetOverrides,
 			Dependencies:              []string{nginx.ComponentName},
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				ReplicasKey:          "replicas",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 			Certificates: []types.NamespacedName{
 				{Name: constants.VerrazzanoIngressSecret, Namespace: ComponentNamespace},
 			},
@@ -74,13 +105,36 @@ func (c authProxyComponent) IsEnabled(effectiveCR runtime.Object) bool {
 	return vzconfig.IsAuthProxyEnabled(effectiveCR)
 }
 
+// ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
+func (c authProxyComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
+	vzV1Beta1 := &installv1beta1.Verrazzano{}
+	if err := vz.ConvertTo(vzV1Beta1); err != nil {
+		return err
+	}
+	return c.ValidateInstallV1Beta1(vzV1Beta1)
+}
+
+// ValidateInstallV1Beta1 checks if the specified Verrazzano CR is valid for this component to be installed
+func (c authProxyComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) error {
+	if vz.Spec.Components.AuthProxy != nil {
+		if err := validateOIDC(vz.Spec.Components.AuthProxy.OIDC); err != nil {
+			return err
+		}
+	}
+	return c.HelmComponent.ValidateInstallV1Beta1(vz)
+}
+
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
 func (c authProxyComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazzano) error {
-	// Do not allow any changes except to enable the component post-install
-	if c.IsEnabled(old) && !c.IsEnabled(new) {
-		return fmt.Errorf("Disabling component %s is not allowed", ComponentJSONName)
+	oldBeta := &installv1beta1.Verrazzano{}
+	newBeta := &installv1beta1.Verrazzano{}
+	if err := old.ConvertTo(oldBeta); err != nil {
+		return err
 	}
-	return c.HelmComponent.ValidateUpdate(old, new)
+	if err := new.ConvertTo(newBeta); err != nil {
+		return err
+	}
+	return c.ValidateUpdateV1Beta1(oldBeta, newBeta)
 }
 
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
@@ -89,6 +143,11 @@ func (c authProxyComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano
 	if c.IsEnabled(old) && !c.IsEnabled(new) {
 		return fmt.Errorf("Disabling component %s is not allowed", ComponentJSONName)
 	}
+	if new.Spec.Components.AuthProxy != nil {
+		if err := validateOIDC(new.Spec.Components.AuthProxy.OIDC); err != nil {
+			return err
+		}
+	}
 	return c.HelmComponent.ValidateUpdateV1Beta1(old, new)
 }
 
@@ -158,6 +217,12 @@ func (c authProxyComponent) PreUpgrade(ctx spi.ComponentContext) error {
 	return authproxyPreHelmOps(ctx)
 }
 
+// Reconcile copies the client secret and CA bundle of the external OIDC provider again, so that the AuthProxy picks up
+// the rotated values without an upgrade.  The Verrazzano resource is reconciled when the source Secrets change.
+func (c authProxyComponent) Reconcile(ctx spi.ComponentContext) error {
+	return createOrUpdateOIDCSecret(ctx)
+}
+
 // MonitorOverrides checks whether monitoring of install overrides is enabled or not
 func (c authProxyComponent) MonitorOverrides(ctx spi.ComponentContext) bool {
 	if ctx.EffectiveCR().Spec.Components.AuthProxy != nil {
@@ -4,12 +4,11 @@
 package authproxy
 
 import (
-	"fmt"
 	"testing"
 
 	helmcli "github.com/verrazzano/verrazzano/pkg/helm"
-	"github.com/verrazzano/verrazzano/pkg/os"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"helm.sh/helm/v3/pkg/release"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/stretchr/testify/assert"
@@ -125,6 +124,23 @@ func TestValidateUpdate(t *testing.T) {
 			new:     &vzapi.Verrazzano{},
 			wantErr: false,
 		},
+		// GIVEN a default VZ CR with auth proxy component,
+		// WHEN I call update the VZ CR to configure an external OIDC provider with an http issuer
+		// THEN the update fails with an error.
+		{
+			name: "invalid oidc",
+			old:  &vzapi.Verrazzano{},
+			new: &vzapi.Verrazzano{
+				Spec: vzapi.VerrazzanoSpec{
+					Components: vzapi.ComponentSpec{
+						AuthProxy: &vzapi.AuthProxyComponent{
+							OIDC: &vzapi.AuthProxyOIDC{IssuerURL: "http://idp.example.com", ClientID: "verrazzano"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -204,12 +220,9 @@ func TestValidateUpdateV1beta1(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart installed
 //  THEN no error is returned
 func TestUninstallHelmChartInstalled(t *testing.T) {
-	helmcli.SetCmdRunner(os.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    nil,
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction(
+		helmcli.NewFakeRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "", nil)))
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &vzapi.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
@@ -220,12 +233,8 @@ func TestUninstallHelmChartInstalled(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart not installed
 //  THEN no error is returned
 func TestUninstallHelmChartNotInstalled(t *testing.T) {
-	helmcli.SetCmdRunner(os.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    fmt.Errorf("Not installed"),
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction())
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &vzapi.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
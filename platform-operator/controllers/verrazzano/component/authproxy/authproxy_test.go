@@ -5,7 +5,6 @@ package authproxy
 
 import (
 	"context"
-	"fmt"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"io/fs"
 	"io/ioutil"
@@ -200,6 +199,30 @@ func TestAppendOverrides(t *testing.T) {
 			numKeyValues: 1,
 			expectedErr:  nil,
 		},
+		{
+			name:         "OverrideExternalOIDC",
+			description:  "Test configuring an external OIDC provider",
+			expectedYAML: "testdata/oidcOverrideValues.yaml",
+			actualCR:     "testdata/oidcOverrideVz.yaml",
+			numKeyValues: 1,
+			expectedErr:  nil,
+		},
+		{
+			name:         "OverrideClusterIssuer",
+			description:  "Test issuing the console certificate from an existing ClusterIssuer",
+			expectedYAML: "testdata/issuerRefOverrideValues.yaml",
+			actualCR:     "testdata/issuerRefOverrideVz.yaml",
+			numKeyValues: 1,
+			expectedErr:  nil,
+		},
+		{
+			name:         "OverrideIngressHSTS",
+			description:  "Test leaving the HSTS header of the console to the ingress controller",
+			expectedYAML: "testdata/ingressHSTSOverrideValues.yaml",
+			actualCR:     "testdata/ingressHSTSOverrideVz.yaml",
+			numKeyValues: 1,
+			expectedErr:  nil,
+		},
 	}
 	defer resetWriteFileFunc()
 	for _, test := range tests {
@@ -305,12 +328,8 @@ func TestRemoveResourcePolicyAnnotation(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart not installed
 //  THEN ensure that all Fluentd resources are explicity deleted
 func TestUninstallResources(t *testing.T) {
-	helmcli.SetCmdRunner(vzos.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    fmt.Errorf("Not installed"),
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction())
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "impersonate-api-user"}}
 	clusterRoleBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "impersonate-api-user"}}
@@ -447,3 +466,32 @@ func cleanTempFiles(ctx spi.ComponentContext) {
 		ctx.Log().Errorf("Failed deleting temp files: %v", err)
 	}
 }
+
+// TestGetWorkload tests the workload settings of the AuthProxy component
+// GIVEN a Verrazzano CR with AuthProxy Kubernetes replicas and optional workload settings
+// WHEN GetWorkload is called
+// THEN the Kubernetes replicas are used only when the workload settings do not set the replicas
+func TestGetWorkload(t *testing.T) {
+	replicas := int32(3)
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				AuthProxy: &vzapi.AuthProxyComponent{
+					Kubernetes: &vzapi.AuthProxyKubernetesSection{
+						CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 2},
+					},
+					Workload: &vzapi.WorkloadSpec{PriorityClassName: "verrazzano-high"},
+				},
+			},
+		},
+	}
+	workload := GetWorkload(vz)
+	assert.Equal(t, int32(2), *workload.Replicas)
+	assert.Equal(t, "verrazzano-high", workload.PriorityClassName)
+	assert.Nil(t, vz.Spec.Components.AuthProxy.Workload.Replicas)
+
+	vz.Spec.Components.AuthProxy.Workload.Replicas = &replicas
+	assert.Equal(t, int32(3), *GetWorkload(vz).Replicas)
+
+	assert.Nil(t, GetWorkload(&v1beta1.Verrazzano{}))
+}
@@ -33,6 +33,17 @@ type proxyValues struct {
 	AuthnStateTTL                string `json:"AuthnStateTTL,omitempty"`
 	MaxRequestSize               string `json:"MaxRequestSize,omitempty"`
 	ProxyBufferSize              string `json:"ProxyBufferSize,omitempty"`
+
+	ExternalOidc *externalOidcValues `json:"ExternalOidc,omitempty"`
+}
+
+type externalOidcValues struct {
+	Enabled       bool                `json:"Enabled"`
+	IssuerURL     string              `json:"IssuerURL,omitempty"`
+	ClientID      string              `json:"ClientID,omitempty"`
+	UsernameClaim string              `json:"UsernameClaim,omitempty"`
+	GroupsClaim   string              `json:"GroupsClaim,omitempty"`
+	GroupMappings map[string][]string `json:"GroupMappings,omitempty"`
 }
 
 type configValues struct {
@@ -40,6 +51,8 @@ type configValues struct {
 	DNSSuffix                 string `json:"dnsSuffix,omitempty"`
 	PrometheusOperatorEnabled bool   `json:"prometheusOperatorEnabled,omitempty"`
 	IngressClassName          string `json:"ingressClassName,omitempty"`
+	ClusterIssuer             string `json:"clusterIssuer,omitempty"`
+	IngressHSTS               bool   `json:"ingressHSTS,omitempty"`
 }
 
 type dnsValues struct {
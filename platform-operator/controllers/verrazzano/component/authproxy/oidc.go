@@ -0,0 +1,130 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package authproxy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	oidcSecretName      = "verrazzano-authproxy-oidc"
+	oidcClientSecretKey = "client-secret"
+	oidcCABundleKey     = "ca-bundle"
+)
+
+// buildExternalOIDCValues builds the proxy values for the external OIDC provider, nil is returned if Keycloak is used
+func buildExternalOIDCValues(ctx spi.ComponentContext) *externalOidcValues {
+	effectiveCR := ctx.EffectiveCR()
+	oidc := common.GetExternalOIDC(effectiveCR)
+	if oidc == nil {
+		return nil
+	}
+	values := &externalOidcValues{
+		Enabled:       true,
+		IssuerURL:     oidc.IssuerURL,
+		ClientID:      oidc.ClientID,
+		UsernameClaim: oidc.UsernameClaim,
+		GroupsClaim:   oidc.GroupsClaim,
+		GroupMappings: common.GetExternalOIDCGroupMappings(effectiveCR),
+	}
+	if values.UsernameClaim == "" {
+		values.UsernameClaim = common.OIDCDefaultUsernameClaim
+	}
+	if values.GroupsClaim == "" {
+		values.GroupsClaim = common.OIDCDefaultGroupsClaim
+	}
+	return values
+}
+
+// createOrUpdateOIDCSecret copies the client secret and CA bundle of the external OIDC provider into the secret
+// mounted by the AuthProxy, the secret is deleted if Keycloak is used
+func createOrUpdateOIDCSecret(ctx spi.ComponentContext) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      oidcSecretName,
+			Namespace: ComponentNamespace,
+		},
+	}
+	if ctx.IsDryRun() {
+		return nil
+	}
+	oidc := common.GetExternalOIDC(ctx.EffectiveCR())
+	if oidc == nil {
+		if err := ctx.Client().Delete(context.TODO(), secret); err != nil && !errors.IsNotFound(err) {
+			return ctx.Log().ErrorfNewErr("Failed deleting secret %s/%s: %v", ComponentNamespace, oidcSecretName, err)
+		}
+		return nil
+	}
+
+	clientSecret, err := common.GetExternalOIDCSecretValue(ctx, oidc.ClientSecretRef)
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the OIDC client secret: %v", err)
+	}
+	data := map[string][]byte{oidcClientSecretKey: clientSecret}
+	if oidc.CABundleSecretRef != nil {
+		caBundle, err := common.GetExternalOIDCSecretValue(ctx, *oidc.CABundleSecretRef)
+		if err != nil {
+			return ctx.Log().ErrorfNewErr("Failed getting the OIDC CA bundle: %v", err)
+		}
+		data[oidcCABundleKey] = caBundle
+	}
+
+	_, err = controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), secret, func() error {
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = data
+		return nil
+	})
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed creating or updating secret %s/%s: %v", ComponentNamespace, oidcSecretName, err)
+	}
+	return nil
+}
+
+// validateOIDC validates the external OIDC provider configuration
+func validateOIDC(oidc *v1beta1.AuthProxyOIDC) error {
+	if oidc == nil {
+		return nil
+	}
+	issuer, err := url.Parse(oidc.IssuerURL)
+	if err != nil || issuer.Scheme != "https" || issuer.Host == "" {
+		return fmt.Errorf("The %s OIDC issuerURL %q must be an https URL", ComponentJSONName, oidc.IssuerURL)
+	}
+	if issuer.RawQuery != "" || issuer.Fragment != "" {
+		return fmt.Errorf("The %s OIDC issuerURL %q must not have a query or fragment", ComponentJSONName, oidc.IssuerURL)
+	}
+	if oidc.ClientID == "" {
+		return fmt.Errorf("The %s OIDC clientID is required", ComponentJSONName)
+	}
+	if err := validateSecretKeySelector(oidc.ClientSecretRef, "clientSecretRef"); err != nil {
+		return err
+	}
+	if oidc.CABundleSecretRef != nil {
+		if err := validateSecretKeySelector(*oidc.CABundleSecretRef, "caBundleSecretRef"); err != nil {
+			return err
+		}
+	}
+	for i, mapping := range oidc.GroupMappings {
+		if mapping.ProviderGroup == "" || len(mapping.Groups) == 0 {
+			return fmt.Errorf("The %s OIDC groupMappings[%d] requires a providerGroup and at least one group", ComponentJSONName, i)
+		}
+	}
+	return nil
+}
+
+func validateSecretKeySelector(selector corev1.SecretKeySelector, field string) error {
+	if selector.Name == "" || selector.Key == "" {
+		return fmt.Errorf("The %s OIDC %s requires a secret name and key", ComponentJSONName, field)
+	}
+	return nil
+}
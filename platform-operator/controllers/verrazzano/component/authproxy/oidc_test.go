@@ -0,0 +1,166 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package authproxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testOIDCSecretNamespace = "default"
+
+func newOIDCTestCR(oidc *vzapi.AuthProxyOIDC) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: testOIDCSecretNamespace},
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				AuthProxy: &vzapi.AuthProxyComponent{OIDC: oidc},
+			},
+		},
+	}
+}
+
+func newTestOIDC() *vzapi.AuthProxyOIDC {
+	return &vzapi.AuthProxyOIDC{
+		IssuerURL:         "https://idp.example.com",
+		ClientID:          "verrazzano",
+		ClientSecretRef:   corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp"}, Key: "client-secret"},
+		CABundleSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp"}, Key: "ca.crt"},
+	}
+}
+
+// TestCreateOrUpdateOIDCSecret tests the createOrUpdateOIDCSecret function
+// GIVEN a Verrazzano CR configuring an external OIDC provider
+//  WHEN createOrUpdateOIDCSecret is called
+//  THEN the client secret and CA bundle are copied to the AuthProxy OIDC secret
+func TestCreateOrUpdateOIDCSecret(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "idp", Namespace: testOIDCSecretNamespace},
+			Data: map[string][]byte{
+				"client-secret": []byte("s3cret"),
+				"ca.crt":        []byte("ca-data"),
+			},
+		},
+	).Build()
+	ctx := spi.NewFakeContext(cli, newOIDCTestCR(newTestOIDC()), nil, false)
+
+	asserts.NoError(createOrUpdateOIDCSecret(ctx))
+	secret := &corev1.Secret{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: oidcSecretName, Namespace: ComponentNamespace}, secret))
+	asserts.Equal("s3cret", string(secret.Data[oidcClientSecretKey]))
+	asserts.Equal("ca-data", string(secret.Data[oidcCABundleKey]))
+}
+
+// TestCreateOrUpdateOIDCSecretMissing tests the createOrUpdateOIDCSecret function
+// GIVEN a Verrazzano CR configuring an external OIDC provider
+//  WHEN createOrUpdateOIDCSecret is called and the referenced secret does not exist
+//  THEN an error is returned
+func TestCreateOrUpdateOIDCSecretMissing(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	ctx := spi.NewFakeContext(cli, newOIDCTestCR(newTestOIDC()), nil, false)
+	assert.Error(t, createOrUpdateOIDCSecret(ctx))
+}
+
+// TestReconcileOIDCSecret tests the Reconcile function
+// GIVEN an AuthProxy OIDC secret copied from an external OIDC provider client secret that has been rotated
+//  WHEN Reconcile is called
+//  THEN the rotated client secret is copied to the AuthProxy OIDC secret
+func TestReconcileOIDCSecret(t *testing.T) {
+	asserts := assert.New(t)
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp", Namespace: testOIDCSecretNamespace},
+		Data: map[string][]byte{
+			"client-secret": []byte("s3cret"),
+			"ca.crt":        []byte("ca-data"),
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(source).Build()
+	ctx := spi.NewFakeContext(cli, newOIDCTestCR(newTestOIDC()), nil, false)
+	asserts.NoError(createOrUpdateOIDCSecret(ctx))
+
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: "idp", Namespace: testOIDCSecretNamespace}, source))
+	source.Data["client-secret"] = []byte("rotated")
+	asserts.NoError(cli.Update(context.TODO(), source))
+
+	asserts.NoError(NewComponent().Reconcile(ctx))
+	secret := &corev1.Secret{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: oidcSecretName, Namespace: ComponentNamespace}, secret))
+	asserts.Equal("rotated", string(secret.Data[oidcClientSecretKey]))
+}
+
+// TestDeleteOIDCSecret tests the createOrUpdateOIDCSecret function
+// GIVEN a Verrazzano CR that does not configure an external OIDC provider
+//  WHEN createOrUpdateOIDCSecret is called
+//  THEN a previously created AuthProxy OIDC secret is deleted
+func TestDeleteOIDCSecret(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: oidcSecretName, Namespace: ComponentNamespace}},
+	).Build()
+	ctx := spi.NewFakeContext(cli, newOIDCTestCR(nil), nil, false)
+
+	asserts.NoError(createOrUpdateOIDCSecret(ctx))
+	err := cli.Get(context.TODO(), types.NamespacedName{Name: oidcSecretName, Namespace: ComponentNamespace}, &corev1.Secret{})
+	asserts.True(errors.IsNotFound(err))
+
+	// deleting again is a no-op
+	asserts.NoError(createOrUpdateOIDCSecret(ctx))
+}
+
+// TestValidateOIDC tests the validateOIDC function
+// GIVEN an external OIDC provider configuration
+//  WHEN validateOIDC is called
+//  THEN an error is returned for invalid configurations
+func TestValidateOIDC(t *testing.T) {
+	valid := func() *v1beta1.AuthProxyOIDC {
+		return &v1beta1.AuthProxyOIDC{
+			IssuerURL:       "https://idp.example.com/realms/apps",
+			ClientID:        "verrazzano",
+			ClientSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp"}, Key: "client-secret"},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(oidc *v1beta1.AuthProxyOIDC)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(oidc *v1beta1.AuthProxyOIDC) {}},
+		{name: "http issuer", mutate: func(oidc *v1beta1.AuthProxyOIDC) { oidc.IssuerURL = "http://idp.example.com" }, wantErr: true},
+		{name: "no issuer host", mutate: func(oidc *v1beta1.AuthProxyOIDC) { oidc.IssuerURL = "https://" }, wantErr: true},
+		{name: "issuer query", mutate: func(oidc *v1beta1.AuthProxyOIDC) { oidc.IssuerURL = "https://idp.example.com?a=b" }, wantErr: true},
+		{name: "no client id", mutate: func(oidc *v1beta1.AuthProxyOIDC) { oidc.ClientID = "" }, wantErr: true},
+		{name: "no client secret key", mutate: func(oidc *v1beta1.AuthProxyOIDC) { oidc.ClientSecretRef.Key = "" }, wantErr: true},
+		{name: "no CA bundle name", mutate: func(oidc *v1beta1.AuthProxyOIDC) {
+			oidc.CABundleSecretRef = &corev1.SecretKeySelector{Key: "ca.crt"}
+		}, wantErr: true},
+		{name: "empty group mapping", mutate: func(oidc *v1beta1.AuthProxyOIDC) {
+			oidc.GroupMappings = []v1beta1.AuthProxyOIDCGroupMapping{{ProviderGroup: "developers"}}
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oidc := valid()
+			tt.mutate(oidc)
+			err := validateOIDC(oidc)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+	assert.NoError(t, validateOIDC(nil))
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package certmanager
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	cmutil "github.com/jetstack/cert-manager/pkg/api/util"
+	certv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	certmetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	certv1client "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/mcconstants"
+	"github.com/verrazzano/verrazzano/pkg/security/cabundle"
+	"github.com/verrazzano/verrazzano/pkg/security/password"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// States of a rotation of the Verrazzano CA
+	caRotationIssuing      = "Issuing"
+	caRotationDistributing = "Distributing"
+	caRotationReissuing    = "Reissuing"
+	caRotationTransition   = "Transition"
+	caRotationCompleted    = "Completed"
+
+	// Reasons of a rotation of the Verrazzano CA
+	caRotationRequested = "Requested"
+	caRotationExpiring  = "Expiring"
+
+	defaultCARenewBefore      = 1080 * time.Hour
+	defaultCATransitionPeriod = 24 * time.Hour
+
+	// cert-manager renews the 90 day CA itself when a third of its lifetime is left, with the same key, so the
+	// rotation must start before that
+	minCARenewBefore = 720 * time.Hour
+	maxCARenewBefore = 2160 * time.Hour
+
+	// caBundleDistributionPeriod is how long the CA bundle with both CAs is distributed before the certificates are
+	// re-issued by the new CA, the managed cluster agents poll the admin cluster every minute
+	caBundleDistributionPeriod = 5 * time.Minute
+
+	// caRotationPollPeriod is how often a rotation is checked while cert-manager issues the certificates
+	caRotationPollPeriod = 30 * time.Second
+)
+
+// reconcileCARotation starts a rotation of the self-signed Verrazzano CA when it is requested with a new trigger or
+// when the CA expires within the renewal window, and advances a rotation in progress.  A rotation that was started
+// always completes, even if the rotation is removed from the spec.  It returns the status of the last rotation and
+// how long to wait before the next check, zero means there is nothing to check.
+func reconcileCARotation(ctx spi.ComponentContext, current *vzapi.CARotationStatus) (*vzapi.CARotationStatus, time.Duration, error) {
+	if current != nil && current.State != caRotationCompleted {
+		return advanceCARotation(ctx, current.DeepCopy())
+	}
+	spec := getCARotationSpec(ctx.EffectiveCR())
+	if spec == nil || !isDefaultSelfSignedCA(ctx) {
+		return current, 0, nil
+	}
+	caSecret, caCert, err := getDefaultCACertificate(ctx)
+	if err != nil || caSecret == nil {
+		return current, 0, err
+	}
+
+	renewBefore := getCARenewBefore(spec)
+	var reason string
+	if len(spec.Trigger) > 0 && (current == nil || current.Trigger != spec.Trigger) {
+		reason = caRotationRequested
+	} else if time.Until(caCert.NotAfter) < renewBefore {
+		reason = caRotationExpiring
+	}
+	if len(reason) == 0 {
+		// Check again once the CA enters the renewal window
+		return current, time.Until(caCert.NotAfter.Add(-renewBefore)), nil
+	}
+	return startCARotation(ctx, spec.Trigger, reason, caSecret, caCert)
+}
+
+// startCARotation keeps the previous CA in the transition secret, so that the CA bundles keep trusting it, then
+// re-issues the CA with a new common name and a new private key
+func startCARotation(ctx spi.ComponentContext, trigger string, reason string, caSecret *v1.Secret, caCert *x509.Certificate) (*vzapi.CARotationStatus, time.Duration, error) {
+	ctx.Log().Infof("Starting the rotation of the Verrazzano CA %s, reason: %s", caCert.Subject.CommonName, reason)
+	if err := updateCATransitionSecret(ctx, caSecret.Data[v1.TLSCertKey]); err != nil {
+		return nil, 0, err
+	}
+
+	commonNameSuffix, err := password.GenerateRandomAlphaLower(8)
+	if err != nil {
+		return nil, 0, ctx.Log().ErrorfNewErr("Failed to generate CA common name suffix: %v", err)
+	}
+	newCommonName := fmt.Sprintf("%s-%s", caCertCommonName, commonNameSuffix)
+	certificate := certv1.Certificate{}
+	if err := ctx.Client().Get(context.TODO(), crtclient.ObjectKey{Namespace: ComponentNamespace, Name: caCertificateName}, &certificate); err != nil {
+		return nil, 0, ctx.Log().ErrorfNewErr("Failed to get the Certificate %s/%s: %v", ComponentNamespace, caCertificateName, err)
+	}
+	// cert-manager re-issues the certificate once its spec no longer matches the secret
+	certificate.Spec.CommonName = newCommonName
+	if certificate.Spec.PrivateKey == nil {
+		certificate.Spec.PrivateKey = &certv1.CertificatePrivateKey{}
+	}
+	certificate.Spec.PrivateKey.RotationPolicy = certv1.RotationPolicyAlways
+	if err := ctx.Client().Update(context.TODO(), &certificate); err != nil {
+		return nil, 0, ctx.Log().ErrorfNewErr("Failed to update the Certificate %s/%s: %v", ComponentNamespace, caCertificateName, err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	return &vzapi.CARotationStatus{
+		Trigger:    trigger,
+		Reason:     reason,
+		State:      caRotationIssuing,
+		PreviousCA: caCert.Subject.CommonName,
+		CurrentCA:  newCommonName,
+		StartTime:  now,
+		StateTime:  now,
+		Message:    fmt.Sprintf("Waiting for cert-manager to issue the CA %s", newCommonName),
+	}, caRotationPollPeriod, nil
+}
+
+// advanceCARotation moves a rotation to its next state once the current state is done
+// - Issuing: cert-manager issues the new CA, which is then added to the transition secret
+// - Distributing: the CA bundles with both CAs are distributed to the clusters that trust the Verrazzano CA
+// - Reissuing: the certificates signed by the previous CA are re-issued by the new CA
+// - Transition: the previous CA remains trusted for the transition period, then the transition secret is deleted
+func advanceCARotation(ctx spi.ComponentContext, status *vzapi.CARotationStatus) (*vzapi.CARotationStatus, time.Duration, error) {
+	stateTime, err := time.Parse(time.RFC3339, status.StateTime)
+	if err != nil {
+		stateTime = time.Now()
+	}
+	switch status.State {
+	case caRotationIssuing:
+		caSecret, caCert, err := getDefaultCACertificate(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		if caSecret == nil || caCert.Subject.CommonName != status.CurrentCA {
+			return status, caRotationPollPeriod, nil
+		}
+		if err := updateCATransitionSecret(ctx, caSecret.Data[v1.TLSCertKey]); err != nil {
+			return nil, 0, err
+		}
+		setCARotationState(status, caRotationDistributing, "Distributing the CA bundle with the previous and the new CA")
+		return status, caBundleDistributionPeriod, nil
+
+	case caRotationDistributing:
+		if remaining := time.Until(stateTime.Add(caBundleDistributionPeriod)); remaining > 0 {
+			return status, remaining, nil
+		}
+		if _, err := reissueCertificates(ctx, status.CurrentCA); err != nil {
+			return nil, 0, err
+		}
+		setCARotationState(status, caRotationReissuing, fmt.Sprintf("Re-issuing the certificates signed by the CA %s", status.PreviousCA))
+		return status, caRotationPollPeriod, nil
+
+	case caRotationReissuing:
+		pending, err := reissueCertificates(ctx, status.CurrentCA)
+		if err != nil {
+			return nil, 0, err
+		}
+		if pending > 0 {
+			status.Message = fmt.Sprintf("Waiting for %d certificates to be re-issued by the CA %s", pending, status.CurrentCA)
+			return status, caRotationPollPeriod, nil
+		}
+		transitionPeriod := getCATransitionPeriod(getCARotationSpec(ctx.EffectiveCR()))
+		setCARotationState(status, caRotationTransition, fmt.Sprintf("The CA %s is trusted until %s", status.PreviousCA,
+			time.Now().Add(transitionPeriod).UTC().Format(time.RFC3339)))
+		return status, transitionPeriod, nil
+
+	case caRotationTransition:
+		transitionPeriod := getCATransitionPeriod(getCARotationSpec(ctx.EffectiveCR()))
+		if remaining := time.Until(stateTime.Add(transitionPeriod)); remaining > 0 {
+			return status, remaining, nil
+		}
+		transitionSecret := v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: constants.VerrazzanoCATransitionSecret}}
+		if err := ctx.Client().Delete(context.TODO(), &transitionSecret); crtclient.IgnoreNotFound(err) != nil {
+			return nil, 0, ctx.Log().ErrorfNewErr("Failed to delete the secret %s/%s: %v", ComponentNamespace, constants.VerrazzanoCATransitionSecret, err)
+		}
+		ctx.Log().Infof("Completed the rotation of the Verrazzano CA, the CA %s is retired", status.PreviousCA)
+		setCARotationState(status, caRotationCompleted, fmt.Sprintf("The CA %s is retired", status.PreviousCA))
+		status.CompletionTime = status.StateTime
+		return status, 0, nil
+	}
+	return status, 0, nil
+}
+
+// reissueCertificates renews the certificates issued by the Verrazzano ClusterIssuer that are not yet signed by the
+// CA with the given common name, and returns how many of them are still to be re-issued
+func reissueCertificates(ctx spi.ComponentContext, caCommonName string) (int, error) {
+	certList := certv1.CertificateList{}
+	if err := ctx.Client().List(context.TODO(), &certList); err != nil {
+		return 0, ctx.Log().ErrorfNewErr("Failed to list the certificates: %v", err)
+	}
+	var cmClient certv1client.CertmanagerV1Interface
+	pending := 0
+	for i, cert := range certList.Items {
+		if cert.Name == caCertificateName || cert.Spec.IssuerRef.Name != verrazzanoClusterIssuerName {
+			continue
+		}
+		secret := v1.Secret{}
+		if err := ctx.Client().Get(context.TODO(), crtclient.ObjectKey{Namespace: cert.Namespace, Name: cert.Spec.SecretName}, &secret); crtclient.IgnoreNotFound(err) != nil {
+			return 0, ctx.Log().ErrorfNewErr("Failed to get the secret %s/%s: %v", cert.Namespace, cert.Spec.SecretName, err)
+		}
+		if issuerCN, err := extractCommonNameFromCertSecret(&secret); err == nil && issuerCN == caCommonName {
+			continue
+		}
+		pending++
+		if cmutil.CertificateHasCondition(&cert, certv1.CertificateCondition{Type: certv1.CertificateConditionIssuing, Status: certmetav1.ConditionTrue}) {
+			// cert-manager is already issuing the certificate
+			continue
+		}
+		if cmClient == nil {
+			var err error
+			if cmClient, err = getCMClientFunc(); err != nil {
+				return 0, ctx.Log().ErrorfNewErr("Failed to get the cert-manager client: %v", err)
+			}
+		}
+		if err := renewCertificate(context.TODO(), cmClient, ctx.Log(), &certList.Items[i]); err != nil {
+			return 0, ctx.Log().ErrorfNewErr("Failed to renew the certificate %s/%s: %v", cert.Namespace, cert.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// updateCATransitionSecret adds the certificates to the transition secret, the CAs of the transition secret are
+// trusted by the CA bundles of the admin and managed clusters until the rotation retires the previous CA
+func updateCATransitionSecret(ctx spi.ComponentContext, certs []byte) error {
+	secret := v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: constants.VerrazzanoCATransitionSecret}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), &secret, func() error {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[mcconstants.CaCrtKey] = cabundle.AppendCertificates(secret.Data[mcconstants.CaCrtKey], certs)
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed to create or update the secret %s/%s: %v", ComponentNamespace, constants.VerrazzanoCATransitionSecret, err)
+	}
+	return nil
+}
+
+// getDefaultCACertificate returns the secret and the certificate of the self-signed Verrazzano CA, the secret is nil
+// while cert-manager has not issued the CA
+func getDefaultCACertificate(ctx spi.ComponentContext) (*v1.Secret, *x509.Certificate, error) {
+	secret := v1.Secret{}
+	err := ctx.Client().Get(context.TODO(), crtclient.ObjectKey{Namespace: ComponentNamespace, Name: defaultCACertificateSecretName}, &secret)
+	if crtclient.IgnoreNotFound(err) != nil {
+		return nil, nil, ctx.Log().ErrorfNewErr("Failed to get the CA secret %s/%s: %v", ComponentNamespace, defaultCACertificateSecretName, err)
+	}
+	if err != nil {
+		return nil, nil, nil
+	}
+	cert, err := parseCertSecret(&secret)
+	if err != nil {
+		return nil, nil, ctx.Log().ErrorfNewErr("Failed to parse the CA certificate of the secret %s/%s: %v", ComponentNamespace, defaultCACertificateSecretName, err)
+	}
+	return &secret, cert, nil
+}
+
+// isDefaultSelfSignedCA returns true if Verrazzano is configured with the self-signed CA that it issues itself
+func isDefaultSelfSignedCA(ctx spi.ComponentContext) bool {
+	isCAValue, err := isCA(ctx)
+	if err != nil || !isCAValue {
+		return false
+	}
+	caConfig := getCAConfig(ctx.EffectiveCR())
+	return caConfig.SecretName == defaultCACertificateSecretName && caConfig.ClusterResourceNamespace == ComponentNamespace
+}
+
+// validateCARotation checks that the CA rotation is only configured for the self-signed Verrazzano CA and that its
+// renewal window starts before cert-manager renews the CA itself
+func validateCARotation(comp *v1beta1.CertManagerComponent) error {
+	if comp == nil || comp.CARotation == nil {
+		return nil
+	}
+	certificate := comp.Certificate
+	if certificate.Acme != (v1beta1.Acme{}) || certificate.IssuerRef != (v1beta1.IssuerRef{}) ||
+		(certificate.CA != (v1beta1.CA{}) && (certificate.CA.SecretName != defaultCACertificateSecretName || certificate.CA.ClusterResourceNamespace != ComponentNamespace)) {
+		return fmt.Errorf("The CA rotation is only supported for the self-signed Verrazzano CA")
+	}
+	if renewBefore := comp.CARotation.RenewBefore; renewBefore != nil && (renewBefore.Duration <= minCARenewBefore || renewBefore.Duration >= maxCARenewBefore) {
+		return fmt.Errorf("The CA rotation renewBefore %s must be longer than %s and shorter than %s", renewBefore.Duration, minCARenewBefore, maxCARenewBefore)
+	}
+	if transitionPeriod := comp.CARotation.TransitionPeriod; transitionPeriod != nil && transitionPeriod.Duration < 0 {
+		return fmt.Errorf("The CA rotation transitionPeriod %s must not be negative", transitionPeriod.Duration)
+	}
+	return nil
+}
+
+func getCARotationSpec(cr *vzapi.Verrazzano) *vzapi.CARotationSpec {
+	if cr.Spec.Components.CertManager == nil {
+		return nil
+	}
+	return cr.Spec.Components.CertManager.CARotation
+}
+
+func getCAConfig(cr *vzapi.Verrazzano) vzapi.CA {
+	if cr.Spec.Components.CertManager == nil {
+		return vzapi.CA{}
+	}
+	return cr.Spec.Components.CertManager.Certificate.CA
+}
+
+func getCARenewBefore(spec *vzapi.CARotationSpec) time.Duration {
+	if spec == nil || spec.RenewBefore == nil {
+		return defaultCARenewBefore
+	}
+	return spec.RenewBefore.Duration
+}
+
+func getCATransitionPeriod(spec *vzapi.CARotationSpec) time.Duration {
+	if spec == nil || spec.TransitionPeriod == nil {
+		return defaultCATransitionPeriod
+	}
+	return spec.TransitionPeriod.Duration
+}
+
+func setCARotationState(status *vzapi.CARotationStatus, state string, message string) {
+	status.State = state
+	status.StateTime = time.Now().UTC().Format(time.RFC3339)
+	status.Message = message
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package certmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cmutil "github.com/jetstack/cert-manager/pkg/api/util"
+	certv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	certv1fake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	certv1client "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/pkg/mcconstants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testPreviousCA = caCertCommonName + "-previous"
+	testLeafName   = "verrazzano-tls"
+	testLeafNS     = "verrazzano-system"
+)
+
+var caTransitionSecretName = types.NamespacedName{Namespace: ComponentNamespace, Name: constants.VerrazzanoCATransitionSecret}
+
+// newCARotationTestVZ returns a Verrazzano resource with the self-signed Verrazzano CA and its rotation
+func newCARotationTestVZ(rotation *vzapi.CARotationSpec) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				CertManager: &vzapi.CertManagerComponent{
+					Certificate: vzapi.Certificate{
+						CA: vzapi.CA{SecretName: defaultCACertificateSecretName, ClusterResourceNamespace: ComponentNamespace},
+					},
+					CARotation: rotation,
+				},
+			},
+		},
+	}
+}
+
+// newTestCASecret returns the secret of a self-signed CA with the given common name
+func newTestCASecret(t *testing.T, commonName string) *v1.Secret {
+	certBytes, err := createFakeCertBytes(commonName, nil)
+	assert.NoError(t, err)
+	secret, err := createCertSecret(defaultCACertificateSecretName, ComponentNamespace, certBytes)
+	assert.NoError(t, err)
+	return secret
+}
+
+// newTestLeafSecret returns the secret of a leaf certificate signed by the CA with the given common name
+func newTestLeafSecret(t *testing.T, caCommonName string) *v1.Secret {
+	certBytes, err := createFakeCertBytes("verrazzano", createFakeCertificate(caCommonName))
+	assert.NoError(t, err)
+	secret, err := createCertSecret(testLeafName, testLeafNS, certBytes)
+	assert.NoError(t, err)
+	return secret
+}
+
+func newTestCACertificate() *certv1.Certificate {
+	return &certv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: caCertificateName, Namespace: ComponentNamespace},
+		Spec: certv1.CertificateSpec{
+			SecretName: defaultCACertificateSecretName,
+			CommonName: testPreviousCA,
+			IsCA:       true,
+			IssuerRef:  cmmeta.ObjectReference{Name: caSelfSignedIssuerName},
+		},
+	}
+}
+
+func newTestLeafCertificate() *certv1.Certificate {
+	notAfter := metav1.NewTime(time.Date(2023, 1, 18, 9, 14, 30, 0, time.UTC))
+	return &certv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: testLeafName, Namespace: testLeafNS},
+		Spec: certv1.CertificateSpec{
+			SecretName: testLeafName,
+			IssuerRef:  cmmeta.ObjectReference{Name: verrazzanoClusterIssuerName, Kind: "ClusterIssuer"},
+		},
+		Status: certv1.CertificateStatus{NotAfter: &notAfter},
+	}
+}
+
+// TestValidateCARotation tests the validateCARotation function
+// GIVEN a cert-manager component with a CA rotation
+// WHEN validateCARotation is called
+// THEN an error is returned unless the CA is the self-signed Verrazzano CA and the durations are in range
+func TestValidateCARotation(t *testing.T) {
+	defaultCA := v1beta1.CA{SecretName: defaultCACertificateSecretName, ClusterResourceNamespace: ComponentNamespace}
+	tests := []struct {
+		name    string
+		comp    *v1beta1.CertManagerComponent
+		wantErr bool
+	}{
+		{name: "no component", comp: nil},
+		{name: "no rotation", comp: &v1beta1.CertManagerComponent{Certificate: v1beta1.Certificate{Acme: v1beta1.Acme{Provider: "LetsEncrypt"}}}},
+		{name: "defaults", comp: &v1beta1.CertManagerComponent{CARotation: &v1beta1.CARotationSpec{}}},
+		{
+			name: "self-signed CA",
+			comp: &v1beta1.CertManagerComponent{
+				Certificate: v1beta1.Certificate{CA: defaultCA},
+				CARotation: &v1beta1.CARotationSpec{
+					RenewBefore:      &metav1.Duration{Duration: 1200 * time.Hour},
+					TransitionPeriod: &metav1.Duration{Duration: 48 * time.Hour},
+					Trigger:          "2022-10-20",
+				},
+			},
+		},
+		{
+			name:    "customer CA",
+			comp:    &v1beta1.CertManagerComponent{Certificate: v1beta1.Certificate{CA: v1beta1.CA{SecretName: "my-ca", ClusterResourceNamespace: "my-namespace"}}, CARotation: &v1beta1.CARotationSpec{}},
+			wantErr: true,
+		},
+		{
+			name:    "ACME",
+			comp:    &v1beta1.CertManagerComponent{Certificate: v1beta1.Certificate{Acme: v1beta1.Acme{Provider: "LetsEncrypt"}}, CARotation: &v1beta1.CARotationSpec{}},
+			wantErr: true,
+		},
+		{
+			name:    "ClusterIssuer",
+			comp:    &v1beta1.CertManagerComponent{Certificate: v1beta1.Certificate{IssuerRef: v1beta1.IssuerRef{Name: "my-issuer"}}, CARotation: &v1beta1.CARotationSpec{}},
+			wantErr: true,
+		},
+		{
+			name:    "renewal after cert-manager",
+			comp:    &v1beta1.CertManagerComponent{CARotation: &v1beta1.CARotationSpec{RenewBefore: &metav1.Duration{Duration: 720 * time.Hour}}},
+			wantErr: true,
+		},
+		{
+			name:    "renewal longer than the CA lifetime",
+			comp:    &v1beta1.CertManagerComponent{CARotation: &v1beta1.CARotationSpec{RenewBefore: &metav1.Duration{Duration: 2160 * time.Hour}}},
+			wantErr: true,
+		},
+		{
+			name:    "negative transition period",
+			comp:    &v1beta1.CertManagerComponent{CARotation: &v1beta1.CARotationSpec{TransitionPeriod: &metav1.Duration{Duration: -time.Hour}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCARotation(tt.comp)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestStartCARotationRequested tests the reconcileCARotation function
+// GIVEN a Verrazzano resource with a new CA rotation trigger
+// WHEN reconcileCARotation is called
+// THEN the previous CA is kept in the transition secret and the CA is re-issued with a new common name and key
+func TestStartCARotationRequested(t *testing.T) {
+	asserts := assert.New(t)
+	caSecret := newTestCASecret(t, testPreviousCA)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(caSecret, newTestCACertificate()).Build()
+	ctx := spi.NewFakeContext(cli, newCARotationTestVZ(&vzapi.CARotationSpec{Trigger: "2022-10-20"}), nil, false)
+
+	status, requeueAfter, err := reconcileCARotation(ctx, nil)
+	asserts.NoError(err)
+	asserts.Equal(caRotationPollPeriod, requeueAfter)
+	asserts.Equal("2022-10-20", status.Trigger)
+	asserts.Equal(caRotationRequested, status.Reason)
+	asserts.Equal(caRotationIssuing, status.State)
+	asserts.Equal(testPreviousCA, status.PreviousCA)
+	asserts.NotEqual(testPreviousCA, status.CurrentCA)
+	asserts.NotEmpty(status.StartTime)
+
+	transition := v1.Secret{}
+	asserts.NoError(cli.Get(context.TODO(), caTransitionSecretName, &transition))
+	asserts.Equal(caSecret.Data[v1.TLSCertKey], transition.Data[mcconstants.CaCrtKey])
+
+	certificate := certv1.Certificate{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: caCertificateName}, &certificate))
+	asserts.Equal(status.CurrentCA, certificate.Spec.CommonName)
+	asserts.Equal(certv1.RotationPolicyAlways, certificate.Spec.PrivateKey.RotationPolicy)
+}
+
+// TestStartCARotationExpiring tests the reconcileCARotation function
+// GIVEN a Verrazzano resource with a CA rotation and a CA that expires within the renewal window
+// WHEN reconcileCARotation is called
+// THEN a rotation is started
+func TestStartCARotationExpiring(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newTestCASecret(t, testPreviousCA), newTestCACertificate()).Build()
+	ctx := spi.NewFakeContext(cli, newCARotationTestVZ(&vzapi.CARotationSpec{}), nil, false)
+
+	status, _, err := reconcileCARotation(ctx, nil)
+	asserts.NoError(err)
+	asserts.Equal(caRotationExpiring, status.Reason)
+	asserts.Equal(caRotationIssuing, status.State)
+}
+
+// TestNoCARotation tests the reconcileCARotation function
+// GIVEN a Verrazzano resource whose CA rotation trigger was already handled, and a CA outside of the renewal window
+// WHEN reconcileCARotation is called
+// THEN no rotation is started and the check is scheduled for when the CA enters the renewal window
+func TestNoCARotation(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newTestCASecret(t, testPreviousCA), newTestCACertificate()).Build()
+	last := &vzapi.CARotationStatus{Trigger: "2022-10-20", State: caRotationCompleted}
+	rotation := &vzapi.CARotationSpec{Trigger: "2022-10-20", RenewBefore: &metav1.Duration{Duration: time.Hour}}
+	ctx := spi.NewFakeContext(cli, newCARotationTestVZ(rotation), nil, false)
+
+	status, requeueAfter, err := reconcileCARotation(ctx, last)
+	asserts.NoError(err)
+	asserts.Equal(last, status)
+	asserts.Greater(requeueAfter, 50*time.Hour)
+
+	// Without a rotation in the spec, there is nothing to check
+	ctx = spi.NewFakeContext(cli, newCARotationTestVZ(nil), nil, false)
+	status, requeueAfter, err = reconcileCARotation(ctx, last)
+	asserts.NoError(err)
+	asserts.Equal(last, status)
+	asserts.Zero(requeueAfter)
+
+	// A customer CA is not rotated
+	vz := newCARotationTestVZ(&vzapi.CARotationSpec{Trigger: "2022-10-21"})
+	vz.Spec.Components.CertManager.Certificate.CA = vzapi.CA{SecretName: "my-ca", ClusterResourceNamespace: ComponentNamespace}
+	cli = fake.NewClientBuilder().WithScheme(testScheme).Build()
+	defer func() { getClientFunc = k8sutil.GetCoreV1Client }()
+	getClientFunc = createClientFunc(vz.Spec.Components.CertManager.Certificate.CA, "my-ca")
+	status, requeueAfter, err = reconcileCARotation(spi.NewFakeContext(cli, vz, nil, false), last)
+	asserts.NoError(err)
+	asserts.Equal(last, status)
+	asserts.Zero(requeueAfter)
+}
+
+// TestAdvanceCARotation tests the reconcileCARotation function
+// GIVEN a rotation of the Verrazzano CA in progress
+// WHEN reconcileCARotation is called as cert-manager issues the new CA and the certificates
+// THEN the new CA is added to the transition secret, the certificates signed by the previous CA are re-issued, and
+// the previous CA is retired at the end of the transition period
+func TestAdvanceCARotation(t *testing.T) {
+	asserts := assert.New(t)
+	const newCA = caCertCommonName + "-current"
+	previousCASecret := newTestCASecret(t, testPreviousCA)
+	leafCert := newTestLeafCertificate()
+	leafSecret := newTestLeafSecret(t, testPreviousCA)
+	transition := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: caTransitionSecretName.Namespace, Name: caTransitionSecretName.Name},
+		Data:       map[string][]byte{mcconstants.CaCrtKey: previousCASecret.Data[v1.TLSCertKey]},
+	}
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(previousCASecret, leafCert, leafSecret, transition).Build()
+	ctx := spi.NewFakeContext(cli, newCARotationTestVZ(&vzapi.CARotationSpec{}), nil, false)
+
+	cmClient := certv1fake.NewSimpleClientset(leafCert)
+	defer func() { getCMClientFunc = GetCertManagerClientset }()
+	getCMClientFunc = func() (certv1client.CertmanagerV1Interface, error) {
+		return cmClient.CertmanagerV1(), nil
+	}
+
+	// cert-manager has not issued the new CA yet
+	status := &vzapi.CARotationStatus{State: caRotationIssuing, PreviousCA: testPreviousCA, CurrentCA: newCA, StateTime: time.Now().UTC().Format(time.RFC3339)}
+	status, requeueAfter, err := reconcileCARotation(ctx, status)
+	asserts.NoError(err)
+	asserts.Equal(caRotationIssuing, status.State)
+	asserts.Equal(caRotationPollPeriod, requeueAfter)
+
+	// Once the new CA is issued, the bundle with both CAs is distributed
+	newCASecret := newTestCASecret(t, newCA)
+	asserts.NoError(cli.Update(context.TODO(), newCASecret))
+	status, requeueAfter, err = reconcileCARotation(ctx, status)
+	asserts.NoError(err)
+	asserts.Equal(caRotationDistributing, status.State)
+	asserts.Equal(caBundleDistributionPeriod, requeueAfter)
+	asserts.NoError(cli.Get(context.TODO(), caTransitionSecretName, transition))
+	asserts.Equal(append(append([]byte{}, previousCASecret.Data[v1.TLSCertKey]...), newCASecret.Data[v1.TLSCertKey]...), transition.Data[mcconstants.CaCrtKey])
+
+	// The certificates are re-issued once the bundle is distributed
+	status.StateTime = time.Now().Add(-caBundleDistributionPeriod).UTC().Format(time.RFC3339)
+	status, _, err = reconcileCARotation(ctx, status)
+	asserts.NoError(err)
+	asserts.Equal(caRotationReissuing, status.State)
+	updatedCert, err := cmClient.CertmanagerV1().Certificates(testLeafNS).Get(context.TODO(), testLeafName, metav1.GetOptions{})
+	asserts.NoError(err)
+	asserts.True(cmutil.CertificateHasCondition(updatedCert, certv1.CertificateCondition{Type: certv1.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}))
+
+	status, _, err = reconcileCARotation(ctx, status)
+	asserts.NoError(err)
+	asserts.Equal(caRotationReissuing, status.State)
+	asserts.Contains(status.Message, "Waiting for 1 certificates")
+
+	// The previous CA remains trusted for the transition period once the certificates are re-issued
+	asserts.NoError(cli.Update(context.TODO(), newTestLeafSecret(t, newCA)))
+	status, requeueAfter, err = reconcileCARotation(ctx, status)
+	asserts.NoError(err)
+	asserts.Equal(caRotationTransition, status.State)
+	asserts.Equal(defaultCATransitionPeriod, requeueAfter)
+
+	// The previous CA is retired at the end of the transition period
+	status.StateTime = time.Now().Add(-defaultCATransitionPeriod).UTC().Format(time.RFC3339)
+	status, requeueAfter, err = reconcileCARotation(ctx, status)
+	asserts.NoError(err)
+	asserts.Equal(caRotationCompleted, status.State)
+	asserts.NotEmpty(status.CompletionTime)
+	asserts.Zero(requeueAfter)
+	err = cli.Get(context.TODO(), caTransitionSecretName, &v1.Secret{})
+	asserts.True(errors.IsNotFound(err))
+}
+
+// TestReconcileCertificates tests the ReconcileCertificates function
+// GIVEN a cluster with the self-signed Verrazzano CA and certificates issued by the Verrazzano ClusterIssuer
+// WHEN ReconcileCertificates is called
+// THEN the expiry of the CA and of the certificates issued for Verrazzano is returned
+func TestReconcileCertificates(t *testing.T) {
+	asserts := assert.New(t)
+	caSecret := newTestCASecret(t, testPreviousCA)
+	otherCert := newTestLeafCertificate()
+	otherCert.Name = "other"
+	otherCert.Namespace = "other"
+	otherCert.Spec.IssuerRef.Name = "other-issuer"
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(caSecret, newTestCACertificate(), newTestLeafCertificate(), otherCert).Build()
+	ctx := spi.NewFakeContext(cli, newCARotationTestVZ(nil), nil, false)
+
+	status, requeueAfter, err := ReconcileCertificates(ctx)
+	asserts.NoError(err)
+	asserts.Equal(certificatesRefreshPeriod, requeueAfter)
+	asserts.Nil(status.CARotation)
+	cert, err := parseCertSecret(caSecret)
+	asserts.NoError(err)
+	asserts.Equal([]vzapi.CertificateExpiryStatus{
+		{Namespace: ComponentNamespace, SecretName: defaultCACertificateSecretName, CA: true, NotAfter: cert.NotAfter.UTC().Format(time.RFC3339)},
+		{Namespace: testLeafNS, SecretName: testLeafName, NotAfter: "2023-01-18T09:14:30Z"},
+	}, status.Expiry)
+}
+
+// TestReconcileCertificatesNoCertificates tests the ReconcileCertificates function
+// GIVEN a Verrazzano resource with cert-manager disabled, or without any certificate issued yet
+// WHEN ReconcileCertificates is called
+// THEN no status is returned
+func TestReconcileCertificatesNoCertificates(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	status, requeueAfter, err := ReconcileCertificates(spi.NewFakeContext(cli, newCARotationTestVZ(nil), nil, false))
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+	assert.Zero(t, requeueAfter)
+
+	disabled := false
+	vz := newCARotationTestVZ(nil)
+	vz.Spec.Components.CertManager.Enabled = &disabled
+	status, requeueAfter, err = ReconcileCertificates(spi.NewFakeContext(cli, vz, nil, false))
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+	assert.Zero(t, requeueAfter)
+}
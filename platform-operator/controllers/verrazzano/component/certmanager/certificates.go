@@ -0,0 +1,110 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package certmanager
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	certv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// certificatesRefreshPeriod is how often the expiry of the certificates is refreshed
+const certificatesRefreshPeriod = time.Hour
+
+// ReconcileCertificates records the expiry of the Verrazzano CA and of the certificates issued for Verrazzano, and
+// rotates the self-signed Verrazzano CA when a rotation is requested or when the CA nears its expiry.  It returns how
+// long to wait before the next check, the status is nil when cert-manager is disabled or has not issued any
+// certificate for Verrazzano.
+func ReconcileCertificates(ctx spi.ComponentContext) (*vzapi.CertificatesStatus, time.Duration, error) {
+	if !vzconfig.IsCertManagerEnabled(ctx.EffectiveCR()) {
+		return nil, 0, nil
+	}
+	var current *vzapi.CARotationStatus
+	if ctx.ActualCR().Status.Certificates != nil {
+		current = ctx.ActualCR().Status.Certificates.CARotation
+	}
+	rotation, requeueAfter, err := reconcileCARotation(ctx, current)
+	if err != nil {
+		return nil, 0, err
+	}
+	expiry, err := getCertificatesExpiry(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(expiry) == 0 && rotation == nil {
+		// cert-manager has not issued any certificate for Verrazzano yet
+		return nil, 0, nil
+	}
+	if requeueAfter <= 0 || requeueAfter > certificatesRefreshPeriod {
+		requeueAfter = certificatesRefreshPeriod
+	}
+	return &vzapi.CertificatesStatus{Expiry: expiry, CARotation: rotation}, requeueAfter, nil
+}
+
+// getCertificatesExpiry returns the expiry of the Verrazzano CA, when Verrazzano is configured with a CA, and of each
+// certificate issued by the ClusterIssuer of Verrazzano, sorted by namespace and secret name
+func getCertificatesExpiry(ctx spi.ComponentContext) ([]vzapi.CertificateExpiryStatus, error) {
+	var expiry []vzapi.CertificateExpiryStatus
+	isCAValue, err := isCA(ctx)
+	if err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed to get the certificate configuration: %v", err)
+	}
+	if isCAValue {
+		caConfig := getCAConfig(ctx.EffectiveCR())
+		secret := v1.Secret{}
+		err := ctx.Client().Get(context.TODO(), crtclient.ObjectKey{Namespace: caConfig.ClusterResourceNamespace, Name: caConfig.SecretName}, &secret)
+		if crtclient.IgnoreNotFound(err) != nil {
+			return nil, ctx.Log().ErrorfNewErr("Failed to get the CA secret %s/%s: %v", caConfig.ClusterResourceNamespace, caConfig.SecretName, err)
+		}
+		if err == nil {
+			cert, err := parseCertSecret(&secret)
+			if err != nil {
+				return nil, ctx.Log().ErrorfNewErr("Failed to parse the CA certificate of the secret %s/%s: %v", secret.Namespace, secret.Name, err)
+			}
+			expiry = append(expiry, vzapi.CertificateExpiryStatus{
+				Namespace:  secret.Namespace,
+				SecretName: secret.Name,
+				CA:         true,
+				NotAfter:   cert.NotAfter.UTC().Format(time.RFC3339),
+			})
+		}
+	}
+
+	certList := certv1.CertificateList{}
+	if err := ctx.Client().List(context.TODO(), &certList); err != nil {
+		// The cert-manager API is not available
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return expiry, nil
+		}
+		return nil, ctx.Log().ErrorfNewErr("Failed to list the certificates: %v", err)
+	}
+	issuerName := vzconfig.GetClusterIssuerName(ctx.EffectiveCR())
+	for _, cert := range certList.Items {
+		if cert.Name == caCertificateName || cert.Spec.IssuerRef.Name != issuerName || cert.Status.NotAfter == nil {
+			continue
+		}
+		expiry = append(expiry, vzapi.CertificateExpiryStatus{
+			Namespace:  cert.Namespace,
+			SecretName: cert.Spec.SecretName,
+			NotAfter:   cert.Status.NotAfter.UTC().Format(time.RFC3339),
+		})
+	}
+	sort.SliceStable(expiry, func(i, j int) bool {
+		if expiry[i].Namespace != expiry[j].Namespace {
+			return expiry[i].Namespace < expiry[j].Namespace
+		}
+		return expiry[i].SecretName < expiry[j].SecretName
+	})
+	return expiry, nil
+}
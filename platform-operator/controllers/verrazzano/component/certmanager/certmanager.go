@@ -14,9 +14,12 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"io"
 	"k8s.io/apimachinery/pkg/runtime"
+	"net"
 	"net/mail"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -34,12 +37,15 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/security/password"
 	vzstring "github.com/verrazzano/verrazzano/pkg/string"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/externaldns"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,7 +61,7 @@ const (
 	caSelfSignedIssuerName      = "verrazzano-selfsigned-issuer"
 	caCertificateName           = "verrazzano-ca-certificate"
 	caCertCommonName            = "verrazzano-root-ca"
-	verrazzanoClusterIssuerName = "verrazzano-cluster-issuer"
+	verrazzanoClusterIssuerName = constants.VerrazzanoClusterIssuerName
 	clusterResourceNamespaceKey = "clusterResourceNamespace"
 
 	crdDirectory  = "/cert-manager/"
@@ -65,6 +71,11 @@ const (
 	// ACME-related constants
 	defaultCACertificateSecretName = "verrazzano-ca-certificate-secret" //nolint:gosec //#gosec G101
 	caAcmeSecretName               = "verrazzano-cert-acme-secret"      //nolint:gosec //#gosec G101
+	caAcmeEABSecretName            = "verrazzano-cert-acme-eab-secret"  //nolint:gosec //#gosec G101
+	acmeEABSecretKey               = "secret"
+
+	// ingress-shim overrides to issue the ingress certificates from an existing ClusterIssuer
+	defaultIssuerNameKey = "ingressShim.defaultIssuerName"
 
 	// Valid Let's Encrypt environment values
 	letsencryptProduction    = "production"
@@ -128,14 +139,36 @@ spec:
     preferredChain: ""
     privateKeySecretRef:
       name: {{.AcmeSecretName}}
+{{- if .EABKeyID }}
+    externalAccountBinding:
+      keyID: {{.EABKeyID}}
+      keySecretRef:
+        name: {{.EABSecretName}}
+        key: secret
+{{- end }}
     solvers:
+{{- if .OCIZoneName }}
       - dns01:
           ocidns:
             useInstancePrincipals: {{ .UseInstancePrincipals}}
             serviceAccountSecretRef:
               name: {{.SecretName}}
               key: "oci.yaml"
-            ocizonename: {{.OCIZoneName}}`
+            ocizonename: {{.OCIZoneName}}
+{{- else if .RFC2136Nameserver }}
+      - dns01:
+          rfc2136:
+            nameserver: "{{.RFC2136Nameserver}}"
+            tsigKeyName: {{.RFC2136TSIGKeyName}}
+            tsigAlgorithm: {{.RFC2136TSIGAlgorithm}}
+            tsigSecretSecretRef:
+              name: {{.SecretName}}
+              key: {{.RFC2136SecretKey}}
+{{- else }}
+      - http01:
+          ingress:
+            class: {{.IngressClassName}}
+{{- end }}`
 
 const snippetSubstring = "rfc2136:\n"
 
@@ -177,6 +210,13 @@ type templateData struct {
 	SecretName            string
 	OCIZoneName           string
 	UseInstancePrincipals bool
+	IngressClassName      string
+	EABKeyID              string
+	EABSecretName         string
+	RFC2136Nameserver     string
+	RFC2136TSIGKeyName    string
+	RFC2136TSIGAlgorithm  string
+	RFC2136SecretKey      string
 }
 
 // CertIssuerType identifies the certificate issuer type
@@ -204,7 +244,8 @@ func GetCertManagerClientset() (certv1client.CertmanagerV1Interface, error) {
 }
 
 // checkRenewAllCertificates Update the status field for each certificate generated by the Verrazzano ClusterIssuer
-func checkRenewAllCertificates(compContext spi.ComponentContext, isCAConfig bool) error {
+// - when the issuer CN is not known, the certificates are renewed only if the ClusterIssuer was updated
+func checkRenewAllCertificates(compContext spi.ComponentContext, isCAConfig bool, issuerUpdated bool) error {
 	cli := compContext.Client()
 	log := compContext.Log()
 
@@ -226,6 +267,9 @@ func checkRenewAllCertificates(compContext spi.ComponentContext, isCAConfig bool
 	if err != nil {
 		return err
 	}
+	if len(issuerCNs) == 0 && !issuerUpdated {
+		return nil
+	}
 	// Compare the Issuer CN of all leaf certs in the system with the currently configured Issuer CN
 	if err := updateCerts(ctx, log, cmClient, issuerCNs, certList); err != nil {
 		return err
@@ -244,6 +288,13 @@ func updateCerts(ctx context.Context, log vzlog.VerrazzanoLogger, cmClient certv
 			log.Oncef("Certificate %s/%s not issued by the Verrazzano cluster issuer, skipping", currentCert.Namespace, currentCert.Name)
 			continue
 		}
+		if len(issuerCNs) == 0 {
+			// The issuer CN is not known, renew the certificate from the updated ClusterIssuer
+			if err := renewCertificate(ctx, cmClient, log, &certList.Items[index]); err != nil {
+				return err
+			}
+			continue
+		}
 		// Get the common name from the cert and update if it doesn't match the issuer CN
 		certIssuerCN, err := getCertIssuerCommonName(currentCert)
 		if err != nil {
@@ -387,6 +438,10 @@ func AppendOverrides(compContext spi.ComponentContext, _ string, _ string, _ str
 		ns := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.CA.ClusterResourceNamespace
 		kvs = append(kvs, bom.KeyValue{Key: clusterResourceNamespaceKey, Value: ns})
 	}
+	// Issue the ingress certificates from the referenced ClusterIssuer
+	if isIssuerRef(compContext) {
+		kvs = append(kvs, bom.KeyValue{Key: defaultIssuerNameKey, Value: vzconfig.GetClusterIssuerName(compContext.EffectiveCR())})
+	}
 	return kvs, nil
 }
 
@@ -492,12 +547,19 @@ func validateConfiguration(comp *v1beta1.CertManagerComponent) (isCA bool, err e
 		// Is default CA configuration
 		return true, nil
 	}
-	// Check if Ca or Acme is empty
+	// Check if Ca, Acme or IssuerRef is empty
 	caNotEmpty := comp.Certificate.CA != v1beta1.CA{}
 	acmeNotEmpty := comp.Certificate.Acme != v1beta1.Acme{}
+	issuerRefNotEmpty := comp.Certificate.IssuerRef != v1beta1.IssuerRef{}
 	if caNotEmpty && acmeNotEmpty {
 		return false, errors.New("Certificate object Acme and CA cannot be simultaneously populated")
 	}
+	if issuerRefNotEmpty && (caNotEmpty || acmeNotEmpty) {
+		return false, errors.New("Certificate object IssuerRef cannot be populated with Acme or CA")
+	}
+	if issuerRefNotEmpty {
+		return false, validateIssuerRef(comp.Certificate.IssuerRef)
+	}
 	if caNotEmpty {
 		if err := validateCAConfiguration(comp.Certificate.CA); err != nil {
 			return true, err
@@ -509,7 +571,18 @@ func validateConfiguration(comp *v1beta1.CertManagerComponent) (isCA bool, err e
 		}
 		return false, nil
 	}
-	return false, errors.New("Either Acme or CA certificate authorities must be configured")
+	return false, errors.New("Either Acme, CA or IssuerRef certificate authorities must be configured")
+}
+
+// validateIssuerRef Validate the reference to an existing ClusterIssuer
+func validateIssuerRef(issuerRef v1beta1.IssuerRef) error {
+	if errs := validation.IsDNS1123Subdomain(issuerRef.Name); len(errs) > 0 {
+		return fmt.Errorf("Invalid ClusterIssuer name %s: %s", issuerRef.Name, strings.Join(errs, ", "))
+	}
+	if issuerRef.Name == verrazzanoClusterIssuerName {
+		return fmt.Errorf("The ClusterIssuer %s is managed by Verrazzano and cannot be referenced", verrazzanoClusterIssuerName)
+	}
+	return nil
 }
 
 func validateCAConfiguration(ca v1beta1.CA) error {
@@ -538,14 +611,53 @@ func getSecret(namespace string, name string) (*v1.Secret, error) {
 
 //validateAcmeConfiguration Validate the ACME/LetsEncrypt values
 func validateAcmeConfiguration(acme v1beta1.Acme) error {
-	if !isLetsEncryptProvider(acme) {
+	if isGenericProvider(acme) {
+		if err := validateAcmeServer(acme.Server); err != nil {
+			return err
+		}
+	} else if !isLetsEncryptProvider(acme) {
 		return fmt.Errorf("Invalid ACME certificate provider %v", acme.Provider)
+	} else if len(acme.Server) > 0 {
+		return fmt.Errorf("The ACME server can only be set for the %s provider", vzapi.Generic)
 	}
 	if len(acme.Environment) > 0 && !isLetsEncryptProductionEnv(acme) && !isLetsEncryptStagingEnv(acme) {
 		return fmt.Errorf("Invalid Let's Encrypt environment: %s", acme.Environment)
 	}
-	if _, err := mail.ParseAddress(acme.EmailAddress); err != nil {
-		return err
+	// The email address is optional for the private ACME servers
+	if isLetsEncryptProvider(acme) || len(acme.EmailAddress) > 0 {
+		if _, err := mail.ParseAddress(acme.EmailAddress); err != nil {
+			return err
+		}
+	}
+	if (acme.ExternalAccountBinding != v1beta1.ExternalAccountBinding{}) {
+		return validateExternalAccountBinding(acme.ExternalAccountBinding)
+	}
+	return nil
+}
+
+// validateAcmeServer Validate the URL of the ACME directory
+func validateAcmeServer(server string) error {
+	if len(server) == 0 {
+		return fmt.Errorf("The ACME server is required by the %s provider", vzapi.Generic)
+	}
+	serverURL, err := url.Parse(server)
+	if err != nil || serverURL.Scheme != "https" || len(serverURL.Host) == 0 {
+		return fmt.Errorf("Invalid ACME server %s, it must be an https URL", server)
+	}
+	return nil
+}
+
+// validateExternalAccountBinding Validate the external account binding and its secret
+func validateExternalAccountBinding(eab v1beta1.ExternalAccountBinding) error {
+	if len(eab.KeyID) == 0 || len(eab.SecretName) == 0 {
+		return errors.New("The ACME external account binding requires a key ID and a secret name")
+	}
+	secret, err := getSecret(constants.VerrazzanoInstallNamespace, eab.SecretName)
+	if err != nil {
+		return fmt.Errorf("Failed to get the ACME external account binding secret %s/%s: %v", constants.VerrazzanoInstallNamespace, eab.SecretName, err)
+	}
+	if len(secret.Data[acmeEABSecretKey]) == 0 {
+		return fmt.Errorf("The ACME external account binding secret %s/%s does not contain the %s key", constants.VerrazzanoInstallNamespace, eab.SecretName, acmeEABSecretKey)
 	}
 	return nil
 }
@@ -554,6 +666,15 @@ func isLetsEncryptProvider(acme v1beta1.Acme) bool {
 	return strings.ToLower(string(acme.Provider)) == strings.ToLower(string(vzapi.LetsEncrypt))
 }
 
+func isGenericProvider(acme v1beta1.Acme) bool {
+	return strings.ToLower(string(acme.Provider)) == strings.ToLower(string(vzapi.Generic))
+}
+
+// isIssuerRef Check if the certificates are issued by an existing ClusterIssuer
+func isIssuerRef(compContext spi.ComponentContext) bool {
+	return vzconfig.GetClusterIssuerName(compContext.EffectiveCR()) != verrazzanoClusterIssuerName
+}
+
 func isLetsEncryptStagingEnv(acme v1beta1.Acme) bool {
 	return strings.ToLower(acme.Environment) == letsEncryptStaging
 }
@@ -573,13 +694,17 @@ func isLetsEncryptStaging(compContext spi.ComponentContext) bool {
 // - returns OperationResultUpdated/nil if the CI is updated
 func createOrUpdateAcmeResources(compContext spi.ComponentContext) (opResult controllerutil.OperationResult, err error) {
 	opResult = controllerutil.OperationResultNone
+	// Copy the external account binding secret to the cluster resource namespace
+	if err := copyExternalAccountBindingSecret(compContext); err != nil {
+		return opResult, err
+	}
 	// Create a lookup object
 	getCIObject, err := createAcmeCusterIssuerLookupObject(compContext.Log())
 	if err != nil {
 		return opResult, err
 	}
 	// Update or create the unstructured object
-	compContext.Log().Debug("Applying ClusterIssuer with OCI DNS")
+	compContext.Log().Debug("Applying ACME ClusterIssuer")
 	if opResult, err = controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), getCIObject, func() error {
 		ciObject, err := createACMEIssuerObject(compContext)
 		if err != nil {
@@ -593,27 +718,42 @@ func createOrUpdateAcmeResources(compContext spi.ComponentContext) (opResult con
 	return opResult, nil
 }
 
-func createACMEIssuerObject(compContext spi.ComponentContext) (*unstructured.Unstructured, error) {
-	// Initialize Acme variables for the cluster issuer
-	var ociDNSConfigSecret string
-	var ociDNSZoneName string
-	vzDNS := compContext.EffectiveCR().Spec.Components.DNS
-	vzCertAcme := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Acme
-	if vzDNS != nil && vzDNS.OCI != nil {
-		ociDNSConfigSecret = vzDNS.OCI.OCIConfigSecret
-		ociDNSZoneName = vzDNS.OCI.DNSZoneName
+// copyExternalAccountBindingSecret Copy the ACME external account binding secret from the verrazzano-install namespace
+func copyExternalAccountBindingSecret(compContext spi.ComponentContext) error {
+	eab := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Acme.ExternalAccountBinding
+	if len(eab.SecretName) == 0 {
+		return nil
 	}
-	// Verify that the secret exists
-	secret := v1.Secret{}
-	if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: ociDNSConfigSecret, Namespace: ComponentNamespace}, &secret); err != nil {
-		return nil, compContext.Log().ErrorfNewErr("Failed to retrieve the OCI DNS config secret: %v", err)
+	eabSecret := v1.Secret{}
+	if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: eab.SecretName, Namespace: constants.VerrazzanoInstallNamespace}, &eabSecret); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to retrieve the ACME external account binding secret %s/%s: %v", constants.VerrazzanoInstallNamespace, eab.SecretName, err)
 	}
+	secret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caAcmeEABSecretName,
+			Namespace: ComponentNamespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), &secret, func() error {
+		secret.Data = map[string][]byte{
+			acmeEABSecretKey: eabSecret.Data[acmeEABSecretKey],
+		}
+		return nil
+	}); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to create or update the ACME external account binding secret: %v", err)
+	}
+	return nil
+}
 
-	emailAddress := vzCertAcme.EmailAddress
+func createACMEIssuerObject(compContext spi.ComponentContext) (*unstructured.Unstructured, error) {
+	// Initialize Acme variables for the cluster issuer
+	vzCertAcme := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Acme
 
 	// Verify the acme environment and set the server
 	acmeServer := letsEncryptProdEndpoint
-	if isLetsEncryptStaging(compContext) {
+	if len(vzCertAcme.Server) > 0 {
+		acmeServer = vzCertAcme.Server
+	} else if isLetsEncryptStaging(compContext) {
 		acmeServer = letsEncryptStageEndpoint
 	}
 
@@ -621,10 +761,38 @@ func createACMEIssuerObject(compContext spi.ComponentContext) (*unstructured.Uns
 	clusterIssuerData := templateData{
 		ClusterIssuerName: verrazzanoClusterIssuerName,
 		AcmeSecretName:    caAcmeSecretName,
-		Email:             emailAddress,
+		Email:             vzCertAcme.EmailAddress,
 		Server:            acmeServer,
-		SecretName:        ociDNSConfigSecret,
-		OCIZoneName:       ociDNSZoneName,
+		IngressClassName:  vzconfig.GetIngressClassName(compContext.EffectiveCR()),
+	}
+	if len(vzCertAcme.ExternalAccountBinding.KeyID) > 0 {
+		clusterIssuerData.EABKeyID = vzCertAcme.ExternalAccountBinding.KeyID
+		clusterIssuerData.EABSecretName = caAcmeEABSecretName
+	}
+
+	// The DNS01 challenges are solved with OCI DNS or RFC2136 DNS, the HTTP01 challenges are used when no DNS provider
+	// is configured
+	vzDNS := compContext.EffectiveCR().Spec.Components.DNS
+	if vzDNS != nil && vzDNS.RFC2136 != nil {
+		// The TSIG secret is the copy created by external-dns in the cert-manager namespace
+		rfc2136 := vzDNS.RFC2136
+		clusterIssuerData.SecretName = rfc2136.TSIGSecret
+		clusterIssuerData.RFC2136Nameserver = net.JoinHostPort(rfc2136.Host, strconv.Itoa(externaldns.GetRFC2136Port(rfc2136)))
+		clusterIssuerData.RFC2136TSIGKeyName = rfc2136.TSIGKeyName
+		clusterIssuerData.RFC2136TSIGAlgorithm = getRFC2136TSIGAlgorithm(rfc2136)
+		clusterIssuerData.RFC2136SecretKey = externaldns.RFC2136SecretKey
+		return createAcmeClusterIssuer(compContext.Log(), clusterIssuerData)
+	}
+	if vzDNS == nil || vzDNS.OCI == nil {
+		return createAcmeClusterIssuer(compContext.Log(), clusterIssuerData)
+	}
+	clusterIssuerData.SecretName = vzDNS.OCI.OCIConfigSecret
+	clusterIssuerData.OCIZoneName = vzDNS.OCI.DNSZoneName
+
+	// Verify that the secret exists
+	secret := v1.Secret{}
+	if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: clusterIssuerData.SecretName, Namespace: ComponentNamespace}, &secret); err != nil {
+		return nil, compContext.Log().ErrorfNewErr("Failed to retrieve the OCI DNS config secret: %v", err)
 	}
 
 	for key := range secret.Data {
@@ -642,6 +810,11 @@ func createACMEIssuerObject(compContext spi.ComponentContext) (*unstructured.Uns
 	return ciObject, err
 }
 
+// getRFC2136TSIGAlgorithm returns the cert-manager name of the TSIG algorithm, for example HMACSHA256 for hmac-sha256
+func getRFC2136TSIGAlgorithm(rfc2136 *vzapi.RFC2136) string {
+	return strings.ToUpper(strings.ReplaceAll(externaldns.GetRFC2136TSIGAlgorithm(rfc2136), "-", ""))
+}
+
 func createAcmeClusterIssuer(log vzlog.VerrazzanoLogger, clusterIssuerData templateData) (*unstructured.Unstructured, error) {
 	var buff bytes.Buffer
 	// Parse the template string and create the template object
@@ -766,7 +939,11 @@ func findIssuerCommonName(certificate v1beta1.Certificate, isCAValue bool) ([]st
 }
 
 //getACMEIssuerName Let's encrypt certificates are published, and the intermediate signing CA CNs are well-known
+// - the CNs of the private ACME servers are not known, an empty list is returned for those
 func getACMEIssuerName(acme v1beta1.Acme) ([]string, error) {
+	if isGenericProvider(acme) {
+		return []string{}, nil
+	}
 	if isLetsEncryptProductionEnv(acme) {
 		return letsEncryptProductionCACommonNames, nil
 	}
@@ -783,9 +960,18 @@ func extractCACommonName(ca v1beta1.CA) ([]string, error) {
 }
 
 func extractCommonNameFromCertSecret(secret *v1.Secret) (string, error) {
+	cert, err := parseCertSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return cert.Issuer.CommonName, nil
+}
+
+// parseCertSecret returns the first certificate of the tls.crt data of a secret
+func parseCertSecret(secret *v1.Secret) (*x509.Certificate, error) {
 	certBytes, found := secret.Data[v1.TLSCertKey]
 	if !found {
-		return "", fmt.Errorf("No Certificate data found in secret %s/%s", secret.Namespace, secret.Name)
+		return nil, fmt.Errorf("No Certificate data found in secret %s/%s", secret.Namespace, secret.Name)
 	}
 	leafCACertBytes := []byte{}
 	for {
@@ -800,11 +986,7 @@ func extractCommonNameFromCertSecret(secret *v1.Secret) (string, error) {
 		}
 		certBytes = rest
 	}
-	cert, err := x509.ParseCertificate(leafCACertBytes)
-	if err != nil {
-		return "", err
-	}
-	return cert.Issuer.CommonName, nil
+	return x509.ParseCertificate(leafCACertBytes)
 }
 
 func cleanupUnusedResources(compContext spi.ComponentContext, isCAValue bool) error {
@@ -814,13 +996,27 @@ func cleanupUnusedResources(compContext spi.ComponentContext, isCAValue bool) er
 	}
 	client := compContext.Client()
 	log := compContext.Log()
-	if isCAValue {
+	issuerRef := isIssuerRef(compContext)
+	if isCAValue || issuerRef {
 		log.Oncef("Clean up ACME issuer secret")
 		// clean up ACME secret if present
 		if err := deleteObject(client, caAcmeSecretName, ComponentNamespace, &v1.Secret{}); err != nil {
 			return err
 		}
 	}
+	if isCAValue || issuerRef || len(compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Acme.ExternalAccountBinding.SecretName) == 0 {
+		// clean up the copy of the ACME external account binding secret if present
+		if err := deleteObject(client, caAcmeEABSecretName, ComponentNamespace, &v1.Secret{}); err != nil {
+			return err
+		}
+	}
+	if issuerRef {
+		// The certificates are issued by an existing ClusterIssuer, clean up the Verrazzano ClusterIssuer
+		log.Oncef("Clean up the Verrazzano ClusterIssuer")
+		if err := deleteObject(client, verrazzanoClusterIssuerName, "", &certv1.ClusterIssuer{}); err != nil {
+			return err
+		}
+	}
 	if defaultCANotUsed() {
 		// Issuer is either the default or Custom issuer; clean up the default Verrazzano issuer resources
 		// - self-signed Issuer object
@@ -909,16 +1105,18 @@ func uninstallCertManager(compContext spi.ComponentContext) error {
 		return err
 	}
 
-	// Delete the ACME secret if present
-	err = vzresource.Resource{
-		Name:      caAcmeSecretName,
-		Namespace: ComponentNamespace,
-		Client:    compContext.Client(),
-		Object:    &v1.Secret{},
-		Log:       compContext.Log(),
-	}.Delete()
-	if err != nil {
-		return err
+	// Delete the ACME secrets if present
+	for _, name := range []string{caAcmeSecretName, caAcmeEABSecretName} {
+		err = vzresource.Resource{
+			Name:      name,
+			Namespace: ComponentNamespace,
+			Client:    compContext.Client(),
+			Object:    &v1.Secret{},
+			Log:       compContext.Log(),
+		}.Delete()
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -946,3 +1144,18 @@ func GetOverrides(object runtime.Object) interface{} {
 	}
 	return []v1beta1.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.CertManager != nil {
+			return effectiveCR.Spec.Components.CertManager.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*v1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.CertManager != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.CertManager.Workload)
+		}
+	}
+	return nil
+}
@@ -40,6 +40,13 @@ type certManagerComponent struct {
 // Verify that certManagerComponent implements Component
 var _ spi.Component = certManagerComponent{}
 
+// podDisruptionBudgets are the replicated pods of the controller, the webhook and the CA injector of cert-manager
+var podDisruptionBudgets = []spi.PodDisruptionBudget{
+	{Name: "cert-manager", PodLabels: map[string]string{"app": "cert-manager"}},
+	{Name: "cert-manager-webhook", PodLabels: map[string]string{"app": "webhook"}},
+	{Name: "cert-manager-cainjector", PodLabels: map[string]string{"app": "cainjector"}},
+}
+
 // NewComponent returns a new CertManager component
 func NewComponent() spi.Component {
 	return certManagerComponent{
@@ -57,6 +64,15 @@ func NewComponent() spi.Component {
 			MinVerrazzanoVersion:      constants.VerrazzanoVersion1_0_0,
 			Dependencies:              []string{},
 			GetInstallOverridesFunc:   GetOverrides,
+			PodDisruptionBudgets:      podDisruptionBudgets,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				ReplicasKey:          "replicaCount",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "global.priorityClassName",
+			},
 		},
 	}
 }
@@ -103,6 +119,9 @@ func (c certManagerComponent) ValidateInstallV1Beta1(vz *v1beta1.Verrazzano) err
 		if _, err := validateConfiguration(vz.Spec.Components.CertManager); err != nil {
 			return err
 		}
+		if err := validateCARotation(vz.Spec.Components.CertManager); err != nil {
+			return err
+		}
 	}
 	return c.HelmComponent.ValidateInstallV1Beta1(vz)
 }
@@ -116,6 +135,9 @@ func (c certManagerComponent) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new
 	if _, err := validateConfiguration(new.Spec.Components.CertManager); err != nil {
 		return err
 	}
+	if err := validateCARotation(new.Spec.Components.CertManager); err != nil {
+		return err
+	}
 	return c.HelmComponent.ValidateUpdateV1Beta1(old, new)
 }
 
@@ -191,6 +213,10 @@ func (c certManagerComponent) createOrUpdateClusterIssuer(compContext spi.Compon
 	if err != nil {
 		return compContext.Log().ErrorfNewErr("Failed to verify the config type: %v", err)
 	}
+	if isIssuerRef(compContext) {
+		// The certificates are issued by an existing ClusterIssuer, cert-manager renews them when their issuer is changed
+		return cleanupUnusedResources(compContext, isCAValue)
+	}
 	var opResult controllerutil.OperationResult
 	if !isCAValue {
 		// Create resources needed for Acme certificates
@@ -216,7 +242,7 @@ func (c certManagerComponent) createOrUpdateClusterIssuer(compContext spi.Compon
 	if err := cleanupUnusedResources(compContext, isCAValue); err != nil {
 		return err
 	}
-	if err := checkRenewAllCertificates(compContext, isCAValue); err != nil {
+	if err := checkRenewAllCertificates(compContext, isCAValue, opResult == controllerutil.OperationResultUpdated); err != nil {
 		compContext.Log().Errorf("Error requesting certificate renewal: %s", err.Error())
 		return err
 	}
@@ -16,9 +16,11 @@ import (
 	certv1fake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
 	certv1client "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/externaldns"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -38,8 +40,11 @@ import (
 )
 
 const (
-	testDNSDomain  = "example.dns.io"
-	testOCIDNSName = "ociDNS"
+	testDNSDomain     = "example.dns.io"
+	testOCIDNSName    = "ociDNS"
+	testAcmeServer    = "https://step-ca.example.com/acme/acme/directory"
+	testEABSecretName = "acme-eab"
+	testIssuerName    = "vault-issuer"
 )
 
 // TestValidateUpdate tests the ValidateUpdate function
@@ -258,6 +263,81 @@ func runAcmeUpdateTest(t *testing.T, upgrade bool) {
 
 }
 
+// TestPostInstallGenericAcme tests the PostInstall function
+// GIVEN a call to PostInstall
+//  WHEN the cert type is Acme with a private ACME server, an external account binding and no DNS provider
+//  THEN the external account binding secret is copied and the ClusterIssuer uses the HTTP01 challenges
+func TestPostInstallGenericAcme(t *testing.T) {
+	vz := getGenericAcmeCR(testAcmeServer, vzapi.ExternalAccountBinding{KeyID: "kid", SecretName: testEABSecretName})
+	eabSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: testEABSecretName, Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{acmeEABSecretKey: []byte("hmac")},
+	}
+	defer func() { getClientFunc = k8sutil.GetCoreV1Client }()
+	getClientFunc = func(...vzlog.VerrazzanoLogger) (v1.CoreV1Interface, error) {
+		return createFakeClient(eabSecret).CoreV1(), nil
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(eabSecret).Build()
+	assert.NoError(t, fakeComponent.PostInstall(spi.NewFakeContext(client, vz, nil, false)))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: caAcmeEABSecretName, Namespace: ComponentNamespace}, secret))
+	assert.Equal(t, []byte("hmac"), secret.Data[acmeEABSecretKey])
+
+	issuer := &certv1.ClusterIssuer{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: verrazzanoClusterIssuerName}, issuer))
+	assert.Equal(t, testAcmeServer, issuer.Spec.ACME.Server)
+	assert.Equal(t, "kid", issuer.Spec.ACME.ExternalAccountBinding.KeyID)
+	assert.Equal(t, caAcmeEABSecretName, issuer.Spec.ACME.ExternalAccountBinding.Key.Name)
+	assert.Len(t, issuer.Spec.ACME.Solvers, 1)
+	assert.Nil(t, issuer.Spec.ACME.Solvers[0].DNS01)
+	assert.Equal(t, "verrazzano-nginx", *issuer.Spec.ACME.Solvers[0].HTTP01.Ingress.Class)
+}
+
+// TestPostInstallRFC2136DNS tests the PostInstall function
+// GIVEN a call to PostInstall
+//  WHEN an ACME issuer is configured with RFC2136 DNS
+//  THEN the ClusterIssuer solves the DNS01 challenges on the RFC2136 name server with the TSIG secret
+func TestPostInstallRFC2136DNS(t *testing.T) {
+	vz := getGenericAcmeCR(testAcmeServer, vzapi.ExternalAccountBinding{})
+	vz.Spec.Components.DNS = &vzapi.DNSComponent{
+		RFC2136: &vzapi.RFC2136{
+			Host:          "10.0.0.53",
+			DNSZoneName:   testDNSDomain,
+			TSIGKeyName:   "externaldns-key",
+			TSIGAlgorithm: "hmac-sha512",
+			TSIGSecret:    "tsig",
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	assert.NoError(t, fakeComponent.PostInstall(spi.NewFakeContext(client, vz, nil, false)))
+
+	issuer := &certv1.ClusterIssuer{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: verrazzanoClusterIssuerName}, issuer))
+	assert.Len(t, issuer.Spec.ACME.Solvers, 1)
+	rfc2136 := issuer.Spec.ACME.Solvers[0].DNS01.RFC2136
+	assert.NotNil(t, rfc2136)
+	assert.Equal(t, "10.0.0.53:53", rfc2136.Nameserver)
+	assert.Equal(t, "externaldns-key", rfc2136.TSIGKeyName)
+	assert.Equal(t, "HMACSHA512", rfc2136.TSIGAlgorithm)
+	assert.Equal(t, "tsig", rfc2136.TSIGSecret.Name)
+	assert.Equal(t, externaldns.RFC2136SecretKey, rfc2136.TSIGSecret.Key)
+}
+
+// TestPostInstallIssuerRef tests the PostInstall function
+// GIVEN a call to PostInstall
+//  WHEN the certificates are issued by an existing ClusterIssuer
+//  THEN the Verrazzano ClusterIssuer and the ACME resources are cleaned up
+func TestPostInstallIssuerRef(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(testScheme).
+		WithObjects(createACMEResources()...).
+		WithObjects(createClusterIssuerResources()...).
+		Build()
+	assert.NoError(t, fakeComponent.PostInstall(spi.NewFakeContext(client, getIssuerRefCR(testIssuerName), nil, false)))
+	assertNotFound(t, client, verrazzanoClusterIssuerName, "", &certv1.ClusterIssuer{})
+	assertNotFound(t, client, caAcmeSecretName, ComponentNamespace, &corev1.Secret{})
+}
+
 // TestClusterIssuerUpdated tests the createOrUpdateClusterIssuer function
 // GIVEN a call to createOrUpdateClusterIssuer
 // WHEN the ClusterIssuer is updated and there are existing certificates with failed and successful CertificateRequests
@@ -721,6 +801,84 @@ var tests = []validationTestStruct{
 		new:     getAcmeCR(vzapi.LetsEncrypt, "joeblow", letsEncryptStaging),
 		wantErr: true,
 	},
+	{
+		name:    "validGenericAcme",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(testAcmeServer, vzapi.ExternalAccountBinding{}),
+		wantErr: false,
+	},
+	{
+		name: "validGenericAcmeExternalAccountBinding",
+		old:  &vzapi.Verrazzano{},
+		new:  getGenericAcmeCR(testAcmeServer, vzapi.ExternalAccountBinding{KeyID: "kid", SecretName: testEABSecretName}),
+		caSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testEABSecretName, Namespace: constants.VerrazzanoInstallNamespace},
+			Data:       map[string][]byte{acmeEABSecretKey: []byte("hmac")},
+		},
+		wantErr: false,
+	},
+	{
+		name:    "invalidGenericAcmeExternalAccountBindingSecretNotFound",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(testAcmeServer, vzapi.ExternalAccountBinding{KeyID: "kid", SecretName: testEABSecretName}),
+		wantErr: true,
+	},
+	{
+		name:    "invalidGenericAcmeExternalAccountBindingNoKeyID",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(testAcmeServer, vzapi.ExternalAccountBinding{SecretName: testEABSecretName}),
+		wantErr: true,
+	},
+	{
+		name:    "invalidGenericAcmeNoServer",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR("", vzapi.ExternalAccountBinding{}),
+		wantErr: true,
+	},
+	{
+		name:    "invalidGenericAcmeHTTPServer",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR("http://step-ca.example.com/acme/acme/directory", vzapi.ExternalAccountBinding{}),
+		wantErr: true,
+	},
+	{
+		name: "invalidLetsEncryptServer",
+		old:  &vzapi.Verrazzano{},
+		new: func() *vzapi.Verrazzano {
+			vz := getAcmeCR(vzapi.LetsEncrypt, emailAddress, letsEncryptStaging)
+			vz.Spec.Components.CertManager.Certificate.Acme.Server = testAcmeServer
+			return vz
+		}(),
+		wantErr: true,
+	},
+	{
+		name:    "validIssuerRef",
+		old:     &vzapi.Verrazzano{},
+		new:     getIssuerRefCR(testIssuerName),
+		wantErr: false,
+	},
+	{
+		name:    "invalidIssuerRefName",
+		old:     &vzapi.Verrazzano{},
+		new:     getIssuerRefCR("Vault_Issuer"),
+		wantErr: true,
+	},
+	{
+		name:    "invalidIssuerRefVerrazzanoIssuer",
+		old:     &vzapi.Verrazzano{},
+		new:     getIssuerRefCR(verrazzanoClusterIssuerName),
+		wantErr: true,
+	},
+	{
+		name: "invalidIssuerRefWithAcme",
+		old:  &vzapi.Verrazzano{},
+		new: func() *vzapi.Verrazzano {
+			vz := getAcmeCR(vzapi.LetsEncrypt, emailAddress, letsEncryptStaging)
+			vz.Spec.Components.CertManager.Certificate.IssuerRef = vzapi.IssuerRef{Name: testIssuerName}
+			return vz
+		}(),
+		wantErr: true,
+	},
 	{
 		name: "singleOverride",
 		new:  getSingleOverrideCR(),
@@ -806,6 +964,38 @@ func getAcmeCR(provider vzapi.ProviderType, emailAddr string, env string) *vzapi
 	}
 }
 
+func getGenericAcmeCR(server string, eab vzapi.ExternalAccountBinding) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				CertManager: &vzapi.CertManagerComponent{
+					Certificate: vzapi.Certificate{
+						Acme: vzapi.Acme{
+							Provider:               vzapi.Generic,
+							Server:                 server,
+							ExternalAccountBinding: eab,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getIssuerRefCR(name string) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				CertManager: &vzapi.CertManagerComponent{
+					Certificate: vzapi.Certificate{
+						IssuerRef: vzapi.IssuerRef{Name: name},
+					},
+				},
+			},
+		},
+	}
+}
+
 func getCaSecretCR() *vzapi.Verrazzano {
 	return &vzapi.Verrazzano{
 		Spec: vzapi.VerrazzanoSpec{
@@ -176,6 +176,19 @@ func TestAppendCertManagerOverridesWithInstallArgs(t *testing.T) {
 	assert.Contains(t, kvs, bom.KeyValue{Key: clusterResourceNamespaceKey, Value: testNamespace})
 }
 
+// TestAppendCertManagerOverridesWithIssuerRef tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+// WHEN a VZ spec is passed with a reference to an existing ClusterIssuer
+// THEN the ingress-shim default issuer is set to the referenced ClusterIssuer
+func TestAppendCertManagerOverridesWithIssuerRef(t *testing.T) {
+	localvz := defaultVZConfig.DeepCopy()
+	localvz.Spec.Components.CertManager.Certificate.IssuerRef = vzapi.IssuerRef{Name: "vault-issuer"}
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	assert.Len(t, kvs, 1)
+	assert.Contains(t, kvs, bom.KeyValue{Key: defaultIssuerNameKey, Value: "vault-issuer"})
+}
+
 // TestCertManagerPreInstall tests the PreInstall fn
 // GIVEN a call to this fn
 // WHEN I call PreInstall with dry-run = true
@@ -361,7 +374,7 @@ func TestCreateCAResources(t *testing.T) {
 func TestRenewAllCertificatesNoCertsPresent(t *testing.T) {
 	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
 	fakeContext := spi.NewFakeContext(client, defaultVZConfig, nil, false)
-	assert.NoError(t, checkRenewAllCertificates(fakeContext, true))
+	assert.NoError(t, checkRenewAllCertificates(fakeContext, true, false))
 }
 
 // TestDeleteObject tests the deleteObject function
@@ -40,3 +40,18 @@ func GetOverrides(object runtime.Object) interface{} {
 	}
 	return []v1beta1.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.CoherenceOperator != nil {
+			return effectiveCR.Spec.Components.CoherenceOperator.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*v1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.CoherenceOperator != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.CoherenceOperator.Workload)
+		}
+	}
+	return nil
+}
@@ -33,6 +33,16 @@ type coherenceComponent struct {
 	helm.HelmComponent
 }
 
+// networkPolicyRules are the network peers of the operator outside of the verrazzano-system namespace, the operator
+// serves its webhook and REST endpoint, and connects to the Coherence clusters of the application namespaces
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app.kubernetes.io/name": ComponentName},
+		Ingress:   []spi.NetworkPeer{{Ports: []int32{8000, 9443}}},
+		Egress:    []spi.NetworkPeer{{}},
+	},
+}
+
 func NewComponent() spi.Component {
 	return coherenceComponent{
 		helm.HelmComponent{
@@ -40,6 +50,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -47,6 +58,11 @@ func NewComponent() spi.Component {
 			ValuesFile:                filepath.Join(config.GetHelmOverridesDir(), "coherence-values.yaml"),
 			Dependencies:              []string{},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc: GetWorkload,
+				NodeSelectorKey: "nodeSelector",
+				TolerationsKey:  "tolerations",
+			},
 		},
 	}
 }
@@ -0,0 +1,182 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// OIDCDefaultUsernameClaim is the ID token claim used as the user name when none is configured
+	OIDCDefaultUsernameClaim = "preferred_username"
+	// OIDCDefaultGroupsClaim is the ID token claim holding the user groups when none is configured
+	OIDCDefaultGroupsClaim = "groups"
+
+	oidcDefaultAdminsGroup   = "verrazzano-admins"
+	oidcDefaultMonitorsGroup = "verrazzano-monitors"
+	oidcDiscoveryPath        = "/.well-known/openid-configuration"
+)
+
+// OIDCProviderMetadata is the subset of the OpenID Connect discovery document used by Verrazzano
+type OIDCProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint,omitempty"`
+}
+
+// GetExternalOIDC returns the external OIDC provider configured for the AuthProxy, or nil if Keycloak is used
+func GetExternalOIDC(vz *vzapi.Verrazzano) *vzapi.AuthProxyOIDC {
+	if vz == nil || vz.Spec.Components.AuthProxy == nil {
+		return nil
+	}
+	return vz.Spec.Components.AuthProxy.OIDC
+}
+
+// IsExternalOIDCSecret returns true if the Secret, in the namespace of the Verrazzano resource, holds the client secret
+// or the CA bundle of the external OIDC provider
+func IsExternalOIDCSecret(vz *vzapi.Verrazzano, name string) bool {
+	oidc := GetExternalOIDC(vz)
+	if oidc == nil {
+		return false
+	}
+	return oidc.ClientSecretRef.Name == name || (oidc.CABundleSecretRef != nil && oidc.CABundleSecretRef.Name == name)
+}
+
+// GetExternalOIDCSecretValue returns the value of a Secret key referenced by the external OIDC configuration, the
+// Secret is read from the namespace of the Verrazzano resource
+func GetExternalOIDCSecretValue(ctx spi.ComponentContext, selector corev1.SecretKeySelector) ([]byte, error) {
+	secret := &corev1.Secret{}
+	nsn := types.NamespacedName{Namespace: ctx.EffectiveCR().Namespace, Name: selector.Name}
+	if err := ctx.Client().Get(context.TODO(), nsn, secret); err != nil {
+		return nil, fmt.Errorf("Failed getting OIDC secret %s/%s: %v", nsn.Namespace, nsn.Name, err)
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok || len(value) == 0 {
+		return nil, fmt.Errorf("OIDC secret %s/%s does not contain the key %s", nsn.Namespace, nsn.Name, selector.Key)
+	}
+	return value, nil
+}
+
+// GetExternalOIDCAdminGroups returns the groups the OIDC admin groups are mapped to, these are the Group subjects
+// of the admin subjects or verrazzano-admins if no admin subjects are declared
+func GetExternalOIDCAdminGroups(vz *vzapi.Verrazzano) []string {
+	return subjectGroups(vz.Spec.Security.AdminSubjects, oidcDefaultAdminsGroup)
+}
+
+// GetExternalOIDCMonitorGroups returns the groups the OIDC monitor groups are mapped to, these are the Group subjects
+// of the monitor subjects or verrazzano-monitors if no monitor subjects are declared
+func GetExternalOIDCMonitorGroups(vz *vzapi.Verrazzano) []string {
+	return subjectGroups(vz.Spec.Security.MonitorSubjects, oidcDefaultMonitorsGroup)
+}
+
+// GetExternalOIDCGroupMappings returns the groups each provider group is mapped to, combining the declared group
+// mappings with the admin and monitor group mappings
+func GetExternalOIDCGroupMappings(vz *vzapi.Verrazzano) map[string][]string {
+	oidc := GetExternalOIDC(vz)
+	if oidc == nil {
+		return nil
+	}
+	mappings := map[string][]string{}
+	add := func(providerGroup string, groups ...string) {
+		for _, group := range groups {
+			if !vzstring.SliceContainsString(mappings[providerGroup], group) {
+				mappings[providerGroup] = append(mappings[providerGroup], group)
+			}
+		}
+	}
+	for _, mapping := range oidc.GroupMappings {
+		add(mapping.ProviderGroup, mapping.Groups...)
+	}
+	for _, providerGroup := range oidc.AdminGroups {
+		add(providerGroup, GetExternalOIDCAdminGroups(vz)...)
+	}
+	for _, providerGroup := range oidc.MonitorGroups {
+		add(providerGroup, GetExternalOIDCMonitorGroups(vz)...)
+	}
+	for _, groups := range mappings {
+		sort.Strings(groups)
+	}
+	return mappings
+}
+
+// DiscoverOIDCProvider fetches the discovery document of the external OIDC provider, verifying the provider with the
+// configured CA bundle if any
+func DiscoverOIDCProvider(ctx spi.ComponentContext, oidc *vzapi.AuthProxyOIDC) (*OIDCProviderMetadata, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if oidc.CABundleSecretRef != nil {
+		caBundle, err := GetExternalOIDCSecretValue(ctx, *oidc.CABundleSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("Failed parsing the OIDC CA bundle from secret %s", oidc.CABundleSecretRef.Name)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	hc := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}
+	discoveryURL := strings.TrimSuffix(oidc.IssuerURL, "/") + oidcDiscoveryPath
+	req, err := http.NewRequest(http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := HTTPDo(hc, req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed fetching OIDC discovery document %s: %v", discoveryURL, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed fetching OIDC discovery document %s, status code %d", discoveryURL, resp.StatusCode)
+	}
+	metadata := &OIDCProviderMetadata{}
+	if err := json.Unmarshal(body, metadata); err != nil {
+		return nil, fmt.Errorf("Failed parsing OIDC discovery document %s: %v", discoveryURL, err)
+	}
+	if metadata.Issuer != oidc.IssuerURL {
+		return nil, fmt.Errorf("OIDC discovery document issuer %s does not match the configured issuer %s", metadata.Issuer, oidc.IssuerURL)
+	}
+	if metadata.AuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery document %s has no authorization endpoint", discoveryURL)
+	}
+	return metadata, nil
+}
+
+// subjectGroups returns the names of the Group subjects, or the default group when no subjects are declared
+func subjectGroups(subjects []rbacv1.Subject, defaultGroup string) []string {
+	if len(subjects) == 0 {
+		return []string{defaultGroup}
+	}
+	var groups []string
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.GroupKind {
+			groups = append(groups, subject.Name)
+		}
+	}
+	return groups
+}
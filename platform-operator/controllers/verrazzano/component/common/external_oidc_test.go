@@ -0,0 +1,156 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newExternalOIDCTestCR(oidc *vzapi.AuthProxyOIDC) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				AuthProxy: &vzapi.AuthProxyComponent{OIDC: oidc},
+			},
+		},
+	}
+}
+
+// TestGetExternalOIDCGroupMappings tests the GetExternalOIDCGroupMappings function
+// GIVEN a Verrazzano CR configuring an external OIDC provider
+//  WHEN GetExternalOIDCGroupMappings is called
+//  THEN the declared mappings are combined with the admin and monitor group mappings
+func TestGetExternalOIDCGroupMappings(t *testing.T) {
+	asserts := assert.New(t)
+	asserts.Nil(GetExternalOIDCGroupMappings(&vzapi.Verrazzano{}))
+
+	vz := newExternalOIDCTestCR(&vzapi.AuthProxyOIDC{
+		GroupMappings: []vzapi.AuthProxyOIDCGroupMapping{
+			{ProviderGroup: "developers", Groups: []string{"app-developers", "verrazzano-users"}},
+			{ProviderGroup: "ops", Groups: []string{"verrazzano-users"}},
+		},
+		AdminGroups:   []string{"ops"},
+		MonitorGroups: []string{"ops", "auditors"},
+	})
+	asserts.Equal(map[string][]string{
+		"developers": {"app-developers", "verrazzano-users"},
+		"ops":        {"verrazzano-admins", "verrazzano-monitors", "verrazzano-users"},
+		"auditors":   {"verrazzano-monitors"},
+	}, GetExternalOIDCGroupMappings(vz))
+
+	// declared security subjects replace the default groups, only Group subjects are mapped
+	vz.Spec.Security.AdminSubjects = []rbacv1.Subject{
+		{Kind: rbacv1.GroupKind, Name: "platform-admins"},
+		{Kind: rbacv1.UserKind, Name: "admin"},
+	}
+	vz.Spec.Security.MonitorSubjects = []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "auditor"}}
+	asserts.Equal(map[string][]string{
+		"developers": {"app-developers", "verrazzano-users"},
+		"ops":        {"platform-admins", "verrazzano-users"},
+	}, GetExternalOIDCGroupMappings(vz))
+}
+
+// TestIsExternalOIDCSecret tests the IsExternalOIDCSecret function
+// GIVEN a Verrazzano CR configuring an external OIDC provider
+//  WHEN IsExternalOIDCSecret is called
+//  THEN true is returned for the Secrets holding the client secret and the CA bundle
+func TestIsExternalOIDCSecret(t *testing.T) {
+	asserts := assert.New(t)
+	asserts.False(IsExternalOIDCSecret(&vzapi.Verrazzano{}, "idp"))
+
+	vz := newExternalOIDCTestCR(&vzapi.AuthProxyOIDC{
+		ClientSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp"}, Key: "client-secret"},
+	})
+	asserts.True(IsExternalOIDCSecret(vz, "idp"))
+	asserts.False(IsExternalOIDCSecret(vz, "idp-ca"))
+
+	vz.Spec.Components.AuthProxy.OIDC.CABundleSecretRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp-ca"}, Key: "ca.crt"}
+	asserts.True(IsExternalOIDCSecret(vz, "idp-ca"))
+}
+
+// TestGetExternalOIDCSecretValue tests the GetExternalOIDCSecretValue function
+// GIVEN a Secret in the namespace of the Verrazzano CR
+//  WHEN GetExternalOIDCSecretValue is called
+//  THEN the value of the key is returned, or an error if the Secret or key does not exist
+func TestGetExternalOIDCSecretValue(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp", Namespace: "default"},
+		Data:       map[string][]byte{"client-secret": []byte("s3cret")},
+	}).Build()
+	ctx := spi.NewFakeContext(cli, newExternalOIDCTestCR(&vzapi.AuthProxyOIDC{}), nil, false)
+	selector := func(name, key string) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	value, err := GetExternalOIDCSecretValue(ctx, selector("idp", "client-secret"))
+	asserts.NoError(err)
+	asserts.Equal("s3cret", string(value))
+	_, err = GetExternalOIDCSecretValue(ctx, selector("idp", "missing"))
+	asserts.Error(err)
+	_, err = GetExternalOIDCSecretValue(ctx, selector("missing", "client-secret"))
+	asserts.Error(err)
+}
+
+// TestDiscoverOIDCProvider tests the DiscoverOIDCProvider function
+// GIVEN an external OIDC provider serving a discovery document over TLS
+//  WHEN DiscoverOIDCProvider is called with the provider CA bundle
+//  THEN the provider metadata is returned, or an error if the issuer does not match
+func TestDiscoverOIDCProvider(t *testing.T) {
+	asserts := assert.New(t)
+	issuer := ""
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != oidcDiscoveryPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(OIDCProviderMetadata{
+			Issuer:                issuer,
+			AuthorizationEndpoint: issuer + "/authorize",
+			TokenEndpoint:         issuer + "/token",
+			JWKSURI:               issuer + "/keys",
+		})
+	}))
+	defer srv.Close()
+	issuer = srv.URL
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	cli := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp", Namespace: "default"},
+		Data:       map[string][]byte{"ca.crt": caBundle},
+	}).Build()
+	oidc := &vzapi.AuthProxyOIDC{
+		IssuerURL:         srv.URL,
+		CABundleSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp"}, Key: "ca.crt"},
+	}
+	ctx := spi.NewFakeContext(cli, newExternalOIDCTestCR(oidc), nil, false)
+
+	metadata, err := DiscoverOIDCProvider(ctx, oidc)
+	asserts.NoError(err)
+	asserts.Equal(srv.URL+"/authorize", metadata.AuthorizationEndpoint)
+	asserts.Equal(srv.URL+"/token", metadata.TokenEndpoint)
+
+	// the provider is not trusted without the CA bundle
+	_, err = DiscoverOIDCProvider(ctx, &vzapi.AuthProxyOIDC{IssuerURL: srv.URL})
+	asserts.Error(err)
+
+	// the discovered issuer must match the configured issuer
+	issuer = "https://other.example.com"
+	_, err = DiscoverOIDCProvider(ctx, oidc)
+	asserts.Error(err)
+}
@@ -86,7 +86,7 @@ func CreateOrUpdateSystemComponentIngress(ctx spi.ComponentContext, props Ingres
 			ingress.Annotations = make(map[string]string)
 		}
 		ingress.Annotations["kubernetes.io/tls-acme"] = "true"
-		ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = "6M"
+		ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = vzconfig.GetSystemIngressBodySize(ctx.EffectiveCR())
 		ingress.Annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/$2"
 		ingress.Annotations["nginx.ingress.kubernetes.io/service-upstream"] = "true"
 		ingress.Annotations["nginx.ingress.kubernetes.io/upstream-vhost"] = "${service_name}.${namespace}.svc.cluster.local"
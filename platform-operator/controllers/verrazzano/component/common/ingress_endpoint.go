@@ -0,0 +1,107 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tlsCAKey is the key of the CA certificate in the TLS secret of an ingress
+const tlsCAKey = "ca.crt"
+
+// IngressEndpoint is a system ingress reached by the platform operator.  The platform operator is not in the mesh
+// and the system services only accept mutual TLS, so the requests are sent over TLS to the NGINX ingress controller
+// with the host of the ingress, and are authenticated by the service behind the ingress.
+type IngressEndpoint struct {
+	// URL is the base URL of the ingress controller service
+	URL string
+	// Host is sent as the Host header of each request, it is the host of the ingress
+	Host string
+	// Client is the HTTP client used to send the requests
+	Client *http.Client
+	// Username and Password are sent in the basic authentication header of each request when set
+	Username string
+	Password string
+}
+
+// GetIngressEndpoint returns the endpoint of the ingress host, the server certificate is verified with the CA of the
+// TLS secret of the ingress and the additional CA
+func GetIngressEndpoint(ctx spi.ComponentContext, host string, tlsSecret types.NamespacedName) (*IngressEndpoint, error) {
+	secret := &corev1.Secret{}
+	err := ctx.Client().Get(context.TODO(), tlsSecret, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed getting the TLS secret %s of the ingress host %s: %v", tlsSecret, host, err)
+	}
+	caCert := secret.Data[tlsCAKey]
+	additionalCA := GetAdditionalCA(ctx.Client())
+
+	tlsConfig := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(caCert) > 0 || len(additionalCA) > 0 {
+		tlsConfig.RootCAs = CertPool(caCert, additionalCA)
+	}
+	tr := &http.Transport{
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	return &IngressEndpoint{
+		URL:    fmt.Sprintf("https://%s.%s", constants.NGINXControllerServiceName, globalconst.IngressNamespace),
+		Host:   host,
+		Client: &http.Client{Transport: tr, Timeout: 30 * time.Second},
+	}, nil
+}
+
+// GetVMIIngressEndpoint returns the endpoint of the ingress of a system VMI component, such as OpenSearch or Grafana.
+// The ingress is served by the authproxy, the requests are authenticated with the credentials of the VMI secret.
+func GetVMIIngressEndpoint(ctx spi.ComponentContext, hostPrefix string, tlsSecretName string) (*IngressEndpoint, error) {
+	dnsSuffix, err := vzconfig.GetDNSSuffix(ctx.Client(), ctx.EffectiveCR())
+	if err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed getting the DNS suffix of the %s ingress: %v", hostPrefix, err)
+	}
+	host := fmt.Sprintf("%s.vmi.system.%s.%s", hostPrefix, vzconfig.GetEnvName(ctx.EffectiveCR()), dnsSuffix)
+	endpoint, err := GetIngressEndpoint(ctx, host, types.NamespacedName{Namespace: globalconst.VerrazzanoSystemNamespace, Name: tlsSecretName})
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: globalconst.VerrazzanoSystemNamespace, Name: constants.VMISecret}, secret); err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed getting the VMI secret %s/%s: %v", globalconst.VerrazzanoSystemNamespace, constants.VMISecret, err)
+	}
+	endpoint.Username = string(secret.Data["username"])
+	endpoint.Password = string(secret.Data["password"])
+	return endpoint, nil
+}
+
+// NewRequest returns a request for the path on the ingress host, the request is cancelled when the context is done
+func (e *IngressEndpoint) NewRequest(reqCtx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(reqCtx, method, strings.TrimSuffix(e.URL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if e.Host != "" {
+		req.Host = e.Host
+	}
+	if e.Username != "" {
+		req.SetBasicAuth(e.Username, e.Password)
+	}
+	return req, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testIngressHost = "grafana.vmi.system.default.11.22.33.44.nip.io"
+
+// TestGetIngressEndpoint tests the GetIngressEndpoint function
+// GIVEN the TLS secret of an ingress
+// WHEN GetIngressEndpoint is called
+// THEN an endpoint is returned that sends the requests over TLS to the ingress controller with the ingress host
+func TestGetIngressEndpoint(t *testing.T) {
+	tlsSecret := types.NamespacedName{Namespace: "verrazzano-system", Name: "system-tls-grafana"}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tlsSecret.Namespace, Name: tlsSecret.Name},
+		Data:       map[string][]byte{tlsCAKey: []byte("not a certificate")},
+	}
+	c := fake.NewClientBuilder().WithScheme(getScheme()).WithObjects(secret).Build()
+	endpoint, err := GetIngressEndpoint(spi.NewFakeContext(c, nil, nil, false), testIngressHost, tlsSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://ingress-controller-ingress-nginx-controller.ingress-nginx", endpoint.URL)
+	assert.Equal(t, testIngressHost, endpoint.Host)
+	tr, ok := endpoint.Client.Transport.(*http.Transport)
+	assert.True(t, ok)
+	assert.Equal(t, testIngressHost, tr.TLSClientConfig.ServerName)
+	assert.NotNil(t, tr.TLSClientConfig.RootCAs)
+	assert.False(t, tr.TLSClientConfig.InsecureSkipVerify)
+
+	req, err := endpoint.NewRequest(context.TODO(), http.MethodGet, "/api/health", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://ingress-controller-ingress-nginx-controller.ingress-nginx/api/health", req.URL.String())
+	assert.Equal(t, testIngressHost, req.Host)
+}
+
+// TestGetIngressEndpointNoSecret tests the GetIngressEndpoint function
+// GIVEN an ingress without a TLS secret
+// WHEN GetIngressEndpoint is called
+// THEN an endpoint is returned that verifies the server certificate with the system CAs
+func TestGetIngressEndpointNoSecret(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(getScheme()).Build()
+	endpoint, err := GetIngressEndpoint(spi.NewFakeContext(c, nil, nil, false), testIngressHost, types.NamespacedName{Namespace: "verrazzano-system", Name: "system-tls-grafana"})
+	assert.NoError(t, err)
+	tr, ok := endpoint.Client.Transport.(*http.Transport)
+	assert.True(t, ok)
+	assert.Nil(t, tr.TLSClientConfig.RootCAs)
+}
+
+// TestGetVMIIngressEndpoint tests the GetVMIIngressEndpoint function
+// GIVEN a Verrazzano install with the VMI secret
+// WHEN GetVMIIngressEndpoint is called
+// THEN an endpoint is returned for the VMI host of the component that authenticates with the VMI credentials
+func TestGetVMIIngressEndpoint(t *testing.T) {
+	nginxService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "ingress-controller-ingress-nginx-controller"},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "11.22.33.44"}}},
+		},
+	}
+	vmiSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "verrazzano-system", Name: "verrazzano"},
+		Data:       map[string][]byte{"username": []byte("verrazzano"), "password": []byte("changeme")},
+	}
+	vz := &vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{EnvironmentName: "default"}}
+	c := fake.NewClientBuilder().WithScheme(getScheme()).WithObjects(nginxService, vmiSecret).Build()
+	endpoint, err := GetVMIIngressEndpoint(spi.NewFakeContext(c, vz, nil, false), "grafana", "system-tls-grafana")
+	assert.NoError(t, err)
+	assert.Equal(t, testIngressHost, endpoint.Host)
+
+	req, err := endpoint.NewRequest(context.TODO(), http.MethodGet, "/api/health", nil)
+	assert.NoError(t, err)
+	username, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "verrazzano", username)
+	assert.Equal(t, "changeme", password)
+
+	// The VMI secret is needed to authenticate the requests
+	c = fake.NewClientBuilder().WithScheme(getScheme()).WithObjects(nginxService).Build()
+	_, err = GetVMIIngressEndpoint(spi.NewFakeContext(c, vz, nil, false), "grafana", "system-tls-grafana")
+	assert.Error(t, err)
+}
@@ -4,15 +4,23 @@
 package common
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
 
 	"github.com/Jeffail/gabs/v2"
+	"github.com/verrazzano/verrazzano/pkg/helm"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 
 	"k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -20,9 +28,60 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// OverridesTemplateError is returned when templated install overrides cannot be rendered
+type OverridesTemplateError struct {
+	Source string
+	Err    error
+}
+
+// Error returns the error message
+func (e *OverridesTemplateError) Error() string {
+	return fmt.Sprintf("Failed rendering the templated install overrides from %s: %v", e.Source, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *OverridesTemplateError) Unwrap() error {
+	return e.Err
+}
+
+// overridesTemplateData is the data that templated install overrides are rendered with, for example
+// "{{ .EnvironmentName }}" or "{{ .Verrazzano.Spec.Components.DNS.External.Suffix }}"
+type overridesTemplateData struct {
+	ctx spi.ComponentContext
+	// Verrazzano is the effective Verrazzano resource
+	Verrazzano *v1alpha1.Verrazzano
+}
+
+// EnvironmentName returns the environment name of the installation
+func (d *overridesTemplateData) EnvironmentName() string {
+	return vzconfig.GetEnvName(d.Verrazzano)
+}
+
+// DNSDomain returns the DNS domain of the installation, it is only looked up if a template references it
+func (d *overridesTemplateData) DNSDomain() (string, error) {
+	return vzconfig.BuildDNSDomain(d.ctx.Client(), d.Verrazzano)
+}
+
+// Profile returns the installation profile
+func (d *overridesTemplateData) Profile() string {
+	if len(d.Verrazzano.Spec.Profile) == 0 {
+		return string(v1alpha1.Prod)
+	}
+	return string(d.Verrazzano.Spec.Profile)
+}
+
+// Version returns the Verrazzano version
+func (d *overridesTemplateData) Version() (string, error) {
+	return vzconfig.GetVersion(d.Verrazzano)
+}
+
+// optionalForValidation treats the ConfigMaps and Secrets as optional when validating overrides
+var optionalForValidation = true
+
 // GetInstallOverridesYAML takes the list of Overrides and returns a string array of YAMLs
 func GetInstallOverridesYAML(ctx spi.ComponentContext, overrides []v1alpha1.Overrides) ([]string, error) {
-	return getInstallOverridesYAML(ctx.Log(), ctx.Client(), v1alpha1.ConvertValueOverridesToV1Beta1(overrides), ctx.EffectiveCR().Namespace)
+	templateData := &overridesTemplateData{ctx: ctx, Verrazzano: ctx.EffectiveCR()}
+	return getInstallOverridesYAML(ctx.Log(), ctx.Client(), v1alpha1.ConvertValueOverridesToV1Beta1(overrides), ctx.EffectiveCR().Namespace, templateData)
 }
 
 // GetInstallOverridesYAMLUsingClient takes the list of Overrides and returns a string array of YAMLs using the
@@ -31,7 +90,49 @@ func GetInstallOverridesYAMLUsingClient(client client.Client, overrides []v1beta
 	// DefaultLogger is used since this is invoked from validateInstall and validateUpdate functions and
 	// any actual logging isn't being performed
 	log := vzlog.DefaultLogger()
-	return getInstallOverridesYAML(log, client, overrides, namespace)
+	return getInstallOverridesYAML(log, client, overrides, namespace, nil)
+}
+
+// GetInstallOverrideSourcesUsingClient takes the list of Overrides and returns the YAML of each one along with a
+// description of its source, for validating the overrides.  ConfigMaps and Secrets that do not exist are skipped,
+// they are reported when the component is reconciled.  Templated overrides are only checked for template syntax
+// errors, since they are rendered when the component is reconciled.
+func GetInstallOverrideSourcesUsingClient(client client.Client, overrides []v1beta1.Overrides, namespace string) ([]helm.OverrideSource, error) {
+	log := vzlog.DefaultLogger()
+	var sources []helm.OverrideSource
+	for i, override := range overrides {
+		source := getOverrideSourceName(override, i)
+		var data string
+		var err error
+		switch {
+		case override.ConfigMapRef != nil:
+			selector := override.ConfigMapRef.DeepCopy()
+			selector.Optional = &optionalForValidation
+			data, err = getConfigMapOverrides(log, client, selector, namespace)
+		case override.SecretRef != nil:
+			selector := override.SecretRef.DeepCopy()
+			selector.Optional = &optionalForValidation
+			data, err = getSecretOverrides(log, client, selector, namespace)
+		case override.Values != nil:
+			var valuesData []byte
+			valuesData, err = yaml.Marshal(override.Values)
+			data = string(valuesData)
+		}
+		if err != nil {
+			return sources, err
+		}
+		if data == "" {
+			continue
+		}
+		if override.Template {
+			if _, err := parseOverridesTemplate(source, data); err != nil {
+				return sources, &OverridesTemplateError{Source: source, Err: err}
+			}
+			continue
+		}
+		sources = append(sources, helm.OverrideSource{Source: source, Values: data})
+	}
+	return sources, nil
 }
 
 // ExtractValueFromOverrideString is a helper function to extract a given value from override.
@@ -47,41 +148,155 @@ func ExtractValueFromOverrideString(overrideStr string, field string) (interface
 	return jsonString.Path(field).Data(), nil
 }
 
-// getInstallOverridesYAML takes the list of Overrides and returns a string array of YAMLs
+// getInstallOverridesYAML takes the list of Overrides and returns a string array of YAMLs.  Templated overrides are
+// rendered with the template data, if specified.
 func getInstallOverridesYAML(log vzlog.VerrazzanoLogger, client client.Client, overrides []v1beta1.Overrides,
-	namespace string) ([]string, error) {
+	namespace string, templateData *overridesTemplateData) ([]string, error) {
 	var overrideStrings []string
-	for _, override := range overrides {
+	for i, override := range overrides {
+		var data string
+		var err error
+		switch {
 		// Check if ConfigMapRef is populated and gather data
-		if override.ConfigMapRef != nil {
-			// Get the ConfigMap data
-			data, err := getConfigMapOverrides(log, client, override.ConfigMapRef, namespace)
+		case override.ConfigMapRef != nil:
+			data, err = getConfigMapOverrides(log, client, override.ConfigMapRef, namespace)
+		// Check if SecretRef is populated and gather data
+		case override.SecretRef != nil:
+			data, err = getSecretOverrides(log, client, override.SecretRef, namespace)
+		case override.Values != nil:
+			var overrideValuesData []byte
+			overrideValuesData, err = yaml.Marshal(override.Values)
+			data = string(overrideValuesData)
+		default:
+			continue
+		}
+		if err != nil {
+			return overrideStrings, err
+		}
+		if override.Template && templateData != nil {
+			data, err = renderOverridesTemplate(log, templateData, override, getOverrideSourceName(override, i), data)
 			if err != nil {
 				return overrideStrings, err
 			}
-			overrideStrings = append(overrideStrings, data)
-			continue
 		}
-		// Check if SecretRef is populated and gather data
-		if override.SecretRef != nil {
-			// Get the Secret data
-			data, err := getSecretOverrides(log, client, override.SecretRef, namespace)
+		overrideStrings = append(overrideStrings, data)
+	}
+	return overrideStrings, nil
+}
+
+// getOverrideSourceName returns a description of where the override at index i of the list comes from
+func getOverrideSourceName(override v1beta1.Overrides, i int) string {
+	switch {
+	case override.ConfigMapRef != nil:
+		return fmt.Sprintf("ConfigMap %s key %s", override.ConfigMapRef.Name, override.ConfigMapRef.Key)
+	case override.SecretRef != nil:
+		return fmt.Sprintf("Secret %s key %s", override.SecretRef.Name, override.SecretRef.Key)
+	}
+	return fmt.Sprintf("overrides[%d].values", i)
+}
+
+// singleActionPattern matches an inline value that consists of a single template action, for example "{{ .Replicas }}"
+var singleActionPattern = regexp.MustCompile(`^\s*{{[^{}]*}}\s*$`)
+
+// renderOverridesTemplate renders templated override values.  The rendered values are logged when they change,
+// unless they come from a Secret.
+func renderOverridesTemplate(log vzlog.VerrazzanoLogger, templateData *overridesTemplateData, override v1beta1.Overrides,
+	source string, values string) (string, error) {
+	if values == "" {
+		return values, nil
+	}
+	var rendered string
+	var err error
+	if override.Values != nil {
+		rendered, err = renderValuesTemplate(templateData, source, override.Values.Raw)
+	} else {
+		rendered, err = renderTemplate(templateData, source, values)
+	}
+	if err != nil {
+		return "", &OverridesTemplateError{Source: source, Err: err}
+	}
+	if _, err := yaml.YAMLToJSON([]byte(rendered)); err != nil {
+		return "", &OverridesTemplateError{Source: source, Err: fmt.Errorf("rendered values are not valid YAML: %v", err)}
+	}
+	if override.SecretRef != nil {
+		log.Debugf("Rendered templated install overrides from %s", source)
+	} else {
+		log.Progressf("Rendered templated install overrides from %s:\n%s", source, rendered)
+	}
+	return rendered, nil
+}
+
+// renderTemplate renders the text of templated override values
+func renderTemplate(templateData *overridesTemplateData, source string, text string) (string, error) {
+	tmpl, err := parseOverridesTemplate(source, text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderValuesTemplate renders the string values of templated inline override values and returns them as YAML.  An
+// inline value can only be a JSON string, so a value that consists of a single template action is unmarshalled
+// after it is rendered, and takes the type of the rendered YAML.  For example "{{ .Replicas }}" renders a number.
+func renderValuesTemplate(templateData *overridesTemplateData, source string, raw []byte) (string, error) {
+	var values interface{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return "", err
+	}
+	values, err := renderValue(templateData, source, values)
+	if err != nil {
+		return "", err
+	}
+	rendered, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+// renderValue renders the strings in an inline override value
+func renderValue(templateData *overridesTemplateData, source string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			rendered, err := renderValue(templateData, source, child)
 			if err != nil {
-				return overrideStrings, err
+				return nil, err
 			}
-			overrideStrings = append(overrideStrings, data)
-			continue
+			v[key] = rendered
 		}
-		if override.Values != nil {
-			overrideValuesData, err := yaml.Marshal(override.Values)
+	case []interface{}:
+		for i, child := range v {
+			rendered, err := renderValue(templateData, source, child)
 			if err != nil {
-				return overrideStrings, err
+				return nil, err
 			}
-			overrideStrings = append(overrideStrings, string(overrideValuesData))
+			v[i] = rendered
 		}
-
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		rendered, err := renderTemplate(templateData, source, v)
+		if err != nil || !singleActionPattern.MatchString(v) {
+			return rendered, err
+		}
+		var typed interface{}
+		if err := yaml.Unmarshal([]byte(rendered), &typed); err != nil {
+			return nil, fmt.Errorf("rendered value %q of %q is not valid YAML: %v", rendered, v, err)
+		}
+		return typed, nil
 	}
-	return overrideStrings, nil
+	return value, nil
+}
+
+// parseOverridesTemplate parses templated override values, referencing a missing map key is an error
+func parseOverridesTemplate(source string, values string) (*template.Template, error) {
+	return template.New(source).Option("missingkey=error").Parse(values)
 }
 
 // getConfigMapOverrides takes a ConfigMap selector and returns the YAML data and handles k8s api errors appropriately
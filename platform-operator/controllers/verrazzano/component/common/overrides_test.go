@@ -7,7 +7,9 @@ import (
 	"context"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
+	"github.com/verrazzano/verrazzano/pkg/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
 
@@ -16,6 +18,8 @@ import (
 	"k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
 
@@ -287,3 +291,145 @@ func TestExtractValueFromOverrideString(t *testing.T) {
 		})
 	}
 }
+
+// TestGetInstallOverrideSourcesUsingClient tests GetInstallOverrideSourcesUsingClient
+// GIVEN overrides from a ConfigMap, a Secret, inline values and a ConfigMap that does not exist
+// WHEN I call GetInstallOverrideSourcesUsingClient
+// THEN I get the YAML of each existing override labelled with its source
+func TestGetInstallOverrideSourcesUsingClient(t *testing.T) {
+	const ns = "verrazzano-install"
+	cm := &v1.ConfigMap{
+		ObjectMeta: v12.ObjectMeta{Name: "test-cm", Namespace: ns},
+		Data:       map[string]string{"values.yaml": "replicas: 2\n"},
+	}
+	sec := &v1.Secret{
+		ObjectMeta: v12.ObjectMeta{Name: "test-secret", Namespace: ns},
+		Data:       map[string][]byte{"overrides": []byte("port: 8080\n")},
+	}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(cm, sec).Build()
+	overrides := []v1beta1.Overrides{
+		{ConfigMapRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "test-cm"}, Key: "values.yaml"}},
+		{SecretRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "test-secret"}, Key: "overrides"}},
+		{ConfigMapRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "missing-cm"}, Key: "values.yaml"}},
+		{Values: &apiextensionsv1.JSON{Raw: []byte("{\"name\": \"test\"}")}},
+	}
+
+	sources, err := GetInstallOverrideSourcesUsingClient(client, overrides, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, []helm.OverrideSource{
+		{Source: "ConfigMap test-cm key values.yaml", Values: "replicas: 2\n"},
+		{Source: "Secret test-secret key overrides", Values: "port: 8080\n"},
+		{Source: "overrides[3].values", Values: "name: test\n"},
+	}, sources)
+}
+
+// TestGetInstallOverridesYAMLTemplate tests GetInstallOverridesYAML with templated overrides
+// GIVEN templated overrides from a ConfigMap and inline values, and an override that is not templated
+// WHEN I call GetInstallOverridesYAML
+// THEN the templated overrides are rendered with the effective CR, inline values that are a single template action
+// take the type of the rendered value, and the other override is unchanged
+func TestGetInstallOverridesYAMLTemplate(t *testing.T) {
+	const ns = "verrazzano-install"
+	cm := &v1.ConfigMap{
+		ObjectMeta: v12.ObjectMeta{Name: "test-cm", Namespace: ns},
+		Data: map[string]string{"values.yaml": "env: {{ .EnvironmentName }}\nprofile: {{ .Profile }}\n" +
+			"version: {{ .Version }}\nhost: app.{{ .DNSDomain }}\n"},
+	}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(cm).Build()
+	vz := &v1alpha1.Verrazzano{
+		ObjectMeta: v12.ObjectMeta{Namespace: ns},
+		Spec: v1alpha1.VerrazzanoSpec{
+			EnvironmentName: "dev1",
+			Profile:         v1alpha1.Dev,
+			Version:         "v1.4.0",
+			Components: v1alpha1.ComponentSpec{
+				DNS: &v1alpha1.DNSComponent{External: &v1alpha1.External{Suffix: "example.com"}},
+			},
+		},
+	}
+	overrides := []v1alpha1.Overrides{
+		{ConfigMapRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "test-cm"}, Key: "values.yaml"}, Template: true},
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"name": "{{ .Verrazzano.Spec.EnvironmentName }}-app", ` +
+			`"replicas": "{{ len .EnvironmentName }}", "dev": "{{ eq .Profile \"dev\" }}", "hosts": ["{{ .DNSDomain }}"]}`)}, Template: true},
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"name": "{{ .EnvironmentName }}"}`)}},
+	}
+
+	yamls, err := GetInstallOverridesYAML(spi.NewFakeContext(client, vz, nil, false), overrides)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"env: dev1\nprofile: dev\nversion: 1.4.0\nhost: app.dev1.example.com\n",
+		"dev: true\nhosts:\n- dev1.example.com\nname: dev1-app\nreplicas: 4\n",
+		"name: '{{ .EnvironmentName }}'\n",
+	}, yamls)
+}
+
+// TestGetInstallOverridesYAMLTemplateErrors tests GetInstallOverridesYAML with templated overrides that cannot be rendered
+// GIVEN templated overrides with a syntax error, a reference to a missing field, or that render invalid YAML
+// WHEN I call GetInstallOverridesYAML
+// THEN an OverridesTemplateError naming the override source is returned
+func TestGetInstallOverridesYAMLTemplateErrors(t *testing.T) {
+	vz := &v1alpha1.Verrazzano{ObjectMeta: v12.ObjectMeta{Namespace: "default"}}
+	tests := []struct {
+		name   string
+		values string
+	}{
+		{name: "syntax error", values: "name: {{ .EnvironmentName\n"},
+		{name: "missing field", values: "name: {{ .Cluster }}\n"},
+		{name: "invalid rendered YAML", values: "name: {{ .EnvironmentName }}\n  bad: [\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &v1.ConfigMap{
+				ObjectMeta: v12.ObjectMeta{Name: "test-cm", Namespace: "default"},
+				Data:       map[string]string{"values.yaml": tt.values},
+			}
+			client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(cm).Build()
+			overrides := []v1alpha1.Overrides{
+				{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 1}`)}},
+				{ConfigMapRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "test-cm"}, Key: "values.yaml"}, Template: true},
+			}
+			_, err := GetInstallOverridesYAML(spi.NewFakeContext(client, vz, nil, false), overrides)
+			var templateErr *OverridesTemplateError
+			if assert.ErrorAs(t, err, &templateErr) {
+				assert.Equal(t, "ConfigMap test-cm key values.yaml", templateErr.Source)
+			}
+		})
+	}
+}
+
+// TestGetInstallOverrideSourcesTemplate tests GetInstallOverrideSourcesUsingClient with templated overrides
+// GIVEN templated inline overrides
+// WHEN I call GetInstallOverrideSourcesUsingClient
+// THEN templated overrides are not returned for schema validation, and template syntax errors are returned
+func TestGetInstallOverrideSourcesTemplate(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	sources, err := GetInstallOverrideSourcesUsingClient(client, []v1beta1.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"name": "{{ .EnvironmentName }}"}`)}, Template: true},
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 1}`)}},
+	}, "default")
+	assert.NoError(t, err)
+	assert.Equal(t, []helm.OverrideSource{{Source: "overrides[1].values", Values: "replicas: 1\n"}}, sources)
+
+	_, err = GetInstallOverrideSourcesUsingClient(client, []v1beta1.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"name": "{{ .EnvironmentName "}`)}, Template: true},
+	}, "default")
+	var templateErr *OverridesTemplateError
+	assert.ErrorAs(t, err, &templateErr)
+}
+
+// TestGetInstallOverridesYAMLTemplateInlineError tests GetInstallOverridesYAML with templated inline overrides
+// GIVEN templated inline values that reference a missing field
+// WHEN I call GetInstallOverridesYAML
+// THEN an OverridesTemplateError naming the inline override is returned
+func TestGetInstallOverridesYAMLTemplateInlineError(t *testing.T) {
+	vz := &v1alpha1.Verrazzano{ObjectMeta: v12.ObjectMeta{Namespace: "default"}}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	overrides := []v1alpha1.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"app": {"replicas": "{{ .Cluster }}"}}`)}, Template: true},
+	}
+	_, err := GetInstallOverridesYAML(spi.NewFakeContext(client, vz, nil, false), overrides)
+	var templateErr *OverridesTemplateError
+	if assert.ErrorAs(t, err, &templateErr) {
+		assert.Equal(t, "overrides[0].values", templateErr.Source)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
@@ -70,8 +71,14 @@ func (c *certBuilder) buildLetsEncryptStagingChain() error {
 	return nil
 }
 
+// IsLetsEncrypt returns true if the certificates are issued by Let's Encrypt, the private ACME servers are not
+// supported by the Rancher Let's Encrypt integration
+func IsLetsEncrypt(acme vzapi.Acme) bool {
+	return strings.EqualFold(string(acme.Provider), string(vzapi.LetsEncrypt))
+}
+
 func useAdditionalCAs(acme vzapi.Acme) bool {
-	return acme != vzapi.Acme{} && acme.Environment != "production"
+	return IsLetsEncrypt(acme) && acme.Environment != "production"
 }
 
 func ProcessAdditionalCertificates(log vzlog.VerrazzanoLogger, cli client.Client, vz *vzapi.Verrazzano) error {
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 )
 
 // TestCertBuilder verifies downloading certs from the web
@@ -82,3 +83,15 @@ func TestBuildLetsEncryptChain(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "certcertcert", string(builder.cert))
 }
+
+// TestUseAdditionalCAs verifies when the additional Let's Encrypt CAs are used
+// GIVEN an ACME configuration
+//  WHEN useAdditionalCAs is called
+//  THEN useAdditionalCAs should return true only for the non-production Let's Encrypt environments
+func TestUseAdditionalCAs(t *testing.T) {
+	assert.True(t, useAdditionalCAs(vzapi.Acme{Provider: vzapi.LetsEncrypt, Environment: "staging"}))
+	assert.True(t, useAdditionalCAs(vzapi.Acme{Provider: "letsEncrypt"}))
+	assert.False(t, useAdditionalCAs(vzapi.Acme{Provider: vzapi.LetsEncrypt, Environment: "production"}))
+	assert.False(t, useAdditionalCAs(vzapi.Acme{Provider: vzapi.Generic, Server: "https://step-ca.example.com/acme/acme/directory"}))
+	assert.False(t, useAdditionalCAs(vzapi.Acme{}))
+}
@@ -32,6 +32,11 @@ type consoleComponent struct {
 // Verify that ConsoleComponent implements Component
 var _ spi.Component = consoleComponent{}
 
+// podDisruptionBudgets are the replicated console pods
+var podDisruptionBudgets = []spi.PodDisruptionBudget{
+	{Name: ComponentName, PodLabels: map[string]string{"app": ComponentName}},
+}
+
 // NewComponent returns a new consoleComponent
 func NewComponent() spi.Component {
 	return consoleComponent{
@@ -48,6 +53,11 @@ func NewComponent() spi.Component {
 			MinVerrazzanoVersion:      constants.VerrazzanoVersion1_4_0,
 			ImagePullSecretKeyname:    secret.DefaultImagePullSecretKeyName,
 			GetInstallOverridesFunc:   GetOverrides,
+			PodDisruptionBudgets:      podDisruptionBudgets,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc: GetWorkload,
+				ReplicasKey:     "replicas",
+			},
 		},
 	}
 }
@@ -108,6 +118,21 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []installv1beta1.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Console != nil {
+			return effectiveCR.Spec.Components.Console.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Console != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.Console.Workload)
+		}
+	}
+	return nil
+}
+
 // MonitorOverrides checks whether monitoring of install overrides for the console is enabled or not
 func (c consoleComponent) MonitorOverrides(ctx spi.ComponentContext) bool {
 	if ctx.EffectiveCR().Spec.Components.Console != nil {
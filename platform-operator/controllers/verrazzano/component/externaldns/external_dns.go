@@ -13,7 +13,6 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
-	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"hash/fnv"
 	v1 "k8s.io/api/core/v1"
@@ -34,6 +33,7 @@ const (
 	imagePullSecretHelmKey = "global.imagePullSecrets[0]"
 	ownerIDHelmKey         = "txtOwnerId"
 	prefixKey              = "txtPrefix"
+	providerKey            = "provider"
 
 	clusterRoleName        = ComponentName
 	clusterRoleBindingName = ComponentName
@@ -54,42 +54,12 @@ func preInstall(compContext spi.ComponentContext) error {
 		return compContext.Log().ErrorfNewErr("Failed to create or update the cert-manager namespace: %v", err)
 	}
 
-	// Get OCI DNS secret from the verrazzano-install namespace
-	dns := compContext.EffectiveCR().Spec.Components.DNS
-	dnsSecret := v1.Secret{}
-	if err := compContext.Client().Get(context.TODO(), client.ObjectKey{Name: dns.OCI.OCIConfigSecret, Namespace: constants.VerrazzanoInstallNamespace}, &dnsSecret); err != nil {
-		return compContext.Log().ErrorfNewErr("Failed to find secret %s in the %s namespace: %v", dns.OCI.OCIConfigSecret, constants.VerrazzanoInstallNamespace, err)
-	}
-
-	//check if scope value is valid
-	scope := dns.OCI.DNSScope
-	if scope != dnsGlobal && scope != dnsPrivate && scope != "" {
-		return compContext.Log().ErrorfNewErr("Failed, invalid OCI DNS scope value: %s. If set, value can only be 'GLOBAL' or 'PRIVATE", dns.OCI.DNSScope)
-	}
-
-	// Attach compartment field to secret and apply it in the external DNS namespace
-	externalDNSSecret := v1.Secret{}
-	compContext.Log().Debug("Creating the external DNS secret")
-	externalDNSSecret.Namespace = ComponentNamespace
-	externalDNSSecret.Name = dnsSecret.Name
-	if _, err := controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), &externalDNSSecret, func() error {
-		externalDNSSecret.Data = make(map[string][]byte)
-
-		// Verify that the oci secret has one value
-		if len(dnsSecret.Data) != 1 {
-			return compContext.Log().ErrorNewErr("Failed, OCI secret for OCI DNS should be created from one file")
-		}
-
-		// Extract data and create secret in the external DNS namespace
-		for k := range dnsSecret.Data {
-			externalDNSSecret.Data[ociSecretFileName] = append(dnsSecret.Data[k], []byte(fmt.Sprintf("compartment: %s", dns.OCI.DNSZoneCompartmentOCID))...)
-		}
-
-		return nil
-	}); err != nil {
-		return compContext.Log().ErrorfNewErr("Failed to create or update the external DNS secret: %v", err)
+	// Create the secret of the DNS provider in the external DNS namespace
+	provider, err := getDNSProvider(compContext.EffectiveCR())
+	if err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to get the DNS provider: %v", err)
 	}
-	return nil
+	return provider.createSecret(compContext)
 }
 
 // postUninstall Clean up the cluster role/bindings
@@ -125,11 +95,11 @@ func isExternalDNSReady(compContext spi.ComponentContext) bool {
 
 // AppendOverrides builds the set of external-dns overrides for the helm install
 func AppendOverrides(compContext spi.ComponentContext, releaseName string, namespace string, _ string, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
-	oci, err := getOCIDNS(compContext.EffectiveCR())
+	provider, err := getDNSProvider(compContext.EffectiveCR())
 	if err != nil {
 		return kvs, err
 	}
-	// OCI DNS is configured, append all helm overrides for external DNS
+	// A DNS provider is configured, append all helm overrides for external DNS
 	ids, err := getOrBuildIDs(compContext, releaseName, namespace)
 	if err != nil {
 		return kvs, err
@@ -138,33 +108,15 @@ func AppendOverrides(compContext spi.ComponentContext, releaseName string, names
 	txtPrefix := ids[1]
 	compContext.Log().Debugf("Owner ID: %s, TXT record prefix: %s", ownerID, txtPrefix)
 	arguments := []bom.KeyValue{
-		{Key: "domainFilters[0]", Value: oci.DNSZoneName},
-		{Key: "zoneIDFilters[0]", Value: oci.DNSZoneOCID},
-		{Key: "ociDnsScope", Value: oci.DNSScope},
+		{Key: providerKey, Value: provider.getName()},
 		{Key: "txtOwnerId", Value: ownerID},
 		{Key: "txtPrefix", Value: txtPrefix},
-		{Key: "extraVolumes[0].name", Value: "config"},
-		{Key: "extraVolumes[0].secret.secretName", Value: oci.OCIConfigSecret},
-		{Key: "extraVolumeMounts[0].name", Value: "config"},
-		{Key: "extraVolumeMounts[0].mountPath", Value: "/etc/kubernetes/"},
 	}
 	kvs = append(kvs, arguments...)
+	kvs = append(kvs, provider.getOverrides()...)
 	return kvs, nil
 }
 
-func getOCIDNS(vz *vzapi.Verrazzano) (*vzapi.OCI, error) {
-	dns := vz.Spec.Components.DNS
-	// Should never fail the next error checks if IsEnabled() is correct, but can't hurt to check
-	if dns == nil {
-		return nil, fmt.Errorf("DNS not configured for component %s", ComponentName)
-	}
-	oci := dns.OCI
-	if oci == nil {
-		return nil, fmt.Errorf("OCI must be configured for component %s", ComponentName)
-	}
-	return oci, nil
-}
-
 //getOrBuildIDs Get the owner and TXT prefix IDs from the Helm release if they exist and preserve it, otherwise build a new ones
 func getOrBuildIDs(compContext spi.ComponentContext, releaseName string, namespace string) ([]string, error) {
 	values, err := helm.GetReleaseStringValues(compContext.Log(), []string{ownerIDHelmKey, prefixKey}, releaseName, namespace)
@@ -213,3 +165,18 @@ func GetOverrides(object runtime.Object) interface{} {
 	}
 	return []installv1beta1.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.DNS != nil {
+			return effectiveCR.Spec.Components.DNS.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.DNS != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.DNS.Workload)
+		}
+	}
+	return nil
+}
@@ -46,6 +46,14 @@ func NewComponent() spi.Component {
 			MinVerrazzanoVersion:      constants.VerrazzanoVersion1_0_0,
 			Dependencies:              []string{},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				ReplicasKey:          "replicas",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
@@ -70,11 +78,35 @@ func (e externalDNSComponent) PostUninstall(ctx spi.ComponentContext) error {
 	return postUninstall(ctx.Log(), ctx.Client())
 }
 
+// ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
+func (e externalDNSComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
+	vzV1Beta1 := &installv1beta1.Verrazzano{}
+	if err := vz.ConvertTo(vzV1Beta1); err != nil {
+		return err
+	}
+	return e.ValidateInstallV1Beta1(vzV1Beta1)
+}
+
+// ValidateInstallV1Beta1 checks if the specified Verrazzano CR is valid for this component to be installed
+func (e externalDNSComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) error {
+	if err := validateExternalDNS(vz); err != nil {
+		return err
+	}
+	return e.HelmComponent.ValidateInstallV1Beta1(vz)
+}
+
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
 func (e externalDNSComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazzano) error {
 	// Do not allow any changes except to enable the component post-install
 	if e.IsEnabled(old) && !e.IsEnabled(new) {
-		return fmt.Errorf("Disabling an existing OCI DNS configuration is not allowed")
+		return fmt.Errorf("Disabling an existing OCI or RFC2136 DNS configuration is not allowed")
+	}
+	newBeta := &installv1beta1.Verrazzano{}
+	if err := new.ConvertTo(newBeta); err != nil {
+		return err
+	}
+	if err := validateExternalDNS(newBeta); err != nil {
+		return err
 	}
 	return e.HelmComponent.ValidateUpdate(old, new)
 }
@@ -83,7 +115,10 @@ func (e externalDNSComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.V
 func (e externalDNSComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano, new *installv1beta1.Verrazzano) error {
 	// Do not allow any changes except to enable the component post-install
 	if e.IsEnabled(old) && !e.IsEnabled(new) {
-		return fmt.Errorf("Disabling an existing OCI DNS configuration is not allowed")
+		return fmt.Errorf("Disabling an existing OCI or RFC2136 DNS configuration is not allowed")
+	}
+	if err := validateExternalDNS(new); err != nil {
+		return err
 	}
 	return e.HelmComponent.ValidateUpdateV1Beta1(old, new)
 }
@@ -0,0 +1,179 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package externaldns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	ociProviderName     = "oci"
+	rfc2136ProviderName = "rfc2136"
+
+	// RFC2136TSIGSecretKey is the key of the TSIG secret in the RFC2136 secret of the verrazzano-install namespace
+	RFC2136TSIGSecretKey = "secret"
+	// RFC2136SecretKey is the key of the TSIG secret in the copy of the RFC2136 secret used by external-dns and cert-manager
+	RFC2136SecretKey = "rfc2136_tsig_secret" //nolint:gosec //#gosec G101
+
+	defaultRFC2136Port          = 53
+	defaultRFC2136TSIGAlgorithm = "hmac-sha256"
+)
+
+// dnsProvider is a DNS service that external-dns updates the records of
+type dnsProvider interface {
+	// getName returns the name of the external-dns provider
+	getName() string
+	// createSecret creates the secret used by external-dns to access the DNS service in the external DNS namespace
+	createSecret(compContext spi.ComponentContext) error
+	// getOverrides returns the provider specific helm overrides for external-dns
+	getOverrides() []bom.KeyValue
+}
+
+// ociDNSProvider updates the records of an OCI DNS zone
+type ociDNSProvider struct {
+	oci *vzapi.OCI
+}
+
+// rfc2136DNSProvider updates the records of a DNS zone with RFC2136 dynamic updates, signed with a TSIG key
+type rfc2136DNSProvider struct {
+	rfc2136 *vzapi.RFC2136
+}
+
+// getDNSProvider returns the DNS provider configured in the Verrazzano CR
+func getDNSProvider(vz *vzapi.Verrazzano) (dnsProvider, error) {
+	dns := vz.Spec.Components.DNS
+	// Should never fail the next error checks if IsEnabled() is correct, but can't hurt to check
+	if dns == nil {
+		return nil, fmt.Errorf("DNS not configured for component %s", ComponentName)
+	}
+	if dns.OCI != nil {
+		return ociDNSProvider{oci: dns.OCI}, nil
+	}
+	if dns.RFC2136 != nil {
+		return rfc2136DNSProvider{rfc2136: dns.RFC2136}, nil
+	}
+	return nil, fmt.Errorf("OCI or RFC2136 DNS must be configured for component %s", ComponentName)
+}
+
+func (p ociDNSProvider) getName() string {
+	return ociProviderName
+}
+
+// createSecret attaches the compartment field to the OCI DNS secret and applies it in the external DNS namespace
+func (p ociDNSProvider) createSecret(compContext spi.ComponentContext) error {
+	// Get OCI DNS secret from the verrazzano-install namespace
+	dnsSecret := v1.Secret{}
+	if err := compContext.Client().Get(context.TODO(), client.ObjectKey{Name: p.oci.OCIConfigSecret, Namespace: constants.VerrazzanoInstallNamespace}, &dnsSecret); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to find secret %s in the %s namespace: %v", p.oci.OCIConfigSecret, constants.VerrazzanoInstallNamespace, err)
+	}
+
+	//check if scope value is valid
+	scope := p.oci.DNSScope
+	if scope != dnsGlobal && scope != dnsPrivate && scope != "" {
+		return compContext.Log().ErrorfNewErr("Failed, invalid OCI DNS scope value: %s. If set, value can only be 'GLOBAL' or 'PRIVATE", p.oci.DNSScope)
+	}
+
+	// Attach compartment field to secret and apply it in the external DNS namespace
+	externalDNSSecret := v1.Secret{}
+	compContext.Log().Debug("Creating the external DNS secret")
+	externalDNSSecret.Namespace = ComponentNamespace
+	externalDNSSecret.Name = dnsSecret.Name
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), &externalDNSSecret, func() error {
+		externalDNSSecret.Data = make(map[string][]byte)
+
+		// Verify that the oci secret has one value
+		if len(dnsSecret.Data) != 1 {
+			return compContext.Log().ErrorNewErr("Failed, OCI secret for OCI DNS should be created from one file")
+		}
+
+		// Extract data and create secret in the external DNS namespace
+		for k := range dnsSecret.Data {
+			externalDNSSecret.Data[ociSecretFileName] = append(dnsSecret.Data[k], []byte(fmt.Sprintf("compartment: %s", p.oci.DNSZoneCompartmentOCID))...)
+		}
+
+		return nil
+	}); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to create or update the external DNS secret: %v", err)
+	}
+	return nil
+}
+
+func (p ociDNSProvider) getOverrides() []bom.KeyValue {
+	return []bom.KeyValue{
+		{Key: "domainFilters[0]", Value: p.oci.DNSZoneName},
+		{Key: "zoneIDFilters[0]", Value: p.oci.DNSZoneOCID},
+		{Key: "ociDnsScope", Value: p.oci.DNSScope},
+		{Key: "extraVolumes[0].name", Value: "config"},
+		{Key: "extraVolumes[0].secret.secretName", Value: p.oci.OCIConfigSecret},
+		{Key: "extraVolumeMounts[0].name", Value: "config"},
+		{Key: "extraVolumeMounts[0].mountPath", Value: "/etc/kubernetes/"},
+	}
+}
+
+func (p rfc2136DNSProvider) getName() string {
+	return rfc2136ProviderName
+}
+
+// createSecret copies the TSIG secret to the external DNS namespace, with the key expected by external-dns
+func (p rfc2136DNSProvider) createSecret(compContext spi.ComponentContext) error {
+	// Get the TSIG secret from the verrazzano-install namespace
+	tsigSecret := v1.Secret{}
+	if err := compContext.Client().Get(context.TODO(), client.ObjectKey{Name: p.rfc2136.TSIGSecret, Namespace: constants.VerrazzanoInstallNamespace}, &tsigSecret); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to find secret %s in the %s namespace: %v", p.rfc2136.TSIGSecret, constants.VerrazzanoInstallNamespace, err)
+	}
+	secretValue, ok := tsigSecret.Data[RFC2136TSIGSecretKey]
+	if !ok {
+		return compContext.Log().ErrorfNewErr("Failed, the secret %s for RFC2136 DNS must have a %s key", p.rfc2136.TSIGSecret, RFC2136TSIGSecretKey)
+	}
+
+	compContext.Log().Debug("Creating the external DNS secret")
+	externalDNSSecret := v1.Secret{}
+	externalDNSSecret.Namespace = ComponentNamespace
+	externalDNSSecret.Name = tsigSecret.Name
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), &externalDNSSecret, func() error {
+		externalDNSSecret.Data = map[string][]byte{RFC2136SecretKey: secretValue}
+		return nil
+	}); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to create or update the external DNS secret: %v", err)
+	}
+	return nil
+}
+
+func (p rfc2136DNSProvider) getOverrides() []bom.KeyValue {
+	return []bom.KeyValue{
+		{Key: "domainFilters[0]", Value: p.rfc2136.DNSZoneName},
+		{Key: "rfc2136.host", Value: p.rfc2136.Host},
+		{Key: "rfc2136.port", Value: strconv.Itoa(GetRFC2136Port(p.rfc2136))},
+		{Key: "rfc2136.zone", Value: p.rfc2136.DNSZoneName},
+		{Key: "rfc2136.tsigKeyname", Value: p.rfc2136.TSIGKeyName},
+		{Key: "rfc2136.tsigSecretAlg", Value: GetRFC2136TSIGAlgorithm(p.rfc2136)},
+		{Key: "rfc2136.secretName", Value: p.rfc2136.TSIGSecret},
+	}
+}
+
+// GetRFC2136Port returns the port of the RFC2136 name server
+func GetRFC2136Port(rfc2136 *vzapi.RFC2136) int {
+	if rfc2136.Port == 0 {
+		return defaultRFC2136Port
+	}
+	return rfc2136.Port
+}
+
+// GetRFC2136TSIGAlgorithm returns the algorithm of the RFC2136 TSIG key
+func GetRFC2136TSIGAlgorithm(rfc2136 *vzapi.RFC2136) string {
+	if len(rfc2136.TSIGAlgorithm) == 0 {
+		return defaultRFC2136TSIGAlgorithm
+	}
+	return rfc2136.TSIGAlgorithm
+}
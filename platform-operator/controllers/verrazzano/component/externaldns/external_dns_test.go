@@ -4,11 +4,13 @@
 package externaldns
 
 import (
+	"context"
+
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"os/exec"
+	"k8s.io/apimachinery/pkg/types"
 	"strings"
 	"testing"
 
@@ -17,6 +19,7 @@ import (
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"helm.sh/helm/v3/pkg/release"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -69,6 +72,13 @@ var ociInvalidScope = &vzapi.OCI{
 	DNSScope:               "#jhwuyusj!!!",
 }
 
+var rfc2136 = &vzapi.RFC2136{
+	Host:        "10.0.0.53",
+	DNSZoneName: "zone.name.io",
+	TSIGKeyName: "externaldns-key",
+	TSIGSecret:  "tsig",
+}
+
 var fakeComponent = externalDNSComponent{}
 
 var testScheme = runtime.NewScheme()
@@ -78,18 +88,6 @@ func init() {
 	_ = vzapi.AddToScheme(testScheme)
 }
 
-// genericTestRunner is used to run generic OS commands with expected results
-type genericTestRunner struct {
-	stdOut []byte
-	stdErr []byte
-	err    error
-}
-
-// Run genericTestRunner executor
-func (r genericTestRunner) Run(_ *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return r.stdOut, r.stdErr, r.err
-}
-
 // TestIsExternalDNSEnabled tests the IsEnabled fn
 // GIVEN a call to IsEnabled
 // WHEN OCI DNS is enabled
@@ -138,12 +136,30 @@ func TestAppendExternalDNSOverrides(t *testing.T) {
 	localvz := vz.DeepCopy()
 	localvz.Spec.Components.DNS.OCI = oci
 
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte{},
-		err:    nil,
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
+
+	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return helm.ChartNotFound, nil
 	})
-	defer helm.SetDefaultRunner()
+	defer helm.SetDefaultChartStatusFunction()
+
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	assert.Len(t, kvs, 10)
+	assert.Contains(t, kvs, bom.KeyValue{Key: providerKey, Value: ociProviderName})
+}
+
+// TestAppendExternalDNSOverridesRFC2136 tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+// WHEN a VZ spec is passed with RFC2136 DNS
+// THEN the values for the rfc2136 provider are created, with the default TSIG algorithm
+func TestAppendExternalDNSOverridesRFC2136(t *testing.T) {
+	localvz := vz.DeepCopy()
+	localvz.Spec.Components.DNS.RFC2136 = rfc2136
+
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return helm.ChartNotFound, nil
@@ -152,7 +168,15 @@ func TestAppendExternalDNSOverrides(t *testing.T) {
 
 	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
 	assert.NoError(t, err)
-	assert.Len(t, kvs, 9)
+	assert.Len(t, kvs, 10)
+	assert.Contains(t, kvs, bom.KeyValue{Key: providerKey, Value: rfc2136ProviderName})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "domainFilters[0]", Value: "zone.name.io"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.host", Value: "10.0.0.53"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.port", Value: "53"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.zone", Value: "zone.name.io"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.tsigKeyname", Value: "externaldns-key"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.tsigSecretAlg", Value: "hmac-sha256"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.secretName", Value: "tsig"})
 }
 
 // TestExternalDNSPreInstallDryRun tests the PreInstall fn
@@ -229,31 +253,63 @@ func TestExternalDNSPreInstall3InvalidScope(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestExternalDNSPreInstallRFC2136 tests the PreInstall fn
+// GIVEN a call to this fn
+// WHEN I call PreInstall with RFC2136 DNS
+// THEN the TSIG secret is copied to the external DNS namespace with the key expected by external-dns
+func TestExternalDNSPreInstallRFC2136(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tsig",
+				Namespace: constants.VerrazzanoInstallNamespace,
+			},
+			Data: map[string][]byte{RFC2136TSIGSecretKey: []byte("tsig-secret")},
+		}).Build()
+	localvz := vz.DeepCopy()
+	localvz.Spec.Components.DNS.RFC2136 = rfc2136
+	err := fakeComponent.PreInstall(spi.NewFakeContext(client, localvz, nil, false))
+	assert.NoError(t, err)
+
+	secret := v1.Secret{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "tsig", Namespace: ComponentNamespace}, &secret)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("tsig-secret"), secret.Data[RFC2136SecretKey])
+}
+
+// TestExternalDNSPreInstallRFC2136MissingKey tests the PreInstall fn
+// GIVEN a call to this fn
+// WHEN I call PreInstall with RFC2136 DNS and the TSIG secret does not have the secret key
+// THEN an error is returned
+func TestExternalDNSPreInstallRFC2136MissingKey(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tsig",
+				Namespace: constants.VerrazzanoInstallNamespace,
+			},
+			Data: map[string][]byte{"key": []byte("tsig-secret")},
+		}).Build()
+	localvz := vz.DeepCopy()
+	localvz.Spec.Components.DNS.RFC2136 = rfc2136
+	err := fakeComponent.PreInstall(spi.NewFakeContext(client, localvz, nil, false))
+	assert.Error(t, err)
+}
+
 // TestOwnerIDTextPrefix_HelmValueExists tests the getOrBuildIDs and getOrBuildTXTRecordPrefix functions
 // GIVEN calls to getOrBuildIDs and getOrBuildTXTRecordPrefix
 //  WHEN a valid helm release and namespace are deployed and the txtOwnerId and txtPrefix values exist in the release values
 //  THEN the function returns the stored helm values and no error
 func TestOwnerIDTextPrefix_HelmValueExists(t *testing.T) {
-	jsonOut := []byte(`
-{
-  "domainFilters": [
-    "my.domain.io"
-  ],
-  "triggerLoopOnEvent": true,
-  "txtOwnerId": "storedOwnerId",
-  "txtPrefix": "storedPrefix",
-  "zoneIDFilters": [
-    "ocid1.dns-zone.oc1..blahblahblah"
-  ]
-}
-`)
-
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction(
+		helm.NewFakeRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "", map[string]interface{}{
+			"domainFilters":      []interface{}{"my.domain.io"},
+			"triggerLoopOnEvent": true,
+			"txtOwnerId":         "storedOwnerId",
+			"txtPrefix":          "storedPrefix",
+			"zoneIDFilters":      []interface{}{"ocid1.dns-zone.oc1..blahblahblah"},
+		})))
+	defer helm.SetDefaultActionConfigFunction()
 
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return helm.ChartStatusDeployed, nil
@@ -281,12 +337,8 @@ func TestOwnerIDTextPrefix_HelmValueExists(t *testing.T) {
 //  WHEN no stored helm values exist
 //  THEN the function returns the generated values and no error
 func Test_getOrBuildOwnerID_NoHelmValueExists(t *testing.T) {
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return helm.ChartNotFound, nil
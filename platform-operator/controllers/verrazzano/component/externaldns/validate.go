@@ -0,0 +1,102 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package externaldns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The TSIG algorithms supported by both external-dns and cert-manager
+var rfc2136TSIGAlgorithms = []string{"hmac-md5", "hmac-sha1", "hmac-sha256", "hmac-sha512"}
+
+var getControllerRuntimeClient = getClient
+
+// validateExternalDNS checks the DNS provider configuration of external-dns
+func validateExternalDNS(vz *v1beta1.Verrazzano) error {
+	dns := vz.Spec.Components.DNS
+	if dns == nil || dns.RFC2136 == nil {
+		return nil
+	}
+	if dns.OCI != nil {
+		return fmt.Errorf("only one of OCI or RFC2136 DNS can be configured")
+	}
+	return validateRFC2136(dns.RFC2136)
+}
+
+// validateRFC2136 checks the RFC2136 DNS configuration and its TSIG secret
+func validateRFC2136(rfc2136 *v1beta1.RFC2136) error {
+	if len(rfc2136.Host) == 0 {
+		return fmt.Errorf("the host of the RFC2136 name server is required")
+	}
+	if rfc2136.Port < 0 || rfc2136.Port > 65535 {
+		return fmt.Errorf("invalid port %d of the RFC2136 name server", rfc2136.Port)
+	}
+	if errs := validation.IsDNS1123Subdomain(rfc2136.DNSZoneName); len(errs) > 0 {
+		return fmt.Errorf("invalid RFC2136 DNS zone name \"%s\": %v", rfc2136.DNSZoneName, errs)
+	}
+	if len(rfc2136.TSIGKeyName) == 0 {
+		return fmt.Errorf("the RFC2136 TSIG key name is required")
+	}
+	if len(rfc2136.TSIGAlgorithm) > 0 && !isValidTSIGAlgorithm(rfc2136.TSIGAlgorithm) {
+		return fmt.Errorf("invalid RFC2136 TSIG algorithm \"%s\", it must be one of %v", rfc2136.TSIGAlgorithm, rfc2136TSIGAlgorithms)
+	}
+	if len(rfc2136.TSIGSecret) == 0 {
+		return fmt.Errorf("the RFC2136 TSIG secret is required")
+	}
+
+	cli, err := getControllerRuntimeClient()
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(context.TODO(), types.NamespacedName{Name: rfc2136.TSIGSecret, Namespace: constants.VerrazzanoInstallNamespace}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("secret \"%s\" must be created in the \"%s\" namespace", rfc2136.TSIGSecret, constants.VerrazzanoInstallNamespace)
+		}
+		return err
+	}
+	if _, ok := secret.Data[RFC2136TSIGSecretKey]; !ok {
+		return fmt.Errorf("invalid RFC2136 DNS configuration, missing %s entry in secret \"%s\"", RFC2136TSIGSecretKey, rfc2136.TSIGSecret)
+	}
+	return nil
+}
+
+func isValidTSIGAlgorithm(algorithm string) bool {
+	for _, alg := range rfc2136TSIGAlgorithms {
+		if alg == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// getClient returns a controller runtime client for the Verrazzano resource
+func getClient() (client.Client, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: newScheme()})
+}
+
+// newScheme creates a new scheme that includes this package's object for use by client
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	clientgoscheme.AddToScheme(scheme)
+	return scheme
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package externaldns
+
+import (
+	"testing"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func createRFC2136VZ(rfc2136 *v1beta1.RFC2136) *v1beta1.Verrazzano {
+	return &v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{
+			Components: v1beta1.ComponentSpec{
+				DNS: &v1beta1.DNSComponent{
+					RFC2136: rfc2136,
+				},
+			},
+		},
+	}
+}
+
+// TestValidateExternalDNS tests validating the RFC2136 DNS configuration
+// GIVEN valid and invalid RFC2136 DNS configurations
+// WHEN validateExternalDNS is called
+// THEN an error is returned for the invalid configurations
+func TestValidateExternalDNS(t *testing.T) {
+	tsigSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tsig", Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{RFC2136TSIGSecretKey: []byte("secret")},
+	}
+	badSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bad", Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{"key": []byte("secret")},
+	}
+	getControllerRuntimeClient = func() (client.Client, error) {
+		return fake.NewClientBuilder().WithScheme(newScheme()).WithRuntimeObjects(&tsigSecret, &badSecret).Build(), nil
+	}
+	defer func() { getControllerRuntimeClient = getClient }()
+
+	valid := v1beta1.RFC2136{Host: "10.0.0.53", DNSZoneName: "example.com", TSIGKeyName: "externaldns-key", TSIGSecret: "tsig"}
+	with := func(update func(rfc2136 *v1beta1.RFC2136)) *v1beta1.Verrazzano {
+		rfc2136 := valid
+		update(&rfc2136)
+		return createRFC2136VZ(&rfc2136)
+	}
+	ociAndRFC2136 := createRFC2136VZ(&valid)
+	ociAndRFC2136.Spec.Components.DNS.OCI = &v1beta1.OCI{OCIConfigSecret: "oci"}
+
+	tests := []struct {
+		name    string
+		vz      *v1beta1.Verrazzano
+		wantErr bool
+	}{{
+		name:    "default",
+		vz:      &v1beta1.Verrazzano{},
+		wantErr: false,
+	}, {
+		name:    "valid",
+		vz:      createRFC2136VZ(&valid),
+		wantErr: false,
+	}, {
+		name:    "valid algorithm and port",
+		vz:      with(func(r *v1beta1.RFC2136) { r.TSIGAlgorithm = "hmac-sha512"; r.Port = 5353 }),
+		wantErr: false,
+	}, {
+		name:    "oci and rfc2136",
+		vz:      ociAndRFC2136,
+		wantErr: true,
+	}, {
+		name:    "missing host",
+		vz:      with(func(r *v1beta1.RFC2136) { r.Host = "" }),
+		wantErr: true,
+	}, {
+		name:    "invalid port",
+		vz:      with(func(r *v1beta1.RFC2136) { r.Port = 70000 }),
+		wantErr: true,
+	}, {
+		name:    "invalid zone",
+		vz:      with(func(r *v1beta1.RFC2136) { r.DNSZoneName = "Example_Zone" }),
+		wantErr: true,
+	}, {
+		name:    "missing key name",
+		vz:      with(func(r *v1beta1.RFC2136) { r.TSIGKeyName = "" }),
+		wantErr: true,
+	}, {
+		name:    "invalid algorithm",
+		vz:      with(func(r *v1beta1.RFC2136) { r.TSIGAlgorithm = "hmac-sha384" }),
+		wantErr: true,
+	}, {
+		name:    "missing secret",
+		vz:      with(func(r *v1beta1.RFC2136) { r.TSIGSecret = "missing" }),
+		wantErr: true,
+	}, {
+		name:    "secret without key",
+		vz:      with(func(r *v1beta1.RFC2136) { r.TSIGSecret = "bad" }),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateExternalDNS(tt.vz); (err != nil) != tt.wantErr {
+				t.Errorf("validateExternalDNS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
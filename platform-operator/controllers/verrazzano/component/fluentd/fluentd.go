@@ -10,6 +10,7 @@ import (
 	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
 	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
@@ -72,8 +73,27 @@ func loggingPreInstall(ctx spi.ComponentContext) error {
 					return err
 				}
 			}
+			// Copy the secrets of the additional outputs
+			for _, output := range fluentdConfig.Outputs {
+				if err := copyOutputSecrets(ctx, output); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// copyOutputSecrets copies the secrets of an additional output to the Fluentd namespace
+func copyOutputSecrets(ctx spi.ComponentContext, output vzapi.FluentdOutput) error {
+	if output.Kafka != nil && len(output.Kafka.Secret) > 0 {
+		if err := common.CopySecret(ctx, output.Kafka.Secret, constants.VerrazzanoSystemNamespace, "Kafka output"); err != nil {
+			return err
 		}
 	}
+	if output.S3 != nil {
+		return common.CopySecret(ctx, output.S3.CredentialsSecret, constants.VerrazzanoSystemNamespace, "S3 output")
+	}
 	return nil
 }
 
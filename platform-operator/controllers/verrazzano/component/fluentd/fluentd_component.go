@@ -56,6 +56,15 @@ type fluentdComponent struct {
 // Verify that fluentdComponent implements Component
 var _ spi.Component = fluentdComponent{}
 
+// networkPolicyRules are the network peers of Fluentd outside of the verrazzano-system namespace, Fluentd sends the
+// logs to the external log stores
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app": ComponentName},
+		Egress:    []spi.NetworkPeer{{}},
+	},
+}
+
 // NewComponent returns a new Fluentd component
 func NewComponent() spi.Component {
 	return fluentdComponent{
@@ -64,6 +73,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetHelmChartsDir(), HelmChartDir),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -71,6 +81,13 @@ func NewComponent() spi.Component {
 			AppendOverridesFunc:       appendOverrides,
 			Dependencies:              []string{},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "fluentd.resources",
+				NodeSelectorKey:      "fluentd.nodeSelector",
+				TolerationsKey:       "fluentd.tolerations",
+				PriorityClassNameKey: "fluentd.priorityClassName",
+			},
 		},
 	}
 }
@@ -262,3 +279,18 @@ func GetOverrides(object runtime.Object) interface{} {
 	}
 	return []v1beta1.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *v1alpha1.WorkloadSpec {
+	if effectiveCR, ok := object.(*v1alpha1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Fluentd != nil {
+			return effectiveCR.Spec.Components.Fluentd.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*v1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Fluentd != nil {
+			return (*v1alpha1.WorkloadSpec)(effectiveCR.Spec.Components.Fluentd.Workload)
+		}
+	}
+	return nil
+}
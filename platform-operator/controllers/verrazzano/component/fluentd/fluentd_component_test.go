@@ -5,10 +5,10 @@ package fluentd
 
 import (
 	"context"
-	"fmt"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"testing"
 
+	"helm.sh/helm/v3/pkg/release"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -19,7 +19,6 @@ import (
 	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
 	helmcli "github.com/verrazzano/verrazzano/pkg/helm"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
-	"github.com/verrazzano/verrazzano/pkg/os"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
@@ -428,8 +427,8 @@ func TestInstall(t *testing.T) {
 }
 
 // fakeUpgrade override the upgrade function during unit tests
-func fakeUpgrade(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helmcli.HelmOverrides) (stdout []byte, stderr []byte, err error) {
-	return []byte("success"), []byte(""), nil
+func fakeUpgrade(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helmcli.HelmOverrides) (*release.Release, error) {
+	return helmcli.NewFakeRelease(releaseName, namespace, release.StatusDeployed, "", nil), nil
 }
 
 // TestPreUpgrade tests the Verrazzano PreUpgrade call
@@ -489,8 +488,9 @@ func TestUpgrade(t *testing.T) {
 	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
 	ctx := getFakeComponentContext(c)
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helmcli.SetCmdRunner(os.GenericTestRunner{})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction(
+		helmcli.NewFakeRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "", nil)))
+	defer helmcli.SetDefaultActionConfigFunction()
 	helm.SetUpgradeFunc(fakeUpgrade)
 	defer helm.SetDefaultUpgradeFunc()
 	helmcli.SetChartStateFunction(func(releaseName string, namespace string) (string, error) {
@@ -545,12 +545,9 @@ func TestIsInstalled(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart installed
 //  THEN no error is returned
 func TestUninstallHelmChartInstalled(t *testing.T) {
-	helmcli.SetCmdRunner(os.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    nil,
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction(
+		helmcli.NewFakeRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "", nil)))
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &v1alpha1.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
@@ -561,12 +558,8 @@ func TestUninstallHelmChartInstalled(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart not installed
 //  THEN no error is returned
 func TestUninstallHelmChartNotInstalled(t *testing.T) {
-	helmcli.SetCmdRunner(os.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    fmt.Errorf("Not installed"),
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction())
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &v1alpha1.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
@@ -577,12 +570,8 @@ func TestUninstallHelmChartNotInstalled(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart not installed
 //  THEN ensure that all Fluentd resources are explicity deleted
 func TestUninstallResources(t *testing.T) {
-	helmcli.SetCmdRunner(os.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    fmt.Errorf("Not installed"),
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction())
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: ComponentName}}
 	clusterRoleBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: ComponentName}}
@@ -271,6 +271,71 @@ func TestLoggingPreInstall(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestLoggingPreInstallOutputs tests the Fluentd loggingPreInstall call
+// GIVEN a Fluentd component with Kafka and S3 outputs
+//  WHEN I call loggingPreInstall
+//  THEN no error is returned and the secrets of the outputs have been copied
+func TestLoggingPreInstallOutputs(t *testing.T) {
+	trueValue := true
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: vpoconst.VerrazzanoInstallNamespace, Name: "kafka"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: vpoconst.VerrazzanoInstallNamespace, Name: "minio"}},
+	).Build()
+	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Fluentd: &vzapi.FluentdComponent{
+					Enabled: &trueValue,
+					Outputs: []vzapi.FluentdOutput{
+						{Name: "siem", Kafka: &vzapi.FluentdKafkaOutput{Brokers: []string{"kafka:9093"}, Topic: "logs", Secret: "kafka"}},
+						{Name: "archive", S3: &vzapi.FluentdS3Output{Bucket: "logs", CredentialsSecret: "minio"}},
+						{Name: "syslog", Syslog: &vzapi.FluentdSyslogOutput{Host: "syslog"}},
+					},
+				},
+			},
+		},
+	}, nil, false)
+	assert.NoError(t, loggingPreInstall(ctx))
+
+	for _, name := range []string{"kafka", "minio"} {
+		secret := &corev1.Secret{}
+		assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: ComponentNamespace}, secret))
+	}
+}
+
+// TestAppendFluentdOutputOverrides tests the overrides of the additional outputs
+// GIVEN a Fluentd component with Kafka, S3 and syslog outputs
+//  WHEN I call appendFluentdOverrides
+//  THEN the outputs are added to the overrides with their defaults
+func TestAppendFluentdOutputOverrides(t *testing.T) {
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Fluentd: &vzapi.FluentdComponent{
+					Outputs: []vzapi.FluentdOutput{
+						{Name: "siem", Namespaces: []string{"app"}, Kafka: &vzapi.FluentdKafkaOutput{Brokers: []string{"kafka:9093"}, Topic: "logs", TLS: true, SASLMechanism: "scram-sha-512", Secret: "kafka"}},
+						{Name: "plain", Kafka: &vzapi.FluentdKafkaOutput{Brokers: []string{"kafka:9092"}, Topic: "logs", SASLMechanism: "plain", Secret: "kafka"}},
+						{Name: "archive", S3: &vzapi.FluentdS3Output{Endpoint: "http://minio:9000", Bucket: "logs", ForcePathStyle: true, CredentialsSecret: "minio"}},
+						{Name: "syslog", Syslog: &vzapi.FluentdSyslogOutput{Host: "syslog"}},
+					},
+				},
+			},
+		},
+	}
+	overrides := &fluentdComponentValues{}
+	appendFluentdOverrides(vz, overrides)
+
+	outputs := overrides.Fluentd.Outputs
+	assert.Len(t, outputs, 4)
+	assert.Equal(t, []string{"app"}, outputs[0].Namespaces)
+	assert.Equal(t, &kafkaOutputValues{Brokers: []string{"kafka:9093"}, Topic: "logs", TLS: true, SASL: true, ScramMechanism: "sha512", Secret: "kafka"}, outputs[0].Kafka)
+	assert.True(t, outputs[1].Kafka.SASL)
+	assert.Empty(t, outputs[1].Kafka.ScramMechanism)
+	assert.Equal(t, defaultS3Path, outputs[2].S3.Path)
+	assert.True(t, outputs[2].S3.ForcePathStyle)
+	assert.Equal(t, &syslogOutputValues{Host: "syslog", Port: defaultSyslogPort, Transport: defaultSyslogTransport}, outputs[3].Syslog)
+}
+
 // TestLoggingPreInstallSecretNotFound tests the Verrazzano loggingPreInstall call
 // GIVEN a Verrazzano component
 //  WHEN I call loggingPreInstall with fluentd overrides for ES and a custom ES secret and the secret does not exist
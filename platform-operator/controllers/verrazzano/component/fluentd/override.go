@@ -16,6 +16,7 @@ import (
 	"io/fs"
 	"os"
 	"sigs.k8s.io/yaml"
+	"strings"
 )
 
 const (
@@ -23,6 +24,12 @@ const (
 	tmpSuffix            = "yaml"
 	tmpFileCreatePattern = tmpFilePrefix + "*." + tmpSuffix
 	tmpFileCleanPattern  = tmpFilePrefix + ".*\\." + tmpSuffix
+
+	// Defaults of the additional outputs
+	defaultS3Path          = "logs/%Y/%m/%d/"
+	defaultSyslogPort      = 514
+	defaultSyslogTransport = "tcp"
+	scramPrefix            = "scram-"
 )
 
 type fluentdComponentValues struct {
@@ -42,6 +49,7 @@ type fluentdValues struct {
 	Enabled           bool                `json:"enabled"` // Always write
 	ExtraVolumeMounts []volumeMount       `json:"extraVolumeMounts,omitempty"`
 	OCI               *ociLoggingSettings `json:"oci,omitempty"`
+	Outputs           []outputValues      `json:"outputs,omitempty"`
 }
 
 type volumeMount struct {
@@ -56,6 +64,38 @@ type ociLoggingSettings struct {
 	APISecret       string `json:"apiSecret,omitempty"`
 }
 
+type outputValues struct {
+	Name       string              `json:"name"`
+	Namespaces []string            `json:"namespaces,omitempty"`
+	Kafka      *kafkaOutputValues  `json:"kafka,omitempty"`
+	S3         *s3OutputValues     `json:"s3,omitempty"`
+	Syslog     *syslogOutputValues `json:"syslog,omitempty"`
+}
+
+type kafkaOutputValues struct {
+	Brokers        []string `json:"brokers"`
+	Topic          string   `json:"topic"`
+	TLS            bool     `json:"tls,omitempty"`
+	SASL           bool     `json:"sasl,omitempty"`
+	ScramMechanism string   `json:"scramMechanism,omitempty"`
+	Secret         string   `json:"secret,omitempty"`
+}
+
+type s3OutputValues struct {
+	Endpoint          string `json:"endpoint,omitempty"`
+	Bucket            string `json:"bucket"`
+	Region            string `json:"region,omitempty"`
+	Path              string `json:"path"`
+	ForcePathStyle    bool   `json:"forcePathStyle,omitempty"`
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+type syslogOutputValues struct {
+	Host      string `json:"host"`
+	Port      int32  `json:"port"`
+	Transport string `json:"transport"`
+}
+
 type Monitoring struct {
 	Enabled       bool `json:"enabled,omitempty"`
 	UseIstioCerts bool `json:"useIstioCerts,omitempty"`
@@ -139,6 +179,9 @@ func appendFluentdOverrides(effectiveCR *vzapi.Verrazzano, overrides *fluentdCom
 				APISecret:       fluentd.OCI.APISecret,
 			}
 		}
+		for _, output := range fluentd.Outputs {
+			overrides.Fluentd.Outputs = append(overrides.Fluentd.Outputs, newOutputValues(output))
+		}
 	}
 
 	// Force the override to be the internal ES secret if the legacy ES secret is being used.
@@ -155,6 +198,54 @@ func appendFluentdOverrides(effectiveCR *vzapi.Verrazzano, overrides *fluentdCom
 	}
 }
 
+// newOutputValues returns the values of an additional output, with the defaults applied
+func newOutputValues(output vzapi.FluentdOutput) outputValues {
+	values := outputValues{
+		Name:       output.Name,
+		Namespaces: output.Namespaces,
+	}
+	if output.Kafka != nil {
+		values.Kafka = &kafkaOutputValues{
+			Brokers: output.Kafka.Brokers,
+			Topic:   output.Kafka.Topic,
+			TLS:     output.Kafka.TLS,
+			SASL:    len(output.Kafka.SASLMechanism) > 0,
+			Secret:  output.Kafka.Secret,
+		}
+		// The Kafka plugin uses plain SASL unless a SCRAM mechanism is set
+		if strings.HasPrefix(output.Kafka.SASLMechanism, scramPrefix) {
+			values.Kafka.ScramMechanism = strings.ReplaceAll(strings.TrimPrefix(output.Kafka.SASLMechanism, scramPrefix), "-", "")
+		}
+	}
+	if output.S3 != nil {
+		values.S3 = &s3OutputValues{
+			Endpoint:          output.S3.Endpoint,
+			Bucket:            output.S3.Bucket,
+			Region:            output.S3.Region,
+			Path:              output.S3.Path,
+			ForcePathStyle:    output.S3.ForcePathStyle,
+			CredentialsSecret: output.S3.CredentialsSecret,
+		}
+		if len(values.S3.Path) == 0 {
+			values.S3.Path = defaultS3Path
+		}
+	}
+	if output.Syslog != nil {
+		values.Syslog = &syslogOutputValues{
+			Host:      output.Syslog.Host,
+			Port:      output.Syslog.Port,
+			Transport: output.Syslog.Transport,
+		}
+		if values.Syslog.Port == 0 {
+			values.Syslog.Port = defaultSyslogPort
+		}
+		if len(values.Syslog.Transport) == 0 {
+			values.Syslog.Transport = defaultSyslogTransport
+		}
+	}
+	return values
+}
+
 func generateOverridesFile(ctx spi.ComponentContext, overrides *fluentdComponentValues) (string, error) {
 	bytes, err := yaml.Marshal(overrides)
 	if err != nil {
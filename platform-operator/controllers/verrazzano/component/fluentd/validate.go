@@ -15,6 +15,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"net"
+	"net/url"
+	"regexp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -23,13 +26,22 @@ const (
 	// ES secret keys
 	esUsernameKey = "username"
 	esPasswordKey = "password"
+
+	// Kafka output secret keys
+	kafkaUsernameKey = "username"
+	kafkaPasswordKey = "password"
+	kafkaCertKey     = "tls.crt"
+	kafkaKeyKey      = "tls.key"
 )
 
 // existing Fluentd mount paths can be found at platform-operator/helm_config/charts/verrazzano/templates/daemonset.yaml
-var existingFluentdMountPaths = [7]string{
-	"/fluentd/cacerts", "/fluentd/secret", "/fluentd/etc",
+var existingFluentdMountPaths = [8]string{
+	"/fluentd/cacerts", "/fluentd/secret", "/fluentd/etc", "/fluentd/outputs",
 	"/root/.oci", "/var/log", "/var/lib", "/run/log/journal"}
 
+// outputNameRegex matches the valid names of the additional outputs, they are used in Fluentd labels and file paths
+var outputNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
 var getControllerRuntimeClient = getClient
 
 func validateFluentd(vz *v1beta1.Verrazzano) error {
@@ -43,6 +55,9 @@ func validateFluentd(vz *v1beta1.Verrazzano) error {
 	if err := validateLogCollector(fluentd); err != nil {
 		return err
 	}
+	if err := validateOutputs(fluentd); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -91,6 +106,129 @@ func validateLogCollectorSecret(fluentd *v1beta1.FluentdComponent) error {
 	return nil
 }
 
+func validateOutputs(fluentd *v1beta1.FluentdComponent) error {
+	names := map[string]bool{}
+	for _, output := range fluentd.Outputs {
+		if !outputNameRegex.MatchString(output.Name) {
+			return fmt.Errorf("invalid Fluentd output name \"%s\", it must consist of lower case alphanumeric characters or '-'", output.Name)
+		}
+		if names[output.Name] {
+			return fmt.Errorf("duplicate Fluentd output name \"%s\"", output.Name)
+		}
+		names[output.Name] = true
+
+		var err error
+		count := 0
+		if output.Kafka != nil {
+			count++
+			err = validateKafkaOutput(output.Name, output.Kafka)
+		}
+		if output.S3 != nil {
+			count++
+			err = validateS3Output(output.Name, output.S3)
+		}
+		if output.Syslog != nil {
+			count++
+			err = validateSyslogOutput(output.Name, output.Syslog)
+		}
+		if count != 1 {
+			return fmt.Errorf("invalid Fluentd output \"%s\", it must have exactly one of kafka, s3 or syslog", output.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateKafkaOutput(name string, kafka *v1beta1.FluentdKafkaOutput) error {
+	if len(kafka.Brokers) == 0 || len(kafka.Topic) == 0 {
+		return fmt.Errorf("invalid Kafka output \"%s\", it must have brokers and a topic", name)
+	}
+	for _, broker := range kafka.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			return fmt.Errorf("invalid broker \"%s\" of Kafka output \"%s\", it must be host:port", broker, name)
+		}
+	}
+	switch kafka.SASLMechanism {
+	case "", "plain", "scram-sha-256", "scram-sha-512":
+	default:
+		return fmt.Errorf("invalid SASL mechanism \"%s\" of Kafka output \"%s\", it must be plain, scram-sha-256 or scram-sha-512", kafka.SASLMechanism, name)
+	}
+	if len(kafka.Secret) == 0 {
+		if len(kafka.SASLMechanism) > 0 {
+			return fmt.Errorf("invalid Kafka output \"%s\", it must have a secret to use SASL", name)
+		}
+		return nil
+	}
+	secret, err := getOutputSecret(kafka.Secret)
+	if err != nil {
+		return err
+	}
+	if len(kafka.SASLMechanism) > 0 {
+		if err := validateEntryExist(secret, kafkaUsernameKey); err != nil {
+			return err
+		}
+		if err := validateEntryExist(secret, kafkaPasswordKey); err != nil {
+			return err
+		}
+	}
+	// The client certificate and key are optional, but go together
+	_, hasCert := secret.Data[kafkaCertKey]
+	_, hasKey := secret.Data[kafkaKeyKey]
+	if hasCert != hasKey {
+		return fmt.Errorf("invalid Fluentd configuration, secret \"%s\" must have both %s and %s entries, or none", secret.Name, kafkaCertKey, kafkaKeyKey)
+	}
+	return nil
+}
+
+func validateS3Output(name string, s3 *v1beta1.FluentdS3Output) error {
+	if len(s3.Bucket) == 0 || len(s3.CredentialsSecret) == 0 {
+		return fmt.Errorf("invalid S3 output \"%s\", it must have a bucket and a credentials secret", name)
+	}
+	if len(s3.Endpoint) > 0 {
+		if u, err := url.Parse(s3.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return fmt.Errorf("invalid endpoint \"%s\" of S3 output \"%s\", it must be an http or https URL", s3.Endpoint, name)
+		}
+	}
+	secret, err := getOutputSecret(s3.CredentialsSecret)
+	if err != nil {
+		return err
+	}
+	if err := validateEntryExist(secret, vzconst.ObjectStoreAccessKey); err != nil {
+		return err
+	}
+	return validateEntryExist(secret, vzconst.ObjectStoreAccessSecretKey)
+}
+
+func validateSyslogOutput(name string, syslog *v1beta1.FluentdSyslogOutput) error {
+	if len(syslog.Host) == 0 {
+		return fmt.Errorf("invalid syslog output \"%s\", it must have a host", name)
+	}
+	if syslog.Port < 0 || syslog.Port > 65535 {
+		return fmt.Errorf("invalid port %d of syslog output \"%s\"", syslog.Port, name)
+	}
+	switch syslog.Transport {
+	case "", "udp", "tcp", "tls":
+	default:
+		return fmt.Errorf("invalid transport \"%s\" of syslog output \"%s\", it must be udp, tcp or tls", syslog.Transport, name)
+	}
+	return nil
+}
+
+// getOutputSecret returns a secret of an additional output from the verrazzano-install namespace
+func getOutputSecret(name string) (*corev1.Secret, error) {
+	cli, err := getControllerRuntimeClient()
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := getInstallSecret(cli, name, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
 func validateEntryExist(secret *corev1.Secret, entry string) error {
 	secretName := secret.Name
 	_, ok := secret.Data[entry]
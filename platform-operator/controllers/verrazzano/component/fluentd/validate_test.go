@@ -134,3 +134,109 @@ func getFakeSecret(secName string) corev1.Secret {
 	}
 	return sec
 }
+
+func createOutputsVZ(outputs ...v1beta1.FluentdOutput) *v1beta1.Verrazzano {
+	return &v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{
+			Components: v1beta1.ComponentSpec{
+				Fluentd: &v1beta1.FluentdComponent{
+					Outputs: outputs,
+				},
+			},
+		},
+	}
+}
+
+// TestValidateOutputs tests validating the additional Fluentd outputs
+// GIVEN Kafka, S3 and syslog outputs
+// WHEN validateFluentd is called
+// THEN an error is returned for the invalid outputs
+func TestValidateOutputs(t *testing.T) {
+	kafkaSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: constants.VerrazzanoInstallNamespace},
+		Data: map[string][]byte{
+			kafkaUsernameKey: []byte("user"),
+			kafkaPasswordKey: []byte("password"),
+			kafkaCertKey:     []byte("cert"),
+		},
+	}
+	s3Secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: constants.VerrazzanoInstallNamespace},
+		Data: map[string][]byte{
+			constants.ObjectStoreAccessKey:       []byte("key"),
+			constants.ObjectStoreAccessSecretKey: []byte("secret"),
+		},
+	}
+	getControllerRuntimeClient = func() (client.Client, error) {
+		return fake.NewClientBuilder().WithScheme(newScheme()).WithRuntimeObjects(&kafkaSecret, &s3Secret).Build(), nil
+	}
+	defer func() { getControllerRuntimeClient = getClient }()
+
+	kafka := &v1beta1.FluentdKafkaOutput{Brokers: []string{"kafka:9092"}, Topic: "logs"}
+	s3 := &v1beta1.FluentdS3Output{Endpoint: "http://minio:9000", Bucket: "logs", CredentialsSecret: "minio"}
+	syslog := &v1beta1.FluentdSyslogOutput{Host: "syslog"}
+	tests := []struct {
+		name    string
+		vz      *v1beta1.Verrazzano
+		wantErr bool
+	}{{
+		name: "valid outputs",
+		vz: createOutputsVZ(
+			v1beta1.FluentdOutput{Name: "kafka", Namespaces: []string{"app"}, Kafka: kafka},
+			v1beta1.FluentdOutput{Name: "s3", S3: s3},
+			v1beta1.FluentdOutput{Name: "syslog", Syslog: syslog},
+		),
+		wantErr: false,
+	}, {
+		name:    "duplicate name",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", Kafka: kafka}, v1beta1.FluentdOutput{Name: "out", Syslog: syslog}),
+		wantErr: true,
+	}, {
+		name:    "invalid name",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "My_Output", Kafka: kafka}),
+		wantErr: true,
+	}, {
+		name:    "no output type",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out"}),
+		wantErr: true,
+	}, {
+		name:    "several output types",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", Kafka: kafka, Syslog: syslog}),
+		wantErr: true,
+	}, {
+		name:    "kafka broker without port",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", Kafka: &v1beta1.FluentdKafkaOutput{Brokers: []string{"kafka"}, Topic: "logs"}}),
+		wantErr: true,
+	}, {
+		name:    "kafka sasl without secret",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", Kafka: &v1beta1.FluentdKafkaOutput{Brokers: []string{"kafka:9092"}, Topic: "logs", SASLMechanism: "plain"}}),
+		wantErr: true,
+	}, {
+		name:    "kafka certificate without key",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", Kafka: &v1beta1.FluentdKafkaOutput{Brokers: []string{"kafka:9092"}, Topic: "logs", TLS: true, Secret: "kafka"}}),
+		wantErr: true,
+	}, {
+		name:    "s3 missing secret",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", S3: &v1beta1.FluentdS3Output{Bucket: "logs", CredentialsSecret: "missing"}}),
+		wantErr: true,
+	}, {
+		name:    "s3 invalid endpoint",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", S3: &v1beta1.FluentdS3Output{Endpoint: "minio:9000", Bucket: "logs", CredentialsSecret: "minio"}}),
+		wantErr: true,
+	}, {
+		name:    "syslog invalid transport",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", Syslog: &v1beta1.FluentdSyslogOutput{Host: "syslog", Transport: "http"}}),
+		wantErr: true,
+	}, {
+		name:    "syslog invalid port",
+		vz:      createOutputsVZ(v1beta1.FluentdOutput{Name: "out", Syslog: &v1beta1.FluentdSyslogOutput{Host: "syslog", Port: 70000}}),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFluentd(tt.vz); (err != nil) != tt.wantErr {
+				t.Errorf("validateFluentd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package grafana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	vzappclusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzpassword "github.com/verrazzano/verrazzano/pkg/security/password"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// appDashboardTag tags the application dashboards imported from ConfigMaps
+	appDashboardTag = "verrazzano-application"
+	// projectFolderUIDPrefix prefixes the UID of the Grafana folder of each VerrazzanoProject
+	projectFolderUIDPrefix = "vz-project-"
+	// appDashboardUIDPrefix prefixes the UID of the application dashboards
+	appDashboardUIDPrefix = "vz-app-"
+
+	// dashboardChecksumAnnotation records the checksum of the dashboards last imported from a ConfigMap
+	dashboardChecksumAnnotation = "verrazzano.io/grafana-dashboard-checksum"
+	// dashboardErrorAnnotation reports why the dashboards of a ConfigMap could not be imported
+	dashboardErrorAnnotation = "verrazzano.io/grafana-dashboard-error"
+
+	// The Grafana folder permissions of the project monitors and project admins
+	folderPermissionView  = 1
+	folderPermissionAdmin = 4
+)
+
+// grafanaSearchResult is a dashboard or folder returned by the Grafana search and folder APIs
+type grafanaSearchResult struct {
+	UID       string `json:"uid"`
+	Title     string `json:"title"`
+	FolderUID string `json:"folderUid,omitempty"`
+}
+
+// folderPermission is an item of the permissions of a Grafana folder, for a user or a team
+type folderPermission struct {
+	UserID     int `json:"userId,omitempty"`
+	TeamID     int `json:"teamId,omitempty"`
+	Permission int `json:"permission"`
+}
+
+// ReconcileApplicationDashboards imports the Grafana dashboards of the ConfigMaps labeled with
+// verrazzano.io/grafana-dashboard=true into a folder per VerrazzanoProject.  The project admin subjects can administer
+// the folder of their project and the project monitor subjects can view it.  User subjects are matched to the Grafana
+// users with the same login, Group subjects to the Grafana teams with the same name.  Dashboards that are no longer
+// in a ConfigMap and folders of deleted projects are removed.  Problems with a ConfigMap are reported in its
+// verrazzano.io/grafana-dashboard-error annotation.
+func ReconcileApplicationDashboards(ctx spi.ComponentContext) error {
+	projects, err := getProjectsByNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	configMaps := corev1.ConfigMapList{}
+	if err := ctx.Client().List(context.TODO(), &configMaps, clipkg.MatchingLabels{vzconst.LabelGrafanaDashboard: "true"}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing the Grafana dashboard ConfigMaps: %v", err)
+	}
+
+	var existing []grafanaSearchResult
+	path := fmt.Sprintf("/api/search?type=dash-db&tag=%s", appDashboardTag)
+	if _, err := grafanaRequest(ctx, http.MethodGet, path, nil, &existing, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed searching the application dashboards in Grafana: %v", err)
+	}
+	existingFolders := make(map[string]string, len(existing))
+	for _, dashboard := range existing {
+		existingFolders[dashboard.UID] = dashboard.FolderUID
+	}
+
+	folders := make(map[string]bool)
+	dashboards := make(map[string]bool)
+	for i := range configMaps.Items {
+		cm := &configMaps.Items[i]
+		project, ok := projects[cm.Namespace]
+		if !ok {
+			if err := reportDashboardResult(ctx, cm, "", fmt.Sprintf("namespace %s is not in a VerrazzanoProject", cm.Namespace)); err != nil {
+				return err
+			}
+			continue
+		}
+		folderUID := projectFolderUID(project.Name)
+		if !folders[folderUID] {
+			if err := createOrUpdateProjectFolder(ctx, project); err != nil {
+				return err
+			}
+			folders[folderUID] = true
+		}
+		uids, err := importConfigMapDashboards(ctx, cm, folderUID, existingFolders)
+		if err != nil {
+			return err
+		}
+		for _, uid := range uids {
+			dashboards[uid] = true
+		}
+	}
+
+	for _, dashboard := range existing {
+		if dashboards[dashboard.UID] {
+			continue
+		}
+		ctx.Log().Infof("Deleting application dashboard %s from Grafana, its ConfigMap no longer exists", dashboard.Title)
+		if _, err := grafanaRequest(ctx, http.MethodDelete, "/api/dashboards/uid/"+dashboard.UID, nil, nil, http.StatusOK, http.StatusNotFound); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting application dashboard %s from Grafana: %v", dashboard.Title, err)
+		}
+	}
+	return deleteUnusedProjectFolders(ctx, folders)
+}
+
+// getProjectsByNamespace returns the VerrazzanoProject of each namespace, a namespace in several projects belongs
+// to the first one by name
+func getProjectsByNamespace(ctx spi.ComponentContext) (map[string]*vzappclusters.VerrazzanoProject, error) {
+	projectList := vzappclusters.VerrazzanoProjectList{}
+	if err := ctx.Client().List(context.TODO(), &projectList, clipkg.InNamespace(vzconst.VerrazzanoMultiClusterNamespace)); err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed listing the VerrazzanoProjects: %v", err)
+	}
+	sort.Slice(projectList.Items, func(i, j int) bool {
+		return projectList.Items[i].Name < projectList.Items[j].Name
+	})
+	projects := make(map[string]*vzappclusters.VerrazzanoProject)
+	for i := range projectList.Items {
+		for _, ns := range projectList.Items[i].Spec.Template.Namespaces {
+			if _, ok := projects[ns.Metadata.Name]; !ok {
+				projects[ns.Metadata.Name] = &projectList.Items[i]
+			}
+		}
+	}
+	return projects, nil
+}
+
+// importConfigMapDashboards imports the dashboards of the .json keys of a ConfigMap into the project folder, unless
+// they are unchanged since the last import.  The UIDs of the dashboards are returned.
+func importConfigMapDashboards(ctx spi.ComponentContext, cm *corev1.ConfigMap, folderUID string, existingFolders map[string]string) ([]string, error) {
+	var uids []string
+	var problems []string
+	checksum := dashboardChecksum(cm)
+	upToDate := cm.Annotations[dashboardChecksumAnnotation] == checksum
+	for _, key := range sortedKeys(cm.Data) {
+		if !strings.HasSuffix(key, ".json") {
+			continue
+		}
+		uid := appDashboardUID(cm.Namespace, cm.Name, key)
+		dashboard := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(cm.Data[key]), &dashboard); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid dashboard JSON: %v", key, err))
+			continue
+		}
+		uids = append(uids, uid)
+		if existingFolder, ok := existingFolders[uid]; upToDate && ok && existingFolder == folderUID {
+			continue
+		}
+
+		// The dashboard is identified by its ConfigMap key, so the same dashboard can be used in several namespaces
+		dashboard["uid"] = uid
+		delete(dashboard, "id")
+		dashboard["tags"] = appendDashboardTag(dashboard["tags"])
+		payload := map[string]interface{}{
+			"dashboard": dashboard,
+			"folderUid": folderUID,
+			"overwrite": true,
+			"message":   fmt.Sprintf("Imported from ConfigMap %s/%s", cm.Namespace, cm.Name),
+		}
+		if code, err := grafanaRequest(ctx, http.MethodPost, "/api/dashboards/db", payload, nil, http.StatusOK); err != nil {
+			if code >= http.StatusBadRequest && code < http.StatusInternalServerError {
+				problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+				continue
+			}
+			return nil, ctx.Log().ErrorfNewErr("Failed importing dashboard %s of ConfigMap %s/%s into Grafana: %v", key, cm.Namespace, cm.Name, err)
+		}
+		ctx.Log().Oncef("Imported dashboard %s of ConfigMap %s/%s into Grafana", key, cm.Namespace, cm.Name)
+	}
+	return uids, reportDashboardResult(ctx, cm, checksum, strings.Join(problems, "; "))
+}
+
+// reportDashboardResult records the checksum of the imported dashboards and the import problems in the annotations
+// of the ConfigMap
+func reportDashboardResult(ctx spi.ComponentContext, cm *corev1.ConfigMap, checksum string, problems string) error {
+	if cm.Annotations[dashboardChecksumAnnotation] == checksum && cm.Annotations[dashboardErrorAnnotation] == problems {
+		return nil
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	setOrDelete := func(key string, value string) {
+		if len(value) > 0 {
+			cm.Annotations[key] = value
+		} else {
+			delete(cm.Annotations, key)
+		}
+	}
+	setOrDelete(dashboardChecksumAnnotation, checksum)
+	setOrDelete(dashboardErrorAnnotation, problems)
+	if len(problems) > 0 {
+		ctx.Log().Oncef("Failed importing the Grafana dashboards of ConfigMap %s/%s: %s", cm.Namespace, cm.Name, problems)
+	}
+	if err := ctx.Client().Update(context.TODO(), cm); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating the annotations of Grafana dashboard ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+	}
+	return nil
+}
+
+// createOrUpdateProjectFolder creates the Grafana folder of a project and sets its permissions from the project
+// admin and monitor subjects
+func createOrUpdateProjectFolder(ctx spi.ComponentContext, project *vzappclusters.VerrazzanoProject) error {
+	uid := projectFolderUID(project.Name)
+	code, err := grafanaRequest(ctx, http.MethodGet, "/api/folders/"+uid, nil, nil, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the Grafana folder of project %s: %v", project.Name, err)
+	}
+	if code == http.StatusNotFound {
+		folder := grafanaSearchResult{UID: uid, Title: project.Name}
+		if _, err := grafanaRequest(ctx, http.MethodPost, "/api/folders", folder, nil, http.StatusOK); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed creating the Grafana folder of project %s: %v", project.Name, err)
+		}
+		ctx.Log().Infof("Created the Grafana folder of project %s", project.Name)
+	}
+
+	// Setting the permissions replaces the default permissions of the Editor and Viewer roles, so the folder is only
+	// visible to the project subjects and the Grafana admins
+	permissions := make(map[rbacv1.Subject]int)
+	for _, subject := range project.Spec.Template.Security.ProjectMonitorSubjects {
+		permissions[subject] = folderPermissionView
+	}
+	for _, subject := range project.Spec.Template.Security.ProjectAdminSubjects {
+		permissions[subject] = folderPermissionAdmin
+	}
+	items := []folderPermission{}
+	for subject, permission := range permissions {
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			userID, err := getOrCreateGrafanaUser(ctx, subject.Name)
+			if err != nil {
+				return err
+			}
+			items = append(items, folderPermission{UserID: userID, Permission: permission})
+		case rbacv1.GroupKind:
+			teamID, err := getOrCreateGrafanaTeam(ctx, subject.Name)
+			if err != nil {
+				return err
+			}
+			items = append(items, folderPermission{TeamID: teamID, Permission: permission})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].UserID != items[j].UserID {
+			return items[i].UserID < items[j].UserID
+		}
+		return items[i].TeamID < items[j].TeamID
+	})
+	payload := map[string]interface{}{"items": items}
+	if _, err := grafanaRequest(ctx, http.MethodPost, fmt.Sprintf("/api/folders/%s/permissions", uid), payload, nil, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed setting the permissions of the Grafana folder of project %s: %v", project.Name, err)
+	}
+	return nil
+}
+
+// getOrCreateGrafanaUser returns the ID of the Grafana user with the login, creating the user if it has not logged
+// in to Grafana yet.  The user logs in through the auth proxy, the generated password is never used.
+func getOrCreateGrafanaUser(ctx spi.ComponentContext, login string) (int, error) {
+	user := struct {
+		ID int `json:"id"`
+	}{}
+	path := "/api/users/lookup?loginOrEmail=" + url.QueryEscape(login)
+	code, err := grafanaRequest(ctx, http.MethodGet, path, nil, &user, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return 0, ctx.Log().ErrorfNewErr("Failed looking up Grafana user %s: %v", login, err)
+	}
+	if code == http.StatusOK {
+		return user.ID, nil
+	}
+
+	pw, err := vzpassword.GeneratePassword(32)
+	if err != nil {
+		return 0, err
+	}
+	payload := map[string]string{"name": login, "login": login, "password": pw}
+	if _, err := grafanaRequest(ctx, http.MethodPost, "/api/admin/users", payload, &user, http.StatusOK); err != nil {
+		return 0, ctx.Log().ErrorfNewErr("Failed creating Grafana user %s: %v", login, err)
+	}
+	return user.ID, nil
+}
+
+// getOrCreateGrafanaTeam returns the ID of the Grafana team with the name, creating the team if it does not exist.
+// The members of the team are managed in Grafana.
+func getOrCreateGrafanaTeam(ctx spi.ComponentContext, name string) (int, error) {
+	teams := struct {
+		Teams []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"teams"`
+	}{}
+	path := "/api/teams/search?name=" + url.QueryEscape(name)
+	if _, err := grafanaRequest(ctx, http.MethodGet, path, nil, &teams, http.StatusOK); err != nil {
+		return 0, ctx.Log().ErrorfNewErr("Failed searching Grafana team %s: %v", name, err)
+	}
+	for _, team := range teams.Teams {
+		if team.Name == name {
+			return team.ID, nil
+		}
+	}
+
+	team := struct {
+		TeamID int `json:"teamId"`
+	}{}
+	if _, err := grafanaRequest(ctx, http.MethodPost, "/api/teams", map[string]string{"name": name}, &team, http.StatusOK); err != nil {
+		return 0, ctx.Log().ErrorfNewErr("Failed creating Grafana team %s: %v", name, err)
+	}
+	return team.TeamID, nil
+}
+
+// deleteUnusedProjectFolders deletes the project folders that are not used by a dashboard ConfigMap anymore
+func deleteUnusedProjectFolders(ctx spi.ComponentContext, folders map[string]bool) error {
+	var existing []grafanaSearchResult
+	if _, err := grafanaRequest(ctx, http.MethodGet, "/api/folders", nil, &existing, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing the Grafana folders: %v", err)
+	}
+	for _, folder := range existing {
+		if !strings.HasPrefix(folder.UID, projectFolderUIDPrefix) || folders[folder.UID] {
+			continue
+		}
+		ctx.Log().Infof("Deleting the Grafana folder of project %s, it has no dashboards", folder.Title)
+		if _, err := grafanaRequest(ctx, http.MethodDelete, "/api/folders/"+folder.UID, nil, nil, http.StatusOK, http.StatusNotFound); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting the Grafana folder of project %s: %v", folder.Title, err)
+		}
+	}
+	return nil
+}
+
+// appendDashboardTag adds the application dashboard tag to the tags of a dashboard
+func appendDashboardTag(tags interface{}) []interface{} {
+	result := []interface{}{}
+	if existing, ok := tags.([]interface{}); ok {
+		for _, tag := range existing {
+			if tag == appDashboardTag {
+				return existing
+			}
+		}
+		result = append(result, existing...)
+	}
+	return append(result, appDashboardTag)
+}
+
+// projectFolderUID returns the UID of the Grafana folder of a project, Grafana UIDs are limited to 40 characters
+func projectFolderUID(project string) string {
+	return projectFolderUIDPrefix + shortHash(project)
+}
+
+// appDashboardUID returns the UID of the Grafana dashboard of a ConfigMap key
+func appDashboardUID(namespace string, name string, key string) string {
+	return appDashboardUIDPrefix + shortHash(fmt.Sprintf("%s/%s/%s", namespace, name, key))
+}
+
+func shortHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:24]
+}
+
+// dashboardChecksum returns the checksum of the data of a dashboard ConfigMap
+func dashboardChecksum(cm *corev1.ConfigMap) string {
+	hash := sha256.New()
+	for _, key := range sortedKeys(cm.Data) {
+		hash.Write([]byte(key))
+		hash.Write([]byte(cm.Data[key]))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzappclusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testDashboard = `{"id": 12, "title": "Orders", "tags": ["shop"], "panels": []}`
+
+// fakeGrafana records the dashboards and folders of the Grafana API requests
+type fakeGrafana struct {
+	dashboards  map[string]grafanaSearchResult
+	folders     map[string]grafanaSearchResult
+	permissions map[string][]folderPermission
+	imports     []map[string]interface{}
+}
+
+func newFakeGrafana() *fakeGrafana {
+	return &fakeGrafana{
+		dashboards:  map[string]grafanaSearchResult{},
+		folders:     map[string]grafanaSearchResult{},
+		permissions: map[string][]folderPermission{},
+	}
+}
+
+func (g *fakeGrafana) request(_ spi.ComponentContext, method string, path string, body []byte) (int, []byte, error) {
+	respond := func(v interface{}) (int, []byte, error) {
+		data, err := json.Marshal(v)
+		return http.StatusOK, data, err
+	}
+	switch {
+	case method == http.MethodGet && strings.HasPrefix(path, "/api/search"):
+		results := []grafanaSearchResult{}
+		for _, dashboard := range g.dashboards {
+			results = append(results, dashboard)
+		}
+		return respond(results)
+	case method == http.MethodPost && path == "/api/dashboards/db":
+		payload := map[string]interface{}{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return 0, nil, err
+		}
+		dashboard := payload["dashboard"].(map[string]interface{})
+		if dashboard["title"] == "" {
+			return http.StatusBadRequest, []byte(`{"message":"Dashboard title cannot be empty"}`), nil
+		}
+		uid := dashboard["uid"].(string)
+		g.dashboards[uid] = grafanaSearchResult{UID: uid, Title: dashboard["title"].(string), FolderUID: payload["folderUid"].(string)}
+		g.imports = append(g.imports, payload)
+		return respond(map[string]string{"status": "success"})
+	case method == http.MethodDelete && strings.HasPrefix(path, "/api/dashboards/uid/"):
+		delete(g.dashboards, strings.TrimPrefix(path, "/api/dashboards/uid/"))
+		return respond(map[string]string{})
+	case method == http.MethodGet && path == "/api/folders":
+		results := []grafanaSearchResult{}
+		for _, folder := range g.folders {
+			results = append(results, folder)
+		}
+		return respond(results)
+	case method == http.MethodPost && path == "/api/folders":
+		folder := grafanaSearchResult{}
+		if err := json.Unmarshal(body, &folder); err != nil {
+			return 0, nil, err
+		}
+		g.folders[folder.UID] = folder
+		return respond(folder)
+	case method == http.MethodPost && strings.HasSuffix(path, "/permissions"):
+		payload := struct {
+			Items []folderPermission `json:"items"`
+		}{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return 0, nil, err
+		}
+		g.permissions[strings.TrimSuffix(strings.TrimPrefix(path, "/api/folders/"), "/permissions")] = payload.Items
+		return respond(map[string]string{})
+	case strings.HasPrefix(path, "/api/folders/"):
+		uid := strings.TrimPrefix(path, "/api/folders/")
+		folder, ok := g.folders[uid]
+		if !ok {
+			return http.StatusNotFound, []byte(`{"message":"folder not found"}`), nil
+		}
+		if method == http.MethodDelete {
+			delete(g.folders, uid)
+		}
+		return respond(folder)
+	case strings.HasPrefix(path, "/api/users/lookup"):
+		if strings.HasSuffix(path, "=alice") {
+			return respond(map[string]int{"id": 7})
+		}
+		return http.StatusNotFound, []byte(`{"message":"user not found"}`), nil
+	case path == "/api/admin/users":
+		return respond(map[string]int{"id": 8})
+	case strings.HasPrefix(path, "/api/teams/search"):
+		return respond(map[string]interface{}{"teams": []interface{}{}})
+	case path == "/api/teams":
+		return respond(map[string]int{"teamId": 3})
+	}
+	return 0, nil, fmt.Errorf("unexpected Grafana request %s %s", method, path)
+}
+
+func newDashboardConfigMap(namespace string, name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{vzconst.LabelGrafanaDashboard: "true"},
+		},
+		Data: data,
+	}
+}
+
+func newTestProject(name string, namespaces ...string) *vzappclusters.VerrazzanoProject {
+	project := &vzappclusters.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: vzconst.VerrazzanoMultiClusterNamespace},
+	}
+	for _, ns := range namespaces {
+		project.Spec.Template.Namespaces = append(project.Spec.Template.Namespaces, vzappclusters.NamespaceTemplate{
+			Metadata: metav1.ObjectMeta{Name: ns},
+		})
+	}
+	project.Spec.Template.Security.ProjectAdminSubjects = []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}}
+	project.Spec.Template.Security.ProjectMonitorSubjects = []rbacv1.Subject{
+		{Kind: rbacv1.UserKind, Name: "bob"},
+		{Kind: rbacv1.GroupKind, Name: "operators"},
+	}
+	return project
+}
+
+func getTestConfigMap(t *testing.T, c client.Client, namespace string, name string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, cm))
+	return cm
+}
+
+// TestReconcileApplicationDashboards tests the ReconcileApplicationDashboards function
+// GIVEN labeled dashboard ConfigMaps in the namespaces of a VerrazzanoProject
+// WHEN ReconcileApplicationDashboards is called
+// THEN the dashboards are imported into the project folder, which can be viewed by the project monitors and
+// administered by the project admins
+func TestReconcileApplicationDashboards(t *testing.T) {
+	grafana := newFakeGrafana()
+	grafanaRequestFunc = grafana.request
+	defer func() { grafanaRequestFunc = httpGrafanaRequest }()
+
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		newTestProject("shop", "orders"),
+		newDashboardConfigMap("orders", "dashboards", map[string]string{"orders.json": testDashboard, "README.md": "not a dashboard"}),
+	).Build()
+	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)
+
+	err := ReconcileApplicationDashboards(ctx)
+	assert.NoError(t, err)
+
+	folderUID := projectFolderUID("shop")
+	uid := appDashboardUID("orders", "dashboards", "orders.json")
+	assert.Equal(t, grafanaSearchResult{UID: folderUID, Title: "shop"}, grafana.folders[folderUID])
+	assert.Equal(t, grafanaSearchResult{UID: uid, Title: "Orders", FolderUID: folderUID}, grafana.dashboards[uid])
+	assert.Len(t, grafana.imports, 1)
+	dashboard := grafana.imports[0]["dashboard"].(map[string]interface{})
+	assert.NotContains(t, dashboard, "id")
+	assert.Equal(t, []interface{}{"shop", appDashboardTag}, dashboard["tags"])
+	assert.Equal(t, []folderPermission{
+		{TeamID: 3, Permission: folderPermissionView},
+		{UserID: 7, Permission: folderPermissionAdmin},
+		{UserID: 8, Permission: folderPermissionView},
+	}, grafana.permissions[folderUID])
+
+	cm := getTestConfigMap(t, c, "orders", "dashboards")
+	assert.Equal(t, dashboardChecksum(cm), cm.Annotations[dashboardChecksumAnnotation])
+	assert.NotContains(t, cm.Annotations, dashboardErrorAnnotation)
+
+	// GIVEN the dashboard ConfigMap is unchanged
+	// WHEN ReconcileApplicationDashboards is called again
+	// THEN the dashboard is not imported again
+	err = ReconcileApplicationDashboards(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, grafana.imports, 1)
+
+	// GIVEN the dashboard ConfigMap is deleted
+	// WHEN ReconcileApplicationDashboards is called
+	// THEN the dashboard and the project folder are deleted
+	assert.NoError(t, c.Delete(context.TODO(), cm))
+	err = ReconcileApplicationDashboards(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, grafana.dashboards)
+	assert.Empty(t, grafana.folders)
+}
+
+// TestReconcileApplicationDashboardsProblems tests the ReconcileApplicationDashboards function
+// GIVEN dashboard ConfigMaps with invalid dashboards, or in a namespace that is not in a VerrazzanoProject
+// WHEN ReconcileApplicationDashboards is called
+// THEN the problems are reported in the annotations of the ConfigMaps and the valid dashboards are imported
+func TestReconcileApplicationDashboardsProblems(t *testing.T) {
+	grafana := newFakeGrafana()
+	grafanaRequestFunc = grafana.request
+	defer func() { grafanaRequestFunc = httpGrafanaRequest }()
+
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		newTestProject("shop", "orders"),
+		newDashboardConfigMap("orders", "dashboards", map[string]string{
+			"orders.json":   testDashboard,
+			"invalid.json":  "{",
+			"untitled.json": `{"title": ""}`,
+		}),
+		newDashboardConfigMap("payments", "dashboards", map[string]string{"payments.json": testDashboard}),
+	).Build()
+
+	err := ReconcileApplicationDashboards(spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+	assert.Len(t, grafana.dashboards, 1)
+	assert.Contains(t, grafana.dashboards, appDashboardUID("orders", "dashboards", "orders.json"))
+
+	cm := getTestConfigMap(t, c, "orders", "dashboards")
+	assert.Contains(t, cm.Annotations[dashboardErrorAnnotation], "invalid.json: invalid dashboard JSON")
+	assert.Contains(t, cm.Annotations[dashboardErrorAnnotation], "untitled.json: Failed, Grafana request")
+
+	cm = getTestConfigMap(t, c, "payments", "dashboards")
+	assert.Equal(t, "namespace payments is not in a VerrazzanoProject", cm.Annotations[dashboardErrorAnnotation])
+	assert.NotContains(t, cm.Annotations, dashboardChecksumAnnotation)
+}
+
+// TestReconcileApplicationDashboardsGrafanaError tests the ReconcileApplicationDashboards function
+// GIVEN Grafana cannot be reached
+// WHEN ReconcileApplicationDashboards is called
+// THEN an error is returned
+func TestReconcileApplicationDashboardsGrafanaError(t *testing.T) {
+	grafanaRequestFunc = func(_ spi.ComponentContext, _ string, _ string, _ []byte) (int, []byte, error) {
+		return 0, nil, fmt.Errorf("no ready Grafana pods")
+	}
+	defer func() { grafanaRequestFunc = httpGrafanaRequest }()
+
+	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	err := ReconcileApplicationDashboards(spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false))
+	assert.Error(t, err)
+}
+
+// TestAppendDashboardTag tests the appendDashboardTag function
+// GIVEN the tags of a dashboard
+// WHEN appendDashboardTag is called
+// THEN the application dashboard tag is added once
+func TestAppendDashboardTag(t *testing.T) {
+	assert.Equal(t, []interface{}{appDashboardTag}, appendDashboardTag(nil))
+	assert.Equal(t, []interface{}{"a", appDashboardTag}, appendDashboardTag([]interface{}{"a"}))
+	assert.Equal(t, []interface{}{appDashboardTag, "a"}, appendDashboardTag([]interface{}{appDashboardTag, "a"}))
+	assert.LessOrEqual(t, len(appDashboardUID("a-very-long-namespace-name", "a-very-long-configmap-name", "dashboard.json")), 40)
+}
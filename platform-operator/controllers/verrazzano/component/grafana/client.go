@@ -0,0 +1,100 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+)
+
+const (
+	// PodAppLabel is the app label of the Grafana pods
+	PodAppLabel = "system-grafana"
+
+	// grafanaIngressHostPrefix is the prefix of the host of the Grafana ingress
+	grafanaIngressHostPrefix = "grafana"
+)
+
+// grafanaRequestSig sends a request to the Grafana HTTP API, returning the status code and the body of the response
+type grafanaRequestSig func(ctx spi.ComponentContext, method string, path string, body []byte) (int, []byte, error)
+
+// grafanaRequestFunc is the function used to send Grafana requests, can be overridden for unit testing
+var grafanaRequestFunc grafanaRequestSig = httpGrafanaRequest
+
+// grafanaEndpointFunc returns the endpoint used to send Grafana requests, can be overridden for unit testing
+var grafanaEndpointFunc = getGrafanaEndpoint
+
+// getGrafanaEndpoint returns the endpoint of the Grafana ingress.  The Grafana service only accepts mutual TLS from the
+// mesh, the platform operator sends its requests over TLS to the ingress and authenticates with the VMI credentials.
+// The authproxy passes the VMI user on to Grafana, which is the Grafana admin user.
+func getGrafanaEndpoint(ctx spi.ComponentContext) (*common.IngressEndpoint, error) {
+	return common.GetVMIIngressEndpoint(ctx, grafanaIngressHostPrefix, grafanaCertificateName)
+}
+
+// httpGrafanaRequest sends the request to the Grafana ingress
+func httpGrafanaRequest(ctx spi.ComponentContext, method string, path string, body []byte) (int, []byte, error) {
+	endpoint, err := grafanaEndpointFunc(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := endpoint.NewRequest(context.TODO(), method, path, reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := endpoint.Client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Failed sending Grafana request %s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("Failed reading the response of Grafana request %s %s: %v", method, path, err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// grafanaRequest sends a request with an optional JSON payload, decoding the JSON response into the optional result.
+// An error is returned if the status code is not one of the expected codes.
+func grafanaRequest(ctx spi.ComponentContext, method string, path string, payload interface{}, result interface{}, expectedCodes ...int) (int, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return 0, err
+		}
+	}
+	code, respBody, err := grafanaRequestFunc(ctx, method, path, body)
+	if err != nil {
+		return code, err
+	}
+	expected := false
+	for _, expectedCode := range expectedCodes {
+		if code == expectedCode {
+			expected = true
+			break
+		}
+	}
+	if !expected {
+		return code, fmt.Errorf("Failed, Grafana request %s %s returned status code %d: %s", method, path, code, string(respBody))
+	}
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return code, fmt.Errorf("Failed parsing the response of Grafana request %s %s: %v", method, path, err)
+		}
+	}
+	return code, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package grafana
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testGrafanaHost = "grafana.vmi.system.default.11.22.33.44.nip.io"
+
+// TestHTTPGrafanaRequest tests the httpGrafanaRequest function
+// GIVEN a Grafana HTTP API behind the Grafana ingress
+// WHEN httpGrafanaRequest is called
+// THEN the request is sent over TLS with the VMI credentials in the basic authentication header and the status code
+// and the body of the response are returned
+func TestHTTPGrafanaRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "verrazzano" || password != "changeme" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(r.Host + " " + r.Method + " " + r.URL.RequestURI() + " " + r.Header.Get("Content-Type") + " " + string(body)))
+	}))
+	defer server.Close()
+	grafanaEndpointFunc = func(_ spi.ComponentContext) (*common.IngressEndpoint, error) {
+		return &common.IngressEndpoint{URL: server.URL, Host: testGrafanaHost, Client: server.Client(), Username: "verrazzano", Password: "changeme"}, nil
+	}
+	defer func() { grafanaEndpointFunc = getGrafanaEndpoint }()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), &vzapi.Verrazzano{}, nil, false)
+
+	code, body, err := httpGrafanaRequest(ctx, http.MethodPost, "/api/teams?x=y", []byte(`{"name":"team"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, testGrafanaHost+` POST /api/teams?x=y application/json {"name":"team"}`, string(body))
+
+	// The endpoint can not be built
+	grafanaEndpointFunc = func(_ spi.ComponentContext) (*common.IngressEndpoint, error) {
+		return nil, fmt.Errorf("secret not found")
+	}
+	_, _, err = httpGrafanaRequest(ctx, http.MethodGet, "/api/teams", nil)
+	assert.Error(t, err)
+}
+
+// TestGetGrafanaEndpoint tests the getGrafanaEndpoint function
+// GIVEN a Verrazzano install with the VMI secret
+// WHEN getGrafanaEndpoint is called
+// THEN the endpoint reaches the Grafana ingress host with the VMI credentials
+func TestGetGrafanaEndpoint(t *testing.T) {
+	nginxService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "ingress-controller-ingress-nginx-controller"},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "11.22.33.44"}}},
+		},
+	}
+	vmiSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: constants.VMISecret},
+		Data:       map[string][]byte{"username": []byte("verrazzano"), "password": []byte("changeme")},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(nginxService, vmiSecret).Build()
+	vz := &vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{EnvironmentName: "default"}}
+	endpoint, err := getGrafanaEndpoint(spi.NewFakeContext(c, vz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, testGrafanaHost, endpoint.Host)
+	assert.Equal(t, "verrazzano", endpoint.Username)
+}
+
+// TestGrafanaRequest tests the grafanaRequest function
+// GIVEN a Grafana response
+// WHEN grafanaRequest is called
+// THEN the response is decoded if its status code is expected, otherwise an error is returned
+func TestGrafanaRequest(t *testing.T) {
+	grafanaRequestFunc = func(_ spi.ComponentContext, _ string, _ string, body []byte) (int, []byte, error) {
+		assert.JSONEq(t, `{"name":"team"}`, string(body))
+		return http.StatusConflict, []byte(`{"message":"Team name taken"}`), nil
+	}
+	defer func() { grafanaRequestFunc = httpGrafanaRequest }()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), &vzapi.Verrazzano{}, nil, false)
+
+	result := map[string]string{}
+	code, err := grafanaRequest(ctx, http.MethodPost, "/api/teams", map[string]string{"name": "team"}, &result, http.StatusConflict)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "Team name taken", result["message"])
+
+	code, err = grafanaRequest(ctx, http.MethodPost, "/api/teams", map[string]string{"name": "team"}, nil, http.StatusOK)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusConflict, code)
+}
@@ -11,6 +11,7 @@ import (
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/vmo"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
@@ -32,6 +33,13 @@ const (
 // ComponentJSONName is the json name of the component in the Verrazzano CRD
 const ComponentJSONName = "grafana"
 
+// workloadValues lists the workload settings that are applied to the Grafana spec of the VMI, the VMO does not support
+// scheduling settings for Grafana
+var workloadValues = helm.WorkloadValues{
+	ResourcesKey: "resources",
+	ReplicasKey:  "replicas",
+}
+
 type grafanaComponent struct{}
 
 // NewComponent creates a new Grafana component
@@ -59,6 +67,17 @@ func (g grafanaComponent) GetDependencies() []string {
 	return []string{vmo.ComponentName}
 }
 
+// GetNetworkPolicyRules returns the network peers of the Grafana pods outside of the verrazzano-system namespace,
+// Grafana queries Prometheus
+func (g grafanaComponent) GetNetworkPolicyRules(_ spi.ComponentContext) []spi.NetworkPolicyRules {
+	return []spi.NetworkPolicyRules{
+		{
+			PodLabels: map[string]string{"app": PodAppLabel},
+			Egress:    []spi.NetworkPeer{{Namespace: constants.VerrazzanoMonitoringNamespace, Ports: []int32{9090}}},
+		},
+	}
+}
+
 // GetCertificateNames returns the Grafana certificate names if Nginx is enabled, otherwise returns
 // an empty slice
 func (g grafanaComponent) GetCertificateNames(ctx spi.ComponentContext) []types.NamespacedName {
@@ -101,6 +120,39 @@ func (g grafanaComponent) GetOverrides(object runtime.Object) interface{} {
 	return []installv1beta1.Overrides{}
 }
 
+// Verify that grafanaComponent implements ComponentWorkload
+var _ spi.ComponentWorkload = grafanaComponent{}
+
+// GetWorkload returns the workload settings of Grafana, the Grafana replicas are used when the workload settings do
+// not set the replicas
+func (g grafanaComponent) GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	var workload *vzapi.WorkloadSpec
+	var replicas *int32
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Grafana == nil {
+			return nil
+		}
+		workload = effectiveCR.Spec.Components.Grafana.Workload
+		replicas = effectiveCR.Spec.Components.Grafana.Replicas
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Grafana == nil {
+			return nil
+		}
+		workload = (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.Grafana.Workload)
+		replicas = effectiveCR.Spec.Components.Grafana.Replicas
+	}
+	if replicas == nil || (workload != nil && workload.Replicas != nil) {
+		return workload
+	}
+	if workload == nil {
+		workload = &vzapi.WorkloadSpec{}
+	} else {
+		workload = workload.DeepCopy()
+	}
+	workload.Replicas = replicas
+	return workload
+}
+
 // MonitorOverrides indicates if monitoring of override sources is enabled or not for a component
 func (g grafanaComponent) MonitorOverrides(_ spi.ComponentContext) bool {
 	return true
@@ -133,11 +185,17 @@ func (g grafanaComponent) IsReady(ctx spi.ComponentContext) bool {
 
 // ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
 func (g grafanaComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
+	if err := helm.ValidateWorkload(ComponentName, g.GetWorkload(vz), workloadValues); err != nil {
+		return err
+	}
 	return checkExistingCNEGrafana(vz)
 }
 
 // ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
 func (g grafanaComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) error {
+	if err := helm.ValidateWorkload(ComponentName, g.GetWorkload(vz), workloadValues); err != nil {
+		return err
+	}
 	return checkExistingCNEGrafana(vz)
 }
 
@@ -219,7 +277,7 @@ func (g grafanaComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verra
 	if vzconfig.IsGrafanaEnabled(old) && !vzconfig.IsGrafanaEnabled(new) {
 		return fmt.Errorf("Disabling component Grafana not allowed")
 	}
-	return nil
+	return helm.ValidateWorkload(ComponentName, g.GetWorkload(new), workloadValues)
 }
 
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
@@ -228,7 +286,7 @@ func (g grafanaComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano,
 	if vzconfig.IsGrafanaEnabled(old) && !vzconfig.IsGrafanaEnabled(new) {
 		return fmt.Errorf("Disabling component Grafana not allowed")
 	}
-	return nil
+	return helm.ValidateWorkload(ComponentName, g.GetWorkload(new), workloadValues)
 }
 
 // Reconcile reconciles the Grafana component
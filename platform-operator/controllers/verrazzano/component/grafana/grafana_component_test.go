@@ -345,6 +345,19 @@ func TestValidateUpdate(t *testing.T) {
 	// THEN the function does not return an error
 	newVz.Spec.Components.Grafana.Enabled = &trueValue
 	assert.NoError(t, NewComponent().ValidateUpdate(oldVz, newVz))
+
+	// GIVEN a new VZ with Grafana workload settings that the VMO does not support
+	// WHEN we call the ValidateUpdate function
+	// THEN the function returns an error
+	newVz.Spec.Components.Grafana.Workload = &vzapi.WorkloadSpec{NodeSelector: map[string]string{"node": "grafana"}}
+	assert.ErrorContains(t, NewComponent().ValidateUpdate(oldVz, newVz), "nodeSelector")
+
+	// GIVEN a new VZ with Grafana workload replicas
+	// WHEN we call the ValidateUpdate function
+	// THEN the function does not return an error
+	replicas := int32(2)
+	newVz.Spec.Components.Grafana.Workload = &vzapi.WorkloadSpec{Replicas: &replicas}
+	assert.NoError(t, NewComponent().ValidateUpdate(oldVz, newVz))
 }
 
 // TestValidateUpdateV1beta1 tests the Grafana component ValidateUpdate function
@@ -10,6 +10,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // updateFunc mutates the VMI struct and ensures the Grafana component is configured properly
@@ -38,6 +39,7 @@ func newGrafana(cr *vzapi.Verrazzano, storage *common.ResourceRequestValues, exi
 	} else {
 		grafana.Replicas = int32(1)
 	}
+	setGrafanaWorkload(grafanaSpec.Workload, &grafana)
 	common.SetStorageSize(storage, &grafana.Storage)
 	if existingVMI != nil {
 		// preserve PVC names since these are set by the VMO
@@ -56,3 +58,29 @@ func newGrafana(cr *vzapi.Verrazzano, storage *common.ResourceRequestValues, exi
 	log.Debugf("VMO grafana spec: %v", grafana)
 	return grafana
 }
+
+// setGrafanaWorkload applies the workload settings to the Grafana spec of the VMI, the workload replicas take
+// precedence over the Grafana replicas
+func setGrafanaWorkload(workload *vzapi.WorkloadSpec, grafana *vmov1.Grafana) {
+	if workload == nil {
+		return
+	}
+	if workload.Replicas != nil {
+		grafana.Replicas = *workload.Replicas
+	}
+	if workload.Resources == nil {
+		return
+	}
+	if cpu, ok := workload.Resources.Requests[corev1.ResourceCPU]; ok {
+		grafana.Resources.RequestCPU = cpu.String()
+	}
+	if memory, ok := workload.Resources.Requests[corev1.ResourceMemory]; ok {
+		grafana.Resources.RequestMemory = memory.String()
+	}
+	if cpu, ok := workload.Resources.Limits[corev1.ResourceCPU]; ok {
+		grafana.Resources.LimitCPU = cpu.String()
+	}
+	if memory, ok := workload.Resources.Limits[corev1.ResourceMemory]; ok {
+		grafana.Resources.LimitMemory = memory.String()
+	}
+}
@@ -12,6 +12,8 @@ import (
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var enabled = true
@@ -81,3 +83,27 @@ func TestNewGrafanaWithExistingVMI(t *testing.T) {
 	assert.Equal(t, "50Gi", vmi.Spec.Grafana.Storage.Size)
 	assert.Equal(t, []string{"my-pvc"}, vmi.Spec.Grafana.Storage.PvcNames)
 }
+
+// TestNewGrafanaWithWorkload tests that the workload settings are applied to the Grafana VMO config
+// GIVEN a Verrazzano CR with Grafana replicas and workload settings
+//  WHEN I create new Grafana resource
+//  THEN the workload replicas and resources take precedence over the Grafana settings
+func TestNewGrafanaWithWorkload(t *testing.T) {
+	vmi := vmov1.VerrazzanoMonitoringInstance{}
+	cr := grafanaEnabledCR.DeepCopy()
+	cr.Spec.Components.Grafana.Workload = &vzapi.WorkloadSpec{
+		Replicas: resources.NewVal(3),
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+	}
+
+	ctx := spi.NewFakeContext(nil, cr, nil, false)
+	updateFunc(ctx, nil, &vmi, nil)
+	assert.Equal(t, int32(3), vmi.Spec.Grafana.Replicas)
+	assert.Equal(t, "100m", vmi.Spec.Grafana.Resources.RequestCPU)
+	assert.Equal(t, "48Mi", vmi.Spec.Grafana.Resources.RequestMemory)
+	assert.Equal(t, "512Mi", vmi.Spec.Grafana.Resources.LimitMemory)
+	assert.Empty(t, vmi.Spec.Grafana.Resources.LimitCPU)
+}
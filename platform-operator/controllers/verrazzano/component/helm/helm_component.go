@@ -6,6 +6,9 @@ package helm
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -25,6 +28,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/secret"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/status"
+	"helm.sh/helm/v3/pkg/release"
 	"k8s.io/apimachinery/pkg/types"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -103,11 +107,27 @@ type HelmComponent struct {
 
 	// Certificates associated with the component
 	Certificates []types.NamespacedName
+
+	// NetworkPolicyRules are the network peers of the pods of the component outside of its namespace
+	NetworkPolicyRules []spi.NetworkPolicyRules
+
+	// PodDisruptionBudgets are the replicated pods of the component protected in the high availability mode
+	PodDisruptionBudgets []spi.PodDisruptionBudget
+
+	// WorkloadValues maps the workload settings of the component onto its chart values, nil if the component does not
+	// support workload settings
+	WorkloadValues *WorkloadValues
 }
 
 // Verify that HelmComponent implements Component
 var _ spi.Component = HelmComponent{}
 
+// Verify that HelmComponent implements ComponentWorkload
+var _ spi.ComponentWorkload = HelmComponent{}
+
+// Verify that HelmComponent implements ComponentAvailability
+var _ spi.ComponentAvailability = HelmComponent{}
+
 // preInstallFuncSig is the signature for the optional function to run before installing; any KeyValue pairs should be prepended to the Helm overrides list
 type preInstallFuncSig func(context spi.ComponentContext, releaseName string, namespace string, chartDir string) error
 
@@ -127,7 +147,7 @@ type getInstallOverridesSig func(object runtime.Object) interface{}
 type resolveNamespaceSig func(ns string) string
 
 // upgradeFuncSig is a function needed for unit test override
-type upgradeFuncSig func(log vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (stdout []byte, stderr []byte, err error)
+type upgradeFuncSig func(log vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (*release.Release, error)
 
 // upgradeFunc is the default upgrade function
 var upgradeFunc upgradeFuncSig = helm.Upgrade
@@ -140,6 +160,34 @@ func SetDefaultUpgradeFunc() {
 	upgradeFunc = helm.Upgrade
 }
 
+// getControllerRuntimeClientFuncSig is the signature of the function that gets the client used to read
+// override ConfigMaps and Secrets when validating overrides, needed for unit test override
+type getControllerRuntimeClientFuncSig func() (clipkg.Client, error)
+
+// validationClient is the client used to read override ConfigMaps and Secrets when validating overrides.  It is the
+// controller manager client, so the webhook reads from the same cache as the reconciler.
+var validationClient clipkg.Client
+
+// SetValidationClient sets the client used to read override ConfigMaps and Secrets when validating overrides
+func SetValidationClient(c clipkg.Client) {
+	validationClient = c
+}
+
+// valuesSchemas caches the values schema of each chart by chart directory, nil if the chart has no schema.  The
+// charts do not change while the operator is running.
+var valuesSchemas = map[string]*helmcli.ValuesSchema{}
+var valuesSchemasMutex sync.Mutex
+
+var getControllerRuntimeClient getControllerRuntimeClientFuncSig = getClient
+
+func SetControllerRuntimeClientFunc(f getControllerRuntimeClientFuncSig) {
+	getControllerRuntimeClient = f
+}
+
+func SetDefaultControllerRuntimeClientFunc() {
+	getControllerRuntimeClient = getClient
+}
+
 // UpgradePrehooksEnabled is needed so that higher level units tests can disable as needed
 var UpgradePrehooksEnabled = true
 
@@ -163,6 +211,11 @@ func (h HelmComponent) GetJSONName() string {
 	return h.JSONName
 }
 
+// GetChartDir returns the directory of the chart of the component
+func (h HelmComponent) GetChartDir() string {
+	return h.ChartDir
+}
+
 // GetOverrides returns the list of install overrides for a component
 func (h HelmComponent) GetOverrides(cr runtime.Object) interface{} {
 	if h.GetInstallOverridesFunc != nil {
@@ -195,6 +248,16 @@ func (h HelmComponent) GetCertificateNames(_ spi.ComponentContext) []types.Names
 	return h.Certificates
 }
 
+// GetNetworkPolicyRules returns the network peers of the pods of this component outside of its namespace
+func (h HelmComponent) GetNetworkPolicyRules(_ spi.ComponentContext) []spi.NetworkPolicyRules {
+	return h.NetworkPolicyRules
+}
+
+// GetPodDisruptionBudgets returns the replicated pods of this component protected in the high availability mode
+func (h HelmComponent) GetPodDisruptionBudgets(_ spi.ComponentContext) []spi.PodDisruptionBudget {
+	return h.PodDisruptionBudgets
+}
+
 // GetMinVerrazzanoVersion returns the minimum Verrazzano version required by this component
 func (h HelmComponent) GetMinVerrazzanoVersion() string {
 	if len(h.MinVerrazzanoVersion) == 0 {
@@ -245,38 +308,108 @@ func (h HelmComponent) IsEnabled(effectiveCR runtime.Object) bool {
 	return true
 }
 
-func (h HelmComponent) v1alpha1Validate(vz *v1alpha1.Verrazzano) error {
-	if err := v1alpha1.ValidateInstallOverrides(h.GetOverrides(vz).([]v1alpha1.Overrides)); err != nil {
+func (h HelmComponent) v1alpha1Validate(old *v1alpha1.Verrazzano, vz *v1alpha1.Verrazzano) error {
+	overrides := h.GetOverrides(vz).([]v1alpha1.Overrides)
+	if err := v1alpha1.ValidateInstallOverrides(overrides); err != nil {
 		return err
 	}
-	return nil
+	if err := h.validateWorkload(vz); err != nil {
+		return err
+	}
+	var oldOverrides []v1beta1.Overrides
+	if old != nil {
+		oldOverrides = v1alpha1.ConvertValueOverridesToV1Beta1(h.GetOverrides(old).([]v1alpha1.Overrides))
+	}
+	return h.validateOverridesSchema(oldOverrides, v1alpha1.ConvertValueOverridesToV1Beta1(overrides), vz.Namespace)
 }
 
 // ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
 func (h HelmComponent) ValidateInstall(vz *v1alpha1.Verrazzano) error {
-	return h.v1alpha1Validate(vz)
+	return h.v1alpha1Validate(nil, vz)
 }
 
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
 func (h HelmComponent) ValidateUpdate(old *v1alpha1.Verrazzano, new *v1alpha1.Verrazzano) error {
-	return h.v1alpha1Validate(new)
+	return h.v1alpha1Validate(old, new)
 }
 
-func (h HelmComponent) v1beta1Validate(vz *v1beta1.Verrazzano) error {
-	if err := v1alpha1.ValidateInstallOverridesV1Beta1(h.GetOverrides(vz).([]v1beta1.Overrides)); err != nil {
+func (h HelmComponent) v1beta1Validate(old *v1beta1.Verrazzano, vz *v1beta1.Verrazzano) error {
+	overrides := h.GetOverrides(vz).([]v1beta1.Overrides)
+	if err := v1alpha1.ValidateInstallOverridesV1Beta1(overrides); err != nil {
 		return err
 	}
-	return nil
+	if err := h.validateWorkload(vz); err != nil {
+		return err
+	}
+	var oldOverrides []v1beta1.Overrides
+	if old != nil {
+		oldOverrides = h.GetOverrides(old).([]v1beta1.Overrides)
+	}
+	return h.validateOverridesSchema(oldOverrides, overrides, vz.Namespace)
+}
+
+// validateOverridesSchema validates the install overrides against the values schema of the chart, or the
+// Verrazzano provided schema for the release if the chart does not have one.  On update, only the overrides
+// that changed are validated.
+func (h HelmComponent) validateOverridesSchema(oldOverrides []v1beta1.Overrides, overrides []v1beta1.Overrides, namespace string) error {
+	if len(overrides) == 0 || reflect.DeepEqual(oldOverrides, overrides) {
+		return nil
+	}
+	schema, err := h.getValuesSchema()
+	if err != nil || schema == nil {
+		return err
+	}
+	var client clipkg.Client
+	if hasOverrideRefs(overrides) || hasOverrideRefs(oldOverrides) {
+		client, err = getControllerRuntimeClient()
+		if err != nil {
+			return err
+		}
+	}
+	oldSources, err := common.GetInstallOverrideSourcesUsingClient(client, oldOverrides, namespace)
+	if err != nil {
+		return err
+	}
+	sources, err := common.GetInstallOverrideSourcesUsingClient(client, overrides, namespace)
+	if err != nil {
+		return err
+	}
+	return schema.ValidateOverridesUpdate(oldSources, sources)
+}
+
+// getValuesSchema returns the values schema of the chart, loading it the first time
+func (h HelmComponent) getValuesSchema() (*helmcli.ValuesSchema, error) {
+	valuesSchemasMutex.Lock()
+	defer valuesSchemasMutex.Unlock()
+	if schema, ok := valuesSchemas[h.ChartDir]; ok {
+		return schema, nil
+	}
+	schema, err := helmcli.LoadValuesSchema(h.ChartDir, filepath.Join(config.GetHelmSchemasDir(), h.ReleaseName+".schema.json"))
+	if err != nil {
+		return nil, err
+	}
+	valuesSchemas[h.ChartDir] = schema
+	return schema, nil
+}
+
+// hasOverrideRefs returns true if any of the overrides reference a ConfigMap or Secret
+func hasOverrideRefs(overrides []v1beta1.Overrides) bool {
+	for _, override := range overrides {
+		if override.ConfigMapRef != nil || override.SecretRef != nil {
+			return true
+		}
+	}
+	return false
 }
 
 // ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
 func (h HelmComponent) ValidateInstallV1Beta1(vz *v1beta1.Verrazzano) error {
-	return h.v1beta1Validate(vz)
+	return h.v1beta1Validate(nil, vz)
 }
 
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
 func (h HelmComponent) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1beta1.Verrazzano) error {
-	return h.v1beta1Validate(new)
+	return h.v1beta1Validate(old, new)
 }
 
 func (h HelmComponent) MonitorOverrides(ctx spi.ComponentContext) bool {
@@ -304,7 +437,7 @@ func (h HelmComponent) Install(context spi.ComponentContext) error {
 	}
 
 	// Perform an install using the helm upgrade --install command
-	_, _, err = upgradeFunc(context.Log(), h.ReleaseName, resolvedNamespace, h.ChartDir, h.WaitForInstall, context.IsDryRun(), overrides)
+	_, err = upgradeFunc(context.Log(), h.ReleaseName, resolvedNamespace, h.ChartDir, h.WaitForInstall, context.IsDryRun(), overrides)
 	return err
 }
 
@@ -358,9 +491,9 @@ func (h HelmComponent) Uninstall(context spi.ComponentContext) error {
 		context.Log().Infof("%s already uninstalled", h.Name())
 		return nil
 	}
-	_, stderr, err := helmcli.Uninstall(context.Log(), h.ReleaseName, h.resolveNamespace(context), context.IsDryRun())
+	err = helmcli.Uninstall(context.Log(), h.ReleaseName, h.resolveNamespace(context), context.IsDryRun())
 	if err != nil {
-		context.Log().Errorf("Error uninstalling %s, error: %s, stderr: %s", h.Name(), err.Error(), stderr)
+		context.Log().Errorf("Error uninstalling %s, error: %s", h.Name(), err.Error())
 		return err
 	}
 	return nil
@@ -429,7 +562,7 @@ func (h HelmComponent) Upgrade(context spi.ComponentContext) error {
 	// Generate a list of override files making helm get values overrides first
 	overrides = append([]helm.HelmOverrides{{FileOverride: tmpFile.Name()}}, overrides...)
 
-	_, _, err = upgradeFunc(context.Log(), h.ReleaseName, resolvedNamespace, h.ChartDir, true, context.IsDryRun(), overrides)
+	_, err = upgradeFunc(context.Log(), h.ReleaseName, resolvedNamespace, h.ChartDir, true, context.IsDryRun(), overrides)
 	return err
 }
 
@@ -471,6 +604,20 @@ func (h HelmComponent) buildCustomHelmOverrides(context spi.ComponentContext, na
 		kvs = append(kvs, bom.KeyValue{Value: file.Name(), IsFile: true})
 	}
 
+	// Getting the workload settings of the component, they take precedence over the Verrazzano Helm values
+	workloadOverrides, err := h.getWorkloadOverrides(context.EffectiveCR())
+	if err != nil {
+		return overrides, context.Log().ErrorfNewErr("Failed creating the workload overrides of component %s: %v", h.Name(), err)
+	}
+	if len(workloadOverrides) > 0 {
+		file, err := vzos.CreateTempFile(fmt.Sprintf("helm-overrides-workload-%s-*.yaml", h.Name()), []byte(workloadOverrides))
+		if err != nil {
+			context.Log().Error(err.Error())
+			return overrides, err
+		}
+		kvs = append(kvs, bom.KeyValue{Value: file.Name(), IsFile: true})
+	}
+
 	// Create files from the Verrazzano Helm values
 	newKvs, err := h.filesFromVerrazzanoHelm(context, namespace, additionalValues)
 	if err != nil {
@@ -642,3 +789,11 @@ func GetInstallArgs(args []v1alpha1.InstallArgs) []bom.KeyValue {
 	}
 	return installArgs
 }
+
+// getClient returns the client used to read override ConfigMaps and Secrets when validating overrides
+func getClient() (clipkg.Client, error) {
+	if validationClient == nil {
+		return nil, fmt.Errorf("No client is available to read the install overrides")
+	}
+	return validationClient, nil
+}
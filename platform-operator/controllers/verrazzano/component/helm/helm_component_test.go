@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -17,11 +16,14 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
+	"helm.sh/helm/v3/pkg/release"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8scheme "k8s.io/client-go/kubernetes/scheme"
@@ -32,19 +34,8 @@ import (
 // Needed for unit tests
 var fakeOverrides []string
 
-// helmFakeRunner is used to test helm without actually running an OS exec command
-type helmFakeRunner struct {
-}
-
 const testBomFilePath = "../../testdata/test_bom.json"
 
-// genericHelmTestRunner is used to run generic OS commands with expected results
-type genericHelmTestRunner struct {
-	stdOut []byte
-	stdErr []byte
-	err    error
-}
-
 var testScheme = runtime.NewScheme()
 
 func init() {
@@ -55,11 +46,6 @@ func init() {
 	// +kubebuilder:scaffold:testScheme
 }
 
-// Run genericHelmTestRunner executor
-func (r genericHelmTestRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return r.stdOut, r.stdErr, r.err
-}
-
 // TestGetName tests the component name
 // GIVEN a Verrazzano component
 //  WHEN I call Name
@@ -97,8 +83,8 @@ func TestUpgrade(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(newRancherActionConfigFn())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -125,17 +111,13 @@ func TestUpgradeIsInstalledUnexpectedError(t *testing.T) {
 
 	comp := HelmComponent{}
 
-	SetUpgradeFunc(func(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (stdout []byte, stderr []byte, err error) {
-		return nil, nil, nil
+	SetUpgradeFunc(func(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (*release.Release, error) {
+		return nil, nil
 	})
 	defer SetDefaultUpgradeFunc()
 
-	helm.SetCmdRunner(genericHelmTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte("What happened?"),
-		err:    fmt.Errorf("Unexpected error"),
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewFailingActionConfigFunction(fmt.Errorf("Unexpected error")))
+	defer helm.SetDefaultActionConfigFunction()
 
 	err := comp.Upgrade(spi.NewFakeContext(nil, &v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "foo"}}, nil, false))
 	a.Error(err)
@@ -148,13 +130,18 @@ func TestUpgradeIsInstalledUnexpectedError(t *testing.T) {
 func TestUpgradeReleaseNotInstalled(t *testing.T) {
 	a := assert.New(t)
 
-	comp := HelmComponent{}
+	comp := HelmComponent{
+		ReleaseName:             "rancher",
+		ChartNamespace:          "chartNS",
+		IgnoreNamespaceOverride: true,
+	}
 
-	SetUpgradeFunc(func(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (stdout []byte, stderr []byte, err error) {
-		return nil, nil, nil
+	SetUpgradeFunc(func(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (*release.Release, error) {
+		return nil, fmt.Errorf("Upgrade should not be called")
 	})
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	defer SetDefaultUpgradeFunc()
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 	config.SetDefaultBomFilePath(testBomFilePath)
 	defer config.SetDefaultBomFilePath("")
 
@@ -201,8 +188,8 @@ func TestUpgradeWithEnvOverrides(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(newRancherActionConfigFn())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -238,8 +225,8 @@ func TestInstall(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(newRancherActionConfigFn())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -286,8 +273,8 @@ func TestInstallWithAllOverride(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(newRancherActionConfigFn())
+	defer helm.SetDefaultActionConfigFunction()
 
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
@@ -332,8 +319,8 @@ func TestInstallPreviousFailure(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(newRancherActionConfigFn())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -375,8 +362,8 @@ func TestInstallWithPreInstallFunc(t *testing.T) {
 	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(newRancherActionConfigFn())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -426,24 +413,20 @@ func TestGetDependencies(t *testing.T) {
 func TestIsInstalled(t *testing.T) {
 	a := assert.New(t)
 
-	comp := HelmComponent{}
+	comp := HelmComponent{
+		ReleaseName:             "rancher",
+		ChartNamespace:          "chartNS",
+		IgnoreNamespaceOverride: true,
+	}
 	defer helm.SetDefaultChartStatusFunction()
 	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
 
-	helm.SetCmdRunner(genericHelmTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte(""),
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(newRancherActionConfigFn())
+	defer helm.SetDefaultActionConfigFunction()
 	config.SetDefaultBomFilePath(testBomFilePath)
 	defer config.SetDefaultBomFilePath("")
 	a.True(comp.IsInstalled(spi.NewFakeContext(nil, &v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "foo"}}, nil, false)))
-	helm.SetCmdRunner(genericHelmTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte(""),
-		err:    fmt.Errorf("Not installed"),
-	})
+	helm.SetActionConfigFunction(helm.NewFailingActionConfigFunction(fmt.Errorf("Not installed")))
 	a.False(comp.IsInstalled(spi.NewFakeContext(client, &v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "foo"}}, nil, false)))
 }
 
@@ -756,29 +739,29 @@ func TestFilesFromVerrazzanoHelm(t *testing.T) {
 }
 
 // fakeUpgrade verifies that the correct parameter values are passed to upgrade
-func fakeUpgrade(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (stdout []byte, stderr []byte, err error) {
+func fakeUpgrade(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (*release.Release, error) {
 	if releaseName != "rancher" {
-		return []byte("error"), []byte(""), errors.New("Invalid release name")
+		return nil, errors.New("Invalid release name")
 	}
 	if chartDir != "ChartDir" {
-		return []byte("error"), []byte(""), errors.New("Invalid chart directory name")
+		return nil, errors.New("Invalid chart directory name")
 	}
 	if namespace != "chartNS" {
-		return []byte("error"), []byte(""), errors.New("Invalid chart namespace")
+		return nil, errors.New("Invalid chart namespace")
 	}
 
 	for _, override := range overrides {
 		if override.FileOverride == "" {
-			return []byte("error"), []byte(""), errors.New("found empty filename or non-file override")
+			return nil, errors.New("found empty filename or non-file override")
 		}
 
 	}
-	return []byte("success"), []byte(""), nil
+	return helm.NewFakeRelease(releaseName, namespace, release.StatusDeployed, "", nil), nil
 }
 
-// helmFakeRunner overrides the helm run command
-func (r helmFakeRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return []byte("success"), []byte(""), nil
+// newRancherActionConfigFn returns a Helm action configuration function with the rancher release deployed
+func newRancherActionConfigFn() helm.ActionConfigFnType {
+	return helm.NewFakeActionConfigFunction(helm.NewFakeRelease("rancher", "chartNS", release.StatusDeployed, "", nil))
 }
 
 func fakePreUpgrade(log vzlog.VerrazzanoLogger, client clipkg.Client, release string, namespace string, chartDir string) error {
@@ -794,3 +777,161 @@ func fakePreUpgrade(log vzlog.VerrazzanoLogger, client clipkg.Client, release st
 
 	return nil
 }
+
+// newOverridesSchemaComponent returns an AuthProxy Helm component, which uses the Verrazzano provided values schema,
+// with the specified install overrides
+func newOverridesSchemaComponent(overrides []v1beta1.Overrides) HelmComponent {
+	return HelmComponent{
+		ReleaseName: "verrazzano-authproxy",
+		ChartDir:    "../../../../helm_config/charts/verrazzano-authproxy",
+		GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+			if _, ok := object.(*v1alpha1.Verrazzano); ok {
+				var v1alpha1Overrides []v1alpha1.Overrides
+				for _, override := range overrides {
+					v1alpha1Overrides = append(v1alpha1Overrides, v1alpha1.Overrides{
+						ConfigMapRef: override.ConfigMapRef,
+						SecretRef:    override.SecretRef,
+						Values:       override.Values,
+					})
+				}
+				return v1alpha1Overrides
+			}
+			return overrides
+		},
+	}
+}
+
+// TestValidateOverridesSchema tests ValidateInstallV1Beta1 and ValidateUpdate
+// GIVEN a component with install overrides from inline values and a ConfigMap that match the values schema
+// WHEN I call ValidateInstallV1Beta1 and ValidateUpdate
+// THEN no error is returned
+func TestValidateOverridesSchema(t *testing.T) {
+	config.TestHelmConfigDir = "../../../../helm_config"
+	defer func() { config.TestHelmConfigDir = "" }()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "authproxy-overrides", Namespace: "default"},
+		Data:       map[string]string{"values.yaml": "proxy:\n  MaxRequestSize: 10m\n"},
+	}
+	SetControllerRuntimeClientFunc(func() (clipkg.Client, error) {
+		return fake.NewClientBuilder().WithScheme(testScheme).WithObjects(cm).Build(), nil
+	})
+	defer SetDefaultControllerRuntimeClientFunc()
+
+	comp := newOverridesSchemaComponent([]v1beta1.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 2}`)}},
+		{ConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "authproxy-overrides"}, Key: "values.yaml"}},
+	})
+	vz := &v1beta1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "default"}}
+	assert.NoError(t, comp.ValidateInstallV1Beta1(vz))
+	vzV1Alpha1 := &v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "default"}}
+	assert.NoError(t, comp.ValidateUpdate(vzV1Alpha1, vzV1Alpha1))
+}
+
+// TestValidateOverridesSchemaInvalid tests ValidateInstallV1Beta1 and ValidateInstall
+// GIVEN a component with install overrides that have an unknown top-level key and a type mismatch
+// WHEN I call ValidateInstallV1Beta1 and ValidateInstall
+// THEN an error is returned that names the override source of each problem
+func TestValidateOverridesSchemaInvalid(t *testing.T) {
+	config.TestHelmConfigDir = "../../../../helm_config"
+	defer func() { config.TestHelmConfigDir = "" }()
+	sec := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "authproxy-overrides", Namespace: "default"},
+		Data:       map[string][]byte{"overrides": []byte("replicas: two\n")},
+	}
+	SetControllerRuntimeClientFunc(func() (clipkg.Client, error) {
+		return fake.NewClientBuilder().WithScheme(testScheme).WithObjects(sec).Build(), nil
+	})
+	defer SetDefaultControllerRuntimeClientFunc()
+
+	comp := newOverridesSchemaComponent([]v1beta1.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replica": 2}`)}},
+		{SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "authproxy-overrides"}, Key: "overrides"}},
+	})
+	err := comp.ValidateInstallV1Beta1(&v1beta1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "default"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "overrides[0].values: unknown top-level key \"replica\"")
+	assert.Contains(t, err.Error(), "Secret authproxy-overrides key overrides: replicas: Invalid type")
+
+	err = comp.ValidateInstall(&v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "default"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "overrides[0].values: unknown top-level key \"replica\"")
+}
+
+// TestValidateOverridesSchemaClientError tests ValidateInstallV1Beta1
+// GIVEN a component with install overrides from a ConfigMap
+// WHEN I call ValidateInstallV1Beta1 and the client cannot be created
+// THEN an error is returned
+func TestValidateOverridesSchemaClientError(t *testing.T) {
+	config.TestHelmConfigDir = "../../../../helm_config"
+	defer func() { config.TestHelmConfigDir = "" }()
+	SetControllerRuntimeClientFunc(func() (clipkg.Client, error) {
+		return nil, fmt.Errorf("no cluster")
+	})
+	defer SetDefaultControllerRuntimeClientFunc()
+
+	comp := newOverridesSchemaComponent([]v1beta1.Overrides{
+		{ConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "authproxy-overrides"}, Key: "values.yaml"}},
+	})
+	assert.Error(t, comp.ValidateInstallV1Beta1(&v1beta1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "default"}}))
+}
+
+// TestValidateOverridesSchemaUpdate tests ValidateUpdateV1Beta1
+// GIVEN existing install overrides that do not match the values schema
+// WHEN I call ValidateUpdateV1Beta1 with the same overrides, and with a new override that is not valid
+// THEN the update is only rejected because of the new override
+func TestValidateOverridesSchemaUpdate(t *testing.T) {
+	config.TestHelmConfigDir = "../../../../helm_config"
+	defer func() { config.TestHelmConfigDir = "" }()
+
+	invalid := v1beta1.Overrides{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replica": 2}`)}}
+	oldComp := newOverridesSchemaComponent([]v1beta1.Overrides{invalid})
+	vz := &v1beta1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "default"}}
+	assert.NoError(t, oldComp.ValidateUpdateV1Beta1(vz, vz))
+
+	newComp := newOverridesSchemaComponent([]v1beta1.Overrides{
+		invalid,
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 2}`)}},
+	})
+	newComp.GetInstallOverridesFunc = overridesByVerrazzano(vz, oldComp, newComp)
+	newVZ := vz.DeepCopy()
+	assert.NoError(t, newComp.ValidateUpdateV1Beta1(vz, newVZ))
+
+	newComp = newOverridesSchemaComponent([]v1beta1.Overrides{
+		invalid,
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": "two"}`)}},
+	})
+	newComp.GetInstallOverridesFunc = overridesByVerrazzano(vz, oldComp, newComp)
+	err := newComp.ValidateUpdateV1Beta1(vz, newVZ)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "overrides[1].values: replicas: Invalid type")
+	assert.NotContains(t, err.Error(), "replica\"")
+}
+
+// overridesByVerrazzano returns an install overrides function that returns the overrides of the old component for
+// the old Verrazzano resource, and the overrides of the new component otherwise
+func overridesByVerrazzano(old runtime.Object, oldComp HelmComponent, newComp HelmComponent) func(object runtime.Object) interface{} {
+	newFunc := newComp.GetInstallOverridesFunc
+	return func(object runtime.Object) interface{} {
+		if object == old {
+			return oldComp.GetInstallOverridesFunc(object)
+		}
+		return newFunc(object)
+	}
+}
+
+// TestValidateOverridesSchemaNoClient tests ValidateInstallV1Beta1
+// GIVEN a component with install overrides from a ConfigMap
+// WHEN I call ValidateInstallV1Beta1 and the validation client has not been set
+// THEN an error is returned
+func TestValidateOverridesSchemaNoClient(t *testing.T) {
+	config.TestHelmConfigDir = "../../../../helm_config"
+	defer func() { config.TestHelmConfigDir = "" }()
+	SetValidationClient(nil)
+
+	comp := newOverridesSchemaComponent([]v1beta1.Overrides{
+		{ConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "authproxy-overrides"}, Key: "values.yaml"}},
+	})
+	err := comp.ValidateInstallV1Beta1(&v1beta1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "default"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "No client is available")
+}
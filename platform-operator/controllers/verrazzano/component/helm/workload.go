@@ -0,0 +1,130 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// WorkloadValues maps the workload settings of a component onto the values of its Helm chart.  The keys are dotted
+// paths in the chart values, an empty key means that the chart does not support the setting.
+type WorkloadValues struct {
+	// GetWorkloadFunc returns the workload settings of the component from a v1alpha1 or v1beta1 Verrazzano resource
+	GetWorkloadFunc func(cr runtime.Object) *v1alpha1.WorkloadSpec
+
+	ResourcesKey         string
+	ReplicasKey          string
+	NodeSelectorKey      string
+	TolerationsKey       string
+	PriorityClassNameKey string
+}
+
+// GetWorkload returns the workload settings of the component, nil if the component does not support them or none
+// are set
+func (h HelmComponent) GetWorkload(cr runtime.Object) *v1alpha1.WorkloadSpec {
+	if h.WorkloadValues == nil || h.WorkloadValues.GetWorkloadFunc == nil {
+		return nil
+	}
+	return h.WorkloadValues.GetWorkloadFunc(cr)
+}
+
+// validateWorkload returns an error if the workload settings of the component include settings that the chart of
+// the component does not support
+func (h HelmComponent) validateWorkload(cr runtime.Object) error {
+	if h.WorkloadValues == nil {
+		return nil
+	}
+	return ValidateWorkload(h.Name(), h.GetWorkload(cr), *h.WorkloadValues)
+}
+
+// ValidateWorkload returns an error if the workload settings include settings that have no key in the workload values,
+// it is used by the components that validate the workload settings outside of the Helm component
+func ValidateWorkload(componentName string, workload *v1alpha1.WorkloadSpec, values WorkloadValues) error {
+	if workload == nil {
+		return nil
+	}
+	var unsupported []string
+	if workload.Resources != nil && values.ResourcesKey == "" {
+		unsupported = append(unsupported, "resources")
+	}
+	if workload.Replicas != nil && values.ReplicasKey == "" {
+		unsupported = append(unsupported, "replicas")
+	}
+	if len(workload.NodeSelector) > 0 && values.NodeSelectorKey == "" {
+		unsupported = append(unsupported, "nodeSelector")
+	}
+	if len(workload.Tolerations) > 0 && values.TolerationsKey == "" {
+		unsupported = append(unsupported, "tolerations")
+	}
+	if workload.PriorityClassName != "" && values.PriorityClassNameKey == "" {
+		unsupported = append(unsupported, "priorityClassName")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("The %s component does not support the workload settings: %s", componentName, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// getWorkloadOverrides returns the Helm values YAML of the workload settings of the component, empty if none are set
+func (h HelmComponent) getWorkloadOverrides(cr runtime.Object) (string, error) {
+	if h.WorkloadValues == nil {
+		return "", nil
+	}
+	return GetWorkloadOverrides(h.GetWorkload(cr), *h.WorkloadValues)
+}
+
+// GetWorkloadOverrides returns the Helm values YAML of the workload settings at the keys of the workload values, empty
+// if none are set
+func GetWorkloadOverrides(workload *v1alpha1.WorkloadSpec, keys WorkloadValues) (string, error) {
+	if workload == nil {
+		return "", nil
+	}
+	values := map[string]interface{}{}
+	if workload.Resources != nil {
+		setWorkloadValue(values, keys.ResourcesKey, workload.Resources)
+	}
+	if workload.Replicas != nil {
+		setWorkloadValue(values, keys.ReplicasKey, *workload.Replicas)
+	}
+	if len(workload.NodeSelector) > 0 {
+		setWorkloadValue(values, keys.NodeSelectorKey, workload.NodeSelector)
+	}
+	if len(workload.Tolerations) > 0 {
+		setWorkloadValue(values, keys.TolerationsKey, workload.Tolerations)
+	}
+	if workload.PriorityClassName != "" {
+		setWorkloadValue(values, keys.PriorityClassNameKey, workload.PriorityClassName)
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// setWorkloadValue sets the value at the dotted key path in the chart values, the settings the chart does not support
+// are skipped
+func setWorkloadValue(values map[string]interface{}, key string, value interface{}) {
+	if key == "" {
+		return
+	}
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := values[part].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			values[part] = child
+		}
+		values = child
+	}
+	values[parts[len(parts)-1]] = value
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// newWorkloadComponent returns a component that maps the workload settings of the ingress controller onto nested
+// chart values, and does not support priority classes
+func newWorkloadComponent() HelmComponent {
+	return HelmComponent{
+		ReleaseName: "ingress-controller",
+		WorkloadValues: &WorkloadValues{
+			GetWorkloadFunc: func(cr runtime.Object) *v1alpha1.WorkloadSpec {
+				if vz, ok := cr.(*v1beta1.Verrazzano); ok {
+					return (*v1alpha1.WorkloadSpec)(vz.Spec.Components.IngressNGINX.Workload)
+				}
+				return cr.(*v1alpha1.Verrazzano).Spec.Components.Ingress.Workload
+			},
+			ResourcesKey:    "controller.resources",
+			ReplicasKey:     "controller.replicaCount",
+			NodeSelectorKey: "controller.nodeSelector",
+			TolerationsKey:  "controller.tolerations",
+		},
+	}
+}
+
+func newWorkloadCR(workload *v1alpha1.WorkloadSpec) *v1alpha1.Verrazzano {
+	return &v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{
+			Components: v1alpha1.ComponentSpec{
+				Ingress: &v1alpha1.IngressNginxComponent{Workload: workload},
+			},
+		},
+	}
+}
+
+// TestGetWorkloadOverrides tests the getWorkloadOverrides function
+// GIVEN a component with workload settings
+// WHEN getWorkloadOverrides is called
+// THEN the settings are returned at their chart value keys
+func TestGetWorkloadOverrides(t *testing.T) {
+	replicas := int32(3)
+	vz := newWorkloadCR(&v1alpha1.WorkloadSpec{
+		Replicas: &replicas,
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		NodeSelector: map[string]string{"ingress": "true"},
+		Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+	})
+
+	overrides, err := newWorkloadComponent().getWorkloadOverrides(vz)
+	assert.NoError(t, err)
+	values := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(overrides), &values))
+	assert.Equal(t, map[string]interface{}{
+		"controller": map[string]interface{}{
+			"replicaCount": float64(3),
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"memory": "512Mi"},
+			},
+			"nodeSelector": map[string]interface{}{"ingress": "true"},
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "dedicated", "operator": "Exists"},
+			},
+		},
+	}, values)
+}
+
+// TestGetWorkloadOverridesNotSet tests the getWorkloadOverrides function
+// GIVEN a component without workload settings, or without workload support
+// WHEN getWorkloadOverrides is called
+// THEN no overrides are returned
+func TestGetWorkloadOverridesNotSet(t *testing.T) {
+	overrides, err := newWorkloadComponent().getWorkloadOverrides(newWorkloadCR(nil))
+	assert.NoError(t, err)
+	assert.Empty(t, overrides)
+
+	replicas := int32(2)
+	overrides, err = HelmComponent{}.getWorkloadOverrides(newWorkloadCR(&v1alpha1.WorkloadSpec{Replicas: &replicas}))
+	assert.NoError(t, err)
+	assert.Empty(t, overrides)
+}
+
+// TestValidateWorkload tests ValidateInstall and ValidateInstallV1Beta1
+// GIVEN a component whose chart does not support priority classes
+// WHEN the workload settings of the component set a priority class
+// THEN an error is returned that names the setting
+func TestValidateWorkload(t *testing.T) {
+	comp := newWorkloadComponent()
+	replicas := int32(2)
+	assert.NoError(t, comp.ValidateInstall(newWorkloadCR(&v1alpha1.WorkloadSpec{Replicas: &replicas})))
+
+	err := comp.ValidateInstall(newWorkloadCR(&v1alpha1.WorkloadSpec{PriorityClassName: "high"}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "priorityClassName")
+
+	vz := &v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{
+			Components: v1beta1.ComponentSpec{
+				IngressNGINX: &v1beta1.IngressNginxComponent{
+					Workload: &v1beta1.WorkloadSpec{PriorityClassName: "high"},
+				},
+			},
+		},
+	}
+	err = comp.ValidateInstallV1Beta1(vz)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "priorityClassName")
+}
+
+// TestBuildCustomHelmOverridesWithWorkload tests the buildCustomHelmOverrides function
+// GIVEN a component with workload settings and user overrides
+// WHEN buildCustomHelmOverrides is called
+// THEN the workload overrides file is passed to Helm, with a lower precedence than the user overrides
+func TestBuildCustomHelmOverridesWithWorkload(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	replicas := int32(3)
+	vz := newWorkloadCR(&v1alpha1.WorkloadSpec{Replicas: &replicas})
+	comp := newWorkloadComponent()
+	comp.IgnoreImageOverrides = true
+	comp.GetInstallOverridesFunc = func(_ runtime.Object) interface{} {
+		return []v1alpha1.Overrides{{Values: &apiextensionsv1.JSON{Raw: []byte(`{"controller": {"replicaCount": 5}}`)}}}
+	}
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), vz, nil, false)
+
+	overrides, err := comp.buildCustomHelmOverrides(ctx, "default")
+	assert.NoError(t, err)
+	var files []string
+	for _, override := range overrides {
+		if override.FileOverride != "" {
+			files = append(files, override.FileOverride)
+			defer os.Remove(override.FileOverride)
+		}
+	}
+	// Helm precedence is right to left, the user overrides file is last
+	assert.Len(t, files, 3)
+	assert.True(t, strings.Contains(files[1], "helm-overrides-workload-ingress-controller-"))
+	assert.True(t, strings.Contains(files[2], "helm-overrides-user-ingress-controller-"))
+	data, err := os.ReadFile(files[1])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "replicaCount: 3")
+}
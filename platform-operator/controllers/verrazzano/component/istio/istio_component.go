@@ -112,6 +112,37 @@ func (i istioComponent) GetOverrides(object runtime.Object) interface{} {
 	return []installv1beta1.Overrides{}
 }
 
+// Verify that istioComponent implements ComponentWorkload
+var _ spi.ComponentWorkload = istioComponent{}
+
+// GetWorkload returns the workload settings of the Istio ingress gateway, the replicas of the v1alpha1 ingress
+// Kubernetes settings are used when the workload settings do not set the replicas
+func (i istioComponent) GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		istio := effectiveCR.Spec.Components.Istio
+		if istio == nil {
+			return nil
+		}
+		if istio.Ingress == nil || istio.Ingress.Kubernetes == nil || istio.Ingress.Kubernetes.Replicas == 0 {
+			return istio.Workload
+		}
+		workload := &vzapi.WorkloadSpec{}
+		if istio.Workload != nil {
+			workload = istio.Workload.DeepCopy()
+		}
+		if workload.Replicas == nil {
+			replicas := int32(istio.Ingress.Kubernetes.Replicas)
+			workload.Replicas = &replicas
+		}
+		return workload
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Istio != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.Istio.Workload)
+		}
+	}
+	return nil
+}
+
 // MonitorOverrides indicates whether monitoring of override sources is enabled for a component
 func (i istioComponent) MonitorOverrides(ctx spi.ComponentContext) bool {
 	if ctx.EffectiveCR().Spec.Components.Istio == nil {
@@ -148,7 +179,7 @@ func SetDefaultIstioUninstallFunction() {
 	istioUninstallFunc = istio.Uninstall
 }
 
-type helmUninstallFuncSig func(log vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) (stdout []byte, stderr []byte, err error)
+type helmUninstallFuncSig func(log vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) error
 
 var helmUninstallFunction helmUninstallFuncSig = helm.Uninstall
 
@@ -179,7 +210,23 @@ func (i istioComponent) PreUninstall(_ spi.ComponentContext) error {
 // Uninstall processing for Istio
 func (i istioComponent) Uninstall(context spi.ComponentContext) error {
 	_, _, err := istioUninstallFunc(context.Log())
-	return err
+	if err != nil {
+		return err
+	}
+	// Uninstall the control planes installed by revision upgrades
+	revisions, err := getInstalledRevisions(context.Client())
+	if err != nil {
+		return err
+	}
+	for revision := range revisions {
+		if revision == istio.DefaultRevision {
+			continue
+		}
+		if _, _, err := uninstallRevisionFunc(context.Log(), revision); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // PostUninstall processing for Istio
@@ -271,6 +318,9 @@ func (i istioComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazz
 	if i.IsEnabled(old) && !i.IsEnabled(new) {
 		return fmt.Errorf("Disabling component %s is not allowed", ComponentJSONName)
 	}
+	if err := validateUpgradeStrategyUpdate(old, new); err != nil {
+		return err
+	}
 	// Validate install overrides
 	if new.Spec.Components.Istio != nil {
 		if err := vzapi.ValidateInstallOverrides(new.Spec.Components.Istio.ValueOverrides); err != nil {
@@ -285,6 +335,9 @@ func (i istioComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano, ne
 	if i.IsEnabled(old) && !i.IsEnabled(new) {
 		return fmt.Errorf("Disabling component %s is not allowed", ComponentJSONName)
 	}
+	if err := validateUpgradeStrategyUpdate(old, new); err != nil {
+		return err
+	}
 	// Validate install overrides
 	if new.Spec.Components.Istio != nil {
 		if err := vzapi.ValidateInstallOverridesV1Beta1(new.Spec.Components.Istio.ValueOverrides); err != nil {
@@ -308,6 +361,15 @@ func (i istioComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) er
 	return i.validateForExternalIPSWithNodePortV1Beta1(&vz.Spec)
 }
 
+// validateUpgradeStrategyUpdate checks that the upgrade strategy is not changed back to InPlace, the Istio control
+// plane may have been installed as a revision which is not upgraded in place
+func validateUpgradeStrategyUpdate(old runtime.Object, new runtime.Object) error {
+	if IsRevisionUpgrade(old) && !IsRevisionUpgrade(new) {
+		return fmt.Errorf("Changing the upgrade strategy of component %s from Revision is not allowed", ComponentJSONName)
+	}
+	return nil
+}
+
 // validateForExternalIPSWithNodePort checks that externalIPs are set when Type=NodePort
 func (i istioComponent) validateForExternalIPSWithNodePort(vz *vzapi.VerrazzanoSpec) error {
 	// good if istio or istio.ingress is not set
@@ -361,19 +423,55 @@ func (i istioComponent) Upgrade(context spi.ComponentContext) error {
 	if err != nil {
 		return err
 	}
+
+	revision := istio.DefaultRevision
+	if IsRevisionUpgrade(context.EffectiveCR()) {
+		revision, err = getTargetRevision(context.Client())
+		if err != nil {
+			return err
+		}
+		if revision != istio.DefaultRevision {
+			// Install the control plane of the new version as a revision alongside the old one
+			overrideStrings = appendOverrideString(overrideStrings, "revision="+revision)
+		}
+	}
 	_, _, err = upgradeFunc(log, overrideStrings, istioTempFiles...)
 	if err != nil {
 		return err
 	}
 
-	return err
+	if revision != istio.DefaultRevision {
+		// New pods of the namespaces labeled with istio-injection=enabled get the proxy of the new revision,
+		// the existing pods keep using the old revision until they are restarted
+		if _, stderr, err := setDefaultTagFunc(log, revision); err != nil {
+			return log.ErrorfNewErr("Failed to point the Istio default tag to revision %s: %v stderr: %s", revision, err, string(stderr))
+		}
+	}
+	return nil
+}
+
+// appendOverrideString appends an override to a comma separated string of overrides
+func appendOverrideString(overrideStrings string, override string) string {
+	if len(overrideStrings) == 0 {
+		return override
+	}
+	return overrideStrings + "," + override
 }
 
 func (i istioComponent) IsReady(context spi.ComponentContext) bool {
 	prefix := fmt.Sprintf("Component %s", context.GetComponent())
+	istiodDeployment := IstiodDeployment
+	if IsRevisionUpgrade(context.EffectiveCR()) {
+		revision, err := getTargetRevision(context.Client())
+		if err != nil {
+			context.Log().ErrorfThrottled("Unexpected error getting the Istio revision: %s", err)
+			return false
+		}
+		istiodDeployment = getIstiodDeploymentName(revision)
+	}
 	deployments := []types.NamespacedName{
 		{
-			Name:      IstiodDeployment,
+			Name:      istiodDeployment,
 			Namespace: IstioNamespace,
 		},
 		{
@@ -429,6 +527,10 @@ func (i istioComponent) PreUpgrade(context spi.ComponentContext) error {
 			return err
 		}
 	}
+	if IsRevisionUpgrade(context.EffectiveCR()) {
+		// The sidecar injector of the old revision is used until the new revision is installed
+		return nil
+	}
 	//Upgrading Istio may result in a duplicate mutating webhook configuration. Istioctl will recreate the webhook during upgrade.
 	return webhook.DeleteMutatingWebhookConfiguration(context.Log(), context.Client(), istioSidecarMutatingWebhook)
 }
@@ -447,7 +549,13 @@ func (i istioComponent) PostUpgrade(context spi.ComponentContext) error {
 }
 
 func (i istioComponent) Reconcile(ctx spi.ComponentContext) error {
-	return i.Upgrade(ctx)
+	if err := i.Upgrade(ctx); err != nil {
+		return err
+	}
+	if IsRevisionUpgrade(ctx.EffectiveCR()) {
+		return RemoveUnusedRevisions(ctx.Log(), ctx.Client())
+	}
+	return nil
 }
 
 // GetIngressNames returns the list of ingress names associated with the component
@@ -460,6 +568,30 @@ func (i istioComponent) GetCertificateNames(_ spi.ComponentContext) []types.Name
 	return []types.NamespacedName{}
 }
 
+// GetNetworkPolicyRules returns the network peers of the Istio pods outside of the istio-system namespace.  The
+// sidecars and the API server connect to istiod, and the gateways route the traffic of the applications.
+func (i istioComponent) GetNetworkPolicyRules(_ spi.ComponentContext) []spi.NetworkPolicyRules {
+	return []spi.NetworkPolicyRules{
+		{
+			Ingress: []spi.NetworkPeer{{Ports: []int32{8080, 8443, 15012, 15017, 15021}}},
+			Egress:  []spi.NetworkPeer{{}},
+		},
+	}
+}
+
+// Verify that istioComponent implements ComponentAvailability
+var _ spi.ComponentAvailability = istioComponent{}
+
+// GetPodDisruptionBudgets returns the replicated pods of istiod and the gateways.  The pod disruption budgets of the
+// IstioOperator are disabled so that a single replica does not block the drain of its node.
+func (i istioComponent) GetPodDisruptionBudgets(_ spi.ComponentContext) []spi.PodDisruptionBudget {
+	return []spi.PodDisruptionBudget{
+		{Name: "istiod", PodLabels: map[string]string{"app": "istiod"}},
+		{Name: IstioIngressgatewayDeployment, PodLabels: map[string]string{"app": IstioIngressgatewayDeployment}},
+		{Name: IstioEgressgatewayDeployment, PodLabels: map[string]string{"app": IstioEgressgatewayDeployment}},
+	}
+}
+
 // ShouldInstallBeforeUpgrade returns true if component can be installed before upgrade is done
 func (i istioComponent) ShouldInstallBeforeUpgrade() bool {
 	return false
@@ -472,7 +604,7 @@ func deleteIstioCoreDNS(context spi.ComponentContext) error {
 		return context.Log().ErrorfNewErr("Failed searching for release: %v", err)
 	}
 	if found {
-		_, _, err = helmUninstallFunction(context.Log(), IstioCoreDNSReleaseName, constants.IstioSystemNamespace, context.IsDryRun())
+		err = helmUninstallFunction(context.Log(), IstioCoreDNSReleaseName, constants.IstioSystemNamespace, context.IsDryRun())
 		if err != nil {
 			return context.Log().ErrorfNewErr("Failed trying to uninstall istiocoredns: %v", err)
 		}
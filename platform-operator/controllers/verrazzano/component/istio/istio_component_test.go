@@ -27,6 +27,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
+	"helm.sh/helm/v3/pkg/release"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -318,22 +319,23 @@ func TestPostUpgrade(t *testing.T) {
 	k8sutil.SetFakeClient(clientSet)
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(fakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction(
+		helm.NewFakeRelease(IstioCoreDNSReleaseName, constants.IstioSystemNamespace, release.StatusDeployed, "", nil)))
+	defer helm.SetDefaultActionConfigFunction()
 	SetHelmUninstallFunction(fakeHelmUninstall)
 	SetDefaultHelmUninstallFunction()
 	err := comp.PostUpgrade(spi.NewFakeContext(getMock(t), crInstall, nil, false))
 	a.NoError(err, "PostUpgrade returned an error")
 }
 
-func fakeHelmUninstall(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) (stdout []byte, stderr []byte, err error) {
+func fakeHelmUninstall(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) error {
 	if releaseName != "istiocoredns" {
-		return []byte("error"), []byte(""), fmt.Errorf("expected release name istiocoredns does not match provided release name of %v", releaseName)
+		return fmt.Errorf("expected release name istiocoredns does not match provided release name of %v", releaseName)
 	}
 	if releaseName != "istio-system" {
-		return []byte("error"), []byte(""), fmt.Errorf("expected namespace istio-system does not match provided namespace of %v", namespace)
+		return fmt.Errorf("expected namespace istio-system does not match provided namespace of %v", namespace)
 	}
-	return []byte("success"), []byte(""), nil
+	return nil
 }
 
 func getMock(t *testing.T) *mocks.MockClient {
@@ -623,6 +625,18 @@ func Test_istioComponent_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "change-upgrade-strategy-to-revision",
+			old:     &v1alpha1.Verrazzano{},
+			new:     newRevisionCR(0),
+			wantErr: false,
+		},
+		{
+			name:    "change-upgrade-strategy-from-revision",
+			old:     newRevisionCR(0),
+			new:     &v1alpha1.Verrazzano{},
+			wantErr: true,
+		},
 		{
 			name: "change-install-args",
 			old:  &v1alpha1.Verrazzano{},
@@ -857,6 +871,20 @@ func Test_istioComponent_ValidateUpdateV1Beta1(t *testing.T) {
 			new:     createVerrazzanoData(loadBalancerWithoutIPAddressJSON),
 			wantErr: false,
 		},
+		{
+			name: "change-upgrade-strategy-from-revision",
+			old: &v1beta1.Verrazzano{
+				Spec: v1beta1.VerrazzanoSpec{
+					Components: v1beta1.ComponentSpec{
+						Istio: &v1beta1.IstioComponent{
+							Upgrade: &v1beta1.IstioUpgrade{Strategy: v1beta1.IstioUpgradeRevision},
+						},
+					},
+				},
+			},
+			new:     &v1beta1.Verrazzano{},
+			wantErr: true,
+		},
 		{
 			name:    "no change",
 			old:     &v1beta1.Verrazzano{},
@@ -1199,7 +1227,7 @@ func TestPostUninstall(t *testing.T) {
 // GIVEN a call to Uninstall
 //
 //	WHEN the uninstall function is called
-//	THEN success is returned
+//	THEN success is returned and the control planes of the revisions are uninstalled
 func TestUninstall(t *testing.T) {
 
 	fakeUnInstallFunc := func(log vzlog.VerrazzanoLogger) (stdout []byte, stderr []byte, err error) {
@@ -1208,9 +1236,18 @@ func TestUninstall(t *testing.T) {
 	SetIstioUninstallFunction(fakeUnInstallFunc)
 	defer SetDefaultIstioUninstallFunction()
 
+	var uninstalledRevisions []string
+	uninstallRevisionFunc = func(log vzlog.VerrazzanoLogger, revision string) (stdout []byte, stderr []byte, err error) {
+		uninstalledRevisions = append(uninstalledRevisions, revision)
+		return []byte(""), []byte(""), nil
+	}
+	defer func() { uninstallRevisionFunc = istio.UninstallRevision }()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(newIstiodDeployment("1-14-3", "")).Build()
 	var iComp istioComponent
-	compContext := spi.NewFakeContext(nil, nil, nil, false)
+	compContext := spi.NewFakeContext(fakeClient, nil, nil, false)
 	assert.NoError(t, iComp.Uninstall(compContext))
+	assert.Equal(t, []string{"1-14-3"}, uninstalledRevisions)
 }
 
 func TestGetOverrides(t *testing.T) {
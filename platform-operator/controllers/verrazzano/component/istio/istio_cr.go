@@ -32,6 +32,9 @@ const (
 	//meshConfigTracingTLSMode is the TLS mode for Istio-Jaeger communication
 	meshConfigTracingTLSMode = "meshConfig.defaultConfig.tracing.tlsSettings.mode"
 
+	//meshConfigTracingSampling is the percentage of the requests traced by the Istio proxies
+	meshConfigTracingSampling = "meshConfig.defaultConfig.tracing.sampling"
+
 	leftMargin      = 0
 	leftMarginExtIP = 12
 )
@@ -78,6 +81,21 @@ spec:
         enabled: true
         k8s:
           replicaCount: {{.IngressReplicaCount}}
+          {{- if .IngressResources }}
+          resources:
+{{ multiLineIndent 12 .IngressResources }}
+          {{- end}}
+          {{- if .IngressNodeSelector }}
+          nodeSelector:
+{{ multiLineIndent 12 .IngressNodeSelector }}
+          {{- end}}
+          {{- if .IngressTolerations }}
+          tolerations:
+{{ multiLineIndent 12 .IngressTolerations }}
+          {{- end}}
+          {{- if .IngressPriorityClassName }}
+          priorityClassName: {{.IngressPriorityClassName}}
+          {{- end}}
           service:
             type: {{.IngressServiceType}}
             {{- if .IngressServicePorts }}
@@ -93,13 +111,17 @@ spec:
 `
 
 type ReplicaData struct {
-	IngressReplicaCount uint32
-	EgressReplicaCount  uint32
-	IngressAffinity     string
-	EgressAffinity      string
-	IngressServiceType  string
-	IngressServicePorts string
-	ExternalIps         string
+	IngressReplicaCount      uint32
+	EgressReplicaCount       uint32
+	IngressAffinity          string
+	EgressAffinity           string
+	IngressServiceType       string
+	IngressServicePorts      string
+	ExternalIps              string
+	IngressResources         string
+	IngressNodeSelector      string
+	IngressTolerations       string
+	IngressPriorityClassName string
 }
 
 // BuildIstioOperatorYaml builds the IstioOperator CR YAML that will be passed as an override to istioctl
@@ -229,6 +251,10 @@ func configureGateways(istioComponent *vzapi.IstioComponent, externalIP string)
 		data.ExternalIps = externalIP
 	}
 
+	if err := configureIngressWorkload(istioComponent.Workload, &data); err != nil {
+		return "", err
+	}
+
 	// use template to get populate template with data
 	var b bytes.Buffer
 	t, err := template.New("istioGateways").Funcs(template.FuncMap{
@@ -255,3 +281,37 @@ func configureGateways(istioComponent *vzapi.IstioComponent, externalIP string)
 
 	return b.String(), nil
 }
+
+// configureIngressWorkload sets the workload settings of the Istio ingress gateway, the workload replicas take
+// precedence over the replicas of the ingress Kubernetes settings
+func configureIngressWorkload(workload *vzapi.WorkloadSpec, data *ReplicaData) error {
+	if workload == nil {
+		return nil
+	}
+	if workload.Replicas != nil {
+		data.IngressReplicaCount = uint32(*workload.Replicas)
+	}
+	if workload.Resources != nil {
+		yml, err := yaml.Marshal(workload.Resources)
+		if err != nil {
+			return err
+		}
+		data.IngressResources = string(yml)
+	}
+	if len(workload.NodeSelector) > 0 {
+		yml, err := yaml.Marshal(workload.NodeSelector)
+		if err != nil {
+			return err
+		}
+		data.IngressNodeSelector = string(yml)
+	}
+	if len(workload.Tolerations) > 0 {
+		yml, err := yaml.Marshal(workload.Tolerations)
+		if err != nil {
+			return err
+		}
+		data.IngressTolerations = string(yml)
+	}
+	data.IngressPriorityClassName = workload.PriorityClassName
+	return nil
+}
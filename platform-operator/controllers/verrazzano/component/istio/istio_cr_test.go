@@ -10,8 +10,10 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	istioclisec "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -746,3 +748,90 @@ func TestBuildIstioOperatorYaml(t *testing.T) {
 		})
 	}
 }
+
+// TestConfigureGatewaysWorkload tests the workload settings of the ingress gateway
+// GIVEN an Istio component with ingress replicas and workload settings
+// WHEN configureGateways is called
+// THEN the workload settings are set on the ingress gateway and the workload replicas take precedence
+func TestConfigureGatewaysWorkload(t *testing.T) {
+	a := assert.New(t)
+	replicas := int32(3)
+	comp := &vzapi.IstioComponent{
+		Ingress: &vzapi.IstioIngressSection{
+			Kubernetes: &vzapi.IstioKubernetesSection{
+				CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 2},
+			},
+		},
+		Egress: &vzapi.IstioEgressSection{
+			Kubernetes: &vzapi.IstioKubernetesSection{
+				CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 1},
+			},
+		},
+		Workload: &vzapi.WorkloadSpec{
+			Replicas: &replicas,
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+			NodeSelector:      map[string]string{"node-role.verrazzano.io/ingress": "true"},
+			Tolerations:       []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ingress", Effect: corev1.TaintEffectNoSchedule}},
+			PriorityClassName: "system-cluster-critical",
+		},
+	}
+	s, err := configureGateways(comp, "")
+	a.NoError(err)
+
+	operator := struct {
+		Spec struct {
+			Components struct {
+				IngressGateways []struct {
+					K8s struct {
+						ReplicaCount      int32                       `json:"replicaCount"`
+						Resources         corev1.ResourceRequirements `json:"resources"`
+						NodeSelector      map[string]string           `json:"nodeSelector"`
+						Tolerations       []corev1.Toleration         `json:"tolerations"`
+						PriorityClassName string                      `json:"priorityClassName"`
+					} `json:"k8s"`
+				} `json:"ingressGateways"`
+			} `json:"components"`
+		} `json:"spec"`
+	}{}
+	a.NoError(yaml.Unmarshal([]byte(s), &operator), s)
+	a.Len(operator.Spec.Components.IngressGateways, 1)
+	k8s := operator.Spec.Components.IngressGateways[0].K8s
+	a.Equal(int32(3), k8s.ReplicaCount)
+	a.Equal("256Mi", k8s.Resources.Requests.Memory().String())
+	a.Equal(comp.Workload.NodeSelector, k8s.NodeSelector)
+	a.Equal(comp.Workload.Tolerations, k8s.Tolerations)
+	a.Equal("system-cluster-critical", k8s.PriorityClassName)
+}
+
+// TestGetWorkload tests the workload settings of the Istio component
+// GIVEN a Verrazzano CR with ingress replicas and optional workload settings
+// WHEN GetWorkload is called
+// THEN the ingress replicas are used only when the workload settings do not set the replicas
+func TestGetWorkload(t *testing.T) {
+	a := assert.New(t)
+	replicas := int32(3)
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Istio: &vzapi.IstioComponent{
+					Ingress: &vzapi.IstioIngressSection{
+						Kubernetes: &vzapi.IstioKubernetesSection{
+							CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 2},
+						},
+					},
+				},
+			},
+		},
+	}
+	workload := istioComponent{}.GetWorkload(vz)
+	a.NotNil(workload)
+	a.Equal(int32(2), *workload.Replicas)
+
+	vz.Spec.Components.Istio.Workload = &vzapi.WorkloadSpec{Replicas: &replicas}
+	workload = istioComponent{}.GetWorkload(vz)
+	a.Equal(int32(3), *workload.Replicas)
+
+	a.Nil(istioComponent{}.GetWorkload(&vzapi.Verrazzano{}))
+}
@@ -158,16 +158,21 @@ func (i istioComponent) IsOperatorInstallSupported() bool {
 	return true
 }
 
-// IsInstalled checks if Istio is installed by looking for the Istio control plane deployment
+// IsInstalled checks if Istio is installed by looking for the Istio control plane deployment of any revision
 func (i istioComponent) IsInstalled(compContext spi.ComponentContext) (bool, error) {
 	deployment := appsv1.Deployment{}
 	nsn := types.NamespacedName{Name: IstiodDeployment, Namespace: IstioNamespace}
 	if err := compContext.Client().Get(context.TODO(), nsn, &deployment); err != nil {
-		if errors.IsNotFound(err) {
-			return false, nil
+		if !errors.IsNotFound(err) {
+			// Unexpected error
+			return false, err
+		}
+		// Look for the control plane of a revision
+		revisions, err := getInstalledRevisions(compContext.Client())
+		if err != nil {
+			return false, err
 		}
-		// Unexpected error
-		return false, err
+		return len(revisions) > 0, nil
 	}
 	return true, nil
 }
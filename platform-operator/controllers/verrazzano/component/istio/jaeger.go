@@ -24,7 +24,7 @@ func configureJaeger(ctx spi.ComponentContext) ([]vzapi.InstallArgs, error) {
 		constants.VerrazzanoMonitoringNamespace,
 		collectorZipkinPort,
 	)
-	return []vzapi.InstallArgs{
+	args := []vzapi.InstallArgs{
 		{
 			Name:  meshConfigTracingTLSMode,
 			Value: "ISTIO_MUTUAL",
@@ -33,5 +33,16 @@ func configureJaeger(ctx spi.ComponentContext) ([]vzapi.InstallArgs, error) {
 			Name:  meshConfigTracingAddress,
 			Value: collectorURL,
 		},
-	}, nil
+	}
+
+	// The mesh tracing sampling is the default sampling percentage of the Jaeger instance, the percentage is
+	// validated by the Jaeger Operator component
+	jaeger := ctx.EffectiveCR().Spec.Components.JaegerOperator
+	if jaeger != nil && jaeger.Sampling != nil && len(jaeger.Sampling.DefaultPercentage) > 0 {
+		args = append(args, vzapi.InstallArgs{
+			Name:  meshConfigTracingSampling,
+			Value: jaeger.Sampling.DefaultPercentage,
+		})
+	}
+	return args, nil
 }
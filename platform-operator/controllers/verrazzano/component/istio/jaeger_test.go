@@ -64,6 +64,20 @@ func TestConfigureJaeger(t *testing.T) {
 			ctxWithServiceAndUnmanagedNamespace,
 			2,
 		},
+		{
+			"3 args (tls mode, zipkin address and sampling) returned when the Jaeger sampling percentage is set",
+			spi.NewFakeContext(fake.NewClientBuilder().Build(), &vzapi.Verrazzano{
+				Spec: vzapi.VerrazzanoSpec{
+					Components: vzapi.ComponentSpec{
+						JaegerOperator: &vzapi.JaegerOperatorComponent{
+							Enabled:  &enabled,
+							Sampling: &vzapi.JaegerSamplingSpec{DefaultPercentage: "2.5"},
+						},
+					},
+				},
+			}, nil, false),
+			3,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,450 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package istio
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	oam "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/istio"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzString "github.com/verrazzano/verrazzano/pkg/string"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// istioRevisionLabel is the label of the Istio revision used by a namespace, or that injected the proxy of a pod
+	istioRevisionLabel = "istio.io/rev"
+
+	// istiodAppLabel is the app label of the istiod deployments of all the revisions
+	istiodAppLabel = "app"
+
+	// istiodContainer is the name of the istiod container
+	istiodContainer = "discovery"
+
+	// defaultNamespaceBatchSize is the default number of application namespaces migrated at a time
+	defaultNamespaceBatchSize = 5
+
+	// oamAppNameLabel is the label of the pods of OAM applications
+	oamAppNameLabel = "app.oam.dev/name"
+)
+
+// invalidRevisionChars matches the characters that are not allowed in an Istio revision name
+var invalidRevisionChars = regexp.MustCompile("[^a-z0-9-]")
+
+type setDefaultTagFuncSig func(log vzlog.VerrazzanoLogger, revision string) (stdout []byte, stderr []byte, err error)
+
+var setDefaultTagFunc setDefaultTagFuncSig = istio.SetDefaultTag
+
+type uninstallRevisionFuncSig func(log vzlog.VerrazzanoLogger, revision string) (stdout []byte, stderr []byte, err error)
+
+var uninstallRevisionFunc uninstallRevisionFuncSig = istio.UninstallRevision
+
+// IsRevisionUpgrade returns true if the Istio control plane is upgraded with revisions
+func IsRevisionUpgrade(cr runtime.Object) bool {
+	if vz, ok := cr.(*vzapi.Verrazzano); ok {
+		istioComp := vz.Spec.Components.Istio
+		return istioComp != nil && istioComp.Upgrade != nil && istioComp.Upgrade.Strategy == vzapi.IstioUpgradeRevision
+	}
+	if vz, ok := cr.(*installv1beta1.Verrazzano); ok {
+		istioComp := vz.Spec.Components.Istio
+		return istioComp != nil && istioComp.Upgrade != nil && istioComp.Upgrade.Strategy == installv1beta1.IstioUpgradeRevision
+	}
+	return false
+}
+
+// getNamespaceBatchSize returns the number of application namespaces migrated to a new revision at a time
+func getNamespaceBatchSize(cr *vzapi.Verrazzano) int {
+	istioComp := cr.Spec.Components.Istio
+	if istioComp == nil || istioComp.Upgrade == nil || istioComp.Upgrade.NamespaceBatchSize < 1 {
+		return defaultNamespaceBatchSize
+	}
+	return istioComp.Upgrade.NamespaceBatchSize
+}
+
+// getBomRevision returns the name of the revision of the Istio version in the BOM, for example 1-14-3 for Istio 1.14.3
+func getBomRevision() (string, error) {
+	bomFile, err := bom.NewBom(config.GetDefaultBOMFilePath())
+	if err != nil {
+		return "", err
+	}
+	comp, err := bomFile.GetComponent(ComponentName)
+	if err != nil {
+		return "", err
+	}
+	if len(comp.Version) == 0 {
+		return "", errors.New("Failed to find the Istio version in the BOM")
+	}
+	return invalidRevisionChars.ReplaceAllString(strings.ToLower(comp.Version), "-"), nil
+}
+
+// getIstiodImageFromBom returns the istiod image of the Istiod subcomponent in the BOM
+func getIstiodImageFromBom() (string, error) {
+	bomFile, err := bom.NewBom(config.GetDefaultBOMFilePath())
+	if err != nil {
+		return "", errors.New("Failed to get access to the BOM")
+	}
+	images, err := bomFile.GetImageNameList(subcompIstiod)
+	if err != nil {
+		return "", errors.New("Failed to get the images for Istiod")
+	}
+	for i, image := range images {
+		if strings.Contains(image, "pilot") {
+			return images[i], nil
+		}
+	}
+	return "", errors.New("Failed to find the istiod image in the BOM for Istiod")
+}
+
+// getInstalledRevisions returns the istiod deployments of the installed Istio control planes, by revision
+func getInstalledRevisions(client clipkg.Client) (map[string]appsv1.Deployment, error) {
+	deployments := appsv1.DeploymentList{}
+	if err := client.List(context.TODO(), &deployments, clipkg.InNamespace(IstioNamespace), clipkg.MatchingLabels{istiodAppLabel: IstiodDeployment}); err != nil {
+		return nil, err
+	}
+	revisions := make(map[string]appsv1.Deployment)
+	for _, deployment := range deployments.Items {
+		revision := deployment.Labels[istioRevisionLabel]
+		if len(revision) == 0 {
+			revision = istio.DefaultRevision
+		}
+		revisions[revision] = deployment
+	}
+	return revisions, nil
+}
+
+// getTargetRevision returns the revision of the Istio control plane of the BOM version.  This is the default revision
+// when Istio was installed without a revision at that version, otherwise the revision named after the BOM version.
+func getTargetRevision(client clipkg.Client) (string, error) {
+	bomRevision, err := getBomRevision()
+	if err != nil {
+		return "", err
+	}
+	revisions, err := getInstalledRevisions(client)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := revisions[bomRevision]; ok {
+		return bomRevision, nil
+	}
+	if deployment, ok := revisions[istio.DefaultRevision]; ok {
+		istiodImage, err := getIstiodImageFromBom()
+		if err != nil {
+			return "", err
+		}
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if container.Name == istiodContainer && container.Image == istiodImage {
+				return istio.DefaultRevision, nil
+			}
+		}
+	}
+	return bomRevision, nil
+}
+
+// getIstiodDeploymentName returns the name of the istiod deployment of a revision
+func getIstiodDeploymentName(revision string) string {
+	if revision == istio.DefaultRevision {
+		return IstiodDeployment
+	}
+	return IstiodDeployment + "-" + revision
+}
+
+// MigrateSystemNamespaces migrates the Istio injected system namespaces to the target Istio revision, one
+// namespace at a time.  It returns false while a namespace is being migrated, and is expected to be called again
+// until it returns true.
+func MigrateSystemNamespaces(log vzlog.VerrazzanoLogger, client clipkg.Client, generation int64) (bool, error) {
+	goClient, err := k8sutil.GetGoClient(log)
+	if err != nil {
+		return false, err
+	}
+	return migrateNamespaces(log, client, goClient, config.GetInjectedSystemNamespaces(), 1, func(namespaces []string) error {
+		return RestartComponents(log, namespaces, generation, DoesPodContainOldIstioSidecar)
+	})
+}
+
+// MigrateAppNamespaces migrates the Istio injected application namespaces to the target Istio revision, in
+// batches of namespaces.  It returns false while a batch is being migrated, and is expected to be called again
+// until it returns true.
+func MigrateAppNamespaces(log vzlog.VerrazzanoLogger, client clipkg.Client, cr *vzapi.Verrazzano) (bool, error) {
+	goClient, err := k8sutil.GetGoClient(log)
+	if err != nil {
+		return false, err
+	}
+	restartVersion := "upgrade-" + strconv.Itoa(int(cr.Generation))
+	oamEnabled := vzconfig.IsApplicationOperatorEnabled(cr)
+	if oamEnabled {
+		// Start the WebLogic domains stopped pre-upgrade, they get the proxy of the new revision
+		if err := startDomainsStoppedByUpgrade(log, client, restartVersion); err != nil {
+			return false, err
+		}
+	}
+
+	namespaces, err := getAppNamespaces(goClient)
+	if err != nil {
+		return false, log.ErrorfNewErr("Failed to list the Istio injected application namespaces: %v", err)
+	}
+	return migrateNamespaces(log, client, goClient, namespaces, getNamespaceBatchSize(cr), func(namespaces []string) error {
+		if oamEnabled {
+			if err := restartOAMAppsInNamespaces(log, client, goClient, namespaces, restartVersion); err != nil {
+				return err
+			}
+		}
+		return RestartComponents(log, namespaces, cr.Generation, doesNonOAMPodContainOldIstioSidecar)
+	})
+}
+
+// migrateNamespaces migrates the first batch of namespaces that still have workloads with an old Istio proxy to the
+// target Istio revision.  The namespaces labeled with an Istio revision are relabeled, then the workloads with an
+// old proxy are restarted.  The next batch is only migrated once all the proxies of the previous one are up-to-date.
+// Returns true when all the namespaces have been migrated.
+func migrateNamespaces(log vzlog.VerrazzanoLogger, client clipkg.Client, goClient kubernetes.Interface, namespaces []string, batchSize int, restartFunc func(namespaces []string) error) (bool, error) {
+	revision, err := getTargetRevision(client)
+	if err != nil {
+		return false, log.ErrorfNewErr("Failed to get the target Istio revision: %v", err)
+	}
+	istioProxyImage, err := getIstioProxyImageFromBom()
+	if err != nil {
+		return false, log.ErrorfNewErr("Failed, cannot find Istio proxy image in BOM: %v", err)
+	}
+
+	var batch []string
+	for _, ns := range namespaces {
+		migrated, err := isNamespaceMigrated(log, goClient, ns, revision, istioProxyImage)
+		if err != nil {
+			return false, err
+		}
+		if !migrated {
+			batch = append(batch, ns)
+		}
+		if len(batch) == batchSize {
+			break
+		}
+	}
+	if len(batch) == 0 {
+		log.Oncef("All the namespaces %v have been migrated to Istio revision %s", namespaces, revision)
+		return true, nil
+	}
+
+	log.Progressf("Migrating namespaces %v to Istio revision %s", batch, revision)
+	for _, ns := range batch {
+		if err := labelNamespaceRevision(log, goClient, ns, revision); err != nil {
+			return false, err
+		}
+	}
+	if err := restartFunc(batch); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// isNamespaceMigrated returns true if the namespace uses the revision, and all the running pods of the namespace
+// have the Istio proxy of the BOM
+func isNamespaceMigrated(log vzlog.VerrazzanoLogger, goClient kubernetes.Interface, ns string, revision string, istioProxyImage string) (bool, error) {
+	namespace, err := goClient.CoreV1().Namespaces().Get(context.TODO(), ns, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, log.ErrorfNewErr("Failed to get namespace %s: %v", ns, err)
+	}
+	if nsRevision, ok := namespace.Labels[istioRevisionLabel]; ok && nsRevision != revision {
+		return false, nil
+	}
+
+	podList, err := goClient.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return false, log.ErrorfNewErr("Failed to list the pods of namespace %s: %v", ns, err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isPodRunning(pod) || !hasOldIstioProxy(pod, istioProxyImage) {
+			continue
+		}
+		if len(pod.OwnerReferences) == 0 {
+			// Pods without a controller are not restarted, they keep the old revision in use until they are deleted
+			log.Oncef("Pod %s/%s has an old Istio proxy and must be restarted manually to use Istio revision %s", ns, pod.Name, revision)
+			continue
+		}
+		log.Progressf("Waiting for pod %s/%s to be restarted with the Istio proxy of revision %s", ns, pod.Name, revision)
+		return false, nil
+	}
+	return true, nil
+}
+
+// labelNamespaceRevision updates the revision label of a namespace that uses an Istio revision, the namespaces
+// labeled with istio-injection=enabled use the revision the default tag points to
+func labelNamespaceRevision(log vzlog.VerrazzanoLogger, goClient kubernetes.Interface, ns string, revision string) error {
+	namespace, err := goClient.CoreV1().Namespaces().Get(context.TODO(), ns, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return log.ErrorfNewErr("Failed to get namespace %s: %v", ns, err)
+	}
+	nsRevision, ok := namespace.Labels[istioRevisionLabel]
+	if !ok || nsRevision == revision {
+		return nil
+	}
+	namespace.Labels[istioRevisionLabel] = revision
+	if _, err := goClient.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{}); err != nil {
+		return log.ErrorfNewErr("Failed to update the Istio revision label of namespace %s: %v", ns, err)
+	}
+	return nil
+}
+
+// getAppNamespaces returns the sorted Istio injected namespaces that are not system namespaces
+func getAppNamespaces(goClient kubernetes.Interface) ([]string, error) {
+	nsList, err := goClient.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	systemNamespaces := config.GetInjectedSystemNamespaces()
+	var namespaces []string
+	for _, ns := range nsList.Items {
+		if ns.Name == IstioNamespace || vzString.SliceContainsString(systemNamespaces, ns.Name) {
+			continue
+		}
+		_, hasRevision := ns.Labels[istioRevisionLabel]
+		if ns.Labels[vzconst.LabelIstioInjection] == "enabled" || hasRevision {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// restartOAMAppsInNamespaces restarts the OAM applications of the namespaces that have a pod with an old Istio proxy
+func restartOAMAppsInNamespaces(log vzlog.VerrazzanoLogger, client clipkg.Client, goClient kubernetes.Interface, namespaces []string, restartVersion string) error {
+	istioProxyImage, err := getIstioProxyImageFromBom()
+	if err != nil {
+		return log.ErrorfNewErr("Failed, restart components cannot find Istio proxy image in BOM: %v", err)
+	}
+	for _, ns := range namespaces {
+		appConfigs := oam.ApplicationConfigurationList{}
+		if err := client.List(context.TODO(), &appConfigs, clipkg.InNamespace(ns)); err != nil {
+			return log.ErrorfNewErr("Failed to list appConfigs in namespace %s: %v", ns, err)
+		}
+		for _, appConfig := range appConfigs.Items {
+			podList, err := goClient.CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{LabelSelector: oamAppNameLabel + "=" + appConfig.Name})
+			if err != nil {
+				return log.ErrorfNewErr("Failed to list pods for AppConfig %s/%s: %v", ns, appConfig.Name, err)
+			}
+			if !DoesPodContainOldIstioSidecar(log, podList, "OAM Application", appConfig.Name, istioProxyImage) {
+				continue
+			}
+			if err := restartOAMApp(log, appConfig, client, restartVersion); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// doesNonOAMPodContainOldIstioSidecar returns true if any pods that are not part of an OAM application contain an old
+// Istio proxy sidecar, the OAM applications are restarted through their ApplicationConfiguration
+func doesNonOAMPodContainOldIstioSidecar(log vzlog.VerrazzanoLogger, podList *v1.PodList, workloadType string, workloadName string, istioProxyImageName string) bool {
+	nonOAMPods := &v1.PodList{}
+	for _, pod := range podList.Items {
+		if _, ok := pod.Labels[oamAppNameLabel]; !ok {
+			nonOAMPods.Items = append(nonOAMPods.Items, pod)
+		}
+	}
+	return DoesPodContainOldIstioSidecar(log, nonOAMPods, workloadType, workloadName, istioProxyImageName)
+}
+
+// RemoveUnusedRevisions uninstalls the Istio control planes of the revisions other than the target one that no
+// namespace or pod references anymore
+func RemoveUnusedRevisions(log vzlog.VerrazzanoLogger, client clipkg.Client) error {
+	revision, err := getTargetRevision(client)
+	if err != nil {
+		return log.ErrorfNewErr("Failed to get the target Istio revision: %v", err)
+	}
+	revisions, err := getInstalledRevisions(client)
+	if err != nil {
+		return log.ErrorfNewErr("Failed to list the Istio revisions: %v", err)
+	}
+	if _, ok := revisions[revision]; !ok {
+		// The control plane of the BOM version is not installed yet
+		return nil
+	}
+	for oldRevision := range revisions {
+		if oldRevision == revision {
+			continue
+		}
+		referenced, err := isRevisionReferenced(log, client, oldRevision)
+		if err != nil {
+			return err
+		}
+		if referenced {
+			log.Oncef("Istio revision %s is still in use, it will be removed once nothing references it", oldRevision)
+			continue
+		}
+		log.Infof("Removing Istio revision %s, which is no longer in use", oldRevision)
+		if _, stderr, err := uninstallRevisionFunc(log, oldRevision); err != nil {
+			return log.ErrorfNewErr("Failed to uninstall Istio revision %s: %v stderr: %s", oldRevision, err, string(stderr))
+		}
+	}
+	return nil
+}
+
+// isRevisionReferenced returns true if a namespace is labeled with the revision, or a running pod outside of the
+// Istio namespace has a proxy injected by the revision
+func isRevisionReferenced(log vzlog.VerrazzanoLogger, client clipkg.Client, revision string) (bool, error) {
+	// The revision label of the namespaces refers to the default tag rather than the default revision
+	if revision != istio.DefaultRevision {
+		nsList := v1.NamespaceList{}
+		if err := client.List(context.TODO(), &nsList, clipkg.MatchingLabels{istioRevisionLabel: revision}); err != nil {
+			return false, log.ErrorfNewErr("Failed to list the namespaces of Istio revision %s: %v", revision, err)
+		}
+		if len(nsList.Items) > 0 {
+			log.Debugf("Istio revision %s is used by namespace %s", revision, nsList.Items[0].Name)
+			return true, nil
+		}
+	}
+	podList := v1.PodList{}
+	if err := client.List(context.TODO(), &podList, clipkg.MatchingLabels{istioRevisionLabel: revision}); err != nil {
+		return false, log.ErrorfNewErr("Failed to list the pods of Istio revision %s: %v", revision, err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Namespace != IstioNamespace && isPodRunning(pod) {
+			log.Debugf("Istio revision %s is used by pod %s/%s", revision, pod.Namespace, pod.Name)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasOldIstioProxy returns true if the pod has an Istio proxy container that doesn't match the Istio proxy in the BOM
+func hasOldIstioProxy(pod *v1.Pod, istioProxyImage string) bool {
+	for _, container := range pod.Spec.Containers {
+		if strings.Contains(container.Image, "proxyv2") && container.Image != istioProxyImage {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodRunning returns true if the pod is neither being deleted nor terminated
+func isPodRunning(pod *v1.Pod) bool {
+	return pod.DeletionTimestamp == nil && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+}
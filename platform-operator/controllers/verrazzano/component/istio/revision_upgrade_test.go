@@ -0,0 +1,313 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package istio
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/istio"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	gofake "k8s.io/client-go/kubernetes/fake"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const bomRevision = "1-14-3"
+
+// newRevisionCR returns a Verrazzano CR that upgrades Istio with revisions
+func newRevisionCR(batchSize int) *v1alpha1.Verrazzano {
+	return &v1alpha1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec: v1alpha1.VerrazzanoSpec{
+			Components: v1alpha1.ComponentSpec{
+				Istio: &v1alpha1.IstioComponent{
+					Upgrade: &v1alpha1.IstioUpgrade{Strategy: v1alpha1.IstioUpgradeRevision, NamespaceBatchSize: batchSize},
+				},
+			},
+		},
+	}
+}
+
+// newIstiodDeployment returns the istiod deployment of a revision
+func newIstiodDeployment(revision string, image string) *appsv1.Deployment {
+	labels := map[string]string{istiodAppLabel: IstiodDeployment}
+	if revision != istio.DefaultRevision {
+		labels[istioRevisionLabel] = revision
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: IstioNamespace,
+			Name:      getIstiodDeploymentName(revision),
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: istiodContainer, Image: image}},
+				},
+			},
+		},
+	}
+}
+
+// newProxyPod returns a pod of a deployment with an Istio proxy sidecar
+func newProxyPod(namespace string, name string, image string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: name}},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "istio-proxy", Image: image}},
+		},
+	}
+}
+
+// newNamespace returns a namespace with labels
+func newNamespace(name string, labels map[string]string) *v1.Namespace {
+	return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+// newDeployment returns a deployment selecting the app: foo pods
+func newDeployment(namespace string) *appsv1.Deployment {
+	deployment := initFakeDeployment()
+	deployment.Namespace = namespace
+	return deployment
+}
+
+func newRevisionScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = oamcore.AddToScheme(scheme)
+	return scheme
+}
+
+// TestIsRevisionUpgrade tests the IsRevisionUpgrade function
+// GIVEN v1alpha1 and v1beta1 Verrazzano CRs
+// WHEN IsRevisionUpgrade is called
+// THEN true is returned only when the Istio upgrade strategy is Revision
+func TestIsRevisionUpgrade(t *testing.T) {
+	assert.False(t, IsRevisionUpgrade(&v1alpha1.Verrazzano{}))
+	assert.True(t, IsRevisionUpgrade(newRevisionCR(0)))
+	assert.False(t, IsRevisionUpgrade(&v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{Istio: &v1beta1.IstioComponent{
+			Upgrade: &v1beta1.IstioUpgrade{Strategy: v1beta1.IstioUpgradeInPlace},
+		}}},
+	}))
+	assert.True(t, IsRevisionUpgrade(&v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{Istio: &v1beta1.IstioComponent{
+			Upgrade: &v1beta1.IstioUpgrade{Strategy: v1beta1.IstioUpgradeRevision},
+		}}},
+	}))
+	assert.Equal(t, defaultNamespaceBatchSize, getNamespaceBatchSize(newRevisionCR(0)))
+	assert.Equal(t, 2, getNamespaceBatchSize(newRevisionCR(2)))
+}
+
+// TestGetTargetRevision tests the getTargetRevision function
+// GIVEN the installed Istio control planes
+// WHEN getTargetRevision is called
+// THEN the default revision is returned if it is at the BOM version, otherwise the revision of the BOM version
+func TestGetTargetRevision(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	istiodImage, err := getIstiodImageFromBom()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		revision string
+	}{
+		{name: "not installed", revision: bomRevision},
+		{name: "default at BOM version", objects: []client.Object{newIstiodDeployment(istio.DefaultRevision, istiodImage)}, revision: istio.DefaultRevision},
+		{name: "old default", objects: []client.Object{newIstiodDeployment(istio.DefaultRevision, "pilot:1.13.5")}, revision: bomRevision},
+		{name: "old default and new revision", objects: []client.Object{newIstiodDeployment(istio.DefaultRevision, "pilot:1.13.5"), newIstiodDeployment(bomRevision, istiodImage)}, revision: bomRevision},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(tt.objects...).Build()
+			revision, err := getTargetRevision(fakeClient)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.revision, revision)
+		})
+	}
+}
+
+// TestUpgradeRevision tests the component upgrade with the Revision strategy
+// GIVEN an Istio control plane installed without a revision at an old version
+// WHEN Upgrade is called
+// THEN the new control plane is installed as a revision and the default tag points to it
+func TestUpgradeRevision(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+
+	var overrides string
+	SetIstioUpgradeFunction(func(log vzlog.VerrazzanoLogger, imageOverridesString string, overridesFiles ...string) (stdout []byte, stderr []byte, err error) {
+		overrides = imageOverridesString
+		return []byte("success"), []byte(""), nil
+	})
+	defer SetDefaultIstioUpgradeFunction()
+	var taggedRevision string
+	setDefaultTagFunc = func(log vzlog.VerrazzanoLogger, revision string) (stdout []byte, stderr []byte, err error) {
+		taggedRevision = revision
+		return []byte("success"), []byte(""), nil
+	}
+	defer func() { setDefaultTagFunc = istio.SetDefaultTag }()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(newIstiodDeployment(istio.DefaultRevision, "pilot:1.13.5")).Build()
+	cr := crInstall.DeepCopy()
+	cr.Spec.Components.Istio.Upgrade = &v1alpha1.IstioUpgrade{Strategy: v1alpha1.IstioUpgradeRevision}
+	comp := istioComponent{ValuesFile: "test-values-file.yaml"}
+	err := comp.Upgrade(spi.NewFakeContext(fakeClient, cr, nil, false))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(overrides, ",revision="+bomRevision), "revision override missing from %s", overrides)
+	assert.Equal(t, bomRevision, taggedRevision)
+}
+
+// TestMigrateSystemNamespaces tests the migration of the system namespaces to a new revision
+// GIVEN system namespaces with pods that have an old Istio proxy
+// WHEN MigrateSystemNamespaces is called
+// THEN the workloads of one namespace at a time are restarted, and true is returned once all the proxies are up-to-date
+func TestMigrateSystemNamespaces(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	proxyImage, err := getIstioProxyImageFromBom()
+	assert.NoError(t, err)
+
+	clientSet := gofake.NewSimpleClientset(
+		newNamespace(constants.VerrazzanoSystemNamespace, map[string]string{vzconst.LabelIstioInjection: "enabled"}),
+		newNamespace(constants.KeycloakNamespace, map[string]string{vzconst.LabelIstioInjection: "enabled"}),
+		newProxyPod(constants.VerrazzanoSystemNamespace, "pod1", oldIstioImage, map[string]string{"app": "foo"}),
+		newProxyPod(constants.KeycloakNamespace, "pod2", oldIstioImage, map[string]string{"app": "foo"}),
+		newDeployment(constants.VerrazzanoSystemNamespace),
+		newDeployment(constants.KeycloakNamespace),
+	)
+	k8sutil.SetFakeClient(clientSet)
+	defer k8sutil.ClearFakeClient()
+	fakeClient := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(newIstiodDeployment(bomRevision, "pilot")).Build()
+
+	// The first namespace is migrated
+	done, err := MigrateSystemNamespaces(vzlog.DefaultLogger(), fakeClient, 2)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, "2", getRestartAnnotation(t, clientSet, constants.VerrazzanoSystemNamespace))
+	assert.Empty(t, getRestartAnnotation(t, clientSet, constants.KeycloakNamespace))
+
+	// The next namespace is only migrated once the proxies of the first one are up-to-date
+	done, err = MigrateSystemNamespaces(vzlog.DefaultLogger(), fakeClient, 2)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Empty(t, getRestartAnnotation(t, clientSet, constants.KeycloakNamespace))
+
+	updatePodImage(t, clientSet, constants.VerrazzanoSystemNamespace, "pod1", proxyImage)
+	done, err = MigrateSystemNamespaces(vzlog.DefaultLogger(), fakeClient, 2)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, "2", getRestartAnnotation(t, clientSet, constants.KeycloakNamespace))
+
+	updatePodImage(t, clientSet, constants.KeycloakNamespace, "pod2", proxyImage)
+	done, err = MigrateSystemNamespaces(vzlog.DefaultLogger(), fakeClient, 2)
+	assert.NoError(t, err)
+	assert.True(t, done)
+}
+
+// TestMigrateAppNamespaces tests the migration of the application namespaces to a new revision
+// GIVEN application namespaces with pods that have an old Istio proxy
+// WHEN MigrateAppNamespaces is called
+// THEN the namespaces are migrated in batches, and the namespaces labeled with the old revision are relabeled
+func TestMigrateAppNamespaces(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+
+	clientSet := gofake.NewSimpleClientset(
+		newNamespace("app1", map[string]string{vzconst.LabelIstioInjection: "enabled"}),
+		newNamespace("app2", map[string]string{istioRevisionLabel: "1-13-5"}),
+		newNamespace("app3", map[string]string{vzconst.LabelIstioInjection: "enabled"}),
+		newNamespace("noistio", nil),
+		newProxyPod("app1", "pod1", oldIstioImage, map[string]string{"app": "foo"}),
+		newProxyPod("app3", "pod3", oldIstioImage, map[string]string{"app": "foo"}),
+		newDeployment("app1"),
+		newDeployment("app2"),
+		newDeployment("app3"),
+	)
+	k8sutil.SetFakeClient(clientSet)
+	defer k8sutil.ClearFakeClient()
+	fakeClient := fake.NewClientBuilder().WithScheme(newRevisionScheme()).WithObjects(newIstiodDeployment(bomRevision, "pilot")).Build()
+
+	done, err := MigrateAppNamespaces(vzlog.DefaultLogger(), fakeClient, newRevisionCR(2))
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, "2", getRestartAnnotation(t, clientSet, "app1"))
+	assert.Empty(t, getRestartAnnotation(t, clientSet, "app3"))
+	ns, err := clientSet.CoreV1().Namespaces().Get(context.TODO(), "app2", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, bomRevision, ns.Labels[istioRevisionLabel])
+	ns, err = clientSet.CoreV1().Namespaces().Get(context.TODO(), "app1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "enabled", ns.Labels[vzconst.LabelIstioInjection])
+	assert.NotContains(t, ns.Labels, istioRevisionLabel)
+}
+
+// TestRemoveUnusedRevisions tests the removal of the old revisions
+// GIVEN an old and a new Istio revision
+// WHEN RemoveUnusedRevisions is called
+// THEN the old revision is only uninstalled when no pod references it
+func TestRemoveUnusedRevisions(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+
+	var uninstalledRevisions []string
+	uninstallRevisionFunc = func(log vzlog.VerrazzanoLogger, revision string) (stdout []byte, stderr []byte, err error) {
+		uninstalledRevisions = append(uninstalledRevisions, revision)
+		return []byte(""), []byte(""), nil
+	}
+	defer func() { uninstallRevisionFunc = istio.UninstallRevision }()
+
+	oldPod := newProxyPod("app1", "pod1", oldIstioImage, map[string]string{istioRevisionLabel: istio.DefaultRevision})
+	istiodPod := newProxyPod(IstioNamespace, "istiod", "pilot", map[string]string{istioRevisionLabel: istio.DefaultRevision})
+	fakeClient := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		newIstiodDeployment(istio.DefaultRevision, "pilot:1.13.5"),
+		newIstiodDeployment(bomRevision, "pilot"),
+		oldPod,
+		istiodPod,
+	).Build()
+
+	err := RemoveUnusedRevisions(vzlog.DefaultLogger(), fakeClient)
+	assert.NoError(t, err)
+	assert.Empty(t, uninstalledRevisions)
+
+	assert.NoError(t, fakeClient.Delete(context.TODO(), oldPod))
+	err = RemoveUnusedRevisions(vzlog.DefaultLogger(), fakeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{istio.DefaultRevision}, uninstalledRevisions)
+}
+
+// getRestartAnnotation returns the restart annotation of the test deployment of a namespace
+func getRestartAnnotation(t *testing.T, clientSet *gofake.Clientset, namespace string) string {
+	dep, err := clientSet.AppsV1().Deployments(namespace).Get(context.TODO(), "test", metav1.GetOptions{})
+	assert.NoError(t, err)
+	return dep.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation]
+}
+
+// updatePodImage updates the proxy image of a pod, as if it was restarted
+func updatePodImage(t *testing.T, clientSet *gofake.Clientset, namespace string, name string, image string) {
+	pod, err := clientSet.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	pod.Spec.Containers[0].Image = image
+	_, err = clientSet.CoreV1().Pods(namespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/opensearch"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
@@ -98,6 +97,17 @@ const extraEnvValueTemplate = `extraEnv:
 const jaegerCreateTemplate = `jaeger:
   create: true
   spec:
+{{- if .MemoryStorage }}
+    strategy: allInOne
+    storage:
+      type: memory
+      options:
+        es: null
+        memory:
+          max-traces: {{.MaxTraces}}
+      esIndexCleaner:
+        enabled: false
+{{- else }}
     strategy: production
     storage:
       # Jaeger Elasticsearch storage is compatible with Verrazzano OpenSearch.
@@ -107,6 +117,16 @@ const jaegerCreateTemplate = `jaeger:
           server-urls: {{.OpenSearchURL}}
           num-replicas: {{.OpenSearchReplicaCount}}
       secretName: {{.SecretName}}
+{{- if .RetentionDays }}
+      esIndexCleaner:
+        numberOfDays: {{.RetentionDays}}
+{{- end }}
+{{- end }}
+{{- if .SamplingOptions }}
+    sampling:
+      options:
+{{.SamplingOptions}}
+{{- end }}
 `
 
 // imageData needed for template rendering
@@ -125,6 +145,10 @@ type jaegerData struct {
 	OpenSearchURL          string
 	SecretName             string
 	OpenSearchReplicaCount int32
+	RetentionDays          int32
+	MemoryStorage          bool
+	MaxTraces              int
+	SamplingOptions        string
 }
 
 // isJaegerOperatorReady checks if the Jaeger Operator deployment is ready
@@ -269,11 +293,10 @@ func AppendOverrides(compContext spi.ComponentContext, _ string, _ string, _ str
 		if err != nil {
 			return nil, err
 		}
-		var osReplicaCount int32 = 1
-		if opensearch.IsSingleDataNodeCluster(compContext) {
-			osReplicaCount = 0
+		data, err := buildJaegerData(compContext)
+		if err != nil {
+			return nil, err
 		}
-		data := jaegerData{OpenSearchURL: openSearchURL, SecretName: globalconst.DefaultJaegerSecretName, OpenSearchReplicaCount: osReplicaCount}
 		err = jaegerCRTemplate.Execute(&b, data)
 		if err != nil {
 			return nil, err
@@ -315,7 +338,10 @@ func (c jaegerOperatorComponent) validateJaegerOperator(cr *v1beta1.Verrazzano)
 	if err != nil {
 		return err
 	}
-	return validateInstallOverrides(cr.Spec.Components.JaegerOperator.ValueOverrides, client)
+	if err := validateInstallOverrides(cr.Spec.Components.JaegerOperator.ValueOverrides, client); err != nil {
+		return err
+	}
+	return validateJaegerSettings(cr, client)
 }
 
 // validateInstallOverrides validates that the overrides contain only values that are allowed for override
@@ -360,6 +386,21 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []v1beta1.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *v1alpha1.WorkloadSpec {
+	if effectiveCR, ok := object.(*v1alpha1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.JaegerOperator != nil {
+			return effectiveCR.Spec.Components.JaegerOperator.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*v1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.JaegerOperator != nil {
+			return (*v1alpha1.WorkloadSpec)(effectiveCR.Spec.Components.JaegerOperator.Workload)
+		}
+	}
+	return nil
+}
+
 func generateOverridesFile(ctx spi.ComponentContext, contents []byte) (string, error) {
 	file, err := os.CreateTemp(os.TempDir(), tmpFileCreatePattern)
 	if err != nil {
@@ -377,6 +418,12 @@ func generateOverridesFile(ctx spi.ComponentContext, contents []byte) (string, e
 
 // createJaegerSecret creates a Jaeger secret for storing credentials needed to access OpenSearch.
 func createJaegerSecret(ctx spi.ComponentContext) error {
+	switch getStorageType(ctx.EffectiveCR()) {
+	case v1alpha1.JaegerStorageMemory:
+		return nil
+	case v1alpha1.JaegerStorageExternalOpenSearch:
+		return createExternalStorageSecret(ctx)
+	}
 	// Check if the user has specified a Jaeger secret override. As the user is expected to create the secret in
 	// verrazzano-install namespace, copy it to verrazzano-monitoring namespace.
 	jaegerSecretOverride, err := getOverrideVal(ctx, jaegerSecNameField)
@@ -450,8 +497,8 @@ func getESInternalSecret(ctx spi.ComponentContext) (corev1.Secret, error) {
 
 // isCreateDefaultJaegerInstance determines if the default Jaeger instance has to be created or not.
 func isCreateDefaultJaegerInstance(ctx spi.ComponentContext) (bool, error) {
-	// Default Jaeger instance will be created if Verrazzano's OpenSearch can be used as storage
-	if canUseVZOpenSearchStorage(ctx) {
+	// Default Jaeger instance will be created if its storage can be used
+	if canUseStorage(ctx) {
 		jaegerCreateOverride, err := getOverrideVal(ctx, jaegerCreateField)
 		if err != nil {
 			return false, err
@@ -475,8 +522,8 @@ func isJaegerCREnabled(ctx spi.ComponentContext) (bool, error) {
 	if jaegerCreateOverride != nil {
 		return jaegerCreateOverride.(bool), nil
 	}
-	// Jaeger instance would be created if Verrazzano's OpenSearch can be used as storage
-	return canUseVZOpenSearchStorage(ctx), nil
+	// Jaeger instance would be created if its storage can be used
+	return canUseStorage(ctx), nil
 }
 
 // canUseVZOpenSearchStorage determines if Verrazzano's OpenSearch can be used as a storage for Jaeger instance.
@@ -757,7 +804,7 @@ func createOrUpdateJaegerIngress(ctx spi.ComponentContext, namespace string) err
 			ingress.Annotations = make(map[string]string)
 		}
 		ingress.Annotations["kubernetes.io/tls-acme"] = "true"
-		ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = "6M"
+		ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = vzconfig.GetSystemIngressBodySize(ctx.EffectiveCR())
 		ingress.Annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/$2"
 		ingress.Annotations["nginx.ingress.kubernetes.io/secure-backends"] = "false"
 		ingress.Annotations["nginx.ingress.kubernetes.io/backend-protocol"] = "HTTP"
@@ -73,6 +73,24 @@ var (
 	}
 )
 
+// networkPolicyRules are the network peers of the Jaeger pods outside of the verrazzano-monitoring namespace, the
+// collectors receive the spans of all the namespaces and write them to the storage, the query is reached through the
+// proxy, and the operator serves its webhook
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app": "jaeger"},
+		Ingress: []spi.NetworkPeer{
+			{Ports: []int32{9411, 14250, 14268}},
+			{Namespace: constants.VerrazzanoSystemNamespace, Ports: []int32{16686}},
+		},
+		Egress: []spi.NetworkPeer{{}},
+	},
+	{
+		PodLabels: map[string]string{"app.kubernetes.io/name": ComponentName},
+		Ingress:   []spi.NetworkPeer{{Ports: []int32{9443}}},
+	},
+}
+
 func NewComponent() spi.Component {
 	return jaegerOperatorComponent{
 		helm.HelmComponent{
@@ -80,6 +98,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ChartDir),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -89,6 +108,13 @@ func NewComponent() spi.Component {
 			Dependencies:              []string{certmanager.ComponentName, opensearch.ComponentName},
 			AppendOverridesFunc:       AppendOverrides,
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
@@ -209,6 +209,22 @@ func TestAppendOverrides(t *testing.T) {
 			numKeyValues: 1,
 			expectedErr:  nil,
 		},
+		{
+			name:         "MemoryStorageAndSampling",
+			description:  "Test the in-memory storage and the sampling strategies",
+			expectedYAML: "testdata/jaegerOperatorMemoryStorageValues.yaml",
+			actualCR:     "testdata/jaegerOperatorMemoryStorageVz.yaml",
+			numKeyValues: 1,
+			expectedErr:  nil,
+		},
+		{
+			name:         "ExternalOpenSearchStorage",
+			description:  "Test the external OpenSearch storage with a retention",
+			expectedYAML: "testdata/jaegerOperatorExternalStorageValues.yaml",
+			actualCR:     "testdata/jaegerOperatorExternalStorageVz.yaml",
+			numKeyValues: 1,
+			expectedErr:  nil,
+		},
 	}
 	defer resetWriteFileFunc()
 	for _, test := range tests {
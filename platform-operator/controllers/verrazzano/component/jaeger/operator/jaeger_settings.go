@@ -0,0 +1,292 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/opensearch"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Keys of the credentials secret of an external OpenSearch
+	storageUsernameKey = "username"
+	storagePasswordKey = "password"
+
+	// Maximum number of traces kept by the in-memory storage
+	memoryMaxTraces = 100000
+
+	jaegerStorageTypeField = "jaeger.spec.storage.type"
+	jaegerSamplingField    = "jaeger.spec.sampling"
+
+	samplingProbabilistic = "probabilistic"
+	samplingRateLimiting  = "ratelimiting"
+)
+
+// samplingStrategy is a Jaeger sampling strategy, the param is the sampling probability of the probabilistic
+// strategy and the maximum traces per second of the rate limiting strategy
+type samplingStrategy struct {
+	Service string  `json:"service,omitempty"`
+	Type    string  `json:"type"`
+	Param   float64 `json:"param"`
+}
+
+// samplingOptions are the options of the Jaeger sampling strategies file
+type samplingOptions struct {
+	DefaultStrategy   *samplingStrategy  `json:"default_strategy,omitempty"`
+	ServiceStrategies []samplingStrategy `json:"service_strategies,omitempty"`
+}
+
+// getStorageType returns the storage backend of the default Jaeger instance, Verrazzano OpenSearch by default
+func getStorageType(cr *v1alpha1.Verrazzano) v1alpha1.JaegerStorageType {
+	jaeger := cr.Spec.Components.JaegerOperator
+	if jaeger == nil || jaeger.Storage == nil || len(jaeger.Storage.Type) == 0 {
+		return v1alpha1.JaegerStorageVerrazzanoOpenSearch
+	}
+	return jaeger.Storage.Type
+}
+
+// canUseStorage determines if the storage backend of the default Jaeger instance is available
+func canUseStorage(ctx spi.ComponentContext) bool {
+	if getStorageType(ctx.EffectiveCR()) == v1alpha1.JaegerStorageVerrazzanoOpenSearch {
+		return canUseVZOpenSearchStorage(ctx)
+	}
+	return true
+}
+
+// buildJaegerData returns the template data of the default Jaeger instance
+func buildJaegerData(ctx spi.ComponentContext) (jaegerData, error) {
+	data := jaegerData{
+		OpenSearchURL: openSearchURL,
+		SecretName:    globalconst.DefaultJaegerSecretName,
+		MaxTraces:     memoryMaxTraces,
+	}
+	jaeger := ctx.EffectiveCR().Spec.Components.JaegerOperator
+	switch getStorageType(ctx.EffectiveCR()) {
+	case v1alpha1.JaegerStorageMemory:
+		data.MemoryStorage = true
+	case v1alpha1.JaegerStorageExternalOpenSearch:
+		data.OpenSearchURL = jaeger.Storage.URL
+		data.OpenSearchReplicaCount = 1
+	default:
+		data.OpenSearchReplicaCount = 1
+		if opensearch.IsSingleDataNodeCluster(ctx) {
+			data.OpenSearchReplicaCount = 0
+		}
+	}
+	if jaeger != nil && jaeger.Storage != nil && jaeger.Storage.RetentionDays != nil {
+		data.RetentionDays = *jaeger.Storage.RetentionDays
+	}
+	if jaeger != nil && jaeger.Sampling != nil {
+		sampling, err := buildSamplingOptions(jaeger.Sampling)
+		if err != nil {
+			return data, err
+		}
+		data.SamplingOptions = sampling
+	}
+	return data, nil
+}
+
+// buildSamplingOptions returns the YAML of the Jaeger sampling options, indented for the Jaeger template
+func buildSamplingOptions(sampling *v1alpha1.JaegerSamplingSpec) (string, error) {
+	options := samplingOptions{}
+	if len(sampling.DefaultPercentage) > 0 {
+		probability, err := parseSamplingPercentage(sampling.DefaultPercentage)
+		if err != nil {
+			return "", err
+		}
+		options.DefaultStrategy = &samplingStrategy{Type: samplingProbabilistic, Param: probability}
+	}
+	for _, service := range sampling.Services {
+		strategy := samplingStrategy{Service: service.Service}
+		if service.MaxTracesPerSecond != nil {
+			strategy.Type = samplingRateLimiting
+			strategy.Param = float64(*service.MaxTracesPerSecond)
+		} else {
+			probability, err := parseSamplingPercentage(service.Percentage)
+			if err != nil {
+				return "", err
+			}
+			strategy.Type = samplingProbabilistic
+			strategy.Param = probability
+		}
+		options.ServiceStrategies = append(options.ServiceStrategies, strategy)
+	}
+	if options.DefaultStrategy == nil && len(options.ServiceStrategies) == 0 {
+		return "", nil
+	}
+	out, err := yaml.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	for i := range lines {
+		lines[i] = "        " + lines[i]
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// parseSamplingPercentage returns the sampling probability of a percentage
+func parseSamplingPercentage(percentage string) (float64, error) {
+	value, err := strconv.ParseFloat(percentage, 64)
+	if err != nil || value < 0 || value > 100 {
+		return 0, fmt.Errorf("invalid Jaeger sampling percentage %q, it must be a number from 0 to 100", percentage)
+	}
+	return value / 100, nil
+}
+
+// createExternalStorageSecret creates the Jaeger secret with the credentials of the external OpenSearch
+func createExternalStorageSecret(ctx spi.ComponentContext) error {
+	secretName := ctx.EffectiveCR().Spec.Components.JaegerOperator.Storage.CredentialsSecret
+	credentials := corev1.Secret{}
+	if err := ctx.Client().Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: constants.VerrazzanoInstallNamespace}, &credentials); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the Jaeger OpenSearch credentials secret %s/%s: %v",
+			constants.VerrazzanoInstallNamespace, secretName, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      globalconst.DefaultJaegerSecretName,
+			Namespace: ComponentNamespace,
+		},
+	}
+	if _, err := controllerruntime.CreateOrUpdate(context.TODO(), ctx.Client(), secret, func() error {
+		secret.Data = map[string][]byte{
+			"ES_USERNAME": credentials.Data[storageUsernameKey],
+			"ES_PASSWORD": credentials.Data[storagePasswordKey],
+		}
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed to create or update the %s secret: %v",
+			globalconst.DefaultJaegerSecretName, err)
+	}
+	return nil
+}
+
+// validateJaegerSettings validates the storage and sampling settings of the default Jaeger instance
+func validateJaegerSettings(cr *v1beta1.Verrazzano, client clipkg.Client) error {
+	jaeger := cr.Spec.Components.JaegerOperator
+	overrideYAMLs, err := common.GetInstallOverridesYAMLUsingClient(client, jaeger.ValueOverrides, ComponentNamespace)
+	if err != nil {
+		return err
+	}
+	if jaeger.Storage != nil {
+		if err := validateStorage(cr, jaeger.Storage, client); err != nil {
+			return err
+		}
+		if err := checkSettingOverride(overrideYAMLs, jaegerStorageTypeField, "storage"); err != nil {
+			return err
+		}
+	}
+	if jaeger.Sampling != nil {
+		if err := validateSampling(jaeger.Sampling); err != nil {
+			return err
+		}
+		if err := checkSettingOverride(overrideYAMLs, jaegerSamplingField, "sampling"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStorage validates the storage backend of the default Jaeger instance
+func validateStorage(cr *v1beta1.Verrazzano, storage *v1beta1.JaegerStorageSpec, client clipkg.Client) error {
+	switch storage.Type {
+	case v1beta1.JaegerStorageVerrazzanoOpenSearch, "":
+		if !vzconfig.IsOpenSearchEnabled(cr) || !vzconfig.IsKeycloakEnabled(cr) {
+			return fmt.Errorf("the Jaeger %s storage requires the OpenSearch and Keycloak components to be enabled", v1beta1.JaegerStorageVerrazzanoOpenSearch)
+		}
+	case v1beta1.JaegerStorageExternalOpenSearch:
+		if len(storage.URL) == 0 || len(storage.CredentialsSecret) == 0 {
+			return fmt.Errorf("the Jaeger %s storage requires the url and credentialsSecret fields", storage.Type)
+		}
+		if u, err := url.Parse(storage.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return fmt.Errorf("the Jaeger OpenSearch url %s is not a valid http or https URL", storage.URL)
+		}
+		secret := corev1.Secret{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: storage.CredentialsSecret, Namespace: constants.VerrazzanoInstallNamespace}, &secret)
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("the Jaeger OpenSearch credentials secret %s/%s does not exist", constants.VerrazzanoInstallNamespace, storage.CredentialsSecret)
+		}
+		if err != nil {
+			return err
+		}
+		for _, key := range []string{storageUsernameKey, storagePasswordKey} {
+			if len(secret.Data[key]) == 0 {
+				return fmt.Errorf("the Jaeger OpenSearch credentials secret %s/%s has no %s", constants.VerrazzanoInstallNamespace, storage.CredentialsSecret, key)
+			}
+		}
+	case v1beta1.JaegerStorageMemory:
+		if len(storage.URL) > 0 || len(storage.CredentialsSecret) > 0 || storage.RetentionDays != nil {
+			return fmt.Errorf("the url, credentialsSecret and retentionDays fields are not supported by the Jaeger %s storage", storage.Type)
+		}
+	default:
+		return fmt.Errorf("invalid Jaeger storage type %s", storage.Type)
+	}
+	if storage.RetentionDays != nil && *storage.RetentionDays < 1 {
+		return fmt.Errorf("the Jaeger storage retentionDays must be at least 1")
+	}
+	return nil
+}
+
+// validateSampling validates the sampling strategies of the default Jaeger instance
+func validateSampling(sampling *v1beta1.JaegerSamplingSpec) error {
+	if len(sampling.DefaultPercentage) > 0 {
+		if _, err := parseSamplingPercentage(sampling.DefaultPercentage); err != nil {
+			return err
+		}
+	}
+	services := make(map[string]bool)
+	for _, service := range sampling.Services {
+		if len(service.Service) == 0 {
+			return fmt.Errorf("the Jaeger sampling strategies require a service name")
+		}
+		if services[service.Service] {
+			return fmt.Errorf("the Jaeger sampling strategy of service %s is specified more than once", service.Service)
+		}
+		services[service.Service] = true
+		if (len(service.Percentage) > 0) == (service.MaxTracesPerSecond != nil) {
+			return fmt.Errorf("the Jaeger sampling strategy of service %s requires either a percentage or maxTracesPerSecond", service.Service)
+		}
+		if len(service.Percentage) > 0 {
+			if _, err := parseSamplingPercentage(service.Percentage); err != nil {
+				return err
+			}
+		} else if *service.MaxTracesPerSecond < 1 {
+			return fmt.Errorf("the Jaeger sampling maxTracesPerSecond of service %s must be at least 1", service.Service)
+		}
+	}
+	return nil
+}
+
+// checkSettingOverride returns an error if a Jaeger setting is also specified with a Helm value override
+func checkSettingOverride(overrideYAMLs []string, field string, setting string) error {
+	for _, overrideYAML := range overrideYAMLs {
+		value, err := common.ExtractValueFromOverrideString(overrideYAML, field)
+		if err != nil {
+			return err
+		}
+		if value != nil {
+			return fmt.Errorf("the Jaeger Operator Helm chart value %s cannot be overridden when the Jaeger %s is specified", field, setting)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var jaegerCredentialsSecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{Name: "jaeger-opensearch", Namespace: constants.VerrazzanoInstallNamespace},
+	Data: map[string][]byte{
+		storageUsernameKey: []byte("jaeger"),
+		storagePasswordKey: []byte("changeme"),
+	},
+}
+
+func newJaegerSettingsCR(storage *vzapi.JaegerStorageSpec, sampling *vzapi.JaegerSamplingSpec) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				JaegerOperator: &vzapi.JaegerOperatorComponent{
+					Enabled:  &trueValue,
+					Storage:  storage,
+					Sampling: sampling,
+				},
+			},
+		},
+	}
+}
+
+// TestValidateJaegerSettings tests the validateJaegerSettings function
+// GIVEN Verrazzano CRs with valid and invalid Jaeger storage and sampling settings
+// WHEN validateJaegerSettings is called
+// THEN an error is returned for the invalid settings
+func TestValidateJaegerSettings(t *testing.T) {
+	maxTraces := int32(10)
+	zero := int32(0)
+	external := &vzapi.JaegerStorageSpec{
+		Type:              vzapi.JaegerStorageExternalOpenSearch,
+		URL:               "https://opensearch.example.com:9200",
+		CredentialsSecret: jaegerCredentialsSecret.Name,
+	}
+	storageOverride := newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageMemory}, nil)
+	storageOverride.Spec.Components.JaegerOperator.ValueOverrides = []vzapi.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"jaeger": {"spec": {"storage": {"type": "badger"}}}}`)}},
+	}
+	noOpenSearch := newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageVerrazzanoOpenSearch}, nil)
+	noOpenSearch.Spec.Components.Elasticsearch = &vzapi.ElasticsearchComponent{Enabled: &falseValue}
+
+	tests := []struct {
+		name      string
+		vz        *vzapi.Verrazzano
+		expectErr bool
+	}{
+		{name: "no settings", vz: newJaegerSettingsCR(nil, nil)},
+		{name: "external OpenSearch", vz: newJaegerSettingsCR(external, nil)},
+		{name: "memory", vz: newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageMemory}, nil)},
+		{
+			name: "sampling strategies",
+			vz: newJaegerSettingsCR(nil, &vzapi.JaegerSamplingSpec{
+				DefaultPercentage: "0.5",
+				Services: []vzapi.JaegerServiceSampling{
+					{Service: "orders", Percentage: "100"},
+					{Service: "payments", MaxTracesPerSecond: &maxTraces},
+				},
+			}),
+		},
+		{
+			name:      "Verrazzano OpenSearch disabled",
+			vz:        noOpenSearch,
+			expectErr: true,
+		},
+		{
+			name:      "external OpenSearch without URL",
+			vz:        newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageExternalOpenSearch, CredentialsSecret: jaegerCredentialsSecret.Name}, nil),
+			expectErr: true,
+		},
+		{
+			name:      "external OpenSearch with invalid URL",
+			vz:        newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageExternalOpenSearch, URL: "opensearch:9200", CredentialsSecret: jaegerCredentialsSecret.Name}, nil),
+			expectErr: true,
+		},
+		{
+			name:      "external OpenSearch with missing secret",
+			vz:        newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageExternalOpenSearch, URL: external.URL, CredentialsSecret: "missing"}, nil),
+			expectErr: true,
+		},
+		{
+			name:      "memory with retention",
+			vz:        newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageMemory, RetentionDays: &maxTraces}, nil),
+			expectErr: true,
+		},
+		{
+			name:      "storage also overridden",
+			vz:        storageOverride,
+			expectErr: true,
+		},
+		{
+			name:      "invalid percentage",
+			vz:        newJaegerSettingsCR(nil, &vzapi.JaegerSamplingSpec{DefaultPercentage: "101"}),
+			expectErr: true,
+		},
+		{
+			name: "duplicate service",
+			vz: newJaegerSettingsCR(nil, &vzapi.JaegerSamplingSpec{Services: []vzapi.JaegerServiceSampling{
+				{Service: "orders", Percentage: "10"},
+				{Service: "orders", Percentage: "20"},
+			}}),
+			expectErr: true,
+		},
+		{
+			name: "percentage and rate limit",
+			vz: newJaegerSettingsCR(nil, &vzapi.JaegerSamplingSpec{Services: []vzapi.JaegerServiceSampling{
+				{Service: "orders", Percentage: "10", MaxTracesPerSecond: &maxTraces},
+			}}),
+			expectErr: true,
+		},
+		{
+			name: "invalid rate limit",
+			vz: newJaegerSettingsCR(nil, &vzapi.JaegerSamplingSpec{Services: []vzapi.JaegerServiceSampling{
+				{Service: "orders", MaxTracesPerSecond: &zero},
+			}}),
+			expectErr: true,
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(jaegerCredentialsSecret).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			convertedVZ := v1beta1.Verrazzano{}
+			assert.NoError(t, common.ConvertVerrazzanoCR(tt.vz, &convertedVZ))
+			err := validateJaegerSettings(&convertedVZ, client)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestCreateJaegerSecretExternalStorage tests the createJaegerSecret function
+// GIVEN a Verrazzano CR with the external OpenSearch storage
+// WHEN createJaegerSecret is called
+// THEN the Jaeger secret has the credentials of the external OpenSearch
+func TestCreateJaegerSecretExternalStorage(t *testing.T) {
+	vz := newJaegerSettingsCR(&vzapi.JaegerStorageSpec{
+		Type:              vzapi.JaegerStorageExternalOpenSearch,
+		URL:               "https://opensearch.example.com:9200",
+		CredentialsSecret: jaegerCredentialsSecret.Name,
+	}, nil)
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(jaegerCredentialsSecret).Build()
+	ctx := spi.NewFakeContext(client, vz, nil, false, profileDir)
+
+	createInstance, err := isCreateDefaultJaegerInstance(ctx)
+	assert.NoError(t, err)
+	assert.True(t, createInstance)
+	assert.NoError(t, createJaegerSecret(ctx))
+
+	secret := corev1.Secret{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: globalconst.DefaultJaegerSecretName, Namespace: ComponentNamespace}, &secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "jaeger", string(secret.Data["ES_USERNAME"]))
+	assert.Equal(t, "changeme", string(secret.Data["ES_PASSWORD"]))
+}
+
+// TestIsCreateDefaultJaegerInstanceMemoryStorage tests the isCreateDefaultJaegerInstance function
+// GIVEN a Verrazzano CR with the in-memory storage and OpenSearch disabled
+// WHEN isCreateDefaultJaegerInstance is called
+// THEN the default Jaeger instance is created
+func TestIsCreateDefaultJaegerInstanceMemoryStorage(t *testing.T) {
+	vz := newJaegerSettingsCR(&vzapi.JaegerStorageSpec{Type: vzapi.JaegerStorageMemory}, nil)
+	vz.Spec.Components.Elasticsearch = &vzapi.ElasticsearchComponent{Enabled: &falseValue}
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), vz, nil, false, profileDir)
+
+	createInstance, err := isCreateDefaultJaegerInstance(ctx)
+	assert.NoError(t, err)
+	assert.True(t, createInstance)
+}
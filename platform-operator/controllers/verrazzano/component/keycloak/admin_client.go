@@ -0,0 +1,502 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	keycloakAdminUser     = "keycloakadmin"
+	keycloakAdminClientID = "admin-cli"
+	keycloakAdminSecret   = "keycloak-http" //nolint:gosec //#gosec G101
+	keycloakTokenPath     = "/auth/realms/master/protocol/openid-connect/token"
+	keycloakAdminPath     = "/auth/admin/realms"
+
+	// tokenExpiryMargin is subtracted from the lifetime of an access token so that it is renewed before it expires
+	tokenExpiryMargin = 10 * time.Second
+)
+
+// AdminEndpoint describes how the Keycloak Admin REST API is reached
+type AdminEndpoint struct {
+	// URL is the base URL of the Keycloak server
+	URL string
+	// Host is sent as the Host header of each request when it is different from the URL host
+	Host string
+	// Client is the HTTP client used to send the requests
+	Client *http.Client
+}
+
+// AdminEndpointFuncType is the function type that returns the endpoint of the Keycloak Admin REST API
+type AdminEndpointFuncType func(ctx spi.ComponentContext) (*AdminEndpoint, error)
+
+var getAdminEndpointFunc AdminEndpointFuncType = getIngressAdminEndpoint
+
+// SetAdminEndpointFunc sets the function used to get the endpoint of the Keycloak Admin REST API, for unit testing
+func SetAdminEndpointFunc(f AdminEndpointFuncType) {
+	getAdminEndpointFunc = f
+}
+
+// SetDefaultAdminEndpointFunc restores the function used to get the endpoint of the Keycloak Admin REST API
+func SetDefaultAdminEndpointFunc() {
+	getAdminEndpointFunc = getIngressAdminEndpoint
+}
+
+// adminClient is a client for the Keycloak Admin REST API that logs in as the Keycloak admin user
+type adminClient struct {
+	// reqCtx is the context of the requests, they are cancelled when it is done
+	reqCtx      context.Context
+	endpoint    *AdminEndpoint
+	username    string
+	password    string
+	token       string
+	tokenExpiry time.Time
+}
+
+// adminAPIError is returned when the Keycloak Admin REST API responds with an unexpected status code
+type adminAPIError struct {
+	method     string
+	path       string
+	statusCode int
+	body       string
+}
+
+// Error returns the request and the response from Keycloak, the request body is not included since it may
+// contain credentials
+func (e *adminAPIError) Error() string {
+	return fmt.Sprintf("Keycloak request %s %s failed with response code %d: %s", e.method, e.path, e.statusCode, strings.TrimSpace(e.body))
+}
+
+// tokenResponse is the response of the OpenID Connect token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// realmRepresentation is the subset of the Keycloak realm representation managed by Verrazzano, unset fields are
+// left unchanged when a realm is updated
+type realmRepresentation struct {
+	Realm          string `json:"realm,omitempty"`
+	Enabled        *bool  `json:"enabled,omitempty"`
+	PasswordPolicy string `json:"passwordPolicy,omitempty"`
+	LoginTheme     string `json:"loginTheme,omitempty"`
+}
+
+// groupRepresentation is the Keycloak representation of a group
+type groupRepresentation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+// roleRepresentation is the Keycloak representation of a realm role
+type roleRepresentation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// userRepresentation is the Keycloak representation of a user, unset fields are left unchanged when a user is updated
+type userRepresentation struct {
+	ID          string                     `json:"id,omitempty"`
+	Username    string                     `json:"username"`
+	Enabled     bool                       `json:"enabled"`
+	FirstName   string                     `json:"firstName,omitempty"`
+	LastName    string                     `json:"lastName,omitempty"`
+	Email       string                     `json:"email,omitempty"`
+	Groups      []string                   `json:"groups,omitempty"`
+	Attributes  map[string][]string        `json:"attributes,omitempty"`
+	Credentials []credentialRepresentation `json:"credentials,omitempty"`
+}
+
+// clientRepresentation is the subset of the Keycloak client representation managed for declared clients, unset
+// fields are left unchanged when a client is updated
+type clientRepresentation struct {
+	ID                  string   `json:"id,omitempty"`
+	ClientID            string   `json:"clientId"`
+	Enabled             bool     `json:"enabled"`
+	Protocol            string   `json:"protocol,omitempty"`
+	PublicClient        bool     `json:"publicClient"`
+	StandardFlowEnabled bool     `json:"standardFlowEnabled"`
+	RedirectURIs        []string `json:"redirectUris"`
+	WebOrigins          []string `json:"webOrigins"`
+	Secret              string   `json:"secret,omitempty"`
+}
+
+// credentialRepresentation is the Keycloak representation used to set the password of a user
+type credentialRepresentation struct {
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Temporary bool   `json:"temporary"`
+}
+
+// newAdminClient returns a client for the Keycloak Admin REST API using the Keycloak admin credentials
+func newAdminClient(ctx spi.ComponentContext) (*adminClient, error) {
+	password, err := getSecretPassword(ctx, ComponentNamespace, keycloakAdminSecret)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := getAdminEndpointFunc(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &adminClient{
+		reqCtx:   context.TODO(),
+		endpoint: endpoint,
+		username: keycloakAdminUser,
+		password: password,
+	}, nil
+}
+
+// getIngressAdminEndpoint returns an endpoint that reaches Keycloak over TLS through the ingress controller, using
+// the Keycloak host name and trusting the CA of the Keycloak certificate
+func getIngressAdminEndpoint(ctx spi.ComponentContext) (*AdminEndpoint, error) {
+	dnsSubDomain, err := getDNSDomain(ctx.Client(), ctx.EffectiveCR())
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := common.GetIngressEndpoint(ctx, "keycloak."+dnsSubDomain, types.NamespacedName{Namespace: ComponentNamespace, Name: keycloakCertificateName})
+	if err != nil {
+		return nil, err
+	}
+	return &AdminEndpoint{
+		URL:    endpoint.URL,
+		Host:   endpoint.Host,
+		Client: endpoint.Client,
+	}, nil
+}
+
+// login gets an access token for the admin user, the token is reused until it is about to expire
+func (c *adminClient) login() error {
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return nil
+	}
+	form := url.Values{
+		"grant_type": {"password"},
+		"client_id":  {keycloakAdminClientID},
+		"username":   {c.username},
+		"password":   {c.password},
+	}
+	req, err := c.newRequest(http.MethodPost, keycloakTokenPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, body, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &adminAPIError{method: http.MethodPost, path: keycloakTokenPath, statusCode: resp.StatusCode, body: string(body)}
+	}
+	token := tokenResponse{}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return fmt.Errorf("Failed parsing the Keycloak token response: %v", err)
+	}
+	if token.AccessToken == "" {
+		return errors.New("Failed logging into Keycloak, the token response does not contain an access token")
+	}
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return nil
+}
+
+// do sends a request to the Admin REST API for the realm, the path is relative to the realm.  The request body is
+// marshalled from in, and the response body is unmarshalled into out when the response has one of the expected
+// status codes.  The response is returned so callers can read headers or handle other status codes.
+func (c *adminClient) do(method string, realm string, relPath string, in interface{}, out interface{}, expected ...int) (*http.Response, error) {
+	reqPath := path.Join(keycloakAdminPath, realm, relPath)
+	var payload []byte
+	if in != nil {
+		var err error
+		if payload, err = json.Marshal(in); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *http.Response
+	var body []byte
+	// Retry once with a new token if the current one has been revoked
+	for attempt := 0; attempt < 2; attempt++ {
+		if err := c.login(); err != nil {
+			return nil, err
+		}
+		req, err := c.newRequest(method, reqPath, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, body, err = c.send(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized {
+			break
+		}
+		c.token = ""
+	}
+
+	for _, code := range expected {
+		if resp.StatusCode != code {
+			continue
+		}
+		if out != nil && len(body) > 0 {
+			if err := json.Unmarshal(body, out); err != nil {
+				return resp, fmt.Errorf("Failed parsing the response of Keycloak request %s %s: %v", method, reqPath, err)
+			}
+		}
+		return resp, nil
+	}
+	return resp, &adminAPIError{method: method, path: reqPath, statusCode: resp.StatusCode, body: string(body)}
+}
+
+// newRequest builds a request for the path on the Keycloak server
+func (c *adminClient) newRequest(method string, reqPath string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(c.reqCtx, method, strings.TrimSuffix(c.endpoint.URL, "/")+reqPath, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.endpoint.Host != "" {
+		req.Host = c.endpoint.Host
+	}
+	return req, nil
+}
+
+// send sends the request and reads the response body
+func (c *adminClient) send(req *http.Request) (*http.Response, []byte, error) {
+	httpClient := c.endpoint.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed sending Keycloak request %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed reading the response of Keycloak request %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	return resp, body, nil
+}
+
+// isStatus returns true if the error is a Keycloak API error with the status code
+func isStatus(err error, statusCode int) bool {
+	apiErr := &adminAPIError{}
+	return errors.As(err, &apiErr) && apiErr.statusCode == statusCode
+}
+
+// getCreatedID returns the ID of a created resource, which is the last segment of the Location header
+func getCreatedID(resp *http.Response) (string, error) {
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("Keycloak request %s %s did not return the location of the created resource", resp.Request.Method, resp.Request.URL.Path)
+	}
+	return path.Base(location), nil
+}
+
+// realmExists returns true if the realm exists
+func (c *adminClient) realmExists(realm string) (bool, error) {
+	_, err := c.do(http.MethodGet, realm, "", nil, nil, http.StatusOK)
+	if isStatus(err, http.StatusNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// createRealm creates a realm
+func (c *adminClient) createRealm(rep realmRepresentation) error {
+	_, err := c.do(http.MethodPost, "", "", rep, nil, http.StatusCreated)
+	return err
+}
+
+// updateRealm updates the fields of the realm that are set in rep
+func (c *adminClient) updateRealm(realm string, rep realmRepresentation) error {
+	_, err := c.do(http.MethodPut, realm, "", rep, nil, http.StatusNoContent)
+	return err
+}
+
+// getGroups returns the top level groups of the realm along with their subgroups
+func (c *adminClient) getGroups(realm string) (KeycloakGroups, error) {
+	var groups KeycloakGroups
+	_, err := c.do(http.MethodGet, realm, "groups", nil, &groups, http.StatusOK)
+	return groups, err
+}
+
+// createGroup creates a group, as a subgroup of the parent if parentID is set, and returns the group ID
+func (c *adminClient) createGroup(realm string, name string, parentID string) (string, error) {
+	groupsPath := "groups"
+	if parentID != "" {
+		groupsPath = path.Join("groups", parentID, "children")
+	}
+	resp, err := c.do(http.MethodPost, realm, groupsPath, groupRepresentation{Name: name}, nil, http.StatusCreated)
+	if err != nil {
+		return "", err
+	}
+	return getCreatedID(resp)
+}
+
+// getRoles returns the realm roles
+func (c *adminClient) getRoles(realm string) (KeycloakRoles, error) {
+	var roles KeycloakRoles
+	_, err := c.do(http.MethodGet, realm, "roles", nil, &roles, http.StatusOK)
+	return roles, err
+}
+
+// getRole returns the realm role with the name
+func (c *adminClient) getRole(realm string, name string) (*roleRepresentation, error) {
+	role := &roleRepresentation{}
+	_, err := c.do(http.MethodGet, realm, path.Join("roles", url.PathEscape(name)), nil, role, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// createRole creates a realm role
+func (c *adminClient) createRole(realm string, name string) error {
+	_, err := c.do(http.MethodPost, realm, "roles", roleRepresentation{Name: name}, nil, http.StatusCreated)
+	return err
+}
+
+// addRealmRolesToGroup maps the realm roles to the group, roles that are already mapped are ignored by Keycloak
+func (c *adminClient) addRealmRolesToGroup(realm string, groupID string, roles []roleRepresentation) error {
+	_, err := c.do(http.MethodPost, realm, path.Join("groups", groupID, "role-mappings", "realm"), roles, nil, http.StatusNoContent)
+	return err
+}
+
+// getGroupRealmRoles returns the realm roles mapped to the group
+func (c *adminClient) getGroupRealmRoles(realm string, groupID string) ([]roleRepresentation, error) {
+	var roles []roleRepresentation
+	_, err := c.do(http.MethodGet, realm, path.Join("groups", groupID, "role-mappings", "realm"), nil, &roles, http.StatusOK)
+	return roles, err
+}
+
+// getUsers returns the users of the realm, only users with a user name containing username are returned if it is set
+func (c *adminClient) getUsers(realm string, username string) ([]KeycloakUser, error) {
+	var users []KeycloakUser
+	usersPath := "users"
+	if username != "" {
+		usersPath += "?" + url.Values{"username": {username}}.Encode()
+	}
+	_, err := c.do(http.MethodGet, realm, usersPath, nil, &users, http.StatusOK)
+	return users, err
+}
+
+// createUser creates a user and returns the user ID
+func (c *adminClient) createUser(realm string, user userRepresentation) (string, error) {
+	resp, err := c.do(http.MethodPost, realm, "users", user, nil, http.StatusCreated)
+	if err != nil {
+		return "", err
+	}
+	return getCreatedID(resp)
+}
+
+// getUser returns the user with the user name, nil is returned if the user does not exist
+func (c *adminClient) getUser(realm string, username string) (*userRepresentation, error) {
+	var users []userRepresentation
+	usersPath := "users?" + url.Values{"username": {username}}.Encode()
+	if _, err := c.do(http.MethodGet, realm, usersPath, nil, &users, http.StatusOK); err != nil {
+		return nil, err
+	}
+	// Keycloak returns all the users with a user name containing username
+	for i := range users {
+		if users[i].Username == username {
+			return &users[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// updateUser updates the fields of the user that are set in rep
+func (c *adminClient) updateUser(realm string, userID string, rep userRepresentation) error {
+	_, err := c.do(http.MethodPut, realm, path.Join("users", userID), rep, nil, http.StatusNoContent)
+	return err
+}
+
+// getUserGroups returns the groups the user is a member of
+func (c *adminClient) getUserGroups(realm string, userID string) ([]groupRepresentation, error) {
+	var groups []groupRepresentation
+	_, err := c.do(http.MethodGet, realm, path.Join("users", userID, "groups"), nil, &groups, http.StatusOK)
+	return groups, err
+}
+
+// addUserToGroup makes the user a member of the group
+func (c *adminClient) addUserToGroup(realm string, userID string, groupID string) error {
+	_, err := c.do(http.MethodPut, realm, path.Join("users", userID, "groups", groupID), nil, nil, http.StatusNoContent)
+	return err
+}
+
+// resetPassword sets a permanent password for the user
+func (c *adminClient) resetPassword(realm string, userID string, password string) error {
+	cred := credentialRepresentation{Type: "password", Value: password}
+	_, err := c.do(http.MethodPut, realm, path.Join("users", userID, "reset-password"), cred, nil, http.StatusNoContent)
+	return err
+}
+
+// getClients returns the clients of the realm
+func (c *adminClient) getClients(realm string) (KeycloakClients, error) {
+	var clients KeycloakClients
+	_, err := c.do(http.MethodGet, realm, "clients", nil, &clients, http.StatusOK)
+	return clients, err
+}
+
+// createClient creates a client from its representation, either a clientRepresentation or raw JSON, and returns the client ID
+func (c *adminClient) createClient(realm string, rep interface{}) (string, error) {
+	resp, err := c.do(http.MethodPost, realm, "clients", rep, nil, http.StatusCreated)
+	if err != nil {
+		return "", err
+	}
+	return getCreatedID(resp)
+}
+
+// getClient returns the client with the ID
+func (c *adminClient) getClient(realm string, id string) (*clientRepresentation, error) {
+	client := &clientRepresentation{}
+	_, err := c.do(http.MethodGet, realm, path.Join("clients", id), nil, client, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// updateClient updates the fields of the client that are set in its representation, either a clientRepresentation or raw JSON
+func (c *adminClient) updateClient(realm string, id string, rep interface{}) error {
+	_, err := c.do(http.MethodPut, realm, path.Join("clients", id), rep, nil, http.StatusNoContent)
+	return err
+}
+
+// generateClientSecret generates a new secret for the client
+func (c *adminClient) generateClientSecret(realm string, id string) error {
+	_, err := c.do(http.MethodPost, realm, path.Join("clients", id, "client-secret"), nil, nil, http.StatusOK)
+	return err
+}
+
+// getClientSecret returns the secret of the client
+func (c *adminClient) getClientSecret(realm string, id string) (*KeycloakClientSecret, error) {
+	secret := &KeycloakClientSecret{}
+	_, err := c.do(http.MethodGet, realm, path.Join("clients", id, "client-secret"), nil, secret, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
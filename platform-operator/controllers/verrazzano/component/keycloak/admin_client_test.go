@@ -0,0 +1,659 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testAdminPassword = "password"
+
+// fakeGroup is a group stored by the fake Keycloak server
+type fakeGroup struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Path      string       `json:"path"`
+	SubGroups []*fakeGroup `json:"subGroups"`
+	roles     []string
+}
+
+// fakeUser is a user stored by the fake Keycloak server
+type fakeUser struct {
+	ID         string              `json:"id"`
+	Username   string              `json:"username"`
+	Enabled    bool                `json:"enabled"`
+	FirstName  string              `json:"firstName,omitempty"`
+	LastName   string              `json:"lastName,omitempty"`
+	Email      string              `json:"email,omitempty"`
+	Attributes map[string][]string `json:"attributes,omitempty"`
+	groups     []string
+	password   string
+}
+
+// fakeClient is a client stored by the fake Keycloak server
+type fakeClient struct {
+	id     string
+	rep    map[string]interface{}
+	secret string
+}
+
+// fakeRealm is a realm stored by the fake Keycloak server
+type fakeRealm struct {
+	rep     map[string]interface{}
+	groups  []*fakeGroup
+	roles   []roleRepresentation
+	users   []*fakeUser
+	clients []*fakeClient
+}
+
+// fakeKeycloak is an in memory implementation of the parts of the Keycloak Admin REST API used by Verrazzano
+type fakeKeycloak struct {
+	sync.Mutex
+	server   *httptest.Server
+	password string
+	realms   map[string]*fakeRealm
+	tokens   map[string]bool
+	logins   int
+	nextID   int
+	requests []string
+	// fail returns the status code to respond with instead of handling the request, zero handles the request
+	fail func(r *http.Request) int
+}
+
+// newFakeKeycloak starts a fake Keycloak server with the master realm and sets it as the Admin REST API endpoint
+func newFakeKeycloak(t *testing.T) *fakeKeycloak {
+	kc := &fakeKeycloak{
+		password: testAdminPassword,
+		realms:   map[string]*fakeRealm{"master": {rep: map[string]interface{}{"realm": "master", "enabled": true}}},
+		tokens:   map[string]bool{},
+	}
+	kc.server = httptest.NewServer(http.HandlerFunc(kc.handle))
+	SetAdminEndpointFunc(func(_ spi.ComponentContext) (*AdminEndpoint, error) {
+		return &AdminEndpoint{URL: kc.server.URL, Client: kc.server.Client()}, nil
+	})
+	t.Cleanup(func() {
+		kc.server.Close()
+		SetDefaultAdminEndpointFunc()
+	})
+	return kc
+}
+
+// failOn makes the fake server respond with the status code to requests with the method and a path containing pathPart
+func (kc *fakeKeycloak) failOn(method string, pathPart string, statusCode int) {
+	kc.fail = func(r *http.Request) int {
+		if r.Method == method && strings.Contains(r.URL.Path, pathPart) {
+			return statusCode
+		}
+		return 0
+	}
+}
+
+func (kc *fakeKeycloak) id() string {
+	kc.nextID++
+	return fmt.Sprintf("id-%d", kc.nextID)
+}
+
+func (kc *fakeKeycloak) handle(w http.ResponseWriter, r *http.Request) {
+	kc.Lock()
+	defer kc.Unlock()
+	kc.requests = append(kc.requests, r.Method+" "+r.URL.Path)
+	if kc.fail != nil {
+		if code := kc.fail(r); code != 0 {
+			w.WriteHeader(code)
+			fmt.Fprintf(w, "failed")
+			return
+		}
+	}
+	if r.URL.Path == keycloakTokenPath {
+		kc.handleToken(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, keycloakAdminPath) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !kc.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, keycloakAdminPath), "/"), "/")
+	if segments[0] == "" {
+		kc.handleRealms(w, r)
+		return
+	}
+	realm := kc.realms[segments[0]]
+	if realm == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if len(segments) == 1 {
+		kc.handleRealm(w, r, realm)
+		return
+	}
+	switch segments[1] {
+	case "groups":
+		kc.handleGroups(w, r, segments[0], realm, segments[2:])
+	case "roles":
+		kc.handleRoles(w, r, realm, segments[2:])
+	case "users":
+		kc.handleUsers(w, r, segments[0], realm, segments[2:])
+	case "clients":
+		kc.handleClients(w, r, segments[0], realm, segments[2:])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (kc *fakeKeycloak) handleToken(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	if r.Form.Get("username") != keycloakAdminUser || r.Form.Get("password") != kc.password || r.Form.Get("client_id") != keycloakAdminClientID {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"error":"invalid_grant","error_description":"Invalid user credentials"}`)
+		return
+	}
+	kc.logins++
+	token := fmt.Sprintf("token-%d", kc.logins)
+	kc.tokens[token] = true
+	writeJSON(w, http.StatusOK, tokenResponse{AccessToken: token, ExpiresIn: 60})
+}
+
+func (kc *fakeKeycloak) handleRealms(w http.ResponseWriter, r *http.Request) {
+	rep := map[string]interface{}{}
+	if r.Method != http.MethodPost || !readJSON(w, r, &rep) {
+		return
+	}
+	name, _ := rep["realm"].(string)
+	if kc.realms[name] != nil {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	kc.realms[name] = &fakeRealm{rep: rep}
+	w.Header().Set("Location", kc.server.URL+keycloakAdminPath+"/"+name)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (kc *fakeKeycloak) handleRealm(w http.ResponseWriter, r *http.Request, realm *fakeRealm) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, realm.rep)
+	case http.MethodPut:
+		rep := map[string]interface{}{}
+		if !readJSON(w, r, &rep) {
+			return
+		}
+		for key, val := range rep {
+			realm.rep[key] = val
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (kc *fakeKeycloak) handleGroups(w http.ResponseWriter, r *http.Request, realmName string, realm *fakeRealm, segments []string) {
+	if len(segments) == 0 {
+		if r.Method == http.MethodGet {
+			writeJSON(w, http.StatusOK, realm.groups)
+			return
+		}
+		kc.createGroup(w, r, realmName, realm, nil)
+		return
+	}
+	group := realm.findGroup(segments[0])
+	if group == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(segments) == 2 && segments[1] == "children" && r.Method == http.MethodPost:
+		kc.createGroup(w, r, realmName, realm, group)
+	case len(segments) == 3 && segments[1] == "role-mappings" && r.Method == http.MethodGet:
+		roles := []roleRepresentation{}
+		for _, role := range realm.roles {
+			if contains(group.roles, role.Name) {
+				roles = append(roles, role)
+			}
+		}
+		writeJSON(w, http.StatusOK, roles)
+	case len(segments) == 3 && segments[1] == "role-mappings" && r.Method == http.MethodPost:
+		var roles []roleRepresentation
+		if !readJSON(w, r, &roles) {
+			return
+		}
+		for _, role := range roles {
+			if !realm.hasRole(role.Name) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if !contains(group.roles, role.Name) {
+				group.roles = append(group.roles, role.Name)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (kc *fakeKeycloak) createGroup(w http.ResponseWriter, r *http.Request, realmName string, realm *fakeRealm, parent *fakeGroup) {
+	rep := groupRepresentation{}
+	if r.Method != http.MethodPost || !readJSON(w, r, &rep) {
+		return
+	}
+	siblings := &realm.groups
+	path := "/" + rep.Name
+	if parent != nil {
+		siblings = &parent.SubGroups
+		path = parent.Path + path
+	}
+	for _, g := range *siblings {
+		if g.Name == rep.Name {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	}
+	group := &fakeGroup{ID: kc.id(), Name: rep.Name, Path: path, SubGroups: []*fakeGroup{}}
+	*siblings = append(*siblings, group)
+	w.Header().Set("Location", fmt.Sprintf("%s%s/%s/groups/%s", kc.server.URL, keycloakAdminPath, realmName, group.ID))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (kc *fakeKeycloak) handleRoles(w http.ResponseWriter, r *http.Request, realm *fakeRealm, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, realm.roles)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		rep := roleRepresentation{}
+		if !readJSON(w, r, &rep) {
+			return
+		}
+		if realm.hasRole(rep.Name) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		realm.roles = append(realm.roles, roleRepresentation{ID: kc.id(), Name: rep.Name})
+		w.WriteHeader(http.StatusCreated)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		for _, role := range realm.roles {
+			if role.Name == segments[0] {
+				writeJSON(w, http.StatusOK, role)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (kc *fakeKeycloak) handleUsers(w http.ResponseWriter, r *http.Request, realmName string, realm *fakeRealm, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		users := []*fakeUser{}
+		for _, user := range realm.users {
+			if strings.Contains(user.Username, r.URL.Query().Get("username")) {
+				users = append(users, user)
+			}
+		}
+		writeJSON(w, http.StatusOK, users)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		rep := userRepresentation{}
+		if !readJSON(w, r, &rep) {
+			return
+		}
+		for _, user := range realm.users {
+			if user.Username == rep.Username {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		for _, path := range rep.Groups {
+			if realm.findGroupByPath(path) == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+		user := &fakeUser{ID: kc.id(), Username: rep.Username, Enabled: rep.Enabled, FirstName: rep.FirstName, LastName: rep.LastName,
+			Email: rep.Email, Attributes: rep.Attributes, groups: rep.Groups}
+		for _, cred := range rep.Credentials {
+			user.password = cred.Value
+		}
+		realm.users = append(realm.users, user)
+		w.Header().Set("Location", fmt.Sprintf("%s%s/%s/users/%s", kc.server.URL, keycloakAdminPath, realmName, user.ID))
+		w.WriteHeader(http.StatusCreated)
+	case len(segments) > 0:
+		kc.handleUser(w, r, realm, segments)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (kc *fakeKeycloak) handleUser(w http.ResponseWriter, r *http.Request, realm *fakeRealm, segments []string) {
+	var user *fakeUser
+	for _, u := range realm.users {
+		if u.ID == segments[0] {
+			user = u
+		}
+	}
+	if user == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		rep := userRepresentation{}
+		if !readJSON(w, r, &rep) {
+			return
+		}
+		user.Enabled = rep.Enabled
+		if rep.FirstName != "" {
+			user.FirstName = rep.FirstName
+		}
+		if rep.LastName != "" {
+			user.LastName = rep.LastName
+		}
+		if rep.Email != "" {
+			user.Email = rep.Email
+		}
+		if rep.Attributes != nil {
+			user.Attributes = rep.Attributes
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 2 && segments[1] == "reset-password" && r.Method == http.MethodPut:
+		cred := credentialRepresentation{}
+		if !readJSON(w, r, &cred) {
+			return
+		}
+		user.password = cred.Value
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 2 && segments[1] == "groups" && r.Method == http.MethodGet:
+		groups := []groupRepresentation{}
+		for _, path := range user.groups {
+			if g := realm.findGroupByPath(path); g != nil {
+				groups = append(groups, groupRepresentation{ID: g.ID, Name: g.Name, Path: g.Path})
+			}
+		}
+		writeJSON(w, http.StatusOK, groups)
+	case len(segments) == 3 && segments[1] == "groups" && r.Method == http.MethodPut:
+		g := realm.findGroup(segments[2])
+		if g == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !contains(user.groups, g.Path) {
+			user.groups = append(user.groups, g.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (kc *fakeKeycloak) handleClients(w http.ResponseWriter, r *http.Request, realmName string, realm *fakeRealm, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			clients := []map[string]interface{}{}
+			for _, c := range realm.clients {
+				clients = append(clients, map[string]interface{}{"id": c.id, "clientId": c.rep["clientId"]})
+			}
+			writeJSON(w, http.StatusOK, clients)
+		case http.MethodPost:
+			rep := map[string]interface{}{}
+			if !readJSON(w, r, &rep) {
+				return
+			}
+			if realm.findClientByClientID(rep["clientId"]) != nil {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			c := &fakeClient{id: kc.id(), rep: rep}
+			c.secret, _ = rep["secret"].(string)
+			realm.clients = append(realm.clients, c)
+			w.Header().Set("Location", fmt.Sprintf("%s%s/%s/clients/%s", kc.server.URL, keycloakAdminPath, realmName, c.id))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	c := realm.findClient(segments[0])
+	if c == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		rep := map[string]interface{}{"id": c.id}
+		for key, val := range c.rep {
+			rep[key] = val
+		}
+		writeJSON(w, http.StatusOK, rep)
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		rep := map[string]interface{}{}
+		if !readJSON(w, r, &rep) {
+			return
+		}
+		for key, val := range rep {
+			c.rep[key] = val
+		}
+		if secret, ok := rep["secret"].(string); ok {
+			c.secret = secret
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 2 && segments[1] == "client-secret" && r.Method == http.MethodPost:
+		c.secret = "secret-" + kc.id()
+		writeJSON(w, http.StatusOK, KeycloakClientSecret{Type: "secret", Value: c.secret})
+	case len(segments) == 2 && segments[1] == "client-secret" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, KeycloakClientSecret{Type: "secret", Value: c.secret})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (realm *fakeRealm) findGroup(id string) *fakeGroup {
+	for _, g := range realm.groups {
+		if g.ID == id {
+			return g
+		}
+		for _, sub := range g.SubGroups {
+			if sub.ID == id {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+func (realm *fakeRealm) findGroupByPath(path string) *fakeGroup {
+	for _, g := range realm.groups {
+		if g.Path == path {
+			return g
+		}
+		for _, sub := range g.SubGroups {
+			if sub.Path == path {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+func (realm *fakeRealm) hasRole(name string) bool {
+	for _, role := range realm.roles {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (realm *fakeRealm) findUser(username string) *fakeUser {
+	for _, user := range realm.users {
+		if user.Username == username {
+			return user
+		}
+	}
+	return nil
+}
+
+func (realm *fakeRealm) findClient(id string) *fakeClient {
+	for _, c := range realm.clients {
+		if c.id == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (realm *fakeRealm) findClientByClientID(clientID interface{}) *fakeClient {
+	for _, c := range realm.clients {
+		if c.rep["clientId"] == clientID {
+			return c
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, in interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(in)
+}
+
+// newTestAdminClient returns an admin client for the fake Keycloak server
+func newTestAdminClient(t *testing.T) *adminClient {
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret()).Build()
+	client, err := newAdminClient(spi.NewFakeContext(c, testVZ, nil, false))
+	assert.NoError(t, err)
+	return client
+}
+
+// TestAdminClientLogin tests logging into Keycloak
+// GIVEN the Keycloak admin credentials
+// WHEN Admin REST API requests are made
+// THEN a token is requested once and reused for the requests
+func TestAdminClientLogin(t *testing.T) {
+	kc := newFakeKeycloak(t)
+	client := newTestAdminClient(t)
+
+	for i := 0; i < 3; i++ {
+		exists, err := client.realmExists("master")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	}
+	assert.Equal(t, 1, kc.logins)
+}
+
+// TestAdminClientLoginRevokedToken tests logging into Keycloak after the token is revoked
+// GIVEN an admin client whose token is no longer valid
+// WHEN an Admin REST API request is made
+// THEN the client logs in again and retries the request
+func TestAdminClientLoginRevokedToken(t *testing.T) {
+	kc := newFakeKeycloak(t)
+	client := newTestAdminClient(t)
+
+	assert.NoError(t, client.login())
+	kc.tokens = map[string]bool{}
+	exists, err := client.realmExists("master")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, 2, kc.logins)
+}
+
+// TestAdminClientLoginInvalidCredentials tests logging into Keycloak with the wrong password
+// GIVEN an admin password that Keycloak does not accept
+// WHEN an Admin REST API request is made
+// THEN an unauthorized error is returned that does not contain the password
+func TestAdminClientLoginInvalidCredentials(t *testing.T) {
+	kc := newFakeKeycloak(t)
+	kc.password = "other"
+	client := newTestAdminClient(t)
+
+	_, err := client.getGroups(vzSysRealm)
+	assert.Error(t, err)
+	assert.True(t, isStatus(err, http.StatusUnauthorized))
+	assert.NotContains(t, err.Error(), testAdminPassword)
+}
+
+// TestAdminClientNotFound tests reading a realm that does not exist
+// GIVEN a realm that does not exist
+// WHEN realmExists is called
+// THEN false is returned without an error
+func TestAdminClientNotFound(t *testing.T) {
+	newFakeKeycloak(t)
+	client := newTestAdminClient(t)
+
+	exists, err := client.realmExists(vzSysRealm)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+// TestAdminClientError tests an Admin REST API request that fails
+// GIVEN Keycloak responds with a server error
+// WHEN a request is made
+// THEN an error is returned with the request and response code
+func TestAdminClientError(t *testing.T) {
+	kc := newFakeKeycloak(t)
+	kc.failOn(http.MethodGet, "/clients", http.StatusInternalServerError)
+	client := newTestAdminClient(t)
+
+	_, err := client.getClients(vzSysRealm)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Keycloak request GET /auth/admin/realms/verrazzano-system/clients failed with response code 500")
+}
+
+// TestGetIngressAdminEndpoint tests getting the Admin REST API endpoint
+// GIVEN a Verrazzano install with the Keycloak certificate
+// WHEN getIngressAdminEndpoint is called
+// THEN an endpoint is returned that reaches Keycloak over TLS through the ingress controller using the Keycloak host name
+func TestGetIngressAdminEndpoint(t *testing.T) {
+	tlsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: keycloakCertificateName, Namespace: ComponentNamespace},
+		Data:       map[string][]byte{"ca.crt": []byte("not a certificate")},
+	}
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestNginxService(), tlsSecret).Build()
+	vz := testVZ.DeepCopy()
+	vz.Spec.EnvironmentName = "test-env"
+	endpoint, err := getIngressAdminEndpoint(spi.NewFakeContext(c, vz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://ingress-controller-ingress-nginx-controller.ingress-nginx", endpoint.URL)
+	assert.Equal(t, testKeycloakIngressHost, endpoint.Host)
+	assert.NotNil(t, endpoint.Client)
+
+	// The ingress controller service is needed to build the Keycloak host name
+	c = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	_, err = getIngressAdminEndpoint(spi.NewFakeContext(c, vz, nil, false))
+	assert.Error(t, err)
+}
@@ -9,12 +9,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"text/template"
 
 	"github.com/verrazzano/verrazzano/pkg/bom"
-	"github.com/verrazzano/verrazzano/pkg/k8sutil"
 	vzpassword "github.com/verrazzano/verrazzano/pkg/security/password"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
@@ -31,8 +31,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/kubernetes"
-	restclient "k8s.io/client-go/rest"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -54,6 +52,7 @@ const (
 	vzInternalPromUser      = "verrazzano-prom-internal"
 	vzInternalEsUser        = "verrazzano-es-internal"
 	keycloakPodName         = "keycloak-0"
+	passwordPolicy          = "length(8) and notUsername"
 )
 
 // Define the Keycloak Key:Value pair for init container.
@@ -469,9 +468,6 @@ type imageData struct {
 	Image string
 }
 
-// maskPw will mask passwords in strings with '******'
-var maskPw = vzpassword.MaskFunction("password ")
-
 // AppendKeycloakOverrides appends the Keycloak theme for the Key keycloak.extraInitContainers.
 // A go template is used to replace the image in the init container spec.
 func AppendKeycloakOverrides(compContext spi.ComponentContext, _ string, _ string, _ string, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
@@ -583,21 +579,18 @@ func updateKeycloakIngress(ctx spi.ComponentContext) error {
 	return err
 }
 
-// updateKeycloakUris invokes kcadm.sh in Keycloak pod to update the client with Keycloak rewrite and weborigin uris
-func updateKeycloakUris(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, kcPod *v1.Pod, clientID string, uriTemplate string) error {
+// updateKeycloakUris updates the client with the Keycloak redirect and web origin uris
+func updateKeycloakUris(ctx spi.ComponentContext, kc *adminClient, clientID string, uriTemplate string) error {
 	data, err := populateSubdomainInTemplate(ctx, "{"+uriTemplate+"}")
 	if err != nil {
 		return err
 	}
 
 	// Update client
-	updateClientCmd := "/opt/jboss/keycloak/bin/kcadm.sh update clients/" + clientID + " -r " + vzSysRealm + " -b '" +
-		strings.TrimSpace(data) +
-		"'"
-	ctx.Log().Debugf("updateKeycloakUris: Update client with Id = %s, Cmd = %s", clientID, updateClientCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(updateClientCmd))
+	ctx.Log().Debugf("updateKeycloakUris: Update client with Id = %s", clientID)
+	err = kc.updateClient(vzSysRealm, clientID, json.RawMessage(data))
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed updating client with Id = %s stdout = %s, stderr = %s", clientID, stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed updating client with Id = %s: %v", clientID, err)
 		return err
 	}
 
@@ -619,41 +612,37 @@ func configureKeycloakRealms(ctx spi.ComponentContext) error {
 		return fmt.Errorf("Waiting for pod %s to be ready", pod.Name)
 	}
 
-	cfg, cli, err := k8sutil.ClientConfig()
+	kc, err := newAdminClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	// If ephemeral storage is configured, additional steps may be required to
-	// rebuild the configuration lost due to MySQL pod getting restarted.
-	if (ctx.EffectiveCR().Spec.Components.Keycloak != nil) && (ctx.EffectiveCR().Spec.Components.Keycloak.MySQL.VolumeSource == nil) {
-		// When the MySQL pod restarts and using ephemeral storage, the
-		// login to Keycloak will fail.  Need to recycle the Keycloak pod
-		// to resolve the condition.
-		err = loginKeycloak(ctx, cfg, cli)
-		if err != nil {
+	// Login to Keycloak
+	err = loginKeycloak(ctx, kc)
+	if err != nil {
+		// If ephemeral storage is configured, additional steps may be required to
+		// rebuild the configuration lost due to MySQL pod getting restarted.
+		// When the MySQL pod restarts, the Keycloak admin user no longer exists and
+		// the login will fail.  Need to recycle the Keycloak pod to resolve the condition.
+		// Only a rejected login recycles the pod, other failures such as Keycloak not being
+		// reachable yet are retried on the next reconcile.
+		if isStatus(err, http.StatusUnauthorized) && (ctx.EffectiveCR().Spec.Components.Keycloak != nil) && (ctx.EffectiveCR().Spec.Components.Keycloak.MySQL.VolumeSource == nil) {
 			err2 := ctx.Client().Delete(context.TODO(), pod)
 			if err2 != nil {
 				ctx.Log().Errorf("Component Keycloak failed to recycle pod %s: %v", pod.Name, err2)
 			}
-			return err
 		}
-	}
-
-	// Login to Keycloak
-	err = loginKeycloak(ctx, cfg, cli)
-	if err != nil {
 		return err
 	}
 
 	// Create VerrazzanoSystem Realm
-	err = createVerrazzanoSystemRealm(ctx, cfg, cli)
+	err = createVerrazzanoSystemRealm(ctx, kc)
 	if err != nil {
 		return err
 	}
 
 	// Create Verrazzano Users Group
-	userGroupID, err := createVerrazzanoGroup(ctx, cfg, cli, vzUsersGroup, "")
+	userGroupID, err := createVerrazzanoGroup(ctx, kc, vzUsersGroup, "")
 	if err != nil {
 		return err
 	}
@@ -664,7 +653,7 @@ func configureKeycloakRealms(ctx spi.ComponentContext) error {
 	}
 
 	// Create Verrazzano Admin Group
-	adminGroupID, err := createVerrazzanoGroup(ctx, cfg, cli, vzAdminGroup, userGroupID)
+	adminGroupID, err := createVerrazzanoGroup(ctx, kc, vzAdminGroup, userGroupID)
 	if err != nil {
 		return err
 	}
@@ -675,7 +664,7 @@ func configureKeycloakRealms(ctx spi.ComponentContext) error {
 	}
 
 	// Create Verrazzano Project Monitors Group
-	monitorGroupID, err := createVerrazzanoGroup(ctx, cfg, cli, vzMonitorGroup, userGroupID)
+	monitorGroupID, err := createVerrazzanoGroup(ctx, kc, vzMonitorGroup, userGroupID)
 	if err != nil {
 		return err
 	}
@@ -686,62 +675,62 @@ func configureKeycloakRealms(ctx spi.ComponentContext) error {
 	}
 
 	// Create Verrazzano System Group
-	_, err = createVerrazzanoGroup(ctx, cfg, cli, vzSystemGroup, userGroupID)
+	_, err = createVerrazzanoGroup(ctx, kc, vzSystemGroup, userGroupID)
 	if err != nil {
 		return err
 	}
 
 	// Create Verrazzano API Access Role
-	err = createVerrazzanoRole(ctx, cfg, cli, vzAPIAccessRole)
+	err = createVerrazzanoRole(ctx, kc, vzAPIAccessRole)
 	if err != nil {
 		return err
 	}
 
 	// Granting Roles to Groups
-	err = grantRolesToGroups(ctx, cfg, cli, userGroupID, adminGroupID, monitorGroupID)
+	err = grantRolesToGroups(ctx, kc, userGroupID)
 	if err != nil {
 		return err
 	}
 
 	// Creating Verrazzano User
-	err = createUser(ctx, cfg, cli, vzUserName, "verrazzano", vzAdminGroup, "Verrazzano", "Admin")
+	err = createUser(ctx, kc, vzUserName, "verrazzano", vzAdminGroup, "Verrazzano", "Admin")
 	if err != nil {
 		return err
 	}
 
 	// Creating Verrazzano Internal Prometheus User
-	err = createUser(ctx, cfg, cli, vzInternalPromUser, "verrazzano-prom-internal", vzSystemGroup, "", "")
+	err = createUser(ctx, kc, vzInternalPromUser, "verrazzano-prom-internal", vzSystemGroup, "", "")
 	if err != nil {
 		return err
 	}
 
 	// Creating Verrazzano Internal ES User
-	err = createUser(ctx, cfg, cli, vzInternalEsUser, "verrazzano-es-internal", vzSystemGroup, "", "")
+	err = createUser(ctx, kc, vzInternalEsUser, "verrazzano-es-internal", vzSystemGroup, "", "")
 	if err != nil {
 		return err
 	}
 
 	// Create verrazzano-pkce client
-	err = createOrUpdateClient(ctx, cfg, cli, "verrazzano-pkce", pkceTmpl, pkceClientUrisTemplate, false)
+	err = createOrUpdateClient(ctx, kc, "verrazzano-pkce", pkceTmpl, pkceClientUrisTemplate, false)
 	if err != nil {
 		return err
 	}
 
 	// Creating verrazzano-pg client
-	err = createOrUpdateClient(ctx, cfg, cli, "verrazzano-pg", pgClient, "", true)
+	err = createOrUpdateClient(ctx, kc, "verrazzano-pg", pgClient, "", true)
 	if err != nil {
 		return err
 	}
 
 	if vzconfig.IsRancherEnabled(ctx.ActualCR()) {
 		// Creating rancher client
-		err = createOrUpdateClient(ctx, cfg, cli, "rancher", rancherClientTmpl, rancherClientUrisTemplate, true)
+		err = createOrUpdateClient(ctx, kc, "rancher", rancherClientTmpl, rancherClientUrisTemplate, true)
 		if err != nil {
 			return err
 		}
 
 		// Update Keycloak AuthConfig for Rancher with client secret
-		err = updateRancherClientSecretForKeycloakAuthConfig(ctx)
+		err = updateRancherClientSecretForKeycloakAuthConfig(ctx, kc)
 		if err != nil {
 			return err
 		}
@@ -749,37 +738,31 @@ func configureKeycloakRealms(ctx spi.ComponentContext) error {
 	}
 
 	// Setting password policy for master
-	err = setPasswordPolicyForRealm(ctx, cfg, cli, "master", "passwordPolicy=length(8) and notUsername")
+	err = setPasswordPolicyForRealm(ctx, kc, "master", passwordPolicy)
 	if err != nil {
 		return err
 	}
 
 	// Setting password policy for Verrazzano realm
-	err = setPasswordPolicyForRealm(ctx, cfg, cli, "verrazzano-system", "passwordPolicy=length(8) and notUsername")
+	err = setPasswordPolicyForRealm(ctx, kc, vzSysRealm, passwordPolicy)
 	if err != nil {
 		return err
 	}
 
 	// Configuring login theme for master
-	err = configureLoginThemeForRealm(ctx, cfg, cli, "master", "oracle")
+	err = configureLoginThemeForRealm(ctx, kc, "master", "oracle")
 	if err != nil {
 		return err
 	}
 
 	// Configuring login theme for verrazzano-system
-	err = configureLoginThemeForRealm(ctx, cfg, cli, "verrazzano-system", "oracle")
+	err = configureLoginThemeForRealm(ctx, kc, vzSysRealm, "oracle")
 	if err != nil {
 		return err
 	}
 
 	// Enabling vzSysRealm realm
-	err = enableVerrazzanoSystemRealm(ctx, cfg, cli)
-	if err != nil {
-		return err
-	}
-
-	// Removing login config file
-	err = removeLoginConfigFile(ctx, cfg, cli)
+	err = enableVerrazzanoSystemRealm(ctx, kc)
 	if err != nil {
 		return err
 	}
@@ -788,49 +771,17 @@ func configureKeycloakRealms(ctx spi.ComponentContext) error {
 	return nil
 }
 
-// loginKeycloak logs into Keycloak so kcadm API calls can be made
-func loginKeycloak(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface) error {
-	// Get the Keycloak admin password
-	secret := &corev1.Secret{}
-	err := ctx.Client().Get(context.TODO(), client.ObjectKey{
-		Namespace: "keycloak",
-		Name:      "keycloak-http",
-	}, secret)
+// loginKeycloak logs into Keycloak as the admin user so Admin REST API calls can be made
+func loginKeycloak(ctx spi.ComponentContext, kc *adminClient) error {
+	err := kc.login()
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed retrieving Keycloak password: %s", err)
+		ctx.Log().Errorf("Component Keycloak failed logging into Keycloak: %v", err)
 		return err
 	}
-	pw := secret.Data["password"]
-	keycloakpw := string(pw)
-	if keycloakpw == "" {
-		err = errors.New("Component Keycloak failed; Keycloak password is an empty string")
-		ctx.Log().Error(err)
-		return err
-	}
-	ctx.Log().Debug("loginKeycloak: Successfully retrieved Keycloak password")
-
-	// Login to Keycloak
-	kcPod := keycloakPod()
-	loginCmd := "/opt/jboss/keycloak/bin/kcadm.sh config credentials --server http://localhost:8080/auth --realm master --user keycloakadmin --password " + keycloakpw
-	ctx.Log().Debugf("loginKeycloak: Login Cmd = %s", maskPw(loginCmd))
-	stdOut, stdErr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(loginCmd))
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed logging into Keycloak: stdout = %s: stderr = %s", stdOut, stdErr)
-		return fmt.Errorf("error: %s", maskPw(err.Error()))
-	}
 	ctx.Log().Once("Component Keycloak successfully logged into Keycloak")
-
 	return nil
 }
 
-func bashCMD(command string) []string {
-	return []string{
-		"bash",
-		"-c",
-		command,
-	}
-}
-
 func keycloakPod() *v1.Pod {
 	return &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -905,88 +856,79 @@ func getDNSDomain(c client.Client, vz *vzapi.Verrazzano) (string, error) {
 	return dnsDomain, nil
 }
 
-func createVerrazzanoSystemRealm(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface) error {
-	kcPod := keycloakPod()
-	realm := "realm=" + vzSysRealm
-	checkRealmExistsCmd := "/opt/jboss/keycloak/bin/kcadm.sh get realms/" + vzSysRealm
-	ctx.Log().Debugf("createVerrazzanoSystemRealm: Check Verrazzano System Realm Exists Cmd = %s", checkRealmExistsCmd)
-	_, _, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(checkRealmExistsCmd))
+func createVerrazzanoSystemRealm(ctx spi.ComponentContext, kc *adminClient) error {
+	exists, err := kc.realmExists(vzSysRealm)
 	if err != nil {
-		ctx.Log().Debug("createVerrazzanoSystemRealm: Verrazzano System Realm doesn't exist: Creating it")
-		createRealmCmd := "/opt/jboss/keycloak/bin/kcadm.sh create realms -s " + realm + " -s enabled=false"
-		ctx.Log().Debugf("createVerrazzanoSystemRealm: Create Verrazzano System Realm Cmd = %s", createRealmCmd)
-		stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(createRealmCmd))
-		if err != nil {
-			ctx.Log().Errorf("Component Keycloak failed creating Verrazzano System Realm: stdout = %s, stderr = %s", stdout, stderr)
-			return err
-		}
-		ctx.Log().Once("Component Keycloak successfully created the Verrazzano system realm")
+		ctx.Log().Errorf("Component Keycloak failed checking if the Verrazzano System Realm exists: %v", err)
+		return err
+	}
+	if exists {
+		return nil
 	}
+	ctx.Log().Debug("createVerrazzanoSystemRealm: Verrazzano System Realm doesn't exist: Creating it")
+	enabled := false
+	err = kc.createRealm(realmRepresentation{Realm: vzSysRealm, Enabled: &enabled})
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed creating Verrazzano System Realm: %v", err)
+		return err
+	}
+	ctx.Log().Once("Component Keycloak successfully created the Verrazzano system realm")
 	return nil
 }
 
-func createVerrazzanoGroup(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, group string, parentID string) (string, error) {
-	kcPod := keycloakPod()
-	keycloakGroups, err := getKeycloakGroups(ctx, cfg, cli, kcPod)
-	if err == nil && groupExists(keycloakGroups, group) {
+func createVerrazzanoGroup(ctx spi.ComponentContext, kc *adminClient, group string, parentID string) (string, error) {
+	keycloakGroups, err := kc.getGroups(vzSysRealm)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving Groups: %v", err)
+		return "", err
+	}
+	if groupExists(keycloakGroups, group) {
 		// Group already exists
 		return getGroupID(keycloakGroups, group), nil
 	}
-	groupsResource := "groups"
-	groupName := "name=" + group
-	if parentID != "" {
-		groupsResource = fmt.Sprintf("groups/%s/children", parentID)
-	}
 
-	cmd := fmt.Sprintf("/opt/jboss/keycloak/bin/kcadm.sh create %s -r %s -s %s", groupsResource, vzSysRealm, groupName)
-	ctx.Log().Debugf("createVerrazzanoGroup: Create Verrazzano %s Group Cmd = %s", group, cmd)
-	out, _, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
+	ctx.Log().Debugf("createVerrazzanoGroup: Create Verrazzano %s Group", group)
+	groupID, err := kc.createGroup(vzSysRealm, group, parentID)
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed creating Verrazzano %s Group: command output = %s", group, out)
+		ctx.Log().Errorf("Component Keycloak failed creating Verrazzano %s Group: %v", group, err)
 		return "", err
 	}
-	ctx.Log().Debugf("createVerrazzanoGroup: Create Verrazzano %s Group Output = %s", group, out)
-	if len(out) == 0 {
-		err = fmt.Errorf("Component Keycloak failed; %s group ID from Keycloak is zero length", group)
-		ctx.Log().Error(err)
-		return "", err
-	}
-	arr := strings.Split(string(out), "'")
-	if len(arr) != 3 {
-		return "", fmt.Errorf("Component Keycloak failed parsing output returned from %s Group create stdout returned = %s", group, out)
-	}
-	ctx.Log().Debugf("createVerrazzanoGroup: %s Group ID = %s", group, arr[1])
+	ctx.Log().Debugf("createVerrazzanoGroup: %s Group ID = %s", group, groupID)
 	ctx.Log().Oncef("Component Keycloak successfully created the Verrazzano %s group", group)
-	return arr[1], nil
+	return groupID, nil
 }
 
-func createVerrazzanoRole(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, roleName string) error {
-	kcPod := keycloakPod()
-	keycloakRoles, err := getKeycloakRoles(ctx, cfg, cli, kcPod)
-	if err == nil && roleExists(keycloakRoles, roleName) {
+func createVerrazzanoRole(ctx spi.ComponentContext, kc *adminClient, roleName string) error {
+	keycloakRoles, err := kc.getRoles(vzSysRealm)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving Roles: %v", err)
+		return err
+	}
+	if roleExists(keycloakRoles, roleName) {
 		return nil
 	}
-	role := "name=" + roleName
-	createRoleCmd := "/opt/jboss/keycloak/bin/kcadm.sh create roles -r " + vzSysRealm + " -s " + role
-	ctx.Log().Debugf("createVerrazzanoRole: Create Verrazzano API Access Role Cmd = %s", createRoleCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(createRoleCmd))
+	ctx.Log().Debugf("createVerrazzanoRole: Create Verrazzano %s Role", roleName)
+	err = kc.createRole(vzSysRealm, roleName)
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed creating Verrazzano API Access Role: stdout = %s, stderr = %s", stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed creating Verrazzano API Access Role: %v", err)
 		return err
 	}
 	ctx.Log().Once("Component Keycloak successfully created the Verrazzano API access role")
 	return nil
 }
 
-func grantRolesToGroups(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, userGroupID string, adminGroupID string, monitorGroupID string) error {
-	// Keycloak API does not fail if Role already exists as of 15.0.3
-	kcPod := keycloakPod()
+func grantRolesToGroups(ctx spi.ComponentContext, kc *adminClient, userGroupID string) error {
+	// Keycloak API does not fail if the role is already mapped to the group
+	role, err := kc.getRole(vzSysRealm, vzAPIAccessRole)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving the %s role: %v", vzAPIAccessRole, err)
+		return err
+	}
 	// Granting vz_api_access role to verrazzano users group
-	grantAPIAccessToVzUserGroupCmd := "/opt/jboss/keycloak/bin/kcadm.sh add-roles -r " + vzSysRealm + " --gid " + userGroupID + " --rolename " + vzAPIAccessRole
-	ctx.Log().Debugf("grantRolesToGroups: Grant API Access to VZ Users Cmd = %s", grantAPIAccessToVzUserGroupCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(grantAPIAccessToVzUserGroupCmd))
+	ctx.Log().Debugf("grantRolesToGroups: Grant API Access to VZ Users Group %s", userGroupID)
+	err = kc.addRealmRolesToGroup(vzSysRealm, userGroupID, []roleRepresentation{*role})
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed granting api access role to Verrazzano users group: stdout = %s, stderr = %s", stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed granting api access role to Verrazzano users group: %v", err)
 		return err
 	}
 	ctx.Log().Once("Component Keycloak successfully granted the access role to the Verrazzano user group")
@@ -994,57 +936,57 @@ func grantRolesToGroups(ctx spi.ComponentContext, cfg *restclient.Config, cli ku
 	return nil
 }
 
-func createUser(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, userName string, secretName string, groupName string, firstName string, lastName string) error {
-	kcPod := keycloakPod()
-	keycloakUsers, err := getKeycloakUsers(ctx, cfg, cli, kcPod)
-	if err == nil && userExists(keycloakUsers, userName) {
-		return nil
+func createUser(ctx spi.ComponentContext, kc *adminClient, userName string, secretName string, groupName string, firstName string, lastName string) error {
+	keycloakUsers, err := kc.getUsers(vzSysRealm, userName)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving Users: %v", err)
+		return err
 	}
-	vzUser := "username=" + userName
-	vzUserGroup := "groups[0]=/" + vzUsersGroup + "/" + groupName
-	createVzUserCmd := "/opt/jboss/keycloak/bin/kcadm.sh create users -r " + vzSysRealm + " -s " + vzUser + " -s " + vzUserGroup + " -s enabled=true"
-	if firstName != "" {
-		createVzUserCmd = createVzUserCmd + " -s firstName=" + firstName
+	if userExists(keycloakUsers, userName) {
+		return nil
 	}
 
-	if lastName != "" {
-		createVzUserCmd = createVzUserCmd + " -s lastName=" + lastName
+	// Get the password before creating the user, so the user is not left without a password
+	vzpw, err := getSecretPassword(ctx, "verrazzano-system", secretName)
+	if err != nil {
+		return err
 	}
 
-	ctx.Log().Debugf("createUser: Create Verrazzano User Cmd = %s", createVzUserCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(createVzUserCmd))
+	ctx.Log().Debugf("createUser: Create Verrazzano User %s", userName)
+	userID, err := kc.createUser(vzSysRealm, userRepresentation{
+		Username:  userName,
+		Enabled:   true,
+		FirstName: firstName,
+		LastName:  lastName,
+		Groups:    []string{"/" + vzUsersGroup + "/" + groupName},
+	})
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed creating Verrazzano user: stdout = %s, stderr = %s", stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed creating Verrazzano user: %v", err)
 		return err
 	}
 	ctx.Log().Debugf("createUser: Successfully Created VZ User %s", userName)
 
-	vzpw, err := getSecretPassword(ctx, "verrazzano-system", secretName)
+	err = kc.resetPassword(vzSysRealm, userID, vzpw)
 	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed setting Verrazzano user password: %v", err)
 		return err
 	}
-	setVZUserPwCmd := "/opt/jboss/keycloak/bin/kcadm.sh set-password -r " + vzSysRealm + " --username " + userName + " --new-password " + vzpw
-	ctx.Log().Debugf("createUser: Set Verrazzano User PW Cmd = %s", maskPw(setVZUserPwCmd))
-	stdout, stderr, err = k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(setVZUserPwCmd))
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed setting Verrazzano user password: stdout = %s, stderr = %s", stdout, stderr)
-		return fmt.Errorf("error: %s", maskPw(err.Error()))
-	}
 	ctx.Log().Debugf("createUser: Created VZ User %s PW", userName)
 	ctx.Log().Oncef("Component Keycloak successfully created user %s", userName)
 
 	return nil
 }
-func createOrUpdateClient(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, clientName string, clientTemplate string, uriTemplate string, generateSecret bool) error {
-	keycloakClients, err := getKeycloakClients(ctx)
+
+func createOrUpdateClient(ctx spi.ComponentContext, kc *adminClient, clientName string, clientTemplate string, uriTemplate string, generateSecret bool) error {
+	keycloakClients, err := kc.getClients(vzSysRealm)
 	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving clients: %v", err)
 		return err
 	}
 
-	kcPod := keycloakPod()
 	if clientID := getClientID(keycloakClients, clientName); clientID != "" {
 		if uriTemplate != "" {
-			err := updateKeycloakUris(ctx, cfg, cli, kcPod, clientID, uriTemplate)
+			err := updateKeycloakUris(ctx, kc, clientID, uriTemplate)
 			if err != nil {
 				return err
 			}
@@ -1059,19 +1001,15 @@ func createOrUpdateClient(ctx spi.ComponentContext, cfg *restclient.Config, cli
 	}
 
 	// Create client
-	clientCreateCmd := "/opt/jboss/keycloak/bin/kcadm.sh create clients -r " + vzSysRealm + " -f - <<\\END" +
-		data +
-		"END"
-
-	ctx.Log().Debugf("createOrUpdateClient: Create %s client Cmd = %s", clientName, clientCreateCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(clientCreateCmd))
+	ctx.Log().Debugf("createOrUpdateClient: Create %s client", clientName)
+	clientID, err := kc.createClient(vzSysRealm, json.RawMessage(data))
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed creating %s client: stdout = %s, stderr = %s", clientName, stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed creating %s client: %v", clientName, err)
 		return err
 	}
 
 	if generateSecret {
-		err = generateClientSecret(ctx, cfg, cli, clientName, stdout, kcPod)
+		err = generateClientSecret(ctx, kc, clientName, clientID)
 		if err != nil {
 			ctx.Log().Errorf("Component Keycloak failed creating %s client secret: err = %s", clientName, err.Error())
 			return err
@@ -1083,13 +1021,11 @@ func createOrUpdateClient(ctx spi.ComponentContext, cfg *restclient.Config, cli
 	return nil
 }
 
-func setPasswordPolicyForRealm(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, realmName string, policy string) error {
-	kcPod := keycloakPod()
-	setPolicyCmd := "/opt/jboss/keycloak/bin/kcadm.sh update realms/" + realmName + " -s \"" + policy + "\""
-	ctx.Log().Debugf("setPasswordPolicyForRealm: Setting password policy for realm %s Cmd = %s", realmName, setPolicyCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(setPolicyCmd))
+func setPasswordPolicyForRealm(ctx spi.ComponentContext, kc *adminClient, realmName string, policy string) error {
+	ctx.Log().Debugf("setPasswordPolicyForRealm: Setting password policy for realm %s to %s", realmName, policy)
+	err := kc.updateRealm(realmName, realmRepresentation{PasswordPolicy: policy})
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed setting password policy for master: stdout = %s, stderr = %s", stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed setting password policy for realm %s: %v", realmName, err)
 		return err
 	}
 	ctx.Log().Debugf("setPasswordPolicyForRealm: Set password policy for realm %s", realmName)
@@ -1097,27 +1033,24 @@ func setPasswordPolicyForRealm(ctx spi.ComponentContext, cfg *restclient.Config,
 	return nil
 }
 
-func configureLoginThemeForRealm(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, realmName string, loginTheme string) error {
-	kcPod := keycloakPod()
-	setLoginThemeCmd := "/opt/jboss/keycloak/bin/kcadm.sh update realms/" + realmName + " -s loginTheme=" + loginTheme
-	ctx.Log().Debugf("configureLoginThemeForRealm: Configuring login theme Cmd = %s", setLoginThemeCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(setLoginThemeCmd))
+func configureLoginThemeForRealm(ctx spi.ComponentContext, kc *adminClient, realmName string, loginTheme string) error {
+	ctx.Log().Debugf("configureLoginThemeForRealm: Configuring login theme %s for realm %s", loginTheme, realmName)
+	err := kc.updateRealm(realmName, realmRepresentation{LoginTheme: loginTheme})
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed configuring login theme for master: stdout = %s, stderr = %s", stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed configuring login theme for realm %s: %v", realmName, err)
 		return err
 	}
-	ctx.Log().Debug("configureLoginThemeForRealm: Configured login theme for master Cmd")
+	ctx.Log().Debugf("configureLoginThemeForRealm: Configured login theme for realm %s", realmName)
 	ctx.Log().Oncef("Component Keycloak successfully set the login theme for realm %s", realmName)
 	return nil
 }
 
-func enableVerrazzanoSystemRealm(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface) error {
-	kcPod := keycloakPod()
-	setVzEnableRealmCmd := "/opt/jboss/keycloak/bin/kcadm.sh update realms/" + vzSysRealm + " -s enabled=true"
-	ctx.Log().Debugf("enableVerrazzanoSystemRealm: Enabling vzSysRealm realm Cmd = %s", setVzEnableRealmCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(setVzEnableRealmCmd))
+func enableVerrazzanoSystemRealm(ctx spi.ComponentContext, kc *adminClient) error {
+	ctx.Log().Debug("enableVerrazzanoSystemRealm: Enabling vzSysRealm realm")
+	enabled := true
+	err := kc.updateRealm(vzSysRealm, realmRepresentation{Enabled: &enabled})
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed enabling vzSysRealm realm: stdout = %s, stderr = %s", stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed enabling vzSysRealm realm: %v", err)
 		return err
 	}
 	ctx.Log().Debug("enableVerrazzanoSystemRealm: Enabled vzSysRealm realm")
@@ -1126,43 +1059,6 @@ func enableVerrazzanoSystemRealm(ctx spi.ComponentContext, cfg *restclient.Confi
 	return nil
 }
 
-func removeLoginConfigFile(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface) error {
-	kcPod := keycloakPod()
-	removeLoginConfigFileCmd := "rm /root/.keycloak/kcadm.config"
-	ctx.Log().Debugf("removeLoginConfigFile: Removing login config file Cmd = %s", removeLoginConfigFileCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(removeLoginConfigFileCmd))
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed removing login config file: stdout = %s, stderr = %s", stdout, stderr)
-		return err
-	}
-	ctx.Log().Debug("removeLoginConfigFile: Removed login config file")
-	return nil
-}
-
-// getKeycloakGroups returns a structure of Groups in Realm verrazzano-system
-func getKeycloakGroups(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, kcPod *v1.Pod) (KeycloakGroups, error) {
-	var keycloakGroups KeycloakGroups
-	// Get the Client ID JSON array
-	cmd := fmt.Sprintf("/opt/jboss/keycloak/bin/kcadm.sh get groups -r %s", vzSysRealm)
-	out, _, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed retrieving Groups: %s", err)
-		return nil, err
-	}
-	if len(out) == 0 {
-		err = errors.New("Component Keycloak failed; groups JSON from Keycloak is zero length")
-		ctx.Log().Error(err)
-		return nil, err
-	}
-	err = json.Unmarshal([]byte(out), &keycloakGroups)
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed ummarshalling groups json: %v", err)
-		return nil, err
-	}
-
-	return keycloakGroups, nil
-}
-
 func groupExists(keycloakGroups KeycloakGroups, groupName string) bool {
 	for _, keycloakGroup := range keycloakGroups {
 		if keycloakGroup.Name == groupName {
@@ -1191,29 +1087,6 @@ func getGroupID(keycloakGroups KeycloakGroups, groupName string) string {
 	return ""
 }
 
-// getKeycloakRoless returns a structure of Groups in Realm verrazzano-system
-func getKeycloakRoles(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, kcPod *v1.Pod) (KeycloakRoles, error) {
-	var keycloakRoles KeycloakRoles
-	// Get the Client ID JSON array
-	out, _, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD("/opt/jboss/keycloak/bin/kcadm.sh get-roles -r "+vzSysRealm))
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed retrieving Roles: %s", err)
-		return nil, err
-	}
-	if len(out) == 0 {
-		err = errors.New("Component Keycloak failed; roles JSON from Keycloak is zero length")
-		ctx.Log().Error(err)
-		return nil, err
-	}
-	err = json.Unmarshal([]byte(out), &keycloakRoles)
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed ummarshalling groups json: %v", err)
-		return nil, err
-	}
-
-	return keycloakRoles, nil
-}
-
 func roleExists(keycloakRoles KeycloakRoles, roleName string) bool {
 	for _, keycloakRole := range keycloakRoles {
 		if keycloakRole.Name == roleName {
@@ -1223,28 +1096,6 @@ func roleExists(keycloakRoles KeycloakRoles, roleName string) bool {
 	return false
 }
 
-// getKeycloakUsers returns a structure of Users in Realm verrazzano-system
-func getKeycloakUsers(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, kcPod *v1.Pod) ([]KeycloakUser, error) {
-	var keycloakUsers []KeycloakUser
-	// Get the Client ID JSON array
-	out, _, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD("/opt/jboss/keycloak/bin/kcadm.sh get users -r "+vzSysRealm))
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed retrieving Users: %s", err)
-		return nil, err
-	}
-	if len(out) == 0 {
-		err := errors.New("Component Keycloak failed; users JSON from Keycloak is zero length")
-		ctx.Log().Error(err)
-		return nil, err
-	}
-	err = json.Unmarshal([]byte(out), &keycloakUsers)
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed ummarshalling users json: %v", err)
-		return nil, err
-	}
-	return keycloakUsers, nil
-}
-
 func userExists(keycloakUsers []KeycloakUser, userName string) bool {
 	for _, keycloakUser := range keycloakUsers {
 		if keycloakUser.Username == userName {
@@ -1254,32 +1105,6 @@ func userExists(keycloakUsers []KeycloakUser, userName string) bool {
 	return false
 }
 
-// getKeycloakClients returns a structure of Users in Realm verrazzano-system
-func getKeycloakClients(ctx spi.ComponentContext) (KeycloakClients, error) {
-	var keycloakClients KeycloakClients
-	cfg, cli, err := k8sutil.ClientConfig()
-	if err != nil {
-		return nil, err
-	}
-	// Get the Client ID JSON array
-	out, _, err := k8sutil.ExecPod(cli, cfg, keycloakPod(), ComponentName, bashCMD("/opt/jboss/keycloak/bin/kcadm.sh get clients -r "+vzSysRealm+" --fields id,clientId"))
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed retrieving clients: %s", err)
-		return nil, err
-	}
-	if len(out) == 0 {
-		err := errors.New("Component Keycloak failed; clients JSON from Keycloak is zero length")
-		ctx.Log().Error(err)
-		return nil, err
-	}
-	err = json.Unmarshal([]byte(out), &keycloakClients)
-	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed ummarshalling client json: %v", err)
-		return nil, err
-	}
-	return keycloakClients, nil
-}
-
 func getClientID(keycloakClients KeycloakClients, clientName string) string {
 
 	for _, keycloakClient := range keycloakClients {
@@ -1329,6 +1154,21 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []vzapi.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Keycloak != nil {
+			return effectiveCR.Spec.Components.Keycloak.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Keycloak != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.Keycloak.Workload)
+		}
+	}
+	return nil
+}
+
 // upgradeStatefulSet - determine if the replica count for the StatefulSet needs
 // to be scaled down before the upgrade.  The affinity rules installed by default
 // prior to the 1.4 release conflict with the new affinity rules being overridden
@@ -1422,52 +1262,32 @@ func populateSubdomainInTemplate(ctx spi.ComponentContext, tmpl string) (string,
 
 // GetRancherClientSecretFromKeycloak returns the secret from rancher client in Keycloak
 func GetRancherClientSecretFromKeycloak(ctx spi.ComponentContext) (string, error) {
-	cfg, cli, err := k8sutil.ClientConfig()
-	if err != nil {
-		return "", err
-	}
-
-	// Login to Keycloak
-	err = loginKeycloak(ctx, cfg, cli)
+	kc, err := newAdminClient(ctx)
 	if err != nil {
 		return "", err
 	}
+	return getRancherClientSecret(ctx, kc)
+}
 
-	kcClients, err := getKeycloakClients(ctx)
+// getRancherClientSecret returns the secret from rancher client in Keycloak
+func getRancherClientSecret(ctx spi.ComponentContext, kc *adminClient) (string, error) {
+	kcClients, err := kc.getClients(vzSysRealm)
 	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving clients: %v", err)
 		return "", err
 	}
 
-	id := ""
-	for _, kcClient := range kcClients {
-		if kcClient.ClientID == "rancher" {
-			id = kcClient.ID
-		}
-	}
-
+	id := getClientID(kcClients, "rancher")
 	if id == "" {
 		ctx.Log().Debugf("GetRancherClientSecretFromKeycloak: rancher client does not exist")
 		return "", nil
 	}
 
-	var clientSecret KeycloakClientSecret
-	// Get the Client secret JSON array
-	out, _, err := k8sutil.ExecPod(cli, cfg, keycloakPod(), ComponentName, bashCMD("/opt/jboss/keycloak/bin/kcadm.sh get clients/"+id+"/client-secret -r "+vzSysRealm))
+	clientSecret, err := kc.getClientSecret(vzSysRealm, id)
 	if err != nil {
 		ctx.Log().Errorf("failed retrieving rancher client secret from keycloak: %s", err)
 		return "", err
 	}
-	if len(out) == 0 {
-		err = errors.New("client secret json from keycloak is zero length")
-		ctx.Log().Error(err)
-		return "", err
-	}
-
-	err = json.Unmarshal([]byte(out), &clientSecret)
-	if err != nil {
-		ctx.Log().Errorf("failed ummarshalling client secret json: %v", err)
-		return "", err
-	}
 
 	if clientSecret.Value == "" {
 		return "", ctx.Log().ErrorNewErr("client secret is empty")
@@ -1476,27 +1296,13 @@ func GetRancherClientSecretFromKeycloak(ctx spi.ComponentContext) (string, error
 	return clientSecret.Value, nil
 }
 
-func generateClientSecret(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, clientName string, createClientOutput string, kcPod *v1.Pod) error {
-	if len(createClientOutput) == 0 {
-		err := fmt.Errorf("Component Keycloak failed; %s client ID from Keycloak is zero length", clientName)
-		ctx.Log().Error(err)
-		return err
-	}
-
-	arr := strings.Split(string(createClientOutput), "'")
-	if len(arr) != 3 {
-		return fmt.Errorf("Component Keycloak failed parsing output returned from %s Client create stdout returned = %s", clientName, createClientOutput)
-	}
-
-	clientID := arr[1]
+func generateClientSecret(ctx spi.ComponentContext, kc *adminClient, clientName string, clientID string) error {
 	ctx.Log().Debugf("generateClientSecret: %s Client ID = %s", clientName, clientID)
 
 	// Create client secret
-	clientCreateSecretCmd := "/opt/jboss/keycloak/bin/kcadm.sh create clients/" + clientID + "/client-secret" + " -r " + vzSysRealm
-	ctx.Log().Debugf("generateClientSecret: Create %s client secret Cmd = %s", clientName, clientCreateSecretCmd)
-	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(clientCreateSecretCmd))
+	err := kc.generateClientSecret(vzSysRealm, clientID)
 	if err != nil {
-		ctx.Log().Errorf("Component Keycloak failed creating %s client secret: stdout = %s, stderr = %s", clientName, stdout, stderr)
+		ctx.Log().Errorf("Component Keycloak failed creating %s client secret: %v", clientName, err)
 		return err
 	}
 
@@ -1506,26 +1312,21 @@ func generateClientSecret(ctx spi.ComponentContext, cfg *restclient.Config, cli
 
 // GetVerrazzanoUserFromKeycloak returns the user verrazzano in Keycloak
 func GetVerrazzanoUserFromKeycloak(ctx spi.ComponentContext) (*KeycloakUser, error) {
-	cfg, cli, err := k8sutil.ClientConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	// Login to Keycloak
-	err = loginKeycloak(ctx, cfg, cli)
+	kc, err := newAdminClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	kcUsers, err := getKeycloakUsers(ctx, cfg, cli, keycloakPod())
+	kcUsers, err := kc.getUsers(vzSysRealm, vzUserName)
 	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving Users: %v", err)
 		return nil, err
 	}
 
 	var vzUser KeycloakUser
 	found := false
 	for _, user := range kcUsers {
-		if user.Username == "verrazzano" {
+		if user.Username == vzUserName {
 			vzUser = user
 			found = true
 			break
@@ -1539,9 +1340,9 @@ func GetVerrazzanoUserFromKeycloak(ctx spi.ComponentContext) (*KeycloakUser, err
 	return &vzUser, nil
 }
 
-func updateRancherClientSecretForKeycloakAuthConfig(ctx spi.ComponentContext) error {
+func updateRancherClientSecretForKeycloakAuthConfig(ctx spi.ComponentContext, kc *adminClient) error {
 	log := ctx.Log()
-	clientSecret, err := GetRancherClientSecretFromKeycloak(ctx)
+	clientSecret, err := getRancherClientSecret(ctx, kc)
 	if err != nil {
 		return log.ErrorfThrottledNewErr("failed updating client secret in keycloak auth config, unable to fetch rancher client secret: %s", err.Error())
 	}
@@ -51,6 +51,23 @@ var certificates = []types.NamespacedName{
 	{Namespace: ComponentNamespace, Name: keycloakCertificateName},
 }
 
+// networkPolicyRules are the network peers of Keycloak outside of the keycloak namespace, Keycloak is reached through
+// the ingress controller and by the Verrazzano system pods
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app.kubernetes.io/name": "keycloak"},
+		Ingress: []spi.NetworkPeer{
+			{Namespace: nginx.ComponentNamespace, PodLabels: map[string]string{"app.kubernetes.io/instance": "ingress-controller"}, Ports: []int32{8080}},
+			{Namespace: constants.VerrazzanoSystemNamespace, Ports: []int32{8080}},
+		},
+	},
+}
+
+// podDisruptionBudgets are the replicated Keycloak pods
+var podDisruptionBudgets = []spi.PodDisruptionBudget{
+	{Name: ComponentName, PodLabels: map[string]string{"app.kubernetes.io/instance": ComponentName, "app.kubernetes.io/name": ComponentName}},
+}
+
 // NewComponent returns a new Keycloak component
 func NewComponent() spi.Component {
 	return KeycloakComponent{
@@ -59,6 +76,8 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
+			PodDisruptionBudgets:      podDisruptionBudgets,
 			IgnoreNamespaceOverride:   true,
 			ImagePullSecretKeyname:    secret.DefaultImagePullSecretKeyName,
 			ValuesFile:                filepath.Join(config.GetHelmOverridesDir(), "keycloak-values.yaml"),
@@ -74,6 +93,14 @@ func NewComponent() spi.Component {
 				},
 			},
 			GetInstallOverridesFunc: GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				ReplicasKey:          "replicas",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
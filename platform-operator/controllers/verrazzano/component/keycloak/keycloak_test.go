@@ -5,16 +5,13 @@ package keycloak
 
 import (
 	"context"
-	"fmt"
-	"net/url"
+	"net/http"
 	"strings"
 	"testing"
 
 	certmanager "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/pkg/bom"
-	"github.com/verrazzano/verrazzano/pkg/k8sutil"
-	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
@@ -24,12 +21,11 @@ import (
 	v1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/kubernetes"
 	k8scheme "k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -60,11 +56,6 @@ var crEnabled = vzapi.Verrazzano{
 	},
 }
 
-func fakeRESTConfig() (*rest.Config, kubernetes.Interface, error) {
-	cfg, cli := k8sutilfake.NewClientsetConfig()
-	return cfg, cli, nil
-}
-
 func createTestLoginSecret() *v1.Secret {
 	return &v1.Secret{
 		TypeMeta: metav1.TypeMeta{},
@@ -104,272 +95,100 @@ func createTestKeycloakAuthConfig() unstructured.Unstructured {
 	return authConfig
 }
 
-func fakeConfigureRealmCommands(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		command := commands[2]
-		if strings.Contains(command, "create groups") {
-			return "Created new group with id 'quick-brown-fox'", "", nil
-		}
-
-		if strings.Contains(command, "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"jump-window\"}]", "", nil
-		}
-
-		if strings.Contains(command, "create clients/") {
-			return "Created client secret blah", "", nil
-		}
-
-		if strings.Contains(command, "create clients") {
-			return "Created client 'blahblah'", "", nil
-		}
-
-	}
-
-	return "", "", nil
-}
-
-func fakeConfigureRealmCommandsUpdateKeycloakURIFailed(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "create groups") {
-			return "Created new group with id 'quick-brown-fox'", "", nil
-		}
-	}
-
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"},{\"id\" : \"quick-fox-1\",\"clientId\" : \"verrazzano-pg\"}]", "", nil
-		}
-	}
-
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "update clients") {
-			return "", "", fmt.Errorf("failed")
-		}
-	}
-	return "", "", nil
-}
-
-func fakeCreateUserGroupCommand(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "create groups") {
-			return "Created new group with id 'quick-brown-fox'", "", nil
-		}
-	}
-	return "", "", nil
-}
-
-func fakeCreateUserGroupCommandFail(url *url.URL) (string, string, error) {
-	return "", "", nil
-}
-
-func fakeCreateUserGroupParseCommandFail(url *url.URL) (string, string, error) {
-	return "invalidoutput", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloak(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "{\"type\":\"secret\",\"value\":\"abcdef\"}", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakGetClientsFails(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get clients") {
-			return "", "", fmt.Errorf("failed")
-		}
-
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakNoRancherClient(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"norancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretFailed(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "", "", fmt.Errorf("failed")
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretResultEmpty(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretResultInvalid(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "invalid", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretEmpty(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "{\"type\":\"secret\",\"value\":\"\"}", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetVerrazzanoUserFromKeycloak(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "[{\"id\" : \"quick-fox\",\"username\" : \"verrazzano\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetVerrazzanoUserFromKeycloakFails(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "", "", fmt.Errorf("failed")
-		}
-
-	}
-
-	return "", "", nil
-}
-
-func fakeGetVerrazzanoUserFromKeycloakResultEmpty(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "", "", nil
-		}
+// createTestReadyKeycloakPod returns a Keycloak pod that is ready
+func createTestReadyKeycloakPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      keycloakPodName,
+			Namespace: ComponentNamespace,
+		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{
+					Type:   v1.PodReady,
+					Status: v1.ConditionTrue,
+				},
+			},
+		},
 	}
-
-	return "", "", nil
 }
 
-func fakeGetVerrazzanoUserFromKeycloakResultInvalid(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "invalid", "", nil
-		}
+// createTestUserSecrets returns the secrets with the passwords of the Verrazzano users
+func createTestUserSecrets() []client.Object {
+	var secrets []client.Object
+	for _, name := range []string{"verrazzano", "verrazzano-prom-internal", "verrazzano-es-internal"} {
+		secrets = append(secrets, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "verrazzano-system",
+			},
+			Data: map[string][]byte{
+				"password": []byte(name + "-password"),
+			},
+		})
 	}
-
-	return "", "", nil
+	return secrets
 }
 
-func fakeGetVerrazzanoUserFromKeycloakNoVerrazzanoUser(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "[{\"id\" : \"quick-fox\",\"username\" : \"notverrazzano\"}]", "", nil
-		}
+// createTestKeycloakClients creates the Verrazzano clients in the fake Keycloak server
+func createTestKeycloakClients(kc *fakeKeycloak) {
+	kc.realms[vzSysRealm] = &fakeRealm{rep: map[string]interface{}{"realm": vzSysRealm}}
+	for _, clientID := range []string{"verrazzano-pkce", "verrazzano-pg", "rancher"} {
+		kc.realms[vzSysRealm].clients = append(kc.realms[vzSysRealm].clients, &fakeClient{
+			id:     kc.id(),
+			rep:    map[string]interface{}{"clientId": clientID},
+			secret: clientID + "-secret",
+		})
 	}
-
-	return "", "", nil
 }
 
+// TestUpdateKeycloakURIs tests updating the redirect and web origin URIs of a client
+// GIVEN a client that exists in Keycloak
+// WHEN I call updateKeycloakUris
+// THEN the client URIs are updated, otherwise returning an error if the template or environment is invalid
 func TestUpdateKeycloakURIs(t *testing.T) {
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	cfg, cli, _ := fakeRESTConfig()
-	clientID := "client"
 	uriTemplate := "\"redirectUris\": [\"https://client.{{.DNSSubDomain}}/verify-auth\"]"
+	vz := testVZ.DeepCopy()
+	vz.Spec.EnvironmentName = "test-env"
 	tests := []struct {
 		name        string
 		ctx         spi.ComponentContext
-		clientID    string
 		uriTemplate string
 		wantErr     bool
 	}{
 		{
 			name:        "testUpdateKeycloakURIs",
-			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestNginxService()).Build(), testVZ, nil, false),
-			clientID:    clientID,
+			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestNginxService()).Build(), vz, nil, false),
 			uriTemplate: uriTemplate,
 			wantErr:     false,
 		},
 		{
 			name:        "testFailForInvalidUriTemplate",
-			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestNginxService()).Build(), testVZ, nil, false),
-			clientID:    clientID,
+			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestNginxService()).Build(), vz, nil, false),
 			uriTemplate: "test.{{{.DNSSubDomain}}",
 			wantErr:     true,
 		},
 		{
 			name:        "testFailForNoIngress",
-			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret()).Build(), testVZ, nil, false),
-			wantErr:     true,
-			clientID:    clientID,
+			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret()).Build(), vz, nil, false),
 			uriTemplate: uriTemplate,
+			wantErr:     true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			podExecResult := k8sutilfake.PodExecResult
-			if tt.wantErr && tt.name == "testFailForNoKeycloakClientsReturned" {
-				k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
-					return "", "[]", nil
-				}
-			}
-			defer func() { k8sutilfake.PodExecResult = podExecResult }()
-			if err := updateKeycloakUris(tt.ctx, cfg, cli, keycloakPod(), tt.clientID, tt.uriTemplate); (err != nil) != tt.wantErr {
-				t.Errorf("updateKeycloakUris() error = %v, wantErr %v", err, tt.wantErr)
+			kc := newFakeKeycloak(t)
+			createTestKeycloakClients(kc)
+			client := kc.realms[vzSysRealm].clients[0]
+			adminClient, err := newAdminClient(tt.ctx)
+			assert.NoError(t, err)
+			err = updateKeycloakUris(tt.ctx, adminClient, client.id, tt.uriTemplate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, []interface{}{"https://client.test-env.192.132.111.122.nip.io/verify-auth"}, client.rep["redirectUris"])
 		})
 	}
 }
@@ -381,69 +200,55 @@ func TestUpdateKeycloakURIs(t *testing.T) {
 func TestConfigureKeycloakRealms(t *testing.T) {
 	loginSecret := createTestLoginSecret()
 	nginxService := createTestNginxService()
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	podExecFunc := k8sutilfake.PodExecResult
 	authConfig := createTestKeycloakAuthConfig()
-
-	keycloakPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      keycloakPodName,
-			Namespace: ComponentNamespace,
-		},
-		Status: v1.PodStatus{
-			Conditions: []v1.PodCondition{
-				{
-					Type:   v1.PodReady,
-					Status: v1.ConditionTrue,
-				},
-			},
-		},
-	}
+	readyPod := createTestReadyKeycloakPod()
+	objects := append([]client.Object{loginSecret, nginxService, readyPod, &authConfig}, createTestUserSecrets()...)
 
 	var tests = []struct {
 		name        string
 		c           client.Client
-		stdout      string
+		setup       func(kc *fakeKeycloak)
 		isErr       bool
 		errContains string
-		execFunc    func(url *url.URL) (string, string, error)
 	}{
 		{
-			"should fail when login fails",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(keycloakPod).Build(),
-			"blahblah",
+			"should fail when login secret does not exist",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(readyPod).Build(),
+			nil,
 			true,
 			"secrets \"keycloak-http\" not found",
-			fakeCreateUserGroupCommand,
 		},
 		{
-			"should fail to retrieve user group ID from Keycloak when stdout is empty",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			"",
+			"should fail when the Keycloak pod is not ready",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod()).Build(),
+			nil,
 			true,
-			"Component Keycloak failed; verrazzano-users group ID from Keycloak is zero length",
-			fakeCreateUserGroupCommandFail,
+			"Waiting for pod keycloak-0 to be ready",
 		},
 		{
-			"should fail to retrieve user group ID from Keycloak when stdout is incorrect",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			"",
+			"should fail when login fails",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(objects...).Build(),
+			func(kc *fakeKeycloak) { kc.password = "other" },
+			true,
+			"failed with response code 401",
+		},
+		{
+			"should fail when creating the user group fails",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(objects...).Build(),
+			func(kc *fakeKeycloak) { kc.failOn(http.MethodPost, "/groups", http.StatusInternalServerError) },
 			true,
-			"failed parsing output returned from verrazzano-users Group",
-			fakeCreateUserGroupParseCommandFail,
+			"/auth/admin/realms/verrazzano-system/groups failed with response code 500",
 		},
 		{
 			"should fail when Verrazzano secret is not present",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			"blahblah'id",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, readyPod).Build(),
+			nil,
 			true,
 			"secrets \"verrazzano\" not found",
-			fakeCreateUserGroupCommand,
 		},
 		{
 			"should fail when Verrazzano secret has no password",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, keycloakPod,
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, readyPod,
 				&v1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "verrazzano",
@@ -453,123 +258,43 @@ func TestConfigureKeycloakRealms(t *testing.T) {
 						"password": []byte(""),
 					},
 				}).Build(),
-			"blahblah'id",
+			nil,
 			true,
 			"password field empty in secret",
-			fakeCreateUserGroupCommand,
 		},
 		{
 			"should fail when nginx service is not present",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod,
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-prom-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-es-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				}).Build(),
-			"blahblah'id",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(append([]client.Object{loginSecret, readyPod}, createTestUserSecrets()...)...).Build(),
+			nil,
 			true,
 			"services \"ingress-controller-ingress-nginx-controller\" not found",
-			fakeConfigureRealmCommands,
 		},
 		{
 			"fails during updateKeycloakURIs",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, keycloakPod,
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-prom-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-es-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				}).Build(),
-			"blahblah'id",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(objects...).Build(),
+			func(kc *fakeKeycloak) {
+				createTestKeycloakClients(kc)
+				kc.failOn(http.MethodPut, "/clients/", http.StatusInternalServerError)
+			},
 			true,
-			"keycloak/keycloak-0: failed",
-			fakeConfigureRealmCommandsUpdateKeycloakURIFailed,
+			"failed with response code 500",
 		},
 		{
-			"should pass when able to successfully exec commands on the keycloak pod and all k8s objects are present",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, keycloakPod, &authConfig,
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-prom-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-es-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				}).Build(),
-			"blahblah'id",
+			"should pass when all k8s objects are present",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(objects...).Build(),
+			nil,
 			false,
 			"",
-			fakeConfigureRealmCommands,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			kc := newFakeKeycloak(t)
+			if tt.setup != nil {
+				tt.setup(kc)
+			}
 			ctx := spi.NewFakeContext(tt.c, testVZ, nil, false)
-			k8sutilfake.PodExecResult = tt.execFunc
-			defer func() { k8sutilfake.PodExecResult = podExecFunc }()
 			err := configureKeycloakRealms(ctx)
 			if tt.isErr {
 				assert.Error(t, err)
@@ -581,6 +306,135 @@ func TestConfigureKeycloakRealms(t *testing.T) {
 	}
 }
 
+// TestConfigureKeycloakRealmsResources tests configuration of the Keycloak realms
+// GIVEN a new Keycloak install
+// WHEN I call configureKeycloakRealms twice
+// THEN the Verrazzano realm, groups, roles, users and clients are created, and the second call does not create anything
+func TestConfigureKeycloakRealmsResources(t *testing.T) {
+	a := assert.New(t)
+	kc := newFakeKeycloak(t)
+	authConfig := createTestKeycloakAuthConfig()
+	objects := append([]client.Object{createTestLoginSecret(), createTestNginxService(), createTestReadyKeycloakPod(), &authConfig}, createTestUserSecrets()...)
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(objects...).Build()
+	vz := testVZ.DeepCopy()
+	vz.Spec.EnvironmentName = "test-env"
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	a.NoError(configureKeycloakRealms(ctx))
+
+	// Realms
+	realm := kc.realms[vzSysRealm]
+	a.NotNil(realm)
+	a.Equal(true, realm.rep["enabled"])
+	for _, r := range []*fakeRealm{kc.realms["master"], realm} {
+		a.Equal(passwordPolicy, r.rep["passwordPolicy"])
+		a.Equal("oracle", r.rep["loginTheme"])
+	}
+
+	// Groups and roles
+	a.Len(realm.groups, 1)
+	users := realm.groups[0]
+	a.Equal(vzUsersGroup, users.Name)
+	a.Equal([]string{vzAPIAccessRole}, users.roles)
+	a.Len(users.SubGroups, 3)
+	for _, name := range []string{vzAdminGroup, vzMonitorGroup, vzSystemGroup} {
+		a.NotNil(realm.findGroupByPath("/" + vzUsersGroup + "/" + name))
+	}
+
+	// Users
+	for _, user := range []struct {
+		name  string
+		group string
+	}{
+		{vzUserName, vzAdminGroup},
+		{vzInternalPromUser, vzSystemGroup},
+		{vzInternalEsUser, vzSystemGroup},
+	} {
+		u := realm.findUser(user.name)
+		a.NotNil(u)
+		a.True(u.Enabled)
+		a.Equal([]string{"/" + vzUsersGroup + "/" + user.group}, u.groups)
+		a.Equal(user.name+"-password", u.password)
+	}
+	a.Equal("Verrazzano", realm.findUser(vzUserName).FirstName)
+
+	// Clients
+	a.Len(realm.clients, 3)
+	a.Empty(realm.findClientByClientID("verrazzano-pkce").secret)
+	a.NotEmpty(realm.findClientByClientID("verrazzano-pg").secret)
+	rancher := realm.findClientByClientID("rancher")
+	a.NotEmpty(rancher.secret)
+	a.Equal([]interface{}{"https://rancher.test-env.192.132.111.122.nip.io/verify-auth"}, rancher.rep["redirectUris"])
+	err := c.Get(context.TODO(), types.NamespacedName{Name: common.AuthConfigKeycloak}, &authConfig)
+	a.NoError(err)
+	a.Equal(rancher.secret, authConfig.Object[common.AuthConfigKeycloakAttributeClientSecret])
+
+	// A second reconcile only updates the existing resources
+	kc.requests = nil
+	a.NoError(configureKeycloakRealms(ctx))
+	for _, req := range kc.requests {
+		if strings.HasPrefix(req, http.MethodPost) && !strings.HasSuffix(req, "/role-mappings/realm") {
+			a.Equal(http.MethodPost+" "+keycloakTokenPath, req)
+		}
+	}
+	a.Len(realm.groups, 1)
+	a.Len(realm.users, 3)
+	a.Len(realm.clients, 3)
+}
+
+// TestConfigureKeycloakRealmsEphemeralStorage tests configuration of the Keycloak realms when the admin login fails
+// GIVEN Keycloak using ephemeral storage for MySQL and credentials that are no longer valid
+// WHEN I call configureKeycloakRealms
+// THEN the Keycloak pod is recycled
+func TestConfigureKeycloakRealmsEphemeralStorage(t *testing.T) {
+	kc := newFakeKeycloak(t)
+	kc.password = "other"
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestReadyKeycloakPod()).Build()
+	err := configureKeycloakRealms(spi.NewFakeContext(c, testVZ, nil, false))
+	assert.Error(t, err)
+
+	pod := &v1.Pod{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: keycloakPodName}, pod)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+// TestConfigureKeycloakRealmsNoRecycle tests configuration of the Keycloak realms when the admin login fails
+// GIVEN Keycloak failing the admin login for another reason than invalid credentials, or using persistent storage
+// WHEN I call configureKeycloakRealms
+// THEN an error is returned and the Keycloak pod is not recycled
+func TestConfigureKeycloakRealmsNoRecycle(t *testing.T) {
+	persistentVZ := testVZ.DeepCopy()
+	persistentVZ.Spec.Components.Keycloak.MySQL.VolumeSource = &v1.VolumeSource{
+		PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "mysql"},
+	}
+	tests := []struct {
+		name       string
+		vz         *vzapi.Verrazzano
+		password   string
+		statusCode int
+	}{
+		{name: "server error with ephemeral storage", vz: testVZ, statusCode: http.StatusInternalServerError},
+		{name: "invalid credentials with persistent storage", vz: persistentVZ, password: "other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := newFakeKeycloak(t)
+			if tt.password != "" {
+				kc.password = tt.password
+			}
+			if tt.statusCode != 0 {
+				kc.failOn(http.MethodPost, keycloakTokenPath, tt.statusCode)
+			}
+			c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestReadyKeycloakPod()).Build()
+			err := configureKeycloakRealms(spi.NewFakeContext(c, tt.vz, nil, false))
+			assert.Error(t, err)
+
+			pod := &v1.Pod{}
+			assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: keycloakPodName}, pod))
+		})
+	}
+}
+
 // TestAppendKeycloakOverrides tests that the Keycloak overrides are generated correctly.
 // GIVEN a Verrazzano BOM
 // WHEN I call AppendKeycloakOverrides
@@ -675,13 +529,14 @@ func TestGetEnvironmentName(t *testing.T) {
 // TestLoginKeycloak tests the login to keycloak interacts with k8s resources as expected
 // GIVEN a client
 // WHEN I call loginKeycloak
-// THEN throw an error if the k8s environment is invalid (bad secret)
+// THEN throw an error if the k8s environment is invalid (bad secret) or Keycloak does not accept the password
 func TestLoginKeycloak(t *testing.T) {
+	kc := newFakeKeycloak(t)
 	httpSecret := createTestLoginSecret()
 	httpSecretEmptyPassword := createTestLoginSecret()
 	httpSecretEmptyPassword.Data["password"] = []byte("")
-	cfg, restclient, _ := fakeRESTConfig()
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	httpSecretWrongPassword := createTestLoginSecret()
+	httpSecretWrongPassword.Data["password"] = []byte("wrong")
 
 	var tests = []struct {
 		name  string
@@ -698,6 +553,11 @@ func TestLoginKeycloak(t *testing.T) {
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(httpSecretEmptyPassword).Build(),
 			true,
 		},
+		{
+			"should fail when keycloak does not accept the password",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(httpSecretWrongPassword).Build(),
+			true,
+		},
 		{
 			"should log into keycloak when the password is present",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(httpSecret).Build(),
@@ -707,7 +567,11 @@ func TestLoginKeycloak(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := loginKeycloak(spi.NewFakeContext(tt.c, testVZ, nil, false), cfg, restclient)
+			ctx := spi.NewFakeContext(tt.c, testVZ, nil, false)
+			adminClient, err := newAdminClient(ctx)
+			if err == nil {
+				err = loginKeycloak(ctx, adminClient)
+			}
 			if tt.isErr {
 				assert.Error(t, err)
 			} else {
@@ -715,6 +579,7 @@ func TestLoginKeycloak(t *testing.T) {
 			}
 		})
 	}
+	assert.Equal(t, 1, kc.logins)
 }
 
 // TestCreateOrUpdateAuthSecret tests creation of the auth secret
@@ -1406,94 +1271,105 @@ func TestUpgradeStatefulSet(t *testing.T) {
 // THEN returns an rancher client secret, otherwise returning an error if the environment is invalid
 func TestGetRancherClientSecretFromKeycloak(t *testing.T) {
 	loginSecret := createTestLoginSecret()
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	podExecFunc := k8sutilfake.PodExecResult
-
-	keycloakPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      keycloakPodName,
-			Namespace: ComponentNamespace,
-		},
-		Status: v1.PodStatus{
-			Conditions: []v1.PodCondition{
-				{
-					Type:   v1.PodReady,
-					Status: v1.ConditionTrue,
-				},
-			},
-		},
-	}
+	keycloakPod := createTestReadyKeycloakPod()
 
 	var tests = []struct {
 		name        string
 		c           client.Client
+		setup       func(kc *fakeKeycloak)
+		secret      string
 		isErr       bool
 		errContains string
-		execFunc    func(url *url.URL) (string, string, error)
 	}{
 		{
 			"should fail when login fails",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(keycloakPod).Build(),
+			createTestKeycloakClients,
+			"",
 			true,
 			"secrets \"keycloak-http\" not found",
-			fakeGetRancherClientSecretFromKeycloak,
 		},
 		{
 			"should fail when fails to get clients",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
+			func(kc *fakeKeycloak) {
+				createTestKeycloakClients(kc)
+				kc.failOn(http.MethodGet, "/clients", http.StatusInternalServerError)
+			},
+			"",
 			true,
-			"keycloak/keycloak-0: failed",
-			fakeGetRancherClientSecretFromKeycloakGetClientsFails,
+			"failed with response code 500",
 		},
 		{
 			"should not fail when rancher client id does not exist",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
+			func(kc *fakeKeycloak) {
+				kc.realms[vzSysRealm] = &fakeRealm{rep: map[string]interface{}{"realm": vzSysRealm}}
+			},
+			"",
 			false,
 			"",
-			fakeGetRancherClientSecretFromKeycloakNoRancherClient,
 		},
 		{
 			"should fail when fetching client secret fails",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
+			func(kc *fakeKeycloak) {
+				createTestKeycloakClients(kc)
+				kc.failOn(http.MethodGet, "/client-secret", http.StatusNotFound)
+			},
 			"",
-			fakeGetRancherClientSecretFromKeycloakClientSecretFailed,
-		},
-		{
-			"should fail when client secret result is empty",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
 			true,
-			"",
-			fakeGetRancherClientSecretFromKeycloakClientSecretResultEmpty,
+			"failed with response code 404",
 		},
 		{
 			"should fail when client secret result is invalid",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
+			func(kc *fakeKeycloak) {
+				createTestKeycloakClients(kc)
+				kc.fail = func(r *http.Request) int {
+					if strings.HasSuffix(r.URL.Path, "/client-secret") {
+						return http.StatusOK
+					}
+					return 0
+				}
+			},
 			"",
-			fakeGetRancherClientSecretFromKeycloakClientSecretResultInvalid,
+			true,
+			"Failed parsing the response",
 		},
 		{
 			"should fail when client secret is empty",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
+			func(kc *fakeKeycloak) {
+				createTestKeycloakClients(kc)
+				kc.realms[vzSysRealm].findClientByClientID("rancher").secret = ""
+			},
+			"",
 			true,
 			"client secret is empty",
-			fakeGetRancherClientSecretFromKeycloakClientSecretEmpty,
+		},
+		{
+			"should return the rancher client secret",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
+			createTestKeycloakClients,
+			"rancher-secret",
+			false,
+			"",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			kc := newFakeKeycloak(t)
+			tt.setup(kc)
 			ctx := spi.NewFakeContext(tt.c, testVZ, nil, false)
-			k8sutilfake.PodExecResult = tt.execFunc
-			defer func() { k8sutilfake.PodExecResult = podExecFunc }()
-			_, err := GetRancherClientSecretFromKeycloak(ctx)
+			secret, err := GetRancherClientSecretFromKeycloak(ctx)
 			if tt.isErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.secret, secret)
 			}
 		})
 	}
@@ -1505,80 +1381,76 @@ func TestGetRancherClientSecretFromKeycloak(t *testing.T) {
 // THEN returns a verrazzano user struct, otherwise returning an error if the environment is invalid
 func TestGetVerrazzanoUserFromKeycloak(t *testing.T) {
 	loginSecret := createTestLoginSecret()
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	podExecFunc := k8sutilfake.PodExecResult
-
-	keycloakPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      keycloakPodName,
-			Namespace: ComponentNamespace,
-		},
-		Status: v1.PodStatus{
-			Conditions: []v1.PodCondition{
-				{
-					Type:   v1.PodReady,
-					Status: v1.ConditionTrue,
-				},
-			},
-		},
+	keycloakPod := createTestReadyKeycloakPod()
+	addUsers := func(names ...string) func(kc *fakeKeycloak) {
+		return func(kc *fakeKeycloak) {
+			realm := &fakeRealm{rep: map[string]interface{}{"realm": vzSysRealm}}
+			for _, name := range names {
+				realm.users = append(realm.users, &fakeUser{ID: kc.id(), Username: name, Enabled: true})
+			}
+			kc.realms[vzSysRealm] = realm
+		}
 	}
 
 	var tests = []struct {
 		name        string
 		c           client.Client
+		setup       func(kc *fakeKeycloak)
 		isErr       bool
 		errContains string
-		execFunc    func(url *url.URL) (string, string, error)
 	}{
 		{
 			"should fail when login fails",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(keycloakPod).Build(),
+			addUsers(vzUserName),
 			true,
 			"secrets \"keycloak-http\" not found",
-			fakeGetVerrazzanoUserFromKeycloak,
 		},
 		{
 			"should fail when fails to get users",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
+			func(kc *fakeKeycloak) {
+				addUsers(vzUserName)(kc)
+				kc.failOn(http.MethodGet, "/users", http.StatusInternalServerError)
+			},
 			true,
-			"keycloak/keycloak-0: failed",
-			fakeGetVerrazzanoUserFromKeycloakFails,
+			"failed with response code 500",
 		},
 		{
-			"should fail when get users result is empty",
+			"should fail when the realm does not exist",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
+			func(kc *fakeKeycloak) {},
 			true,
-			"",
-			fakeGetVerrazzanoUserFromKeycloakResultEmpty,
+			"failed with response code 404",
 		},
 		{
-			"should fail when get users result is invalid",
+			"should fail when verrazzano user is not found",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
+			addUsers("notverrazzano", "verrazzano-es-internal"),
 			true,
-			"",
-			fakeGetVerrazzanoUserFromKeycloakResultInvalid,
+			"verrazzano user does not exist",
 		},
 		{
-			"should fail when verrazzano user is not found",
+			"should return the verrazzano user",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
-			"verrazzano user does not exist",
-			fakeGetVerrazzanoUserFromKeycloakNoVerrazzanoUser,
+			addUsers("verrazzano-prom-internal", vzUserName),
+			false,
+			"",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			kc := newFakeKeycloak(t)
+			tt.setup(kc)
 			ctx := spi.NewFakeContext(tt.c, testVZ, nil, false)
-			k8sutilfake.PodExecResult = tt.execFunc
-			defer func() { k8sutilfake.PodExecResult = podExecFunc }()
-			_, err := GetVerrazzanoUserFromKeycloak(ctx)
+			user, err := GetVerrazzanoUserFromKeycloak(ctx)
 			if tt.isErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, vzUserName, user.Username)
 			}
 		})
 	}
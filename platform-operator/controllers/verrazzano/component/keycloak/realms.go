@@ -0,0 +1,499 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// passwordVersionAttribute is the user attribute that records the version of the Secret the password of a declared
+// user was last set from, so the password is only reset when the Secret changes
+const passwordVersionAttribute = "verrazzano-password-version"
+
+// realmDrift collects the differences found between the declared objects of a realm and the objects in Keycloak
+type realmDrift struct {
+	realm   string
+	changes []string
+}
+
+func (d *realmDrift) add(format string, args ...interface{}) {
+	d.changes = append(d.changes, fmt.Sprintf(format, args...))
+}
+
+// report logs the drift that was corrected, so changes made to declared objects outside of Verrazzano are visible
+func (d *realmDrift) report(ctx spi.ComponentContext) {
+	if len(d.changes) == 0 {
+		return
+	}
+	ctx.Log().Infof("Component Keycloak corrected drift from the declared configuration of realm %s: %s", d.realm, strings.Join(d.changes, "; "))
+}
+
+// ReconcileDeclaredRealms converges Keycloak to the realms declared in the Keycloak component of the Verrazzano
+// resource and returns the Keycloak status to record in the Verrazzano resource.  The Keycloak requests are cancelled
+// when the request context is done.  Nothing is done while Keycloak is not ready, the current status is returned.
+func ReconcileDeclaredRealms(reqCtx context.Context, ctx spi.ComponentContext) (*vzapi.KeycloakStatus, error) {
+	keycloak := ctx.EffectiveCR().Spec.Components.Keycloak
+	if !NewComponent().IsEnabled(ctx.EffectiveCR()) || keycloak == nil || len(keycloak.Realms) == 0 {
+		return nil, nil
+	}
+	if !isKeycloakReady(ctx) {
+		ctx.Log().Progressf("Component Keycloak waiting to be ready to configure the declared realms")
+		return ctx.ActualCR().Status.Keycloak, nil
+	}
+	kc, err := newAdminClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kc.reqCtx = reqCtx
+	return configureDeclaredRealms(ctx, kc)
+}
+
+// configureDeclaredRealms converges Keycloak to the realms declared in the Keycloak component of the Verrazzano resource.
+// Missing realms, groups, role mappings, users and clients are created and declared fields that were changed in
+// Keycloak are restored.  Objects that are not declared are left unchanged.  The Keycloak status is returned with the
+// drift that was corrected.
+func configureDeclaredRealms(ctx spi.ComponentContext, kc *adminClient) (*vzapi.KeycloakStatus, error) {
+	var drifts []*realmDrift
+	if ctx.EffectiveCR().Spec.Components.Keycloak != nil {
+		for _, realm := range ctx.EffectiveCR().Spec.Components.Keycloak.Realms {
+			drift, err := configureDeclaredRealm(ctx, kc, realm)
+			if err != nil {
+				return nil, err
+			}
+			drifts = append(drifts, drift)
+		}
+	}
+	return getRealmsStatus(ctx.ActualCR().Status.Keycloak, drifts), nil
+}
+
+func configureDeclaredRealm(ctx spi.ComponentContext, kc *adminClient, realm vzapi.KeycloakRealm) (*realmDrift, error) {
+	exists, err := kc.realmExists(realm.Name)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed checking if realm %s exists: %v", realm.Name, err)
+		return nil, err
+	}
+	if !exists {
+		enabled := true
+		if err := kc.createRealm(realmRepresentation{Realm: realm.Name, Enabled: &enabled}); err != nil {
+			ctx.Log().Errorf("Component Keycloak failed creating realm %s: %v", realm.Name, err)
+			return nil, err
+		}
+		ctx.Log().Oncef("Component Keycloak successfully created realm %s", realm.Name)
+	}
+
+	drift := &realmDrift{realm: realm.Name}
+	groupIDs, err := configureDeclaredGroups(ctx, kc, realm, drift)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range realm.Users {
+		if err := configureDeclaredUser(ctx, kc, realm, user, groupIDs, drift); err != nil {
+			return nil, err
+		}
+	}
+	for _, client := range realm.Clients {
+		if err := configureDeclaredClient(ctx, kc, realm.Name, client, drift); err != nil {
+			return nil, err
+		}
+	}
+	drift.report(ctx)
+	ctx.Log().Oncef("Component Keycloak successfully configured the declared objects of realm %s", realm.Name)
+	return drift, nil
+}
+
+// getRealmsStatus returns the Keycloak status with the drift corrected in the declared realms.  The last drift of a
+// realm is kept until new drift is corrected, so that it remains visible after it has been reverted.
+func getRealmsStatus(current *vzapi.KeycloakStatus, drifts []*realmDrift) *vzapi.KeycloakStatus {
+	previous := map[string]vzapi.KeycloakRealmStatus{}
+	if current != nil {
+		for _, realm := range current.Realms {
+			previous[realm.Name] = realm
+		}
+	}
+	var realms []vzapi.KeycloakRealmStatus
+	for _, drift := range drifts {
+		if len(drift.changes) > 0 {
+			realms = append(realms, vzapi.KeycloakRealmStatus{
+				Name:      drift.realm,
+				Drift:     drift.changes,
+				DriftTime: time.Now().UTC().Format(time.RFC3339),
+			})
+		} else if realm, ok := previous[drift.realm]; ok {
+			realms = append(realms, realm)
+		}
+	}
+	if len(realms) == 0 {
+		return nil
+	}
+	return &vzapi.KeycloakStatus{Realms: realms}
+}
+
+// groupPath returns the path of a declared group in Keycloak
+func groupPath(group vzapi.KeycloakRealmGroup) string {
+	if group.Parent == "" {
+		return "/" + group.Name
+	}
+	return "/" + group.Parent + "/" + group.Name
+}
+
+// userGroupPath returns the path of a group a declared user is a member of.  A group that is not selected by its path
+// is the declared group with that name, or the top level group with that name if none is declared.
+func userGroupPath(realm vzapi.KeycloakRealm, groupName string) string {
+	if strings.HasPrefix(groupName, "/") {
+		return groupName
+	}
+	for _, group := range realm.Groups {
+		if group.Name == groupName {
+			return groupPath(group)
+		}
+	}
+	return "/" + groupName
+}
+
+// configureDeclaredGroups creates the declared groups and maps their realm roles, and returns the IDs of the groups of
+// the realm by path
+func configureDeclaredGroups(ctx spi.ComponentContext, kc *adminClient, realm vzapi.KeycloakRealm, drift *realmDrift) (map[string]string, error) {
+	keycloakGroups, err := kc.getGroups(realm.Name)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving the groups of realm %s: %v", realm.Name, err)
+		return nil, err
+	}
+	groupIDs := map[string]string{}
+	for _, group := range keycloakGroups {
+		groupIDs[group.Path] = group.ID
+		for _, subGroup := range group.SubGroups {
+			groupIDs[subGroup.Path] = subGroup.ID
+		}
+	}
+
+	// Create the top level groups first so parents exist when their subgroups are created
+	groups := make([]vzapi.KeycloakRealmGroup, len(realm.Groups))
+	copy(groups, realm.Groups)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Parent == "" && groups[j].Parent != ""
+	})
+	created := map[string]bool{}
+	for _, group := range groups {
+		path := groupPath(group)
+		if groupIDs[path] != "" {
+			continue
+		}
+		parentID := ""
+		if group.Parent != "" {
+			if parentID = groupIDs["/"+group.Parent]; parentID == "" {
+				err := fmt.Errorf("Component Keycloak failed creating group %s in realm %s, the parent group %s does not exist", group.Name, realm.Name, group.Parent)
+				ctx.Log().Error(err)
+				return nil, err
+			}
+		}
+		groupID, err := kc.createGroup(realm.Name, group.Name, parentID)
+		if err != nil {
+			ctx.Log().Errorf("Component Keycloak failed creating group %s in realm %s: %v", group.Name, realm.Name, err)
+			return nil, err
+		}
+		groupIDs[path] = groupID
+		created[path] = true
+		ctx.Log().Oncef("Component Keycloak successfully created group %s in realm %s", group.Name, realm.Name)
+	}
+
+	for _, group := range groups {
+		path := groupPath(group)
+		if err := configureGroupRealmRoles(ctx, kc, realm.Name, group, groupIDs[path], created[path], drift); err != nil {
+			return nil, err
+		}
+	}
+	return groupIDs, nil
+}
+
+// configureGroupRealmRoles maps the declared realm roles that are not mapped to the group, roles that do not exist are
+// created.  Missing mappings are reported as drift unless the group has just been created.
+func configureGroupRealmRoles(ctx spi.ComponentContext, kc *adminClient, realmName string, group vzapi.KeycloakRealmGroup, groupID string, created bool, drift *realmDrift) error {
+	if len(group.RealmRoles) == 0 {
+		return nil
+	}
+	mappedRoles, err := kc.getGroupRealmRoles(realmName, groupID)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving the roles of group %s in realm %s: %v", group.Name, realmName, err)
+		return err
+	}
+	var missingRoles []roleRepresentation
+	for _, roleName := range group.RealmRoles {
+		if isRoleMapped(mappedRoles, roleName) {
+			continue
+		}
+		role, err := kc.getRole(realmName, roleName)
+		if isStatus(err, http.StatusNotFound) {
+			if err = kc.createRole(realmName, roleName); err == nil {
+				ctx.Log().Oncef("Component Keycloak successfully created role %s in realm %s", roleName, realmName)
+				role, err = kc.getRole(realmName, roleName)
+			}
+		}
+		if err != nil {
+			ctx.Log().Errorf("Component Keycloak failed retrieving role %s in realm %s: %v", roleName, realmName, err)
+			return err
+		}
+		missingRoles = append(missingRoles, *role)
+		if !created {
+			drift.add("role %s not mapped to group %s", roleName, groupPath(group))
+		}
+	}
+	if len(missingRoles) == 0 {
+		return nil
+	}
+	if err := kc.addRealmRolesToGroup(realmName, groupID, missingRoles); err != nil {
+		ctx.Log().Errorf("Component Keycloak failed mapping roles to group %s in realm %s: %v", group.Name, realmName, err)
+		return err
+	}
+	return nil
+}
+
+func isRoleMapped(roles []roleRepresentation, roleName string) bool {
+	for _, role := range roles {
+		if role.Name == roleName {
+			return true
+		}
+	}
+	return false
+}
+
+// configureDeclaredUser creates the declared user or restores its declared fields, group memberships and password
+func configureDeclaredUser(ctx spi.ComponentContext, kc *adminClient, realm vzapi.KeycloakRealm, user vzapi.KeycloakRealmUser, groupIDs map[string]string, drift *realmDrift) error {
+	realmName := realm.Name
+	password, passwordVersion, err := getDeclaredSecretValue(ctx, user.PasswordSecretRef)
+	if err != nil {
+		return err
+	}
+	existing, err := kc.getUser(realmName, user.Username)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving user %s in realm %s: %v", user.Username, realmName, err)
+		return err
+	}
+
+	desired := userRepresentation{
+		Username:   user.Username,
+		Enabled:    true,
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		Email:      user.Email,
+		Attributes: map[string][]string{passwordVersionAttribute: {passwordVersion}},
+	}
+	var userID string
+	var memberOf []groupRepresentation
+	if existing == nil {
+		// Set the password when the user is created, so the user is never left without a password
+		desired.Credentials = []credentialRepresentation{{Type: "password", Value: password}}
+		if userID, err = kc.createUser(realmName, desired); err != nil {
+			ctx.Log().Errorf("Component Keycloak failed creating user %s in realm %s: %v", user.Username, realmName, err)
+			return err
+		}
+		ctx.Log().Oncef("Component Keycloak successfully created user %s in realm %s", user.Username, realmName)
+	} else {
+		userID = existing.ID
+		// Reset the password before recording the new password version, so a failure is retried
+		versions := existing.Attributes[passwordVersionAttribute]
+		if len(versions) != 1 || versions[0] != passwordVersion {
+			if err := kc.resetPassword(realmName, userID, password); err != nil {
+				ctx.Log().Errorf("Component Keycloak failed setting the password of user %s in realm %s: %v", user.Username, realmName, err)
+				return err
+			}
+		}
+		if err := updateDeclaredUser(ctx, kc, realmName, existing, desired, drift); err != nil {
+			return err
+		}
+		if memberOf, err = kc.getUserGroups(realmName, userID); err != nil {
+			ctx.Log().Errorf("Component Keycloak failed retrieving the groups of user %s in realm %s: %v", user.Username, realmName, err)
+			return err
+		}
+	}
+
+	for _, groupName := range user.Groups {
+		path := userGroupPath(realm, groupName)
+		if isGroupMember(memberOf, path) {
+			continue
+		}
+		groupID := groupIDs[path]
+		if groupID == "" {
+			err := fmt.Errorf("Component Keycloak failed adding user %s to group %s in realm %s, the group does not exist", user.Username, path, realmName)
+			ctx.Log().Error(err)
+			return err
+		}
+		if err := kc.addUserToGroup(realmName, userID, groupID); err != nil {
+			ctx.Log().Errorf("Component Keycloak failed adding user %s to group %s in realm %s: %v", user.Username, path, realmName, err)
+			return err
+		}
+		if existing != nil {
+			drift.add("user %s not a member of group %s", user.Username, path)
+		}
+	}
+	return nil
+}
+
+// updateDeclaredUser restores the declared fields of an existing user, the password version is updated without being
+// reported as drift since it changes whenever the password Secret is updated
+func updateDeclaredUser(ctx spi.ComponentContext, kc *adminClient, realmName string, existing *userRepresentation, desired userRepresentation, drift *realmDrift) error {
+	var changed []string
+	if !existing.Enabled {
+		changed = append(changed, "enabled")
+	}
+	if existing.FirstName != desired.FirstName {
+		changed = append(changed, "firstName")
+	}
+	if existing.LastName != desired.LastName {
+		changed = append(changed, "lastName")
+	}
+	if existing.Email != desired.Email {
+		changed = append(changed, "email")
+	}
+	versions := existing.Attributes[passwordVersionAttribute]
+	if len(changed) == 0 && len(versions) == 1 && versions[0] == desired.Attributes[passwordVersionAttribute][0] {
+		return nil
+	}
+
+	// Keycloak replaces all the attributes of the user when they are set
+	attributes := map[string][]string{}
+	for key, val := range existing.Attributes {
+		attributes[key] = val
+	}
+	attributes[passwordVersionAttribute] = desired.Attributes[passwordVersionAttribute]
+	desired.Attributes = attributes
+	if err := kc.updateUser(realmName, existing.ID, desired); err != nil {
+		ctx.Log().Errorf("Component Keycloak failed updating user %s in realm %s: %v", desired.Username, realmName, err)
+		return err
+	}
+	if len(changed) > 0 {
+		drift.add("user %s changed %s", desired.Username, strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+func isGroupMember(groups []groupRepresentation, groupPath string) bool {
+	for _, group := range groups {
+		if group.Path == groupPath {
+			return true
+		}
+	}
+	return false
+}
+
+// configureDeclaredClient creates the declared client or restores its declared fields and secret
+func configureDeclaredClient(ctx spi.ComponentContext, kc *adminClient, realmName string, client vzapi.KeycloakRealmClient, drift *realmDrift) error {
+	desired := clientRepresentation{
+		ClientID:            client.ClientID,
+		Enabled:             true,
+		Protocol:            "openid-connect",
+		PublicClient:        client.Public,
+		StandardFlowEnabled: true,
+		RedirectURIs:        client.RedirectURIs,
+		WebOrigins:          client.WebOrigins,
+	}
+	if !client.Public && client.SecretRef != nil {
+		secret, _, err := getDeclaredSecretValue(ctx, *client.SecretRef)
+		if err != nil {
+			return err
+		}
+		desired.Secret = secret
+	}
+
+	keycloakClients, err := kc.getClients(realmName)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving the clients of realm %s: %v", realmName, err)
+		return err
+	}
+	id := getClientID(keycloakClients, client.ClientID)
+	if id == "" {
+		if _, err := kc.createClient(realmName, desired); err != nil {
+			ctx.Log().Errorf("Component Keycloak failed creating client %s in realm %s: %v", client.ClientID, realmName, err)
+			return err
+		}
+		ctx.Log().Oncef("Component Keycloak successfully created client %s in realm %s", client.ClientID, realmName)
+		return nil
+	}
+
+	existing, err := kc.getClient(realmName, id)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving client %s in realm %s: %v", client.ClientID, realmName, err)
+		return err
+	}
+	var changed []string
+	if !existing.Enabled {
+		changed = append(changed, "enabled")
+	}
+	if existing.PublicClient != desired.PublicClient {
+		changed = append(changed, "publicClient")
+	}
+	if !existing.StandardFlowEnabled {
+		changed = append(changed, "standardFlowEnabled")
+	}
+	if !sameStrings(existing.RedirectURIs, desired.RedirectURIs) {
+		changed = append(changed, "redirectUris")
+	}
+	if !sameStrings(existing.WebOrigins, desired.WebOrigins) {
+		changed = append(changed, "webOrigins")
+	}
+	if desired.Secret != "" {
+		clientSecret, err := kc.getClientSecret(realmName, id)
+		if err != nil {
+			ctx.Log().Errorf("Component Keycloak failed retrieving the secret of client %s in realm %s: %v", client.ClientID, realmName, err)
+			return err
+		}
+		if clientSecret.Value != desired.Secret {
+			changed = append(changed, "secret")
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := kc.updateClient(realmName, id, desired); err != nil {
+		ctx.Log().Errorf("Component Keycloak failed updating client %s in realm %s: %v", client.ClientID, realmName, err)
+		return err
+	}
+	drift.add("client %s changed %s", client.ClientID, strings.Join(changed, ", "))
+	return nil
+}
+
+// sameStrings returns true if the slices contain the same strings in any order
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// getDeclaredSecretValue returns the value of the Secret key selected in the Verrazzano resource, along with a version
+// that changes whenever the Secret is updated
+func getDeclaredSecretValue(ctx spi.ComponentContext, selector corev1.SecretKeySelector) (string, string, error) {
+	namespace := ctx.EffectiveCR().Namespace
+	secret := &corev1.Secret{}
+	err := ctx.Client().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: selector.Name}, secret)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving secret %s/%s: %v", namespace, selector.Name, err)
+		return "", "", err
+	}
+	val := string(secret.Data[selector.Key])
+	if val == "" {
+		err := fmt.Errorf("Component Keycloak failed, key %s is empty in secret %s/%s", selector.Key, namespace, selector.Name)
+		ctx.Log().Error(err)
+		return "", "", err
+	}
+	return val, fmt.Sprintf("%s/%s", secret.UID, secret.ResourceVersion), nil
+}
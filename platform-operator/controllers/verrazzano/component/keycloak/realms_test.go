@@ -0,0 +1,428 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testAppsRealm     = "apps"
+	testVZNamespace   = "default"
+	testAppPassword   = "app-admin-password"
+	testBackendSecret = "app-backend-secret"
+)
+
+// createTestRealmsVZ returns a Verrazzano resource that declares a realm with groups, a user and clients
+func createTestRealmsVZ() *vzapi.Verrazzano {
+	vz := testVZ.DeepCopy()
+	vz.Namespace = testVZNamespace
+	vz.Spec.Components.Keycloak.Realms = []vzapi.KeycloakRealm{
+		{
+			Name: testAppsRealm,
+			Groups: []vzapi.KeycloakRealmGroup{
+				// The subgroup is declared first to check that parents are created first
+				{Name: "app-admins", Parent: "app-users", RealmRoles: []string{"app_admin"}},
+				{Name: "app-users", RealmRoles: []string{"app_access"}},
+			},
+			Users: []vzapi.KeycloakRealmUser{
+				{
+					Username:  "app-admin",
+					FirstName: "App",
+					LastName:  "Admin",
+					Email:     "app-admin@example.com",
+					Groups:    []string{"app-admins"},
+					PasswordSecretRef: v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "app-admin"},
+						Key:                  "password",
+					},
+				},
+			},
+			Clients: []vzapi.KeycloakRealmClient{
+				{
+					ClientID:     "app-ui",
+					Public:       true,
+					RedirectURIs: []string{"https://app.example.com/*"},
+					WebOrigins:   []string{"+"},
+				},
+				{
+					ClientID:     "app-backend",
+					RedirectURIs: []string{"https://api.example.com/callback"},
+					SecretRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "app-backend"},
+						Key:                  "secret",
+					},
+				},
+			},
+		},
+	}
+	return vz
+}
+
+// createTestRealmsSecrets returns the Secrets referenced by the realm declared in createTestRealmsVZ
+func createTestRealmsSecrets() []client.Object {
+	return []client.Object{
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-admin", Namespace: testVZNamespace},
+			Data:       map[string][]byte{"password": []byte(testAppPassword)},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-backend", Namespace: testVZNamespace},
+			Data:       map[string][]byte{"secret": []byte(testBackendSecret)},
+		},
+	}
+}
+
+// newRealmsTestContext returns a context for the Verrazzano resource with the client objects and an admin client
+// for the fake Keycloak server
+func newRealmsTestContext(t *testing.T, vz *vzapi.Verrazzano, objects ...client.Object) (spi.ComponentContext, *adminClient) {
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(append(objects, createTestLoginSecret())...).Build()
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+	kc, err := newAdminClient(ctx)
+	assert.NoError(t, err)
+	return ctx, kc
+}
+
+// TestConfigureDeclaredRealms tests configuring the realms declared in the Verrazzano resource
+// GIVEN a Verrazzano resource that declares a realm with groups, role mappings, a user and clients
+// WHEN configureDeclaredRealms is called
+// THEN the realm and all its declared objects are created in Keycloak
+func TestConfigureDeclaredRealms(t *testing.T) {
+	a := assert.New(t)
+	kc := newFakeKeycloak(t)
+	ctx, client := newRealmsTestContext(t, createTestRealmsVZ(), createTestRealmsSecrets()...)
+
+	_, err := configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+
+	realm := kc.realms[testAppsRealm]
+	a.NotNil(realm)
+	a.Equal(true, realm.rep["enabled"])
+
+	// Groups and role mappings
+	a.Len(realm.groups, 1)
+	users := realm.findGroupByPath("/app-users")
+	a.NotNil(users)
+	a.Equal([]string{"app_access"}, users.roles)
+	admins := realm.findGroupByPath("/app-users/app-admins")
+	a.NotNil(admins)
+	a.Equal([]string{"app_admin"}, admins.roles)
+
+	// Users
+	user := realm.findUser("app-admin")
+	a.NotNil(user)
+	a.True(user.Enabled)
+	a.Equal("App", user.FirstName)
+	a.Equal("Admin", user.LastName)
+	a.Equal("app-admin@example.com", user.Email)
+	a.Equal([]string{"/app-users/app-admins"}, user.groups)
+	a.Equal(testAppPassword, user.password)
+	a.Len(user.Attributes[passwordVersionAttribute], 1)
+
+	// Clients
+	a.Len(realm.clients, 2)
+	ui := realm.findClientByClientID("app-ui")
+	a.Equal(true, ui.rep["publicClient"])
+	a.Equal([]interface{}{"https://app.example.com/*"}, ui.rep["redirectUris"])
+	a.Equal([]interface{}{"+"}, ui.rep["webOrigins"])
+	a.Empty(ui.secret)
+	backend := realm.findClientByClientID("app-backend")
+	a.Equal(false, backend.rep["publicClient"])
+	a.Equal(testBackendSecret, backend.secret)
+
+	// A second reconcile finds no drift and changes nothing
+	kc.requests = nil
+	_, err = configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	for _, req := range kc.requests {
+		a.True(strings.HasPrefix(req, http.MethodGet), "unexpected request %s", req)
+	}
+}
+
+// TestConfigureDeclaredRealmsNone tests configuring declared realms when there are none
+// GIVEN a Verrazzano resource that does not declare realms
+// WHEN configureDeclaredRealms is called
+// THEN no requests are made to Keycloak
+func TestConfigureDeclaredRealmsNone(t *testing.T) {
+	kc := newFakeKeycloak(t)
+	ctx, client := newRealmsTestContext(t, testVZ)
+
+	status, err := configureDeclaredRealms(ctx, client)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+	assert.Empty(t, kc.requests)
+}
+
+// TestConfigureDeclaredRealmsDrift tests restoring declared objects that were changed in Keycloak
+// GIVEN declared objects that were changed outside of Verrazzano
+// WHEN the declared groups, user and clients are configured
+// THEN the declared configuration is restored and each change is reported as drift
+func TestConfigureDeclaredRealmsDrift(t *testing.T) {
+	a := assert.New(t)
+	kc := newFakeKeycloak(t)
+	vz := createTestRealmsVZ()
+	ctx, client := newRealmsTestContext(t, vz, createTestRealmsSecrets()...)
+	_, err := configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+
+	realm := kc.realms[testAppsRealm]
+	realm.findGroupByPath("/app-users").roles = nil
+	user := realm.findUser("app-admin")
+	user.groups = nil
+	user.Email = "someone@example.com"
+	ui := realm.findClientByClientID("app-ui")
+	ui.rep["redirectUris"] = []interface{}{"https://other.example.com/*"}
+	backend := realm.findClientByClientID("app-backend")
+	backend.secret = "regenerated"
+
+	declared := vz.Spec.Components.Keycloak.Realms[0]
+	drift := &realmDrift{realm: testAppsRealm}
+	groupIDs, err := configureDeclaredGroups(ctx, client, declared, drift)
+	a.NoError(err)
+	a.NoError(configureDeclaredUser(ctx, client, declared, declared.Users[0], groupIDs, drift))
+	for _, c := range declared.Clients {
+		a.NoError(configureDeclaredClient(ctx, client, testAppsRealm, c, drift))
+	}
+
+	a.Equal([]string{
+		"role app_access not mapped to group /app-users",
+		"user app-admin changed email",
+		"user app-admin not a member of group /app-users/app-admins",
+		"client app-ui changed redirectUris",
+		"client app-backend changed secret",
+	}, drift.changes)
+	a.Equal([]string{"app_access"}, realm.findGroupByPath("/app-users").roles)
+	a.Equal("app-admin@example.com", user.Email)
+	a.Equal([]string{"/app-users/app-admins"}, user.groups)
+	a.Equal([]interface{}{"https://app.example.com/*"}, ui.rep["redirectUris"])
+	a.Equal(testBackendSecret, backend.secret)
+	// The password is not reset while the Secret is unchanged
+	a.NotContains(kc.requests, http.MethodPut+" "+keycloakAdminPath+"/"+testAppsRealm+"/users/"+user.ID+"/reset-password")
+}
+
+// TestConfigureDeclaredRealmsDriftStatus tests returning the corrected drift in the Keycloak status
+// GIVEN a declared user that was changed outside of Verrazzano
+// WHEN configureDeclaredRealms is called
+// THEN the drift is returned in the Keycloak status and kept once there is no more drift, the Verrazzano resource is
+// left for the controller to update
+func TestConfigureDeclaredRealmsDriftStatus(t *testing.T) {
+	a := assert.New(t)
+	kc := newFakeKeycloak(t)
+	vz := createTestRealmsVZ()
+	vz.Name = "verrazzano"
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(createTestRealmsSecrets(), createTestLoginSecret(), vz)...).Build()
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+	client, err := newAdminClient(ctx)
+	a.NoError(err)
+
+	// No drift is recorded when the realm is created
+	status, err := configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	a.Nil(status)
+
+	kc.realms[testAppsRealm].findUser("app-admin").Email = "someone@example.com"
+	status, err = configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	a.NotNil(status)
+	a.Len(status.Realms, 1)
+	a.Equal(testAppsRealm, status.Realms[0].Name)
+	a.Equal([]string{"user app-admin changed email"}, status.Realms[0].Drift)
+	a.NotEmpty(status.Realms[0].DriftTime)
+	actual := &vzapi.Verrazzano{}
+	a.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: vz.Namespace, Name: vz.Name}, actual))
+	a.Nil(actual.Status.Keycloak)
+
+	// The last drift remains visible after it has been corrected
+	vz.Status.Keycloak = status
+	kept, err := configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	a.Equal(status, kept)
+}
+
+// TestReconcileDeclaredRealms tests reconciling the declared realms from the controller
+// GIVEN a Verrazzano resource that declares a realm
+// WHEN ReconcileDeclaredRealms is called while Keycloak is not ready
+// THEN the current status is returned without contacting Keycloak, and no status is returned when no realm is declared
+func TestReconcileDeclaredRealms(t *testing.T) {
+	a := assert.New(t)
+	kc := newFakeKeycloak(t)
+	vz := createTestRealmsVZ()
+	vz.Status.Keycloak = &vzapi.KeycloakStatus{Realms: []vzapi.KeycloakRealmStatus{{Name: testAppsRealm}}}
+	ctx, _ := newRealmsTestContext(t, vz, createTestRealmsSecrets()...)
+	status, err := ReconcileDeclaredRealms(context.TODO(), ctx)
+	a.NoError(err)
+	a.Equal(vz.Status.Keycloak, status)
+	a.Empty(kc.requests)
+
+	ctx, _ = newRealmsTestContext(t, testVZ)
+	status, err = ReconcileDeclaredRealms(context.TODO(), ctx)
+	a.NoError(err)
+	a.Nil(status)
+}
+
+// TestConfigureDeclaredRealmsCancelled tests configuring the declared realms once the reconcile request is cancelled
+// GIVEN an admin client with a request context that is done
+// WHEN configureDeclaredRealms is called
+// THEN an error is returned and no request reaches Keycloak
+func TestConfigureDeclaredRealmsCancelled(t *testing.T) {
+	kc := newFakeKeycloak(t)
+	ctx, client := newRealmsTestContext(t, createTestRealmsVZ(), createTestRealmsSecrets()...)
+	reqCtx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	client.reqCtx = reqCtx
+
+	_, err := configureDeclaredRealms(ctx, client)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+	assert.Empty(t, kc.requests)
+}
+
+// TestConfigureDeclaredRealmsGroupPaths tests configuring declared groups that have the same name
+// GIVEN a top level group and a subgroup with the same name, and users that are members of each one
+// WHEN configureDeclaredRealms is called
+// THEN each user is a member of the group selected by its path
+func TestConfigureDeclaredRealmsGroupPaths(t *testing.T) {
+	a := assert.New(t)
+	kc := newFakeKeycloak(t)
+	vz := createTestRealmsVZ()
+	realm := &vz.Spec.Components.Keycloak.Realms[0]
+	realm.Groups = append(realm.Groups, vzapi.KeycloakRealmGroup{Name: "app-admins"})
+	second := realm.Users[0]
+	second.Username = "other-admin"
+	second.Groups = []string{"/app-admins"}
+	realm.Users[0].Groups = []string{"/app-users/app-admins"}
+	realm.Users = append(realm.Users, second)
+	ctx, client := newRealmsTestContext(t, vz, createTestRealmsSecrets()...)
+
+	_, err := configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	keycloakRealm := kc.realms[testAppsRealm]
+	a.NotNil(keycloakRealm.findGroupByPath("/app-admins"))
+	a.NotNil(keycloakRealm.findGroupByPath("/app-users/app-admins"))
+	a.Equal([]string{"/app-users/app-admins"}, keycloakRealm.findUser("app-admin").groups)
+	a.Equal([]string{"/app-admins"}, keycloakRealm.findUser("other-admin").groups)
+
+	// Nothing changes on the next reconcile
+	kc.requests = nil
+	_, err = configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	for _, req := range kc.requests {
+		a.True(strings.HasPrefix(req, http.MethodGet) || req == http.MethodPost+" "+keycloakTokenPath, "unexpected request %s", req)
+	}
+}
+
+// TestConfigureDeclaredRealmsPasswordChange tests updating the password of a declared user
+// GIVEN a declared user whose password Secret has been updated
+// WHEN configureDeclaredRealms is called
+// THEN the password of the user is reset without reporting drift
+func TestConfigureDeclaredRealmsPasswordChange(t *testing.T) {
+	a := assert.New(t)
+	kc := newFakeKeycloak(t)
+	ctx, client := newRealmsTestContext(t, createTestRealmsVZ(), createTestRealmsSecrets()...)
+	_, err := configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	user := kc.realms[testAppsRealm].findUser("app-admin")
+	version := user.Attributes[passwordVersionAttribute]
+
+	secret := &v1.Secret{}
+	a.NoError(ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: testVZNamespace, Name: "app-admin"}, secret))
+	secret.Data["password"] = []byte("new-password")
+	a.NoError(ctx.Client().Update(context.TODO(), secret))
+
+	_, err = configureDeclaredRealms(ctx, client)
+	a.NoError(err)
+	a.Equal("new-password", user.password)
+	a.NotEqual(version, user.Attributes[passwordVersionAttribute])
+}
+
+// TestConfigureDeclaredRealmsErrors tests declarations that cannot be configured
+// GIVEN declared objects that reference missing groups or Secrets
+// WHEN configureDeclaredRealms is called
+// THEN an error is returned
+func TestConfigureDeclaredRealmsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(realm *vzapi.KeycloakRealm)
+		objects []client.Object
+		err     string
+	}{
+		{
+			name: "missing parent group",
+			update: func(realm *vzapi.KeycloakRealm) {
+				realm.Groups[0].Parent = "missing"
+			},
+			objects: createTestRealmsSecrets(),
+			err:     "the parent group missing does not exist",
+		},
+		{
+			name: "missing user group",
+			update: func(realm *vzapi.KeycloakRealm) {
+				realm.Users[0].Groups = []string{"missing"}
+			},
+			objects: createTestRealmsSecrets(),
+			err:     "failed adding user app-admin to group /missing in realm apps, the group does not exist",
+		},
+		{
+			name:   "missing password secret",
+			update: func(realm *vzapi.KeycloakRealm) {},
+			err:    "\"app-admin\" not found",
+		},
+		{
+			name: "missing password key",
+			update: func(realm *vzapi.KeycloakRealm) {
+				realm.Users[0].PasswordSecretRef.Key = "missing"
+			},
+			objects: createTestRealmsSecrets(),
+			err:     "key missing is empty in secret default/app-admin",
+		},
+		{
+			name: "missing client secret",
+			update: func(realm *vzapi.KeycloakRealm) {
+				realm.Clients[1].SecretRef.Name = "missing"
+			},
+			objects: createTestRealmsSecrets(),
+			err:     "\"missing\" not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newFakeKeycloak(t)
+			vz := createTestRealmsVZ()
+			tt.update(&vz.Spec.Components.Keycloak.Realms[0])
+			ctx, client := newRealmsTestContext(t, vz, tt.objects...)
+
+			_, err := configureDeclaredRealms(ctx, client)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+// TestSameStrings tests comparing lists of strings
+// GIVEN two lists of strings
+// WHEN sameStrings is called
+// THEN true is returned only if the lists contain the same strings in any order
+func TestSameStrings(t *testing.T) {
+	assert.True(t, sameStrings(nil, []string{}))
+	assert.True(t, sameStrings([]string{"a", "b"}, []string{"b", "a"}))
+	assert.False(t, sameStrings([]string{"a"}, []string{"a", "b"}))
+	assert.False(t, sameStrings([]string{"a", "a"}, []string{"a", "b"}))
+}
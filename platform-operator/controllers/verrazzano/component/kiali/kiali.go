@@ -112,7 +112,7 @@ func createOrUpdateKialiIngress(ctx spi.ComponentContext, namespace string) erro
 			ingress.Annotations = make(map[string]string)
 		}
 		ingress.Annotations["kubernetes.io/tls-acme"] = "true"
-		ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = "6M"
+		ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = vzconfig.GetSystemIngressBodySize(ctx.EffectiveCR())
 		ingress.Annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/$2"
 		ingress.Annotations["nginx.ingress.kubernetes.io/secure-backends"] = "false"
 		ingress.Annotations["nginx.ingress.kubernetes.io/backend-protocol"] = "HTTP"
@@ -207,3 +207,18 @@ func GetOverrides(object runtime.Object) interface{} {
 	}
 	return []v1alpha1.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *v1alpha1.WorkloadSpec {
+	if effectiveCR, ok := object.(*v1alpha1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Kiali != nil {
+			return effectiveCR.Spec.Components.Kiali.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Kiali != nil {
+			return (*v1alpha1.WorkloadSpec)(effectiveCR.Spec.Components.Kiali.Workload)
+		}
+	}
+	return nil
+}
@@ -49,6 +49,24 @@ var certificates = []types.NamespacedName{
 	{Name: "system-tls-kiali", Namespace: ComponentNamespace},
 }
 
+// networkPolicyRules are the network peers of Kiali outside of the verrazzano-system namespace, Kiali queries
+// Prometheus, Jaeger and istiod
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app": "kiali"},
+		Ingress:   []spi.NetworkPeer{{Namespace: constants.IstioSystemNamespace}},
+		Egress: []spi.NetworkPeer{
+			{Namespace: constants.VerrazzanoMonitoringNamespace, Ports: []int32{9090, 16686}},
+			{Namespace: constants.IstioSystemNamespace},
+		},
+	},
+}
+
+// podDisruptionBudgets are the replicated Kiali pods
+var podDisruptionBudgets = []spi.PodDisruptionBudget{
+	{Name: "kiali", PodLabels: map[string]string{"app": "kiali"}},
+}
+
 func NewComponent() spi.Component {
 	return kialiComponent{
 		helm.HelmComponent{
@@ -56,6 +74,8 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
+			PodDisruptionBudgets:      podDisruptionBudgets,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -72,6 +92,14 @@ func NewComponent() spi.Component {
 				},
 			},
 			GetInstallOverridesFunc: GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "deployment.resources",
+				ReplicasKey:          "deployment.replicas",
+				NodeSelectorKey:      "deployment.node_selector",
+				TolerationsKey:       "deployment.tolerations",
+				PriorityClassNameKey: "deployment.priority_class_name",
+			},
 		},
 	}
 }
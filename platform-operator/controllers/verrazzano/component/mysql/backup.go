@@ -0,0 +1,633 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// backupCronJobName is the name of the CronJob of the scheduled backups, the backups are named after their jobs
+	backupCronJobName = "keycloak-mysql-backup"
+	// preUpgradeJobPrefix prefixes the name of the job backing up the database before an upgrade to a version
+	preUpgradeJobPrefix = "keycloak-mysql-pre-upgrade-"
+	// restoreJobPrefix prefixes the names of the restore jobs
+	restoreJobPrefix = "keycloak-mysql-restore-"
+	// backupCredentialsSecretName is the name of the copy of the S3 credentials made for the backup and restore jobs
+	backupCredentialsSecretName = "keycloak-mysql-backup-credentials"
+
+	// jobAppLabel is the app label of the pods of the backup and the restore jobs
+	jobAppLabel = "keycloak-mysql-backup"
+	// backupLabel and restoreLabel identify the backup and the restore jobs
+	backupLabel  = "verrazzano.io/mysql-backup"
+	restoreLabel = "verrazzano.io/mysql-restore"
+
+	backupVolumeName      = "backups"
+	backupMountPath       = "/backups"
+	credentialsVolumeName = "credentials"
+	credentialsMountPath  = "/etc/mysql-backup"
+
+	// keycloakStatefulSetName is the StatefulSet restarted once the database is restored
+	keycloakStatefulSetName = "keycloak"
+
+	defaultRetentionCount = 7
+
+	// backupRefreshInterval is how often the status of the last backup is refreshed, restoreRefreshInterval how often
+	// the progress of a restore is checked
+	backupRefreshInterval  = 5 * time.Minute
+	restoreRefreshInterval = 30 * time.Second
+)
+
+// States of the backups and the restores
+const (
+	stateInProgress = "InProgress"
+	stateCompleted  = "Completed"
+	stateFailed     = "Failed"
+)
+
+// quitSidecar stops the Istio sidecar of a job pod once the script exits, otherwise the job never completes
+const quitSidecar = `quit_sidecar() {
+  exec 3<>/dev/tcp/127.0.0.1/15020 && printf 'POST /quitquitquit HTTP/1.1\r\nHost: localhost\r\nContent-Length: 0\r\n\r\n' >&3
+}
+trap 'rc=$?; quit_sidecar 2>/dev/null; exit $rc' EXIT
+`
+
+// backupScript dumps the keycloak schema with the dump utility of MySQL Shell, which records the binary log position
+// of the dump in its metadata, then deletes the oldest backups of the persistent volume claim
+const backupScript = `set -o errexit -o pipefail
+` + quitSidecar + `
+TARGET="${BACKUP_NAME}"
+if [ -n "${BACKUP_LOCATION}" ]; then
+  TARGET="${BACKUP_LOCATION}/${BACKUP_NAME}"
+fi
+mysqlsh --uri "root@${MYSQL_HOST}:3306" --password="${MYSQL_ROOT_PASSWORD}" --js \
+  -e "util.dumpSchemas(['keycloak'], '${TARGET}', ${DUMP_OPTIONS})"
+if [ -n "${RETENTION_COUNT}" ]; then
+  ls -1dt "${BACKUP_LOCATION}"/*/ | tail -n +$((RETENTION_COUNT + 1)) | xargs -r rm -rf
+fi
+`
+
+// restoreScript checks that the backup can be loaded and reads its binary log position, replaces the keycloak schema
+// with the backup, then replays the binary log from the position of the backup up to the point in time, if any.  The
+// restore itself is not written to the binary log, so that it is not replayed by a later point in time recovery.
+const restoreScript = `set -o errexit -o pipefail
+` + quitSidecar + `
+MYSQL=(mysql --host="${MYSQL_HOST}" --user=root --password="${MYSQL_ROOT_PASSWORD}")
+MYSQLSH=(mysqlsh --uri "root@${MYSQL_HOST}:3306" --password="${MYSQL_ROOT_PASSWORD}" --js)
+"${MYSQLSH[@]}" -e "util.loadDump('${BACKUP_SOURCE}', Object.assign(${LOAD_OPTIONS}, {dryRun: true, showMetadata: true, ignoreExistingObjects: true}))" | tee /tmp/metadata
+BINLOG_FILE=$(sed -n 's/^ *Binlog_file: *//p' /tmp/metadata)
+BINLOG_POSITION=$(sed -n 's/^ *Binlog_position: *//p' /tmp/metadata)
+if [ -n "${STOP_DATETIME}" ] && [ -z "${BINLOG_FILE}" ]; then
+  echo "The backup ${BACKUP_SOURCE} does not record a binary log position"
+  exit 1
+fi
+"${MYSQL[@]}" -e "SET sql_log_bin = 0; DROP DATABASE IF EXISTS keycloak; SET GLOBAL local_infile = ON"
+"${MYSQLSH[@]}" -e "util.loadDump('${BACKUP_SOURCE}', Object.assign(${LOAD_OPTIONS}, {skipBinlog: true, resetProgress: true}))"
+"${MYSQL[@]}" -e "SET GLOBAL local_infile = OFF"
+if [ -n "${STOP_DATETIME}" ]; then
+  { echo "SET sql_log_bin = 0;"
+    mysqlbinlog --read-from-remote-server --host="${MYSQL_HOST}" --user=root --password="${MYSQL_ROOT_PASSWORD}" \
+      --to-last-log --start-position="${BINLOG_POSITION}" --stop-datetime="${STOP_DATETIME}" --database=keycloak "${BINLOG_FILE}"
+  } | "${MYSQL[@]}"
+fi
+`
+
+// invalidNameChars are the characters of a version that are not allowed in a job name
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ReconcileBackups creates or updates the CronJob of the scheduled backups of the Keycloak database, or deletes it when
+// the backups are removed, and starts the restore requested in the Verrazzano resource.  A restore suspends the
+// scheduled backups until it completes, Keycloak is restarted once the database is restored.  It returns the status of
+// the last backup and of the last restore, and how long to wait before refreshing it; zero means there is nothing to
+// refresh.
+func ReconcileBackups(ctx spi.ComponentContext) (*vzapi.MySQLStatus, time.Duration, error) {
+	spec := getBackupSpec(ctx.EffectiveCR())
+	if spec == nil {
+		if err := deleteBackupObjects(ctx); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, nil
+	}
+	if err := createOrUpdateBackupCredentials(ctx, spec); err != nil {
+		return nil, 0, err
+	}
+
+	status := &vzapi.MySQLStatus{}
+	var err error
+	if status.Restore, err = reconcileRestore(ctx, spec); err != nil {
+		return nil, 0, err
+	}
+	restoring := status.Restore != nil && status.Restore.State == stateInProgress
+	if err := createOrUpdateBackupCronJob(ctx, spec, restoring); err != nil {
+		return nil, 0, err
+	}
+	if status.LastBackup, err = getLastBackupStatus(ctx); err != nil {
+		return nil, 0, err
+	}
+	if restoring {
+		return status, restoreRefreshInterval, nil
+	}
+	return status, backupRefreshInterval, nil
+}
+
+// backupBeforeUpgrade backs up the database before MySQL is upgraded, once for each version, unless the backups are
+// not configured or the pre-upgrade backup is disabled.  The upgrade waits for the backup, and does not proceed if
+// the backup fails.
+func backupBeforeUpgrade(ctx spi.ComponentContext) error {
+	spec := getBackupSpec(ctx.EffectiveCR())
+	if spec == nil || (spec.PreUpgrade != nil && !*spec.PreUpgrade) || ctx.IsDryRun() {
+		return nil
+	}
+	bomFile, err := bom.NewBom(config.GetDefaultBOMFilePath())
+	if err != nil {
+		return err
+	}
+	name := preUpgradeJobPrefix + invalidNameChars.ReplaceAllString(strings.ToLower(bomFile.GetVersion()), "-")
+	job := &batchv1.Job{}
+	err = ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: name}, job)
+	if errors.IsNotFound(err) {
+		if err := createOrUpdateBackupCredentials(ctx, spec); err != nil {
+			return err
+		}
+		jobSpec, err := newBackupJobSpec(ctx, spec)
+		if err != nil {
+			return err
+		}
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: name, Labels: map[string]string{backupLabel: "true"}},
+			Spec:       jobSpec,
+		}
+		ctx.Log().Oncef("Component %s is backing up the Keycloak database before the upgrade, job %s", ComponentName, name)
+		if err := ctx.Client().Create(context.TODO(), job); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed creating the backup job %s/%s: %v", ComponentNamespace, name, err)
+		}
+		return ctrlerrors.RetryableError{Source: ComponentName}
+	}
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the backup job %s/%s: %v", ComponentNamespace, name, err)
+	}
+	switch state, message := getJobState(job); state {
+	case stateCompleted:
+		return nil
+	case stateFailed:
+		return ctx.Log().ErrorfThrottledNewErr("Failed backing up the Keycloak database before the upgrade, job %s/%s: %s.  Delete the job to "+
+			"retry, or disable the pre-upgrade backup to upgrade without a backup", ComponentNamespace, name, message)
+	}
+	ctx.Log().Progressf("Component %s is waiting for the backup job %s/%s to complete", ComponentName, ComponentNamespace, name)
+	return ctrlerrors.RetryableError{Source: ComponentName}
+}
+
+// getBackupSpec returns the backups of the Keycloak database, nil if Keycloak is disabled or its database is not
+// backed up
+func getBackupSpec(cr *vzapi.Verrazzano) *vzapi.MySQLBackupSpec {
+	if !vzconfig.IsKeycloakEnabled(cr) || cr.Spec.Components.Keycloak == nil {
+		return nil
+	}
+	return cr.Spec.Components.Keycloak.MySQL.Backup
+}
+
+// reconcileRestore starts a restore when the restore settings differ from those of the last restore, and follows the
+// progress of the restore that is running.  It returns the status of the last restore.
+func reconcileRestore(ctx spi.ComponentContext, spec *vzapi.MySQLBackupSpec) (*vzapi.MySQLRestoreStatus, error) {
+	var last *vzapi.MySQLRestoreStatus
+	if ctx.ActualCR().Status.MySQL != nil && ctx.ActualCR().Status.MySQL.Restore != nil {
+		last = ctx.ActualCR().Status.MySQL.Restore.DeepCopy()
+	}
+	restore := ctx.EffectiveCR().Spec.Components.Keycloak.MySQL.Restore
+	if restore != nil && (last == nil || last.BackupName != restore.BackupName || last.PointInTime != restore.PointInTime) {
+		return startRestore(ctx, spec, restore)
+	}
+	if last == nil || last.State != stateInProgress {
+		return last, nil
+	}
+
+	job, err := getLastJob(ctx, restoreLabel)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		last.State = stateFailed
+		last.Message = "The restore job was deleted before it completed"
+		return last, nil
+	}
+	state, message := getJobState(job)
+	switch state {
+	case stateCompleted:
+		if err := restartKeycloak(ctx); err != nil {
+			return nil, err
+		}
+		ctx.Log().Infof("Component %s restored the Keycloak database from backup %s", ComponentName, last.BackupName)
+	case stateFailed:
+		ctx.Log().Errorf("Component %s failed restoring the Keycloak database from backup %s: %s", ComponentName, last.BackupName, message)
+	}
+	last.State = state
+	last.Message = message
+	if job.Status.CompletionTime != nil {
+		last.CompletionTime = job.Status.CompletionTime.UTC().Format(time.RFC3339)
+	}
+	return last, nil
+}
+
+// startRestore deletes the previous restore jobs and creates the job of a new restore
+func startRestore(ctx spi.ComponentContext, spec *vzapi.MySQLBackupSpec, restore *vzapi.MySQLRestoreSpec) (*vzapi.MySQLRestoreStatus, error) {
+	jobs := &batchv1.JobList{}
+	if err := ctx.Client().List(context.TODO(), jobs, client.InNamespace(ComponentNamespace), client.HasLabels{restoreLabel}); err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed listing the restore jobs: %v", err)
+	}
+	for i := range jobs.Items {
+		if err := ctx.Client().Delete(context.TODO(), &jobs.Items[i], client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return nil, ctx.Log().ErrorfNewErr("Failed deleting the restore job %s/%s: %v", ComponentNamespace, jobs.Items[i].Name, err)
+		}
+	}
+
+	jobSpec, err := newRestoreJobSpec(ctx, spec, restore)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ComponentNamespace,
+			Name:      fmt.Sprintf("%s%d", restoreJobPrefix, now.Unix()),
+			Labels:    map[string]string{restoreLabel: "true"},
+		},
+		Spec: jobSpec,
+	}
+	ctx.Log().Infof("Component %s is restoring the Keycloak database from backup %s, job %s", ComponentName, restore.BackupName, job.Name)
+	if err := ctx.Client().Create(context.TODO(), job); err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed creating the restore job %s/%s: %v", ComponentNamespace, job.Name, err)
+	}
+	return &vzapi.MySQLRestoreStatus{
+		BackupName:  restore.BackupName,
+		PointInTime: restore.PointInTime,
+		State:       stateInProgress,
+		StartTime:   now.Format(time.RFC3339),
+	}, nil
+}
+
+// restartKeycloak restarts the Keycloak pods, so that they do not serve the data cached before the restore
+func restartKeycloak(ctx spi.ComponentContext) error {
+	sts := &appsv1.StatefulSet{}
+	nsn := types.NamespacedName{Namespace: ComponentNamespace, Name: keycloakStatefulSetName}
+	if err := ctx.Client().Get(context.TODO(), nsn, sts); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return ctx.Log().ErrorfNewErr("Failed getting the StatefulSet %s: %v", nsn, err)
+	}
+	if sts.Spec.Template.ObjectMeta.Annotations == nil {
+		sts.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	sts.Spec.Template.ObjectMeta.Annotations[vzconst.VerrazzanoRestartAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := ctx.Client().Update(context.TODO(), sts); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed restarting the StatefulSet %s: %v", nsn, err)
+	}
+	return nil
+}
+
+// createOrUpdateBackupCronJob creates or updates the CronJob of the scheduled backups, suspended while a restore runs
+func createOrUpdateBackupCronJob(ctx spi.ComponentContext, spec *vzapi.MySQLBackupSpec, suspend bool) error {
+	jobSpec, err := newBackupJobSpec(ctx, spec)
+	if err != nil {
+		return err
+	}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: backupCronJobName}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), cronJob, func() error {
+		successfulJobs := int32(3)
+		failedJobs := int32(1)
+		cronJob.Spec.Schedule = spec.Schedule
+		cronJob.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+		cronJob.Spec.SuccessfulJobsHistoryLimit = &successfulJobs
+		cronJob.Spec.FailedJobsHistoryLimit = &failedJobs
+		cronJob.Spec.Suspend = &suspend
+		cronJob.Spec.JobTemplate.ObjectMeta.Labels = map[string]string{backupLabel: "true"}
+		cronJob.Spec.JobTemplate.Spec = jobSpec
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating the CronJob %s/%s: %v", ComponentNamespace, backupCronJobName, err)
+	}
+	return nil
+}
+
+// createOrUpdateBackupCredentials copies the S3 credentials of the backups to the keycloak namespace, in the AWS
+// credentials and config file formats read by MySQL Shell
+func createOrUpdateBackupCredentials(ctx spi.ComponentContext, spec *vzapi.MySQLBackupSpec) error {
+	if spec.S3 == nil {
+		return nil
+	}
+	source := &corev1.Secret{}
+	nsn := types.NamespacedName{Namespace: ctx.EffectiveCR().Namespace, Name: spec.S3.CredentialsSecret}
+	if err := ctx.Client().Get(context.TODO(), nsn, source); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the backup credentials secret %s: %v", nsn, err)
+	}
+	accessKey := source.Data[constants.ObjectStoreAccessKey]
+	secretKey := source.Data[constants.ObjectStoreAccessSecretKey]
+	if len(accessKey) == 0 || len(secretKey) == 0 {
+		return ctx.Log().ErrorfNewErr("Failed, the backup credentials secret %s must contain the keys %s and %s",
+			nsn, constants.ObjectStoreAccessKey, constants.ObjectStoreAccessSecretKey)
+	}
+	region := spec.S3.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: backupCredentialsSecretName}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), secret, func() error {
+		secret.Data = map[string][]byte{
+			"credentials": []byte(fmt.Sprintf("[default]\naws_access_key_id=%s\naws_secret_access_key=%s\n", accessKey, secretKey)),
+			"config":      []byte(fmt.Sprintf("[default]\nregion=%s\n", region)),
+		}
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating secret %s/%s: %v", ComponentNamespace, backupCredentialsSecretName, err)
+	}
+	return nil
+}
+
+// deleteBackupObjects deletes the CronJob of the scheduled backups and the copy of the S3 credentials, the backups are
+// kept
+func deleteBackupObjects(ctx spi.ComponentContext) error {
+	objects := []client.Object{
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: backupCronJobName}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: backupCredentialsSecretName}},
+	}
+	for _, object := range objects {
+		if err := ctx.Client().Delete(context.TODO(), object, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting %s/%s: %v", ComponentNamespace, object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// getLastBackupStatus returns the status of the last scheduled or pre-upgrade backup, nil if there is none
+func getLastBackupStatus(ctx spi.ComponentContext) (*vzapi.MySQLBackupStatus, error) {
+	job, err := getLastJob(ctx, backupLabel)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	status := &vzapi.MySQLBackupStatus{Name: job.Name}
+	status.State, status.Message = getJobState(job)
+	if job.Status.StartTime != nil {
+		status.StartTime = job.Status.StartTime.UTC().Format(time.RFC3339)
+	}
+	if job.Status.CompletionTime != nil {
+		status.CompletionTime = job.Status.CompletionTime.UTC().Format(time.RFC3339)
+	}
+	return status, nil
+}
+
+// getLastJob returns the most recent job with the label, nil if there is none
+func getLastJob(ctx spi.ComponentContext, label string) (*batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	if err := ctx.Client().List(context.TODO(), jobs, client.InNamespace(ComponentNamespace), client.HasLabels{label}); err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed listing the jobs of namespace %s: %v", ComponentNamespace, err)
+	}
+	var last *batchv1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if last == nil || job.CreationTimestamp.After(last.CreationTimestamp.Time) {
+			last = job
+		}
+	}
+	return last, nil
+}
+
+// getJobState returns the state of a backup or restore job, and the reason of its failure
+func getJobState(job *batchv1.Job) (string, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return stateCompleted, ""
+		case batchv1.JobFailed:
+			return stateFailed, condition.Message
+		}
+	}
+	return stateInProgress, ""
+}
+
+// newBackupJobSpec returns the spec of the backup jobs, the backups are named after their jobs
+func newBackupJobSpec(ctx spi.ComponentContext, spec *vzapi.MySQLBackupSpec) (batchv1.JobSpec, error) {
+	options, err := getStorageOptions(spec)
+	if err != nil {
+		return batchv1.JobSpec{}, err
+	}
+	env := []corev1.EnvVar{
+		{Name: "BACKUP_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['job-name']"}}},
+		{Name: "BACKUP_LOCATION", Value: getBackupLocation(spec)},
+		{Name: "DUMP_OPTIONS", Value: options},
+	}
+	if spec.S3 == nil {
+		retentionCount := int32(defaultRetentionCount)
+		if spec.RetentionCount != nil {
+			retentionCount = *spec.RetentionCount
+		}
+		env = append(env, corev1.EnvVar{Name: "RETENTION_COUNT", Value: fmt.Sprintf("%d", retentionCount)})
+	}
+	return newJobSpec(ctx, spec, backupScript, env)
+}
+
+// newRestoreJobSpec returns the spec of the job restoring a backup
+func newRestoreJobSpec(ctx spi.ComponentContext, spec *vzapi.MySQLBackupSpec, restore *vzapi.MySQLRestoreSpec) (batchv1.JobSpec, error) {
+	options, err := getStorageOptions(spec)
+	if err != nil {
+		return batchv1.JobSpec{}, err
+	}
+	source := restore.BackupName
+	if location := getBackupLocation(spec); location != "" {
+		source = location + "/" + restore.BackupName
+	}
+	// mysqlbinlog reads the stop time in the time zone of the job, which is UTC
+	var stopDatetime string
+	if restore.PointInTime != "" {
+		pointInTime, err := time.Parse(time.RFC3339, restore.PointInTime)
+		if err != nil {
+			return batchv1.JobSpec{}, ctx.Log().ErrorfNewErr("Failed, the restore point in time %s is not in RFC3339 format: %v", restore.PointInTime, err)
+		}
+		stopDatetime = pointInTime.UTC().Format("2006-01-02 15:04:05")
+	}
+	env := []corev1.EnvVar{
+		{Name: "BACKUP_SOURCE", Value: source},
+		{Name: "LOAD_OPTIONS", Value: options},
+		{Name: "STOP_DATETIME", Value: stopDatetime},
+		{Name: "TZ", Value: "UTC"},
+	}
+	jobSpec, err := newJobSpec(ctx, spec, restoreScript, env)
+	if err != nil {
+		return jobSpec, err
+	}
+	// A failed restore is not retried, the keycloak schema may have been partially replaced
+	backoffLimit := int32(0)
+	jobSpec.BackoffLimit = &backoffLimit
+	return jobSpec, nil
+}
+
+// newJobSpec returns the spec of a job running a MySQL Shell script against the Keycloak database, with the image of
+// the MySQL server.  The Istio sidecar is started before the script, which connects to MySQL through the mesh.
+func newJobSpec(ctx spi.ComponentContext, spec *vzapi.MySQLBackupSpec, script string, env []corev1.EnvVar) (batchv1.JobSpec, error) {
+	image, err := getMySQLImage()
+	if err != nil {
+		return batchv1.JobSpec{}, ctx.Log().ErrorfNewErr("Failed getting the MySQL image from the BOM: %v", err)
+	}
+	env = append([]corev1.EnvVar{
+		{Name: "MYSQL_HOST", Value: fmt.Sprintf("%s.%s.svc.cluster.local", ComponentName, ComponentNamespace)},
+		{Name: "MYSQL_ROOT_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  mySQLRootKey,
+		}}},
+	}, env...)
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers: []corev1.Container{{
+			Name:            "mysqlsh",
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"bash", "-c", script},
+			Env:             env,
+		}},
+	}
+	container := &podSpec.Containers[0]
+	if spec.S3 != nil {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         credentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: backupCredentialsSecretName}},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: credentialsVolumeName, MountPath: credentialsMountPath, ReadOnly: true})
+	} else {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         backupVolumeName,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: spec.PersistentVolumeClaim}},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: backupVolumeName, MountPath: backupMountPath})
+	}
+
+	pullSecret := &corev1.Secret{}
+	err = ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: constants.GlobalImagePullSecName}, pullSecret)
+	if err == nil {
+		podSpec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: constants.GlobalImagePullSecName}}
+	} else if !errors.IsNotFound(err) {
+		return batchv1.JobSpec{}, ctx.Log().ErrorfNewErr("Failed getting the image pull secret %s/%s: %v", ComponentNamespace, constants.GlobalImagePullSecName, err)
+	}
+
+	backoffLimit := int32(1)
+	return batchv1.JobSpec{
+		BackoffLimit: &backoffLimit,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"app": jobAppLabel},
+				Annotations: map[string]string{"proxy.istio.io/config": `{ "holdApplicationUntilProxyStarts": true }`},
+			},
+			Spec: podSpec,
+		},
+	}, nil
+}
+
+// getStorageOptions returns the options of the MySQL Shell dump and load utilities for the storage of the backups, in
+// the JSON format of the JavaScript mode
+func getStorageOptions(spec *vzapi.MySQLBackupSpec) (string, error) {
+	options := map[string]interface{}{}
+	if spec.S3 != nil {
+		options["s3BucketName"] = spec.S3.Bucket
+		options["s3EndpointOverride"] = spec.S3.Endpoint
+		options["s3CredentialsFile"] = path.Join(credentialsMountPath, "credentials")
+		options["s3ConfigFile"] = path.Join(credentialsMountPath, "config")
+	}
+	data, err := json.Marshal(options)
+	return string(data), err
+}
+
+// getBackupLocation returns the directory of the backups, the mount path of the persistent volume claim or the prefix
+// of the S3 bucket
+func getBackupLocation(spec *vzapi.MySQLBackupSpec) string {
+	if spec.S3 != nil {
+		return strings.Trim(spec.S3.Prefix, "/")
+	}
+	return backupMountPath
+}
+
+// getMySQLImage returns the MySQL server image of the BOM, which includes MySQL Shell
+func getMySQLImage() (string, error) {
+	bomFile, err := bom.NewBom(config.GetDefaultBOMFilePath())
+	if err != nil {
+		return "", err
+	}
+	images, err := bomFile.GetImageNameList(ComponentName)
+	if err != nil {
+		return "", err
+	}
+	if len(images) == 0 {
+		return "", fmt.Errorf("no image found for subcomponent %s", ComponentName)
+	}
+	return images[0], nil
+}
+
+// validateBackup checks that the backups of the Keycloak database, if specified, have a valid schedule and a single
+// storage location, and that a restore names a backup and a valid point in time
+func validateBackup(vz *v1beta1.Verrazzano) error {
+	if vz.Spec.Components.Keycloak == nil {
+		return nil
+	}
+	backup := vz.Spec.Components.Keycloak.MySQL.Backup
+	restore := vz.Spec.Components.Keycloak.MySQL.Restore
+	if backup == nil {
+		if restore != nil {
+			return fmt.Errorf("The %s backups must be configured to restore a backup", ComponentJSONName)
+		}
+		return nil
+	}
+	if _, err := cron.ParseStandard(backup.Schedule); err != nil {
+		return fmt.Errorf("The %s backup schedule \"%s\" is invalid: %v", ComponentJSONName, backup.Schedule, err)
+	}
+	if (backup.PersistentVolumeClaim == "") == (backup.S3 == nil) {
+		return fmt.Errorf("The %s backups must specify exactly one of a persistent volume claim and an S3 storage", ComponentJSONName)
+	}
+	if s3 := backup.S3; s3 != nil {
+		if s3.Bucket == "" || s3.CredentialsSecret == "" {
+			return fmt.Errorf("The %s backup storage must specify a bucket and a credentials secret", ComponentJSONName)
+		}
+		if endpoint, err := url.Parse(s3.Endpoint); err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("The %s backup storage endpoint \"%s\" must be a http or https URL", ComponentJSONName, s3.Endpoint)
+		}
+	}
+	if restore == nil {
+		return nil
+	}
+	if restore.BackupName == "" {
+		return fmt.Errorf("The %s restore must specify the name of a backup", ComponentJSONName)
+	}
+	if restore.PointInTime != "" {
+		if _, err := time.Parse(time.RFC3339, restore.PointInTime); err != nil {
+			return fmt.Errorf("The %s restore point in time \"%s\" is not in RFC3339 format", ComponentJSONName, restore.PointInTime)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testBackupName = "keycloak-mysql-backup-27769080"
+
+func newBackupTestVZ(backup *vzapi.MySQLBackupSpec, restore *vzapi.MySQLRestoreSpec) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Keycloak: &vzapi.KeycloakComponent{
+					MySQL: vzapi.MySQLComponent{Backup: backup, Restore: restore},
+				},
+			},
+		},
+	}
+}
+
+func newS3BackupSpec() *vzapi.MySQLBackupSpec {
+	return &vzapi.MySQLBackupSpec{
+		Schedule: "0 2 * * *",
+		S3: &vzapi.BackupStorage{
+			Endpoint:          "http://minio.minio:9000",
+			Bucket:            "verrazzano",
+			Prefix:            "/keycloak/",
+			CredentialsSecret: "backup-credentials",
+		},
+	}
+}
+
+func newCompletedJob(name string, label string, created time.Time) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: name, Labels: map[string]string{label: "true"},
+			CreationTimestamp: metav1.NewTime(created)},
+		Status: batchv1.JobStatus{
+			StartTime:      &metav1.Time{Time: created},
+			CompletionTime: &metav1.Time{Time: created.Add(time.Minute)},
+			Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func newBackupTestClient(objects ...client.Object) client.Client {
+	objects = append(objects, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup-credentials"},
+		Data: map[string][]byte{
+			constants.ObjectStoreAccessKey:       []byte("access"),
+			constants.ObjectStoreAccessSecretKey: []byte("secret"),
+		},
+	})
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build()
+}
+
+// TestReconcileBackups tests the ReconcileBackups function
+// GIVEN a Verrazzano resource with scheduled backups of the Keycloak database to S3
+// WHEN ReconcileBackups is called
+// THEN the CronJob of the backups and the copy of the S3 credentials are created, and the last backup is reported
+func TestReconcileBackups(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	created := time.Date(2022, time.October, 19, 2, 0, 0, 0, time.UTC)
+	c := newBackupTestClient(
+		newCompletedJob("keycloak-mysql-backup-27767640", backupLabel, created.Add(-24*time.Hour)),
+		newCompletedJob(testBackupName, backupLabel, created),
+	)
+	ctx := spi.NewFakeContext(c, newBackupTestVZ(newS3BackupSpec(), nil), nil, false)
+
+	status, requeueAfter, err := ReconcileBackups(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, backupRefreshInterval, requeueAfter)
+	assert.Nil(t, status.Restore)
+	assert.Equal(t, &vzapi.MySQLBackupStatus{
+		Name:           testBackupName,
+		State:          stateCompleted,
+		StartTime:      "2022-10-19T02:00:00Z",
+		CompletionTime: "2022-10-19T02:01:00Z",
+	}, status.LastBackup)
+
+	cronJob := &batchv1.CronJob{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: backupCronJobName}, cronJob))
+	assert.Equal(t, "0 2 * * *", cronJob.Spec.Schedule)
+	assert.False(t, *cronJob.Spec.Suspend)
+	assert.Equal(t, batchv1.ForbidConcurrent, cronJob.Spec.ConcurrencyPolicy)
+	container := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "ghcr.io/verrazzano/mysql:8.0.20", container.Image)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "BACKUP_LOCATION", Value: "keycloak"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "DUMP_OPTIONS", Value: `{"s3BucketName":"verrazzano",` +
+		`"s3ConfigFile":"/etc/mysql-backup/config","s3CredentialsFile":"/etc/mysql-backup/credentials","s3EndpointOverride":"http://minio.minio:9000"}`})
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: backupCredentialsSecretName}, secret))
+	assert.Equal(t, "[default]\naws_access_key_id=access\naws_secret_access_key=secret\n", string(secret.Data["credentials"]))
+	assert.Equal(t, "[default]\nregion=us-east-1\n", string(secret.Data["config"]))
+}
+
+// TestReconcileBackupsRemoved tests the ReconcileBackups function
+// GIVEN a Verrazzano resource whose Keycloak database backups have been removed
+// WHEN ReconcileBackups is called
+// THEN the CronJob of the backups and the copy of the S3 credentials are deleted, and no status is returned
+func TestReconcileBackupsRemoved(t *testing.T) {
+	c := newBackupTestClient(
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: backupCronJobName}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: backupCredentialsSecretName}},
+	)
+	ctx := spi.NewFakeContext(c, newBackupTestVZ(nil, nil), nil, false)
+
+	status, requeueAfter, err := ReconcileBackups(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+	assert.Zero(t, requeueAfter)
+	cronJobs := &batchv1.CronJobList{}
+	assert.NoError(t, c.List(context.TODO(), cronJobs))
+	assert.Empty(t, cronJobs.Items)
+	secret := &corev1.Secret{}
+	assert.Error(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: backupCredentialsSecretName}, secret))
+}
+
+// TestReconcileBackupsStartRestore tests the ReconcileBackups function
+// GIVEN a Verrazzano resource requesting a point in time restore of the Keycloak database from a backup in a
+// persistent volume claim
+// WHEN ReconcileBackups is called
+// THEN the restore job is created, the scheduled backups are suspended and the restore is reported in progress
+func TestReconcileBackupsStartRestore(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	c := newBackupTestClient(newCompletedJob("keycloak-mysql-restore-1", restoreLabel, time.Now().Add(-time.Hour)))
+	backup := &vzapi.MySQLBackupSpec{Schedule: "0 2 * * *", PersistentVolumeClaim: "keycloak-backups"}
+	restore := &vzapi.MySQLRestoreSpec{BackupName: testBackupName, PointInTime: "2022-10-19T12:30:00+02:00"}
+	ctx := spi.NewFakeContext(c, newBackupTestVZ(backup, restore), nil, false)
+
+	status, requeueAfter, err := ReconcileBackups(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, restoreRefreshInterval, requeueAfter)
+	assert.Equal(t, testBackupName, status.Restore.BackupName)
+	assert.Equal(t, stateInProgress, status.Restore.State)
+
+	jobs := &batchv1.JobList{}
+	assert.NoError(t, c.List(context.TODO(), jobs, client.HasLabels{restoreLabel}))
+	assert.Len(t, jobs.Items, 1)
+	assert.NotEqual(t, "keycloak-mysql-restore-1", jobs.Items[0].Name)
+	assert.Zero(t, *jobs.Items[0].Spec.BackoffLimit)
+	podSpec := jobs.Items[0].Spec.Template.Spec
+	assert.Equal(t, "keycloak-backups", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "BACKUP_SOURCE", Value: "/backups/" + testBackupName})
+	assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "STOP_DATETIME", Value: "2022-10-19 10:30:00"})
+
+	cronJob := &batchv1.CronJob{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: backupCronJobName}, cronJob))
+	assert.True(t, *cronJob.Spec.Suspend)
+	assert.Contains(t, cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "RETENTION_COUNT", Value: "7"})
+}
+
+// TestReconcileBackupsRestoreCompleted tests the ReconcileBackups function
+// GIVEN a restore of the Keycloak database in progress whose job has completed
+// WHEN ReconcileBackups is called
+// THEN the restore is reported completed, Keycloak is restarted and the scheduled backups are resumed
+func TestReconcileBackupsRestoreCompleted(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	created := time.Date(2022, time.October, 20, 9, 12, 5, 0, time.UTC)
+	c := newBackupTestClient(
+		newCompletedJob("keycloak-mysql-restore-1666257125", restoreLabel, created),
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: keycloakStatefulSetName}},
+	)
+	restore := &vzapi.MySQLRestoreSpec{BackupName: testBackupName}
+	vz := newBackupTestVZ(newS3BackupSpec(), restore)
+	vz.Status.MySQL = &vzapi.MySQLStatus{
+		Restore: &vzapi.MySQLRestoreStatus{BackupName: testBackupName, State: stateInProgress, StartTime: "2022-10-20T09:12:05Z"},
+	}
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	status, requeueAfter, err := ReconcileBackups(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, backupRefreshInterval, requeueAfter)
+	assert.Equal(t, &vzapi.MySQLRestoreStatus{
+		BackupName:     testBackupName,
+		State:          stateCompleted,
+		StartTime:      "2022-10-20T09:12:05Z",
+		CompletionTime: "2022-10-20T09:13:05Z",
+	}, status.Restore)
+
+	sts := &appsv1.StatefulSet{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: keycloakStatefulSetName}, sts))
+	assert.NotEmpty(t, sts.Spec.Template.Annotations[vzconst.VerrazzanoRestartAnnotation])
+	cronJob := &batchv1.CronJob{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: backupCronJobName}, cronJob))
+	assert.False(t, *cronJob.Spec.Suspend)
+}
+
+// TestBackupBeforeUpgrade tests the backupBeforeUpgrade function
+// GIVEN a Verrazzano resource with backups of the Keycloak database
+// WHEN backupBeforeUpgrade is called
+// THEN the pre-upgrade backup job is created and the upgrade waits for it, proceeds once it completes, and is not
+// waited for when the pre-upgrade backup is disabled
+func TestBackupBeforeUpgrade(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	c := newBackupTestClient()
+	ctx := spi.NewFakeContext(c, newBackupTestVZ(newS3BackupSpec(), nil), nil, false)
+	err := backupBeforeUpgrade(ctx)
+	assert.IsType(t, ctrlerrors.RetryableError{}, err)
+
+	jobs := &batchv1.JobList{}
+	assert.NoError(t, c.List(context.TODO(), jobs, client.HasLabels{backupLabel}))
+	assert.Len(t, jobs.Items, 1)
+	assert.Regexp(t, "^"+preUpgradeJobPrefix+"[a-z0-9-]+$", jobs.Items[0].Name)
+
+	job := &jobs.Items[0]
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	assert.NoError(t, c.Status().Update(context.TODO(), job))
+	assert.NoError(t, backupBeforeUpgrade(ctx))
+
+	disabled := false
+	backup := newS3BackupSpec()
+	backup.PreUpgrade = &disabled
+	c = newBackupTestClient()
+	assert.NoError(t, backupBeforeUpgrade(spi.NewFakeContext(c, newBackupTestVZ(backup, nil), nil, false)))
+	assert.NoError(t, c.List(context.TODO(), jobs))
+	assert.Empty(t, jobs.Items)
+}
+
+// TestValidateBackup tests the validateBackup function
+// GIVEN Verrazzano resources with valid and invalid backups and restores of the Keycloak database
+// WHEN validateBackup is called
+// THEN an error is returned for the invalid settings
+func TestValidateBackup(t *testing.T) {
+	newVZ := func(backup *v1beta1.MySQLBackupSpec, restore *v1beta1.MySQLRestoreSpec) *v1beta1.Verrazzano {
+		return &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{
+			Keycloak: &v1beta1.KeycloakComponent{MySQL: v1beta1.MySQLComponent{Backup: backup, Restore: restore}},
+		}}}
+	}
+	s3 := &v1beta1.BackupStorage{Endpoint: "https://s3.us-east-1.amazonaws.com", Bucket: "verrazzano", CredentialsSecret: "backup-credentials"}
+	tests := []struct {
+		name    string
+		vz      *v1beta1.Verrazzano
+		wantErr bool
+	}{
+		{"no backups", &v1beta1.Verrazzano{}, false},
+		{"S3 backups", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "0 2 * * *", S3: s3}, nil), false},
+		{"point in time restore", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "0 2 * * *", PersistentVolumeClaim: "backups"},
+			&v1beta1.MySQLRestoreSpec{BackupName: testBackupName, PointInTime: "2022-10-19T10:30:00Z"}), false},
+		{"invalid schedule", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "daily", S3: s3}, nil), true},
+		{"no storage", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "0 2 * * *"}, nil), true},
+		{"two storages", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "0 2 * * *", S3: s3, PersistentVolumeClaim: "backups"}, nil), true},
+		{"invalid endpoint", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "0 2 * * *",
+			S3: &v1beta1.BackupStorage{Endpoint: "s3.us-east-1.amazonaws.com", Bucket: "verrazzano", CredentialsSecret: "backup-credentials"}}, nil), true},
+		{"restore without backups", newVZ(nil, &v1beta1.MySQLRestoreSpec{BackupName: testBackupName}), true},
+		{"restore without backup name", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "0 2 * * *", S3: s3}, &v1beta1.MySQLRestoreSpec{}), true},
+		{"invalid point in time", newVZ(&v1beta1.MySQLBackupSpec{Schedule: "0 2 * * *", S3: s3},
+			&v1beta1.MySQLRestoreSpec{BackupName: testBackupName, PointInTime: "2022-10-19 10:30"}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackup(tt.vz)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -38,6 +38,16 @@ type mysqlComponent struct {
 // Verify that mysqlComponent implements Component
 var _ spi.Component = mysqlComponent{}
 
+// podDisruptionBudgets are the MySQL pods of Keycloak
+var podDisruptionBudgets = []spi.PodDisruptionBudget{
+	{Name: ComponentName, PodLabels: map[string]string{"app": ComponentName}},
+}
+
+// networkPolicyRules let the backup and the restore jobs reach the S3 compatible storage of the backups
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{PodLabels: map[string]string{"app": jobAppLabel}, Egress: []spi.NetworkPeer{{}}},
+}
+
 // NewComponent returns a new MySQL component
 func NewComponent() spi.Component {
 	return mysqlComponent{
@@ -54,6 +64,15 @@ func NewComponent() spi.Component {
 			AppendOverridesFunc:       appendMySQLOverrides,
 			Dependencies:              []string{istio.ComponentName},
 			GetInstallOverridesFunc:   GetOverrides,
+			NetworkPolicyRules:        networkPolicyRules,
+			PodDisruptionBudgets:      podDisruptionBudgets,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
@@ -82,6 +101,31 @@ func (c mysqlComponent) PostInstall(ctx spi.ComponentContext) error {
 	return postInstall(ctx)
 }
 
+// PreUpgrade backs up the database before MySQL is upgraded
+func (c mysqlComponent) PreUpgrade(ctx spi.ComponentContext) error {
+	return backupBeforeUpgrade(ctx)
+}
+
+// ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
+func (c mysqlComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
+	convertedVZ := v1beta1.Verrazzano{}
+	if err := common.ConvertVerrazzanoCR(vz, &convertedVZ); err != nil {
+		return err
+	}
+	if err := validateBackup(&convertedVZ); err != nil {
+		return err
+	}
+	return c.HelmComponent.ValidateInstall(vz)
+}
+
+// ValidateInstallV1Beta1 checks if the specified Verrazzano CR is valid for this component to be installed
+func (c mysqlComponent) ValidateInstallV1Beta1(vz *v1beta1.Verrazzano) error {
+	if err := validateBackup(vz); err != nil {
+		return err
+	}
+	return c.HelmComponent.ValidateInstallV1Beta1(vz)
+}
+
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
 func (c mysqlComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazzano) error {
 	// Block all changes for now, particularly around storage changes
@@ -103,6 +147,9 @@ func (c mysqlComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazz
 	if err != nil {
 		return err
 	}
+	if err := validateBackup(&convertedNewVZ); err != nil {
+		return err
+	}
 	// Reject any persistence-specific changes via the mysqlInstallArgs settings
 	if err := validatePersistenceSpecificChanges(oldSetting, newSetting); err != nil {
 		return err
@@ -125,6 +172,9 @@ func (c mysqlComponent) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1be
 	if err != nil {
 		return err
 	}
+	if err := validateBackup(new); err != nil {
+		return err
+	}
 	// Reject any persistence-specific changes via the mysqlInstallArgs settings
 	if err := validatePersistenceSpecificChanges(oldSetting, newSetting); err != nil {
 		return err
@@ -11,6 +11,7 @@ import (
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	config "github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,21 @@ func getOverrides(object runtime.Object) interface{} {
 	return []vzapi.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.MySQLOperator != nil {
+			return effectiveCR.Spec.Components.MySQLOperator.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.MySQLOperator != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.MySQLOperator.Workload)
+		}
+	}
+	return nil
+}
+
 // AppendOverrides Build the set of MySQL operator overrides for the helm install
 func AppendOverrides(compContext spi.ComponentContext, _ string, _ string, _ string, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
 
@@ -85,6 +101,9 @@ func (c mysqlOperatorComponent) validateMySQLOperator(vz *installv1beta1.Verrazz
 		if err := vzapi.ValidateInstallOverridesV1Beta1(vz.Spec.Components.MySQLOperator.ValueOverrides); err != nil {
 			return err
 		}
+		if err := helm.ValidateWorkload(ComponentName, (*vzapi.WorkloadSpec)(vz.Spec.Components.MySQLOperator.Workload), *c.WorkloadValues); err != nil {
+			return err
+		}
 	}
 	// Must be enabled if Keycloak is enabled
 	if config.IsKeycloakEnabled(vz) {
@@ -64,6 +64,13 @@ func NewComponent() spi.Component {
 			Dependencies:              []string{},
 			GetInstallOverridesFunc:   getOverrides,
 			InstallBeforeUpgrade:      true,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
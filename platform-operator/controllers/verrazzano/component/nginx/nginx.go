@@ -62,12 +62,15 @@ func AppendOverrides(context spi.ComponentContext, _ string, _ string, _ string,
 
 	newKvs := append(kvs, bom.KeyValue{Key: "controller.service.type", Value: string(ingressType)})
 
-	if cr.Spec.Components.DNS != nil && cr.Spec.Components.DNS.OCI != nil {
+	if vzconfig.IsExternalDNSEnabled(cr) {
 		newKvs = append(newKvs, bom.KeyValue{Key: "controller.service.annotations.external-dns\\.alpha\\.kubernetes\\.io/ttl", Value: "60", SetString: true})
-		hostName := fmt.Sprintf("verrazzano-ingress.%s.%s", cr.Spec.EnvironmentName, cr.Spec.Components.DNS.OCI.DNSZoneName)
+		hostName := fmt.Sprintf("verrazzano-ingress.%s.%s", cr.Spec.EnvironmentName, vzconfig.GetExternalDNSZoneName(cr.Spec.Components.DNS))
 		newKvs = append(newKvs, bom.KeyValue{Key: "controller.service.annotations.external-dns\\.alpha\\.kubernetes\\.io/hostname", Value: hostName})
 	}
 
+	// Render the security settings into the controller ConfigMap, before the install args so that they can be overridden
+	newKvs = append(newKvs, getSecurityOverrides(cr)...)
+
 	// Convert NGINX install-args to helm overrides
 	newKvs = append(newKvs, helm.GetInstallArgs(getInstallArgs(cr))...)
 	return newKvs, nil
@@ -153,3 +156,18 @@ func GetOverrides(object runtime.Object) interface{} {
 
 	return []vzapi.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Ingress != nil {
+			return effectiveCR.Spec.Components.Ingress.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.IngressNGINX != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.IngressNGINX.Workload)
+		}
+	}
+	return nil
+}
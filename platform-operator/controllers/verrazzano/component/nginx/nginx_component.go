@@ -11,6 +11,7 @@ import (
 
 	"github.com/verrazzano/verrazzano/pkg/k8s/resource"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/istio"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
@@ -42,6 +43,15 @@ type nginxComponent struct {
 // Verify that nginxComponent implements Component
 var _ spi.Component = nginxComponent{}
 
+// networkPolicyRules are the network peers of the ingress controller outside of the ingress-nginx namespace, the
+// controller routes the traffic of the ingresses of all the namespaces and serves its admission webhook
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		Ingress: []spi.NetworkPeer{{Ports: []int32{80, 443, 8443}}},
+		Egress:  []spi.NetworkPeer{{}},
+	},
+}
+
 // NewComponent returns a new Nginx component
 func NewComponent() spi.Component {
 	return nginxComponent{
@@ -50,6 +60,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), "ingress-nginx"), // Note name is different than release name
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -60,6 +71,14 @@ func NewComponent() spi.Component {
 			PostInstallFunc:           PostInstall,
 			Dependencies:              []string{istio.ComponentName},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "controller.resources",
+				ReplicasKey:          "controller.replicaCount",
+				NodeSelectorKey:      "controller.nodeSelector",
+				TolerationsKey:       "controller.tolerations",
+				PriorityClassNameKey: "controller.priorityClassName",
+			},
 		},
 	}
 }
@@ -85,6 +104,9 @@ func (c nginxComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazz
 	if err := c.HelmComponent.ValidateUpdate(old, new); err != nil {
 		return err
 	}
+	if err := validateSecurity(new); err != nil {
+		return err
+	}
 	return c.validateForExternalIPSWithNodePort(&new.Spec)
 }
 
@@ -93,6 +115,9 @@ func (c nginxComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
 	if err := c.HelmComponent.ValidateInstall(vz); err != nil {
 		return err
 	}
+	if err := validateSecurity(vz); err != nil {
+		return err
+	}
 	return c.validateForExternalIPSWithNodePort(&vz.Spec)
 }
 
@@ -101,6 +126,9 @@ func (c nginxComponent) ValidateInstallV1Beta1(vz *v1beta1.Verrazzano) error {
 	if err := c.HelmComponent.ValidateInstallV1Beta1(vz); err != nil {
 		return err
 	}
+	if err := vzconfig.ValidateIngressSecurity(vz); err != nil {
+		return err
+	}
 	return c.validateForExternalIPSWithNodePortV1Beta1(&vz.Spec)
 }
 
@@ -112,9 +140,21 @@ func (c nginxComponent) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1be
 	if err := c.HelmComponent.ValidateUpdateV1Beta1(old, new); err != nil {
 		return err
 	}
+	if err := vzconfig.ValidateIngressSecurity(new); err != nil {
+		return err
+	}
 	return c.validateForExternalIPSWithNodePortV1Beta1(&new.Spec)
 }
 
+// validateSecurity checks the security settings of the ingress controller
+func validateSecurity(vz *vzapi.Verrazzano) error {
+	convertedVZ := v1beta1.Verrazzano{}
+	if err := common.ConvertVerrazzanoCR(vz, &convertedVZ); err != nil {
+		return err
+	}
+	return vzconfig.ValidateIngressSecurity(&convertedVZ)
+}
+
 // validateForExternalIPSWithNodePort checks that externalIPs are set when Type=NodePort
 func (c nginxComponent) validateForExternalIPSWithNodePort(vz *vzapi.VerrazzanoSpec) error {
 	// good if ingress is not set
@@ -0,0 +1,138 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package nginx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+)
+
+const (
+	// configKeyPrefix is the Helm key of the data of the controller ConfigMap
+	configKeyPrefix = "controller.config."
+
+	// rateLimitStatusCode is returned to the clients above the rate limits, instead of the NGINX default of 503
+	rateLimitStatusCode = "429"
+
+	// Names of the NGINX variables and shared memory zones of the rate limits
+	rateLimitExemptVar  = "$verrazzano_rate_limit_exempt"
+	rateLimitKeyVar     = "$verrazzano_rate_limit_key"
+	requestsZone        = "verrazzano_requests"
+	connectionsZone     = "verrazzano_connections"
+	rateLimitZoneSizeMB = 10
+)
+
+// getSecurityOverrides renders the security settings of the ingress controller into the data of the controller
+// ConfigMap
+func getSecurityOverrides(cr *vzapi.Verrazzano) []bom.KeyValue {
+	security := vzconfig.GetIngressSecurity(cr)
+	if security == nil {
+		return nil
+	}
+	config := map[string]string{}
+	addTLSConfig(config, security.TLS)
+	addHSTSConfig(config, security.HSTS)
+	addModSecurityConfig(config, security.ModSecurity)
+	addRateLimitConfig(config, security.RateLimit)
+	if len(security.ClientBodySize) > 0 {
+		config["proxy-body-size"] = security.ClientBodySize
+	}
+
+	// Sort the keys so that the overrides do not change from one reconcile to the next
+	var kvs []bom.KeyValue
+	for _, key := range sortedKeys(config) {
+		kvs = append(kvs, bom.KeyValue{Key: configKeyPrefix + key, Value: config[key], SetString: true})
+	}
+	return kvs
+}
+
+func addTLSConfig(config map[string]string, tls *vzapi.IngressTLSSpec) {
+	if tls == nil {
+		return
+	}
+	if tls.MinVersion == "TLSv1.3" {
+		config["ssl-protocols"] = "TLSv1.3"
+	} else {
+		config["ssl-protocols"] = "TLSv1.2 TLSv1.3"
+	}
+	if len(tls.Ciphers) > 0 {
+		config["ssl-ciphers"] = strings.Join(tls.Ciphers, ":")
+	}
+}
+
+func addHSTSConfig(config map[string]string, hsts *vzapi.HSTSSpec) {
+	if hsts == nil {
+		return
+	}
+	if hsts.Enabled != nil && !*hsts.Enabled {
+		config["hsts"] = "false"
+		return
+	}
+	config["hsts"] = "true"
+	if hsts.MaxAge != nil {
+		config["hsts-max-age"] = strconv.FormatInt(*hsts.MaxAge, 10)
+	}
+	if hsts.IncludeSubdomains != nil {
+		config["hsts-include-subdomains"] = strconv.FormatBool(*hsts.IncludeSubdomains)
+	}
+	config["hsts-preload"] = strconv.FormatBool(hsts.Preload)
+}
+
+func addModSecurityConfig(config map[string]string, modSecurity *vzapi.ModSecuritySpec) {
+	if modSecurity == nil || !modSecurity.Enabled {
+		return
+	}
+	mode := modSecurity.Mode
+	if len(mode) == 0 {
+		mode = vzapi.ModSecurityDetectionOnly
+	}
+	config["enable-modsecurity"] = "true"
+	config["enable-owasp-modsecurity-crs"] = strconv.FormatBool(modSecurity.OWASPCoreRuleSet)
+	config["modsecurity-snippet"] = fmt.Sprintf("SecRuleEngine %s\n", mode)
+}
+
+// addRateLimitConfig limits the requests and connections of each client address with zones declared in the http
+// block and applied to every location.  The clients in the allowed CIDRs get an empty key, which NGINX does not count.
+func addRateLimitConfig(config map[string]string, rateLimit *vzapi.RateLimitSpec) {
+	if rateLimit == nil || (rateLimit.RequestsPerSecond == 0 && rateLimit.Connections == 0) {
+		return
+	}
+	var http, location strings.Builder
+	fmt.Fprintf(&http, "geo %s {\n  default 0;\n", rateLimitExemptVar)
+	for _, cidr := range rateLimit.AllowedCIDRs {
+		fmt.Fprintf(&http, "  %s 1;\n", cidr)
+	}
+	fmt.Fprintf(&http, "}\nmap %s %s {\n  0 $binary_remote_addr;\n  1 \"\";\n}\n", rateLimitExemptVar, rateLimitKeyVar)
+	if rateLimit.RequestsPerSecond > 0 {
+		fmt.Fprintf(&http, "limit_req_zone %s zone=%s:%dm rate=%dr/s;\n", rateLimitKeyVar, requestsZone, rateLimitZoneSizeMB, rateLimit.RequestsPerSecond)
+		if rateLimit.Burst > 0 {
+			fmt.Fprintf(&location, "limit_req zone=%s burst=%d nodelay;\n", requestsZone, rateLimit.Burst)
+		} else {
+			fmt.Fprintf(&location, "limit_req zone=%s;\n", requestsZone)
+		}
+		config["limit-req-status-code"] = rateLimitStatusCode
+	}
+	if rateLimit.Connections > 0 {
+		fmt.Fprintf(&http, "limit_conn_zone %s zone=%s:%dm;\n", rateLimitKeyVar, connectionsZone, rateLimitZoneSizeMB)
+		fmt.Fprintf(&location, "limit_conn %s %d;\n", connectionsZone, rateLimit.Connections)
+		config["limit-conn-status-code"] = rateLimitStatusCode
+	}
+	config["http-snippet"] = http.String()
+	config["location-snippet"] = location.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package nginx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+)
+
+func newSecurityTestVZ(security *vzapi.IngressSecuritySpec) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Ingress: &vzapi.IngressNginxComponent{Security: security},
+			},
+		},
+	}
+}
+
+func toConfig(kvs []bom.KeyValue) map[string]string {
+	config := map[string]string{}
+	for _, kv := range kvs {
+		config[kv.Key] = kv.Value
+	}
+	return config
+}
+
+// TestGetSecurityOverrides tests the getSecurityOverrides function
+// GIVEN a Verrazzano resource with the TLS policy, HSTS, ModSecurity, rate limits and client body size of the
+// ingress controller
+// WHEN getSecurityOverrides is called
+// THEN the settings are rendered into the data of the controller ConfigMap
+func TestGetSecurityOverrides(t *testing.T) {
+	maxAge := int64(31536000)
+	includeSubdomains := true
+	vz := newSecurityTestVZ(&vzapi.IngressSecuritySpec{
+		TLS: &vzapi.IngressTLSSpec{
+			MinVersion: "TLSv1.2",
+			Ciphers:    []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+		},
+		HSTS:           &vzapi.HSTSSpec{MaxAge: &maxAge, IncludeSubdomains: &includeSubdomains, Preload: true},
+		ModSecurity:    &vzapi.ModSecuritySpec{Enabled: true, OWASPCoreRuleSet: true, Mode: vzapi.ModSecurityOn},
+		RateLimit:      &vzapi.RateLimitSpec{RequestsPerSecond: 20, Burst: 40, Connections: 10, AllowedCIDRs: []string{"10.0.0.0/8"}},
+		ClientBodySize: "8m",
+	})
+
+	kvs := getSecurityOverrides(vz)
+	for _, kv := range kvs {
+		assert.True(t, kv.SetString)
+	}
+	assert.Equal(t, map[string]string{
+		"controller.config.ssl-protocols":                "TLSv1.2 TLSv1.3",
+		"controller.config.ssl-ciphers":                  "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256",
+		"controller.config.hsts":                         "true",
+		"controller.config.hsts-max-age":                 "31536000",
+		"controller.config.hsts-include-subdomains":      "true",
+		"controller.config.hsts-preload":                 "true",
+		"controller.config.enable-modsecurity":           "true",
+		"controller.config.enable-owasp-modsecurity-crs": "true",
+		"controller.config.modsecurity-snippet":          "SecRuleEngine On\n",
+		"controller.config.limit-req-status-code":        "429",
+		"controller.config.limit-conn-status-code":       "429",
+		"controller.config.http-snippet": "geo $verrazzano_rate_limit_exempt {\n  default 0;\n  10.0.0.0/8 1;\n}\n" +
+			"map $verrazzano_rate_limit_exempt $verrazzano_rate_limit_key {\n  0 $binary_remote_addr;\n  1 \"\";\n}\n" +
+			"limit_req_zone $verrazzano_rate_limit_key zone=verrazzano_requests:10m rate=20r/s;\n" +
+			"limit_conn_zone $verrazzano_rate_limit_key zone=verrazzano_connections:10m;\n",
+		"controller.config.location-snippet": "limit_req zone=verrazzano_requests burst=40 nodelay;\n" +
+			"limit_conn verrazzano_connections 10;\n",
+		"controller.config.proxy-body-size": "8m",
+	}, toConfig(kvs))
+
+	// The overrides are sorted so that they do not change from one reconcile to the next
+	for i := 1; i < len(kvs); i++ {
+		assert.Less(t, kvs[i-1].Key, kvs[i].Key)
+	}
+}
+
+// TestGetSecurityOverridesDefaults tests the getSecurityOverrides function
+// GIVEN a Verrazzano resource with the defaults of the security settings of the ingress controller
+// WHEN getSecurityOverrides is called
+// THEN only the settings that are set are rendered, ModSecurity only detects, and HSTS can be turned off
+func TestGetSecurityOverridesDefaults(t *testing.T) {
+	assert.Empty(t, getSecurityOverrides(&vzapi.Verrazzano{}))
+	assert.Empty(t, getSecurityOverrides(newSecurityTestVZ(nil)))
+
+	disabled := false
+	kvs := getSecurityOverrides(newSecurityTestVZ(&vzapi.IngressSecuritySpec{
+		TLS:         &vzapi.IngressTLSSpec{MinVersion: "TLSv1.3"},
+		HSTS:        &vzapi.HSTSSpec{Enabled: &disabled},
+		ModSecurity: &vzapi.ModSecuritySpec{Enabled: true},
+		RateLimit:   &vzapi.RateLimitSpec{RequestsPerSecond: 5},
+	}))
+	assert.Equal(t, map[string]string{
+		"controller.config.ssl-protocols":                "TLSv1.3",
+		"controller.config.hsts":                         "false",
+		"controller.config.enable-modsecurity":           "true",
+		"controller.config.enable-owasp-modsecurity-crs": "false",
+		"controller.config.modsecurity-snippet":          "SecRuleEngine DetectionOnly\n",
+		"controller.config.limit-req-status-code":        "429",
+		"controller.config.http-snippet": "geo $verrazzano_rate_limit_exempt {\n  default 0;\n}\n" +
+			"map $verrazzano_rate_limit_exempt $verrazzano_rate_limit_key {\n  0 $binary_remote_addr;\n  1 \"\";\n}\n" +
+			"limit_req_zone $verrazzano_rate_limit_key zone=verrazzano_requests:10m rate=5r/s;\n",
+		"controller.config.location-snippet": "limit_req zone=verrazzano_requests;\n",
+	}, toConfig(kvs))
+}
+
+// TestAppendNGINXOverridesWithSecurity tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+// WHEN I pass a VZ spec with security settings and NGINX install args
+// THEN the security settings are rendered before the install args, which can override them
+func TestAppendNGINXOverridesWithSecurity(t *testing.T) {
+	vz := newSecurityTestVZ(&vzapi.IngressSecuritySpec{ClientBodySize: "8m"})
+	vz.Spec.Components.Ingress.NGINXInstallArgs = []vzapi.InstallArgs{{Name: "controller.config.proxy-body-size", Value: "16m"}}
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, vz, nil, false), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	assert.Len(t, kvs, 3)
+	assert.Equal(t, bom.KeyValue{Key: "controller.config.proxy-body-size", Value: "8m", SetString: true}, kvs[1])
+	assert.Equal(t, "controller.config.proxy-body-size", kvs[2].Key)
+	assert.Equal(t, "16m", kvs[2].Value)
+}
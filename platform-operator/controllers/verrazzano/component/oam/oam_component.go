@@ -46,6 +46,13 @@ func NewComponent() spi.Component {
 			ImagePullSecretKeyname:    secret.DefaultImagePullSecretKeyName,
 			Dependencies:              []string{},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc: GetWorkload,
+				ResourcesKey:    "resources",
+				ReplicasKey:     "replicaCount",
+				NodeSelectorKey: "nodeSelector",
+				TolerationsKey:  "tolerations",
+			},
 		},
 	}
 }
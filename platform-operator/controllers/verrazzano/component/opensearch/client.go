@@ -0,0 +1,109 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+)
+
+// osIngressHostPrefix is the prefix of the host of the OpenSearch ingress
+const osIngressHostPrefix = "elasticsearch"
+
+// osRequestSig sends a request to the OpenSearch REST API, returning the status code and the body of the response
+type osRequestSig func(ctx spi.ComponentContext, method string, path string, body []byte) (int, []byte, error)
+
+// osRequestFunc is the function used to send OpenSearch requests, can be overridden for unit testing
+var osRequestFunc osRequestSig = httpOSRequest
+
+// osEndpointFunc returns the endpoint used to send OpenSearch requests, can be overridden for unit testing
+var osEndpointFunc = getOSEndpoint
+
+// SetOSRequestFunc sets the function used to send OpenSearch requests
+func SetOSRequestFunc(f osRequestSig) {
+	osRequestFunc = f
+}
+
+// SetDefaultOSRequestFunc restores the default function used to send OpenSearch requests
+func SetDefaultOSRequestFunc() {
+	osRequestFunc = httpOSRequest
+}
+
+// getOSEndpoint returns the endpoint of the OpenSearch ingress.  The OpenSearch services only accept mutual TLS from
+// the mesh, the platform operator sends its requests over TLS to the ingress and authenticates with the VMI credentials.
+func getOSEndpoint(ctx spi.ComponentContext) (*common.IngressEndpoint, error) {
+	return common.GetVMIIngressEndpoint(ctx, osIngressHostPrefix, osCertificateName)
+}
+
+// httpOSRequest sends the request to the OpenSearch ingress
+func httpOSRequest(ctx spi.ComponentContext, method string, path string, body []byte) (int, []byte, error) {
+	return sendOSRequest(context.TODO(), ctx, method, path, body)
+}
+
+// sendOSRequest sends the request to the OpenSearch ingress, the request is cancelled when the context is done
+func sendOSRequest(reqCtx context.Context, ctx spi.ComponentContext, method string, path string, body []byte) (int, []byte, error) {
+	endpoint, err := osEndpointFunc(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := endpoint.NewRequest(reqCtx, method, path, reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := endpoint.Client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Failed sending OpenSearch request %s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("Failed reading the response of OpenSearch request %s %s: %v", method, path, err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// osRequest sends a request with an optional JSON payload, decoding the JSON response into the optional result.  An
+// error is returned if the status code is not one of the expected codes.
+func osRequest(ctx spi.ComponentContext, method string, path string, payload interface{}, result interface{}, expectedCodes ...int) (int, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return 0, err
+		}
+	}
+	code, respBody, err := osRequestFunc(ctx, method, path, body)
+	if err != nil {
+		return code, err
+	}
+	expected := false
+	for _, expectedCode := range expectedCodes {
+		if code == expectedCode {
+			expected = true
+			break
+		}
+	}
+	if !expected {
+		return code, fmt.Errorf("Failed, OpenSearch request %s %s returned status code %d: %s", method, path, code, string(respBody))
+	}
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return code, fmt.Errorf("Failed parsing the response of OpenSearch request %s %s: %v", method, path, err)
+		}
+	}
+	return code, nil
+}
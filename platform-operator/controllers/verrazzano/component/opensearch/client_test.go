@@ -0,0 +1,163 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testOSHost = "elasticsearch.vmi.system.default.11.22.33.44.nip.io"
+
+// fakeOSResponse is the response of the fake OpenSearch API to a request
+type fakeOSResponse struct {
+	code int
+	body string
+}
+
+// fakeOSRequest records the requests sent to the fake OpenSearch API, requests without a response return 200 with an
+// empty body
+type fakeOSRequest struct {
+	responses map[string]fakeOSResponse
+	requests  []string
+	bodies    map[string]string
+}
+
+func newFakeOSRequest() *fakeOSRequest {
+	return &fakeOSRequest{
+		responses: map[string]fakeOSResponse{},
+		bodies:    map[string]string{},
+	}
+}
+
+func (f *fakeOSRequest) request(_ spi.ComponentContext, method string, path string, body []byte) (int, []byte, error) {
+	key := method + " " + path
+	f.requests = append(f.requests, key)
+	f.bodies[key] = string(body)
+	if response, ok := f.responses[key]; ok {
+		return response.code, []byte(response.body), nil
+	}
+	return http.StatusOK, nil, nil
+}
+
+// TestHTTPOSRequest tests sending requests to the OpenSearch ingress
+// GIVEN an OpenSearch REST API
+// WHEN httpOSRequest is called
+// THEN the JSON body is sent with the VMI credentials and the status code and the body of the response are returned
+func TestHTTPOSRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if username, password, ok := r.BasicAuth(); !ok || username != "verrazzano" || password != "changeme" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(r.Host + " " + r.Method + " " + r.URL.RequestURI() + " " + string(body)))
+	}))
+	defer server.Close()
+	osEndpointFunc = func(_ spi.ComponentContext) (*common.IngressEndpoint, error) {
+		return &common.IngressEndpoint{URL: server.URL, Host: testOSHost, Client: server.Client(), Username: "verrazzano", Password: "changeme"}, nil
+	}
+	defer func() { osEndpointFunc = getOSEndpoint }()
+
+	code, body, err := httpOSRequest(nil, http.MethodPut, "/index/_settings?pretty", []byte(`{"index":{}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, testOSHost+` PUT /index/_settings?pretty {"index":{}}`, string(body))
+
+	code, body, err = httpOSRequest(nil, http.MethodGet, "/missing", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Empty(t, body)
+
+	// The request is cancelled when the context is done
+	reqCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = sendOSRequest(reqCtx, nil, http.MethodGet, "/", nil)
+	assert.Error(t, err)
+
+	// The ingress is not reachable
+	server.Close()
+	_, _, err = httpOSRequest(nil, http.MethodGet, "/", nil)
+	assert.Error(t, err)
+
+	// The endpoint can not be built
+	osEndpointFunc = func(_ spi.ComponentContext) (*common.IngressEndpoint, error) {
+		return nil, fmt.Errorf("secret not found")
+	}
+	_, _, err = httpOSRequest(nil, http.MethodGet, "/", nil)
+	assert.Error(t, err)
+}
+
+// TestGetOSEndpoint tests getting the endpoint of the OpenSearch ingress
+// GIVEN a Verrazzano install with the VMI secret
+// WHEN getOSEndpoint is called
+// THEN the endpoint reaches the OpenSearch ingress host
+func TestGetOSEndpoint(t *testing.T) {
+	nginxService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "ingress-controller-ingress-nginx-controller"},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "11.22.33.44"}}},
+		},
+	}
+	vmiSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "verrazzano"},
+		Data:       map[string][]byte{"username": []byte("verrazzano"), "password": []byte("changeme")},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(nginxService, vmiSecret).Build()
+	vz := &vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{EnvironmentName: "default"}}
+	endpoint, err := getOSEndpoint(spi.NewFakeContext(c, vz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, testOSHost, endpoint.Host)
+	assert.Equal(t, "verrazzano", endpoint.Username)
+}
+
+// TestOSRequest tests sending requests to the OpenSearch API
+// GIVEN requests to the OpenSearch API
+// WHEN osRequest is called
+// THEN the payload is sent, the response is decoded and unexpected status codes are reported
+func TestOSRequest(t *testing.T) {
+	fake := newFakeOSRequest()
+	fake.responses["GET /ok"] = fakeOSResponse{code: http.StatusOK, body: `{"name":"ok"}`}
+	fake.responses["GET /missing"] = fakeOSResponse{code: http.StatusNotFound, body: `{"error":"missing"}`}
+	SetOSRequestFunc(fake.request)
+	defer SetDefaultOSRequestFunc()
+	ctx := spi.NewFakeContext(nil, nil, nil, false)
+
+	result := map[string]string{}
+	code, err := osRequest(ctx, http.MethodGet, "/ok", nil, &result, http.StatusOK)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", result["name"])
+
+	code, err = osRequest(ctx, http.MethodGet, "/missing", nil, nil, http.StatusOK)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	_, err = osRequest(ctx, http.MethodPut, "/payload", map[string]int{"count": 1}, nil, http.StatusOK)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"count":1}`, fake.bodies["PUT /payload"])
+
+	SetOSRequestFunc(func(_ spi.ComponentContext, _ string, _ string, _ []byte) (int, []byte, error) {
+		return 0, nil, fmt.Errorf("connection refused")
+	})
+	_, err = osRequest(ctx, http.MethodGet, "/ok", nil, nil, http.StatusOK)
+	assert.Error(t, err)
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// allocationExcludeSetting is the cluster setting that moves the shards off the listed nodes
+	allocationExcludeSetting = "cluster.routing.allocation.exclude._name"
+
+	// legacyDataNodeName is the name of the data node group created from the install args
+	legacyDataNodeName = "es-data"
+
+	healthGreen = "green"
+
+	// maxIndexReplicasTimeout bounds the lookup of the index replicas made while validating the Verrazzano resource
+	maxIndexReplicasTimeout = 10 * time.Second
+)
+
+type (
+	// dataNodeGroup is a group of OpenSearch nodes with the data role
+	dataNodeGroup struct {
+		replicas int32
+		// Groups that also have the master role run as a StatefulSet, other groups run one Deployment per replica
+		statefulSet bool
+	}
+
+	clusterSettings struct {
+		Persistent map[string]interface{} `json:"persistent"`
+	}
+
+	clusterHealth struct {
+		Status           string `json:"status"`
+		RelocatingShards int    `json:"relocating_shards"`
+	}
+
+	catNode struct {
+		Name string `json:"name"`
+	}
+
+	catIndex struct {
+		Index    string `json:"index"`
+		Replicas string `json:"rep"`
+	}
+
+	catShard struct {
+		Index string `json:"index"`
+		Node  string `json:"node"`
+	}
+)
+
+// drainDataNodes moves the shards off the data nodes that are removed by the update of the VMI, so that scaling
+// down does not drop shard copies.  The nodes are excluded from shard allocation, and a retryable error is returned
+// until their shards have been relocated and the cluster health is green.
+func drainDataNodes(ctx spi.ComponentContext) error {
+	if ctx.IsDryRun() {
+		return nil
+	}
+	vmi := common.NewVMI()
+	if err := ctx.Client().Get(context.TODO(), clipkg.ObjectKeyFromObject(vmi), vmi); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return ctx.Log().ErrorfNewErr("Failed getting VMI %s/%s: %v", vmi.Namespace, vmi.Name, err)
+	}
+	desired, err := newOpenSearch(ctx.EffectiveCR(), ctx.ActualCR(), nil, vmi, false, false)
+	if err != nil {
+		return err
+	}
+	current := getDataNodeGroups(&vmi.Spec.Elasticsearch)
+	wanted := getDataNodeGroups(desired)
+	if !isDataNodeScaleDown(current, wanted) {
+		return nil
+	}
+
+	nodeNames, err := getClusterNodeNames(ctx)
+	if err != nil {
+		return err
+	}
+	var drained []string
+	for _, nodeName := range nodeNames {
+		if belongsToDataNodeGroups(nodeName, current) && !belongsToDataNodeGroups(nodeName, wanted) {
+			drained = append(drained, nodeName)
+		}
+	}
+	if len(drained) == 0 {
+		return nil
+	}
+
+	// Keep the nodes that are already excluded, they may have been excluded by an administrator
+	excluded, err := getExcludedNodeNames(ctx)
+	if err != nil {
+		return err
+	}
+	if merged := mergeNodeNames(excluded, drained); len(merged) != len(excluded) {
+		ctx.Log().Infof("Excluding OpenSearch nodes %v from shard allocation before scaling down", drained)
+		if err := setExcludedNodeNames(ctx, merged); err != nil {
+			return err
+		}
+	}
+
+	drainedSet := map[string]bool{}
+	for _, nodeName := range drained {
+		drainedSet[nodeName] = true
+	}
+	shards := []catShard{}
+	if _, err := osRequest(ctx, http.MethodGet, "/_cat/shards?format=json&h=index,node", nil, &shards, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing the OpenSearch shards: %v", err)
+	}
+	remaining := 0
+	for _, shard := range shards {
+		if drainedSet[shard.Node] {
+			remaining++
+		}
+	}
+	health := &clusterHealth{}
+	if _, err := osRequest(ctx, http.MethodGet, "/_cluster/health", nil, health, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the OpenSearch cluster health: %v", err)
+	}
+	if remaining > 0 || health.RelocatingShards > 0 || health.Status != healthGreen {
+		ctx.Log().Progressf("Waiting for OpenSearch to relocate %d shards off nodes %v, cluster health is %s", remaining, drained, health.Status)
+		return ctrlerrors.RetryableError{
+			Source:    ComponentName,
+			Operation: "Drain OpenSearch data nodes",
+		}
+	}
+	ctx.Log().Oncef("OpenSearch nodes %v have been drained", drained)
+	return nil
+}
+
+// clearDrainedNodes removes the nodes that have been scaled down from the shard allocation exclusions.  Nodes of the
+// data node groups are removed too, a node that is scaled up again reuses the name of its StatefulSet pod.
+func clearDrainedNodes(ctx spi.ComponentContext) error {
+	if ctx.IsDryRun() {
+		return nil
+	}
+	excluded, err := getExcludedNodeNames(ctx)
+	if err != nil || len(excluded) == 0 {
+		return err
+	}
+	vmi := common.NewVMI()
+	if err := ctx.Client().Get(context.TODO(), clipkg.ObjectKeyFromObject(vmi), vmi); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting VMI %s/%s: %v", vmi.Namespace, vmi.Name, err)
+	}
+	groups := getDataNodeGroups(&vmi.Spec.Elasticsearch)
+	nodeNames, err := getClusterNodeNames(ctx)
+	if err != nil {
+		return err
+	}
+	inCluster := map[string]bool{}
+	for _, nodeName := range nodeNames {
+		inCluster[nodeName] = true
+	}
+	var kept []string
+	for _, nodeName := range excluded {
+		if inCluster[nodeName] && !belongsToDataNodeGroups(nodeName, groups) {
+			kept = append(kept, nodeName)
+		}
+	}
+	if len(kept) == len(excluded) {
+		return nil
+	}
+	ctx.Log().Infof("Removing scaled down OpenSearch nodes from the shard allocation exclusions")
+	return setExcludedNodeNames(ctx, kept)
+}
+
+// getDataNodeGroups returns the data node groups of the VMI OpenSearch spec by name
+func getDataNodeGroups(opensearch *vmov1.Elasticsearch) map[string]dataNodeGroup {
+	groups := map[string]dataNodeGroup{}
+	if opensearch.DataNode.Replicas > 0 {
+		name := opensearch.DataNode.Name
+		if name == "" {
+			name = legacyDataNodeName
+		}
+		groups[name] = dataNodeGroup{replicas: opensearch.DataNode.Replicas}
+	}
+	for _, node := range opensearch.Nodes {
+		if hasRole(node.Roles, vmov1.DataRole) && node.Replicas > 0 {
+			groups[node.Name] = dataNodeGroup{
+				replicas:    node.Replicas,
+				statefulSet: hasRole(node.Roles, vmov1.MasterRole),
+			}
+		}
+	}
+	return groups
+}
+
+// isDataNodeScaleDown returns true if any data node group is removed or has fewer replicas
+func isDataNodeScaleDown(current map[string]dataNodeGroup, wanted map[string]dataNodeGroup) bool {
+	for name, group := range current {
+		if wanted[name].replicas < group.replicas {
+			return true
+		}
+	}
+	return false
+}
+
+// belongsToDataNodeGroups returns true if the OpenSearch node is a replica of one of the groups.  The node name is
+// the name of its pod: StatefulSet pods are named <sts>-<ordinal>, the pods of data Deployments are named
+// <deployment>-<replicaset hash>-<pod hash>, with one Deployment named <group>-<index> per replica.
+func belongsToDataNodeGroups(nodeName string, groups map[string]dataNodeGroup) bool {
+	for name, group := range groups {
+		controllerName := fmt.Sprintf(nodeNamePrefix, name)
+		for i := int32(0); i < group.replicas; i++ {
+			if group.statefulSet {
+				if nodeName == fmt.Sprintf("%s-%d", controllerName, i) {
+					return true
+				}
+				continue
+			}
+			suffix := strings.TrimPrefix(nodeName, fmt.Sprintf("%s-%d-", controllerName, i))
+			if suffix != nodeName && len(strings.Split(suffix, "-")) == 2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getClusterNodeNames(ctx spi.ComponentContext) ([]string, error) {
+	nodes := []catNode{}
+	if _, err := osRequest(ctx, http.MethodGet, "/_cat/nodes?format=json&h=name", nil, &nodes, http.StatusOK); err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed listing the OpenSearch nodes: %v", err)
+	}
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names, nil
+}
+
+func getExcludedNodeNames(ctx spi.ComponentContext) ([]string, error) {
+	settings := &clusterSettings{}
+	if _, err := osRequest(ctx, http.MethodGet, "/_cluster/settings?flat_settings=true", nil, settings, http.StatusOK); err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed getting the OpenSearch cluster settings: %v", err)
+	}
+	value, ok := settings.Persistent[allocationExcludeSetting].(string)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	return strings.Split(value, ","), nil
+}
+
+// setExcludedNodeNames sets the nodes excluded from shard allocation, the setting is removed if there are none
+func setExcludedNodeNames(ctx spi.ComponentContext, nodeNames []string) error {
+	var value interface{}
+	if len(nodeNames) > 0 {
+		value = strings.Join(nodeNames, ",")
+	}
+	payload := clusterSettings{Persistent: map[string]interface{}{allocationExcludeSetting: value}}
+	if _, err := osRequest(ctx, http.MethodPut, "/_cluster/settings", payload, nil, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating the OpenSearch shard allocation exclusions: %v", err)
+	}
+	return nil
+}
+
+// mergeNodeNames returns the sorted union of the node names
+func mergeNodeNames(names []string, more []string) []string {
+	set := map[string]bool{}
+	for _, name := range append(append([]string{}, names...), more...) {
+		set[name] = true
+	}
+	var merged []string
+	for name := range set {
+		merged = append(merged, name)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// getMaxIndexReplicas returns the highest number of replicas of the existing indices, or -1 if OpenSearch has no
+// ready master node to ask.  It is called by the validating webhook with the installed Verrazzano resource, the lookup
+// is bounded by maxIndexReplicasTimeout so that an unresponsive OpenSearch cluster fails the validation instead of
+// timing out the webhook.
+func getMaxIndexReplicas(vz *v1beta1.Verrazzano) (int, error) {
+	cli, err := getControllerRuntimeClient()
+	if err != nil {
+		return 0, err
+	}
+	vzV1Alpha1 := &vzapi.Verrazzano{}
+	if err := vzV1Alpha1.ConvertFrom(vz); err != nil {
+		return 0, err
+	}
+	ctx, err := spi.NewContext(vzlog.DefaultLogger(), cli, vzV1Alpha1, vz, false)
+	if err != nil {
+		return 0, err
+	}
+	reqCtx, cancel := context.WithTimeout(context.Background(), maxIndexReplicasTimeout)
+	defer cancel()
+	pods := &corev1.PodList{}
+	if err := cli.List(reqCtx, pods, clipkg.InNamespace(ComponentNamespace), clipkg.MatchingLabels{"app": workloadName}); err != nil {
+		return 0, err
+	}
+	for _, pod := range pods.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != containerName || !containerStatus.Ready {
+				continue
+			}
+			code, body, err := sendOSRequest(reqCtx, ctx, http.MethodGet, "/_cat/indices?format=json&h=index,rep", nil)
+			if err != nil {
+				return 0, err
+			}
+			if code != http.StatusOK {
+				return 0, fmt.Errorf("Failed, listing the OpenSearch indices returned status code %d: %s", code, string(body))
+			}
+			return parseMaxIndexReplicas(body)
+		}
+	}
+	return -1, nil
+}
+
+func parseMaxIndexReplicas(body []byte) (int, error) {
+	indices := []catIndex{}
+	if err := json.Unmarshal(body, &indices); err != nil {
+		return 0, fmt.Errorf("Failed parsing the OpenSearch indices: %v", err)
+	}
+	maxReplicas := 0
+	for _, index := range indices {
+		replicas, err := strconv.Atoi(index.Replicas)
+		if err != nil {
+			return 0, fmt.Errorf("Failed parsing the replicas of OpenSearch index %s: %v", index.Index, err)
+		}
+		if replicas > maxReplicas {
+			maxReplicas = replicas
+		}
+	}
+	return maxReplicas, nil
+}
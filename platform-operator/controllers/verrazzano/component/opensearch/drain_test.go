@@ -0,0 +1,190 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	catNodesRequest       = "GET /_cat/nodes?format=json&h=name"
+	getSettingsRequest    = "GET /_cluster/settings?flat_settings=true"
+	putSettingsRequest    = "PUT /_cluster/settings"
+	catShardsRequest      = "GET /_cat/shards?format=json&h=index,node"
+	clusterHealthRequest  = "GET /_cluster/health"
+	testClusterNodesBody  = `[{"name":"vmi-system-es-master-0"},{"name":"vmi-system-es-data-0-5f7b9c-x2x4z"},{"name":"vmi-system-es-data-1-6c8d7f-q9w8e"},{"name":"vmi-system-es-data-2-7d9e8a-a1b2c"}]`
+	testDrainedDataNode   = "vmi-system-es-data-2-7d9e8a-a1b2c"
+	testExcludedNodesBody = `{"persistent":{"cluster.routing.allocation.exclude._name":"vmi-system-es-data-2-7d9e8a-a1b2c"}}`
+)
+
+var (
+	masterRoles = []vmov1.NodeRole{vmov1.MasterRole}
+	dataRoles   = []vmov1.NodeRole{vmov1.DataRole}
+)
+
+// createDrainTest returns a context with an existing VMI with 3 data nodes, and an effective CR with the given data
+// node replicas
+func createDrainTest(dataReplicas int32) spi.ComponentContext {
+	vmi := common.NewVMI()
+	vmi.Spec.Elasticsearch.Nodes = []vmov1.ElasticsearchNode{
+		{Name: "es-master", Replicas: 1, Roles: masterRoles},
+		{Name: "es-data", Replicas: 3, Roles: dataRoles},
+	}
+	vz := createVZ(&vzapi.ElasticsearchComponent{
+		Nodes: []vzapi.OpenSearchNode{
+			createNG("es-master", 1, masterRoles),
+			createNG("es-data", dataReplicas, dataRoles),
+		},
+	})
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(vmi).Build()
+	return spi.NewFakeContext(c, vz, nil, false)
+}
+
+// TestDrainDataNodes tests draining the data nodes that are scaled down
+// GIVEN a data node group scaled down from 3 to 2 replicas, with shards still on the removed node
+// WHEN drainDataNodes is called
+// THEN the removed node is excluded from shard allocation and a retryable error is returned
+func TestDrainDataNodes(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses[catNodesRequest] = fakeOSResponse{code: http.StatusOK, body: testClusterNodesBody}
+	fakeOS.responses[getSettingsRequest] = fakeOSResponse{code: http.StatusOK, body: `{"persistent":{"cluster.routing.allocation.exclude._name":"other"}}`}
+	fakeOS.responses[catShardsRequest] = fakeOSResponse{code: http.StatusOK, body: `[{"index":"a","node":"vmi-system-es-data-2-7d9e8a-a1b2c"},{"index":"a","node":"vmi-system-es-data-0-5f7b9c-x2x4z"}]`}
+	fakeOS.responses[clusterHealthRequest] = fakeOSResponse{code: http.StatusOK, body: `{"status":"green","relocating_shards":1}`}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	err := drainDataNodes(createDrainTest(2))
+	assert.IsType(t, ctrlerrors.RetryableError{}, err)
+	assert.JSONEq(t, `{"persistent":{"cluster.routing.allocation.exclude._name":"other,vmi-system-es-data-2-7d9e8a-a1b2c"}}`, fakeOS.bodies[putSettingsRequest])
+}
+
+// TestDrainDataNodesDrained tests draining the data nodes that are scaled down
+// GIVEN a data node group scaled down from 3 to 2 replicas, with no shards left on the excluded node
+// WHEN drainDataNodes is called
+// THEN no error is returned so that the VMI is scaled down
+func TestDrainDataNodesDrained(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses[catNodesRequest] = fakeOSResponse{code: http.StatusOK, body: testClusterNodesBody}
+	fakeOS.responses[getSettingsRequest] = fakeOSResponse{code: http.StatusOK, body: testExcludedNodesBody}
+	fakeOS.responses[catShardsRequest] = fakeOSResponse{code: http.StatusOK, body: `[{"index":"a","node":"vmi-system-es-data-0-5f7b9c-x2x4z"}]`}
+	fakeOS.responses[clusterHealthRequest] = fakeOSResponse{code: http.StatusOK, body: `{"status":"green","relocating_shards":0}`}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	assert.NoError(t, drainDataNodes(createDrainTest(2)))
+	assert.NotContains(t, fakeOS.requests, putSettingsRequest)
+}
+
+// TestDrainDataNodesNoScaleDown tests draining the data nodes
+// GIVEN a data node group that is not scaled down
+// WHEN drainDataNodes is called
+// THEN OpenSearch is not called
+func TestDrainDataNodesNoScaleDown(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	assert.NoError(t, drainDataNodes(createDrainTest(4)))
+	assert.Empty(t, fakeOS.requests)
+}
+
+// TestClearDrainedNodes tests clearing the shard allocation exclusions after scaling down
+// GIVEN an excluded node that has been removed from the cluster
+// WHEN clearDrainedNodes is called
+// THEN the exclusion setting is removed
+func TestClearDrainedNodes(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses[catNodesRequest] = fakeOSResponse{code: http.StatusOK, body: `[{"name":"vmi-system-es-master-0"},{"name":"vmi-system-es-data-0-5f7b9c-x2x4z"}]`}
+	fakeOS.responses[getSettingsRequest] = fakeOSResponse{code: http.StatusOK, body: testExcludedNodesBody}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	assert.NoError(t, clearDrainedNodes(createDrainTest(2)))
+	assert.JSONEq(t, `{"persistent":{"cluster.routing.allocation.exclude._name":null}}`, fakeOS.bodies[putSettingsRequest])
+}
+
+// TestBelongsToDataNodeGroups tests matching OpenSearch nodes to their data node groups
+// GIVEN node names of StatefulSet and Deployment pods
+// WHEN belongsToDataNodeGroups is called
+// THEN only the pods of the group replicas match
+func TestBelongsToDataNodeGroups(t *testing.T) {
+	groups := map[string]dataNodeGroup{
+		"data":   {replicas: 2},
+		"master": {replicas: 2, statefulSet: true},
+	}
+	assert.True(t, belongsToDataNodeGroups("vmi-system-data-1-5f7b9c-x2x4z", groups))
+	assert.False(t, belongsToDataNodeGroups("vmi-system-data-2-5f7b9c-x2x4z", groups))
+	assert.False(t, belongsToDataNodeGroups("vmi-system-data-1-0-5f7b9c-x2x4z", groups))
+	assert.True(t, belongsToDataNodeGroups("vmi-system-master-1", groups))
+	assert.False(t, belongsToDataNodeGroups("vmi-system-master-2", groups))
+}
+
+// TestParseMaxIndexReplicas tests parsing the replicas of the indices
+// GIVEN the list of indices returned by OpenSearch
+// WHEN parseMaxIndexReplicas is called
+// THEN the highest number of replicas is returned
+func TestParseMaxIndexReplicas(t *testing.T) {
+	replicas, err := parseMaxIndexReplicas([]byte(`[{"index":"a","rep":"1"},{"index":"b","rep":"2"},{"index":"c","rep":"0"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, replicas)
+
+	_, err = parseMaxIndexReplicas([]byte(`[{"index":"a","rep":"x"}]`))
+	assert.Error(t, err)
+}
+
+// TestGetMaxIndexReplicas tests getting the highest number of replicas of the existing indices from the webhook
+// GIVEN an installed Verrazzano resource
+// WHEN getMaxIndexReplicas is called
+// THEN the indices are listed through the OpenSearch ingress once a master node is ready
+func TestGetMaxIndexReplicas(t *testing.T) {
+	config.TestProfilesDir = "../../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	masterPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "vmi-system-es-master-0", Labels: map[string]string{"app": workloadName}},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: containerName, Ready: false}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(masterPod).Build()
+	getControllerRuntimeClient = func() (clipkg.Client, error) { return c, nil }
+	defer func() { getControllerRuntimeClient = getClient }()
+	osEndpointFunc = func(_ spi.ComponentContext) (*common.IngressEndpoint, error) {
+		return nil, fmt.Errorf("unexpected OpenSearch request")
+	}
+	defer func() { osEndpointFunc = getOSEndpoint }()
+	vz := &v1beta1.Verrazzano{}
+
+	// No master node is ready
+	replicas, err := getMaxIndexReplicas(vz)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, replicas)
+
+	masterPod.Status.ContainerStatuses[0].Ready = true
+	assert.NoError(t, c.Status().Update(context.TODO(), masterPod))
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"index":"a","rep":"1"}]`))
+	}))
+	defer server.Close()
+	osEndpointFunc = func(_ spi.ComponentContext) (*common.IngressEndpoint, error) {
+		return &common.IngressEndpoint{URL: server.URL, Client: server.Client()}, nil
+	}
+	replicas, err = getMaxIndexReplicas(vz)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, replicas)
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	vzappclusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// namespacePolicyDescription identifies the ISM policies managed for namespaces
+	namespacePolicyDescription = "__verrazzano-namespace-policy__"
+	// namespacePolicyPriority is higher than the priority of the VMI policies, so that namespace policies take
+	// precedence over global policies with overlapping index patterns
+	namespacePolicyPriority = 10
+
+	// applicationIndexPrefix is the prefix of the name of the application log data stream of each namespace
+	applicationIndexPrefix = "verrazzano-application-"
+
+	defaultPolicyMinIndexAge   = "7d"
+	defaultRolloverMinIndexAge = "1d"
+)
+
+type (
+	ismPolicyResponse struct {
+		ID          string    `json:"_id"`
+		SeqNo       int       `json:"_seq_no"`
+		PrimaryTerm int       `json:"_primary_term"`
+		Policy      ismPolicy `json:"policy"`
+	}
+
+	ismPolicyList struct {
+		Policies []ismPolicyResponse `json:"policies"`
+	}
+
+	ismPolicyDocument struct {
+		Policy ismPolicy `json:"policy"`
+	}
+
+	ismPolicy struct {
+		Description  string        `json:"description"`
+		DefaultState string        `json:"default_state"`
+		States       []ismState    `json:"states"`
+		ISMTemplate  []ismTemplate `json:"ism_template"`
+	}
+
+	ismState struct {
+		Name        string                   `json:"name"`
+		Actions     []map[string]interface{} `json:"actions"`
+		Transitions []ismTransition          `json:"transitions"`
+	}
+
+	ismTransition struct {
+		StateName  string            `json:"state_name"`
+		Conditions map[string]string `json:"conditions,omitempty"`
+	}
+
+	ismTemplate struct {
+		IndexPatterns []string `json:"index_patterns"`
+		Priority      int      `json:"priority"`
+	}
+)
+
+// createOrUpdateNamespacePolicies creates or updates the ISM policies declared for namespaces and projects, and deletes
+// the namespace policies that are no longer declared
+func createOrUpdateNamespacePolicies(ctx spi.ComponentContext) error {
+	desired, err := buildNamespacePolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Delete the policies first, their index patterns may have moved to other policies
+	existing := &ismPolicyList{}
+	if _, err := osRequest(ctx, http.MethodGet, "/_plugins/_ism/policies?size=1000", nil, existing, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing the OpenSearch ISM policies: %v", err)
+	}
+	for _, policy := range existing.Policies {
+		if _, ok := desired[policy.ID]; ok || policy.Policy.Description != namespacePolicyDescription {
+			continue
+		}
+		if err := deleteNamespacePolicy(ctx, policy); err != nil {
+			return err
+		}
+	}
+
+	// A namespace moving between policies can only be added to its new policy once it has been removed from the old
+	// one, keep going on failures so that the next reconcile succeeds
+	var lastErr error
+	for _, name := range sortedPolicyNames(desired) {
+		if err := createOrUpdateNamespacePolicy(ctx, name, desired[name]); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// createOrUpdateNamespacePolicy creates the policy or updates it if it has changed, the indices that already exist
+// are then managed by the policy
+func createOrUpdateNamespacePolicy(ctx spi.ComponentContext, name string, policy ismPolicy) error {
+	path := "/_plugins/_ism/policies/" + name
+	existing := &ismPolicyResponse{}
+	code, err := osRequest(ctx, http.MethodGet, path, nil, existing, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the OpenSearch ISM policy %s: %v", name, err)
+	}
+	if code == http.StatusOK {
+		if !policyNeedsUpdate(policy, existing.Policy) {
+			return nil
+		}
+		path = fmt.Sprintf("%s?if_seq_no=%d&if_primary_term=%d", path, existing.SeqNo, existing.PrimaryTerm)
+	}
+	ctx.Log().Oncef("Creating or updating OpenSearch ISM policy %s", name)
+	if _, err := osRequest(ctx, http.MethodPut, path, ismPolicyDocument{Policy: policy}, nil, http.StatusOK, http.StatusCreated); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed creating or updating the OpenSearch ISM policy %s: %v", name, err)
+	}
+
+	// New indices pick up the policy from the ISM template, existing indices must be added explicitly.  Indices
+	// managed by another policy are switched to this one.
+	for _, dataStream := range policy.ISMTemplate[0].IndexPatterns {
+		payload := map[string]string{"policy_id": name}
+		if _, err := osRequest(ctx, http.MethodPost, "/_plugins/_ism/add/"+dataStream, payload, nil, http.StatusOK); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed adding the OpenSearch ISM policy %s to %s: %v", name, dataStream, err)
+		}
+		if _, err := osRequest(ctx, http.MethodPost, "/_plugins/_ism/change_policy/"+dataStream, payload, nil, http.StatusOK); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed changing the OpenSearch ISM policy of %s to %s: %v", dataStream, name, err)
+		}
+	}
+	return nil
+}
+
+// deleteNamespacePolicy removes the policy from the indices it manages and deletes it, new indices are then managed
+// by the global policies
+func deleteNamespacePolicy(ctx spi.ComponentContext, policy ismPolicyResponse) error {
+	ctx.Log().Oncef("Deleting OpenSearch ISM policy %s", policy.ID)
+	for _, template := range policy.Policy.ISMTemplate {
+		for _, dataStream := range template.IndexPatterns {
+			if _, err := osRequest(ctx, http.MethodPost, "/_plugins/_ism/remove/"+dataStream, nil, nil, http.StatusOK); err != nil {
+				return ctx.Log().ErrorfNewErr("Failed removing the OpenSearch ISM policy %s from %s: %v", policy.ID, dataStream, err)
+			}
+		}
+	}
+	if _, err := osRequest(ctx, http.MethodDelete, "/_plugins/_ism/policies/"+policy.ID, nil, nil, http.StatusOK, http.StatusNotFound); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed deleting the OpenSearch ISM policy %s: %v", policy.ID, err)
+	}
+	return nil
+}
+
+// buildNamespacePolicies returns the ISM policy of each namespace policy that manages at least one namespace.  A
+// namespace is managed by the first policy that declares it, directly or through a project.
+func buildNamespacePolicies(ctx spi.ComponentContext) (map[string]ismPolicy, error) {
+	policies := map[string]ismPolicy{}
+	opensearch := ctx.EffectiveCR().Spec.Components.Elasticsearch
+	if opensearch == nil {
+		return policies, nil
+	}
+	managed := map[string]string{}
+	for _, namespacePolicy := range opensearch.NamespacePolicies {
+		namespaces, err := resolvePolicyNamespaces(ctx, namespacePolicy)
+		if err != nil {
+			return nil, err
+		}
+		var patterns []string
+		for _, ns := range namespaces {
+			if owner, ok := managed[ns]; ok {
+				if owner != namespacePolicy.PolicyName {
+					ctx.Log().Oncef("Namespace %s is already managed by OpenSearch ISM policy %s, ignoring it in policy %s", ns, owner, namespacePolicy.PolicyName)
+				}
+				continue
+			}
+			managed[ns] = namespacePolicy.PolicyName
+			patterns = append(patterns, applicationIndexPrefix+ns)
+		}
+		if len(patterns) > 0 {
+			policies[namespacePolicy.PolicyName] = newNamespacePolicy(namespacePolicy, patterns)
+		}
+	}
+	return policies, nil
+}
+
+// resolvePolicyNamespaces returns the namespaces of a policy, including the namespaces of its projects
+func resolvePolicyNamespaces(ctx spi.ComponentContext, policy vzapi.OpenSearchNamespacePolicy) ([]string, error) {
+	namespaces := append([]string{}, policy.Namespaces...)
+	for _, projectName := range policy.Projects {
+		project := &vzappclusters.VerrazzanoProject{}
+		nsn := types.NamespacedName{Namespace: vzconst.VerrazzanoMultiClusterNamespace, Name: projectName}
+		if err := ctx.Client().Get(context.TODO(), nsn, project); err != nil {
+			if errors.IsNotFound(err) {
+				ctx.Log().Oncef("VerrazzanoProject %s of OpenSearch ISM policy %s not found", projectName, policy.PolicyName)
+				continue
+			}
+			return nil, ctx.Log().ErrorfNewErr("Failed getting VerrazzanoProject %s: %v", projectName, err)
+		}
+		for _, ns := range project.Spec.Template.Namespaces {
+			namespaces = append(namespaces, ns.Metadata.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+// newNamespacePolicy returns an ISM policy that rolls over the indices and deletes them once they reach the
+// minimum age
+func newNamespacePolicy(policy vzapi.OpenSearchNamespacePolicy, patterns []string) ismPolicy {
+	minIndexAge := defaultPolicyMinIndexAge
+	if policy.MinIndexAge != nil {
+		minIndexAge = *policy.MinIndexAge
+	}
+	sort.Strings(patterns)
+	return ismPolicy{
+		Description:  namespacePolicyDescription,
+		DefaultState: "ingest",
+		States: []ismState{
+			{
+				Name:    "ingest",
+				Actions: []map[string]interface{}{{"rollover": newRolloverAction(policy.Rollover)}},
+				Transitions: []ismTransition{
+					{
+						StateName:  "delete",
+						Conditions: map[string]string{"min_index_age": minIndexAge},
+					},
+				},
+			},
+			{
+				Name:        "delete",
+				Actions:     []map[string]interface{}{{"delete": map[string]interface{}{}}},
+				Transitions: []ismTransition{},
+			},
+		},
+		ISMTemplate: []ismTemplate{
+			{
+				IndexPatterns: patterns,
+				Priority:      namespacePolicyPriority,
+			},
+		},
+	}
+}
+
+func newRolloverAction(rollover vmov1.RolloverPolicy) map[string]interface{} {
+	action := map[string]interface{}{"min_index_age": defaultRolloverMinIndexAge}
+	if rollover.MinIndexAge != nil {
+		action["min_index_age"] = *rollover.MinIndexAge
+	}
+	if rollover.MinSize != nil {
+		action["min_size"] = *rollover.MinSize
+	}
+	if rollover.MinDocCount != nil {
+		action["min_doc_count"] = *rollover.MinDocCount
+	}
+	return action
+}
+
+// policyNeedsUpdate returns true if the existing policy differs from the desired one.  OpenSearch adds defaults to
+// the stored actions, such as retry settings, so only the actions set by Verrazzano are compared.
+func policyNeedsUpdate(desired ismPolicy, existing ismPolicy) bool {
+	// Round trip the desired policy so that its values have the same types as the decoded existing policy
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return true
+	}
+	normalized := ismPolicy{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return true
+	}
+	if normalized.DefaultState != existing.DefaultState || len(normalized.States) != len(existing.States) ||
+		len(existing.ISMTemplate) != 1 {
+		return true
+	}
+	for i, state := range normalized.States {
+		existingState := existing.States[i]
+		if state.Name != existingState.Name || len(state.Actions) != len(existingState.Actions) ||
+			!reflect.DeepEqual(state.Transitions, existingState.Transitions) {
+			return true
+		}
+		for j, action := range state.Actions {
+			for key, value := range action {
+				if !reflect.DeepEqual(value, existingState.Actions[j][key]) {
+					return true
+				}
+			}
+		}
+	}
+	existingPatterns := append([]string{}, existing.ISMTemplate[0].IndexPatterns...)
+	sort.Strings(existingPatterns)
+	return existing.ISMTemplate[0].Priority != normalized.ISMTemplate[0].Priority ||
+		!reflect.DeepEqual(existingPatterns, normalized.ISMTemplate[0].IndexPatterns)
+}
+
+// hasProjectPolicies returns true if any namespace policy manages the namespaces of projects, these must be refreshed
+// when the projects change
+func hasProjectPolicies(effectiveCR *vzapi.Verrazzano) bool {
+	opensearch := effectiveCR.Spec.Components.Elasticsearch
+	if opensearch == nil {
+		return false
+	}
+	for _, policy := range opensearch.NamespacePolicies {
+		if len(policy.Projects) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedPolicyNames(policies map[string]ismPolicy) []string {
+	var names []string
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
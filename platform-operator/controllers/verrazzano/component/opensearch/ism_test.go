@@ -0,0 +1,152 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	vzappclusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const listPoliciesRequest = "GET /_plugins/_ism/policies?size=1000"
+
+func createNamespacePolicyVZ(policies ...vzapi.OpenSearchNamespacePolicy) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Elasticsearch: &vzapi.ElasticsearchComponent{
+					NamespacePolicies: policies,
+				},
+			},
+		},
+	}
+}
+
+func createProject(name string, namespaces ...string) *vzappclusters.VerrazzanoProject {
+	project := &vzappclusters.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: vzconst.VerrazzanoMultiClusterNamespace},
+	}
+	for _, ns := range namespaces {
+		project.Spec.Template.Namespaces = append(project.Spec.Template.Namespaces, vzappclusters.NamespaceTemplate{
+			Metadata: metav1.ObjectMeta{Name: ns},
+		})
+	}
+	return project
+}
+
+// TestBuildNamespacePolicies tests building the ISM policies of namespaces and projects
+// GIVEN namespace policies for namespaces and projects
+// WHEN buildNamespacePolicies is called
+// THEN each namespace is managed by the first policy that declares it
+func TestBuildNamespacePolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = vzappclusters.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(createProject("proj", "app1", "app2")).Build()
+	age := "14d"
+	vz := createNamespacePolicyVZ(
+		vzapi.OpenSearchNamespacePolicy{PolicyName: "direct", Namespaces: []string{"app1"}, MinIndexAge: &age},
+		vzapi.OpenSearchNamespacePolicy{PolicyName: "project", Projects: []string{"proj", "missing"}},
+		vzapi.OpenSearchNamespacePolicy{PolicyName: "empty", Namespaces: []string{"app2"}},
+	)
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	policies, err := buildNamespacePolicies(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.Equal(t, []string{"verrazzano-application-app1"}, policies["direct"].ISMTemplate[0].IndexPatterns)
+	assert.Equal(t, "14d", policies["direct"].States[0].Transitions[0].Conditions["min_index_age"])
+	assert.Equal(t, []string{"verrazzano-application-app2"}, policies["project"].ISMTemplate[0].IndexPatterns)
+	assert.Equal(t, defaultPolicyMinIndexAge, policies["project"].States[0].Transitions[0].Conditions["min_index_age"])
+	assert.Equal(t, namespacePolicyPriority, policies["project"].ISMTemplate[0].Priority)
+	assert.True(t, hasProjectPolicies(vz))
+}
+
+// TestCreateNamespacePolicies tests creating the ISM policies of namespaces
+// GIVEN a namespace policy that does not exist, and a namespace policy that is no longer declared
+// WHEN createOrUpdateNamespacePolicies is called
+// THEN the undeclared policy is deleted, and the declared policy is created and applied to the existing indices
+func TestCreateNamespacePolicies(t *testing.T) {
+	old := ismPolicyList{Policies: []ismPolicyResponse{
+		{ID: "old", Policy: newNamespacePolicy(vzapi.OpenSearchNamespacePolicy{}, []string{"verrazzano-application-old"})},
+		{ID: "vmi", Policy: ismPolicy{Description: "__vmi-managed__"}},
+	}}
+	data, _ := json.Marshal(old)
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses[listPoliciesRequest] = fakeOSResponse{code: http.StatusOK, body: string(data)}
+	fakeOS.responses["GET /_plugins/_ism/policies/new"] = fakeOSResponse{code: http.StatusNotFound}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	vz := createNamespacePolicyVZ(vzapi.OpenSearchNamespacePolicy{PolicyName: "new", Namespaces: []string{"app"}})
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false)
+	assert.NoError(t, createOrUpdateNamespacePolicies(ctx))
+	assert.Equal(t, []string{
+		listPoliciesRequest,
+		"POST /_plugins/_ism/remove/verrazzano-application-old",
+		"DELETE /_plugins/_ism/policies/old",
+		"GET /_plugins/_ism/policies/new",
+		"PUT /_plugins/_ism/policies/new",
+		"POST /_plugins/_ism/add/verrazzano-application-app",
+		"POST /_plugins/_ism/change_policy/verrazzano-application-app",
+	}, fakeOS.requests)
+}
+
+// TestUpdateNamespacePolicies tests updating the ISM policies of namespaces
+// GIVEN namespace policies that already exist
+// WHEN createOrUpdateNamespacePolicies is called
+// THEN only the policy that has changed is updated
+func TestUpdateNamespacePolicies(t *testing.T) {
+	vz := createNamespacePolicyVZ(
+		vzapi.OpenSearchNamespacePolicy{PolicyName: "same", Namespaces: []string{"app1"}},
+		vzapi.OpenSearchNamespacePolicy{PolicyName: "changed", Namespaces: []string{"app2"}},
+	)
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false)
+	desired, err := buildNamespacePolicies(ctx)
+	assert.NoError(t, err)
+
+	// OpenSearch adds retry settings to the stored actions
+	same := desired["same"]
+	same.States[0].Actions[0]["retry"] = map[string]interface{}{"count": 3}
+	sameData, _ := json.Marshal(ismPolicyResponse{ID: "same", SeqNo: 1, PrimaryTerm: 1, Policy: same})
+	size := "10gb"
+	changed := newNamespacePolicy(vzapi.OpenSearchNamespacePolicy{Rollover: vmov1.RolloverPolicy{MinSize: &size}}, []string{"verrazzano-application-app2"})
+	changedData, _ := json.Marshal(ismPolicyResponse{ID: "changed", SeqNo: 4, PrimaryTerm: 2, Policy: changed})
+
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses["GET /_plugins/_ism/policies/same"] = fakeOSResponse{code: http.StatusOK, body: string(sameData)}
+	fakeOS.responses["GET /_plugins/_ism/policies/changed"] = fakeOSResponse{code: http.StatusOK, body: string(changedData)}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	assert.NoError(t, createOrUpdateNamespacePolicies(ctx))
+	assert.Contains(t, fakeOS.requests, "PUT /_plugins/_ism/policies/changed?if_seq_no=4&if_primary_term=2")
+	for _, request := range fakeOS.requests {
+		assert.NotContains(t, request, "PUT /_plugins/_ism/policies/same")
+	}
+}
+
+// TestCreateNamespacePoliciesListFailure tests creating the ISM policies of namespaces
+// GIVEN the OpenSearch API fails to list the policies
+// WHEN createOrUpdateNamespacePolicies is called
+// THEN an error is returned
+func TestCreateNamespacePoliciesListFailure(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses[listPoliciesRequest] = fakeOSResponse{code: http.StatusInternalServerError}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	vz := createNamespacePolicyVZ(vzapi.OpenSearchNamespacePolicy{PolicyName: "new", Namespaces: []string{"app"}})
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false)
+	assert.Error(t, createOrUpdateNamespacePolicies(ctx))
+}
@@ -100,6 +100,14 @@ func (o opensearchComponent) PreInstall(ctx spi.ComponentContext) error {
 	if err := common.EnsureBackupSecret(ctx.Client()); err != nil {
 		return err
 	}
+	// copy the S3 credentials of the snapshot repositories into the backup VMI secret
+	if err := copySnapshotCredentials(ctx); err != nil {
+		return err
+	}
+	// move the shards off the data nodes that are about to be removed
+	if err := drainDataNodes(ctx); err != nil {
+		return err
+	}
 	ctx.Log().Debug("OpenSearch pre-install")
 	if err := common.CreateAndLabelVMINamespaces(ctx); err != nil {
 		return ctx.Log().ErrorfNewErr("Failed creating/labeling namespace %s for OpenSearch : %v", ComponentNamespace, err)
@@ -131,7 +139,15 @@ func (o opensearchComponent) PostUninstall(context spi.ComponentContext) error {
 // PreUpgrade OpenSearch component pre-upgrade processing
 func (o opensearchComponent) PreUpgrade(ctx spi.ComponentContext) error {
 	// create or update  VMI secret
-	return common.EnsureVMISecret(ctx.Client())
+	if err := common.EnsureVMISecret(ctx.Client()); err != nil {
+		return err
+	}
+	// copy the S3 credentials of the snapshot repositories into the backup VMI secret
+	if err := copySnapshotCredentials(ctx); err != nil {
+		return err
+	}
+	// move the shards off the data nodes that are about to be removed
+	return drainDataNodes(ctx)
 }
 
 // Upgrade OpenSearch component upgrade processing
@@ -147,7 +163,13 @@ func (o opensearchComponent) IsReady(ctx spi.ComponentContext) bool {
 // PostInstall OpenSearch post-install processing
 func (o opensearchComponent) PostInstall(ctx spi.ComponentContext) error {
 	ctx.Log().Debugf("OpenSearch component post-upgrade")
-	return common.CheckIngressesAndCerts(ctx, o)
+	if err := common.CheckIngressesAndCerts(ctx, o); err != nil {
+		return err
+	}
+	if err := clearDrainedNodes(ctx); err != nil {
+		return err
+	}
+	return configureIndexManagement(ctx)
 }
 
 // PostUpgrade OpenSearch post-upgrade processing
@@ -156,7 +178,13 @@ func (o opensearchComponent) PostUpgrade(ctx spi.ComponentContext) error {
 	if err := common.CheckIngressesAndCerts(ctx, o); err != nil {
 		return err
 	}
-	return o.updateElasticsearchResources(ctx)
+	if err := clearDrainedNodes(ctx); err != nil {
+		return err
+	}
+	if err := o.updateElasticsearchResources(ctx); err != nil {
+		return err
+	}
+	return configureIndexManagement(ctx)
 }
 
 // updateElasticsearchResources updates elasticsearch resources
@@ -206,12 +234,22 @@ func (o opensearchComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzan
 		return err
 	}
 	// Reject edits that duplicate names of install args or node groups
-	return validateNoDuplicatedConfiguration(new)
+	if err := validateNoDuplicatedConfiguration(new); err != nil {
+		return err
+	}
+	// Reject scaling down the data nodes if shard copies would be lost
+	if err := validateDataNodeScaleDown(old, new); err != nil {
+		return err
+	}
+	return validateIndexManagement(new)
 }
 
 // ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
 func (o opensearchComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) error {
-	return validateNoDuplicatedConfiguration(vz)
+	if err := validateNoDuplicatedConfiguration(vz); err != nil {
+		return err
+	}
+	return validateIndexManagement(vz)
 }
 
 // Name returns the component name
@@ -241,6 +279,31 @@ func (o opensearchComponent) GetIngressNames(ctx spi.ComponentContext) []types.N
 	return ingressNames
 }
 
+// GetNetworkPolicyRules - gets the network peers of the OpenSearch pods outside of the verrazzano-system namespace,
+// Jaeger stores its traces in OpenSearch and the snapshots are written to object storage
+func (o opensearchComponent) GetNetworkPolicyRules(_ spi.ComponentContext) []spi.NetworkPolicyRules {
+	var rules []spi.NetworkPolicyRules
+	for _, app := range []string{"system-es-master", "system-es-data", "system-es-ingest"} {
+		rules = append(rules, spi.NetworkPolicyRules{
+			PodLabels: map[string]string{"app": app},
+			Ingress:   []spi.NetworkPeer{{Namespace: constants.VerrazzanoMonitoringNamespace, Ports: []int32{9200}}},
+			Egress:    []spi.NetworkPeer{{}},
+		})
+	}
+	return rules
+}
+
+// Verify that opensearchComponent implements ComponentAvailability
+var _ spi.ComponentAvailability = opensearchComponent{}
+
+// GetPodDisruptionBudgets - gets the OpenSearch master pods, evicting one master at a time keeps the quorum of the
+// cluster
+func (o opensearchComponent) GetPodDisruptionBudgets(_ spi.ComponentContext) []spi.PodDisruptionBudget {
+	return []spi.PodDisruptionBudget{
+		{Name: "vmi-system-es-master", PodLabels: map[string]string{"app": "system-es-master"}},
+	}
+}
+
 // GetCertificateNames - gets the names of the certificates associated with this component
 func (o opensearchComponent) GetCertificateNames(_ spi.ComponentContext) []types.NamespacedName {
 	return []types.NamespacedName{
@@ -83,6 +83,8 @@ func TestInstall(t *testing.T) {
 //  WHEN I call PostInstall
 //  THEN no error is returned
 func TestPostInstall(t *testing.T) {
+	SetOSRequestFunc(newFakeOSRequest().request)
+	defer SetDefaultOSRequestFunc()
 	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
 	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{
 		Spec: vzapi.VerrazzanoSpec{
@@ -211,6 +213,8 @@ func TestUpgrade(t *testing.T) {
 //  WHEN I call PostUpgrade
 //  THEN no error is returned
 func TestPostUpgrade(t *testing.T) {
+	SetOSRequestFunc(newFakeOSRequest().request)
+	defer SetDefaultOSRequestFunc()
 	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
 	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{
 		Spec: vzapi.VerrazzanoSpec{
@@ -309,6 +313,8 @@ func getBoolPtr(b bool) *bool {
 }
 
 func TestValidateUpdate(t *testing.T) {
+	config.TestProfilesDir = "../../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
 	disabled := false
 	var pvc1Gi, _ = resource.ParseQuantity("1Gi")
 	var pvc2Gi, _ = resource.ParseQuantity("2Gi")
@@ -0,0 +1,358 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/backup"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// Snapshot states reported by OpenSearch
+	snapshotStateInProgress = "IN_PROGRESS"
+	snapshotStateFailed     = "FAILED"
+
+	// snapshotTimeFormat is the format of the time suffix of the snapshot names
+	snapshotTimeFormat = "20060102-150405"
+
+	// snapshotPollInterval is how often the state of a snapshot in progress is checked
+	snapshotPollInterval = time.Minute
+	// projectPolicyRefreshInterval is how often the ISM policies of projects are refreshed to pick up new namespaces
+	projectPolicyRefreshInterval = 10 * time.Minute
+)
+
+// getCurrentTime returns the current time, can be overridden for unit testing
+var getCurrentTime = time.Now
+
+type (
+	snapshotRepository struct {
+		Type     string                 `json:"type"`
+		Settings map[string]interface{} `json:"settings"`
+	}
+
+	snapshotList struct {
+		Snapshots []snapshotInfo `json:"snapshots"`
+	}
+
+	snapshotInfo struct {
+		Snapshot          string            `json:"snapshot"`
+		State             string            `json:"state"`
+		Reason            string            `json:"reason,omitempty"`
+		StartTimeInMillis int64             `json:"start_time_in_millis"`
+		EndTimeInMillis   int64             `json:"end_time_in_millis"`
+		Shards            snapshotShardInfo `json:"shards"`
+	}
+
+	snapshotShardInfo struct {
+		Total  int `json:"total"`
+		Failed int `json:"failed"`
+	}
+)
+
+// copySnapshotCredentials copies the S3 credentials of the snapshot repositories into the VMI backup secret, the
+// OpenSearch nodes add them to their keystore when they start
+func copySnapshotCredentials(ctx spi.ComponentContext) error {
+	snapshots := getSnapshots(ctx.EffectiveCR())
+	if snapshots == nil || snapshots.S3CredentialsSecret == "" || ctx.IsDryRun() {
+		return nil
+	}
+	source := &corev1.Secret{}
+	nsn := types.NamespacedName{Namespace: ctx.EffectiveCR().Namespace, Name: snapshots.S3CredentialsSecret}
+	if err := ctx.Client().Get(context.TODO(), nsn, source); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the OpenSearch snapshot credentials secret %s/%s: %v", nsn.Namespace, nsn.Name, err)
+	}
+	accessKey := source.Data[constants.ObjectStoreAccessKey]
+	secretKey := source.Data[constants.ObjectStoreAccessSecretKey]
+	if len(accessKey) == 0 || len(secretKey) == 0 {
+		return ctx.Log().ErrorfNewErr("Failed, the OpenSearch snapshot credentials secret %s/%s must contain the keys %s and %s",
+			nsn.Namespace, nsn.Name, constants.ObjectStoreAccessKey, constants.ObjectStoreAccessSecretKey)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.VMIBackupSecretName,
+			Namespace: ComponentNamespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), secret, func() error {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[constants.ObjectStoreAccessKey] = accessKey
+		secret.Data[constants.ObjectStoreAccessSecretKey] = secretKey
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating secret %s/%s: %v", ComponentNamespace, constants.VMIBackupSecretName, err)
+	}
+	return nil
+}
+
+// createOrUpdateSnapshotRepositories registers the snapshot repositories with the OpenSearch cluster.  Repositories
+// that are no longer declared are left registered, so that their snapshots can still be restored.
+func createOrUpdateSnapshotRepositories(ctx spi.ComponentContext) error {
+	snapshots := getSnapshots(ctx.EffectiveCR())
+	if snapshots == nil {
+		return nil
+	}
+	for _, repository := range snapshots.Repositories {
+		if _, err := osRequest(ctx, http.MethodPut, "/_snapshot/"+repository.Name, newSnapshotRepository(repository), nil, http.StatusOK); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed registering the OpenSearch snapshot repository %s: %v", repository.Name, err)
+		}
+	}
+	return nil
+}
+
+func newSnapshotRepository(repository vzapi.OpenSearchSnapshotRepository) snapshotRepository {
+	if repository.FileSystem != nil {
+		return snapshotRepository{
+			Type:     "fs",
+			Settings: map[string]interface{}{"location": repository.FileSystem.Location},
+		}
+	}
+	// The credentials of the default client are loaded from the VMI backup secret
+	settings := map[string]interface{}{
+		"bucket": repository.S3.Bucket,
+		"client": "default",
+	}
+	if repository.S3.BasePath != "" {
+		settings["base_path"] = repository.S3.BasePath
+	}
+	if repository.S3.Endpoint != "" {
+		settings["endpoint"] = repository.S3.Endpoint
+	}
+	if repository.S3.Region != "" {
+		settings["region"] = repository.S3.Region
+	}
+	if repository.S3.PathStyleAccess {
+		settings["path_style_access"] = true
+	}
+	return snapshotRepository{
+		Type:     "s3",
+		Settings: settings,
+	}
+}
+
+// configureIndexManagement applies the namespace ISM policies and registers the snapshot repositories
+func configureIndexManagement(ctx spi.ComponentContext) error {
+	if ctx.IsDryRun() {
+		return nil
+	}
+	if err := createOrUpdateNamespacePolicies(ctx); err != nil {
+		return err
+	}
+	return createOrUpdateSnapshotRepositories(ctx)
+}
+
+// ReconcileIndexManagement performs the periodic OpenSearch index management tasks: the ISM policies of projects are
+// refreshed, the scheduled snapshots that are due are taken and the state of the snapshots in progress is checked.
+// It returns the status of the last snapshot of each schedule, and how long to wait before calling it again; zero
+// means there is nothing to check later.
+func ReconcileIndexManagement(ctx spi.ComponentContext) ([]vzapi.OpenSearchSnapshotStatus, time.Duration, error) {
+	var requeueAfter time.Duration
+	if hasProjectPolicies(ctx.EffectiveCR()) {
+		if err := createOrUpdateNamespacePolicies(ctx); err != nil {
+			return nil, 0, err
+		}
+		requeueAfter = projectPolicyRefreshInterval
+	}
+
+	snapshots := getSnapshots(ctx.EffectiveCR())
+	if snapshots == nil {
+		return nil, requeueAfter, nil
+	}
+	now := getCurrentTime()
+	var statuses []vzapi.OpenSearchSnapshotStatus
+	for _, schedule := range snapshots.Schedules {
+		status, err := reconcileSnapshotSchedule(ctx, schedule, findSnapshotStatus(ctx.ActualCR(), schedule.Name), now)
+		if err != nil {
+			return nil, 0, err
+		}
+		statuses = append(statuses, status)
+
+		delay := snapshotPollInterval
+		if status.State != snapshotStateInProgress {
+			next, err := time.Parse(time.RFC3339, status.NextSnapshotTime)
+			if err != nil {
+				return nil, 0, err
+			}
+			if delay = next.Sub(now); delay <= 0 {
+				delay = snapshotPollInterval
+			}
+		}
+		if requeueAfter == 0 || delay < requeueAfter {
+			requeueAfter = delay
+		}
+	}
+	return statuses, requeueAfter, nil
+}
+
+// reconcileSnapshotSchedule refreshes the state of the last snapshot of the schedule and takes a new snapshot if one
+// is due.  Snapshots are not started while the previous one is still in progress.
+func reconcileSnapshotSchedule(ctx spi.ComponentContext, schedule vzapi.OpenSearchSnapshotSchedule, status vzapi.OpenSearchSnapshotStatus, now time.Time) (vzapi.OpenSearchSnapshotStatus, error) {
+	sched, err := cron.ParseStandard(schedule.Schedule)
+	if err != nil {
+		return status, ctx.Log().ErrorfNewErr("Failed parsing the schedule of OpenSearch snapshot schedule %s: %v", schedule.Name, err)
+	}
+
+	if status.State == snapshotStateInProgress {
+		if err := refreshSnapshotStatus(ctx, &status); err != nil {
+			return status, err
+		}
+		if status.State != snapshotStateInProgress && schedule.MaxCount != nil {
+			if err := deleteOldSnapshots(ctx, schedule); err != nil {
+				return status, err
+			}
+		}
+	}
+
+	// The first snapshot of a schedule is taken at its next scheduled time, the next time is also moved forward if
+	// the schedule has been changed to run earlier
+	next, err := time.Parse(time.RFC3339, status.NextSnapshotTime)
+	if err != nil || sched.Next(now).Before(next) {
+		next = sched.Next(now)
+	}
+	if !now.Before(next) && status.State != snapshotStateInProgress {
+		status = startSnapshot(ctx, schedule, now)
+		next = sched.Next(now)
+	}
+	status.Schedule = schedule.Name
+	status.NextSnapshotTime = next.UTC().Format(time.RFC3339)
+	return status, nil
+}
+
+// startSnapshot starts a snapshot of the schedule without waiting for it to complete
+func startSnapshot(ctx spi.ComponentContext, schedule vzapi.OpenSearchSnapshotSchedule, now time.Time) vzapi.OpenSearchSnapshotStatus {
+	status := vzapi.OpenSearchSnapshotStatus{
+		Name:       fmt.Sprintf("%s-%s", schedule.Name, now.UTC().Format(snapshotTimeFormat)),
+		Repository: schedule.Repository,
+		State:      snapshotStateInProgress,
+		StartTime:  now.UTC().Format(time.RFC3339),
+	}
+	payload := map[string]interface{}{}
+	if len(schedule.Indices) > 0 {
+		payload["indices"] = strings.Join(schedule.Indices, ",")
+	}
+	ctx.Log().Infof("Starting OpenSearch snapshot %s in repository %s", status.Name, status.Repository)
+	path := fmt.Sprintf("/_snapshot/%s/%s", status.Repository, status.Name)
+	if _, err := osRequest(ctx, http.MethodPut, path, payload, nil, http.StatusOK); err != nil {
+		// The failure is reported in the status, the next snapshot is attempted at the next scheduled time
+		ctx.Log().Errorf("Failed starting OpenSearch snapshot %s: %v", status.Name, err)
+		status.State = snapshotStateFailed
+		status.EndTime = status.StartTime
+		status.Message = err.Error()
+	}
+	return status
+}
+
+// refreshSnapshotStatus updates the status with the state of the snapshot reported by OpenSearch
+func refreshSnapshotStatus(ctx spi.ComponentContext, status *vzapi.OpenSearchSnapshotStatus) error {
+	snapshots := &snapshotList{}
+	path := fmt.Sprintf("/_snapshot/%s/%s", status.Repository, status.Name)
+	code, err := osRequest(ctx, http.MethodGet, path, nil, snapshots, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting OpenSearch snapshot %s: %v", status.Name, err)
+	}
+	if code == http.StatusNotFound || len(snapshots.Snapshots) == 0 {
+		status.State = snapshotStateFailed
+		status.Message = fmt.Sprintf("Snapshot %s not found in repository %s", status.Name, status.Repository)
+		return nil
+	}
+	snapshot := snapshots.Snapshots[0]
+	status.State = snapshot.State
+	if snapshot.EndTimeInMillis > 0 {
+		status.EndTime = time.UnixMilli(snapshot.EndTimeInMillis).UTC().Format(time.RFC3339)
+	}
+	switch {
+	case snapshot.Reason != "":
+		status.Message = snapshot.Reason
+	case snapshot.Shards.Failed > 0:
+		status.Message = fmt.Sprintf("%d of %d shards failed", snapshot.Shards.Failed, snapshot.Shards.Total)
+	}
+	return nil
+}
+
+// deleteOldSnapshots deletes the oldest completed snapshots of the schedule that exceed its maximum count
+func deleteOldSnapshots(ctx spi.ComponentContext, schedule vzapi.OpenSearchSnapshotSchedule) error {
+	snapshots := &snapshotList{}
+	path := fmt.Sprintf("/_snapshot/%s/%s-*", schedule.Repository, schedule.Name)
+	if _, err := osRequest(ctx, http.MethodGet, path, nil, snapshots, http.StatusOK); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing the OpenSearch snapshots of schedule %s: %v", schedule.Name, err)
+	}
+	var completed []snapshotInfo
+	for _, snapshot := range snapshots.Snapshots {
+		if isScheduleSnapshot(schedule.Name, snapshot.Snapshot) && snapshot.State != snapshotStateInProgress {
+			completed = append(completed, snapshot)
+		}
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].StartTimeInMillis < completed[j].StartTimeInMillis
+	})
+	for i := 0; i < len(completed)-int(*schedule.MaxCount); i++ {
+		ctx.Log().Infof("Deleting OpenSearch snapshot %s from repository %s", completed[i].Snapshot, schedule.Repository)
+		path := fmt.Sprintf("/_snapshot/%s/%s", schedule.Repository, completed[i].Snapshot)
+		if _, err := osRequest(ctx, http.MethodDelete, path, nil, nil, http.StatusOK, http.StatusNotFound); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting OpenSearch snapshot %s: %v", completed[i].Snapshot, err)
+		}
+	}
+	return nil
+}
+
+// isScheduleSnapshot returns true if the snapshot was taken by the schedule, the name of another schedule may start
+// with the name of this one
+func isScheduleSnapshot(scheduleName string, snapshotName string) bool {
+	suffix := strings.TrimPrefix(snapshotName, scheduleName+"-")
+	if suffix == snapshotName {
+		return false
+	}
+	_, err := time.Parse(snapshotTimeFormat, suffix)
+	return err == nil
+}
+
+// findSnapshotStatus returns the status of the last snapshot of the schedule, or an empty status if none was taken
+func findSnapshotStatus(cr *vzapi.Verrazzano, scheduleName string) vzapi.OpenSearchSnapshotStatus {
+	if cr.Status.OpenSearch != nil {
+		for _, status := range cr.Status.OpenSearch.Snapshots {
+			if status.Schedule == scheduleName {
+				return status
+			}
+		}
+	}
+	return vzapi.OpenSearchSnapshotStatus{Schedule: scheduleName}
+}
+
+// getSnapshots returns the snapshot repositories and schedules of the OpenSearch component, together with those that
+// back up OpenSearch for the scheduled platform backups
+func getSnapshots(effectiveCR *vzapi.Verrazzano) *vzapi.OpenSearchSnapshots {
+	var snapshots *vzapi.OpenSearchSnapshots
+	if effectiveCR.Spec.Components.Elasticsearch != nil {
+		snapshots = effectiveCR.Spec.Components.Elasticsearch.Snapshots
+	}
+	backupSnapshots := backup.OpenSearchSnapshots(effectiveCR)
+	if backupSnapshots == nil {
+		return snapshots
+	}
+	if snapshots == nil {
+		return backupSnapshots
+	}
+	// The backup credentials secret is validated to be the same as the snapshot credentials secret, if both are set
+	return &vzapi.OpenSearchSnapshots{
+		Repositories:        append(append([]vzapi.OpenSearchSnapshotRepository{}, snapshots.Repositories...), backupSnapshots.Repositories...),
+		Schedules:           append(append([]vzapi.OpenSearchSnapshotSchedule{}, snapshots.Schedules...), backupSnapshots.Schedules...),
+		S3CredentialsSecret: backupSnapshots.S3CredentialsSecret,
+	}
+}
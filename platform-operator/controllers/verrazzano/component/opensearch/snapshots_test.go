@@ -0,0 +1,269 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testSnapshotTime = time.Date(2022, 6, 1, 2, 0, 0, 0, time.UTC)
+
+func createSnapshotsVZ(schedules ...vzapi.OpenSearchSnapshotSchedule) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Elasticsearch: &vzapi.ElasticsearchComponent{
+					Snapshots: &vzapi.OpenSearchSnapshots{
+						Repositories: []vzapi.OpenSearchSnapshotRepository{
+							{
+								Name:       "backups",
+								FileSystem: &vzapi.OpenSearchFileSystemRepository{Location: "/snapshots"},
+							},
+							{
+								Name: "s3",
+								S3:   &vzapi.OpenSearchS3Repository{Bucket: "bucket", Region: "us-ashburn-1", PathStyleAccess: true},
+							},
+						},
+						Schedules:           schedules,
+						S3CredentialsSecret: "s3-creds",
+					},
+				},
+			},
+		},
+	}
+}
+
+func setSnapshotTime(t time.Time) {
+	getCurrentTime = func() time.Time { return t }
+}
+
+// TestCopySnapshotCredentials tests copying the S3 credentials into the VMI backup secret
+// GIVEN a Verrazzano resource with S3 credentials
+// WHEN copySnapshotCredentials is called
+// THEN the credentials are copied, and an error is returned if they are incomplete
+func TestCopySnapshotCredentials(t *testing.T) {
+	vz := createSnapshotsVZ()
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-creds", Namespace: "default"},
+		Data: map[string][]byte{
+			constants.ObjectStoreAccessKey:       []byte("access"),
+			constants.ObjectStoreAccessSecretKey: []byte("secret"),
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(source).Build()
+	assert.NoError(t, copySnapshotCredentials(spi.NewFakeContext(c, vz, nil, false)))
+	secret := &corev1.Secret{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: constants.VMIBackupSecretName}, secret))
+	assert.Equal(t, "access", string(secret.Data[constants.ObjectStoreAccessKey]))
+	assert.Equal(t, "secret", string(secret.Data[constants.ObjectStoreAccessSecretKey]))
+
+	delete(source.Data, constants.ObjectStoreAccessKey)
+	c = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(source).Build()
+	assert.Error(t, copySnapshotCredentials(spi.NewFakeContext(c, vz, nil, false)))
+}
+
+// TestCreateSnapshotRepositories tests registering the snapshot repositories
+// GIVEN file system and S3 snapshot repositories
+// WHEN createOrUpdateSnapshotRepositories is called
+// THEN the repositories are registered with their settings
+func TestCreateSnapshotRepositories(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().Build(), createSnapshotsVZ(), nil, false)
+	assert.NoError(t, createOrUpdateSnapshotRepositories(ctx))
+	assert.Equal(t, []string{"PUT /_snapshot/backups", "PUT /_snapshot/s3"}, fakeOS.requests)
+	assert.JSONEq(t, `{"type":"fs","settings":{"location":"/snapshots"}}`, fakeOS.bodies["PUT /_snapshot/backups"])
+	assert.JSONEq(t, `{"type":"s3","settings":{"bucket":"bucket","client":"default","region":"us-ashburn-1","path_style_access":true}}`,
+		fakeOS.bodies["PUT /_snapshot/s3"])
+}
+
+// TestGetSnapshotsWithBackup tests merging the snapshots of the scheduled platform backups
+// GIVEN a Verrazzano resource with OpenSearch snapshots and a backup schedule of OpenSearch
+// WHEN getSnapshots is called
+// THEN the repository and schedule of the backups are added to those of the component
+func TestGetSnapshotsWithBackup(t *testing.T) {
+	vz := createSnapshotsVZ(vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *"})
+	vz.Spec.Backup = &vzapi.BackupSpec{
+		Storage: vzapi.BackupStorage{
+			Endpoint:          "http://minio.minio:9000",
+			Bucket:            "verrazzano",
+			CredentialsSecret: "s3-creds",
+		},
+		Schedules: []vzapi.BackupSchedule{{
+			Name:     "nightly",
+			Schedule: "0 1 * * *",
+			Stores:   []vzapi.BackupStore{vzapi.BackupStoreOpenSearch},
+		}},
+	}
+
+	snapshots := getSnapshots(vz)
+	assert.Len(t, snapshots.Repositories, 3)
+	assert.Equal(t, "verrazzano-platform-backup", snapshots.Repositories[2].Name)
+	assert.Len(t, snapshots.Schedules, 2)
+	assert.Equal(t, "platform-backup-nightly", snapshots.Schedules[1].Name)
+	assert.Equal(t, "s3-creds", snapshots.S3CredentialsSecret)
+	// The component snapshots are not modified
+	assert.Len(t, vz.Spec.Components.Elasticsearch.Snapshots.Repositories, 2)
+}
+
+// TestReconcileSnapshotScheduleFirstRun tests reconciling a snapshot schedule that has not run yet
+// GIVEN a snapshot schedule without status
+// WHEN ReconcileIndexManagement is called
+// THEN no snapshot is taken, and the next snapshot time is reported
+func TestReconcileSnapshotScheduleFirstRun(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+	setSnapshotTime(testSnapshotTime)
+	defer func() { getCurrentTime = time.Now }()
+
+	vz := createSnapshotsVZ(vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *"})
+	statuses, requeueAfter, err := ReconcileIndexManagement(spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false))
+	assert.NoError(t, err)
+	assert.Empty(t, fakeOS.requests)
+	assert.Equal(t, []vzapi.OpenSearchSnapshotStatus{{Schedule: "daily", NextSnapshotTime: "2022-06-01T03:00:00Z"}}, statuses)
+	assert.Equal(t, time.Hour, requeueAfter)
+}
+
+// TestReconcileSnapshotScheduleDue tests reconciling a snapshot schedule that is due
+// GIVEN a snapshot schedule whose next snapshot time has passed
+// WHEN ReconcileIndexManagement is called
+// THEN a snapshot of the schedule indices is started
+func TestReconcileSnapshotScheduleDue(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+	setSnapshotTime(testSnapshotTime.Add(time.Hour))
+	defer func() { getCurrentTime = time.Now }()
+
+	vz := createSnapshotsVZ(vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *", Indices: []string{"verrazzano-*", "app"}})
+	vz.Status.OpenSearch = &vzapi.OpenSearchStatus{Snapshots: []vzapi.OpenSearchSnapshotStatus{{Schedule: "daily", NextSnapshotTime: "2022-06-01T03:00:00Z"}}}
+	statuses, requeueAfter, err := ReconcileIndexManagement(spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PUT /_snapshot/backups/daily-20220601-030000"}, fakeOS.requests)
+	assert.JSONEq(t, `{"indices":"verrazzano-*,app"}`, fakeOS.bodies["PUT /_snapshot/backups/daily-20220601-030000"])
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "daily-20220601-030000", statuses[0].Name)
+	assert.Equal(t, snapshotStateInProgress, statuses[0].State)
+	assert.Equal(t, "2022-06-02T03:00:00Z", statuses[0].NextSnapshotTime)
+	assert.Equal(t, snapshotPollInterval, requeueAfter)
+}
+
+// TestReconcileSnapshotScheduleStartFailure tests reconciling a snapshot schedule that is due
+// GIVEN a snapshot schedule whose snapshot cannot be started
+// WHEN ReconcileIndexManagement is called
+// THEN the failure is reported in the status
+func TestReconcileSnapshotScheduleStartFailure(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses["PUT /_snapshot/backups/daily-20220601-030000"] = fakeOSResponse{code: http.StatusInternalServerError, body: "repository_exception"}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+	setSnapshotTime(testSnapshotTime.Add(time.Hour))
+	defer func() { getCurrentTime = time.Now }()
+
+	vz := createSnapshotsVZ(vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *"})
+	vz.Status.OpenSearch = &vzapi.OpenSearchStatus{Snapshots: []vzapi.OpenSearchSnapshotStatus{{Schedule: "daily", NextSnapshotTime: "2022-06-01T03:00:00Z"}}}
+	statuses, requeueAfter, err := ReconcileIndexManagement(spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, snapshotStateFailed, statuses[0].State)
+	assert.Contains(t, statuses[0].Message, "repository_exception")
+	assert.Equal(t, 24*time.Hour, requeueAfter)
+}
+
+// TestReconcileSnapshotScheduleCompleted tests reconciling a snapshot schedule with a snapshot in progress
+// GIVEN a snapshot schedule whose last snapshot has completed, and more snapshots than its maximum count
+// WHEN ReconcileIndexManagement is called
+// THEN the status of the snapshot is updated and the oldest snapshots of the schedule are deleted
+func TestReconcileSnapshotScheduleCompleted(t *testing.T) {
+	end := testSnapshotTime.Add(90 * time.Minute)
+	last, _ := json.Marshal(snapshotList{Snapshots: []snapshotInfo{
+		{Snapshot: "daily-20220601-030000", State: "SUCCESS", EndTimeInMillis: end.UnixMilli()},
+	}})
+	all, _ := json.Marshal(snapshotList{Snapshots: []snapshotInfo{
+		{Snapshot: "daily-20220601-030000", State: "SUCCESS", StartTimeInMillis: 3},
+		{Snapshot: "daily-20220530-030000", State: "SUCCESS", StartTimeInMillis: 1},
+		{Snapshot: "daily-20220531-030000", State: "PARTIAL", StartTimeInMillis: 2},
+		{Snapshot: "daily-manual", State: "SUCCESS"},
+	}})
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses["GET /_snapshot/backups/daily-20220601-030000"] = fakeOSResponse{code: http.StatusOK, body: string(last)}
+	fakeOS.responses["GET /_snapshot/backups/daily-*"] = fakeOSResponse{code: http.StatusOK, body: string(all)}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+	setSnapshotTime(end)
+	defer func() { getCurrentTime = time.Now }()
+
+	maxCount := int32(2)
+	vz := createSnapshotsVZ(vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *", MaxCount: &maxCount})
+	vz.Status.OpenSearch = &vzapi.OpenSearchStatus{Snapshots: []vzapi.OpenSearchSnapshotStatus{{
+		Schedule:         "daily",
+		Name:             "daily-20220601-030000",
+		Repository:       "backups",
+		State:            snapshotStateInProgress,
+		NextSnapshotTime: "2022-06-02T03:00:00Z",
+	}}}
+	statuses, _, err := ReconcileIndexManagement(spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, "SUCCESS", statuses[0].State)
+	assert.Equal(t, "2022-06-01T03:30:00Z", statuses[0].EndTime)
+	assert.Equal(t, "2022-06-02T03:00:00Z", statuses[0].NextSnapshotTime)
+	assert.Equal(t, []string{
+		"GET /_snapshot/backups/daily-20220601-030000",
+		"GET /_snapshot/backups/daily-*",
+		"DELETE /_snapshot/backups/daily-20220530-030000",
+	}, fakeOS.requests)
+}
+
+// TestReconcileSnapshotScheduleNotFound tests reconciling a snapshot schedule with a snapshot in progress
+// GIVEN a snapshot schedule whose last snapshot no longer exists
+// WHEN ReconcileIndexManagement is called
+// THEN the snapshot is reported as failed
+func TestReconcileSnapshotScheduleNotFound(t *testing.T) {
+	fakeOS := newFakeOSRequest()
+	fakeOS.responses["GET /_snapshot/backups/daily-20220601-030000"] = fakeOSResponse{code: http.StatusNotFound}
+	SetOSRequestFunc(fakeOS.request)
+	defer SetDefaultOSRequestFunc()
+	setSnapshotTime(testSnapshotTime.Add(2 * time.Hour))
+	defer func() { getCurrentTime = time.Now }()
+
+	vz := createSnapshotsVZ(vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *"})
+	vz.Status.OpenSearch = &vzapi.OpenSearchStatus{Snapshots: []vzapi.OpenSearchSnapshotStatus{{
+		Schedule:         "daily",
+		Name:             "daily-20220601-030000",
+		Repository:       "backups",
+		State:            snapshotStateInProgress,
+		NextSnapshotTime: "2022-06-02T03:00:00Z",
+	}}}
+	statuses, _, err := ReconcileIndexManagement(spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, snapshotStateFailed, statuses[0].State)
+	assert.Contains(t, statuses[0].Message, "not found")
+}
+
+// TestIsScheduleSnapshot tests matching the snapshots of a schedule
+// GIVEN snapshot names
+// WHEN isScheduleSnapshot is called
+// THEN only the snapshots taken by the schedule match
+func TestIsScheduleSnapshot(t *testing.T) {
+	assert.True(t, isScheduleSnapshot("daily", "daily-20220601-030000"))
+	assert.False(t, isScheduleSnapshot("daily", "daily-weekly-20220601-030000"))
+	assert.False(t, isScheduleSnapshot("daily", "weekly-20220601-030000"))
+	assert.False(t, isScheduleSnapshot("daily", "daily"))
+}
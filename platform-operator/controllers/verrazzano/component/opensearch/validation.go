@@ -5,9 +5,28 @@ package opensearch
 
 import (
 	"fmt"
+	"regexp"
+
+	"github.com/robfig/cron/v3"
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxIndexReplicasFunc returns the highest number of replicas of the existing indices, can be overridden for unit testing
+var maxIndexReplicasFunc = getMaxIndexReplicas
+
+// getControllerRuntimeClient returns the client used by the validating webhook, can be overridden for unit testing
+var getControllerRuntimeClient = getClient
+
+// snapshotNameRegex matches the names of snapshot repositories and schedules, snapshot names must be lowercase
+var snapshotNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
 //entryTracker is a Set like construct to track if a value was seen already
 type entryTracker struct {
 	set map[string]bool
@@ -50,3 +69,149 @@ func validateNoDuplicateNodeGroups(opensearch *v1beta1.OpenSearchComponent) erro
 	}
 	return nil
 }
+
+// validateIndexManagement rejects namespace policies and snapshot settings that cannot be applied to the cluster
+func validateIndexManagement(vz *v1beta1.Verrazzano) error {
+	if vz.Spec.Components.OpenSearch == nil {
+		return nil
+	}
+	opensearch := vz.Spec.Components.OpenSearch
+	if err := validateNamespacePolicies(opensearch); err != nil {
+		return err
+	}
+	return validateSnapshots(opensearch.Snapshots)
+}
+
+// validateNamespacePolicies rejects duplicated policy names, and namespaces managed by more than one policy
+func validateNamespacePolicies(opensearch *v1beta1.OpenSearchComponent) error {
+	policyNames := newTracker()
+	for _, policy := range opensearch.Policies {
+		policyNames.set[policy.PolicyName] = true
+	}
+	namespaces := map[string]string{}
+	for _, policy := range opensearch.NamespacePolicies {
+		if policy.PolicyName == "" {
+			return fmt.Errorf("OpenSearch namespace policies require a policyName")
+		}
+		if err := policyNames.add(policy.PolicyName); err != nil {
+			return fmt.Errorf("OpenSearch policy name is duplicated: %v", err)
+		}
+		if len(policy.Namespaces) == 0 && len(policy.Projects) == 0 {
+			return fmt.Errorf("OpenSearch namespace policy %s requires at least one namespace or project", policy.PolicyName)
+		}
+		for _, ns := range policy.Namespaces {
+			if owner, ok := namespaces[ns]; ok {
+				return fmt.Errorf("OpenSearch namespace %s is managed by both policies %s and %s", ns, owner, policy.PolicyName)
+			}
+			namespaces[ns] = policy.PolicyName
+		}
+	}
+	return nil
+}
+
+// validateSnapshots rejects invalid snapshot repositories, and schedules that are invalid or refer to unknown repositories
+func validateSnapshots(snapshots *v1beta1.OpenSearchSnapshots) error {
+	if snapshots == nil {
+		return nil
+	}
+	repositories := newTracker()
+	for _, repository := range snapshots.Repositories {
+		if !snapshotNameRegex.MatchString(repository.Name) {
+			return fmt.Errorf("OpenSearch snapshot repository name %q must consist of lowercase alphanumeric characters or '-'", repository.Name)
+		}
+		if err := repositories.add(repository.Name); err != nil {
+			return fmt.Errorf("OpenSearch snapshot repository name is duplicated: %v", err)
+		}
+		if (repository.FileSystem == nil) == (repository.S3 == nil) {
+			return fmt.Errorf("OpenSearch snapshot repository %s requires exactly one of fileSystem or s3", repository.Name)
+		}
+		if repository.FileSystem != nil && repository.FileSystem.Location == "" {
+			return fmt.Errorf("OpenSearch snapshot repository %s requires a fileSystem location", repository.Name)
+		}
+		if repository.S3 != nil && repository.S3.Bucket == "" {
+			return fmt.Errorf("OpenSearch snapshot repository %s requires an s3 bucket", repository.Name)
+		}
+	}
+	schedules := newTracker()
+	for _, schedule := range snapshots.Schedules {
+		if !snapshotNameRegex.MatchString(schedule.Name) {
+			return fmt.Errorf("OpenSearch snapshot schedule name %q must consist of lowercase alphanumeric characters or '-'", schedule.Name)
+		}
+		if err := schedules.add(schedule.Name); err != nil {
+			return fmt.Errorf("OpenSearch snapshot schedule name is duplicated: %v", err)
+		}
+		if !repositories.set[schedule.Repository] {
+			return fmt.Errorf("OpenSearch snapshot schedule %s refers to unknown repository %q", schedule.Name, schedule.Repository)
+		}
+		if _, err := cron.ParseStandard(schedule.Schedule); err != nil {
+			return fmt.Errorf("OpenSearch snapshot schedule %s has an invalid schedule %q: %v", schedule.Name, schedule.Schedule, err)
+		}
+		if schedule.MaxCount != nil && *schedule.MaxCount < 1 {
+			return fmt.Errorf("OpenSearch snapshot schedule %s maxCount must be at least 1", schedule.Name)
+		}
+	}
+	return nil
+}
+
+// validateDataNodeScaleDown rejects scaling down the data nodes below the number needed to keep every shard copy of
+// the existing indices, each copy of a shard must be allocated to a different data node.  The data nodes are counted
+// in the effective CRs, the profile defines the nodes when the resource does not.
+func validateDataNodeScaleDown(old *v1beta1.Verrazzano, new *v1beta1.Verrazzano) error {
+	oldCount, err := countDataNodes(old)
+	if err != nil {
+		return err
+	}
+	newCount, err := countDataNodes(new)
+	if err != nil {
+		return err
+	}
+	if newCount >= oldCount {
+		return nil
+	}
+	if newCount == 0 {
+		return fmt.Errorf("OpenSearch data nodes can not be scaled down from %d to 0 without losing data", oldCount)
+	}
+	maxReplicas, err := maxIndexReplicasFunc(old)
+	if err != nil {
+		return fmt.Errorf("Failed verifying the OpenSearch index replicas before scaling down the data nodes: %v", err)
+	}
+	if int(newCount) <= maxReplicas {
+		return fmt.Errorf("OpenSearch data nodes can not be scaled down to %d, indices with %d replicas require at least %d data nodes",
+			newCount, maxReplicas, maxReplicas+1)
+	}
+	return nil
+}
+
+// countDataNodes returns the number of data node replicas of the effective CR
+func countDataNodes(vz *v1beta1.Verrazzano) (int32, error) {
+	effectiveCR, err := transform.GetEffectiveV1beta1CR(vz)
+	if err != nil {
+		return 0, fmt.Errorf("Failed getting the effective CR of %s/%s: %v", vz.Namespace, vz.Name, err)
+	}
+	var count int32
+	if effectiveCR.Spec.Components.OpenSearch != nil {
+		for _, node := range effectiveCR.Spec.Components.OpenSearch.Nodes {
+			if hasRole(node.Roles, vmov1.DataRole) {
+				count += node.Replicas
+			}
+		}
+	}
+	return count, nil
+}
+
+// getClient returns a controller runtime client for the Verrazzano resource
+func getClient() (client.Client, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: newScheme()})
+}
+
+// newScheme creates a new scheme that includes this package's object for use by client
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+	return scheme
+}
@@ -9,6 +9,7 @@ import (
 	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"testing"
 )
 
@@ -100,3 +101,225 @@ func TestValidateNoDuplicatedConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func createSnapshots(repositories []vzapi.OpenSearchSnapshotRepository, schedules ...vzapi.OpenSearchSnapshotSchedule) *vzapi.Verrazzano {
+	return createVZ(&vzapi.ElasticsearchComponent{
+		Snapshots: &vzapi.OpenSearchSnapshots{
+			Repositories: repositories,
+			Schedules:    schedules,
+		},
+	})
+}
+
+func TestValidateIndexManagement(t *testing.T) {
+	zero := int32(0)
+	fsRepository := []vzapi.OpenSearchSnapshotRepository{
+		{Name: "backups", FileSystem: &vzapi.OpenSearchFileSystemRepository{Location: "/snapshots"}},
+	}
+	var tests = []struct {
+		name     string
+		vz       *vzapi.Verrazzano
+		hasError bool
+	}{
+		{
+			"valid when component has no index management",
+			emptyComponent,
+			false,
+		},
+		{
+			"valid namespace policies and snapshots",
+			createVZ(&vzapi.ElasticsearchComponent{
+				NamespacePolicies: []vzapi.OpenSearchNamespacePolicy{
+					{PolicyName: "a", Namespaces: []string{"ns1"}},
+					{PolicyName: "b", Projects: []string{"proj"}},
+				},
+				Snapshots: &vzapi.OpenSearchSnapshots{
+					Repositories: fsRepository,
+					Schedules: []vzapi.OpenSearchSnapshotSchedule{
+						{Name: "daily", Repository: "backups", Schedule: "0 3 * * *"},
+					},
+				},
+			}),
+			false,
+		},
+		{
+			"namespace policy name duplicates a global policy",
+			createVZ(&vzapi.ElasticsearchComponent{
+				Policies: []vmov1.IndexManagementPolicy{{PolicyName: "a", IndexPattern: "verrazzano-*"}},
+				NamespacePolicies: []vzapi.OpenSearchNamespacePolicy{
+					{PolicyName: "a", Namespaces: []string{"ns1"}},
+				},
+			}),
+			true,
+		},
+		{
+			"namespace policy without namespaces or projects",
+			createVZ(&vzapi.ElasticsearchComponent{
+				NamespacePolicies: []vzapi.OpenSearchNamespacePolicy{{PolicyName: "a"}},
+			}),
+			true,
+		},
+		{
+			"namespace managed by two policies",
+			createVZ(&vzapi.ElasticsearchComponent{
+				NamespacePolicies: []vzapi.OpenSearchNamespacePolicy{
+					{PolicyName: "a", Namespaces: []string{"ns1"}},
+					{PolicyName: "b", Namespaces: []string{"ns1"}},
+				},
+			}),
+			true,
+		},
+		{
+			"repository with both file system and s3",
+			createSnapshots([]vzapi.OpenSearchSnapshotRepository{
+				{
+					Name:       "backups",
+					FileSystem: &vzapi.OpenSearchFileSystemRepository{Location: "/snapshots"},
+					S3:         &vzapi.OpenSearchS3Repository{Bucket: "bucket"},
+				},
+			}),
+			true,
+		},
+		{
+			"s3 repository without a bucket",
+			createSnapshots([]vzapi.OpenSearchSnapshotRepository{{Name: "backups", S3: &vzapi.OpenSearchS3Repository{}}}),
+			true,
+		},
+		{
+			"schedule with an unknown repository",
+			createSnapshots(fsRepository, vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "missing", Schedule: "0 3 * * *"}),
+			true,
+		},
+		{
+			"schedule with an invalid schedule",
+			createSnapshots(fsRepository, vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "daily"}),
+			true,
+		},
+		{
+			"schedule with an invalid name",
+			createSnapshots(fsRepository, vzapi.OpenSearchSnapshotSchedule{Name: "Daily", Repository: "backups", Schedule: "0 3 * * *"}),
+			true,
+		},
+		{
+			"schedule with a zero maxCount",
+			createSnapshots(fsRepository, vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *", MaxCount: &zero}),
+			true,
+		},
+		{
+			"duplicated schedules",
+			createSnapshots(fsRepository,
+				vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 3 * * *"},
+				vzapi.OpenSearchSnapshotSchedule{Name: "daily", Repository: "backups", Schedule: "0 4 * * *"}),
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1beta1vz := &v1beta1.Verrazzano{}
+			assert.NoError(t, tt.vz.ConvertTo(v1beta1vz))
+			if err := validateIndexManagement(v1beta1vz); (err != nil) != tt.hasError {
+				t.Errorf("validateIndexManagement() error = %v, hasError: %v", err, tt.hasError)
+			}
+		})
+	}
+}
+
+// TestValidateDataNodeScaleDown tests validating the scale down of the data nodes
+// GIVEN an update of the data node replicas
+// WHEN validateDataNodeScaleDown is called
+// THEN the data nodes of the effective CRs are compared and scale downs that would lose shard copies are rejected
+func TestValidateDataNodeScaleDown(t *testing.T) {
+	config.TestProfilesDir = "../../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	dataRoles := []vmov1.NodeRole{vmov1.DataRole}
+	// es-data replaces the data node group of the prod profile
+	createDataNodes := func(replicas int32) *vzapi.Verrazzano {
+		return createVZ(&vzapi.ElasticsearchComponent{
+			Nodes: []vzapi.OpenSearchNode{
+				createNG("es-data", replicas, dataRoles),
+			},
+		})
+	}
+	// the master node group of the dev profile has no data role
+	createDevDataNodes := func(replicas int32) *vzapi.Verrazzano {
+		vz := createVZ(&vzapi.ElasticsearchComponent{
+			Nodes: []vzapi.OpenSearchNode{
+				createNG("data", replicas, dataRoles),
+			},
+		})
+		vz.Spec.Profile = vzapi.Dev
+		return vz
+	}
+	defer func() { maxIndexReplicasFunc = getMaxIndexReplicas }()
+	var tests = []struct {
+		name        string
+		old         *vzapi.Verrazzano
+		new         *vzapi.Verrazzano
+		maxReplicas int
+		hasError    bool
+	}{
+		{
+			"scale up",
+			createDataNodes(2),
+			createDataNodes(3),
+			1,
+			false,
+		},
+		{
+			"scale down with enough data nodes for the index replicas",
+			createDataNodes(3),
+			createDataNodes(2),
+			1,
+			false,
+		},
+		{
+			"scale down when OpenSearch is not running",
+			createDataNodes(3),
+			createDataNodes(1),
+			-1,
+			false,
+		},
+		{
+			"scale down below the index replicas",
+			createDataNodes(3),
+			createDataNodes(2),
+			2,
+			true,
+		},
+		{
+			"scale down to no data nodes",
+			createDevDataNodes(3),
+			createDevDataNodes(0),
+			-1,
+			true,
+		},
+		{
+			"scale down the data nodes of the profile below the index replicas",
+			emptyComponent,
+			createDataNodes(2),
+			2,
+			true,
+		},
+		{
+			"scale up the data nodes of the profile",
+			emptyComponent,
+			createDataNodes(4),
+			2,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxIndexReplicasFunc = func(_ *v1beta1.Verrazzano) (int, error) { return tt.maxReplicas, nil }
+			v1beta1Old := &v1beta1.Verrazzano{}
+			v1beta1New := &v1beta1.Verrazzano{}
+			assert.NoError(t, tt.old.ConvertTo(v1beta1Old))
+			assert.NoError(t, tt.new.ConvertTo(v1beta1New))
+			if err := validateDataNodeScaleDown(v1beta1Old, v1beta1New); (err != nil) != tt.hasError {
+				t.Errorf("validateDataNodeScaleDown() error = %v, hasError: %v", err, tt.hasError)
+			}
+		})
+	}
+}
@@ -230,6 +230,21 @@ func (d opensearchDashboardsComponent) GetIngressNames(ctx spi.ComponentContext)
 	return ingressNames
 }
 
+// GetNetworkPolicyRules - OpenSearch Dashboards only connects to the pods of the verrazzano-system namespace
+func (d opensearchDashboardsComponent) GetNetworkPolicyRules(_ spi.ComponentContext) []spi.NetworkPolicyRules {
+	return nil
+}
+
+// Verify that opensearchDashboardsComponent implements ComponentAvailability
+var _ spi.ComponentAvailability = opensearchDashboardsComponent{}
+
+// GetPodDisruptionBudgets - gets the replicated OpenSearch Dashboards pods
+func (d opensearchDashboardsComponent) GetPodDisruptionBudgets(_ spi.ComponentContext) []spi.PodDisruptionBudget {
+	return []spi.PodDisruptionBudget{
+		{Name: kibanaDeployment, PodLabels: map[string]string{"app": "system-kibana"}},
+	}
+}
+
 // GetCertificateNames - gets the names of the certificates associated with this component
 func (d opensearchDashboardsComponent) GetCertificateNames(_ spi.ComponentContext) []types.NamespacedName {
 	return []types.NamespacedName{
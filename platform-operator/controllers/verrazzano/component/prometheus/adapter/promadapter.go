@@ -65,3 +65,18 @@ func GetOverrides(object runtime.Object) interface{} {
 
 	return []vzapi.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusAdapter != nil {
+			return effectiveCR.Spec.Components.PrometheusAdapter.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusAdapter != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.PrometheusAdapter.Workload)
+		}
+	}
+	return nil
+}
@@ -29,6 +29,15 @@ type prometheusAdapterComponent struct {
 	helm.HelmComponent
 }
 
+// networkPolicyRules are the network peers of the adapter outside of the verrazzano-monitoring namespace, the API
+// server calls the custom metrics API of the adapter
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app.kubernetes.io/name": "prometheus-adapter"},
+		Ingress:   []spi.NetworkPeer{{Ports: []int32{6443}}},
+	},
+}
+
 func NewComponent() spi.Component {
 	return prometheusAdapterComponent{
 		helm.HelmComponent{
@@ -36,6 +45,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), chartDir),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -44,6 +54,14 @@ func NewComponent() spi.Component {
 			ValuesFile:                filepath.Join(config.GetHelmOverridesDir(), "prometheus-adapter-values.yaml"),
 			Dependencies:              []string{},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				ReplicasKey:          "replicas",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
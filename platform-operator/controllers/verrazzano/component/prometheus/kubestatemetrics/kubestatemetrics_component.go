@@ -47,6 +47,14 @@ func NewComponent() spi.Component {
 			AppendOverridesFunc:       AppendOverrides,
 			Dependencies:              []string{promoperator.ComponentName},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				ReplicasKey:          "replicas",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
@@ -77,6 +77,21 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []vzapi.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusNodeExporter != nil {
+			return effectiveCR.Spec.Components.PrometheusNodeExporter.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusNodeExporter != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.PrometheusNodeExporter.Workload)
+		}
+	}
+	return nil
+}
+
 // createOrUpdateNetworkPolicies creates or updates network policies for this component
 func createOrUpdateNetworkPolicies(ctx spi.ComponentContext) error {
 	netPolicy := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: networkPolicyName, Namespace: ComponentNamespace}}
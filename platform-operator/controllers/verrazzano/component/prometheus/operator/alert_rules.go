@@ -0,0 +1,48 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operator
+
+import (
+	"path"
+
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// platformAlertRules are the PrometheusRule files of the Verrazzano platform alerts, each with the function that
+// checks if the component it monitors is enabled
+var platformAlertRules = []struct {
+	file      string
+	isEnabled func(runtime.Object) bool
+}{
+	{file: "vpo_rules.yaml", isEnabled: func(runtime.Object) bool { return true }},
+	{file: "cert_manager_rules.yaml", isEnabled: vzconfig.IsCertManagerEnabled},
+	{file: "keycloak_rules.yaml", isEnabled: vzconfig.IsKeycloakEnabled},
+	{file: "opensearch_rules.yaml", isEnabled: vzconfig.IsOpenSearchEnabled},
+	{file: "fluentd_rules.yaml", isEnabled: vzconfig.IsFluentdEnabled},
+}
+
+// applyPlatformAlertRules applies the alert rules of the enabled Verrazzano components and deletes the alert
+// rules of the disabled components
+func applyPlatformAlertRules(ctx spi.ComponentContext) error {
+	args := newManifestTemplateArgs(ctx)
+	dir := path.Join(config.GetThirdPartyManifestsDir(), "prometheus-rules")
+	yamlApplier := k8sutil.NewYAMLApplier(ctx.Client(), "")
+	for _, rules := range platformAlertRules {
+		file := path.Join(dir, rules.file)
+		if rules.isEnabled(ctx.EffectiveCR()) {
+			if err := yamlApplier.ApplyFT(file, args); err != nil {
+				return ctx.Log().ErrorfNewErr("Failed to apply the alert rules in %s: %v", rules.file, err)
+			}
+			continue
+		}
+		if err := yamlApplier.DeleteFT(file, args); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed to delete the alert rules in %s: %v", rules.file, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	promoperapi "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestApplyPlatformAlertRules tests the applyPlatformAlertRules function
+// GIVEN a Verrazzano CR with the default components enabled
+// WHEN applyPlatformAlertRules is called
+// THEN the alert rules of every component are applied
+func TestApplyPlatformAlertRules(t *testing.T) {
+	oldConfig := config.Get()
+	defer config.Set(oldConfig)
+	config.Set(config.OperatorConfig{
+		VerrazzanoRootDir: "../../../../../..",
+	})
+
+	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	err := applyPlatformAlertRules(spi.NewFakeContext(client, &vzapi.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+
+	rules := promoperapi.PrometheusRuleList{}
+	assert.NoError(t, client.List(context.TODO(), &rules))
+	assert.Len(t, rules.Items, 5)
+
+	// the Prometheus templates of the annotations are left as is
+	vpoRules := promoperapi.PrometheusRule{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "verrazzano-platform-operator", Namespace: ComponentNamespace}, &vpoRules)
+	assert.NoError(t, err)
+	assert.Equal(t, "prometheus-operator", vpoRules.Labels["release"])
+	assert.Contains(t, vpoRules.Spec.Groups[0].Rules[0].Annotations["description"], "{{ $labels.pod }}")
+	assert.Contains(t, vpoRules.Spec.Groups[0].Rules[0].Expr.String(), `namespace="verrazzano-install"`)
+
+	// GIVEN Keycloak and Fluentd are disabled
+	// WHEN applyPlatformAlertRules is called
+	// THEN the alert rules of Keycloak and Fluentd are deleted
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Keycloak: &vzapi.KeycloakComponent{Enabled: &falseValue},
+				Fluentd:  &vzapi.FluentdComponent{Enabled: &falseValue},
+			},
+		},
+	}
+	err = applyPlatformAlertRules(spi.NewFakeContext(client, vz, nil, false))
+	assert.NoError(t, err)
+
+	rules = promoperapi.PrometheusRuleList{}
+	assert.NoError(t, client.List(context.TODO(), &rules))
+	assert.Len(t, rules.Items, 3)
+	for _, rule := range rules.Items {
+		assert.NotEqual(t, "verrazzano-keycloak", rule.Name)
+		assert.NotEqual(t, "verrazzano-fluentd", rule.Name)
+	}
+}
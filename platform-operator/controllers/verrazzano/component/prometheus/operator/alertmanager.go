@@ -0,0 +1,266 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	alertmanagerConfigSecretName = "verrazzano-alertmanager-config"
+	alertmanagerConfigKey        = "alertmanager.yaml"
+
+	// nullReceiverName is the receiver of the alerts that are not sent anywhere
+	nullReceiverName = "null"
+
+	// The keys of the receiver secrets
+	receiverURLKey       = "url"
+	receiverChannelKey   = "channel"
+	receiverToKey        = "to"
+	receiverFromKey      = "from"
+	receiverSmarthostKey = "smarthost"
+	receiverUsernameKey  = "username"
+	receiverPasswordKey  = "password"
+)
+
+// receiverSecretKeys are the keys that the secret of each type of receiver must have
+var receiverSecretKeys = map[string][]string{
+	string(installv1beta1.AlertmanagerReceiverWebhook): {receiverURLKey},
+	string(installv1beta1.AlertmanagerReceiverSlack):   {receiverURLKey},
+	string(installv1beta1.AlertmanagerReceiverEmail):   {receiverToKey, receiverFromKey, receiverSmarthostKey},
+}
+
+// alertmanagerConfig is the Alertmanager configuration file
+type alertmanagerConfig struct {
+	Global    map[string]string      `json:"global,omitempty"`
+	Route     alertmanagerRoute      `json:"route"`
+	Receivers []alertmanagerReceiver `json:"receivers"`
+}
+
+type alertmanagerRoute struct {
+	Receiver       string              `json:"receiver"`
+	GroupBy        []string            `json:"group_by,omitempty"`
+	GroupWait      string              `json:"group_wait,omitempty"`
+	GroupInterval  string              `json:"group_interval,omitempty"`
+	RepeatInterval string              `json:"repeat_interval,omitempty"`
+	Matchers       []string            `json:"matchers,omitempty"`
+	Continue       bool                `json:"continue,omitempty"`
+	Routes         []alertmanagerRoute `json:"routes,omitempty"`
+}
+
+type alertmanagerReceiver struct {
+	Name           string                   `json:"name"`
+	WebhookConfigs []map[string]interface{} `json:"webhook_configs,omitempty"`
+	EmailConfigs   []map[string]interface{} `json:"email_configs,omitempty"`
+	SlackConfigs   []map[string]interface{} `json:"slack_configs,omitempty"`
+}
+
+// isAlertmanagerEnabled returns true if Alertmanager is enabled in the Verrazzano CR, it is disabled by default
+func isAlertmanagerEnabled(cr runtime.Object) bool {
+	if vz, ok := cr.(*vzapi.Verrazzano); ok {
+		promOperator := vz.Spec.Components.PrometheusOperator
+		return promOperator != nil && promOperator.Alertmanager != nil && promOperator.Alertmanager.Enabled != nil && *promOperator.Alertmanager.Enabled
+	} else if vz, ok := cr.(*installv1beta1.Verrazzano); ok {
+		promOperator := vz.Spec.Components.PrometheusOperator
+		return promOperator != nil && promOperator.Alertmanager != nil && promOperator.Alertmanager.Enabled != nil && *promOperator.Alertmanager.Enabled
+	}
+	return false
+}
+
+// appendAlertmanagerOverrides installs Alertmanager with the configuration secret generated from the receivers
+// of the Verrazzano CR
+func appendAlertmanagerOverrides(ctx spi.ComponentContext, kvs []bom.KeyValue) []bom.KeyValue {
+	if !isAlertmanagerEnabled(ctx.EffectiveCR()) {
+		return kvs
+	}
+	return append(kvs, []bom.KeyValue{
+		{Key: "alertmanager.enabled", Value: "true"},
+		{Key: "alertmanager.alertmanagerSpec.useExistingSecret", Value: "true"},
+		{Key: "alertmanager.alertmanagerSpec.configSecret", Value: alertmanagerConfigSecretName},
+		// Prometheus bypasses its sidecar for outbound requests, so keep Alertmanager out of the mesh
+		{Key: `alertmanager.alertmanagerSpec.podMetadata.annotations.sidecar\.istio\.io/inject`, Value: `"false"`},
+	}...)
+}
+
+// createOrUpdateAlertmanagerConfig generates the Alertmanager configuration secret from the receivers of the
+// Verrazzano CR and their secrets, the secret is deleted if Alertmanager is disabled
+func createOrUpdateAlertmanagerConfig(ctx spi.ComponentContext) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      alertmanagerConfigSecretName,
+			Namespace: ComponentNamespace,
+		},
+	}
+	if !isAlertmanagerEnabled(ctx.EffectiveCR()) {
+		if err := ctx.Client().Delete(context.TODO(), secret); err != nil && !k8serrors.IsNotFound(err) {
+			return ctx.Log().ErrorfNewErr("Failed to delete the Alertmanager configuration secret %s: %v", alertmanagerConfigSecretName, err)
+		}
+		return nil
+	}
+
+	config, err := buildAlertmanagerConfig(ctx)
+	if err != nil {
+		return err
+	}
+	ctx.Log().Debugf("Creating or updating the Alertmanager configuration secret %s", alertmanagerConfigSecretName)
+	if _, err := controllerruntime.CreateOrUpdate(context.TODO(), ctx.Client(), secret, func() error {
+		secret.Data = map[string][]byte{alertmanagerConfigKey: config}
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed to create or update the Alertmanager configuration secret %s: %v", alertmanagerConfigSecretName, err)
+	}
+	return nil
+}
+
+// buildAlertmanagerConfig returns the Alertmanager configuration that routes the alerts to the receivers of the
+// Verrazzano CR.  Each receiver gets the alerts of its severities, the Watchdog alert is never sent.
+func buildAlertmanagerConfig(ctx spi.ComponentContext) ([]byte, error) {
+	config := alertmanagerConfig{
+		Global: map[string]string{"resolve_timeout": "5m"},
+		Route: alertmanagerRoute{
+			Receiver:       nullReceiverName,
+			GroupBy:        []string{"alertname", "namespace"},
+			GroupWait:      "30s",
+			GroupInterval:  "5m",
+			RepeatInterval: "12h",
+			Routes: []alertmanagerRoute{{
+				Receiver: nullReceiverName,
+				Matchers: []string{`alertname="Watchdog"`},
+			}},
+		},
+		Receivers: []alertmanagerReceiver{{Name: nullReceiverName}},
+	}
+
+	for _, receiver := range ctx.EffectiveCR().Spec.Components.PrometheusOperator.Alertmanager.Receivers {
+		secret := corev1.Secret{}
+		if err := ctx.Client().Get(context.TODO(), client.ObjectKey{Name: receiver.Secret, Namespace: constants.VerrazzanoInstallNamespace}, &secret); err != nil {
+			return nil, ctx.Log().ErrorfNewErr("Failed to get the secret %s of Alertmanager receiver %s: %v", receiver.Secret, receiver.Name, err)
+		}
+		amReceiver, err := newAlertmanagerReceiver(receiver, secret)
+		if err != nil {
+			return nil, ctx.Log().ErrorfNewErr("Failed to configure Alertmanager receiver %s: %v", receiver.Name, err)
+		}
+		config.Receivers = append(config.Receivers, amReceiver)
+
+		route := alertmanagerRoute{Receiver: receiver.Name, Continue: true}
+		if len(receiver.Severities) > 0 {
+			route.Matchers = []string{fmt.Sprintf(`severity=~"%s"`, strings.Join(receiver.Severities, "|"))}
+		}
+		config.Route.Routes = append(config.Route.Routes, route)
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, ctx.Log().ErrorfNewErr("Failed to marshal the Alertmanager configuration: %v", err)
+	}
+	return data, nil
+}
+
+// newAlertmanagerReceiver returns the Alertmanager receiver configured from the values of the receiver secret
+func newAlertmanagerReceiver(receiver vzapi.AlertmanagerReceiver, secret corev1.Secret) (alertmanagerReceiver, error) {
+	amReceiver := alertmanagerReceiver{Name: receiver.Name}
+	if err := checkReceiverSecret(string(receiver.Type), secret); err != nil {
+		return amReceiver, err
+	}
+	value := func(key string) string {
+		return string(secret.Data[key])
+	}
+
+	switch receiver.Type {
+	case vzapi.AlertmanagerReceiverWebhook:
+		amReceiver.WebhookConfigs = []map[string]interface{}{{
+			"url":           value(receiverURLKey),
+			"send_resolved": true,
+		}}
+	case vzapi.AlertmanagerReceiverSlack:
+		slackConfig := map[string]interface{}{
+			"api_url":       value(receiverURLKey),
+			"send_resolved": true,
+		}
+		if channel := value(receiverChannelKey); len(channel) > 0 {
+			slackConfig["channel"] = channel
+		}
+		amReceiver.SlackConfigs = []map[string]interface{}{slackConfig}
+	case vzapi.AlertmanagerReceiverEmail:
+		emailConfig := map[string]interface{}{
+			"to":            value(receiverToKey),
+			"from":          value(receiverFromKey),
+			"smarthost":     value(receiverSmarthostKey),
+			"send_resolved": true,
+		}
+		if username := value(receiverUsernameKey); len(username) > 0 {
+			emailConfig["auth_username"] = username
+			emailConfig["auth_password"] = value(receiverPasswordKey)
+		}
+		amReceiver.EmailConfigs = []map[string]interface{}{emailConfig}
+	}
+	return amReceiver, nil
+}
+
+// checkReceiverSecret checks that the secret of a receiver has the keys required by the type of the receiver
+func checkReceiverSecret(receiverType string, secret corev1.Secret) error {
+	keys, ok := receiverSecretKeys[receiverType]
+	if !ok {
+		return fmt.Errorf("invalid receiver type %s", receiverType)
+	}
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("the secret %s of a %s receiver must have a %s key", secret.Name, receiverType, key)
+		}
+	}
+	return nil
+}
+
+// validateAlertmanager checks the Alertmanager receivers and their secrets
+func validateAlertmanager(vz *installv1beta1.Verrazzano) error {
+	if !isAlertmanagerEnabled(vz) {
+		return nil
+	}
+	receivers := vz.Spec.Components.PrometheusOperator.Alertmanager.Receivers
+	names := make(map[string]bool, len(receivers))
+	for _, receiver := range receivers {
+		if len(receiver.Name) == 0 || receiver.Name == nullReceiverName {
+			return fmt.Errorf("invalid Alertmanager receiver name \"%s\"", receiver.Name)
+		}
+		if names[receiver.Name] {
+			return fmt.Errorf("duplicate Alertmanager receiver name \"%s\"", receiver.Name)
+		}
+		names[receiver.Name] = true
+		if len(receiver.Secret) == 0 {
+			return fmt.Errorf("the secret of Alertmanager receiver %s is required", receiver.Name)
+		}
+
+		cli, err := k8sutil.GetCoreV1Func()
+		if err != nil {
+			return err
+		}
+		secret, err := cli.Secrets(constants.VerrazzanoInstallNamespace).Get(context.TODO(), receiver.Secret, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return fmt.Errorf("secret \"%s\" of Alertmanager receiver %s must be created in the \"%s\" namespace", receiver.Secret, receiver.Name, constants.VerrazzanoInstallNamespace)
+			}
+			return err
+		}
+		if err := checkReceiverSecret(string(receiver.Type), *secret); err != nil {
+			return fmt.Errorf("invalid Alertmanager receiver %s: %v", receiver.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func newAlertmanagerVZ(receivers ...vzapi.AlertmanagerReceiver) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				PrometheusOperator: &vzapi.PrometheusOperatorComponent{
+					Alertmanager: &vzapi.AlertmanagerSpec{
+						Enabled:   &trueValue,
+						Receivers: receivers,
+					},
+				},
+			},
+		},
+	}
+}
+
+func newReceiverSecret(name string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
+
+// TestAppendAlertmanagerOverrides tests the appendAlertmanagerOverrides function
+// GIVEN a Verrazzano CR with Alertmanager enabled or disabled
+// WHEN appendAlertmanagerOverrides is called
+// THEN Alertmanager is installed with the generated configuration secret only if it is enabled
+func TestAppendAlertmanagerOverrides(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+	kvs := appendAlertmanagerOverrides(spi.NewFakeContext(client, &vzapi.Verrazzano{}, nil, false), nil)
+	assert.Empty(t, kvs)
+
+	kvs = appendAlertmanagerOverrides(spi.NewFakeContext(client, newAlertmanagerVZ(), nil, false), nil)
+	assert.Equal(t, "true", bom.FindKV(kvs, "alertmanager.enabled"))
+	assert.Equal(t, "true", bom.FindKV(kvs, "alertmanager.alertmanagerSpec.useExistingSecret"))
+	assert.Equal(t, alertmanagerConfigSecretName, bom.FindKV(kvs, "alertmanager.alertmanagerSpec.configSecret"))
+	assert.Equal(t, `"false"`, bom.FindKV(kvs, `alertmanager.alertmanagerSpec.podMetadata.annotations.sidecar\.istio\.io/inject`))
+}
+
+// TestCreateOrUpdateAlertmanagerConfig tests the createOrUpdateAlertmanagerConfig function
+// GIVEN a Verrazzano CR with webhook, Slack and email receivers
+// WHEN createOrUpdateAlertmanagerConfig is called
+// THEN the Alertmanager configuration secret routes the alerts to the receivers, with the values of their secrets
+func TestCreateOrUpdateAlertmanagerConfig(t *testing.T) {
+	vz := newAlertmanagerVZ(
+		vzapi.AlertmanagerReceiver{Name: "oncall", Type: vzapi.AlertmanagerReceiverWebhook, Secret: "oncall", Severities: []string{"critical"}},
+		vzapi.AlertmanagerReceiver{Name: "chat", Type: vzapi.AlertmanagerReceiverSlack, Secret: "chat"},
+		vzapi.AlertmanagerReceiver{Name: "team", Type: vzapi.AlertmanagerReceiverEmail, Secret: "team", Severities: []string{"critical", "warning"}},
+	)
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		newReceiverSecret("oncall", map[string]string{receiverURLKey: "https://oncall.example.com/alerts"}),
+		newReceiverSecret("chat", map[string]string{receiverURLKey: "https://chat.example.com/hooks/123", receiverChannelKey: "#ops"}),
+		newReceiverSecret("team", map[string]string{receiverToKey: "team@example.com", receiverFromKey: "vz@example.com",
+			receiverSmarthostKey: "smtp.example.com:587", receiverUsernameKey: "vz", receiverPasswordKey: "changeme"}),
+	).Build()
+
+	err := createOrUpdateAlertmanagerConfig(spi.NewFakeContext(client, vz, nil, false))
+	assert.NoError(t, err)
+
+	secret := corev1.Secret{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: alertmanagerConfigSecretName, Namespace: ComponentNamespace}, &secret)
+	assert.NoError(t, err)
+	config := alertmanagerConfig{}
+	assert.NoError(t, yaml.Unmarshal(secret.Data[alertmanagerConfigKey], &config))
+
+	assert.Equal(t, nullReceiverName, config.Route.Receiver)
+	assert.Len(t, config.Route.Routes, 4)
+	assert.Equal(t, []string{`alertname="Watchdog"`}, config.Route.Routes[0].Matchers)
+	assert.False(t, config.Route.Routes[0].Continue)
+	assert.Equal(t, alertmanagerRoute{Receiver: "oncall", Matchers: []string{`severity=~"critical"`}, Continue: true}, config.Route.Routes[1])
+	assert.Equal(t, alertmanagerRoute{Receiver: "chat", Continue: true}, config.Route.Routes[2])
+	assert.Equal(t, []string{`severity=~"critical|warning"`}, config.Route.Routes[3].Matchers)
+
+	assert.Len(t, config.Receivers, 4)
+	assert.Equal(t, nullReceiverName, config.Receivers[0].Name)
+	assert.Equal(t, "https://oncall.example.com/alerts", config.Receivers[1].WebhookConfigs[0]["url"])
+	assert.Equal(t, "https://chat.example.com/hooks/123", config.Receivers[2].SlackConfigs[0]["api_url"])
+	assert.Equal(t, "#ops", config.Receivers[2].SlackConfigs[0]["channel"])
+	assert.Equal(t, "team@example.com", config.Receivers[3].EmailConfigs[0]["to"])
+	assert.Equal(t, "smtp.example.com:587", config.Receivers[3].EmailConfigs[0]["smarthost"])
+	assert.Equal(t, "changeme", config.Receivers[3].EmailConfigs[0]["auth_password"])
+
+	// GIVEN Alertmanager is disabled
+	// WHEN createOrUpdateAlertmanagerConfig is called
+	// THEN the Alertmanager configuration secret is deleted
+	err = createOrUpdateAlertmanagerConfig(spi.NewFakeContext(client, &vzapi.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+	err = client.Get(context.TODO(), types.NamespacedName{Name: alertmanagerConfigSecretName, Namespace: ComponentNamespace}, &secret)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// TestCreateOrUpdateAlertmanagerConfigMissingKey tests the createOrUpdateAlertmanagerConfig function
+// GIVEN a receiver secret without a required key
+// WHEN createOrUpdateAlertmanagerConfig is called
+// THEN an error is returned
+func TestCreateOrUpdateAlertmanagerConfigMissingKey(t *testing.T) {
+	vz := newAlertmanagerVZ(vzapi.AlertmanagerReceiver{Name: "team", Type: vzapi.AlertmanagerReceiverEmail, Secret: "team"})
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		newReceiverSecret("team", map[string]string{receiverToKey: "team@example.com"}),
+	).Build()
+	err := createOrUpdateAlertmanagerConfig(spi.NewFakeContext(client, vz, nil, false))
+	assert.Error(t, err)
+}
+
+// TestValidateAlertmanager tests the validateAlertmanager function
+// GIVEN valid and invalid Alertmanager receivers
+// WHEN validateAlertmanager is called
+// THEN an error is returned for the invalid receivers
+func TestValidateAlertmanager(t *testing.T) {
+	k8sutil.GetCoreV1Func = common.MockGetCoreV1(
+		newReceiverSecret("webhook", map[string]string{receiverURLKey: "https://oncall.example.com/alerts"}),
+		newReceiverSecret("email", map[string]string{receiverToKey: "team@example.com"}),
+	)
+	defer func() { k8sutil.GetCoreV1Func = k8sutil.GetCoreV1Client }()
+
+	webhook := vzapi.AlertmanagerReceiver{Name: "oncall", Type: vzapi.AlertmanagerReceiverWebhook, Secret: "webhook"}
+	tests := []struct {
+		name      string
+		vz        *vzapi.Verrazzano
+		expectErr bool
+	}{
+		{
+			name: "default",
+			vz:   &vzapi.Verrazzano{},
+		},
+		{
+			name: "valid receiver",
+			vz:   newAlertmanagerVZ(webhook),
+		},
+		{
+			name:      "reserved name",
+			vz:        newAlertmanagerVZ(vzapi.AlertmanagerReceiver{Name: nullReceiverName, Type: vzapi.AlertmanagerReceiverWebhook, Secret: "webhook"}),
+			expectErr: true,
+		},
+		{
+			name:      "duplicate name",
+			vz:        newAlertmanagerVZ(webhook, webhook),
+			expectErr: true,
+		},
+		{
+			name:      "missing secret",
+			vz:        newAlertmanagerVZ(vzapi.AlertmanagerReceiver{Name: "oncall", Type: vzapi.AlertmanagerReceiverWebhook, Secret: "missing"}),
+			expectErr: true,
+		},
+		{
+			name:      "secret without required keys",
+			vz:        newAlertmanagerVZ(vzapi.AlertmanagerReceiver{Name: "team", Type: vzapi.AlertmanagerReceiverEmail, Secret: "email"}),
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			convertedVZ := v1beta1.Verrazzano{}
+			assert.NoError(t, common.ConvertVerrazzanoCR(tt.vz, &convertedVZ))
+			err := validateAlertmanager(&convertedVZ)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -19,9 +19,11 @@ import (
 	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
 	"github.com/verrazzano/verrazzano/pkg/k8s/status"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	vzos "github.com/verrazzano/verrazzano/pkg/os"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
@@ -97,6 +99,11 @@ func preInstallUpgrade(ctx spi.ComponentContext) error {
 		return err
 	}
 
+	// Generate the Alertmanager configuration from the receivers, Alertmanager does not start without it
+	if err := createOrUpdateAlertmanagerConfig(ctx); err != nil {
+		return err
+	}
+
 	// Remove any existing volume claims from old VMO-managed Prometheus persistent volumes
 	return updateExistingVolumeClaims(ctx)
 }
@@ -111,6 +118,9 @@ func postInstallUpgrade(ctx spi.ComponentContext) error {
 	if err := applySystemMonitors(ctx); err != nil {
 		return err
 	}
+	if err := applyPlatformAlertRules(ctx); err != nil {
+		return err
+	}
 	if err := updateApplicationAuthorizationPolicies(ctx); err != nil {
 		return err
 	}
@@ -305,6 +315,11 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 				Storage: defaultPrometheusStorage,
 			}
 		}
+		// The resources of the Prometheus workload settings replace the memory request of the storage settings
+		prometheusWorkload := GetPrometheusWorkload(ctx.EffectiveCR())
+		if prometheusWorkload != nil && prometheusWorkload.Resources != nil {
+			resourceRequest.Memory = ""
+		}
 		if resourceRequest != nil {
 			kvs, err = appendResourceRequestOverrides(ctx, resourceRequest, kvs)
 			if err != nil {
@@ -335,6 +350,11 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 		if err != nil {
 			return kvs, ctx.Log().ErrorfNewErr("Failed applying additional volume overrides for Prometheus")
 		}
+
+		kvs, err = appendPrometheusWorkloadOverrides(prometheusWorkload, kvs)
+		if err != nil {
+			return kvs, ctx.Log().ErrorfNewErr("Failed applying the workload overrides for Prometheus: %v", err)
+		}
 	} else {
 		kvs = append(kvs, bom.KeyValue{
 			Key:   "prometheus.enabled",
@@ -342,6 +362,8 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 		})
 	}
 
+	kvs = appendAlertmanagerOverrides(ctx, kvs)
+
 	// Add a label to Prometheus Operator resources to distinguish Verrazzano resources
 	kvs = append(kvs, bom.KeyValue{Key: fmt.Sprintf("commonLabels.%s", constants.VerrazzanoComponentLabelKey), Value: ComponentName})
 
@@ -351,6 +373,19 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 	return kvs, nil
 }
 
+// appendPrometheusWorkloadOverrides adds the workload settings of Prometheus to the spec of the Prometheus resource
+func appendPrometheusWorkloadOverrides(workload *vzapi.WorkloadSpec, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
+	workloadOverrides, err := helm.GetWorkloadOverrides(workload, prometheusWorkloadValues)
+	if err != nil || len(workloadOverrides) == 0 {
+		return kvs, err
+	}
+	file, err := vzos.CreateTempFile("helm-overrides-workload-prometheus-*.yaml", []byte(workloadOverrides))
+	if err != nil {
+		return kvs, err
+	}
+	return append(kvs, bom.KeyValue{Value: file.Name(), IsFile: true}), nil
+}
+
 // appendResourceRequestOverrides adds overrides for persistent storage and memory
 func appendResourceRequestOverrides(ctx spi.ComponentContext, resourceRequest *common.ResourceRequestValues, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
 	storage := resourceRequest.Storage
@@ -429,7 +464,7 @@ func (c prometheusComponent) validatePrometheusOperator(vz *installv1beta1.Verra
 			return err
 		}
 	}
-	return nil
+	return validateAlertmanager(vz)
 }
 
 // appendIstioOverrides appends Istio annotations necessary for Prometheus in Istio
@@ -487,6 +522,36 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []vzapi.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusOperator != nil {
+			return effectiveCR.Spec.Components.PrometheusOperator.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusOperator != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.PrometheusOperator.Workload)
+		}
+	}
+	return nil
+}
+
+// GetPrometheusWorkload gets the workload settings of the Prometheus resource created by the Prometheus Operator
+func GetPrometheusWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Prometheus != nil {
+			return effectiveCR.Spec.Components.Prometheus.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Prometheus != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.Prometheus.Workload)
+		}
+	}
+	return nil
+}
+
 // appendAdditionalVolumeOverrides adds a volume and volume mount so we can mount managed cluster TLS certs from a secret in the Prometheus pod.
 // Initially the secret does not exist. When managed clusters are created, the secret is created and Prometheus TLS certs for the managed
 // clusters are added to the secret.
@@ -505,26 +570,33 @@ func appendAdditionalVolumeOverrides(ctx spi.ComponentContext, volumeMountKey, v
 // applySystemMonitors applies templatized PodMonitor and ServiceMonitor custom resources for Verrazzano system
 // components to the cluster
 func applySystemMonitors(ctx spi.ComponentContext) error {
-	// create template key/value map
+	args := newManifestTemplateArgs(ctx)
+
+	// substitute template values to all files in the directory and apply the resulting YAML
+	dir := path.Join(config.GetThirdPartyManifestsDir(), "prometheus-operator")
+	yamlApplier := k8sutil.NewYAMLApplier(ctx.Client(), "")
+	err := yamlApplier.ApplyDT(dir, args)
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed to substitute template values for System Monitors: %v", err)
+	}
+	return nil
+}
+
+// newManifestTemplateArgs returns the template key/value map of the system monitor and alert rule manifests
+func newManifestTemplateArgs(ctx spi.ComponentContext) map[string]interface{} {
 	args := make(map[string]interface{})
 	args["systemNamespace"] = constants.VerrazzanoSystemNamespace
 	args["monitoringNamespace"] = constants.VerrazzanoMonitoringNamespace
 	args["nginxNamespace"] = constants.IngressNginxNamespace
 	args["istioNamespace"] = constants.IstioSystemNamespace
 	args["installNamespace"] = constants.VerrazzanoInstallNamespace
+	args["keycloakNamespace"] = constants.KeycloakNamespace
+	args["certManagerNamespace"] = vzconst.CertManagerNamespace
 
 	istio := ctx.EffectiveCR().Spec.Components.Istio
 	enabled := istio != nil && istio.IsInjectionEnabled()
 	args["isIstioEnabled"] = enabled
-
-	// substitute template values to all files in the directory and apply the resulting YAML
-	dir := path.Join(config.GetThirdPartyManifestsDir(), "prometheus-operator")
-	yamlApplier := k8sutil.NewYAMLApplier(ctx.Client(), "")
-	err := yamlApplier.ApplyDT(dir, args)
-	if err != nil {
-		return ctx.Log().ErrorfNewErr("Failed to substitute template values for System Monitors: %v", err)
-	}
-	return nil
+	return args
 }
 
 func updateApplicationAuthorizationPolicies(ctx spi.ComponentContext) error {
@@ -35,6 +35,15 @@ const ComponentJSONName = "prometheusOperator"
 
 const chartDir = "prometheus-community/kube-prometheus-stack"
 
+// prometheusWorkloadValues maps the workload settings of Prometheus onto the Prometheus spec in the chart values
+var prometheusWorkloadValues = helm.WorkloadValues{
+	ResourcesKey:         "prometheus.prometheusSpec.resources",
+	ReplicasKey:          "prometheus.prometheusSpec.replicas",
+	NodeSelectorKey:      "prometheus.prometheusSpec.nodeSelector",
+	TolerationsKey:       "prometheus.prometheusSpec.tolerations",
+	PriorityClassNameKey: "prometheus.prometheusSpec.priorityClassName",
+}
+
 const (
 	prometheusHostName        = "prometheus.vmi.system"
 	prometheusCertificateName = "system-tls-prometheus"
@@ -46,6 +55,25 @@ type prometheusComponent struct {
 	helm.HelmComponent
 }
 
+// networkPolicyRules are the network peers of the Prometheus pods outside of the verrazzano-monitoring namespace,
+// Prometheus scrapes the pods of all the namespaces and is queried by the Verrazzano system pods, Alertmanager sends
+// the notifications to its receivers, and the operator serves its admission webhook
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app.kubernetes.io/name": constants.PrometheusStorageLabelValue},
+		Ingress:   []spi.NetworkPeer{{Namespace: constants.VerrazzanoSystemNamespace, Ports: []int32{9090}}},
+		Egress:    []spi.NetworkPeer{{}},
+	},
+	{
+		PodLabels: map[string]string{"app.kubernetes.io/name": "alertmanager"},
+		Egress:    []spi.NetworkPeer{{}},
+	},
+	{
+		PodLabels: map[string]string{"app": "kube-prometheus-stack-operator"},
+		Ingress:   []spi.NetworkPeer{{Ports: []int32{10250}}},
+	},
+}
+
 func NewComponent() spi.Component {
 	return prometheusComponent{
 		helm.HelmComponent{
@@ -53,6 +81,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), chartDir),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -64,6 +93,13 @@ func NewComponent() spi.Component {
 			Dependencies:            []string{nginx.ComponentName, certmanager.ComponentName, vmo.ComponentName},
 			AppendOverridesFunc:     AppendOverrides,
 			GetInstallOverridesFunc: GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "prometheusOperator.resources",
+				NodeSelectorKey:      "prometheusOperator.nodeSelector",
+				TolerationsKey:       "prometheusOperator.tolerations",
+				PriorityClassNameKey: "prometheusOperator.priorityClassName",
+			},
 		},
 	}
 }
@@ -6,6 +6,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 
@@ -486,8 +487,8 @@ func TestApplySystemMonitors(t *testing.T) {
 	monitors.SetGroupVersionKind(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"})
 	err = client.List(context.TODO(), monitors)
 	assert.NoError(t, err)
-	// expect that 9 ServiceMonitors are created
-	assert.Len(t, monitors.Items, 9)
+	// expect that 10 ServiceMonitors are created
+	assert.Len(t, monitors.Items, 10)
 }
 
 // TestValidatePrometheusOperator tests the validation of the Prometheus Operator installation and the Verrazzano CR
@@ -1215,3 +1216,47 @@ func TestAppendResourceRequestOverrides(t *testing.T) {
 		})
 	}
 }
+
+// TestAppendPrometheusWorkloadOverrides tests the workload overrides of Prometheus
+// GIVEN a Verrazzano CR with Prometheus workload settings
+// WHEN the AppendOverrides function is called
+// THEN a values file with the workload settings of the Prometheus spec is appended
+func TestAppendPrometheusWorkloadOverrides(t *testing.T) {
+	oldBomPath := config.GetDefaultBOMFilePath()
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath(oldBomPath)
+
+	replicas := int32(2)
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Prometheus: &vzapi.PrometheusComponent{
+					Workload: &vzapi.WorkloadSpec{
+						Replicas:          &replicas,
+						PriorityClassName: "verrazzano-high",
+					},
+				},
+			},
+		},
+	}
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), vz, nil, false)
+	kvs, err := AppendOverrides(ctx, "", "", "", nil)
+	assert.NoError(t, err)
+
+	var workloadFile string
+	for _, kv := range kvs {
+		if kv.IsFile && strings.Contains(kv.Value, "workload-prometheus") {
+			workloadFile = kv.Value
+		}
+	}
+	assert.NotEmpty(t, workloadFile)
+	defer os.Remove(workloadFile)
+	data, err := os.ReadFile(workloadFile)
+	assert.NoError(t, err)
+	assert.YAMLEq(t, `
+prometheus:
+  prometheusSpec:
+    replicas: 2
+    priorityClassName: verrazzano-high
+`, string(data))
+}
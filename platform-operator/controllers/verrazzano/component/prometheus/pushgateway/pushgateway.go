@@ -67,3 +67,18 @@ func GetOverrides(object runtime.Object) interface{} {
 
 	return []vzapi.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusPushgateway != nil {
+			return effectiveCR.Spec.Components.PrometheusPushgateway.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.PrometheusPushgateway != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.PrometheusPushgateway.Workload)
+		}
+	}
+	return nil
+}
@@ -32,6 +32,15 @@ type prometheusPushgatewayComponent struct {
 	helm.HelmComponent
 }
 
+// networkPolicyRules are the network peers of the Pushgateway outside of the verrazzano-monitoring namespace, the
+// batch jobs of all the namespaces push their metrics
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app.kubernetes.io/name": "prometheus-pushgateway"},
+		Ingress:   []spi.NetworkPeer{{Ports: []int32{9091}}},
+	},
+}
+
 func NewComponent() spi.Component {
 	return prometheusPushgatewayComponent{
 		helm.HelmComponent{
@@ -39,6 +48,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), chartName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -48,6 +58,14 @@ func NewComponent() spi.Component {
 			AppendOverridesFunc:       AppendOverrides,
 			Dependencies:              []string{promoperator.ComponentName},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc:      GetWorkload,
+				ResourcesKey:         "resources",
+				ReplicasKey:          "replicaCount",
+				NodeSelectorKey:      "nodeSelector",
+				TolerationsKey:       "tolerations",
+				PriorityClassNameKey: "priorityClassName",
+			},
 		},
 	}
 }
@@ -6,6 +6,7 @@ package rancher
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"strings"
 
@@ -55,6 +56,20 @@ func SetDefaultCheckRancherUpgradeFailureFunc() {
 	checkRancherUpgradeFailureFunc = checkRancherUpgradeFailure
 }
 
+// discoverOIDCProviderSig is a function needed for unit test override
+type discoverOIDCProviderSig func(ctx spi.ComponentContext, oidc *vzapi.AuthProxyOIDC) (*common.OIDCProviderMetadata, error)
+
+// discoverOIDCProviderFunc is the default discoverOIDCProvider function
+var discoverOIDCProviderFunc discoverOIDCProviderSig = common.DiscoverOIDCProvider
+
+func SetDiscoverOIDCProviderFunc(f discoverOIDCProviderSig) {
+	discoverOIDCProviderFunc = f
+}
+
+func SetDefaultDiscoverOIDCProviderFunc() {
+	discoverOIDCProviderFunc = common.DiscoverOIDCProvider
+}
+
 // Constants for Kubernetes resource names
 const (
 	// note: VZ-5241 In Rancher 2.6.3 the agent was moved from cattle-fleet-system ns
@@ -440,6 +455,21 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []vzapi.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Rancher != nil {
+			return effectiveCR.Spec.Components.Rancher.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Rancher != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.Rancher.Workload)
+		}
+	}
+	return nil
+}
+
 // Delete the local cluster
 func DeleteLocalCluster(log vzlog.VerrazzanoLogger, c client.Client) {
 	log.Once("Deleting Rancher local cluster")
@@ -562,6 +592,59 @@ func configureKeycloakOIDC(ctx spi.ComponentContext) error {
 	return common.UpdateKeycloakOIDCAuthConfig(ctx, authConfig)
 }
 
+// configureExternalOIDC configures the external OIDC provider of the AuthProxy as the OIDC provider in Rancher, the
+// provider is configured through the keycloakoidc AuthConfig which supports any OpenID Connect provider
+func configureExternalOIDC(ctx spi.ComponentContext, oidc *vzapi.AuthProxyOIDC) error {
+	log := ctx.Log()
+	rancherURL, err := k8sutil.GetURLForIngress(ctx.Client(), "rancher", "cattle-system", "https")
+	if err != nil {
+		log.Oncef("skipping configuring the external OIDC provider for rancher, unable to fetch rancher url: %s", err.Error())
+		return nil
+	}
+
+	metadata, err := discoverOIDCProviderFunc(ctx, oidc)
+	if err != nil {
+		return log.ErrorfThrottledNewErr("failed configuring the external OIDC provider for rancher, unable to discover the provider: %s", err.Error())
+	}
+
+	clientSecret, err := common.GetExternalOIDCSecretValue(ctx, oidc.ClientSecretRef)
+	if err != nil {
+		return log.ErrorfThrottledNewErr("failed configuring the external OIDC provider for rancher, unable to fetch the client secret: %s", err.Error())
+	}
+
+	authConfig := make(map[string]interface{})
+	authConfig[AuthConfigKeycloakAttributeAccessMode] = AuthConfigKeycloakAccessMode
+	authConfig[AuthConfigKeycloakAttributeClientID] = oidc.ClientID
+	// group search queries the Keycloak admin API, Rancher learns the groups from the ID token instead
+	authConfig[AuthConfigKeycloakAttributeGroupSearchEnabled] = false
+	authConfig[AuthConfigKeycloakAttributeAuthEndpoint] = metadata.AuthorizationEndpoint
+	authConfig[common.AuthConfigKeycloakAttributeClientSecret] = string(clientSecret)
+	authConfig[AuthConfigKeycloakAttributeIssuer] = oidc.IssuerURL
+	authConfig[AuthConfigKeycloakAttributeRancherURL] = rancherURL + AuthConfigKeycloakURLPathVerifyAuth
+	authConfig[AuthConfigAttributeEnabled] = true
+
+	return common.UpdateKeycloakOIDCAuthConfig(ctx, authConfig)
+}
+
+// createOrUpdateExternalOIDCClusterRoleTemplateBindings binds the provider groups mapped to the Verrazzano admin and
+// monitor groups to the cluster roles of those groups
+func createOrUpdateExternalOIDCClusterRoleTemplateBindings(ctx spi.ComponentContext, oidc *vzapi.AuthProxyOIDC) error {
+	providerGroups := map[string][]string{
+		VerrazzanoAdminsGroupName:   oidc.AdminGroups,
+		VerrazzanoMonitorsGroupName: oidc.MonitorGroups,
+	}
+	for _, grp := range GroupRolePairs {
+		for _, providerGroup := range providerGroups[grp[GroupKey]] {
+			hash := sha256.Sum256([]byte(providerGroup))
+			name := fmt.Sprintf("crtb-%s-oidc-%x", grp[ClusterRoleKey], hash[:5])
+			if err := createOrUpdateClusterRoleTemplateBindingForGroup(ctx, name, grp[ClusterRoleKey], providerGroup); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // createOrUpdateResource creates or updates a Rancher resource
 func createOrUpdateResource(ctx spi.ComponentContext, nsn types.NamespacedName, gvk schema.GroupVersionKind, attributes map[string]interface{}) error {
 	log := ctx.Log()
@@ -646,7 +729,11 @@ func createOrUpdateRoleTemplate(ctx spi.ComponentContext, role string) error {
 
 // createOrUpdateClusterRoleTemplateBinding creates or updates ClusterRoleTemplateBinding used to add Keycloak groups to the Rancher cluster
 func createOrUpdateClusterRoleTemplateBinding(ctx spi.ComponentContext, clusterRole string, group string) error {
-	name := fmt.Sprintf("crtb-%s-%s", clusterRole, group)
+	return createOrUpdateClusterRoleTemplateBindingForGroup(ctx, fmt.Sprintf("crtb-%s-%s", clusterRole, group), clusterRole, group)
+}
+
+// createOrUpdateClusterRoleTemplateBindingForGroup creates or updates the named CRTB binding the group to the cluster role
+func createOrUpdateClusterRoleTemplateBindingForGroup(ctx spi.ComponentContext, name string, clusterRole string, group string) error {
 	nsn := types.NamespacedName{Name: name, Namespace: ClusterLocal}
 
 	data := map[string]interface{}{}
@@ -48,6 +48,20 @@ var certificates = []types.NamespacedName{
 	{Name: "tls-rancher-ingress", Namespace: ComponentNamespace},
 }
 
+// networkPolicyRules are the network peers of the Rancher pods outside of the cattle-system namespace, Rancher serves
+// its API and webhook, and connects to the cluster agents and the catalogs
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		Ingress: []spi.NetworkPeer{{Ports: []int32{80, 443, 444, 9443}}},
+		Egress:  []spi.NetworkPeer{{}},
+	},
+}
+
+// podDisruptionBudgets are the replicated Rancher server pods
+var podDisruptionBudgets = []spi.PodDisruptionBudget{
+	{Name: common.RancherName, PodLabels: map[string]string{"app": common.RancherName}},
+}
+
 func NewComponent() spi.Component {
 	return rancherComponent{
 		HelmComponent: helm.HelmComponent{
@@ -55,6 +69,8 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), common.RancherName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
+			PodDisruptionBudgets:      podDisruptionBudgets,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -70,6 +86,11 @@ func NewComponent() spi.Component {
 				},
 			},
 			GetInstallOverridesFunc: GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc: GetWorkload,
+				ResourcesKey:    "resources",
+				ReplicasKey:     "replicas",
+			},
 		},
 	}
 }
@@ -126,7 +147,7 @@ func appendCAOverrides(log vzlog.VerrazzanoLogger, kvs []bom.KeyValue, ctx spi.C
 	}
 
 	// Configure CA Issuer KVs
-	if (cm.Certificate.Acme != vzapi.Acme{}) {
+	if common.IsLetsEncrypt(cm.Certificate.Acme) {
 		kvs = append(kvs,
 			bom.KeyValue{
 				Key:   letsEncryptIngressClassKey,
@@ -144,7 +165,7 @@ func appendCAOverrides(log vzlog.VerrazzanoLogger, kvs []bom.KeyValue, ctx spi.C
 				Key:   additionalTrustedCAsKey,
 				Value: strconv.FormatBool(useAdditionalCAs(cm.Certificate.Acme)),
 			})
-	} else { // Certificate issuer type is CA
+	} else { // Certificate issuer type is CA, a private ACME server or an existing ClusterIssuer
 		kvs = append(kvs, bom.KeyValue{
 			Key:   ingressTLSSourceKey,
 			Value: caTLSSource,
@@ -356,6 +377,9 @@ func activateDrivers(log vzlog.VerrazzanoLogger, c client.Client) error {
 // +enables or disables Keycloak Auth provider.
 func configureAuthProviders(ctx spi.ComponentContext) error {
 	log := ctx.Log()
+	if vzconfig.IsExternalOIDCEnabled(ctx.EffectiveCR()) {
+		return configureExternalOIDCAuthProvider(ctx)
+	}
 	if vzconfig.IsKeycloakEnabled(ctx.ActualCR()) && isKeycloakAuthEnabled(ctx.ActualCR()) {
 		if err := configureKeycloakOIDC(ctx); err != nil {
 			return log.ErrorfThrottledNewErr("failed configuring keycloak oidc provider: %s", err.Error())
@@ -385,6 +409,31 @@ func configureAuthProviders(ctx spi.ComponentContext) error {
 	return nil
 }
 
+// configureExternalOIDCAuthProvider
+// +configures the external OIDC provider of the AuthProxy as OIDC provider for Rancher.
+// +creates or updates the CRTBs for the provider groups mapped to the verrazzano-admins and verrazzano-monitors groups.
+// The verrazzano user is not created since it only exists in Keycloak.
+func configureExternalOIDCAuthProvider(ctx spi.ComponentContext) error {
+	log := ctx.Log()
+	oidc := common.GetExternalOIDC(ctx.EffectiveCR())
+	if err := configureExternalOIDC(ctx, oidc); err != nil {
+		return log.ErrorfThrottledNewErr("failed configuring external oidc provider: %s", err.Error())
+	}
+
+	if err := createOrUpdateRoleTemplates(ctx); err != nil {
+		return err
+	}
+
+	if err := createOrUpdateExternalOIDCClusterRoleTemplateBindings(ctx, oidc); err != nil {
+		return err
+	}
+
+	if err := disableFirstLogin(ctx); err != nil {
+		return log.ErrorfThrottledNewErr("failed disabling first login setting: %s", err.Error())
+	}
+	return nil
+}
+
 // createOrUpdateRoleTemplates creates or updates the verrazzano-admin and verrazzano-monitor RoleTemplates
 func createOrUpdateRoleTemplates(ctx spi.ComponentContext) error {
 	if err := createOrUpdateRoleTemplate(ctx, VerrazzanoAdminRoleName); err != nil {
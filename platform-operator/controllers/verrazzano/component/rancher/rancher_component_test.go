@@ -4,7 +4,10 @@
 package rancher
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
@@ -20,15 +23,20 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -247,7 +255,7 @@ func TestIsReady(t *testing.T) {
 //  THEN PostInstall should return nil
 func TestPostInstall(t *testing.T) {
 	component := NewComponent()
-	ctxWithoutIngress, ctxWithIngress := prepareContexts()
+	ctxWithoutIngress, ctxWithIngress := prepareContexts(t)
 	assert.IsType(t, fmt.Errorf(""), component.PostInstall(ctxWithoutIngress))
 	assert.Nil(t, component.PostInstall(ctxWithIngress))
 }
@@ -258,7 +266,7 @@ func TestPostInstall(t *testing.T) {
 //  THEN PostUpgrade should return nil
 func TestPostUpgrade(t *testing.T) {
 	component := NewComponent()
-	ctxWithoutIngress, ctxWithIngress := prepareContexts()
+	ctxWithoutIngress, ctxWithIngress := prepareContexts(t)
 	assert.Nil(t, component.PostUpgrade(ctxWithoutIngress))
 	assert.Nil(t, component.PostUpgrade(ctxWithIngress))
 }
@@ -369,7 +377,7 @@ func TestValidateUpdateV1beta1(t *testing.T) {
 	}
 }
 
-func prepareContexts() (spi.ComponentContext, spi.ComponentContext) {
+func prepareContexts(t *testing.T) (spi.ComponentContext, spi.ComponentContext) {
 	// mock the k8s resources used in post install
 	caSecret := createCASecret()
 	rootCASecret := createRootCASecret()
@@ -436,18 +444,6 @@ func prepareContexts() (spi.ComponentContext, spi.ComponentContext) {
 	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
 		var commands []string
 		if commands = url.Query()["command"]; len(commands) == 3 {
-			if strings.Contains(commands[2], "id,clientId") {
-				return "[{\"id\":\"something\", \"clientId\":\"" + AuthConfigKeycloakClientIDRancher + "\"}]", "", nil
-			}
-
-			if strings.Contains(commands[2], "client-secret") {
-				return "{\"type\":\"secret\",\"value\":\"abcdef\"}", "", nil
-			}
-
-			if strings.Contains(commands[2], "get users") {
-				return "[{\"id\":\"something\", \"username\":\"verrazzano\"}]", "", nil
-			}
-
 			if strings.Contains(commands[2], fmt.Sprintf("cat %s", SettingUILogoDarkLogoFilePath)) {
 				return "dark", "", nil
 			}
@@ -463,9 +459,36 @@ func prepareContexts() (spi.ComponentContext, spi.ComponentContext) {
 		config, k := k8sutilfake.NewClientsetConfig()
 		return config, k, nil
 	}
+	startFakeKeycloak(t)
 	return ctxWithoutIngress, ctxWithIngress
 }
 
+// startFakeKeycloak starts a fake Keycloak Admin REST API server with the rancher client and the verrazzano user
+func startFakeKeycloak(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/openid-connect/token"):
+			fmt.Fprint(w, `{"access_token":"token","expires_in":60}`)
+		case strings.HasSuffix(r.URL.Path, "/clients"):
+			fmt.Fprintf(w, `[{"id":"something","clientId":"%s"}]`, AuthConfigKeycloakClientIDRancher)
+		case strings.HasSuffix(r.URL.Path, "/client-secret"):
+			fmt.Fprint(w, `{"type":"secret","value":"abcdef"}`)
+		case strings.HasSuffix(r.URL.Path, "/users"):
+			fmt.Fprint(w, `[{"id":"something","username":"verrazzano"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	keycloak.SetAdminEndpointFunc(func(_ spi.ComponentContext) (*keycloak.AdminEndpoint, error) {
+		return &keycloak.AdminEndpoint{URL: server.URL, Client: server.Client()}, nil
+	})
+	t.Cleanup(func() {
+		server.Close()
+		keycloak.SetDefaultAdminEndpointFunc()
+	})
+}
+
 func newReadyDeployment(namespace string, name string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -508,3 +531,82 @@ func newReplicaSet(namespace string, name string) *appsv1.ReplicaSet {
 		},
 	}
 }
+
+// TestConfigureExternalOIDCAuthProvider tests configuring Rancher with the external OIDC provider of the AuthProxy
+// GIVEN a Verrazzano CR configuring an external OIDC provider with Keycloak disabled
+//  WHEN configureAuthProviders is called
+//  THEN the keycloakoidc AuthConfig is configured with the external provider and the provider admin and
+//       monitor groups are bound to the Verrazzano cluster roles
+func TestConfigureExternalOIDCAuthProvider(t *testing.T) {
+	asserts := assert.New(t)
+	SetDiscoverOIDCProviderFunc(func(ctx spi.ComponentContext, oidc *vzapi.AuthProxyOIDC) (*common.OIDCProviderMetadata, error) {
+		return &common.OIDCProviderMetadata{Issuer: oidc.IssuerURL, AuthorizationEndpoint: oidc.IssuerURL + "/authorize"}, nil
+	})
+	defer SetDefaultDiscoverOIDCProviderFunc()
+
+	disabled := false
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Keycloak: &vzapi.KeycloakComponent{Enabled: &disabled},
+				AuthProxy: &vzapi.AuthProxyComponent{
+					OIDC: &vzapi.AuthProxyOIDC{
+						IssuerURL:       "https://idp.example.com",
+						ClientID:        "verrazzano",
+						ClientSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp"}, Key: "client-secret"},
+						AdminGroups:     []string{"Platform Admins"},
+						MonitorGroups:   []string{"operators"},
+					},
+				},
+			},
+		},
+	}
+	ingress := v1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: common.CattleSystem, Name: constants.RancherIngress},
+		Spec:       v1.IngressSpec{Rules: []v1.IngressRule{{Host: "rancher"}}},
+	}
+	idpSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "idp"},
+		Data:       map[string][]byte{"client-secret": []byte("s3cret")},
+	}
+	authConfig := createKeycloakAuthConfig()
+	firstLoginSetting := createFirstLoginSetting()
+	adminRole := createClusterRoles(VerrazzanoAdminRoleName)
+	monitorRole := createClusterRoles(VerrazzanoMonitorRoleName)
+	cli := fake.NewClientBuilder().WithScheme(getScheme()).WithObjects(&ingress, &idpSecret, &authConfig,
+		&firstLoginSetting, &adminRole, &monitorRole).Build()
+	ctx := spi.NewFakeContext(cli, vz, nil, false)
+
+	asserts.NoError(configureAuthProviders(ctx))
+
+	actualAuthConfig := createKeycloakAuthConfig()
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Name: common.AuthConfigKeycloak}, &actualAuthConfig))
+	data := actualAuthConfig.UnstructuredContent()
+	asserts.Equal("verrazzano", data[AuthConfigKeycloakAttributeClientID])
+	asserts.Equal("s3cret", data[common.AuthConfigKeycloakAttributeClientSecret])
+	asserts.Equal("https://idp.example.com", data[AuthConfigKeycloakAttributeIssuer])
+	asserts.Equal("https://idp.example.com/authorize", data[AuthConfigKeycloakAttributeAuthEndpoint])
+	asserts.Equal("https://rancher"+AuthConfigKeycloakURLPathVerifyAuth, data[AuthConfigKeycloakAttributeRancherURL])
+	asserts.Equal(false, data[AuthConfigKeycloakAttributeGroupSearchEnabled])
+	asserts.Equal(true, data[AuthConfigAttributeEnabled])
+
+	crtbs := unstructured.UnstructuredList{}
+	crtbs.SetGroupVersionKind(GVKClusterRoleTemplateBinding)
+	asserts.NoError(cli.List(context.TODO(), &crtbs, client.InNamespace(ClusterLocal)))
+	principals := map[string][]string{}
+	for _, crtb := range crtbs.Items {
+		principal := crtb.UnstructuredContent()[ClusterRoleTemplateBindingAttributeGroupPrincipalName].(string)
+		role := crtb.UnstructuredContent()[ClusterRoleTemplateBindingAttributeRoleTemplateName].(string)
+		principals[principal] = append(principals[principal], role)
+	}
+	asserts.ElementsMatch([]string{AdminRoleName, VerrazzanoAdminRoleName, ClusterMemberRoleName}, principals[GroupPrincipalKeycloakPrefix+"Platform Admins"])
+	asserts.Contains(principals[GroupPrincipalKeycloakPrefix+"operators"], ViewRoleName)
+	asserts.Len(principals, 2)
+
+	// the Keycloak verrazzano user is not created
+	user := unstructured.Unstructured{}
+	user.SetGroupVersionKind(GVKUser)
+	err := cli.Get(context.TODO(), types.NamespacedName{Name: UserVerrazzano}, &user)
+	asserts.True(errors.IsNotFound(err))
+}
@@ -64,10 +64,10 @@ func patchRancherIngress(c client.Client, vz *vzapi.Verrazzano) error {
 	}
 	ingressMerge := client.MergeFrom(ingress.DeepCopy())
 	ingress.Annotations["kubernetes.io/tls-acme"] = "true"
-	if (cm.Certificate.Acme != vzapi.Acme{}) {
+	if common.IsLetsEncrypt(cm.Certificate.Acme) {
 		addAcmeIngressAnnotations(vz.Spec.EnvironmentName, dnsSuffix, ingress)
 	} else {
-		addCAIngressAnnotations(vz.Spec.EnvironmentName, dnsSuffix, ingress)
+		addCAIngressAnnotations(vz.Spec.EnvironmentName, dnsSuffix, vzconfig.GetClusterIssuerName(vz), ingress)
 	}
 	return c.Patch(context.TODO(), ingress, ingressMerge)
 }
@@ -83,8 +83,8 @@ func addAcmeIngressAnnotations(name, dnsSuffix string, ingress *networking.Ingre
 }
 
 //addCAIngressAnnotations annotate ingress with custom CA specific values
-func addCAIngressAnnotations(name, dnsSuffix string, ingress *networking.Ingress) {
+func addCAIngressAnnotations(name, dnsSuffix, clusterIssuer string, ingress *networking.Ingress) {
 	ingress.Annotations["nginx.ingress.kubernetes.io/auth-realm"] = fmt.Sprintf("%s.%s auth", name, dnsSuffix)
-	ingress.Annotations["cert-manager.io/cluster-issuer"] = "verrazzano-cluster-issuer"
+	ingress.Annotations["cert-manager.io/cluster-issuer"] = clusterIssuer
 	ingress.Annotations["cert-manager.io/common-name"] = fmt.Sprintf("%s.%s.%s", common.RancherName, name, dnsSuffix)
 }
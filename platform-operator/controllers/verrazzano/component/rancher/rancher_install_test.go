@@ -4,6 +4,7 @@
 package rancher
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -15,6 +16,7 @@ import (
 	networking "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -67,7 +69,7 @@ func TestAddCAIngressAnnotations(t *testing.T) {
 		},
 	}
 
-	addCAIngressAnnotations(name, dnsSuffix, &in)
+	addCAIngressAnnotations(name, dnsSuffix, "verrazzano-cluster-issuer", &in)
 	assert.Equal(t, out, in)
 }
 
@@ -99,6 +101,26 @@ func TestPatchRancherIngress(t *testing.T) {
 	}
 }
 
+// TestPatchRancherIngressIssuerRef should annotate the Rancher ingress with the referenced ClusterIssuer
+// GIVEN a Rancher Ingress and a Verrazzano CR which references an existing ClusterIssuer
+//  WHEN patchRancherIngress is called
+//  THEN patchRancherIngress should annotate the ingress with the referenced ClusterIssuer
+func TestPatchRancherIngressIssuerRef(t *testing.T) {
+	ingress := networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   common.CattleSystem,
+			Name:        common.RancherName,
+			Annotations: map[string]string{"test": "data"},
+		},
+	}
+	vz := vzDefaultCA.DeepCopy()
+	vz.Spec.Components.CertManager.Certificate = vzapi.Certificate{IssuerRef: vzapi.IssuerRef{Name: "vault-issuer"}}
+	c := fake.NewClientBuilder().WithScheme(getScheme()).WithObjects(&ingress).Build()
+	assert.Nil(t, patchRancherIngress(c, vz))
+	assert.Nil(t, c.Get(context.TODO(), client.ObjectKeyFromObject(&ingress), &ingress))
+	assert.Equal(t, "vault-issuer", ingress.Annotations["cert-manager.io/cluster-issuer"])
+}
+
 // TestPatchRancherIngressNotFound should fail to find the ingress
 // GIVEN no Rancher Ingress and a Verrazzano CR
 //  WHEN patchRancherIngress is called
@@ -32,7 +32,7 @@ var (
 				CertManager: &vzapi.CertManagerComponent{
 					Certificate: vzapi.Certificate{
 						Acme: vzapi.Acme{
-							Provider:     "foobar",
+							Provider:     vzapi.LetsEncrypt,
 							EmailAddress: "foo@bar.com",
 							Environment:  "dev",
 						},
@@ -47,6 +47,21 @@ func GetOverrides(object runtime.Object) interface{} {
 	return []vzapi.Overrides{}
 }
 
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.RancherBackup != nil {
+			return effectiveCR.Spec.Components.RancherBackup.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.RancherBackup != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.RancherBackup.Workload)
+		}
+	}
+	return nil
+}
+
 // AppendOverrides appends Helm value overrides for the Rancher Backups component's Helm chart
 func AppendOverrides(compContext spi.ComponentContext, _ string, _ string, _ string, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
 	bomFile, err := bom.NewBom(config.GetDefaultBOMFilePath())
@@ -18,7 +18,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	k8scheme "k8s.io/client-go/kubernetes/scheme"
-	"os/exec"
 	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
@@ -40,18 +39,6 @@ var rancherBackupEnabledCR = &v1alpha1.Verrazzano{
 	},
 }
 
-// genericTestRunner is used to run generic OS commands with expected results
-type genericTestRunner struct {
-	stdOut []byte
-	stdErr []byte
-	err    error
-}
-
-// Run genericTestRunner executor
-func (r genericTestRunner) Run(_ *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return r.stdOut, r.stdErr, r.err
-}
-
 // TestIsEnabled tests the IsEnabled function for the Rancher Backup Operator component
 func TestIsEnabled(t *testing.T) {
 	falseValue := false
@@ -141,15 +128,11 @@ func TestIsInstalled(t *testing.T) {
 
 func TestInstallUpgrade(t *testing.T) {
 	defer config.Set(config.Get())
-	v := NewComponent()
 	config.Set(config.OperatorConfig{VerrazzanoRootDir: "../../../../../"})
+	v := NewComponent()
 
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return helm.ChartNotFound, nil
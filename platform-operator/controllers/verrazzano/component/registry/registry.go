@@ -44,6 +44,9 @@ var getComponentsFn = getComponents
 
 var componentsRegistry []spi.Component
 
+// userComponents are the user-defined components, they are processed after the built-in components
+var userComponents []spi.Component
+
 var mutex sync.Mutex
 
 // OverrideGetComponentsFn Allows overriding the set of registry components for testing purposes
@@ -65,6 +68,19 @@ func GetComponents() []spi.Component {
 
 // getComponents is the internal impl function for GetComponents, to allow overriding it for testing purposes
 func getComponents() []spi.Component {
+	builtInComponents := getBuiltInComponents()
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(userComponents) == 0 {
+		return builtInComponents
+	}
+	components := make([]spi.Component, 0, len(builtInComponents)+len(userComponents))
+	components = append(components, builtInComponents...)
+	return append(components, userComponents...)
+}
+
+// getBuiltInComponents returns the components that are part of Verrazzano
+func getBuiltInComponents() []spi.Component {
 	mutex.Lock()
 	defer mutex.Unlock()
 	if len(componentsRegistry) == 0 {
@@ -103,6 +119,23 @@ func getComponents() []spi.Component {
 	return componentsRegistry
 }
 
+// SetUserComponents replaces the set of user-defined components, which are processed after the built-in components
+func SetUserComponents(components []spi.Component) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	userComponents = components
+}
+
+// IsBuiltInComponent returns true if the name is the name of a built-in component
+func IsBuiltInComponent(componentName string) bool {
+	for _, comp := range getBuiltInComponents() {
+		if comp.Name() == componentName {
+			return true
+		}
+	}
+	return false
+}
+
 func FindComponent(componentName string) (bool, spi.Component) {
 	for _, comp := range GetComponents() {
 		if comp.Name() == componentName {
@@ -738,3 +738,30 @@ func (f fakeComponent) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1bet
 func (f fakeComponent) GetCertificateNames(_ spi.ComponentContext) []types.NamespacedName {
 	return []types.NamespacedName{}
 }
+
+func (f fakeComponent) GetNetworkPolicyRules(_ spi.ComponentContext) []spi.NetworkPolicyRules {
+	return nil
+}
+
+// TestSetUserComponents tests SetUserComponents
+// GIVEN user-defined components
+//  WHEN I call SetUserComponents
+//  THEN GetComponents returns the built-in components followed by the user-defined components
+func TestSetUserComponents(t *testing.T) {
+	a := assert.New(t)
+	defer SetUserComponents(nil)
+	builtInCount := len(GetComponents())
+
+	SetUserComponents([]spi.Component{helm2.HelmComponent{ReleaseName: "foo"}, helm2.HelmComponent{ReleaseName: "bar"}})
+	comps := GetComponents()
+	a.Len(comps, builtInCount+2)
+	a.Equal("foo", comps[builtInCount].Name())
+	a.Equal("bar", comps[builtInCount+1].Name())
+	found, _ := FindComponent("bar")
+	a.True(found)
+	a.True(IsBuiltInComponent(istio.ComponentName))
+	a.False(IsBuiltInComponent("foo"))
+
+	SetUserComponents(nil)
+	a.Len(GetComponents(), builtInCount)
+}
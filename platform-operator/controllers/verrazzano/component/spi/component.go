@@ -60,6 +60,8 @@ type ComponentInfo interface {
 	GetIngressNames(context ComponentContext) []types.NamespacedName
 	// GetCertificateNames returns a list of names of the TLS certificates associated with the component
 	GetCertificateNames(context ComponentContext) []types.NamespacedName
+	// GetNetworkPolicyRules returns the network peers of the pods of the component outside of its namespace
+	GetNetworkPolicyRules(context ComponentContext) []NetworkPolicyRules
 	// GetJsonName returns the josn name of the verrazzano component in CRD
 	GetJSONName() string
 	// GetOverrides returns the list of overrides for a component
@@ -118,6 +120,51 @@ type ComponentValidator interface {
 	ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1beta1.Verrazzano) error
 }
 
+// ComponentWorkload interface is implemented by the components whose pods are sized and scheduled with the workload
+// settings of the Verrazzano resource
+type ComponentWorkload interface {
+	// GetWorkload returns the workload settings of the component, nil if none are set
+	GetWorkload(effectiveCR runtime.Object) *v1alpha1.WorkloadSpec
+}
+
+// ComponentAvailability interface is implemented by the components whose replicated pods are protected by pod
+// disruption budgets in the high availability mode
+type ComponentAvailability interface {
+	// GetPodDisruptionBudgets returns the sets of replicated pods of the component
+	GetPodDisruptionBudgets(context ComponentContext) []PodDisruptionBudget
+}
+
+// NetworkPolicyRules declares the network peers of a set of pods of a component.  When the network policies of the
+// platform are enabled, only the traffic within a namespace and the traffic declared by its components is allowed.
+type NetworkPolicyRules struct {
+	// PodLabels select the pods of the component, all the pods of its namespace if empty
+	PodLabels map[string]string
+	// Ingress are the peers allowed to connect to the pods
+	Ingress []NetworkPeer
+	// Egress are the peers the pods are allowed to connect to
+	Egress []NetworkPeer
+}
+
+// NetworkPeer is a network peer of the pods of a component.  A peer without a namespace and pod labels is any peer,
+// including the peers outside of the cluster.
+type NetworkPeer struct {
+	// Namespace is the namespace of the peer, any namespace if empty
+	Namespace string
+	// PodLabels select the pods of the peer, all the pods of the namespace if empty
+	PodLabels map[string]string
+	// Ports are the TCP ports of the traffic, all the ports if empty
+	Ports []int32
+}
+
+// PodDisruptionBudget declares a set of replicated pods of a component in the namespace of the component.  In the high
+// availability mode, only one of the pods can be evicted at a time, for example while a node is drained.
+type PodDisruptionBudget struct {
+	// Name is the name of the pod disruption budget
+	Name string
+	// PodLabels select the pods
+	PodLabels map[string]string
+}
+
 // Generate mocs for the spi.Component interface for use in tests.
 //go:generate mockgen -destination=../../../../mocks/component_mock.go -package=mocks -copyright_file=../../../../hack/boilerplate.go.txt github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi Component
 
@@ -0,0 +1,98 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package usercomponent
+
+import (
+	"fmt"
+
+	helmcli "github.com/verrazzano/verrazzano/pkg/helm"
+	"github.com/verrazzano/verrazzano/pkg/k8s/status"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/secret"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/namespace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// userComponent is a component defined by a VerrazzanoComponent resource
+type userComponent struct {
+	helm.HelmComponent
+
+	// monitorChanges indicates whether the override sources of the component are monitored
+	monitorChanges bool
+
+	// readinessDeployments are the deployments that must be available before the component is ready
+	readinessDeployments []types.NamespacedName
+}
+
+// NewComponent returns a component, backed by Helm, for the VerrazzanoComponent resource
+func NewComponent(vc *componentsv1alpha1.VerrazzanoComponent) spi.Component {
+	spec := vc.Spec
+	overrides := spec.ValueOverrides
+	var deployments []types.NamespacedName
+	for _, name := range spec.ReadinessDeployments {
+		deployments = append(deployments, types.NamespacedName{Namespace: spec.Namespace, Name: name})
+	}
+	return userComponent{
+		HelmComponent: helm.HelmComponent{
+			ReleaseName:               vc.GetReleaseName(),
+			JSONName:                  vc.GetReleaseName(),
+			ChartDir:                  spec.Chart.Path,
+			ChartNamespace:            spec.Namespace,
+			IgnoreNamespaceOverride:   true,
+			IgnoreImageOverrides:      true,
+			SupportsOperatorInstall:   true,
+			SupportsOperatorUninstall: true,
+			ImagePullSecretKeyname:    secret.DefaultImagePullSecretKeyName,
+			Dependencies:              spec.Dependencies,
+			PreInstallFunc:            preInstall,
+			GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+				if _, ok := object.(*v1beta1.Verrazzano); ok {
+					return v1alpha1.ConvertValueOverridesToV1Beta1(overrides)
+				}
+				return overrides
+			},
+		},
+		monitorChanges:       spec.MonitorChanges == nil || *spec.MonitorChanges,
+		readinessDeployments: deployments,
+	}
+}
+
+// IsReady returns true if the Helm release is deployed and the readiness deployments are available.  The chart
+// version is not compared with the release, since the chart does not have to be available on the local file system.
+func (c userComponent) IsReady(ctx spi.ComponentContext) bool {
+	if ctx.IsDryRun() {
+		ctx.Log().Debugf("IsReady() dry run for %s", c.ReleaseName)
+		return true
+	}
+	deployed, err := helmcli.IsReleaseDeployed(c.ReleaseName, c.ChartNamespace)
+	if err != nil {
+		ctx.Log().ErrorfThrottled("Failed getting the status of the Helm release %s/%s for component %s: %v", c.ChartNamespace, c.ReleaseName, c.Name(), err)
+		return false
+	}
+	if !deployed {
+		ctx.Log().Progressf("Component %s is waiting for the Helm release %s/%s to be deployed", c.Name(), c.ChartNamespace, c.ReleaseName)
+		return false
+	}
+	prefix := fmt.Sprintf("Component %s", c.Name())
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), c.readinessDeployments, 1, prefix)
+}
+
+// MonitorOverrides returns true if the override sources of the component are monitored for changes
+func (c userComponent) MonitorOverrides(_ spi.ComponentContext) bool {
+	return c.monitorChanges
+}
+
+// preInstall creates the namespace of the Helm release
+func preInstall(ctx spi.ComponentContext, _ string, namespaceName string, _ string) error {
+	if ctx.IsDryRun() {
+		ctx.Log().Debugf("Component %s PreInstall dry run", ctx.GetComponent())
+		return nil
+	}
+	return namespace.CreateAndLabelNamespace(ctx.Client(), namespaceName, false, false)
+}
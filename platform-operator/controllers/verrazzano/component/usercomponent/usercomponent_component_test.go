@@ -0,0 +1,165 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package usercomponent
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	helmcli "github.com/verrazzano/verrazzano/pkg/helm"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace  = "policy-system"
+	testDeployment = "policy-agent"
+)
+
+// TestNewComponent tests the NewComponent function
+// GIVEN a VerrazzanoComponent resource
+// WHEN NewComponent is called
+// THEN a component with the name, namespace, dependencies and overrides of the resource is returned
+func TestNewComponent(t *testing.T) {
+	asserts := assert.New(t)
+	vc := newTestVerrazzanoComponent()
+
+	comp := NewComponent(vc)
+	asserts.Equal("policy-agent", comp.Name())
+	asserts.Equal("policy-agent", comp.GetJSONName())
+	asserts.Equal(testNamespace, comp.Namespace())
+	asserts.Equal([]string{"istio"}, comp.GetDependencies())
+	asserts.True(comp.IsOperatorInstallSupported())
+	asserts.True(comp.IsOperatorUninstallSupported())
+	asserts.True(comp.IsEnabled(&v1alpha1.Verrazzano{}))
+	asserts.True(comp.MonitorOverrides(nil))
+
+	overrides := comp.GetOverrides(&v1alpha1.Verrazzano{}).([]v1alpha1.Overrides)
+	asserts.Equal(vc.Spec.ValueOverrides, overrides)
+	overridesV1Beta1 := comp.GetOverrides(&v1beta1.Verrazzano{}).([]v1beta1.Overrides)
+	asserts.Len(overridesV1Beta1, 1)
+	asserts.Equal(vc.Spec.ValueOverrides[0].Values, overridesV1Beta1[0].Values)
+
+	// The release name in the spec takes precedence over the resource name
+	monitorChanges := false
+	vc.Spec.ReleaseName = "agent"
+	vc.Spec.MonitorChanges = &monitorChanges
+	comp = NewComponent(vc)
+	asserts.Equal("agent", comp.Name())
+	asserts.False(comp.MonitorOverrides(nil))
+}
+
+// TestIsReady tests the IsReady function
+// GIVEN a user-defined component with a readiness deployment
+// WHEN IsReady is called
+// THEN true is returned only if the Helm release is deployed and the deployment is available
+func TestIsReady(t *testing.T) {
+	asserts := assert.New(t)
+	defer helmcli.SetDefaultChartStatusFunction()
+	comp := NewComponent(newTestVerrazzanoComponent())
+
+	// Release not deployed
+	helmcli.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return helmcli.ChartNotFound, nil
+	})
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(newTestDeployment(1)).Build()
+	asserts.False(comp.IsReady(spi.NewFakeContext(c, &v1alpha1.Verrazzano{}, nil, false)))
+
+	// Failed to get the status of the release
+	helmcli.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return "", fmt.Errorf("failed to get the release status")
+	})
+	asserts.False(comp.IsReady(spi.NewFakeContext(c, &v1alpha1.Verrazzano{}, nil, false)))
+
+	// Release deployed, deployment not available
+	helmcli.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return helmcli.ChartStatusDeployed, nil
+	})
+	c = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(newTestDeployment(0)).Build()
+	asserts.False(comp.IsReady(spi.NewFakeContext(c, &v1alpha1.Verrazzano{}, nil, false)))
+
+	// Release deployed, deployment available
+	c = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(newTestDeployment(1), newTestPod(), newTestReplicaSet()).Build()
+	asserts.True(comp.IsReady(spi.NewFakeContext(c, &v1alpha1.Verrazzano{}, nil, false)))
+}
+
+// TestPreInstall tests the PreInstall function
+// GIVEN a user-defined component
+// WHEN PreInstall is called
+// THEN the namespace of the Helm release is created
+func TestPreInstall(t *testing.T) {
+	asserts := assert.New(t)
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	comp := NewComponent(newTestVerrazzanoComponent())
+
+	ctx := spi.NewFakeContext(c, &v1alpha1.Verrazzano{}, nil, false)
+	asserts.NoError(comp.PreInstall(ctx))
+	ns := &corev1.Namespace{}
+	asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Name: testNamespace}, ns))
+}
+
+func newTestVerrazzanoComponent() *componentsv1alpha1.VerrazzanoComponent {
+	return &componentsv1alpha1.VerrazzanoComponent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy-agent"},
+		Spec: componentsv1alpha1.VerrazzanoComponentSpec{
+			Chart:                componentsv1alpha1.ChartLocation{Path: "oci://registry.example.com/charts/policy-agent"},
+			Namespace:            testNamespace,
+			Dependencies:         []string{"istio"},
+			ReadinessDeployments: []string{testDeployment},
+			InstallOverrides: v1alpha1.InstallOverrides{
+				ValueOverrides: []v1alpha1.Overrides{
+					{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 2}`)}},
+				},
+			},
+		},
+	}
+}
+
+func newTestDeployment(available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testDeployment},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": testDeployment}},
+		},
+		Status: appsv1.DeploymentStatus{
+			AvailableReplicas: available,
+			Replicas:          1,
+			UpdatedReplicas:   1,
+		},
+	}
+}
+
+func newTestPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testDeployment + "-95d8c5d96-m6mbr",
+			Labels: map[string]string{
+				"app":               testDeployment,
+				"pod-template-hash": "95d8c5d96",
+			},
+		},
+	}
+}
+
+func newTestReplicaSet() *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testNamespace,
+			Name:        testDeployment + "-95d8c5d96",
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "1"},
+		},
+	}
+}
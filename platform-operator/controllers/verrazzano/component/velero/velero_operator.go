@@ -57,6 +57,21 @@ func GetOverrides(object runtime.Object) interface{} {
 
 	return []vzapi.Overrides{}
 }
+
+// GetWorkload gets the workload settings
+func GetWorkload(object runtime.Object) *vzapi.WorkloadSpec {
+	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Velero != nil {
+			return effectiveCR.Spec.Components.Velero.Workload
+		}
+		return nil
+	} else if effectiveCR, ok := object.(*installv1beta1.Verrazzano); ok {
+		if effectiveCR.Spec.Components.Velero != nil {
+			return (*vzapi.WorkloadSpec)(effectiveCR.Spec.Components.Velero.Workload)
+		}
+	}
+	return nil
+}
 func ensureVeleroNamespace(ctx spi.ComponentContext) error {
 	ctx.Log().Debugf("Creating namespace %s for Velero.", ComponentNamespace)
 	namespace := v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ComponentNamespace}}
@@ -3,7 +3,6 @@
 package verrazzano
 
 import (
-	"os/exec"
 	"testing"
 
 	certv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
@@ -17,6 +16,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"helm.sh/helm/v3/pkg/release"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,18 +42,9 @@ var crEnabled = vzapi.Verrazzano{
 	},
 }
 
-// genericTestRunner is used to run generic OS commands with expected results
-type genericTestRunner struct {
-}
-
-// Run genericTestRunner executor
-func (r genericTestRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return nil, nil, nil
-}
-
 // fakeUpgrade override the upgrade function during unit tests
-func fakeUpgrade(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helmcli.HelmOverrides) (stdout []byte, stderr []byte, err error) {
-	return []byte("success"), []byte(""), nil
+func fakeUpgrade(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helmcli.HelmOverrides) (*release.Release, error) {
+	return helmcli.NewFakeRelease(releaseName, namespace, release.StatusDeployed, "", nil), nil
 }
 
 // TestPreUpgrade tests the Verrazzano PreUpgrade call
@@ -128,8 +119,9 @@ func TestUpgrade(t *testing.T) {
 		Status: vzapi.VerrazzanoStatus{Version: "1.1.0"},
 	}, nil, false)
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helmcli.SetCmdRunner(genericTestRunner{})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewFakeActionConfigFunction(
+		helmcli.NewFakeRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "", nil)))
+	defer helmcli.SetDefaultActionConfigFunction()
 	helm.SetUpgradeFunc(fakeUpgrade)
 	defer helm.SetDefaultUpgradeFunc()
 	helmcli.SetChartStateFunction(func(releaseName string, namespace string) (string, error) {
@@ -4,12 +4,12 @@
 package vmo
 
 import (
-	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"helm.sh/helm/v3/pkg/release"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
@@ -17,25 +17,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8scheme "k8s.io/client-go/kubernetes/scheme"
-	"os/exec"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
 
 const profilesRelativePath = "../../../../manifests/profiles"
 
-// genericTestRunner is used to run generic OS commands with expected results
-type genericTestRunner struct {
-	stdOut []byte
-	stdErr []byte
-	err    error
-}
-
-// Run genericTestRunner executor
-func (r genericTestRunner) Run(_ *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return r.stdOut, r.stdErr, r.err
-}
-
 // TestIsEnabled tests the VMO IsEnabled call
 // GIVEN a VMO component
 //  WHEN I call IsEnabled
@@ -203,12 +190,9 @@ func TestPreUpgrade(t *testing.T) {
 //  WHEN I call Uninstall with the VMO helm chart installed
 //  THEN no error is returned
 func TestUninstallHelmChartInstalled(t *testing.T) {
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction(
+		helm.NewFakeRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "", nil)))
+	defer helm.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &vzapi.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
@@ -219,12 +203,8 @@ func TestUninstallHelmChartInstalled(t *testing.T) {
 //  WHEN I call Uninstall with the VMO helm chart not installed
 //  THEN no error is returned
 func TestUninstallHelmChartNotInstalled(t *testing.T) {
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte{},
-		err:    fmt.Errorf("Not installed"),
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewFakeActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &vzapi.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
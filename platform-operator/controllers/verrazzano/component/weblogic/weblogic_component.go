@@ -37,6 +37,16 @@ type weblogicComponent struct {
 	helm.HelmComponent
 }
 
+// networkPolicyRules are the network peers of the operator outside of the verrazzano-system namespace, the operator
+// connects to the WebLogic servers of the application namespaces
+var networkPolicyRules = []spi.NetworkPolicyRules{
+	{
+		PodLabels: map[string]string{"app": ComponentName},
+		Ingress:   []spi.NetworkPeer{{Namespace: constants.IstioSystemNamespace}},
+		Egress:    []spi.NetworkPeer{{}},
+	},
+}
+
 func NewComponent() spi.Component {
 	return weblogicComponent{
 		helm.HelmComponent{
@@ -44,6 +54,7 @@ func NewComponent() spi.Component {
 			JSONName:                  ComponentJSONName,
 			ChartDir:                  filepath.Join(config.GetThirdPartyDir(), ComponentName),
 			ChartNamespace:            ComponentNamespace,
+			NetworkPolicyRules:        networkPolicyRules,
 			IgnoreNamespaceOverride:   true,
 			SupportsOperatorInstall:   true,
 			SupportsOperatorUninstall: true,
@@ -53,6 +64,10 @@ func NewComponent() spi.Component {
 			AppendOverridesFunc:       AppendWeblogicOperatorOverrides,
 			Dependencies:              []string{istio.ComponentName},
 			GetInstallOverridesFunc:   GetOverrides,
+			WorkloadValues: &helm.WorkloadValues{
+				GetWorkloadFunc: GetWorkload,
+				NodeSelectorKey: "nodeSelector",
+			},
 		},
 	}
 }
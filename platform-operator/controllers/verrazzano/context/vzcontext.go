@@ -4,7 +4,10 @@
 package context
 
 import (
+	"context"
+
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -19,6 +22,10 @@ type VerrazzanoContext struct {
 	DryRun bool
 	// ActualCR is the CR passed to top level Reconcile.  It represents the desired Verrazzano state in the cluster
 	ActualCR *vzapi.Verrazzano
+	// UserComponents are the VerrazzanoComponent resources that declare the user-defined components
+	UserComponents []componentsv1alpha1.VerrazzanoComponent
+	// RequestCtx is the context of the reconcile request, it is done when the request is cancelled
+	RequestCtx context.Context
 }
 
 // NewVerrazzanoContext creates a VerrazzanoContext
@@ -149,6 +149,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 // doReconcile the Verrazzano CR
 func (r *Reconciler) doReconcile(ctx context.Context, log vzlog.VerrazzanoLogger, vz *installv1alpha1.Verrazzano) (ctrl.Result, error) {
+	// Register the user-defined components so they are processed with the built-in components
+	userComponents, err := r.loadUserComponents(ctx, log, vz)
+	if err != nil {
+		return newRequeueWithDelay(), err
+	}
+
 	// Check if uninstalling
 	if !vz.ObjectMeta.DeletionTimestamp.IsZero() {
 		return r.procDelete(ctx, log, vz)
@@ -175,6 +181,8 @@ func (r *Reconciler) doReconcile(ctx context.Context, log vzlog.VerrazzanoLogger
 		log.Errorf("Failed to create component context: %v", err)
 		return newRequeueWithDelay(), err
 	}
+	vzctx.UserComponents = userComponents
+	vzctx.RequestCtx = ctx
 
 	// Process CR based on state
 	switch vz.Status.State {
@@ -222,12 +230,26 @@ func (r *Reconciler) ProcReadyState(vzctx vzcontext.VerrazzanoContext) (ctrl.Res
 			}
 			// if the spec version field is set and the SemVer spec field doesn't equal the SemVer status field
 			if specVersion.CompareTo(statusVersion) != 0 {
-				// Transition to upgrade state
-				r.updateVzState(log, actualCR, installv1alpha1.VzStateUpgrading)
-				return newRequeueWithDelay(), err
+				// The upgrade is disruptive, only start it inside the maintenance window
+				allowed, err := r.checkMaintenanceWindow(log, actualCR, upgradeOperation(actualCR.Spec.Version))
+				if err != nil {
+					return newRequeueWithDelay(), err
+				}
+				if allowed {
+					// Transition to upgrade state
+					r.updateVzState(log, actualCR, installv1alpha1.VzStateUpgrading)
+					return newRequeueWithDelay(), err
+				}
 			}
 		}
 
+		// Start the install flow for user-defined components that have been added or updated
+		if result, err := r.reconcileUserComponentChanges(vzctx); err != nil {
+			return newRequeueWithDelay(), err
+		} else if vzctrl.ShouldRequeue(result) {
+			return result, nil
+		}
+
 		// Keep retrying to reconcile components until it completes
 		if result, err := r.reconcileComponents(vzctx, false); err != nil {
 			return newRequeueWithDelay(), err
@@ -235,12 +257,74 @@ func (r *Reconciler) ProcReadyState(vzctx vzcontext.VerrazzanoContext) (ctrl.Res
 			return result, nil
 		}
 
+		// Maintain the network policies of the system namespaces
+		if err := r.reconcileNetworkPolicies(vzctx); err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Maintain the pod disruption budgets of the components in the high availability mode
+		if err := r.reconcilePodDisruptionBudgets(vzctx); err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Take the scheduled OpenSearch snapshots that are due
+		snapshotRequeue, err := r.reconcileOpenSearchSnapshots(vzctx)
+		if err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Maintain the scheduled platform backups and refresh the status of their last backups
+		backupRequeue, err := r.reconcilePlatformBackups(vzctx)
+		if err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Maintain the backups of the Keycloak database and follow the progress of its restore
+		mysqlBackupRequeue, err := r.reconcileMySQLBackups(vzctx)
+		if err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Converge the realms declared in the Keycloak component and record the drift that was corrected
+		if err := r.reconcileKeycloakRealms(vzctx); err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Track the expiry of the certificates and rotate the Verrazzano CA when it is requested or nears its expiry
+		certificatesRequeue, err := r.reconcileCertificates(vzctx)
+		if err != nil {
+			return newRequeueWithDelay(), err
+		}
+		periodicRequeue := snapshotRequeue
+		for _, requeue := range []time.Duration{backupRequeue, mysqlBackupRequeue, certificatesRequeue} {
+			if requeue > 0 && (periodicRequeue == 0 || requeue < periodicRequeue) {
+				periodicRequeue = requeue
+			}
+		}
+
+		// Import the application dashboards into Grafana
+		if err := r.reconcileApplicationDashboards(vzctx); err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Check again when the maintenance window opens if any operations have been deferred, or when the next
+		// OpenSearch snapshot, backup status or certificates refresh is due
+		if actualCR.Status.Maintenance != nil {
+			result := newDeferralRequeue(actualCR)
+			if periodicRequeue > 0 && periodicRequeue < result.RequeueAfter {
+				result.RequeueAfter = periodicRequeue
+			}
+			return result, nil
+		}
+		if periodicRequeue > 0 {
+			return ctrl.Result{Requeue: true, RequeueAfter: periodicRequeue}, nil
+		}
 		return ctrl.Result{}, nil
 	}
 
-	// if an OCI DNS installation, make sure the secret required exists before proceeding
-	if actualCR.Spec.Components.DNS != nil && actualCR.Spec.Components.DNS.OCI != nil {
-		err := r.doesOCIDNSConfigSecretExist(actualCR)
+	// if an OCI DNS or RFC2136 DNS installation, make sure the secret required exists before proceeding
+	if actualCR.Spec.Components.DNS != nil && (actualCR.Spec.Components.DNS.OCI != nil || actualCR.Spec.Components.DNS.RFC2136 != nil) {
+		err := r.doesDNSSecretExist(actualCR)
 		if err != nil {
 			return newRequeueWithDelay(), err
 		}
@@ -284,6 +368,12 @@ func (r *Reconciler) ProcInstallingState(vzctx vzcontext.VerrazzanoContext) (ctr
 	log := vzctx.Log
 	log.Debug("Entering ProcInstallingState")
 
+	if result, err := r.reconcileUserComponentChanges(vzctx); err != nil {
+		return newRequeueWithDelay(), err
+	} else if vzctrl.ShouldRequeue(result) {
+		return result, nil
+	}
+
 	if result, err := r.reconcileComponents(vzctx, false); err != nil {
 		return newRequeueWithDelay(), err
 	} else if vzctrl.ShouldRequeue(result) {
@@ -413,11 +503,17 @@ func (r *Reconciler) ProcFailedState(vzctx vzcontext.VerrazzanoContext) (ctrl.Re
 	return ctrl.Result{}, nil
 }
 
-// doesOCIDNSConfigSecretExist returns true if the DNS secret exists
-func (r *Reconciler) doesOCIDNSConfigSecretExist(vz *installv1alpha1.Verrazzano) error {
+// doesDNSSecretExist returns an error if the OCI DNS config secret or the RFC2136 TSIG secret does not exist
+func (r *Reconciler) doesDNSSecretExist(vz *installv1alpha1.Verrazzano) error {
+	var secretName string
+	if vz.Spec.Components.DNS.OCI != nil {
+		secretName = vz.Spec.Components.DNS.OCI.OCIConfigSecret
+	} else {
+		secretName = vz.Spec.Components.DNS.RFC2136.TSIGSecret
+	}
 	// ensure the secret exists before proceeding
 	secret := &corev1.Secret{}
-	err := r.Get(context.TODO(), types.NamespacedName{Name: vz.Spec.Components.DNS.OCI.OCIConfigSecret, Namespace: vzconst.VerrazzanoInstallNamespace}, secret)
+	err := r.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: vzconst.VerrazzanoInstallNamespace}, secret)
 	if err != nil {
 		return err
 	}
@@ -698,6 +794,12 @@ func (r *Reconciler) updateComponentStatus(compContext spi.ComponentContext, mes
 				componentStatus.Version = component.Version
 			}
 		}
+		// Record the workload settings applied to the component
+		if found, comp := registry.FindComponent(componentName); found {
+			if workloadComp, ok := comp.(spi.ComponentWorkload); ok {
+				componentStatus.Workload = workloadComp.GetWorkload(compContext.EffectiveCR()).DeepCopy()
+			}
+		}
 	}
 
 	// Update the status
@@ -1106,6 +1208,25 @@ func (r *Reconciler) initForVzResource(vz *installv1alpha1.Verrazzano, log vzlog
 		return newRequeueWithDelay(), err
 	}
 
+	// Watch VerrazzanoComponents to process changes to user-defined components
+	if err := r.watchUserComponents(vz.Namespace, vz.Name, log); err != nil {
+		log.Errorf("Failed to set VerrazzanoComponent watch for Verrazzano CR %s: %v", vz.Name, err)
+		return newRequeueWithDelay(), err
+	}
+
+	// Watch the application dashboard ConfigMaps, the VerrazzanoProjects and the Grafana pods to import the
+	// application dashboards into Grafana
+	if err := r.watchApplicationDashboards(vz.Namespace, vz.Name, log); err != nil {
+		log.Errorf("Failed to set application dashboard watches for Verrazzano CR %s: %v", vz.Name, err)
+		return newRequeueWithDelay(), err
+	}
+
+	// Watch the Secrets of the external OIDC provider to copy them again for the AuthProxy when they are rotated
+	if err := r.watchExternalOIDCSecrets(vz.Namespace, vz.Name, log); err != nil {
+		log.Errorf("Failed to set external OIDC Secret watch for Verrazzano CR %s: %v", vz.Name, err)
+		return newRequeueWithDelay(), err
+	}
+
 	// Update the map indicating the resource is being watched
 	initializedSet[vz.Name] = true
 	return ctrl.Result{Requeue: true}, nil
@@ -1133,7 +1254,13 @@ func (r *Reconciler) updateVerrazzano(log vzlog.VerrazzanoLogger, vz *installv1a
 }
 
 func (r *Reconciler) updateVerrazzanoStatus(log vzlog.VerrazzanoLogger, vz *installv1alpha1.Verrazzano) error {
-	err := r.Status().Update(context.TODO(), vz)
+	return r.updateVerrazzanoStatusWithContext(context.TODO(), log, vz)
+}
+
+// updateVerrazzanoStatusWithContext updates the status of the Verrazzano resource, the update is cancelled when the
+// context is done
+func (r *Reconciler) updateVerrazzanoStatusWithContext(ctx context.Context, log vzlog.VerrazzanoLogger, vz *installv1alpha1.Verrazzano) error {
+	err := r.Status().Update(ctx, vz)
 	if err == nil {
 		return nil
 	}
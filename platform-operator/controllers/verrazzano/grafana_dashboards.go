@@ -0,0 +1,116 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"reflect"
+
+	vzappclusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/grafana"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// appDashboardsWatch is the watched set entry marking the application dashboards for import
+const appDashboardsWatch = "grafana-application-dashboards"
+
+// reconcileAppDashboardsFunc imports the application dashboards into Grafana, can be overridden for unit testing
+var reconcileAppDashboardsFunc = grafana.ReconcileApplicationDashboards
+
+// reconcileApplicationDashboards imports the Grafana dashboards of the labeled application ConfigMaps once Grafana is
+// ready, if a dashboard ConfigMap, a VerrazzanoProject or a Grafana pod has changed since the last import
+func (r *Reconciler) reconcileApplicationDashboards(vzctx vzcontext.VerrazzanoContext) error {
+	if !r.IsWatchedComponent(appDashboardsWatch) {
+		return nil
+	}
+	actualCR := vzctx.ActualCR
+	if !vzconfig.IsGrafanaEnabled(actualCR) {
+		return nil
+	}
+	if compStatus, ok := actualCR.Status.Components[grafana.ComponentName]; !ok || compStatus.State != installv1alpha1.CompStateReady {
+		return nil
+	}
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, actualCR, nil, r.DryRun)
+	if err != nil {
+		return err
+	}
+	if err := reconcileAppDashboardsFunc(spiCtx); err != nil {
+		return err
+	}
+	r.ClearWatch(appDashboardsWatch)
+	return nil
+}
+
+// watchApplicationDashboards watches the dashboard ConfigMaps, the VerrazzanoProjects and the Grafana pods, so that
+// the application dashboards are imported when they change and when Grafana is recreated
+func (r *Reconciler) watchApplicationDashboards(namespace string, name string, log vzlog.VerrazzanoLogger) error {
+	log.Debugf("Watching for application dashboards to activate reconcile for Verrazzano CR %s/%s", namespace, name)
+
+	// Import the dashboards after the watches are started, the ConfigMaps may have changed while the operator was down
+	r.AddWatch(appDashboardsWatch)
+
+	isDashboardConfigMap := func(object client.Object) bool {
+		return object.GetLabels()[vzconst.LabelGrafanaDashboard] == "true"
+	}
+	err := r.Controller.Watch(
+		&source.Kind{Type: &corev1.ConfigMap{}},
+		createReconcileEventHandler(namespace, name),
+		predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				return r.markAppDashboards(isDashboardConfigMap(e.Object))
+			},
+			// Ignore the updates of the annotations reporting the import result
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldCM := e.ObjectOld.(*corev1.ConfigMap)
+				newCM := e.ObjectNew.(*corev1.ConfigMap)
+				if !isDashboardConfigMap(oldCM) && !isDashboardConfigMap(newCM) {
+					return false
+				}
+				return r.markAppDashboards(isDashboardConfigMap(oldCM) != isDashboardConfigMap(newCM) || !reflect.DeepEqual(oldCM.Data, newCM.Data))
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return r.markAppDashboards(isDashboardConfigMap(e.Object))
+			},
+			GenericFunc: func(e event.GenericEvent) bool {
+				return false
+			},
+		})
+	if err != nil {
+		return err
+	}
+
+	err = r.Controller.Watch(
+		&source.Kind{Type: &vzappclusters.VerrazzanoProject{}},
+		createReconcileEventHandler(namespace, name),
+		predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return r.markAppDashboards(object.GetNamespace() == vzconst.VerrazzanoMultiClusterNamespace)
+		}))
+	if err != nil {
+		return err
+	}
+
+	return r.Controller.Watch(
+		&source.Kind{Type: &corev1.Pod{}},
+		createReconcileEventHandler(namespace, name),
+		createPredicate(func(e event.CreateEvent) bool {
+			return r.markAppDashboards(e.Object.GetNamespace() == grafana.ComponentNamespace && e.Object.GetLabels()["app"] == grafana.PodAppLabel)
+		}))
+}
+
+// markAppDashboards marks the application dashboards for import if the watch event is relevant
+func (r *Reconciler) markAppDashboards(relevant bool) bool {
+	if relevant {
+		r.AddWatch(appDashboardsWatch)
+	}
+	return relevant
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/grafana"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+// TestReconcileApplicationDashboards tests the reconcileApplicationDashboards function
+// GIVEN a Verrazzano resource with Grafana ready and the application dashboards marked for import
+// WHEN reconcileApplicationDashboards is called
+// THEN the application dashboards are imported once
+func TestReconcileApplicationDashboards(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	calls := 0
+	reconcileAppDashboardsFunc = func(_ spi.ComponentContext) error {
+		calls++
+		return nil
+	}
+	defer func() { reconcileAppDashboardsFunc = grafana.ReconcileApplicationDashboards }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Components = vzapi.ComponentStatusMap{
+		grafana.ComponentName: &vzapi.ComponentStatusDetails{State: vzapi.CompStateReady},
+	}
+	r := newMaintenanceTestReconciler(vz)
+	vzctx := vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz}
+
+	// not marked for import
+	asserts.NoError(r.reconcileApplicationDashboards(vzctx))
+	asserts.Equal(0, calls)
+
+	r.markAppDashboards(true)
+	asserts.NoError(r.reconcileApplicationDashboards(vzctx))
+	asserts.Equal(1, calls)
+	asserts.False(r.IsWatchedComponent(appDashboardsWatch))
+
+	// GIVEN the import fails
+	// WHEN reconcileApplicationDashboards is called
+	// THEN an error is returned and the application dashboards stay marked for import
+	reconcileAppDashboardsFunc = func(_ spi.ComponentContext) error {
+		return fmt.Errorf("no ready Grafana pods")
+	}
+	r.markAppDashboards(true)
+	asserts.Error(r.reconcileApplicationDashboards(vzctx))
+	asserts.True(r.IsWatchedComponent(appDashboardsWatch))
+}
+
+// TestReconcileApplicationDashboardsNotReady tests the reconcileApplicationDashboards function
+// GIVEN a Verrazzano resource with Grafana not ready
+// WHEN reconcileApplicationDashboards is called
+// THEN the application dashboards are not imported
+func TestReconcileApplicationDashboardsNotReady(t *testing.T) {
+	asserts := assert.New(t)
+	reconcileAppDashboardsFunc = func(_ spi.ComponentContext) error {
+		return fmt.Errorf("unexpected call")
+	}
+	defer func() { reconcileAppDashboardsFunc = grafana.ReconcileApplicationDashboards }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Components = vzapi.ComponentStatusMap{
+		grafana.ComponentName: &vzapi.ComponentStatusDetails{State: vzapi.CompStateInstalling},
+	}
+	r := newMaintenanceTestReconciler(vz)
+	r.markAppDashboards(true)
+
+	err := r.reconcileApplicationDashboards(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.True(r.IsWatchedComponent(appDashboardsWatch))
+}
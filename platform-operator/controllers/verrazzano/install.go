@@ -4,10 +4,13 @@
 package verrazzano
 
 import (
+	"errors"
+
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/pkg/semver"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
@@ -58,6 +61,15 @@ func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preU
 		if checkConfigUpdated(spiCtx, componentStatus, compName) && comp.IsEnabled(compContext.EffectiveCR()) {
 			if !comp.MonitorOverrides(compContext) && comp.IsEnabled(spiCtx.EffectiveCR()) {
 				compLog.Oncef("Skipping update for component %s, monitorChanges set to false", comp.Name())
+			} else if isUpgradePending(cr) {
+				// The component will be updated by the upgrade, which is waiting for the maintenance window
+				compLog.Oncef("Skipping update for component %s, upgrade to version %s is pending", compName, cr.Spec.Version)
+				continue
+			} else if allowed, err := r.isComponentUpdateAllowed(compContext, cr); err != nil {
+				return newRequeueWithDelay(), err
+			} else if !allowed {
+				// Updating an installed component is disruptive, leave it as is until the maintenance window opens
+				continue
 			} else {
 				oldState := componentStatus.State
 				oldGen := componentStatus.ReconcilingGeneration
@@ -132,12 +144,14 @@ func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preU
 			}
 			compLog.Progressf("Component %s pre-install is running ", compName)
 			if err := comp.PreInstall(compContext); err != nil {
+				r.reportOverridesTemplateError(compContext, err, vzapi.CondPreInstall)
 				requeue = true
 				continue
 			}
 			// If component is not installed,install it
 			compLog.Oncef("Component %s install started ", compName)
 			if err := comp.Install(compContext); err != nil {
+				r.reportOverridesTemplateError(compContext, err, vzapi.CondPreInstall)
 				requeue = true
 				continue
 			}
@@ -214,3 +228,37 @@ func isVersionOk(log vzlog.VerrazzanoLogger, compVersion string, vzVersion strin
 	// return false if VZ version is too low to install component, else true
 	return !vzSemver.IsLessThan(compSemver)
 }
+
+// isComponentUpdateAllowed returns true if an update of the component, triggered by a change to the Verrazzano resource
+// or to a monitored override source, can run now.  Updates during the initial install are always allowed.
+func (r *Reconciler) isComponentUpdateAllowed(compContext spi.ComponentContext, cr *vzapi.Verrazzano) (bool, error) {
+	if !isInstalled(cr.Status) {
+		return true, nil
+	}
+	return r.checkMaintenanceWindow(compContext.Log(), cr, componentUpdateOperation(compContext.GetComponent()))
+}
+
+// isUpgradePending returns true if Verrazzano is installed and the version in the spec differs from the installed version
+func isUpgradePending(cr *vzapi.Verrazzano) bool {
+	return isInstalled(cr.Status) && len(cr.Spec.Version) > 0 && len(cr.Status.Version) > 0 &&
+		cr.Spec.Version != cr.Status.Version
+}
+
+// reportOverridesTemplateError sets the message of the current component condition when the templated install
+// overrides of the component cannot be rendered, so that the error is visible in the component status
+func (r *Reconciler) reportOverridesTemplateError(compContext spi.ComponentContext, err error, conditionType vzapi.ConditionType) {
+	var templateErr *common.OverridesTemplateError
+	if !errors.As(err, &templateErr) {
+		return
+	}
+	componentStatus, ok := compContext.ActualCR().Status.Components[compContext.GetComponent()]
+	if ok && len(componentStatus.Conditions) > 0 {
+		lastCondition := componentStatus.Conditions[len(componentStatus.Conditions)-1]
+		if lastCondition.Type == conditionType && lastCondition.Message == templateErr.Error() {
+			return
+		}
+	}
+	if err := r.updateComponentStatus(compContext, templateErr.Error(), conditionType); err != nil {
+		compContext.Log().Errorf("Failed updating the status of component %s: %v", compContext.GetComponent(), err)
+	}
+}
@@ -5,21 +5,29 @@ package verrazzano
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	certv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const testBomFile = "../../verrazzano-bom.json"
@@ -192,6 +200,10 @@ func testUpdate(t *testing.T,
 			verrazzano.Status.Components = compStatusMap
 			return nil
 		})
+	// Expect a call to list the certificates to track their expiry when the resource is Ready
+	mock.EXPECT().
+		List(gomock.Any(), &certv1.CertificateList{}, gomock.Any()).
+		Return(nil).AnyTimes()
 	// The mocks are added to accomodate the expected calls to List instance when component is Ready
 	mock.EXPECT().
 		List(gomock.Any(), gomock.Not(gomock.Nil())).
@@ -199,6 +211,18 @@ func testUpdate(t *testing.T,
 			ingressList.Items = []networkingv1.Ingress{}
 			return nil
 		}).AnyTimes()
+	// Expect a call to list the network policies of the system namespaces when the resource is Ready
+	mock.EXPECT().
+		List(gomock.Any(), &networkingv1.NetworkPolicyList{}, gomock.Any()).
+		Return(nil).AnyTimes()
+	// Expect a call to list the pod disruption budgets created by the operator when the resource is Ready
+	mock.EXPECT().
+		List(gomock.Any(), &policyv1.PodDisruptionBudgetList{}, gomock.Any()).
+		Return(nil).AnyTimes()
+	// Expect a call to get the Verrazzano CA secret to track the expiry of the certificates when the resource is Ready
+	mock.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: vzconst.CertManagerNamespace, Name: vzconst.DefaultVerrazzanoCASecretName}, gomock.Not(gomock.Nil())).
+		Return(errors.NewNotFound(schema.GroupResource{Resource: "Secret"}, vzconst.DefaultVerrazzanoCASecretName)).AnyTimes()
 	mock.EXPECT().Status().Return(mockStatus).AnyTimes()
 	mockStatus.EXPECT().
 		Update(gomock.Any(), gomock.Any()).
@@ -233,3 +257,40 @@ func testUpdate(t *testing.T,
 	mocker.Finish()
 	return asserts, vz, result, fakeCompUpdated, err
 }
+
+// TestReportOverridesTemplateError tests the reportOverridesTemplateError function
+// GIVEN a component that is being installed
+// WHEN the install fails because the templated install overrides cannot be rendered
+// THEN the rendering error is set as the message of the component condition, and other errors are ignored
+func TestReportOverridesTemplateError(t *testing.T) {
+	const compName = "fake"
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano", Generation: 1},
+		Status: vzapi.VerrazzanoStatus{
+			Components: map[string]*vzapi.ComponentStatusDetails{
+				compName: {
+					Name:       compName,
+					State:      vzapi.CompStatePreInstalling,
+					Conditions: []vzapi.Condition{{Type: vzapi.CondPreInstall, Message: "PreInstall started"}},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	reconciler := newVerrazzanoReconciler(c)
+	compContext := spi.NewFakeContext(c, vz, nil, false).Init(compName)
+
+	reconciler.reportOverridesTemplateError(compContext, fmt.Errorf("unrelated error"), vzapi.CondPreInstall)
+	assert.Equal(t, "PreInstall started", vz.Status.Components[compName].Conditions[0].Message)
+
+	templateErr := &common.OverridesTemplateError{Source: "overrides[0].values", Err: fmt.Errorf("bad template")}
+	reconciler.reportOverridesTemplateError(compContext, fmt.Errorf("install failed: %w", templateErr), vzapi.CondPreInstall)
+
+	updated := &vzapi.Verrazzano{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, updated))
+	compStatus := updated.Status.Components[compName]
+	assert.Equal(t, vzapi.CompStatePreInstalling, compStatus.State)
+	assert.Len(t, compStatus.Conditions, 1)
+	assert.Equal(t, vzapi.CondPreInstall, compStatus.Conditions[0].Type)
+	assert.Equal(t, templateErr.Error(), compStatus.Conditions[0].Message)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysql"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+)
+
+// reconcileMySQLBackupsFunc maintains the backups and the restore of the Keycloak database, can be overridden for
+// unit testing
+var reconcileMySQLBackupsFunc = mysql.ReconcileBackups
+
+// reconcileMySQLBackups maintains the scheduled backups of the Keycloak database, starts the requested restore, and
+// records the last backup and the progress of the restore in the Verrazzano status.  It returns how long to wait
+// before refreshing the status, zero means there is nothing to refresh.
+func (r *Reconciler) reconcileMySQLBackups(vzctx vzcontext.VerrazzanoContext) (time.Duration, error) {
+	actualCR := vzctx.ActualCR
+	// Once the backups are removed from the spec, the CronJob is deleted before the status is cleared
+	keycloak := actualCR.Spec.Components.Keycloak
+	if (keycloak == nil || keycloak.MySQL.Backup == nil) && actualCR.Status.MySQL == nil {
+		return 0, nil
+	}
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, actualCR, nil, r.DryRun)
+	if err != nil {
+		return 0, err
+	}
+	status, requeueAfter, err := reconcileMySQLBackupsFunc(spiCtx)
+	if err != nil {
+		return 0, err
+	}
+	if reflect.DeepEqual(status, actualCR.Status.MySQL) {
+		return requeueAfter, nil
+	}
+	actualCR.Status.MySQL = status
+	return requeueAfter, r.updateVerrazzanoStatus(vzctx.Log, actualCR)
+}
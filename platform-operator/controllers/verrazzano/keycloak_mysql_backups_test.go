@@ -0,0 +1,96 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysql"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+var testMySQLStatus = &vzapi.MySQLStatus{
+	LastBackup: &vzapi.MySQLBackupStatus{
+		Name:           "keycloak-mysql-backup-29342160",
+		State:          "Completed",
+		StartTime:      "2026-10-19T02:00:00Z",
+		CompletionTime: "2026-10-19T02:00:45Z",
+	},
+}
+
+// TestReconcileMySQLBackups tests the reconcileMySQLBackups function
+// GIVEN a Verrazzano resource with scheduled backups of the Keycloak database
+// WHEN reconcileMySQLBackups is called
+// THEN the status of the last backup is recorded in the Verrazzano status and the refresh delay is returned
+func TestReconcileMySQLBackups(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileMySQLBackupsFunc = func(_ spi.ComponentContext) (*vzapi.MySQLStatus, time.Duration, error) {
+		return testMySQLStatus, 5 * time.Minute, nil
+	}
+	defer func() { reconcileMySQLBackupsFunc = mysql.ReconcileBackups }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Spec.Components.Keycloak = &vzapi.KeycloakComponent{
+		MySQL: vzapi.MySQLComponent{Backup: &vzapi.MySQLBackupSpec{Schedule: "0 2 * * *", PersistentVolumeClaim: "keycloak-backups"}},
+	}
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcileMySQLBackups(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Equal(5*time.Minute, requeueAfter)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Equal(testMySQLStatus, updated.Status.MySQL)
+}
+
+// TestReconcileMySQLBackupsRemoved tests the reconcileMySQLBackups function
+// GIVEN a Verrazzano resource whose backups of the Keycloak database have been removed
+// WHEN reconcileMySQLBackups is called
+// THEN the MySQL status is cleared once the backup objects are deleted
+func TestReconcileMySQLBackupsRemoved(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileMySQLBackupsFunc = func(_ spi.ComponentContext) (*vzapi.MySQLStatus, time.Duration, error) {
+		return nil, 0, nil
+	}
+	defer func() { reconcileMySQLBackupsFunc = mysql.ReconcileBackups }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.MySQL = testMySQLStatus
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcileMySQLBackups(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Zero(requeueAfter)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Nil(updated.Status.MySQL)
+}
+
+// TestReconcileMySQLBackupsNotConfigured tests the reconcileMySQLBackups function
+// GIVEN a Verrazzano resource without backups of the Keycloak database
+// WHEN reconcileMySQLBackups is called
+// THEN the backups are not reconciled
+func TestReconcileMySQLBackupsNotConfigured(t *testing.T) {
+	asserts := assert.New(t)
+	reconcileMySQLBackupsFunc = func(_ spi.ComponentContext) (*vzapi.MySQLStatus, time.Duration, error) {
+		return nil, 0, fmt.Errorf("unexpected call")
+	}
+	defer func() { reconcileMySQLBackupsFunc = mysql.ReconcileBackups }()
+
+	vz := newMaintenanceTestVZ(nil)
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcileMySQLBackups(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Zero(requeueAfter)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+)
+
+// reconcileKeycloakRealmsFunc converges the realms declared in the Keycloak component, can be overridden for unit
+// testing
+var reconcileKeycloakRealmsFunc = keycloak.ReconcileDeclaredRealms
+
+// reconcileKeycloakRealms converges Keycloak to the realms declared in the Keycloak component and records the drift
+// that was corrected in the Verrazzano status.  The Keycloak requests and the status update are cancelled with the
+// reconcile request.
+func (r *Reconciler) reconcileKeycloakRealms(vzctx vzcontext.VerrazzanoContext) error {
+	actualCR := vzctx.ActualCR
+	reqCtx := vzctx.RequestCtx
+	if reqCtx == nil {
+		reqCtx = context.TODO()
+	}
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, actualCR, nil, r.DryRun)
+	if err != nil {
+		return err
+	}
+	status, err := reconcileKeycloakRealmsFunc(reqCtx, spiCtx)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(status, actualCR.Status.Keycloak) {
+		return nil
+	}
+	actualCR.Status.Keycloak = status
+	return r.updateVerrazzanoStatusWithContext(reqCtx, vzctx.Log, actualCR)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+var testKeycloakStatus = &vzapi.KeycloakStatus{
+	Realms: []vzapi.KeycloakRealmStatus{
+		{
+			Name:      "apps",
+			Drift:     []string{"user app-admin changed email"},
+			DriftTime: "2026-10-19T02:00:00Z",
+		},
+	},
+}
+
+// TestReconcileKeycloakRealms tests the reconcileKeycloakRealms function
+// GIVEN a Verrazzano resource whose declared realms had drift corrected
+// WHEN reconcileKeycloakRealms is called
+// THEN the realms are reconciled with the request context and the drift is recorded in the Verrazzano status
+func TestReconcileKeycloakRealms(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	type requestKey struct{}
+	reqCtx := context.WithValue(context.TODO(), requestKey{}, "reconcile")
+	reconcileKeycloakRealmsFunc = func(ctx context.Context, _ spi.ComponentContext) (*vzapi.KeycloakStatus, error) {
+		asserts.Equal("reconcile", ctx.Value(requestKey{}))
+		return testKeycloakStatus, nil
+	}
+	defer func() { reconcileKeycloakRealmsFunc = keycloak.ReconcileDeclaredRealms }()
+
+	vz := newMaintenanceTestVZ(nil)
+	r := newMaintenanceTestReconciler(vz)
+
+	err := r.reconcileKeycloakRealms(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz, RequestCtx: reqCtx})
+	asserts.NoError(err)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Equal(testKeycloakStatus, updated.Status.Keycloak)
+}
+
+// TestReconcileKeycloakRealmsRemoved tests the reconcileKeycloakRealms function
+// GIVEN a Verrazzano resource whose declared realms have been removed
+// WHEN reconcileKeycloakRealms is called
+// THEN the Keycloak status is cleared
+func TestReconcileKeycloakRealmsRemoved(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileKeycloakRealmsFunc = func(_ context.Context, _ spi.ComponentContext) (*vzapi.KeycloakStatus, error) {
+		return nil, nil
+	}
+	defer func() { reconcileKeycloakRealmsFunc = keycloak.ReconcileDeclaredRealms }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Keycloak = testKeycloakStatus
+	r := newMaintenanceTestReconciler(vz)
+
+	err := r.reconcileKeycloakRealms(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Nil(updated.Status.Keycloak)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/maintenance"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// maxDeferralRequeueDelay is the longest time to wait before checking a maintenance window again
+const maxDeferralRequeueDelay = 10 * time.Minute
+
+// getCurrentTime returns the current time, can be overridden for unit testing
+var getCurrentTime = time.Now
+
+// upgradeOperation returns the name of the deferred operation for an upgrade to the given version
+func upgradeOperation(version string) string {
+	return fmt.Sprintf("Upgrade to version %s", version)
+}
+
+// componentUpdateOperation returns the name of the deferred operation for an update of the given component
+func componentUpdateOperation(compName string) string {
+	return fmt.Sprintf("Update of component %s", compName)
+}
+
+const (
+	// restartSystemComponentsOperation is the name of the deferred operation that restarts system workloads
+	restartSystemComponentsOperation = "Restart of system components with old Istio sidecars"
+
+	// restartAppsOperation is the name of the deferred operation that restarts application workloads
+	restartAppsOperation = "Restart of applications with old Istio sidecars"
+)
+
+// checkMaintenanceWindow returns true if the disruptive operation can run now.  If the Verrazzano resource declares
+// a maintenance window that is currently closed, the operation is recorded as deferred in the Verrazzano status and
+// false is returned.  Once the window opens, the operation is removed from the deferred list.
+func (r *Reconciler) checkMaintenanceWindow(log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano, operation string) (bool, error) {
+	spec := cr.Spec.MaintenanceWindow
+	if spec == nil {
+		return true, r.clearDeferredOperation(log, cr, operation)
+	}
+	window, err := maintenance.NewWindow(spec.Schedule, spec.Duration.Duration, spec.TimeZone)
+	if err != nil {
+		log.Errorf("Failed evaluating the maintenance window: %v", err)
+		return false, err
+	}
+	now := getCurrentTime()
+	if window.IsOpen(now) {
+		return true, r.clearDeferredOperation(log, cr, operation)
+	}
+	next := window.NextOpen(now).UTC().Format(time.RFC3339)
+	log.Oncef("%s deferred until the maintenance window opens at %s", operation, next)
+	return false, r.addDeferredOperation(log, cr, operation, next)
+}
+
+// addDeferredOperation records a deferred operation in the Verrazzano status
+func (r *Reconciler) addDeferredOperation(log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano, operation string, deferredUntil string) error {
+	status := cr.Status.Maintenance
+	if status == nil {
+		status = &installv1alpha1.MaintenanceStatus{}
+	}
+	operations, added := vzstring.SliceAddString(status.DeferredOperations, operation)
+	if !added && status.DeferredUntil == deferredUntil {
+		return nil
+	}
+	status.DeferredUntil = deferredUntil
+	status.DeferredOperations = operations
+	status.Message = buildDeferralMessage(status)
+	cr.Status.Maintenance = status
+	return r.updateVerrazzanoStatus(log, cr)
+}
+
+// clearDeferredOperation removes a deferred operation from the Verrazzano status, if present
+func (r *Reconciler) clearDeferredOperation(log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano, operation string) error {
+	status := cr.Status.Maintenance
+	if status == nil || !vzstring.SliceContainsString(status.DeferredOperations, operation) {
+		return nil
+	}
+	remaining := vzstring.RemoveStringFromSlice(status.DeferredOperations, operation)
+	if len(remaining) == 0 {
+		cr.Status.Maintenance = nil
+	} else {
+		status.DeferredOperations = remaining
+		status.Message = buildDeferralMessage(status)
+	}
+	return r.updateVerrazzanoStatus(log, cr)
+}
+
+// newDeferralRequeue returns a result that requeues the reconcile when the maintenance window opens,
+// but no later than maxDeferralRequeueDelay
+func newDeferralRequeue(cr *installv1alpha1.Verrazzano) ctrl.Result {
+	delay := maxDeferralRequeueDelay
+	if cr.Status.Maintenance != nil {
+		if next, err := time.Parse(time.RFC3339, cr.Status.Maintenance.DeferredUntil); err == nil {
+			if untilNext := next.Sub(getCurrentTime()); untilNext > 0 && untilNext < delay {
+				delay = untilNext
+			}
+		}
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: delay}
+}
+
+func buildDeferralMessage(status *installv1alpha1.MaintenanceStatus) string {
+	return fmt.Sprintf("Deferred until %s: %s", status.DeferredUntil, strings.Join(status.DeferredOperations, ", "))
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// saturdayWindow is a 4 hour window that opens every Saturday at 02:00 UTC
+var saturdayWindow = &vzapi.MaintenanceWindow{
+	Schedule: "0 2 * * SAT",
+	Duration: metav1.Duration{Duration: 4 * time.Hour},
+}
+
+// TestCheckMaintenanceWindowDeferred tests the checkMaintenanceWindow function
+// GIVEN a Verrazzano resource with a maintenance window that is closed
+// WHEN checkMaintenanceWindow is called for an upgrade
+// THEN false is returned and the upgrade is recorded as deferred until the next window in the status
+func TestCheckMaintenanceWindowDeferred(t *testing.T) {
+	asserts := assert.New(t)
+	setCurrentTime(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	defer resetCurrentTime()
+
+	vz := newMaintenanceTestVZ(saturdayWindow)
+	r := newMaintenanceTestReconciler(vz)
+
+	allowed, err := r.checkMaintenanceWindow(vzlog.DefaultLogger(), vz, upgradeOperation("1.4.0"))
+	asserts.NoError(err)
+	asserts.False(allowed)
+
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.NotNil(updated.Status.Maintenance)
+	asserts.Equal("2026-10-17T02:00:00Z", updated.Status.Maintenance.DeferredUntil)
+	asserts.Equal([]string{"Upgrade to version 1.4.0"}, updated.Status.Maintenance.DeferredOperations)
+	asserts.Equal("Deferred until 2026-10-17T02:00:00Z: Upgrade to version 1.4.0", updated.Status.Maintenance.Message)
+
+	// Requeue when the window opens, but no later than the max delay
+	result := newDeferralRequeue(updated)
+	asserts.True(result.Requeue)
+	asserts.Equal(maxDeferralRequeueDelay, result.RequeueAfter)
+}
+
+// TestCheckMaintenanceWindowOpen tests the checkMaintenanceWindow function
+// GIVEN a Verrazzano resource with deferred operations and a maintenance window that is open
+// WHEN checkMaintenanceWindow is called for each deferred operation
+// THEN true is returned and the operations are removed from the status
+func TestCheckMaintenanceWindowOpen(t *testing.T) {
+	asserts := assert.New(t)
+	setCurrentTime(time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC))
+	defer resetCurrentTime()
+
+	vz := newMaintenanceTestVZ(saturdayWindow)
+	vz.Status.Maintenance = &vzapi.MaintenanceStatus{
+		DeferredUntil:      "2026-10-17T02:00:00Z",
+		DeferredOperations: []string{componentUpdateOperation("keycloak"), restartAppsOperation},
+	}
+	r := newMaintenanceTestReconciler(vz)
+
+	allowed, err := r.checkMaintenanceWindow(vzlog.DefaultLogger(), vz, componentUpdateOperation("keycloak"))
+	asserts.NoError(err)
+	asserts.True(allowed)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Equal([]string{restartAppsOperation}, updated.Status.Maintenance.DeferredOperations)
+
+	allowed, err = r.checkMaintenanceWindow(vzlog.DefaultLogger(), updated, restartAppsOperation)
+	asserts.NoError(err)
+	asserts.True(allowed)
+	updated = getMaintenanceTestVZ(t, r)
+	asserts.Nil(updated.Status.Maintenance)
+}
+
+// TestCheckMaintenanceWindowNotConfigured tests the checkMaintenanceWindow function
+// GIVEN a Verrazzano resource without a maintenance window
+// WHEN checkMaintenanceWindow is called
+// THEN true is returned and the status is not changed
+func TestCheckMaintenanceWindowNotConfigured(t *testing.T) {
+	asserts := assert.New(t)
+	vz := newMaintenanceTestVZ(nil)
+	r := newMaintenanceTestReconciler(vz)
+
+	allowed, err := r.checkMaintenanceWindow(vzlog.DefaultLogger(), vz, upgradeOperation("1.4.0"))
+	asserts.NoError(err)
+	asserts.True(allowed)
+	asserts.Nil(getMaintenanceTestVZ(t, r).Status.Maintenance)
+}
+
+// TestNewDeferralRequeueBeforeWindow tests the newDeferralRequeue function
+// GIVEN a deferral that ends in 5 minutes
+// WHEN newDeferralRequeue is called
+// THEN the reconcile is requeued when the window opens
+func TestNewDeferralRequeueBeforeWindow(t *testing.T) {
+	setCurrentTime(time.Date(2026, 10, 17, 1, 55, 0, 0, time.UTC))
+	defer resetCurrentTime()
+
+	vz := newMaintenanceTestVZ(saturdayWindow)
+	vz.Status.Maintenance = &vzapi.MaintenanceStatus{DeferredUntil: "2026-10-17T02:00:00Z"}
+	assert.Equal(t, 5*time.Minute, newDeferralRequeue(vz).RequeueAfter)
+}
+
+// TestIsUpgradePending tests the isUpgradePending function
+// GIVEN an installed Verrazzano resource
+// WHEN the spec version differs from the status version
+// THEN true is returned
+func TestIsUpgradePending(t *testing.T) {
+	asserts := assert.New(t)
+	vz := newMaintenanceTestVZ(nil)
+	vz.Spec.Version = "1.4.0"
+	vz.Status.Version = "1.3.0"
+	asserts.True(isUpgradePending(vz))
+
+	vz.Status.Version = "1.4.0"
+	asserts.False(isUpgradePending(vz))
+
+	vz.Status.Version = "1.3.0"
+	vz.Status.Conditions = nil
+	asserts.False(isUpgradePending(vz))
+}
+
+func newMaintenanceTestVZ(window *vzapi.MaintenanceWindow) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Spec:       vzapi.VerrazzanoSpec{MaintenanceWindow: window},
+		Status: vzapi.VerrazzanoStatus{
+			State:      vzapi.VzStateReady,
+			Conditions: []vzapi.Condition{{Type: vzapi.CondInstallComplete}},
+		},
+	}
+}
+
+func newMaintenanceTestReconciler(vz *vzapi.Verrazzano) Reconciler {
+	_ = vzapi.AddToScheme(k8scheme.Scheme)
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(vz).Build()
+	return newVerrazzanoReconciler(c)
+}
+
+func getMaintenanceTestVZ(t *testing.T, r Reconciler) *vzapi.Verrazzano {
+	vz := &vzapi.Verrazzano{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, vz))
+	return vz
+}
+
+func setCurrentTime(now time.Time) {
+	getCurrentTime = func() time.Time { return now }
+}
+
+func resetCurrentTime() {
+	getCurrentTime = time.Now
+}
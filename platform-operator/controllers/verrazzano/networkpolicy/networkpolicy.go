@@ -0,0 +1,280 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// managedLabel identifies the network policies created by the platform operator in the system namespaces
+	managedLabel = "verrazzano.io/network-policy"
+
+	defaultDenyPolicyName = "verrazzano-default-deny"
+	basePolicyName        = "verrazzano-allow-base"
+	componentPolicyPrefix = "verrazzano-allow-"
+
+	verrazzanoNamespaceLabel = "verrazzano.io/namespace"
+	apiServerEndpointName    = "kubernetes"
+)
+
+// getComponentsFunc returns the components declaring the network peers of their pods, can be overridden for unit
+// testing
+var getComponentsFunc = registry.GetComponents
+
+// ReconcileNetworkPolicies creates and updates the network policies of the Verrazzano system namespaces, and deletes
+// the network policies that are no longer required.  When the network policies are enabled, each system namespace
+// denies all the traffic by default, and allows the traffic within the namespace, the traffic with the DNS server,
+// the API server, istiod and Prometheus, the traffic declared by the enabled components of the namespace and the
+// traffic of the additional policies of the Verrazzano resource.  All the policies are deleted when they are disabled.
+func ReconcileNetworkPolicies(ctx spi.ComponentContext) error {
+	var policies []*netv1.NetworkPolicy
+	if spec := ctx.EffectiveCR().Spec.Security.NetworkPolicies; spec != nil && spec.Enabled != nil && *spec.Enabled {
+		var err error
+		if policies, err = getNetworkPolicies(ctx, spec); err != nil {
+			return err
+		}
+	}
+
+	names := map[types.NamespacedName]bool{}
+	for _, policy := range policies {
+		names[types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}] = true
+		if err := createOrUpdateNetworkPolicy(ctx, policy); err != nil {
+			return err
+		}
+	}
+	return deleteRemovedNetworkPolicies(ctx, names)
+}
+
+// getNetworkPolicies returns the network policies of the system namespaces that exist
+func getNetworkPolicies(ctx spi.ComponentContext, spec *vzapi.NetworkPoliciesSpec) ([]*netv1.NetworkPolicy, error) {
+	apiServerIP, apiServerPort, err := getAPIServerIPAndPort(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var policies []*netv1.NetworkPolicy
+	for _, namespace := range vzconst.NetworkPolicyNamespaces {
+		exists, err := namespaceExists(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		policies = append(policies, newDefaultDenyPolicy(namespace), newBasePolicy(namespace, apiServerIP, apiServerPort))
+		for _, comp := range getComponentsFunc() {
+			if comp.Namespace() != namespace || !comp.IsEnabled(ctx.EffectiveCR()) {
+				continue
+			}
+			for i, rules := range comp.GetNetworkPolicyRules(ctx) {
+				if policy := newComponentPolicy(namespace, componentPolicyName(comp.Name(), i), rules); policy != nil {
+					policies = append(policies, policy)
+				}
+			}
+		}
+		for _, additional := range spec.AdditionalPolicies {
+			if additional.Namespace == namespace {
+				policy := newNetworkPolicy(namespace, additional.Name)
+				additional.Spec.DeepCopyInto(&policy.Spec)
+				policies = append(policies, policy)
+			}
+		}
+	}
+	return policies, nil
+}
+
+// createOrUpdateNetworkPolicy creates or updates a network policy of a system namespace
+func createOrUpdateNetworkPolicy(ctx spi.ComponentContext, policy *netv1.NetworkPolicy) error {
+	existing := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: policy.Name, Namespace: policy.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), ctx.Client(), existing, func() error {
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		existing.Labels[managedLabel] = "true"
+		existing.Spec = policy.Spec
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating network policy %s/%s: %v", policy.Namespace, policy.Name, err)
+	}
+	return nil
+}
+
+// deleteRemovedNetworkPolicies deletes the network policies created by the platform operator that are not in names
+func deleteRemovedNetworkPolicies(ctx spi.ComponentContext, names map[types.NamespacedName]bool) error {
+	policies := &netv1.NetworkPolicyList{}
+	if err := ctx.Client().List(context.TODO(), policies, client.HasLabels{managedLabel}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed listing network policies: %v", err)
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if names[types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}] ||
+			!vzstring.SliceContainsString(vzconst.NetworkPolicyNamespaces, policy.Namespace) {
+			continue
+		}
+		ctx.Log().Oncef("Deleting network policy %s/%s", policy.Namespace, policy.Name)
+		if err := ctx.Client().Delete(context.TODO(), policy); client.IgnoreNotFound(err) != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting network policy %s/%s: %v", policy.Namespace, policy.Name, err)
+		}
+	}
+	return nil
+}
+
+// namespaceExists returns true if the namespace exists
+func namespaceExists(ctx spi.ComponentContext, namespace string) (bool, error) {
+	err := ctx.Client().Get(context.TODO(), types.NamespacedName{Name: namespace}, &corev1.Namespace{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, ctx.Log().ErrorfNewErr("Failed getting namespace %s: %v", namespace, err)
+	}
+	return true, nil
+}
+
+// getAPIServerIPAndPort returns the IP address and port of the Kubernetes API server
+func getAPIServerIPAndPort(ctx spi.ComponentContext) (string, int32, error) {
+	endpoints := &corev1.Endpoints{}
+	if err := ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: apiServerEndpointName}, endpoints); err != nil {
+		return "", 0, ctx.Log().ErrorfNewErr("Failed getting the endpoints of the Kubernetes API server: %v", err)
+	}
+	if len(endpoints.Subsets) > 0 && len(endpoints.Subsets[0].Addresses) > 0 && len(endpoints.Subsets[0].Ports) > 0 {
+		return endpoints.Subsets[0].Addresses[0].IP, endpoints.Subsets[0].Ports[0].Port, nil
+	}
+	return "", 0, ctx.Log().ErrorfNewErr("Failed, unable to find a host and port for the Kubernetes API server")
+}
+
+// componentPolicyName returns the name of the network policy of a set of network policy rules of a component
+func componentPolicyName(componentName string, index int) string {
+	if index == 0 {
+		return componentPolicyPrefix + componentName
+	}
+	return fmt.Sprintf("%s%s-%d", componentPolicyPrefix, componentName, index)
+}
+
+// newNetworkPolicy returns a network policy without rules
+func newNetworkPolicy(namespace string, name string) *netv1.NetworkPolicy {
+	return &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+}
+
+// newDefaultDenyPolicy returns the network policy denying all the traffic of the pods of a namespace
+func newDefaultDenyPolicy(namespace string) *netv1.NetworkPolicy {
+	policy := newNetworkPolicy(namespace, defaultDenyPolicyName)
+	policy.Spec.PolicyTypes = []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress}
+	return policy
+}
+
+// newBasePolicy returns the network policy allowing the traffic required by all the pods of a namespace: the traffic
+// within the namespace, the traffic with the DNS server, the API server and istiod, and the scraping of the metrics
+// by Prometheus
+func newBasePolicy(namespace string, apiServerIP string, apiServerPort int32) *netv1.NetworkPolicy {
+	sameNamespace := netv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}}
+	udpProtocol := corev1.ProtocolUDP
+	dnsPort := intstr.FromInt(53)
+
+	policy := newNetworkPolicy(namespace, basePolicyName)
+	policy.Spec.PolicyTypes = []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress}
+	policy.Spec.Ingress = []netv1.NetworkPolicyIngressRule{
+		{From: []netv1.NetworkPolicyPeer{sameNamespace}},
+		{
+			// ingress from Prometheus for scraping metrics
+			From: []netv1.NetworkPolicyPeer{newPeer(constants.VerrazzanoMonitoringNamespace,
+				map[string]string{"app.kubernetes.io/name": constants.PrometheusStorageLabelValue})},
+		},
+	}
+	policy.Spec.Egress = []netv1.NetworkPolicyEgressRule{
+		{To: []netv1.NetworkPolicyPeer{sameNamespace}},
+		{
+			// egress for DNS
+			Ports: append(newPorts([]int32{53}), netv1.NetworkPolicyPort{Protocol: &udpProtocol, Port: &dnsPort}),
+			To:    []netv1.NetworkPolicyPeer{newPeer(vzconst.KubeSystem, map[string]string{"k8s-app": "kube-dns"})},
+		},
+		{
+			// egress to the Kubernetes API server
+			Ports: newPorts([]int32{apiServerPort}),
+			To:    []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: apiServerIP + "/32"}}},
+		},
+		{
+			// egress from the Istio proxy sidecars to istiod
+			Ports: newPorts([]int32{15012}),
+			To:    []netv1.NetworkPolicyPeer{newPeer(vzconst.IstioSystemNamespace, map[string]string{"app": "istiod"})},
+		},
+	}
+	return policy
+}
+
+// newComponentPolicy returns the network policy allowing the traffic declared by a set of network policy rules of a
+// component, nil if the rules declare no traffic
+func newComponentPolicy(namespace string, name string, rules spi.NetworkPolicyRules) *netv1.NetworkPolicy {
+	if len(rules.Ingress) == 0 && len(rules.Egress) == 0 {
+		return nil
+	}
+	policy := newNetworkPolicy(namespace, name)
+	policy.Spec.PodSelector = metav1.LabelSelector{MatchLabels: rules.PodLabels}
+	if len(rules.Ingress) > 0 {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, netv1.PolicyTypeIngress)
+	}
+	if len(rules.Egress) > 0 {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, netv1.PolicyTypeEgress)
+	}
+	for _, peer := range rules.Ingress {
+		rule := netv1.NetworkPolicyIngressRule{Ports: newPorts(peer.Ports)}
+		if peer.Namespace != "" || len(peer.PodLabels) > 0 {
+			rule.From = []netv1.NetworkPolicyPeer{newPeer(peer.Namespace, peer.PodLabels)}
+		}
+		policy.Spec.Ingress = append(policy.Spec.Ingress, rule)
+	}
+	for _, peer := range rules.Egress {
+		rule := netv1.NetworkPolicyEgressRule{Ports: newPorts(peer.Ports)}
+		if peer.Namespace != "" || len(peer.PodLabels) > 0 {
+			rule.To = []netv1.NetworkPolicyPeer{newPeer(peer.Namespace, peer.PodLabels)}
+		}
+		policy.Spec.Egress = append(policy.Spec.Egress, rule)
+	}
+	return policy
+}
+
+// newPeer returns a network policy peer selecting the pods of a namespace, any namespace if empty, with the labels,
+// all the pods of the namespace if empty
+func newPeer(namespace string, podLabels map[string]string) netv1.NetworkPolicyPeer {
+	peer := netv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{}}
+	if namespace != "" {
+		peer.NamespaceSelector.MatchLabels = map[string]string{verrazzanoNamespaceLabel: namespace}
+	}
+	if len(podLabels) > 0 {
+		peer.PodSelector = &metav1.LabelSelector{MatchLabels: podLabels}
+	}
+	return peer
+}
+
+// newPorts returns the network policy ports of TCP ports
+func newPorts(ports []int32) []netv1.NetworkPolicyPort {
+	var policyPorts []netv1.NetworkPolicyPort
+	for _, port := range ports {
+		tcpProtocol := corev1.ProtocolTCP
+		policyPort := intstr.FromInt(int(port))
+		policyPorts = append(policyPorts, netv1.NetworkPolicyPort{Protocol: &tcpProtocol, Port: &policyPort})
+	}
+	return policyPorts
+}
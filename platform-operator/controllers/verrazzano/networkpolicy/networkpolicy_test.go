@@ -0,0 +1,207 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	keycloakNamespace = "keycloak"
+	nginxNamespace    = "ingress-nginx"
+)
+
+// newTestComponents returns a component of the keycloak namespace declaring ingress from the ingress controller, and
+// a component of a namespace without network policies
+func newTestComponents() []spi.Component {
+	return []spi.Component{
+		helm.HelmComponent{
+			ReleaseName:    "keycloak",
+			ChartNamespace: keycloakNamespace,
+			NetworkPolicyRules: []spi.NetworkPolicyRules{{
+				PodLabels: map[string]string{"app": "keycloak"},
+				Ingress:   []spi.NetworkPeer{{Namespace: nginxNamespace, Ports: []int32{8080}}},
+			}},
+		},
+		helm.HelmComponent{
+			ReleaseName:        "cert-manager",
+			ChartNamespace:     "cert-manager",
+			NetworkPolicyRules: []spi.NetworkPolicyRules{{Egress: []spi.NetworkPeer{{}}}},
+		},
+	}
+}
+
+func newNetworkPoliciesVZ(enabled bool, additional ...vzapi.AdditionalNetworkPolicy) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Spec: vzapi.VerrazzanoSpec{
+			Security: vzapi.SecuritySpec{
+				NetworkPolicies: &vzapi.NetworkPoliciesSpec{Enabled: &enabled, AdditionalPolicies: additional},
+			},
+		},
+	}
+}
+
+func newManagedPolicy(namespace string, name string) *netv1.NetworkPolicy {
+	return &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{managedLabel: "true"}},
+	}
+}
+
+func newFakeClient(objects ...client.Object) client.Client {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: apiServerEndpointName},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+			Ports:     []corev1.EndpointPort{{Port: 6443}},
+		}},
+	}
+	objects = append(objects, endpoints,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: keycloakNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nginxNamespace}},
+	)
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build()
+}
+
+func getPolicy(t *testing.T, c client.Client, namespace string, name string) *netv1.NetworkPolicy {
+	policy := &netv1.NetworkPolicy{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, policy))
+	return policy
+}
+
+// TestReconcileNetworkPolicies tests the ReconcileNetworkPolicies function
+// GIVEN a Verrazzano resource with the network policies enabled and an additional policy
+// WHEN ReconcileNetworkPolicies is called
+// THEN the default-deny, base, component and additional policies are created in the system namespaces that exist,
+// and the stale policies created by the operator are deleted
+func TestReconcileNetworkPolicies(t *testing.T) {
+	getComponentsFunc = newTestComponents
+	defer func() { getComponentsFunc = registry.GetComponents }()
+
+	additional := vzapi.AdditionalNetworkPolicy{
+		Name:      "allow-app",
+		Namespace: keycloakNamespace,
+		Spec:      netv1.NetworkPolicySpec{PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress}},
+	}
+	userPolicy := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: keycloakNamespace, Name: "user-policy"}}
+	c := newFakeClient(newManagedPolicy(keycloakNamespace, "verrazzano-allow-removed"), userPolicy)
+	ctx := spi.NewFakeContext(c, newNetworkPoliciesVZ(true, additional), nil, false)
+
+	assert.NoError(t, ReconcileNetworkPolicies(ctx))
+
+	policies := &netv1.NetworkPolicyList{}
+	assert.NoError(t, c.List(context.TODO(), policies))
+	var names []string
+	for _, policy := range policies.Items {
+		names = append(names, policy.Namespace+"/"+policy.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"keycloak/verrazzano-default-deny",
+		"keycloak/verrazzano-allow-base",
+		"keycloak/verrazzano-allow-keycloak",
+		"keycloak/allow-app",
+		"keycloak/user-policy",
+		"ingress-nginx/verrazzano-default-deny",
+		"ingress-nginx/verrazzano-allow-base",
+	}, names)
+
+	deny := getPolicy(t, c, keycloakNamespace, defaultDenyPolicyName)
+	assert.Equal(t, "true", deny.Labels[managedLabel])
+	assert.Empty(t, deny.Spec.PodSelector.MatchLabels)
+	assert.Empty(t, deny.Spec.Ingress)
+	assert.Empty(t, deny.Spec.Egress)
+
+	base := getPolicy(t, c, nginxNamespace, basePolicyName)
+	assert.Len(t, base.Spec.Ingress, 2)
+	assert.Len(t, base.Spec.Egress, 4)
+	assert.Equal(t, "10.0.0.1/32", base.Spec.Egress[2].To[0].IPBlock.CIDR)
+	assert.Equal(t, 6443, base.Spec.Egress[2].Ports[0].Port.IntValue())
+
+	comp := getPolicy(t, c, keycloakNamespace, "verrazzano-allow-keycloak")
+	assert.Equal(t, map[string]string{"app": "keycloak"}, comp.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []netv1.PolicyType{netv1.PolicyTypeIngress}, comp.Spec.PolicyTypes)
+	assert.Len(t, comp.Spec.Ingress, 1)
+	assert.Equal(t, map[string]string{verrazzanoNamespaceLabel: nginxNamespace}, comp.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, 8080, comp.Spec.Ingress[0].Ports[0].Port.IntValue())
+
+	assert.Equal(t, additional.Spec.PolicyTypes, getPolicy(t, c, keycloakNamespace, "allow-app").Spec.PolicyTypes)
+}
+
+// TestReconcileNetworkPoliciesDisabled tests the ReconcileNetworkPolicies function
+// GIVEN a Verrazzano resource with the network policies disabled
+// WHEN ReconcileNetworkPolicies is called
+// THEN the policies created by the operator are deleted, and the other policies are kept
+func TestReconcileNetworkPoliciesDisabled(t *testing.T) {
+	getComponentsFunc = newTestComponents
+	defer func() { getComponentsFunc = registry.GetComponents }()
+
+	c := newFakeClient(
+		newManagedPolicy(keycloakNamespace, defaultDenyPolicyName),
+		newManagedPolicy(nginxNamespace, basePolicyName),
+		&netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: keycloakNamespace, Name: "user-policy"}},
+	)
+	ctx := spi.NewFakeContext(c, newNetworkPoliciesVZ(false), nil, false)
+
+	assert.NoError(t, ReconcileNetworkPolicies(ctx))
+
+	policies := &netv1.NetworkPolicyList{}
+	assert.NoError(t, c.List(context.TODO(), policies))
+	assert.Len(t, policies.Items, 1)
+	assert.Equal(t, "user-policy", policies.Items[0].Name)
+}
+
+// TestNewComponentPolicy tests the newComponentPolicy function
+// GIVEN network policy rules with any peer, a peer of a namespace and a peer of any namespace
+// WHEN newComponentPolicy is called
+// THEN the rules of any peer have no peers, and the other peers select their namespaces and pods
+func TestNewComponentPolicy(t *testing.T) {
+	assert.Nil(t, newComponentPolicy(keycloakNamespace, "verrazzano-allow-test", spi.NetworkPolicyRules{}))
+
+	policy := newComponentPolicy(constants.VerrazzanoMonitoringNamespace, "verrazzano-allow-test", spi.NetworkPolicyRules{
+		Ingress: []spi.NetworkPeer{
+			{Ports: []int32{9411, 14250}},
+			{PodLabels: map[string]string{"app": "batch"}},
+		},
+		Egress: []spi.NetworkPeer{{Namespace: keycloakNamespace, Ports: []int32{8080}}},
+	})
+	assert.Equal(t, []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+	assert.Empty(t, policy.Spec.PodSelector.MatchLabels)
+
+	assert.Nil(t, policy.Spec.Ingress[0].From)
+	assert.Len(t, policy.Spec.Ingress[0].Ports, 2)
+	assert.Equal(t, corev1.ProtocolTCP, *policy.Spec.Ingress[0].Ports[0].Protocol)
+
+	anyNamespace := policy.Spec.Ingress[1].From[0]
+	assert.Empty(t, anyNamespace.NamespaceSelector.MatchLabels)
+	assert.Equal(t, map[string]string{"app": "batch"}, anyNamespace.PodSelector.MatchLabels)
+	assert.Nil(t, policy.Spec.Ingress[1].Ports)
+
+	namespacePeer := policy.Spec.Egress[0].To[0]
+	assert.Equal(t, map[string]string{verrazzanoNamespaceLabel: keycloakNamespace}, namespacePeer.NamespaceSelector.MatchLabels)
+	assert.Nil(t, namespacePeer.PodSelector)
+}
+
+// TestComponentPolicyName tests the componentPolicyName function
+// GIVEN the index of a set of network policy rules of a component
+// WHEN componentPolicyName is called
+// THEN the first policy is named after the component, and the following policies are suffixed with their index
+func TestComponentPolicyName(t *testing.T) {
+	assert.Equal(t, "verrazzano-allow-opensearch", componentPolicyName("opensearch", 0))
+	assert.Equal(t, "verrazzano-allow-opensearch-2", componentPolicyName("opensearch", 2))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"reflect"
+	"time"
+
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/opensearch"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+)
+
+// reconcileIndexManagementFunc performs the periodic OpenSearch index management tasks, can be overridden for unit testing
+var reconcileIndexManagementFunc = opensearch.ReconcileIndexManagement
+
+// reconcileOpenSearchSnapshots takes the scheduled OpenSearch snapshots that are due and refreshes the ISM policies
+// of projects, once OpenSearch is ready.  The status of the snapshots is recorded in the Verrazzano status.  It returns
+// how long to wait before calling it again, zero means there is nothing to check later.
+func (r *Reconciler) reconcileOpenSearchSnapshots(vzctx vzcontext.VerrazzanoContext) (time.Duration, error) {
+	actualCR := vzctx.ActualCR
+	if !vzconfig.IsOpenSearchEnabled(actualCR) {
+		return 0, nil
+	}
+	if compStatus, ok := actualCR.Status.Components[opensearch.ComponentName]; !ok || compStatus.State != installv1alpha1.CompStateReady {
+		return 0, nil
+	}
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, actualCR, nil, r.DryRun)
+	if err != nil {
+		return 0, err
+	}
+	statuses, requeueAfter, err := reconcileIndexManagementFunc(spiCtx)
+	if err != nil {
+		return 0, err
+	}
+
+	var status *installv1alpha1.OpenSearchStatus
+	if len(statuses) > 0 {
+		status = &installv1alpha1.OpenSearchStatus{Snapshots: statuses}
+	}
+	if reflect.DeepEqual(status, actualCR.Status.OpenSearch) {
+		return requeueAfter, nil
+	}
+	actualCR.Status.OpenSearch = status
+	return requeueAfter, r.updateVerrazzanoStatus(vzctx.Log, actualCR)
+}
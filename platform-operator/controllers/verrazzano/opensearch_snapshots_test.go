@@ -0,0 +1,75 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/opensearch"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+var testSnapshotStatus = vzapi.OpenSearchSnapshotStatus{
+	Schedule:         "daily",
+	Name:             "daily-20261019-030000",
+	Repository:       "backups",
+	State:            "IN_PROGRESS",
+	NextSnapshotTime: "2026-10-20T03:00:00Z",
+}
+
+// TestReconcileOpenSearchSnapshots tests the reconcileOpenSearchSnapshots function
+// GIVEN a Verrazzano resource with OpenSearch ready
+// WHEN reconcileOpenSearchSnapshots is called
+// THEN the snapshot status is recorded in the Verrazzano status and the requeue delay is returned
+func TestReconcileOpenSearchSnapshots(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileIndexManagementFunc = func(_ spi.ComponentContext) ([]vzapi.OpenSearchSnapshotStatus, time.Duration, error) {
+		return []vzapi.OpenSearchSnapshotStatus{testSnapshotStatus}, time.Minute, nil
+	}
+	defer func() { reconcileIndexManagementFunc = opensearch.ReconcileIndexManagement }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Components = vzapi.ComponentStatusMap{
+		opensearch.ComponentName: &vzapi.ComponentStatusDetails{State: vzapi.CompStateReady},
+	}
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcileOpenSearchSnapshots(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Equal(time.Minute, requeueAfter)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.NotNil(updated.Status.OpenSearch)
+	asserts.Equal([]vzapi.OpenSearchSnapshotStatus{testSnapshotStatus}, updated.Status.OpenSearch.Snapshots)
+}
+
+// TestReconcileOpenSearchSnapshotsNotReady tests the reconcileOpenSearchSnapshots function
+// GIVEN a Verrazzano resource with OpenSearch not ready
+// WHEN reconcileOpenSearchSnapshots is called
+// THEN the index management tasks are not performed
+func TestReconcileOpenSearchSnapshotsNotReady(t *testing.T) {
+	asserts := assert.New(t)
+	reconcileIndexManagementFunc = func(_ spi.ComponentContext) ([]vzapi.OpenSearchSnapshotStatus, time.Duration, error) {
+		return nil, 0, fmt.Errorf("unexpected call")
+	}
+	defer func() { reconcileIndexManagementFunc = opensearch.ReconcileIndexManagement }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Components = vzapi.ComponentStatusMap{
+		opensearch.ComponentName: &vzapi.ComponentStatusDetails{State: vzapi.CompStateInstalling},
+	}
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcileOpenSearchSnapshots(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Zero(requeueAfter)
+}
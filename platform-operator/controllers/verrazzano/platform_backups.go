@@ -0,0 +1,40 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/backup"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+)
+
+// reconcileBackupsFunc maintains the objects of the scheduled platform backups, can be overridden for unit testing
+var reconcileBackupsFunc = backup.ReconcileBackups
+
+// reconcilePlatformBackups maintains the Velero schedules and the rancher-backup backups of the scheduled platform
+// backups, and records the last backup of each store in the Verrazzano status.  It returns how long to wait before
+// refreshing the status, zero means there is nothing to refresh.
+func (r *Reconciler) reconcilePlatformBackups(vzctx vzcontext.VerrazzanoContext) (time.Duration, error) {
+	actualCR := vzctx.ActualCR
+	// Once the backups are removed from the spec, the objects of the schedules are deleted before the status is cleared
+	if actualCR.Spec.Backup == nil && actualCR.Status.Backup == nil {
+		return 0, nil
+	}
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, actualCR, nil, r.DryRun)
+	if err != nil {
+		return 0, err
+	}
+	status, requeueAfter, err := reconcileBackupsFunc(spiCtx)
+	if err != nil {
+		return 0, err
+	}
+	if reflect.DeepEqual(status, actualCR.Status.Backup) {
+		return requeueAfter, nil
+	}
+	actualCR.Status.Backup = status
+	return requeueAfter, r.updateVerrazzanoStatus(vzctx.Log, actualCR)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/backup"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+var testBackupStatus = &vzapi.BackupStatus{
+	Stores: []vzapi.BackupStoreStatus{{
+		Schedule:       "daily",
+		Store:          vzapi.BackupStoreKeycloakMySQL,
+		Name:           "daily-keycloak-mysql-20261019010000",
+		State:          backup.StateCompleted,
+		StartTime:      "2026-10-19T01:00:00Z",
+		CompletionTime: "2026-10-19T01:01:30Z",
+	}},
+}
+
+// TestReconcilePlatformBackups tests the reconcilePlatformBackups function
+// GIVEN a Verrazzano resource with scheduled backups
+// WHEN reconcilePlatformBackups is called
+// THEN the status of the last backups is recorded in the Verrazzano status and the refresh delay is returned
+func TestReconcilePlatformBackups(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileBackupsFunc = func(_ spi.ComponentContext) (*vzapi.BackupStatus, time.Duration, error) {
+		return testBackupStatus, 5 * time.Minute, nil
+	}
+	defer func() { reconcileBackupsFunc = backup.ReconcileBackups }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Spec.Backup = &vzapi.BackupSpec{
+		Schedules: []vzapi.BackupSchedule{{Name: "daily", Schedule: "0 1 * * *"}},
+	}
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcilePlatformBackups(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Equal(5*time.Minute, requeueAfter)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Equal(testBackupStatus, updated.Status.Backup)
+}
+
+// TestReconcilePlatformBackupsRemoved tests the reconcilePlatformBackups function
+// GIVEN a Verrazzano resource whose scheduled backups have been removed
+// WHEN reconcilePlatformBackups is called
+// THEN the backup status is cleared once the objects of the schedules are deleted
+func TestReconcilePlatformBackupsRemoved(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	reconcileBackupsFunc = func(_ spi.ComponentContext) (*vzapi.BackupStatus, time.Duration, error) {
+		return nil, 0, nil
+	}
+	defer func() { reconcileBackupsFunc = backup.ReconcileBackups }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Backup = testBackupStatus
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcilePlatformBackups(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Zero(requeueAfter)
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Nil(updated.Status.Backup)
+}
+
+// TestReconcilePlatformBackupsNotConfigured tests the reconcilePlatformBackups function
+// GIVEN a Verrazzano resource without scheduled backups
+// WHEN reconcilePlatformBackups is called
+// THEN the backups are not reconciled
+func TestReconcilePlatformBackupsNotConfigured(t *testing.T) {
+	asserts := assert.New(t)
+	reconcileBackupsFunc = func(_ spi.ComponentContext) (*vzapi.BackupStatus, time.Duration, error) {
+		return nil, 0, fmt.Errorf("unexpected call")
+	}
+	defer func() { reconcileBackupsFunc = backup.ReconcileBackups }()
+
+	vz := newMaintenanceTestVZ(nil)
+	r := newMaintenanceTestReconciler(vz)
+
+	requeueAfter, err := r.reconcilePlatformBackups(vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz})
+	asserts.NoError(err)
+	asserts.Zero(requeueAfter)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/networkpolicy"
+)
+
+// reconcileNetworkPoliciesFunc maintains the network policies of the system namespaces, can be overridden for unit
+// testing
+var reconcileNetworkPoliciesFunc = networkpolicy.ReconcileNetworkPolicies
+
+// reconcileNetworkPolicies maintains the default-deny and allow network policies of the Verrazzano system namespaces,
+// and deletes them once they are disabled
+func (r *Reconciler) reconcileNetworkPolicies(vzctx vzcontext.VerrazzanoContext) error {
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, vzctx.ActualCR, nil, r.DryRun)
+	if err != nil {
+		return err
+	}
+	return reconcileNetworkPoliciesFunc(spiCtx)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/networkpolicy"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+// TestReconcileNetworkPolicies tests the reconcileNetworkPolicies function
+// GIVEN a Verrazzano resource with the network policies enabled
+// WHEN reconcileNetworkPolicies is called
+// THEN the network policies are reconciled from the effective Verrazzano resource, and their errors are returned
+func TestReconcileNetworkPolicies(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	var reconcileErr error
+	var enabled *bool
+	reconcileNetworkPoliciesFunc = func(ctx spi.ComponentContext) error {
+		enabled = ctx.EffectiveCR().Spec.Security.NetworkPolicies.Enabled
+		return reconcileErr
+	}
+	defer func() { reconcileNetworkPoliciesFunc = networkpolicy.ReconcileNetworkPolicies }()
+
+	networkPoliciesEnabled := true
+	vz := newMaintenanceTestVZ(nil)
+	vz.Spec.Security.NetworkPolicies = &vzapi.NetworkPoliciesSpec{Enabled: &networkPoliciesEnabled}
+	r := newMaintenanceTestReconciler(vz)
+	vzctx := vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz}
+
+	asserts.NoError(r.reconcileNetworkPolicies(vzctx))
+	asserts.True(*enabled)
+
+	reconcileErr = fmt.Errorf("update failed")
+	asserts.Error(r.reconcileNetworkPolicies(vzctx))
+}
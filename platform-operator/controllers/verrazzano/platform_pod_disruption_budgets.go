@@ -0,0 +1,71 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"reflect"
+	"time"
+
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/availability"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reconcilePodDisruptionBudgetsFunc maintains the pod disruption budgets of the components, can be overridden for
+// unit testing
+var reconcilePodDisruptionBudgetsFunc = availability.ReconcilePodDisruptionBudgets
+
+// reconcilePodDisruptionBudgets maintains the pod disruption budgets of the replicated pods of the components in the
+// high availability mode, and deletes them once the mode is disabled.  The HighAvailabilityDegraded condition is set
+// in the Verrazzano status while the nodes of the cluster cannot satisfy the mode.
+func (r *Reconciler) reconcilePodDisruptionBudgets(vzctx vzcontext.VerrazzanoContext) error {
+	actualCR := vzctx.ActualCR
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, actualCR, nil, r.DryRun)
+	if err != nil {
+		return err
+	}
+	warning, err := reconcilePodDisruptionBudgetsFunc(spiCtx)
+	if err != nil {
+		return err
+	}
+	conditions := setHighAvailabilityCondition(actualCR.Status.Conditions, warning)
+	if reflect.DeepEqual(conditions, actualCR.Status.Conditions) {
+		return nil
+	}
+	actualCR.Status.Conditions = conditions
+	return r.updateVerrazzanoStatus(vzctx.Log, actualCR)
+}
+
+// setHighAvailabilityCondition returns the conditions with the HighAvailabilityDegraded condition set to the warning,
+// or removed when there is no warning.  A new condition is added first, since the last condition is the install or
+// upgrade condition displayed as the status of the Verrazzano resource.
+func setHighAvailabilityCondition(conditions []installv1alpha1.Condition, warning string) []installv1alpha1.Condition {
+	index := -1
+	for i := range conditions {
+		if conditions[i].Type == installv1alpha1.CondHighAvailabilityDegraded {
+			index = i
+			break
+		}
+	}
+	switch {
+	case index < 0 && warning == "":
+		return conditions
+	case index < 0:
+		degraded := installv1alpha1.Condition{
+			Type:               installv1alpha1.CondHighAvailabilityDegraded,
+			Status:             corev1.ConditionTrue,
+			Message:            warning,
+			LastTransitionTime: time.Now().UTC().Format(time.RFC3339),
+		}
+		return append([]installv1alpha1.Condition{degraded}, conditions...)
+	case warning == "":
+		return append(append([]installv1alpha1.Condition{}, conditions[:index]...), conditions[index+1:]...)
+	default:
+		updated := append([]installv1alpha1.Condition{}, conditions...)
+		updated[index].Message = warning
+		return updated
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/availability"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestReconcilePodDisruptionBudgets tests the reconcilePodDisruptionBudgets function
+// GIVEN a Verrazzano resource with the high availability mode enabled
+// WHEN reconcilePodDisruptionBudgets is called
+// THEN the pod disruption budgets are reconciled from the effective Verrazzano resource, and their errors are returned
+func TestReconcilePodDisruptionBudgets(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	var reconcileErr error
+	var enabled *bool
+	reconcilePodDisruptionBudgetsFunc = func(ctx spi.ComponentContext) (string, error) {
+		enabled = ctx.EffectiveCR().Spec.HighAvailability.Enabled
+		return "", reconcileErr
+	}
+	defer func() { reconcilePodDisruptionBudgetsFunc = availability.ReconcilePodDisruptionBudgets }()
+
+	highAvailabilityEnabled := true
+	vz := newMaintenanceTestVZ(nil)
+	vz.Spec.HighAvailability = &vzapi.HighAvailabilitySpec{Enabled: &highAvailabilityEnabled}
+	r := newMaintenanceTestReconciler(vz)
+	vzctx := vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz}
+
+	asserts.NoError(r.reconcilePodDisruptionBudgets(vzctx))
+	asserts.True(*enabled)
+
+	reconcileErr = fmt.Errorf("update failed")
+	asserts.Error(r.reconcilePodDisruptionBudgets(vzctx))
+}
+
+// TestReconcilePodDisruptionBudgetsCondition tests the reconcilePodDisruptionBudgets function
+// GIVEN a Verrazzano resource with the high availability mode enabled on a cluster whose nodes cannot satisfy it
+// WHEN reconcilePodDisruptionBudgets is called
+// THEN the HighAvailabilityDegraded condition is added before the install condition, and is removed once the nodes
+// satisfy the high availability mode
+func TestReconcilePodDisruptionBudgetsCondition(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	warning := "The high availability mode requires at least two schedulable nodes, the cluster has 1"
+	reconcilePodDisruptionBudgetsFunc = func(_ spi.ComponentContext) (string, error) {
+		return warning, nil
+	}
+	defer func() { reconcilePodDisruptionBudgetsFunc = availability.ReconcilePodDisruptionBudgets }()
+
+	vz := newMaintenanceTestVZ(nil)
+	vz.Status.Conditions = []vzapi.Condition{{Type: vzapi.CondInstallComplete, Status: corev1.ConditionTrue}}
+	r := newMaintenanceTestReconciler(vz)
+	vzctx := vzcontext.VerrazzanoContext{Log: vzlog.DefaultLogger(), Client: r.Client, ActualCR: vz}
+
+	asserts.NoError(r.reconcilePodDisruptionBudgets(vzctx))
+	updated := getMaintenanceTestVZ(t, r)
+	asserts.Len(updated.Status.Conditions, 2)
+	asserts.Equal(vzapi.CondHighAvailabilityDegraded, updated.Status.Conditions[0].Type)
+	asserts.Equal(corev1.ConditionTrue, updated.Status.Conditions[0].Status)
+	asserts.Equal(warning, updated.Status.Conditions[0].Message)
+	asserts.NotEmpty(updated.Status.Conditions[0].LastTransitionTime)
+	asserts.Equal(vzapi.CondInstallComplete, updated.Status.Conditions[1].Type)
+
+	warning = ""
+	asserts.NoError(r.reconcilePodDisruptionBudgets(vzctx))
+	updated = getMaintenanceTestVZ(t, r)
+	asserts.Equal([]vzapi.Condition{{Type: vzapi.CondInstallComplete, Status: corev1.ConditionTrue}}, updated.Status.Conditions)
+}
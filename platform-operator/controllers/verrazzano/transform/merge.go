@@ -16,10 +16,14 @@ import (
 const (
 	// implicit base profile (defaults)
 	baseProfile = "base"
+	// prefix of the size preset profiles
+	sizePresetPrefix = "size-"
+	// preset profile of the high availability mode
+	highAvailabilityPreset = "ha"
 )
 
 // GetEffectiveCR Creates an "effective" v1alpha1.Verrazzano CR based on the user defined resource merged with the profile definitions
-// - Effective CR == base profile + declared profiles + ActualCR (in order)
+// - Effective CR == base profile + declared profiles + high availability preset + size preset + ActualCR (in order)
 // - last definition wins
 func GetEffectiveCR(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error) {
 	if actualCR == nil {
@@ -30,6 +34,12 @@ func GetEffectiveCR(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error)
 	if len(actualCR.Spec.Profile) > 0 {
 		profiles = append([]string{baseProfile}, strings.Split(string(actualCR.Spec.Profile), ",")...)
 	}
+	if ha := actualCR.Spec.HighAvailability; ha != nil && ha.Enabled != nil && *ha.Enabled {
+		profiles = append(profiles, highAvailabilityPreset)
+	}
+	if len(actualCR.Spec.Size) > 0 {
+		profiles = append(profiles, sizePresetPrefix+string(actualCR.Spec.Size))
+	}
 	var profileFiles []string
 	for _, profile := range profiles {
 		profileFiles = append(profileFiles, config.GetProfile(v1alpha1.SchemeGroupVersion, profile))
@@ -52,7 +62,7 @@ func GetEffectiveCR(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error)
 }
 
 // GetEffectiveV1beta1CR Creates an "effective" v1beta1.Verrazzano CR based on the user defined resource merged with the profile definitions
-// - Effective CR == base profile + declared profiles + ActualCR (in order)
+// - Effective CR == base profile + declared profiles + high availability preset + size preset + ActualCR (in order)
 // - last definition wins
 func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, error) {
 	if actualCR == nil {
@@ -63,6 +73,12 @@ func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, e
 	if len(actualCR.Spec.Profile) > 0 {
 		profiles = append([]string{baseProfile}, strings.Split(string(actualCR.Spec.Profile), ",")...)
 	}
+	if ha := actualCR.Spec.HighAvailability; ha != nil && ha.Enabled != nil && *ha.Enabled {
+		profiles = append(profiles, highAvailabilityPreset)
+	}
+	if len(actualCR.Spec.Size) > 0 {
+		profiles = append(profiles, sizePresetPrefix+string(actualCR.Spec.Size))
+	}
 	var profileFiles []string
 	for _, profile := range profiles {
 		profileFiles = append(profileFiles, config.GetProfile(v1beta1.SchemeGroupVersion, profile))
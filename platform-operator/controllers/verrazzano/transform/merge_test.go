@@ -0,0 +1,145 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const profilesDir = "../../../manifests/profiles"
+
+// TestGetEffectiveCRWithSize tests the GetEffectiveCR function
+// GIVEN a Verrazzano resource with a size preset and the replicas of the ingress controller
+// WHEN GetEffectiveCR is called
+// THEN the workload settings of the preset are merged, and the settings of the resource take precedence
+func TestGetEffectiveCRWithSize(t *testing.T) {
+	config.TestProfilesDir = profilesDir
+	defer func() { config.TestProfilesDir = "" }()
+
+	replicas := int32(5)
+	vz := &v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{
+			Profile: v1alpha1.Dev,
+			Size:    v1alpha1.SizeLarge,
+			Components: v1alpha1.ComponentSpec{
+				Ingress: &v1alpha1.IngressNginxComponent{
+					Workload: &v1alpha1.WorkloadSpec{Replicas: &replicas},
+				},
+			},
+		},
+	}
+	effectiveCR, err := GetEffectiveCR(vz)
+	assert.NoError(t, err)
+	workload := effectiveCR.Spec.Components.Ingress.Workload
+	assert.Equal(t, int32(5), *workload.Replicas)
+	assert.True(t, resource.MustParse("1Gi").Equal(workload.Resources.Limits[corev1.ResourceMemory]))
+	assert.NotNil(t, effectiveCR.Spec.Components.Keycloak.MySQL.Workload)
+}
+
+// TestGetEffectiveV1beta1CRSize tests the GetEffectiveV1beta1CR function
+// GIVEN a Verrazzano resource with and without a size preset
+// WHEN GetEffectiveV1beta1CR is called
+// THEN the workload settings are only set when a size preset is requested
+func TestGetEffectiveV1beta1CRSize(t *testing.T) {
+	config.TestProfilesDir = profilesDir
+	defer func() { config.TestProfilesDir = "" }()
+
+	effectiveCR, err := GetEffectiveV1beta1CR(&v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Profile: v1beta1.Prod}})
+	assert.NoError(t, err)
+	assert.Nil(t, effectiveCR.Spec.Components.IngressNGINX.Workload)
+	assert.Nil(t, effectiveCR.Spec.Components.Keycloak.Workload)
+
+	effectiveCR, err = GetEffectiveV1beta1CR(&v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Size: v1beta1.SizeSmall}})
+	assert.NoError(t, err)
+	assert.NotNil(t, effectiveCR.Spec.Components.IngressNGINX.Workload.Resources)
+}
+
+// TestGetEffectiveCRManagedClusterWithSize tests the GetEffectiveCR and GetEffectiveV1beta1CR functions
+// GIVEN a Verrazzano resource with the managed-cluster profile and a size preset
+// WHEN GetEffectiveCR and GetEffectiveV1beta1CR are called
+// THEN the declared profile is overlaid on the base profile and the components it disables stay disabled
+func TestGetEffectiveCRManagedClusterWithSize(t *testing.T) {
+	config.TestProfilesDir = profilesDir
+	defer func() { config.TestProfilesDir = "" }()
+
+	effectiveCR, err := GetEffectiveCR(&v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{Profile: v1alpha1.ManagedCluster, Size: v1alpha1.SizeSmall},
+	})
+	assert.NoError(t, err)
+	components := effectiveCR.Spec.Components
+	for name, enabled := range map[string]*bool{
+		"rancher":       components.Rancher.Enabled,
+		"keycloak":      components.Keycloak.Enabled,
+		"elasticsearch": components.Elasticsearch.Enabled,
+		"grafana":       components.Grafana.Enabled,
+		"kibana":        components.Kibana.Enabled,
+		"kiali":         components.Kiali.Enabled,
+		"console":       components.Console.Enabled,
+	} {
+		assert.NotNil(t, enabled, name)
+		assert.False(t, *enabled, name)
+	}
+	assert.NotNil(t, components.Ingress.Workload.Resources)
+
+	effectiveV1beta1CR, err := GetEffectiveV1beta1CR(&v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{Profile: v1beta1.ManagedCluster, Size: v1beta1.SizeSmall},
+	})
+	assert.NoError(t, err)
+	v1beta1Components := effectiveV1beta1CR.Spec.Components
+	for name, enabled := range map[string]*bool{
+		"rancher":              v1beta1Components.Rancher.Enabled,
+		"keycloak":             v1beta1Components.Keycloak.Enabled,
+		"opensearch":           v1beta1Components.OpenSearch.Enabled,
+		"grafana":              v1beta1Components.Grafana.Enabled,
+		"opensearchDashboards": v1beta1Components.OpenSearchDashboards.Enabled,
+		"kiali":                v1beta1Components.Kiali.Enabled,
+		"console":              v1beta1Components.Console.Enabled,
+	} {
+		assert.NotNil(t, enabled, name)
+		assert.False(t, *enabled, name)
+	}
+	assert.NotNil(t, v1beta1Components.IngressNGINX.Workload.Resources)
+}
+
+// TestGetEffectiveCRWithHighAvailability tests the GetEffectiveCR and GetEffectiveV1beta1CR functions
+// GIVEN a Verrazzano resource with the high availability mode enabled and a large size preset
+// WHEN GetEffectiveCR and GetEffectiveV1beta1CR are called
+// THEN the replicas and the affinity of the preset are merged, the size preset takes precedence over the
+// preset, and the Helm overrides of the preset take precedence over the overrides of the profile
+func TestGetEffectiveCRWithHighAvailability(t *testing.T) {
+	config.TestProfilesDir = profilesDir
+	defer func() { config.TestProfilesDir = "" }()
+
+	enabled := true
+	effectiveCR, err := GetEffectiveCR(&v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{
+			Size:             v1alpha1.SizeLarge,
+			HighAvailability: &v1alpha1.HighAvailabilitySpec{Enabled: &enabled},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), *effectiveCR.Spec.Components.Ingress.Workload.Replicas)
+	assert.Equal(t, uint32(2), effectiveCR.Spec.Components.AuthProxy.Kubernetes.Replicas)
+	assert.Len(t, effectiveCR.Spec.Components.AuthProxy.Kubernetes.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 2)
+	assert.Equal(t, uint32(2), effectiveCR.Spec.Components.Istio.Ingress.Kubernetes.Replicas)
+	assert.Equal(t, int32(2), *effectiveCR.Spec.Components.Kibana.Replicas)
+	assert.Contains(t, string(effectiveCR.Spec.Components.Keycloak.ValueOverrides[0].Values.Raw), "topology.kubernetes.io/zone")
+
+	effectiveV1beta1CR, err := GetEffectiveV1beta1CR(&v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{
+			HighAvailability: &v1beta1.HighAvailabilitySpec{Enabled: &enabled},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), *effectiveV1beta1CR.Spec.Components.IngressNGINX.Workload.Replicas)
+	assert.Equal(t, int32(2), *effectiveV1beta1CR.Spec.Components.OpenSearchDashboards.Replicas)
+	assert.Contains(t, string(effectiveV1beta1CR.Spec.Components.AuthProxy.ValueOverrides[0].Values.Raw), "\"replicas\":2")
+}
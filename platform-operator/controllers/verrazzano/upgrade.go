@@ -99,13 +99,21 @@ func (r *Reconciler) reconcileUpgrade(log vzlog.VerrazzanoLogger, cr *installv1a
 			tracker.vzState = vzStatePostUpgrade
 
 		case vzStatePostUpgrade:
+			// Restarting system components is disruptive, wait for the maintenance window if the upgrade has run past it
+			if allowed, err := r.checkMaintenanceWindow(log, cr, restartSystemComponentsOperation); err != nil || !allowed {
+				return newDeferralRequeue(cr), err
+			}
 			// Invoke the global post upgrade function after all components are upgraded.
 			log.Once("Doing Verrazzano post-upgrade processing")
-			err := postVerrazzanoUpgrade(log, r.Client, cr)
+			done, err := postVerrazzanoUpgrade(log, r.Client, cr)
 			if err != nil {
 				log.Errorf("Error running Verrazzano system-level post-upgrade")
 				return newRequeueWithDelay(), err
 			}
+			if !done {
+				log.Progress("Post-upgrade is waiting for the system namespaces to be migrated to the new Istio revision")
+				return newRequeueWithDelay(), nil
+			}
 			tracker.vzState = vzStateWaitPostUpgradeDone
 
 		case vzStateWaitPostUpgradeDone:
@@ -132,7 +140,28 @@ func (r *Reconciler) reconcileUpgrade(log vzlog.VerrazzanoLogger, cr *installv1a
 			tracker.vzState = vzStateRestartApps
 
 		case vzStateRestartApps:
-			if vzconfig.IsApplicationOperatorEnabled(cr) && vzconfig.IsIstioEnabled(cr) {
+			if vzconfig.IsIstioEnabled(cr) && istio.IsRevisionUpgrade(cr) {
+				// Migrating applications is disruptive, wait for the maintenance window if the upgrade has run past it
+				if allowed, err := r.checkMaintenanceWindow(log, cr, restartAppsOperation); err != nil || !allowed {
+					return newDeferralRequeue(cr), err
+				}
+				log.Once("Migrating the application namespaces to the new Istio revision")
+				done, err := istio.MigrateAppNamespaces(log, r.Client, cr)
+				if err != nil {
+					log.Errorf("Error migrating the application namespaces to the new Istio revision")
+					return newRequeueWithDelay(), err
+				}
+				if !done {
+					return newRequeueWithDelay(), nil
+				}
+				if err := istio.RemoveUnusedRevisions(log, r.Client); err != nil {
+					return newRequeueWithDelay(), err
+				}
+			} else if vzconfig.IsApplicationOperatorEnabled(cr) && vzconfig.IsIstioEnabled(cr) {
+				// Restarting applications is disruptive, wait for the maintenance window if the upgrade has run past it
+				if allowed, err := r.checkMaintenanceWindow(log, cr, restartAppsOperation); err != nil || !allowed {
+					return newDeferralRequeue(cr), err
+				}
 				log.Once("Doing Verrazzano post-upgrade application restarts if needed")
 				err := istio.RestartApps(log, r.Client, cr.Generation)
 				if err != nil {
@@ -222,10 +251,15 @@ func isLastCondition(st installv1alpha1.VerrazzanoStatus, conditionType installv
 	return st.Conditions[l-1].Type == conditionType
 }
 
-// postVerrazzanoUpgrade restarts pods with old Istio sidecar proxies
-func postVerrazzanoUpgrade(log vzlog.VerrazzanoLogger, client clipkg.Client, cr *installv1alpha1.Verrazzano) error {
+// postVerrazzanoUpgrade restarts pods with old Istio sidecar proxies, or migrates the system namespaces to the new
+// Istio revision one at a time with the Revision upgrade strategy.  Returns false while a namespace is being migrated.
+func postVerrazzanoUpgrade(log vzlog.VerrazzanoLogger, client clipkg.Client, cr *installv1alpha1.Verrazzano) (bool, error) {
+	if vzconfig.IsIstioEnabled(cr) && istio.IsRevisionUpgrade(cr) {
+		log.Oncef("Migrating the Istio injected system namespaces to the new Istio revision")
+		return istio.MigrateSystemNamespaces(log, client, cr.Generation)
+	}
 	log.Oncef("Checking if any pods with Istio sidecars need to be restarted to pick up the new version of the Istio proxy")
-	return istio.RestartComponents(log, config.GetInjectedSystemNamespaces(), cr.Generation, istio.DoesPodContainOldIstioSidecar)
+	return true, istio.RestartComponents(log, config.GetInjectedSystemNamespaces(), cr.Generation, istio.DoesPodContainOldIstioSidecar)
 }
 
 // getTrackerKey gets the tracker key for the Verrazzano resource
@@ -97,6 +97,7 @@ func (r *Reconciler) upgradeSingleComponent(spiCtx spi.ComponentContext, upgrade
 			compLog.Oncef("Component %s pre-upgrade running", compName)
 			if err := comp.PreUpgrade(compContext); err != nil {
 				compLog.Errorf("Failed pre-upgrading component %s: %v", compName, err)
+				r.reportOverridesTemplateError(compContext, err, installv1alpha1.CondUpgradeStarted)
 				return ctrl.Result{}, err
 			}
 			upgradeContext.state = compStateUpgrade
@@ -105,6 +106,7 @@ func (r *Reconciler) upgradeSingleComponent(spiCtx spi.ComponentContext, upgrade
 			compLog.Progressf("Component %s upgrade running", compName)
 			if err := comp.Upgrade(compContext); err != nil {
 				compLog.Errorf("Failed upgrading component %s, will retry: %v", compName, err)
+				r.reportOverridesTemplateError(compContext, err, installv1alpha1.CondUpgradeStarted)
 				// check to see whether this is due to a pending upgrade
 				r.resolvePendingUpgrades(compName, compLog)
 				// requeue for 30 to 60 seconds later
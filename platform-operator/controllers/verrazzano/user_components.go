@@ -0,0 +1,148 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/usercomponent"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// listUserComponentsFunc lists the VerrazzanoComponent resources of a namespace, can be overridden for unit testing
+var listUserComponentsFunc = listUserComponents
+
+// listUserComponents lists the VerrazzanoComponent resources of a namespace
+func listUserComponents(ctx context.Context, c client.Client, namespace string) (*componentsv1alpha1.VerrazzanoComponentList, error) {
+	vcList := &componentsv1alpha1.VerrazzanoComponentList{}
+	err := c.List(ctx, vcList, client.InNamespace(namespace))
+	return vcList, err
+}
+
+// loadUserComponents registers the user-defined components, declared by VerrazzanoComponent resources in the namespace
+// of the Verrazzano resource, so that they are processed with the built-in components.  Components being deleted are
+// uninstalled by the VerrazzanoComponent controller and are only registered while Verrazzano itself is being deleted.
+// The VerrazzanoComponent resources are returned.
+func (r *Reconciler) loadUserComponents(ctx context.Context, log vzlog.VerrazzanoLogger, vz *installv1alpha1.Verrazzano) ([]componentsv1alpha1.VerrazzanoComponent, error) {
+	vcList, err := listUserComponentsFunc(ctx, r.Client, vz.Namespace)
+	if err != nil {
+		// The VerrazzanoComponent API is not available
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			registry.SetUserComponents(nil)
+			return nil, nil
+		}
+		log.Errorf("Failed to list VerrazzanoComponent resources in namespace %s: %v", vz.Namespace, err)
+		return nil, err
+	}
+	var components []spi.Component
+	for i := range vcList.Items {
+		vc := &vcList.Items[i]
+		if !vc.DeletionTimestamp.IsZero() && vz.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if registry.IsBuiltInComponent(vc.GetReleaseName()) {
+			log.ErrorfThrottled("Ignoring VerrazzanoComponent %s/%s, %s is the name of a built-in component", vc.Namespace, vc.Name, vc.GetReleaseName())
+			continue
+		}
+		components = append(components, usercomponent.NewComponent(vc))
+	}
+	registry.SetUserComponents(components)
+	return vcList.Items, nil
+}
+
+// reconcileUserComponentChanges applies changes to VerrazzanoComponent resources.  A component that has been
+// added after Verrazzano was installed is installed, and a component whose VerrazzanoComponent has been updated
+// re-enters the install flow, in the maintenance window if one is declared.  Components that are being installed
+// are handled once they are ready.
+func (r *Reconciler) reconcileUserComponentChanges(vzctx vzcontext.VerrazzanoContext) (ctrl.Result, error) {
+	cr := vzctx.ActualCR
+	vcs := vzctx.UserComponents
+	if len(vcs) == 0 {
+		return ctrl.Result{}, nil
+	}
+	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, cr, nil, r.DryRun)
+	if err != nil {
+		return newRequeueWithDelay(), err
+	}
+	for i := range vcs {
+		vc := &vcs[i]
+		compName := vc.GetReleaseName()
+		if !vc.DeletionTimestamp.IsZero() || vc.Generation == vc.Status.ObservedGeneration || registry.IsBuiltInComponent(compName) {
+			continue
+		}
+		componentStatus, ok := cr.Status.Components[compName]
+		if !ok {
+			continue
+		}
+		compContext := spiCtx.Init(compName).Operation(vzconst.InstallOperation)
+		switch componentStatus.State {
+		case installv1alpha1.CompStateDisabled:
+			// A component added after install is not installed by the install flow while Verrazzano is ready
+			if cr.Status.State == installv1alpha1.VzStateReady {
+				if result, err := r.startUserComponentInstall(compContext); err != nil {
+					return result, err
+				}
+			}
+		case installv1alpha1.CompStatePreInstalling:
+			// The install has not started, it uses the latest spec
+		case installv1alpha1.CompStateReady:
+			if allowed, err := r.isComponentUpdateAllowed(compContext, cr); err != nil {
+				return newRequeueWithDelay(), err
+			} else if !allowed {
+				continue
+			}
+			if result, err := r.startUserComponentInstall(compContext); err != nil {
+				return result, err
+			}
+		default:
+			continue
+		}
+		vc.Status.ObservedGeneration = vc.Generation
+		if err := r.Status().Update(context.TODO(), vc); err != nil {
+			return newRequeueWithDelay(), err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// startUserComponentInstall moves a user-defined component to the install flow
+func (r *Reconciler) startUserComponentInstall(compContext spi.ComponentContext) (ctrl.Result, error) {
+	cr := compContext.ActualCR()
+	compContext.Log().Oncef("Component %s VerrazzanoComponent has changed, starting install flow", compContext.GetComponent())
+	cr.Status.Components[compContext.GetComponent()].ReconcilingGeneration = 0
+	if err := r.updateComponentStatus(compContext, "PreInstall started", installv1alpha1.CondPreInstall); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	if cr.Status.State == installv1alpha1.VzStateReady {
+		if err := r.setInstallingState(compContext.Log(), cr); err != nil {
+			compContext.Log().Errorf("Failed to reset state: %v", err)
+			return newRequeueWithDelay(), err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// watchUserComponents watches the VerrazzanoComponent resources in the namespace of the Verrazzano resource,
+// so that the Verrazzano resource is reconciled when user-defined components are added, updated or deleted
+func (r *Reconciler) watchUserComponents(namespace string, name string, log vzlog.VerrazzanoLogger) error {
+	log.Debugf("Watching for VerrazzanoComponents to activate reconcile for Verrazzano CR %s/%s", namespace, name)
+	return r.Controller.Watch(
+		&source.Kind{Type: &componentsv1alpha1.VerrazzanoComponent{}},
+		createReconcileEventHandler(namespace, name),
+		predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetNamespace() == namespace
+		}))
+}
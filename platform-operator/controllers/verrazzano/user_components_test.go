@@ -0,0 +1,231 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/istio"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const userCompName = "policy-agent"
+
+// The controller unit tests using mock clients do not expect the VerrazzanoComponent list call
+func init() {
+	listUserComponentsFunc = listNoUserComponents
+}
+
+func listNoUserComponents(_ context.Context, _ client.Client, _ string) (*componentsv1alpha1.VerrazzanoComponentList, error) {
+	return &componentsv1alpha1.VerrazzanoComponentList{}, nil
+}
+
+// TestLoadUserComponents tests the loadUserComponents function
+// GIVEN VerrazzanoComponent resources in the namespace of the Verrazzano resource
+// WHEN loadUserComponents is called
+// THEN the user-defined components are registered after the built-in components, except for the components that
+// are being deleted or that have the name of a built-in component
+func TestLoadUserComponents(t *testing.T) {
+	asserts := assert.New(t)
+	defer registry.SetUserComponents(nil)
+	listUserComponentsFunc = listUserComponents
+	defer func() { listUserComponentsFunc = listNoUserComponents }()
+
+	vz := newUserComponentTestVZ(vzapi.CompStateReady)
+	deleting := newTestVerrazzanoComponent("deleting-agent", 1)
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deleting.Finalizers = []string{componentsv1alpha1.FinalizerName}
+	builtIn := newTestVerrazzanoComponent("mesh", 1)
+	builtIn.Spec.ReleaseName = istio.ComponentName
+	otherNamespace := newTestVerrazzanoComponent("other-agent", 1)
+	otherNamespace.Namespace = "other"
+	r := newUserComponentTestReconciler(vz, newTestVerrazzanoComponent(userCompName, 1), deleting, builtIn, otherNamespace)
+
+	vcs, err := r.loadUserComponents(context.TODO(), vzlog.DefaultLogger(), vz)
+	asserts.NoError(err)
+	asserts.Len(vcs, 3)
+
+	comps := registry.GetComponents()
+	asserts.Equal(userCompName, comps[len(comps)-1].Name())
+	found, _ := registry.FindComponent("deleting-agent")
+	asserts.False(found)
+	found, _ = registry.FindComponent("other-agent")
+	asserts.False(found)
+	asserts.True(registry.IsBuiltInComponent(istio.ComponentName))
+	asserts.False(registry.IsBuiltInComponent(userCompName))
+
+	// The components are still registered while Verrazzano is being deleted
+	vz.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	_, err = r.loadUserComponents(context.TODO(), vzlog.DefaultLogger(), vz)
+	asserts.NoError(err)
+	found, _ = registry.FindComponent("deleting-agent")
+	asserts.True(found)
+}
+
+// TestReconcileUserComponentAdded tests the reconcileUserComponentChanges function
+// GIVEN an installed Verrazzano and a VerrazzanoComponent that has been added after the install
+// WHEN reconcileUserComponentChanges is called
+// THEN the component enters the install flow, Verrazzano is reconciling and the generation is observed
+func TestReconcileUserComponentAdded(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	vz := newUserComponentTestVZ(vzapi.CompStateDisabled)
+	vc := newTestVerrazzanoComponent(userCompName, 1)
+	r := newUserComponentTestReconciler(vz, vc)
+
+	result, err := r.reconcileUserComponentChanges(newUserComponentTestContext(vz, vc))
+	asserts.NoError(err)
+	asserts.False(result.Requeue)
+
+	updated := getUserComponentTestVZ(t, r)
+	asserts.Equal(vzapi.VzStateReconciling, updated.Status.State)
+	asserts.Equal(vzapi.CompStatePreInstalling, updated.Status.Components[userCompName].State)
+	asserts.Equal(int64(1), getTestVerrazzanoComponent(t, r).Status.ObservedGeneration)
+}
+
+// TestReconcileUserComponentUpdateDeferred tests the reconcileUserComponentChanges function
+// GIVEN an installed Verrazzano with a closed maintenance window and an updated VerrazzanoComponent
+// WHEN reconcileUserComponentChanges is called
+// THEN the update is deferred and the generation is not observed
+func TestReconcileUserComponentUpdateDeferred(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	setCurrentTime(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	defer resetCurrentTime()
+
+	vz := newUserComponentTestVZ(vzapi.CompStateReady)
+	vz.Spec.MaintenanceWindow = saturdayWindow
+	vc := newTestVerrazzanoComponent(userCompName, 2)
+	vc.Status.ObservedGeneration = 1
+	r := newUserComponentTestReconciler(vz, vc)
+
+	_, err := r.reconcileUserComponentChanges(newUserComponentTestContext(vz, vc))
+	asserts.NoError(err)
+
+	updated := getUserComponentTestVZ(t, r)
+	asserts.Equal(vzapi.VzStateReady, updated.Status.State)
+	asserts.Equal(vzapi.CompStateReady, updated.Status.Components[userCompName].State)
+	asserts.Equal([]string{componentUpdateOperation(userCompName)}, updated.Status.Maintenance.DeferredOperations)
+	asserts.Equal(int64(1), getTestVerrazzanoComponent(t, r).Status.ObservedGeneration)
+}
+
+// TestReconcileUserComponentUpdated tests the reconcileUserComponentChanges function
+// GIVEN an installed Verrazzano and an updated VerrazzanoComponent
+// WHEN reconcileUserComponentChanges is called
+// THEN the component re-enters the install flow and the generation is observed
+func TestReconcileUserComponentUpdated(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	vz := newUserComponentTestVZ(vzapi.CompStateReady)
+	vc := newTestVerrazzanoComponent(userCompName, 2)
+	vc.Status.ObservedGeneration = 1
+	r := newUserComponentTestReconciler(vz, vc)
+
+	_, err := r.reconcileUserComponentChanges(newUserComponentTestContext(vz, vc))
+	asserts.NoError(err)
+
+	updated := getUserComponentTestVZ(t, r)
+	asserts.Equal(vzapi.VzStateReconciling, updated.Status.State)
+	asserts.Equal(vzapi.CompStatePreInstalling, updated.Status.Components[userCompName].State)
+	asserts.Equal(int64(2), getTestVerrazzanoComponent(t, r).Status.ObservedGeneration)
+}
+
+// TestReconcileUserComponentInstalling tests the reconcileUserComponentChanges function
+// GIVEN an updated VerrazzanoComponent whose component is being installed
+// WHEN reconcileUserComponentChanges is called
+// THEN nothing is changed until the component is ready
+func TestReconcileUserComponentInstalling(t *testing.T) {
+	asserts := assert.New(t)
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+
+	vz := newUserComponentTestVZ(vzapi.CompStateInstalling)
+	vz.Status.State = vzapi.VzStateReconciling
+	vc := newTestVerrazzanoComponent(userCompName, 2)
+	vc.Status.ObservedGeneration = 1
+	r := newUserComponentTestReconciler(vz, vc)
+
+	_, err := r.reconcileUserComponentChanges(newUserComponentTestContext(vz, vc))
+	asserts.NoError(err)
+
+	updated := getUserComponentTestVZ(t, r)
+	asserts.Equal(vzapi.CompStateInstalling, updated.Status.Components[userCompName].State)
+	asserts.Equal(int64(1), getTestVerrazzanoComponent(t, r).Status.ObservedGeneration)
+}
+
+func newUserComponentTestVZ(compState vzapi.CompStateType) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano", Generation: 1},
+		Status: vzapi.VerrazzanoStatus{
+			State:      vzapi.VzStateReady,
+			Version:    "1.0.1",
+			Conditions: []vzapi.Condition{{Type: vzapi.CondInstallComplete}},
+			Components: map[string]*vzapi.ComponentStatusDetails{
+				userCompName: {Name: userCompName, State: compState, LastReconciledGeneration: 1},
+			},
+		},
+	}
+}
+
+func newTestVerrazzanoComponent(name string, generation int64) *componentsv1alpha1.VerrazzanoComponent {
+	return &componentsv1alpha1.VerrazzanoComponent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Generation: generation},
+		Spec: componentsv1alpha1.VerrazzanoComponentSpec{
+			Chart:     componentsv1alpha1.ChartLocation{Path: "oci://registry.example.com/charts/policy-agent"},
+			Namespace: "policy-system",
+		},
+	}
+}
+
+func newUserComponentTestReconciler(objects ...client.Object) Reconciler {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = componentsv1alpha1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return newVerrazzanoReconciler(c)
+}
+
+func newUserComponentTestContext(vz *vzapi.Verrazzano, vcs ...*componentsv1alpha1.VerrazzanoComponent) vzcontext.VerrazzanoContext {
+	vzctx, _ := vzcontext.NewVerrazzanoContext(vzlog.DefaultLogger(), nil, vz, false)
+	for _, vc := range vcs {
+		vzctx.UserComponents = append(vzctx.UserComponents, *vc)
+	}
+	return vzctx
+}
+
+func getUserComponentTestVZ(t *testing.T, r Reconciler) *vzapi.Verrazzano {
+	vz := &vzapi.Verrazzano{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, vz))
+	return vz
+}
+
+func getTestVerrazzanoComponent(t *testing.T, r Reconciler) *componentsv1alpha1.VerrazzanoComponent {
+	vc := &componentsv1alpha1.VerrazzanoComponent{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: userCompName}, vc))
+	return vc
+}
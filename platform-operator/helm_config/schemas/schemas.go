@@ -0,0 +1,38 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Package schemas embeds the Verrazzano provided values schemas of the charts that do not have a values.schema.json,
+// so that install overrides can be validated where the charts are not available.
+package schemas
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+
+	"github.com/verrazzano/verrazzano/pkg/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/thirdparty/charts"
+)
+
+//go:embed *.schema.json
+var files embed.FS
+
+// GetValuesSchema returns the values schema of the chart of a Helm release, or the Verrazzano provided values schema
+// of the release when the chart does not have one.  Nil is returned if there is no schema.
+func GetValuesSchema(releaseName string, chartName string) (*helm.ValuesSchema, error) {
+	schema, values, err := charts.GetValuesSchema(chartName)
+	if err != nil {
+		return nil, err
+	}
+	if schema != nil {
+		return helm.NewValuesSchemaWithValues(chartName, values, schema)
+	}
+	schema, err = files.ReadFile(releaseName + ".schema.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return helm.NewValuesSchema(releaseName, schema), nil
+}
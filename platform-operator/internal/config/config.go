@@ -24,6 +24,7 @@ const (
 	helmKialiChartsDirSuffix     = "/platform-operator/thirdparty/charts/kiali-server"
 	helmOamChartsDirSuffix       = "/platform-operator/thirdparty/charts/oam-kubernetes-runtime"
 	helmOverridesDirSuffix       = "/platform-operator/helm_config/overrides"
+	helmSchemasDirSuffix         = "/platform-operator/helm_config/schemas"
 )
 
 const defaultBomFilename = "verrazzano-bom.json"
@@ -146,6 +147,14 @@ func GetHelmOverridesDir() string {
 	return filepath.Join(instance.VerrazzanoRootDir, helmOverridesDirSuffix)
 }
 
+// GetHelmSchemasDir returns the dir of the Verrazzano provided values schemas, for charts without a values.schema.json
+func GetHelmSchemasDir() string {
+	if TestHelmConfigDir != "" {
+		return filepath.Join(TestHelmConfigDir, "/schemas")
+	}
+	return filepath.Join(instance.VerrazzanoRootDir, helmSchemasDirSuffix)
+}
+
 // GetInstallDir returns the install dir
 func GetInstallDir() string {
 	return filepath.Join(instance.VerrazzanoRootDir, installDirSuffix)
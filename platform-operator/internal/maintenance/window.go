@@ -0,0 +1,60 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package maintenance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Window is a recurring maintenance window, defined by a cron schedule for the start of the window and a duration
+type Window struct {
+	schedule cron.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// NewWindow creates a maintenance window from a standard five field cron schedule, a duration, and an optional
+// IANA time zone name.  UTC is used if the time zone is empty.
+func NewWindow(schedule string, duration time.Duration, timeZone string) (*Window, error) {
+	if len(schedule) == 0 {
+		return nil, fmt.Errorf("The maintenance window schedule must be specified")
+	}
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("The maintenance window schedule %q is invalid: %v", schedule, err)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("The maintenance window duration %v must be greater than zero", duration)
+	}
+	location := time.UTC
+	if len(timeZone) > 0 {
+		location, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("The maintenance window time zone %q is invalid: %v", timeZone, err)
+		}
+	}
+	return &Window{
+		schedule: sched,
+		duration: duration,
+		location: location,
+	}, nil
+}
+
+// IsOpen returns true if the given time falls within a maintenance window
+func (w *Window) IsOpen(now time.Time) bool {
+	// The most recent window start that could still be open is the first start after (now - duration)
+	start := w.schedule.Next(now.In(w.location).Add(-w.duration))
+	return !start.After(now)
+}
+
+// NextOpen returns the given time if the window is open, otherwise the start time of the next window
+func (w *Window) NextOpen(now time.Time) time.Time {
+	if w.IsOpen(now) {
+		return now
+	}
+	return w.schedule.Next(now.In(w.location))
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewWindowInvalid tests the NewWindow function
+// GIVEN an invalid schedule, duration or time zone
+// WHEN NewWindow is called
+// THEN an error is returned
+func TestNewWindowInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		duration time.Duration
+		timeZone string
+	}{
+		{name: "empty schedule", schedule: "", duration: time.Hour},
+		{name: "bad schedule", schedule: "0 2 * *", duration: time.Hour},
+		{name: "zero duration", schedule: "0 2 * * SAT", duration: 0},
+		{name: "bad time zone", schedule: "0 2 * * SAT", duration: time.Hour, timeZone: "Not/AZone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWindow(tt.schedule, tt.duration, tt.timeZone)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestWindowIsOpen tests the IsOpen and NextOpen functions
+// GIVEN a weekly window starting Saturday at 02:00 UTC lasting 4 hours
+// WHEN the window is evaluated at various times
+// THEN the window is open only between 02:00 and 06:00 on Saturday
+func TestWindowIsOpen(t *testing.T) {
+	asserts := assert.New(t)
+	w, err := NewWindow("0 2 * * SAT", 4*time.Hour, "")
+	asserts.NoError(err)
+
+	// Saturday Oct 17, 2026
+	saturday := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	windowStart := saturday.Add(2 * time.Hour)
+
+	asserts.False(w.IsOpen(saturday.Add(time.Hour)))
+	asserts.Equal(windowStart, w.NextOpen(saturday.Add(time.Hour)))
+
+	asserts.True(w.IsOpen(windowStart))
+	asserts.True(w.IsOpen(windowStart.Add(3 * time.Hour)))
+	now := windowStart.Add(time.Hour)
+	asserts.Equal(now, w.NextOpen(now))
+
+	asserts.False(w.IsOpen(windowStart.Add(4 * time.Hour)))
+	asserts.Equal(windowStart.Add(7*24*time.Hour), w.NextOpen(windowStart.Add(4*time.Hour)))
+}
+
+// TestWindowTimeZone tests the IsOpen function with a time zone
+// GIVEN a daily window starting at 01:00 in the America/New_York time zone
+// WHEN the window is evaluated at 05:30 UTC (01:30 EDT)
+// THEN the window is open
+func TestWindowTimeZone(t *testing.T) {
+	asserts := assert.New(t)
+	w, err := NewWindow("0 1 * * *", time.Hour, "America/New_York")
+	asserts.NoError(err)
+
+	asserts.True(w.IsOpen(time.Date(2026, 10, 17, 5, 30, 0, 0, time.UTC)))
+	asserts.False(w.IsOpen(time.Date(2026, 10, 17, 1, 30, 0, 0, time.UTC)))
+}
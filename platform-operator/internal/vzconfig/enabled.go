@@ -178,14 +178,17 @@ func IsRancherEnabled(cr runtime.Object) bool {
 	return true
 }
 
-// IsExternalDNSEnabled Indicates if the external-dns service is expected to be deployed, true if OCI DNS is configured
+// IsExternalDNSEnabled Indicates if the external-dns service is expected to be deployed, true if OCI DNS or RFC2136 DNS
+// is configured
 func IsExternalDNSEnabled(cr runtime.Object) bool {
 	if vzv1alpha1, ok := cr.(*vzapi.Verrazzano); ok {
-		if vzv1alpha1 != nil && vzv1alpha1.Spec.Components.DNS != nil && vzv1alpha1.Spec.Components.DNS.OCI != nil {
+		if vzv1alpha1 != nil && vzv1alpha1.Spec.Components.DNS != nil &&
+			(vzv1alpha1.Spec.Components.DNS.OCI != nil || vzv1alpha1.Spec.Components.DNS.RFC2136 != nil) {
 			return true
 		}
 	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
-		if vzv1beta1 != nil && vzv1beta1.Spec.Components.DNS != nil && vzv1beta1.Spec.Components.DNS.OCI != nil {
+		if vzv1beta1 != nil && vzv1beta1.Spec.Components.DNS != nil &&
+			(vzv1beta1.Spec.Components.DNS.OCI != nil || vzv1beta1.Spec.Components.DNS.RFC2136 != nil) {
 			return true
 		}
 	}
@@ -293,6 +296,19 @@ func IsAuthProxyEnabled(cr runtime.Object) bool {
 	return true
 }
 
+// IsExternalOIDCEnabled returns true if the AuthProxy is enabled and configured to use an external OIDC provider
+func IsExternalOIDCEnabled(cr runtime.Object) bool {
+	if !IsAuthProxyEnabled(cr) {
+		return false
+	}
+	if vzv1alpha1, ok := cr.(*vzapi.Verrazzano); ok {
+		return vzv1alpha1 != nil && vzv1alpha1.Spec.Components.AuthProxy != nil && vzv1alpha1.Spec.Components.AuthProxy.OIDC != nil
+	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
+		return vzv1beta1 != nil && vzv1beta1.Spec.Components.AuthProxy != nil && vzv1beta1.Spec.Components.AuthProxy.OIDC != nil
+	}
+	return false
+}
+
 // IsApplicationOperatorEnabled returns false only if Application Operator is explicitly disabled in the CR
 func IsApplicationOperatorEnabled(cr runtime.Object) bool {
 	if vzv1alpha1, ok := cr.(*vzapi.Verrazzano); ok {
@@ -418,3 +434,17 @@ func IsRancherBackupEnabled(cr runtime.Object) bool {
 	}
 	return false
 }
+
+//IsHighAvailabilityEnabled returns false unless the high availability mode is explicitly enabled in the CR
+func IsHighAvailabilityEnabled(cr runtime.Object) bool {
+	if vzv1alpha1, ok := cr.(*vzapi.Verrazzano); ok {
+		if vzv1alpha1 != nil && vzv1alpha1.Spec.HighAvailability != nil && vzv1alpha1.Spec.HighAvailability.Enabled != nil {
+			return *vzv1alpha1.Spec.HighAvailability.Enabled
+		}
+	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
+		if vzv1beta1 != nil && vzv1beta1.Spec.HighAvailability != nil && vzv1beta1.Spec.HighAvailability.Enabled != nil {
+			return *vzv1beta1.Spec.HighAvailability.Enabled
+		}
+	}
+	return false
+}
@@ -46,6 +46,26 @@ func TestIsExternalDNSEnabledOCIDNS(t *testing.T) {
 	assert.True(t, IsExternalDNSEnabled(vz))
 }
 
+// TestIsExternalDNSEnabledRFC2136DNS tests the IsExternalDNSEnabled function
+// GIVEN a call to IsExternalDNSEnabled
+//  WHEN the VZ config has RFC2136 DNS configured
+//  THEN true is returned
+func TestIsExternalDNSEnabledRFC2136DNS(t *testing.T) {
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			EnvironmentName: "myenv",
+			Components: vzapi.ComponentSpec{
+				DNS: &vzapi.DNSComponent{
+					RFC2136: &vzapi.RFC2136{
+						DNSZoneName: "mydomain.com",
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, IsExternalDNSEnabled(vz))
+}
+
 // TestIsExternalDNSEnabledWildcardDNS tests the IsExternalDNSEnabled function
 // GIVEN a call to IsExternalDNSEnabled
 //  WHEN the VZ config has Wildcard DNS explicitly configured
@@ -151,6 +171,40 @@ func TestIsKeycloakEnabled(t *testing.T) {
 		}}))
 }
 
+// TestIsExternalOIDCEnabled tests the IsExternalOIDCEnabled function
+// GIVEN a call to IsExternalOIDCEnabled
+//  THEN true is returned only if the AuthProxy is enabled and configures an external OIDC provider
+func TestIsExternalOIDCEnabled(t *testing.T) {
+	asserts := assert.New(t)
+	asserts.False(IsExternalOIDCEnabled(nil))
+	asserts.False(IsExternalOIDCEnabled(&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{}}))
+	asserts.True(IsExternalOIDCEnabled(
+		&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				AuthProxy: &vzapi.AuthProxyComponent{
+					OIDC: &vzapi.AuthProxyOIDC{IssuerURL: "https://idp.example.com"},
+				},
+			},
+		}}))
+	asserts.False(IsExternalOIDCEnabled(
+		&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				AuthProxy: &vzapi.AuthProxyComponent{
+					Enabled: &falseValue,
+					OIDC:    &vzapi.AuthProxyOIDC{IssuerURL: "https://idp.example.com"},
+				},
+			},
+		}}))
+	asserts.True(IsExternalOIDCEnabled(
+		&installv1beta1.Verrazzano{Spec: installv1beta1.VerrazzanoSpec{
+			Components: installv1beta1.ComponentSpec{
+				AuthProxy: &installv1beta1.AuthProxyComponent{
+					OIDC: &installv1beta1.AuthProxyOIDC{IssuerURL: "https://idp.example.com"},
+				},
+			},
+		}}))
+}
+
 // TestIsConsoleEnabled tests the IsConsoleEnabled function
 // GIVEN a call to IsConsoleEnabled
 //  THEN the value of the Enabled flag is returned if present, true otherwise (enabled by default)
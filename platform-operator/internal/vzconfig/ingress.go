@@ -4,6 +4,8 @@ package vzconfig
 
 import (
 	"fmt"
+	"net"
+	"regexp"
 
 	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
@@ -16,6 +18,13 @@ import (
 
 const defaultWildcardDomain = "nip.io"
 const defaultIngressClassName = "verrazzano-nginx"
+const defaultSystemIngressBodySize = "6M"
+
+// hstsPreloadMinMaxAge is the lowest max-age accepted by the HSTS preload list
+const hstsPreloadMinMaxAge = 31536000
+
+var opensslCipherPattern = regexp.MustCompile(`^[A-Za-z0-9_+-]+$`)
+var nginxSizePattern = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
 
 // GetEnvName Returns the configured environment name, or "default" if not specified in the configuration
 func GetEnvName(vz *vzapi.Verrazzano) string {
@@ -57,17 +66,32 @@ func GetDNSSuffix(client client.Client, vz *vzapi.Verrazzano) (string, error) {
 			return "", err
 		}
 		dnsSuffix = fmt.Sprintf("%s.%s", ingressIP, GetWildcardDomain(dnsConfig))
-	} else if dnsConfig.OCI != nil {
-		dnsSuffix = dnsConfig.OCI.DNSZoneName
+	} else if dnsConfig.OCI != nil || dnsConfig.RFC2136 != nil {
+		dnsSuffix = GetExternalDNSZoneName(dnsConfig)
 	} else if dnsConfig.External != nil {
 		dnsSuffix = dnsConfig.External.Suffix
 	}
 	if len(dnsSuffix) == 0 {
-		return "", fmt.Errorf("Invalid OCI DNS configuration, no zone name specified")
+		return "", fmt.Errorf("Invalid DNS configuration, no zone name specified")
 	}
 	return dnsSuffix, nil
 }
 
+// GetExternalDNSZoneName Returns the name of the DNS zone managed by external-dns, empty if neither OCI DNS nor RFC2136
+// DNS is configured
+func GetExternalDNSZoneName(dnsConfig *vzapi.DNSComponent) string {
+	if dnsConfig == nil {
+		return ""
+	}
+	if dnsConfig.OCI != nil {
+		return dnsConfig.OCI.DNSZoneName
+	}
+	if dnsConfig.RFC2136 != nil {
+		return dnsConfig.RFC2136.DNSZoneName
+	}
+	return ""
+}
+
 // Identify the service type, LB vs NodePort
 func GetIngressServiceType(cr *vzapi.Verrazzano) (vzapi.IngressType, error) {
 	ingressConfig := cr.Spec.Components.Ingress
@@ -112,6 +136,140 @@ func GetIngressClassName(vz *vzapi.Verrazzano) string {
 	return defaultIngressClassName
 }
 
+// GetIngressSecurity returns the security settings of the NGINX ingress controller, nil if none are set
+func GetIngressSecurity(vz *vzapi.Verrazzano) *vzapi.IngressSecuritySpec {
+	if vz.Spec.Components.Ingress == nil {
+		return nil
+	}
+	return vz.Spec.Components.Ingress.Security
+}
+
+// GetSystemIngressBodySize returns the largest request body accepted by the Verrazzano system ingresses, which is the
+// client body size of the ingress controller when it is set
+func GetSystemIngressBodySize(vz *vzapi.Verrazzano) string {
+	security := GetIngressSecurity(vz)
+	if security != nil && len(security.ClientBodySize) > 0 {
+		return security.ClientBodySize
+	}
+	return defaultSystemIngressBodySize
+}
+
+// IsIngressHSTSConfigured returns true if the ingress controller returns the HSTS header, in which case the Verrazzano
+// system ingresses must not add their own
+func IsIngressHSTSConfigured(vz *vzapi.Verrazzano) bool {
+	security := GetIngressSecurity(vz)
+	return security != nil && security.HSTS != nil
+}
+
+// ValidateIngressSecurity validates the TLS policy, HSTS, ModSecurity, rate limits and client body size of the NGINX
+// ingress controller
+func ValidateIngressSecurity(vz *v1beta1.Verrazzano) error {
+	ingress := vz.Spec.Components.IngressNGINX
+	if ingress == nil || ingress.Security == nil {
+		return nil
+	}
+	security := ingress.Security
+	if err := validateIngressTLS(security.TLS); err != nil {
+		return err
+	}
+	if err := validateHSTS(security.HSTS); err != nil {
+		return err
+	}
+	if err := validateModSecurity(security.ModSecurity); err != nil {
+		return err
+	}
+	if err := validateRateLimit(security.RateLimit); err != nil {
+		return err
+	}
+	if len(security.ClientBodySize) > 0 && !nginxSizePattern.MatchString(security.ClientBodySize) {
+		return fmt.Errorf("Invalid ingress client body size %s, expected a number of bytes optionally followed by k, m or g", security.ClientBodySize)
+	}
+	return nil
+}
+
+func validateIngressTLS(tls *v1beta1.IngressTLSSpec) error {
+	if tls == nil {
+		return nil
+	}
+	switch tls.MinVersion {
+	case "", "TLSv1.2":
+	case "TLSv1.3":
+		if len(tls.Ciphers) > 0 {
+			return fmt.Errorf("Ingress TLS ciphers only apply to TLSv1.2, they can not be set when the minimum version is TLSv1.3")
+		}
+	default:
+		return fmt.Errorf("Invalid ingress TLS minimum version %s, expected TLSv1.2 or TLSv1.3", tls.MinVersion)
+	}
+	for _, cipher := range tls.Ciphers {
+		if !opensslCipherPattern.MatchString(cipher) {
+			return fmt.Errorf("Invalid ingress TLS cipher %q, expected an OpenSSL cipher suite name", cipher)
+		}
+	}
+	return nil
+}
+
+func validateHSTS(hsts *v1beta1.HSTSSpec) error {
+	if hsts == nil || !hsts.Preload {
+		return nil
+	}
+	if hsts.Enabled != nil && !*hsts.Enabled {
+		return fmt.Errorf("Ingress HSTS preload requires HSTS to be enabled")
+	}
+	if hsts.IncludeSubdomains != nil && !*hsts.IncludeSubdomains {
+		return fmt.Errorf("Ingress HSTS preload requires the policy to include the subdomains")
+	}
+	if hsts.MaxAge != nil && *hsts.MaxAge < hstsPreloadMinMaxAge {
+		return fmt.Errorf("Ingress HSTS preload requires a max age of at least %d seconds", hstsPreloadMinMaxAge)
+	}
+	return nil
+}
+
+func validateModSecurity(modSecurity *v1beta1.ModSecuritySpec) error {
+	if modSecurity == nil {
+		return nil
+	}
+	switch modSecurity.Mode {
+	case "", v1beta1.ModSecurityDetectionOnly, v1beta1.ModSecurityOn:
+	default:
+		return fmt.Errorf("Invalid ModSecurity mode %s, expected %s or %s", modSecurity.Mode, v1beta1.ModSecurityDetectionOnly, v1beta1.ModSecurityOn)
+	}
+	if !modSecurity.Enabled && (modSecurity.OWASPCoreRuleSet || len(modSecurity.Mode) > 0) {
+		return fmt.Errorf("The ModSecurity rule set and mode require ModSecurity to be enabled")
+	}
+	return nil
+}
+
+func validateRateLimit(rateLimit *v1beta1.RateLimitSpec) error {
+	if rateLimit == nil {
+		return nil
+	}
+	if rateLimit.RequestsPerSecond < 0 || rateLimit.Burst < 0 || rateLimit.Connections < 0 {
+		return fmt.Errorf("Ingress rate limits can not be negative")
+	}
+	if rateLimit.RequestsPerSecond == 0 && rateLimit.Connections == 0 {
+		return fmt.Errorf("Ingress rate limit requires requestsPerSecond or connections")
+	}
+	if rateLimit.Burst > 0 && rateLimit.RequestsPerSecond == 0 {
+		return fmt.Errorf("Ingress rate limit burst requires requestsPerSecond")
+	}
+	for _, cidr := range rateLimit.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("Invalid ingress rate limit allowed CIDR %s: %v", cidr, err)
+		}
+	}
+	return nil
+}
+
+// GetClusterIssuerName returns the name of the ClusterIssuer that issues the Verrazzano certificates, which is either an
+// existing ClusterIssuer referenced in the certificate configuration or the ClusterIssuer created by Verrazzano
+func GetClusterIssuerName(vz *vzapi.Verrazzano) string {
+	certManager := vz.Spec.Components.CertManager
+	if certManager != nil && len(certManager.Certificate.IssuerRef.Name) > 0 {
+		return certManager.Certificate.IssuerRef.Name
+	}
+	return globalconst.VerrazzanoClusterIssuerName
+}
+
 // getVolumeClaimSpecTemplates returns the volume claim specs in v1beta1.
 func getVolumeClaimSpecTemplates(object runtime.Object) []v1beta1.VolumeClaimSpecTemplate {
 	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
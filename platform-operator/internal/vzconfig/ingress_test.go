@@ -9,6 +9,7 @@ import (
 
 	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	vpoconst "github.com/verrazzano/verrazzano/platform-operator/constants"
 
 	"github.com/stretchr/testify/assert"
@@ -201,6 +202,7 @@ func TestGetDNSSuffix(t *testing.T) {
 		name              string
 		serviceType       vzapi.IngressType
 		dnsOCIZone        string
+		dnsRFC2136Zone    string
 		dnsExternalSuffix string
 		dnsWildCardSuffix string
 		lbIP              string
@@ -214,6 +216,12 @@ func TestGetDNSSuffix(t *testing.T) {
 			dnsOCIZone:  testDomain,
 			want:        testDomain,
 		},
+		{
+			name:           "lb with rfc2136 dns",
+			serviceType:    vzapi.LoadBalancer,
+			dnsRFC2136Zone: testDomain,
+			want:           testDomain,
+		},
 		{
 			name:              "lb with external dns",
 			serviceType:       vzapi.LoadBalancer,
@@ -309,6 +317,12 @@ func TestGetDNSSuffix(t *testing.T) {
 						DNSZoneName: testDomain,
 					},
 				}
+			} else if len(tt.dnsRFC2136Zone) > 0 {
+				vz.Spec.Components.DNS = &vzapi.DNSComponent{
+					RFC2136: &vzapi.RFC2136{
+						DNSZoneName: tt.dnsRFC2136Zone,
+					},
+				}
 			} else if len(tt.dnsExternalSuffix) > 0 {
 				vz.Spec.Components.DNS = &vzapi.DNSComponent{
 					External: &vzapi.External{
@@ -499,3 +513,97 @@ func TestGetIngressClassName(t *testing.T) {
 		},
 	}))
 }
+
+// TestGetClusterIssuerName Tests the GetClusterIssuerName utility function
+// GIVEN a call to GetClusterIssuerName
+// WHEN a Verrazzano resource with an issuer reference is given
+// THEN the referenced ClusterIssuer name is returned, otherwise the Verrazzano ClusterIssuer name is returned
+func TestGetClusterIssuerName(t *testing.T) {
+	assert.Equal(t, globalconst.VerrazzanoClusterIssuerName, GetClusterIssuerName(&vzapi.Verrazzano{}))
+	assert.Equal(t, "vault-issuer", GetClusterIssuerName(&vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				CertManager: &vzapi.CertManagerComponent{
+					Certificate: vzapi.Certificate{
+						IssuerRef: vzapi.IssuerRef{Name: "vault-issuer"},
+					},
+				},
+			},
+		},
+	}))
+}
+
+// TestGetSystemIngressBodySize tests the GetSystemIngressBodySize and IsIngressHSTSConfigured functions
+// GIVEN Verrazzano resources with and without the security settings of the ingress controller
+// WHEN GetSystemIngressBodySize and IsIngressHSTSConfigured are called
+// THEN the system ingresses follow the client body size and the HSTS header of the ingress controller
+func TestGetSystemIngressBodySize(t *testing.T) {
+	vz := &vzapi.Verrazzano{}
+	assert.Equal(t, "6M", GetSystemIngressBodySize(vz))
+	assert.False(t, IsIngressHSTSConfigured(vz))
+
+	vz.Spec.Components.Ingress = &vzapi.IngressNginxComponent{
+		Security: &vzapi.IngressSecuritySpec{ClientBodySize: "16m", HSTS: &vzapi.HSTSSpec{}},
+	}
+	assert.Equal(t, "16m", GetSystemIngressBodySize(vz))
+	assert.True(t, IsIngressHSTSConfigured(vz))
+}
+
+// TestValidateIngressSecurity tests the ValidateIngressSecurity function
+// GIVEN Verrazzano resources with valid and invalid security settings of the ingress controller
+// WHEN ValidateIngressSecurity is called
+// THEN an error is returned for the invalid settings
+func TestValidateIngressSecurity(t *testing.T) {
+	newVZ := func(security *v1beta1.IngressSecuritySpec) *v1beta1.Verrazzano {
+		return &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{
+			IngressNGINX: &v1beta1.IngressNginxComponent{Security: security},
+		}}}
+	}
+	shortMaxAge := int64(86400)
+	longMaxAge := int64(63072000)
+	falseValue := false
+	tests := []struct {
+		name     string
+		security *v1beta1.IngressSecuritySpec
+		wantErr  bool
+	}{
+		{"no settings", nil, false},
+		{"all settings", &v1beta1.IngressSecuritySpec{
+			TLS:            &v1beta1.IngressTLSSpec{MinVersion: "TLSv1.2", Ciphers: []string{"ECDHE-RSA-AES256-GCM-SHA384"}},
+			HSTS:           &v1beta1.HSTSSpec{MaxAge: &longMaxAge, Preload: true},
+			ModSecurity:    &v1beta1.ModSecuritySpec{Enabled: true, OWASPCoreRuleSet: true, Mode: v1beta1.ModSecurityOn},
+			RateLimit:      &v1beta1.RateLimitSpec{RequestsPerSecond: 10, Burst: 20, AllowedCIDRs: []string{"192.168.0.0/16"}},
+			ClientBodySize: "8m",
+		}, false},
+		{"invalid TLS version", &v1beta1.IngressSecuritySpec{TLS: &v1beta1.IngressTLSSpec{MinVersion: "TLSv1.1"}}, true},
+		{"ciphers with TLSv1.3", &v1beta1.IngressSecuritySpec{
+			TLS: &v1beta1.IngressTLSSpec{MinVersion: "TLSv1.3", Ciphers: []string{"ECDHE-RSA-AES256-GCM-SHA384"}}}, true},
+		{"invalid cipher", &v1beta1.IngressSecuritySpec{
+			TLS: &v1beta1.IngressTLSSpec{Ciphers: []string{"ECDHE-RSA-AES256-GCM-SHA384:!aNULL"}}}, true},
+		{"preload with short max age", &v1beta1.IngressSecuritySpec{HSTS: &v1beta1.HSTSSpec{MaxAge: &shortMaxAge, Preload: true}}, true},
+		{"preload without subdomains", &v1beta1.IngressSecuritySpec{
+			HSTS: &v1beta1.HSTSSpec{IncludeSubdomains: &falseValue, Preload: true}}, true},
+		{"preload with HSTS disabled", &v1beta1.IngressSecuritySpec{HSTS: &v1beta1.HSTSSpec{Enabled: &falseValue, Preload: true}}, true},
+		{"invalid ModSecurity mode", &v1beta1.IngressSecuritySpec{
+			ModSecurity: &v1beta1.ModSecuritySpec{Enabled: true, Mode: "Block"}}, true},
+		{"rule set without ModSecurity", &v1beta1.IngressSecuritySpec{
+			ModSecurity: &v1beta1.ModSecuritySpec{OWASPCoreRuleSet: true}}, true},
+		{"empty rate limit", &v1beta1.IngressSecuritySpec{RateLimit: &v1beta1.RateLimitSpec{}}, true},
+		{"negative rate limit", &v1beta1.IngressSecuritySpec{RateLimit: &v1beta1.RateLimitSpec{Connections: -1}}, true},
+		{"burst without rate", &v1beta1.IngressSecuritySpec{RateLimit: &v1beta1.RateLimitSpec{Connections: 10, Burst: 5}}, true},
+		{"invalid allowed CIDR", &v1beta1.IngressSecuritySpec{
+			RateLimit: &v1beta1.RateLimitSpec{RequestsPerSecond: 10, AllowedCIDRs: []string{"10.0.0.1"}}}, true},
+		{"invalid client body size", &v1beta1.IngressSecuritySpec{ClientBodySize: "8MB"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIngressSecurity(newVZ(tt.security))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+	assert.NoError(t, ValidateIngressSecurity(&v1beta1.Verrazzano{}))
+}
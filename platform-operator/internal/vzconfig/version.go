@@ -0,0 +1,27 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vzconfig
+
+import (
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
+)
+
+// GetVersion Returns the requested Verrazzano version, or the version in the BOM if not specified in the configuration,
+// without a leading "v"
+func GetVersion(vz *vzapi.Verrazzano) (string, error) {
+	if len(vz.Spec.Version) > 0 {
+		version, err := semver.NewSemVersion(vz.Spec.Version)
+		if err != nil {
+			return "", err
+		}
+		return version.ToString(), nil
+	}
+	bomVersion, err := validators.GetCurrentBomVersion()
+	if err != nil {
+		return "", err
+	}
+	return bomVersion.ToString(), nil
+}
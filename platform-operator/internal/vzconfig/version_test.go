@@ -0,0 +1,32 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vzconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+// TestGetVersion tests the GetVersion function
+// GIVEN a Verrazzano resource with and without a version
+// WHEN GetVersion is called
+// THEN the requested version is returned, or the BOM version if no version is requested
+func TestGetVersion(t *testing.T) {
+	config.SetDefaultBomFilePath("../../verrazzano-bom.json")
+	defer config.SetDefaultBomFilePath("")
+
+	version, err := GetVersion(&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{Version: "v1.4.0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0", version)
+
+	version, err = GetVersion(&vzapi.Verrazzano{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, version)
+
+	_, err = GetVersion(&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{Version: "not-a-version"}})
+	assert.Error(t, err)
+}
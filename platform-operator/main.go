@@ -33,12 +33,15 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	vzlog "github.com/verrazzano/verrazzano/pkg/log"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/clusters/v1alpha1"
+	componentsv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/components/v1alpha1"
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	clusterscontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/clusters"
+	componentscontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/components"
 	configmapcontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps"
 	secretscontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/secrets"
 	vzcontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano"
+	helmcomp "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/validator"
 	internalconfig "github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/certificate"
@@ -55,6 +58,7 @@ func init() {
 	_ = installv1alpha1.AddToScheme(scheme)
 	_ = installv1beta1.AddToScheme(scheme)
 	_ = clustersv1alpha1.AddToScheme(scheme)
+	_ = componentsv1alpha1.AddToScheme(scheme)
 
 	_ = istioclinet.AddToScheme(scheme)
 	_ = istioclisec.AddToScheme(scheme)
@@ -97,7 +101,7 @@ func main() {
 	flag.StringVar(&config.VerrazzanoRootDir, "vz-root-dir", config.VerrazzanoRootDir,
 		"Specify the root directory of Verrazzano (used for development)")
 	flag.StringVar(&bomOverride, "bom-path", "", "BOM file location")
-	flag.BoolVar(&helm.Debug, "helm-debug", helm.Debug, "Add the --debug flag to helm commands")
+	flag.BoolVar(&helm.Debug, "helm-debug", helm.Debug, "Log Helm debug output")
 
 	// Add the zap logger flag set to the CLI.
 	opts := kzap.Options{}
@@ -225,6 +229,8 @@ func main() {
 			os.Exit(1)
 		}
 		mgr.GetWebhookServer().CertDir = config.CertDir
+		// The component validators read override ConfigMaps and Secrets using the manager client
+		helmcomp.SetValidationClient(mgr.GetClient())
 	}
 
 	// Setup the reconciler for VerrazzanoManagedCluster objects
@@ -264,6 +270,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup VerrazzanoComponent reconciler
+	if err = (&componentscontroller.VerrazzanoComponentReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		DryRun: config.DryRun,
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to setup controller", vzlog.FieldController, "VerrazzanoComponent")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	log.Info("Starting controller-runtime manager")
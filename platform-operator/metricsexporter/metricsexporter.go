@@ -25,6 +25,7 @@ type data struct {
 	simpleGaugeMetricMap   map[metricName]*SimpleGaugeMetric
 	durationMetricMap      map[metricName]*DurationMetric
 	metricsComponentMap    map[metricName]*MetricsComponent
+	certificateExpiry      *prometheus.GaugeVec
 }
 type SimpleCounterMetric struct {
 	metric prometheus.Counter
@@ -268,3 +268,30 @@ func TestAnalyzeVerrazzanoResourceMetrics(t *testing.T) {
 		})
 	}
 }
+
+// TestAnalyzeCertificateMetrics tests the AnalyzeCertificateMetrics fn
+// GIVEN a call to AnalyzeCertificateMetrics
+// WHEN a VZ CR with the expiry of the Verrazzano certificates in its status is passed to the fn
+// THEN the expiry time of each certificate is set and the certificates that are no longer recorded are removed
+func TestAnalyzeCertificateMetrics(t *testing.T) {
+	assert := asserts.New(t)
+	vz := installv1alpha1.Verrazzano{
+		Status: installv1alpha1.VerrazzanoStatus{
+			Certificates: &installv1alpha1.CertificatesStatus{
+				Expiry: []installv1alpha1.CertificateExpiryStatus{
+					{Namespace: "cert-manager", SecretName: "verrazzano-ca-certificate-secret", CA: true, NotAfter: componentFirstTime},
+					{Namespace: "verrazzano-system", SecretName: "verrazzano-tls", NotAfter: componentSecondTime},
+					{Namespace: "verrazzano-system", SecretName: "invalid", NotAfter: "not a time"},
+				},
+			},
+		},
+	}
+	metric := MetricsExp.internalData.certificateExpiry
+	AnalyzeCertificateMetrics(vzlog.DefaultLogger(), vz)
+	assert.Equal(2, testutil.CollectAndCount(metric))
+	assert.Equal(float64(1657115699), testutil.ToFloat64(metric.WithLabelValues("cert-manager", "verrazzano-ca-certificate-secret", "true")))
+	assert.Equal(float64(1657115745), testutil.ToFloat64(metric.WithLabelValues("verrazzano-system", "verrazzano-tls", "false")))
+
+	AnalyzeCertificateMetrics(vzlog.DefaultLogger(), installv1alpha1.Verrazzano{})
+	assert.Equal(0, testutil.CollectAndCount(metric))
+}
@@ -6,6 +6,7 @@ package metricsexporter
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -96,6 +97,7 @@ func RequiredInitialization() {
 			simpleGaugeMetricMap:   initSimpleGaugeMetricMap(),
 			durationMetricMap:      initDurationMetricMap(),
 			metricsComponentMap:    initMetricComponentMap(),
+			certificateExpiry:      initCertificateExpiryMetric(),
 		},
 	}
 
@@ -182,6 +184,15 @@ func initSimpleGaugeMetricMap() map[metricName]*SimpleGaugeMetric {
 	return map[metricName]*SimpleGaugeMetric{}
 }
 
+// This function initalizes the gauge of the expiry time of the Verrazzano certificates, labelled by the namespace and
+// the name of the secret of each certificate and whether it is the Verrazzano CA
+func initCertificateExpiryMetric() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vz_certificate_expiration_timestamp_seconds",
+		Help: "The expiry time of the Verrazzano CA and of the certificates issued for Verrazzano, in seconds since the epoch",
+	}, []string{"namespace", "secret", "ca"})
+}
+
 // This function initalizes the durationMetricMap for the metricsExporter object
 func initDurationMetricMap() map[metricName]*DurationMetric {
 	return map[metricName]*DurationMetric{
@@ -310,6 +321,24 @@ func AnalyzeVerrazzanoResourceMetrics(log vzlog.VerrazzanoLogger, cr vzapi.Verra
 	}
 }
 
+// This function sets the expiry time of each certificate recorded in the VZ CR status, the certificates that are no
+// longer recorded are removed from the metric
+func AnalyzeCertificateMetrics(log vzlog.VerrazzanoLogger, cr vzapi.Verrazzano) {
+	metric := MetricsExp.internalData.certificateExpiry
+	metric.Reset()
+	if cr.Status.Certificates == nil {
+		return
+	}
+	for _, expiry := range cr.Status.Certificates.Expiry {
+		notAfter, err := time.Parse(time.RFC3339, expiry.NotAfter)
+		if err != nil {
+			log.ErrorfThrottled("Failed to parse the expiry time of the certificate %s/%s: %v", expiry.Namespace, expiry.SecretName, err)
+			continue
+		}
+		metric.WithLabelValues(expiry.Namespace, expiry.SecretName, strconv.FormatBool(expiry.CA)).Set(float64(notAfter.Unix()))
+	}
+}
+
 // This function initalizes the allMetrics array
 func InitializeAllMetricsArray() {
 	//loop through all metrics declarations in metric maps
@@ -322,6 +351,7 @@ func InitializeAllMetricsArray() {
 	for _, value := range MetricsExp.internalData.metricsComponentMap {
 		MetricsExp.internalConfig.allMetrics = append(MetricsExp.internalConfig.allMetrics, value.latestInstallDuration.metric, value.latestUpgradeDuration.metric)
 	}
+	MetricsExp.internalConfig.allMetrics = append(MetricsExp.internalConfig.allMetrics, MetricsExp.internalData.certificateExpiry)
 }
 
 // This function returns an empty struct of type configuration
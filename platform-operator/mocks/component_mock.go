@@ -305,6 +305,20 @@ func (mr *MockComponentInfoMockRecorder) GetMinVerrazzanoVersion() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinVerrazzanoVersion", reflect.TypeOf((*MockComponentInfo)(nil).GetMinVerrazzanoVersion))
 }
 
+// GetNetworkPolicyRules mocks base method.
+func (m *MockComponentInfo) GetNetworkPolicyRules(arg0 spi.ComponentContext) []spi.NetworkPolicyRules {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkPolicyRules", arg0)
+	ret0, _ := ret[0].([]spi.NetworkPolicyRules)
+	return ret0
+}
+
+// GetNetworkPolicyRules indicates an expected call of GetNetworkPolicyRules.
+func (mr *MockComponentInfoMockRecorder) GetNetworkPolicyRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkPolicyRules", reflect.TypeOf((*MockComponentInfo)(nil).GetNetworkPolicyRules), arg0)
+}
+
 // GetOverrides mocks base method.
 func (m *MockComponentInfo) GetOverrides(arg0 runtime.Object) interface{} {
 	m.ctrl.T.Helper()
@@ -655,6 +669,20 @@ func (mr *MockComponentMockRecorder) GetMinVerrazzanoVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinVerrazzanoVersion", reflect.TypeOf((*MockComponent)(nil).GetMinVerrazzanoVersion))
 }
 
+// GetNetworkPolicyRules mocks base method.
+func (m *MockComponent) GetNetworkPolicyRules(arg0 spi.ComponentContext) []spi.NetworkPolicyRules {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkPolicyRules", arg0)
+	ret0, _ := ret[0].([]spi.NetworkPolicyRules)
+	return ret0
+}
+
+// GetNetworkPolicyRules indicates an expected call of GetNetworkPolicyRules.
+func (mr *MockComponentMockRecorder) GetNetworkPolicyRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkPolicyRules", reflect.TypeOf((*MockComponent)(nil).GetNetworkPolicyRules), arg0)
+}
+
 // GetOverrides mocks base method.
 func (m *MockComponent) GetOverrides(arg0 runtime.Object) interface{} {
 	m.ctrl.T.Helper()
@@ -0,0 +1,33 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Package charts embeds the default values and the values schemas of the third party charts, so that install
+// overrides can be validated where the charts are not available.
+package charts
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"path"
+)
+
+//go:embed */values.yaml */values.schema.json
+var files embed.FS
+
+// GetValuesSchema returns the values schema and the default values of a chart, the schema is nil if the chart does
+// not have one
+func GetValuesSchema(chartName string) ([]byte, []byte, error) {
+	schema, err := files.ReadFile(path.Join(chartName, "values.schema.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := files.ReadFile(path.Join(chartName, "values.yaml"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+	return schema, values, nil
+}
@@ -0,0 +1,71 @@
+#!/bin/bash
+#
+# Copyright (c) 2022, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+#
+# Starts a BIND name server in a container on the KIND network, that accepts RFC2136 dynamic updates signed with a TSIG
+# key, and creates the TSIG secret in the verrazzano-install namespace.
+#
+# Usage: create_rfc2136_dns_server.sh <zone name> [<TSIG secret name>] [<docker network>]
+
+set -e
+
+ZONE_NAME=$1
+TSIG_SECRET_NAME=${2:-"rfc2136-tsig"}
+DOCKER_NETWORK=${3:-"kind"}
+CONTAINER_NAME=${CONTAINER_NAME:-"verrazzano-bind"}
+BIND_IMAGE=${BIND_IMAGE:-"internetsystemsconsortium/bind9:9.18"}
+TSIG_KEY_NAME=${TSIG_KEY_NAME:-"externaldns-key"}
+WORKSPACE=${WORKSPACE:-"."}
+
+if [ -z "${ZONE_NAME}" ]; then
+  echo "The DNS zone name must be specified"
+  exit 1
+fi
+
+BIND_DIR=${WORKSPACE}/bind
+mkdir -p ${BIND_DIR}
+TSIG_SECRET=$(openssl rand -base64 32)
+
+cat > ${BIND_DIR}/named.conf <<EOF
+key "${TSIG_KEY_NAME}" {
+  algorithm hmac-sha256;
+  secret "${TSIG_SECRET}";
+};
+options {
+  directory "/var/cache/bind";
+  listen-on { any; };
+  allow-query { any; };
+  recursion no;
+};
+zone "${ZONE_NAME}" {
+  type primary;
+  file "/var/lib/bind/${ZONE_NAME}.zone";
+  allow-update { key "${TSIG_KEY_NAME}"; };
+  allow-transfer { key "${TSIG_KEY_NAME}"; };
+};
+EOF
+
+cat > ${BIND_DIR}/${ZONE_NAME}.zone <<EOF
+\$TTL 60
+@ IN SOA ns.${ZONE_NAME}. admin.${ZONE_NAME}. ( 1 60 60 3600 60 )
+@ IN NS ns.${ZONE_NAME}.
+ns IN A 127.0.0.1
+EOF
+chmod -R a+rwX ${BIND_DIR}
+
+docker rm -f ${CONTAINER_NAME} > /dev/null 2>&1 || true
+docker run -d --name ${CONTAINER_NAME} --network ${DOCKER_NETWORK} \
+  -v ${BIND_DIR}/named.conf:/etc/bind/named.conf \
+  -v ${BIND_DIR}:/var/lib/bind \
+  ${BIND_IMAGE}
+
+BIND_ADDRESS=$(docker inspect -f "{{ (index .NetworkSettings.Networks \"${DOCKER_NETWORK}\").IPAddress }}" ${CONTAINER_NAME})
+echo "BIND name server for zone ${ZONE_NAME} is listening on ${BIND_ADDRESS}:53"
+
+kubectl create namespace verrazzano-install --dry-run=client -o yaml | kubectl apply -f -
+kubectl create secret generic -n verrazzano-install ${TSIG_SECRET_NAME} --from-literal=secret="${TSIG_SECRET}" \
+  --dry-run=client -o yaml | kubectl apply -f -
+
+# Export the name server address for the install config
+echo "${BIND_ADDRESS}" > ${WORKSPACE}/rfc2136_dns_host
@@ -0,0 +1,17 @@
+#!/bin/bash
+
+# Copyright (c) 2022, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+INSTALL_CONFIG_TO_EDIT=$1
+DNS_HOST=${2:-$(cat ${WORKSPACE:-"."}/rfc2136_dns_host)}
+TSIG_SECRET_NAME=${3:-"rfc2136-tsig"}
+TSIG_KEY_NAME=${TSIG_KEY_NAME:-"externaldns-key"}
+echo "Editing install config file for RFC2136 DNS ${INSTALL_CONFIG_TO_EDIT}"
+yq -i eval ".spec.environmentName = \"${VZ_ENVIRONMENT_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.profile = \"${INSTALL_PROFILE}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.host = \"${DNS_HOST}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.dnsZoneName = \"${RFC2136_DNS_ZONE_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.tsigKeyName = \"${TSIG_KEY_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.tsigSecret = \"${TSIG_SECRET_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+cat ${INSTALL_CONFIG_TO_EDIT}
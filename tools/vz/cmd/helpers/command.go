@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"k8s.io/apimachinery/pkg/api/errors"
 )
 
 // NewCommand - utility method to create cobra commands
@@ -86,3 +87,9 @@ func GetOperatorFile(cmd *cobra.Command) (string, error) {
 	}
 	return operatorFile, nil
 }
+
+// IsValidationError returns true if the Verrazzano resource was rejected by the validating webhook, for example
+// because install overrides do not match the values schema of a chart.  Retrying the request will not help.
+func IsValidationError(err error) bool {
+	return errors.IsForbidden(err) || errors.IsInvalid(err)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helpers
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/helm_config/schemas"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// chartComponent is implemented by the components that are installed from a Helm chart
+type chartComponent interface {
+	GetChartDir() string
+}
+
+// ValidateInstallOverrides validates the install overrides of each Helm component of the Verrazzano resource against
+// the values schema of its chart, or the Verrazzano provided values schema when the chart does not have one, so that
+// mistakes are reported before the resource is applied
+func ValidateInstallOverrides(c client.Client, vz client.Object) error {
+	vzV1Beta1, err := toV1Beta1Verrazzano(vz)
+	if err != nil {
+		return err
+	}
+	namespace := vzV1Beta1.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	for _, comp := range registry.GetComponents() {
+		chartComp, ok := comp.(chartComponent)
+		if !ok {
+			continue
+		}
+		overrides, ok := comp.GetOverrides(vzV1Beta1).([]v1beta1.Overrides)
+		if !ok || len(overrides) == 0 {
+			continue
+		}
+		schema, err := schemas.GetValuesSchema(comp.Name(), filepath.Base(chartComp.GetChartDir()))
+		if err != nil || schema == nil {
+			return err
+		}
+		sources, err := common.GetInstallOverrideSourcesUsingClient(c, overrides, namespace)
+		if err != nil {
+			return err
+		}
+		if err := schema.ValidateOverrides(sources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toV1Beta1Verrazzano converts the Verrazzano resource to a v1beta1 Verrazzano
+func toV1Beta1Verrazzano(vz client.Object) (*v1beta1.Verrazzano, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(vz)
+	if err != nil {
+		return nil, err
+	}
+	vzV1Beta1 := &v1beta1.Verrazzano{}
+	switch gv := vz.GetObjectKind().GroupVersionKind().GroupVersion(); gv {
+	case v1beta1.SchemeGroupVersion:
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj, vzV1Beta1)
+	case v1alpha1.SchemeGroupVersion:
+		vzV1Alpha1 := &v1alpha1.Verrazzano{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj, vzV1Alpha1); err == nil {
+			err = vzV1Alpha1.ConvertTo(vzV1Beta1)
+		}
+	default:
+		err = fmt.Errorf("Unsupported Verrazzano API version %s", gv.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vzV1Beta1, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestValidateInstallOverridesChartSchema
+// GIVEN a v1beta1 Verrazzano resource with Keycloak install overrides
+//  WHEN I call ValidateInstallOverrides
+//  THEN the overrides are validated against the values schema of the Keycloak chart
+func TestValidateInstallOverridesChartSchema(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).Build()
+	vz := &v1beta1.Verrazzano{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "Verrazzano"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-verrazzano"},
+		Spec: v1beta1.VerrazzanoSpec{
+			Components: v1beta1.ComponentSpec{
+				Keycloak: &v1beta1.KeycloakComponent{
+					InstallOverrides: v1beta1.InstallOverrides{
+						ValueOverrides: []v1beta1.Overrides{
+							{Values: &apiextensionsv1.JSON{Raw: []byte(`{"image": {"pullPolicy": "IfNotPresent"}}`)}},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, ValidateInstallOverrides(c, vz))
+
+	vz.Spec.Components.Keycloak.ValueOverrides = []v1beta1.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"image": {"pullPolicy": "Sometimes"}}`)}},
+	}
+	err := ValidateInstallOverrides(c, vz)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "overrides[0].values: image.pullPolicy")
+}
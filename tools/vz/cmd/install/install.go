@@ -168,6 +168,11 @@ func runCmdInstall(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper)
 			return err
 		}
 
+		// Validate the install overrides that can be checked without the platform operator
+		if err = cmdhelpers.ValidateInstallOverrides(client, vz); err != nil {
+			return fmt.Errorf("The verrazzano install resource is not valid: %s", err.Error())
+		}
+
 		// Delete leftover verrazzano-operator deployment after an abort.
 		// This allows for the verrazzano-operator validatingWebhookConfiguration to be updated with the correct caBundle.
 		err = cmdhelpers.DeleteFunc(client)
@@ -194,6 +199,9 @@ func runCmdInstall(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper)
 		for {
 			err = client.Create(context.TODO(), vz)
 			if err != nil {
+				if cmdhelpers.IsValidationError(err) {
+					return fmt.Errorf("The verrazzano install resource is not valid: %s", err.Error())
+				}
 				if retry == 5 {
 					return fmt.Errorf("Failed to create the verrazzano install resource: %s", err.Error())
 				}
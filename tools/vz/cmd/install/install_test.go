@@ -16,7 +16,9 @@ import (
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
 	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"os"
@@ -533,6 +535,67 @@ func TestInstallCmdDifferentVersion(t *testing.T) {
 	assert.Contains(t, err.Error(), "Unable to install version v1.3.1, install of version v1.3.2 is in progress")
 }
 
+// rejectingClient is a client whose create requests are denied by the validating webhook
+type rejectingClient struct {
+	client.Client
+}
+
+func (c rejectingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if obj.GetObjectKind().GroupVersionKind().Kind != "Verrazzano" {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	return apierrors.NewForbidden(schema.GroupResource{Group: "install.verrazzano.io", Resource: "verrazzanos"}, obj.GetName(),
+		fmt.Errorf("admission webhook denied the request: Install overrides for chart verrazzano-authproxy are not valid: overrides[0].values: unknown top-level key \"replica\""))
+}
+
+// TestInstallCmdValidationError
+// GIVEN a CLI install command with install overrides that are not valid
+//  WHEN I call cmd.Execute for install and the validating webhook rejects the verrazzano resource
+//  THEN the CLI install command fails without retrying and reports the validation error
+func TestInstallCmdValidationError(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(testhelpers.CreateTestVPOObjects()...).Build()
+	cmd, buf, errBuf, _ := createNewTestCommandAndBuffers(t, rejectingClient{Client: c})
+	cmd.PersistentFlags().Set(constants.WaitFlag, "false")
+	cmdHelpers.SetDeleteFunc(cmdHelpers.FakeDeleteFunc)
+	defer cmdHelpers.SetDefaultDeleteFunc()
+
+	// Run install command
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, errBuf.String(), "The verrazzano install resource is not valid")
+	assert.Contains(t, errBuf.String(), "overrides[0].values: unknown top-level key \"replica\"")
+	assert.NotContains(t, buf.String(), "Retrying")
+}
+
+// TestInstallCmdInvalidOverrides
+// GIVEN a CLI install command with install overrides from inline values and a ConfigMap that are not valid
+//  WHEN I call cmd.Execute for install
+//  THEN the CLI install command fails before the platform operator is applied and reports the override sources
+func TestInstallCmdInvalidOverrides(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "authproxy-overrides"},
+		Data:       map[string]string{"values.yaml": "replicas: two\n"},
+	}
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(append(testhelpers.CreateTestVPOObjects(), cm)...).Build()
+	cmd, _, errBuf, _ := createNewTestCommandAndBuffers(t, c)
+	cmd.PersistentFlags().Set(constants.FilenameFlag, "../../test/testdata/invalid-overrides.yaml")
+	cmd.PersistentFlags().Set(constants.WaitFlag, "false")
+	cmdHelpers.SetDeleteFunc(cmdHelpers.FakeDeleteFunc)
+	defer cmdHelpers.SetDefaultDeleteFunc()
+
+	// Run install command
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, errBuf.String(), "The verrazzano install resource is not valid")
+	assert.Contains(t, errBuf.String(), "overrides[0].values: unknown top-level key \"replica\"")
+	assert.Contains(t, errBuf.String(), "ConfigMap authproxy-overrides key values.yaml: replicas: Invalid type")
+
+	// Verify the vz resource was not created
+	vz := v1alpha1.Verrazzano{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-verrazzano"}, &vz)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 func createNewTestCommandAndBuffers(t *testing.T, c client.Client) (*cobra.Command, *bytes.Buffer, *bytes.Buffer, *testhelpers.FakeRootCmdContext) {
 	buf := new(bytes.Buffer)
 	errBuf := new(bytes.Buffer)
@@ -37,6 +37,9 @@ Verrazzano Status
   Version: {{.verrazzano_version}}
   State: {{.verrazzano_state}}
   Profile: {{.install_profile}}
+{{- if .maintenance_message}}
+  Maintenance: {{.maintenance_message}}
+{{- end}}
   Access Endpoints:
 {{- if .console_url}}
     Console URL: {{.console_url}}
@@ -177,6 +180,9 @@ func runCmdStatus(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 	} else {
 		templateValues["install_profile"] = string(vz.Spec.Profile)
 	}
+	if vz.Status.Maintenance != nil {
+		templateValues["maintenance_message"] = vz.Status.Maintenance.Message
+	}
 	addAccessEndpoints(vz.Status.VerrazzanoInstance, templateValues)
 	addComponents(vz.Status.Components, templateValues)
 	result, err := templates.ApplyTemplate(statusOutputTemplate, templateValues)
@@ -182,6 +182,44 @@ func TestStatusCmdDefaultProfile(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 
+// TestStatusCmdMaintenance tests the status command
+// GIVEN an environment with a single VZ resource that has operations deferred until a maintenance window
+//  WHEN I run the command vz status
+//  THEN expect the deferral to be included in the status report
+func TestStatusCmdMaintenance(t *testing.T) {
+	message := "Deferred until 2026-10-17T02:00:00Z: Upgrade to version 1.4.0"
+	vz := v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "verrazzano",
+		},
+		Status: v1beta1.VerrazzanoStatus{
+			Version: "1.3.0",
+			State:   v1beta1.VzStateReady,
+			Maintenance: &v1beta1.MaintenanceStatus{
+				DeferredUntil:      "2026-10-17T02:00:00Z",
+				DeferredOperations: []string{"Upgrade to version 1.4.0"},
+				Message:            message,
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(&vz).Build()
+
+	// Send the command output to a byte buffer
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	statusCmd := NewCmdStatus(rc)
+	assert.NotNil(t, statusCmd)
+
+	// Run the status command, check that the deferral is displayed
+	err := statusCmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Maintenance: "+message)
+}
+
 // TestVZNotFound tests the status command
 // GIVEN an environment with a no VZ resources exist
 //  WHEN I run the command vz status
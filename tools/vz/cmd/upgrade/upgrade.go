@@ -155,6 +155,9 @@ func runCmdUpgrade(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 				err = helpers.UpdateVerrazzanoResource(client, vz)
 			}
 			if err != nil {
+				if cmdhelpers.IsValidationError(err) {
+					return fmt.Errorf("The verrazzano install resource is not valid for the upgrade: %s", err.Error())
+				}
 				if retry == 5 {
 					return fmt.Errorf("Failed to set the upgrade version in the verrazzano install resource: %s", err.Error())
 				}